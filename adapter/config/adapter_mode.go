@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package config
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	onceInitReadOnlyMode sync.Once
+	// 1 if the adapter is running in read-only mode
+	readOnlyMode int32
+)
+
+func initReadOnlyMode() {
+	conf, _ := ReadConfigs()
+	if conf.Adapter.ReadOnlyMode {
+		atomic.StoreInt32(&readOnlyMode, 1)
+	}
+}
+
+// IsReadOnlyMode returns true if the adapter is running as a passive replica. The mode is initialized
+// from the adapter.readOnlyMode configuration and can be changed at runtime using SetReadOnlyMode.
+func IsReadOnlyMode() bool {
+	onceInitReadOnlyMode.Do(initReadOnlyMode)
+	return atomic.LoadInt32(&readOnlyMode) == 1
+}
+
+// SetReadOnlyMode changes the mode of the adapter without a restart.
+func SetReadOnlyMode(isReadOnly bool) {
+	onceInitReadOnlyMode.Do(initReadOnlyMode)
+	if isReadOnly {
+		atomic.StoreInt32(&readOnlyMode, 1)
+	} else {
+		atomic.StoreInt32(&readOnlyMode, 0)
+	}
+}
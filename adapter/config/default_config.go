@@ -57,8 +57,22 @@ var defaultConfig = &Config{
 		Truststore: truststore{
 			Location: "/home/wso2/security/truststore",
 		},
-		ArtifactsDirectory:    "/home/wso2/artifacts",
-		SoapErrorInXMLEnabled: false,
+		ArtifactsDirectory:             "/home/wso2/artifacts",
+		SoapErrorInXMLEnabled:          false,
+		ReadOnlyMode:                   false,
+		KeepAPIInPreviousVhost:         false,
+		AllowedHTTPMethods:             []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"},
+		StrictPolicyCompatibility:      true,
+		StrictAPITypeFeatures:          true,
+		StripRequestHeaders:            []string{},
+		BasepathConflictResolution:     "serversWins",
+		FailOnParseWarnings:            []string{},
+		PublicPathPatterns:             []string{},
+		AnonymousThrottlingTier:        "",
+		ForceSubscriptionValidation:    false,
+		MaxAPIProjectSizeInMB:          100,
+		MaxExtractedAPIProjectSizeInMB: 200,
+		DefaultOrganizationID:          "",
 		SourceControl: sourceControl{
 			Enabled:            false,
 			PollInterval:       30,
@@ -72,6 +86,62 @@ var defaultConfig = &Config{
 			Port:               19085,
 			CollectionInterval: 5,
 		},
+		DeploymentQueue: deploymentQueue{
+			WorkerCount:         4,
+			QueueSizePerWorker:  100,
+			RetryAfterInSeconds: 5,
+		},
+		APIIdentity: apiIdentity{
+			CaseInsensitive: true,
+		},
+		ResourceUsage: resourceUsage{
+			Enabled: true,
+		},
+		APIQuota: apiQuota{
+			DefaultMaxAPIs: 0,
+			Organizations:  map[string]int{},
+		},
+		NodeGroups: nodeGroups{
+			DefaultGroup: "",
+			Groups:       []NodeGroup{},
+		},
+		ErrorLogAggregation: errorLogAggregation{
+			Enabled:         false,
+			WindowInSeconds: 300,
+		},
+		EndpointGuard: endpointGuard{
+			Enabled:             false,
+			ForbiddenCIDRs:      []string{},
+			AllowedHosts:        []string{},
+			AllowedCIDRs:        []string{},
+			FailOnDNSError:      false,
+			DNSTimeoutInSeconds: 5,
+		},
+		PathTemplateLimits: pathTemplateLimits{
+			MaxPathParameters:          20,
+			MaxPathSegments:            50,
+			MaxTemplateLength:          2048,
+			RegexCompileBudgetInMillis: 100,
+		},
+		DeploymentWebhook: deploymentWebhook{
+			Enabled:                 false,
+			URL:                     "",
+			Headers:                 map[string]string{},
+			MaxRetries:              3,
+			RetryIntervalInMillis:   1000,
+			RequestTimeoutInSeconds: 10,
+			SkipSSLVerification:     false,
+		},
+		RemoteDefinition: remoteDefinition{
+			Enabled:                 false,
+			RequestTimeoutInSeconds: 10,
+			MaxSizeInBytes:          10485760,
+			CacheTTLInSeconds:       300,
+		},
+		ArtifactEncryption: artifactEncryption{
+			Key:     "",
+			KeyPath: "",
+		},
 	},
 	Envoy: envoy{
 		ListenerHost:                     "0.0.0.0",
@@ -106,6 +176,7 @@ var defaultConfig = &Config{
 				TrustedCertPath:        "/etc/ssl/certs/ca-certificates.crt",
 				VerifyHostName:         true,
 				DisableSslVerification: false,
+				AllowTLSSkipVerify:     false,
 			},
 			Timeouts: upstreamTimeout{
 				MaxRouteTimeoutInSeconds:  60,
@@ -131,6 +202,8 @@ var defaultConfig = &Config{
 				HpackTableSize:       4096,
 				MaxConcurrentStreams: 2147483647,
 			},
+			MaxEndpointsPerCluster: 0,
+			DrainPeriodInSeconds:   0,
 		},
 		Downstream: envoyDownstream{
 			TLS: downstreamTLS{
@@ -186,8 +259,41 @@ var defaultConfig = &Config{
 					"chunkSize":           4096,
 				},
 			},
+			Buffer: bufferFilter{
+				MaxRequestBytes: 10485760,
+			},
+			UpstreamDecompression: upstreamDecompression{
+				MaxInflateRatio:      100,
+				MaxDecompressedBytes: 10485760,
+			},
+			ResponseCache: responseCache{
+				Enabled:            false,
+				AllowedVaryHeaders: []string{"accept", "accept-encoding", "accept-language", "origin"},
+			},
 		},
 		PerConnectionBufferLimitBytes: 1048576,
+		MaxRequestHeadersKb:           60,
+		UnmatchedRequests: unmatchedRequests{
+			MethodNotAllowedEnabled: false,
+			VhostCatchAll: vhostCatchAll{
+				Enabled:     false,
+				StatusCode:  404,
+				ContentType: "application/problem+json",
+				Body:        `{"type":"about:blank","title":"Not Found","status":404}`,
+			},
+		},
+		HTTP3: http3{
+			Enabled:               false,
+			AltSvcMaxAgeInSeconds: 86400,
+		},
+		RateLimit: rateLimit{
+			Enabled:                false,
+			Host:                   "ratelimiter",
+			Port:                   8090,
+			Domain:                 "Default",
+			FailureModeDeny:        false,
+			RequestTimeoutInMillis: 80,
+		},
 	},
 	Enforcer: enforcer{
 		Management: management{
@@ -384,6 +490,11 @@ var defaultConfig = &Config{
 			QueueSizePerPool:      1000,
 			PauseTimeAfterFailure: 5,
 		},
+		ArtifactDownload: artifactDownload{
+			MaxAttempts:     3,
+			RetryBackoff:    2,
+			MaxRetryBackoff: 30,
+		},
 	},
 	GlobalAdapter: globalAdapter{
 		Enabled:              false,
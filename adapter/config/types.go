@@ -101,6 +101,76 @@ type adapter struct {
 	SourceControl sourceControl
 	// Metric represents configurations to expose/export go metrics
 	Metrics metrics
+	// DeploymentQueue represents the configuration of the queue through which the API deployments and
+	// undeployments are applied to the xDS resources
+	DeploymentQueue deploymentQueue
+	// RemoteDefinition represents the configuration to fetch the API definitions referenced by URL in API projects
+	RemoteDefinition remoteDefinition
+	// APIIdentity represents how the API names and versions are compared when identifying the APIs
+	APIIdentity apiIdentity
+	// ResourceUsage represents the configuration of accounting the xDS resources generated for the APIs
+	ResourceUsage resourceUsage
+	// APIQuota represents the maximum number of APIs each organization can deploy
+	APIQuota apiQuota
+	// NodeGroups maps the gateway environments to the groups of router node IDs served by the adapter
+	NodeGroups nodeGroups
+	// ReadOnlyMode runs the adapter as a passive replica, which serves the xDS resources but does not change
+	// the deployment state through the REST API or send notifications to the control plane
+	ReadOnlyMode bool
+	// KeepAPIInPreviousVhost keeps an API deployed in its previous vhost of an environment, when the API is deployed
+	// to the same environment with a different vhost. By default, the API is undeployed from the previous vhost.
+	KeepAPIInPreviousVhost bool
+	// AllowedHTTPMethods is the list of HTTP methods that can be used in the operations of the APIs. Custom methods
+	// defined in the operations of the api.yaml are supported, if those are included in this list.
+	AllowedHTTPMethods []string
+	// StrictPolicyCompatibility rejects the deployment of APIs having operation policies which cannot be applied to
+	// the flow, API type or HTTP method of the operation. If disabled, the incompatible policies are logged as warnings.
+	StrictPolicyCompatibility bool
+	// StrictAPITypeFeatures rejects the deployment of API projects using the features not supported for the API type
+	// (e.g. mocked endpoints of WS APIs). If disabled, those are reported as the unsupported features of the APIs.
+	StrictAPITypeFeatures bool
+	// StripRequestHeaders is the list of request headers removed from the requests of all the APIs before those are
+	// sent to the backends. Headers listed in the x-wso2-strip-request-headers extension of an API are removed as well.
+	StripRequestHeaders []string
+	// BasepathConflictResolution decides the basepath of an API when the basepath derived from the basePath (OpenAPI v2)
+	// or the servers (OpenAPI v3) of the API definition conflicts with the context of the api.yaml. Supported values
+	// are serversWins (default), contextWins and error, which rejects the deployment of the API.
+	BasepathConflictResolution string
+	// FailOnParseWarnings is the list of codes of the warnings reported while parsing the API definitions, which
+	// reject the deployment of the APIs instead (e.g. MISSING_OPERATION_ID).
+	FailOnParseWarnings []string
+	// PublicPathPatterns is the list of glob patterns of the resource paths whose operations are exposed without
+	// security in all the APIs (e.g. /swagger.json, /docs/**). Patterns listed in the x-wso2-public-paths extension
+	// of an API are applied as well.
+	PublicPathPatterns []string
+	// AnonymousThrottlingTier is the throttling tier applied to the operations whose security is disabled, which
+	// bypass the subscription throttling. Can be overridden per API and operation by the x-wso2-anonymous-tier
+	// extension. No tier is applied to those operations if empty.
+	AnonymousThrottlingTier string
+	// ForceSubscriptionValidation validates the subscriptions of the requests to all the APIs, overriding the
+	// disableSubscriptionValidation of the api.yaml of the APIs.
+	ForceSubscriptionValidation bool
+	// MaxAPIProjectSizeInMB is the maximum size of the zipped API projects accepted by the adapter. The API projects
+	// exceeding the size are rejected before those are extracted. Set to 0 to accept API projects of any size.
+	MaxAPIProjectSizeInMB int
+	// MaxExtractedAPIProjectSizeInMB is the maximum size of the files of an API project read into memory while it is
+	// extracted. The extraction is aborted once the size is exceeded. Set to 0 to read the files of any size.
+	MaxExtractedAPIProjectSizeInMB int
+	// DefaultOrganizationID is the organization of the mounted and standalone API projects which do not specify their
+	// organization, when the adapter is not connected to a control plane (e.g. air-gapped deployments). If empty, the
+	// tenant domain of the control plane user is used.
+	DefaultOrganizationID string
+	// ArtifactEncryption represents the key used to decrypt the encrypted API projects
+	ArtifactEncryption artifactEncryption
+	// DeploymentWebhook represents the configuration of the webhook to which the API deployment and undeployment
+	// events are posted
+	DeploymentWebhook deploymentWebhook
+	// ErrorLogAggregation represents the configuration of summarizing the repeated deployment errors of the APIs
+	ErrorLogAggregation errorLogAggregation
+	// EndpointGuard represents the configuration of rejecting the API endpoints which resolve to internal addresses
+	EndpointGuard endpointGuard
+	// PathTemplateLimits represents the limits of the path templates of the API resources
+	PathTemplateLimits pathTemplateLimits
 }
 
 // Envoy Listener Component related configurations.
@@ -123,6 +193,59 @@ type envoy struct {
 	UseRemoteAddress                 bool
 	Filters                          filters
 	PerConnectionBufferLimitBytes    uint32
+	// MaxRequestHeadersKb is the maximum size of the request headers accepted by the listeners, in KiB. APIs can
+	// only lower the limit for their requests.
+	MaxRequestHeadersKb uint32
+	// ResourceNamingTemplate is the template used to generate the names of the API clusters and routes.
+	// Supported placeholders are {orgId}, {vhost}, {apiName}, {version}, {endpointType} and {resourceId}.
+	// If not set, the default naming scheme is used.
+	ResourceNamingTemplate string
+	// UnmatchedRequests configures the responses of the requests which do not match any operation of the APIs.
+	UnmatchedRequests unmatchedRequests
+	// HTTP3 configures serving the APIs over HTTP/3 (QUIC) on the port of the secured listener.
+	HTTP3 http3
+	// RateLimit configures the rate limit service which limits the requests of the APIs by their rate limit keys.
+	RateLimit rateLimit
+}
+
+type rateLimit struct {
+	// Enabled adds the rate limit filter calling the rate limit service, which applies the rateLimitKey of the
+	// api.yaml of the APIs. The rate limit keys of the APIs are not applied if the filter is not enabled.
+	Enabled bool
+	// Host and Port of the gRPC rate limit service
+	Host string
+	Port uint32
+	// Domain of the descriptors sent to the rate limit service
+	Domain string
+	// FailureModeDeny rejects the requests when the rate limit service cannot be called, instead of allowing them
+	FailureModeDeny bool
+	// RequestTimeoutInMillis is the timeout of the calls to the rate limit service
+	RequestTimeoutInMillis uint32
+}
+
+type http3 struct {
+	// Enabled adds a UDP listener serving HTTP/3 on the port of the secured listener. As HTTP/3 requires TLS, the
+	// listener is not added if the secured listener is not configured. HTTP/3 is advertised to the clients only by
+	// the APIs having the x-wso2-http3 extension.
+	Enabled bool
+	// AltSvcMaxAgeInSeconds is the time the clients remember that the APIs are served over HTTP/3
+	AltSvcMaxAgeInSeconds uint32
+}
+
+type unmatchedRequests struct {
+	// MethodNotAllowedEnabled adds a route after the routes of each resource, which replies with 405 and an Allow
+	// header listing the methods of the resource, when the path of the resource matches but not the method.
+	MethodNotAllowedEnabled bool
+	// VhostCatchAll adds a route at the end of each vhost having APIs, which replies to the requests matching none
+	// of the routes of the vhost.
+	VhostCatchAll vhostCatchAll
+}
+
+type vhostCatchAll struct {
+	Enabled     bool
+	StatusCode  uint32
+	ContentType string
+	Body        string
 }
 
 type connectionTimeouts struct {
@@ -133,7 +256,37 @@ type connectionTimeouts struct {
 }
 
 type filters struct {
-	Compression compression
+	Compression           compression
+	Buffer                bufferFilter
+	UpstreamDecompression upstreamDecompression
+	ResponseCache         responseCache
+}
+
+// responseCache configures the cache filter, which caches the responses of the APIs in the memory of the router.
+type responseCache struct {
+	// Enabled adds the cache filter. The responses are cached as per their cache-control headers, and the cached
+	// responses vary by the vary headers of the responses, including the cacheVaryHeaders of the operations.
+	Enabled bool
+	// AllowedVaryHeaders are the request headers by which the cached responses can vary. The responses varying by any
+	// other header are not cached.
+	AllowedVaryHeaders []string
+}
+
+// bufferFilter configures the buffering of the requests of the APIs with request buffering enabled.
+type bufferFilter struct {
+	// MaxRequestBytes is the maximum size of the buffered request, beyond which the request is rejected with 413.
+	MaxRequestBytes uint32
+}
+
+// upstreamDecompression configures the decompression of the responses of the APIs having the
+// x-wso2-upstream-decompression extension.
+type upstreamDecompression struct {
+	// MaxInflateRatio is the maximum ratio between the sizes of a decompressed response and the compressed response,
+	// which prevents decompression bombs.
+	MaxInflateRatio uint32
+	// MaxDecompressedBytes is the maximum size of a decompressed response buffered by the interceptors and the
+	// policies of the API.
+	MaxDecompressedBytes uint32
 }
 
 type compression struct {
@@ -269,6 +422,12 @@ type envoyUpstream struct {
 	DNS      upstreamDNS
 	Retry    upstreamRetry
 	HTTP2    upstreamHTTP2Options
+	// MaxEndpointsPerCluster is the maximum number of production or sandbox endpoints of an API, including the
+	// failover endpoints. The validation is disabled when the value is less than 1.
+	MaxEndpointsPerCluster int
+	// DrainPeriodInSeconds is the period for which the clusters of an undeployed API are kept in the router, so that
+	// the in-flight requests to the API can complete. The clusters are removed immediately when the value is 0.
+	DrainPeriodInSeconds uint32
 }
 
 // Envoy Downstream Related Configurations
@@ -292,6 +451,9 @@ type upstreamTLS struct {
 	TrustedCertPath        string
 	VerifyHostName         bool
 	DisableSslVerification bool
+	// AllowTLSSkipVerify allows the APIs to skip the verification of the certificates of their endpoints by the
+	// tlsSkipVerify endpoint config, which is meant for the development environments only.
+	AllowTLSSkipVerify bool
 }
 
 type upstreamTimeout struct {
@@ -477,6 +639,126 @@ type metrics struct {
 	CollectionInterval int32
 }
 
+type deploymentQueue struct {
+	// WorkerCount is the number of workers which apply the queued deployment tasks
+	WorkerCount int
+	// QueueSizePerWorker is the number of deployment tasks which can wait for a worker
+	QueueSizePerWorker int
+	// RetryAfterInSeconds is the time the clients are asked to wait before retrying, when the queue is full
+	RetryAfterInSeconds int
+}
+
+type apiIdentity struct {
+	// CaseInsensitive compares the API names and versions after case folding. The names and versions are
+	// always compared in the Unicode NFC form.
+	CaseInsensitive bool
+}
+
+type resourceUsage struct {
+	// Enabled computes the snapshot sizes and the per API and per organization resource counts on each
+	// snapshot update. Disable it for very large deployments to avoid the additional computation.
+	Enabled bool
+}
+
+type apiQuota struct {
+	// DefaultMaxAPIs is the maximum number of APIs an organization can deploy, unless it is overridden for the
+	// organization. Set to 0 to allow any number of APIs.
+	DefaultMaxAPIs int
+	// Organizations maps the organization IDs to the maximum number of APIs each of them can deploy
+	Organizations map[string]int
+}
+
+type nodeGroups struct {
+	// DefaultGroup is the node group which receives the APIs deployed to the environments that are not mapped
+	// to any node group. If empty, those APIs are served to the routers having the environment name as the node ID.
+	DefaultGroup string
+	// Groups are the node groups along with the environments of which the APIs are served to each group
+	Groups []NodeGroup
+}
+
+// NodeGroup represents a group of router node IDs served with the APIs of the mapped environments.
+type NodeGroup struct {
+	// Name of the node group
+	Name string
+	// NodeIDs of the routers which are served the snapshot of the node group
+	NodeIDs []string
+	// Environments of which the APIs are included in the snapshot of the node group
+	Environments []string
+}
+
+type deploymentWebhook struct {
+	// Enabled posts a JSON event to the URL after an API is deployed or undeployed
+	Enabled bool
+	// URL of the webhook (callback) of the external system
+	URL string
+	// Headers added to each request, ex: to authenticate the adapter to the external system
+	Headers map[string]string
+	// MaxRetries is the number of times a failed event is retried
+	MaxRetries int
+	// RetryIntervalInMillis is the time before the first retry, which is doubled for each subsequent retry
+	RetryIntervalInMillis int
+	// RequestTimeoutInSeconds is the timeout of each request to the webhook
+	RequestTimeoutInSeconds int
+	// SkipSSLVerification skips the verification of the webhook server certificate against the truststore
+	SkipSSLVerification bool
+}
+
+type errorLogAggregation struct {
+	// Enabled logs the first deployment error of an API with an error code in full, and summarizes the identical
+	// errors of the API within the window in a single line logged at the end of the window
+	Enabled bool
+	// WindowInSeconds is the time the repeated errors of an API are counted before those are summarized
+	WindowInSeconds int
+}
+
+type endpointGuard struct {
+	// Enabled resolves the hosts of the production, sandbox and interceptor endpoints of the APIs when those are
+	// deployed, and rejects the endpoints resolving only to loopback, link-local or forbidden addresses
+	Enabled bool
+	// ForbiddenCIDRs are the address ranges the endpoints cannot resolve to, in addition to the loopback and
+	// link-local addresses (e.g. the private ranges of the internal services)
+	ForbiddenCIDRs []string
+	// AllowedHosts are the endpoint hosts which are not checked, given as host names or IP addresses
+	AllowedHosts []string
+	// AllowedCIDRs are the address ranges the endpoints can resolve to, even if those are forbidden otherwise
+	AllowedCIDRs []string
+	// FailOnDNSError rejects the endpoints whose hosts cannot be resolved. By default, those are reported as warnings.
+	FailOnDNSError bool
+	// DNSTimeoutInSeconds is the timeout of resolving the host of an endpoint
+	DNSTimeoutInSeconds int
+}
+
+type artifactEncryption struct {
+	// Key is the base64 encoded 256 bit AES key used to decrypt the API projects encrypted with AES-GCM
+	Key string
+	// KeyPath is the path of the file (e.g. a mounted secret) containing the base64 encoded key. Used if the key
+	// is not set.
+	KeyPath string
+}
+
+type pathTemplateLimits struct {
+	// MaxPathParameters is the maximum number of path parameters in the path template of a resource
+	MaxPathParameters int
+	// MaxPathSegments is the maximum number of segments in the path template of a resource
+	MaxPathSegments int
+	// MaxTemplateLength is the maximum length of the path template of a resource
+	MaxTemplateLength int
+	// RegexCompileBudgetInMillis is the time within which the route regex generated for the path template of a
+	// resource should be compiled
+	RegexCompileBudgetInMillis int
+}
+
+type remoteDefinition struct {
+	// Enabled allows fetching the API definitions referenced by URL in API projects
+	Enabled bool
+	// RequestTimeoutInSeconds is the timeout of the request which fetches the API definition
+	RequestTimeoutInSeconds int
+	// MaxSizeInBytes is the maximum size of an API definition which can be fetched
+	MaxSizeInBytes int64
+	// CacheTTLInSeconds is the time a fetched API definition is reused for the same URL
+	CacheTTLInSeconds int
+}
+
 type analyticsAdapter struct {
 	BufferFlushInterval time.Duration
 	BufferSizeBytes     uint32
@@ -551,6 +833,7 @@ type controlPlane struct {
 	BrokerConnectionParameters brokerConnectionParameters
 	HTTPClient                 httpClient
 	RequestWorkerPool          requestWorkerPool
+	ArtifactDownload           artifactDownload
 }
 
 type requestWorkerPool struct {
@@ -559,6 +842,16 @@ type requestWorkerPool struct {
 	PauseTimeAfterFailure time.Duration
 }
 
+// artifactDownload contains the retry configurations of the runtime artifact downloads, which are retried when the
+// downloaded bytes do not match the Content-Length or the checksum sent by the control plane.
+type artifactDownload struct {
+	MaxAttempts int
+	// RetryBackoff is the delay in seconds before the first retry, which is doubled for each subsequent retry.
+	RetryBackoff time.Duration
+	// MaxRetryBackoff is the maximum delay in seconds between two retries.
+	MaxRetryBackoff time.Duration
+}
+
 type globalAdapter struct {
 	Enabled    bool
 	ServiceURL string
@@ -198,6 +198,11 @@ func Run(conf *config.Config) {
 	}
 
 	logger.LoggerMgw.Info("Starting adapter ....")
+	if config.IsReadOnlyMode() {
+		logger.LoggerMgw.Info("Adapter is running in read-only mode. Deployment state is not changed via the REST API " +
+			"and notifications are not sent to the control plane.")
+	}
+	health.SetReadOnlyMode(config.IsReadOnlyMode())
 
 	// Start the metrics server
 	if conf.Adapter.Metrics.Enabled && strings.EqualFold(conf.Adapter.Metrics.Type, metrics.PrometheusMetricType) {
@@ -247,10 +252,8 @@ func Run(conf *config.Config) {
 
 	for _, env := range envs {
 		xds.GenerateGlobalClusters(env)
-		listeners, clusters, routes, endpoints, apis := xds.GenerateEnvoyResoucesForLabel(env)
-		xds.UpdateXdsCacheWithLock(env, endpoints, clusters, routes, listeners)
-		xds.UpdateEnforcerApis(env, apis, "")
 	}
+	xds.UpdateXdsCacheForLabels(envs)
 
 	// Adapter REST API
 	if conf.Adapter.Server.Enabled {
@@ -359,10 +362,10 @@ func fetchAPIsOnStartUp(conf *config.Config, apiUUIDList []string) {
 	for i := 0; i < 1; i++ {
 		data := <-c
 		logger.LoggerMgw.Debug("Receiving data for an environment")
-		if data.Resp != nil {
-			// For successfull fetches, data.Resp would return a byte slice with API project(s)
+		if data.RespFile != "" {
+			// For successfull fetches, data.RespFile would be the downloaded zip file with API project(s)
 			logger.LoggerMgw.Debug("Pushing data to router and enforcer")
-			err := synchronizer.PushAPIProjects(data.Resp, envs)
+			err := synchronizer.PushAPIProjectsFromFile(data.RespFile, envs)
 			if err != nil {
 				logger.LoggerMgw.ErrorC(logging.ErrorDetails{
 					Message:   fmt.Sprintf("Error occurred while pushing API data to router and enforcer: %v ", err.Error()),
@@ -23,11 +23,16 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/wso2/product-microgateway/adapter/config"
 	apiModel "github.com/wso2/product-microgateway/adapter/internal/api/models"
 	xds "github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
@@ -42,12 +47,64 @@ import (
 // API Controller related constants
 const (
 	apisArtifactDir string = "apis"
+	// maxAPIStatusRequestSize is the maximum number of API revisions of which the status is accepted in a request.
+	maxAPIStatusRequestSize int = 10000
 )
 
+// ErrAPIProjectTooLarge is returned when the size of the zipped API project exceeds the configured maximum.
+var ErrAPIProjectTooLarge = errors.New("API project exceeds the maximum size")
+
+// getMaxAPIProjectSize returns the maximum size of the zipped API projects in bytes, or 0 if the size is not limited.
+func getMaxAPIProjectSize() int64 {
+	conf, _ := config.ReadConfigs()
+	if conf.Adapter.MaxAPIProjectSizeInMB <= 0 {
+		return 0
+	}
+	return int64(conf.Adapter.MaxAPIProjectSizeInMB) * 1024 * 1024
+}
+
+// validateAPIProjectSize returns ErrAPIProjectTooLarge if the size of the zipped API project exceeds the maximum.
+func validateAPIProjectSize(size int64) error {
+	maxSize := getMaxAPIProjectSize()
+	if maxSize > 0 && size > maxSize {
+		loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("API project of %d bytes exceeds the maximum size of %d bytes. Hence it is rejected.",
+				size, maxSize),
+			Severity:  logging.MINOR,
+			ErrorCode: 1233,
+		})
+		return ErrAPIProjectTooLarge
+	}
+	return nil
+}
+
+// ReadAPIProject reads the zipped API project uploaded to the REST API. ErrAPIProjectTooLarge is returned without
+// reading the rest of the API project, once its size exceeds the maximum.
+func ReadAPIProject(reader io.Reader) ([]byte, error) {
+	maxSize := getMaxAPIProjectSize()
+	if maxSize > 0 {
+		reader = io.LimitReader(reader, maxSize+1)
+	}
+	payload, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateAPIProjectSize(int64(len(payload))); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
 // extractAPIProject accepts the API project as a zip file and returns the extracted content.
-// The apictl project must be in zipped format.
+// The apictl project must be in zipped format, which is decrypted first if it is encrypted.
 // API type is decided by the type field in the api.yaml file.
 func extractAPIProject(payload []byte) (apiProject model.ProjectAPI, err error) {
+	if err = validateAPIProjectSize(int64(len(payload))); err != nil {
+		return apiProject, err
+	}
+	if payload, err = decryptAPIProject(payload); err != nil {
+		return apiProject, err
+	}
 	zipReader, err := zip.NewReader(bytes.NewReader(payload), int64(len(payload)))
 
 	if err != nil {
@@ -63,9 +120,17 @@ func extractAPIProject(payload []byte) (apiProject model.ProjectAPI, err error)
 	apiProject.EndpointCerts = make(map[string]string)
 	apiProject.Policies = make(map[string]model.PolicyContainer)
 	apiProject.DownstreamCerts = make(map[string][]byte)
+	budget := newExtractionBudget()
+	hasher := newDefinitionHasher()
 	for _, file := range zipReader.File {
+		if !isProjectFileProcessed(file.Name) {
+			loggers.LoggerAPI.Debugf("File skipped without reading: %v", file.Name)
+			recordUnsupportedProjectFile(&apiProject, file.Name)
+			recordSkippedProjectFile(&apiProject, file.Name)
+			continue
+		}
 		loggers.LoggerAPI.Debugf("File reading now: %v", file.Name)
-		unzippedFileBytes, err := readZipFile(file)
+		unzippedFileBytes, err := readZipFile(file, budget)
 		if err != nil {
 			loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
 				Message:   fmt.Sprintf("Error occurred while reading the file : %v %v", file.Name, err.Error()),
@@ -78,12 +143,22 @@ func extractAPIProject(payload []byte) (apiProject model.ProjectAPI, err error)
 		if err != nil {
 			return apiProject, err
 		}
+		hasher.addZipFile(file.Name, unzippedFileBytes)
 	}
-	err = apiProject.APIYaml.ValidateAPIType()
-	if err != nil {
-		return apiProject, err
+	apiProject.DefinitionHash = hasher.sum()
+	return apiProject, validateExtractedAPIProject(apiProject)
+}
+
+// validateExtractedAPIProject validates the type of the extracted API project, the features used for the type and
+// the references of the API definition. The mandatory fields are validated while reading the api.yaml.
+func validateExtractedAPIProject(apiProject model.ProjectAPI) error {
+	if err := apiProject.APIYaml.ValidateAPIType(); err != nil {
+		return err
+	}
+	if err := apiProject.ValidateAPITypeFeatures(); err != nil {
+		return err
 	}
-	return apiProject, nil
+	return apiProject.ValidateDefinitionRefs()
 }
 
 // ProcessMountedAPIProjects iterates through the api artifacts directory and apply the projects located within the directory.
@@ -115,22 +190,7 @@ func ProcessMountedAPIProjects() (artifactsMap map[string]model.ProjectAPI, err
 		}
 
 		if apiProjectFile.IsDir() {
-			apiProject := model.ProjectAPI{
-				EndpointCerts: make(map[string]string),
-				UpstreamCerts: make(map[string][]byte),
-				Policies:      make(map[string]model.PolicyContainer),
-			}
-			err = filepath.Walk(filepath.FromSlash(apisDirName+"/"+apiProjectFile.Name()), func(path string, info os.FileInfo, err error) error {
-
-				if !info.IsDir() {
-					fileContent, err := ioutil.ReadFile(path)
-					if err != nil {
-						return err
-					}
-					return processFileInsideProject(&apiProject, fileContent, path)
-				}
-				return nil
-			})
+			apiProject, err := readMountedAPIProject(filepath.FromSlash(apisDirName + "/" + apiProjectFile.Name()))
 			if err != nil {
 				loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
 					Message:   fmt.Sprintf("Error while processing api artifact - %s during startup : %s", apiProjectFile.Name(), err.Error()),
@@ -148,9 +208,28 @@ func ProcessMountedAPIProjects() (artifactsMap map[string]model.ProjectAPI, err
 				})
 				continue
 			}
+			err = apiProject.ValidateAPITypeFeatures()
+			if err != nil {
+				loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+					Message:   fmt.Sprintf("Error while validating the features of the API type - %s during startup : %s", apiProjectFile.Name(), err.Error()),
+					Severity:  logging.MAJOR,
+					ErrorCode: 1239,
+				})
+				continue
+			}
+			err = apiProject.ValidateDefinitionRefs()
+			if err != nil {
+				loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+					Message:   fmt.Sprintf("Error while validating the API definition - %s during startup : %s", apiProjectFile.Name(), err.Error()),
+					Severity:  logging.MAJOR,
+					ErrorCode: 1236,
+				})
+				continue
+			}
 
 			overrideValue := true
-			apiProject, err = validateAndUpdateXds(apiProject, &overrideValue)
+			var result DeploymentResult
+			apiProject, result, err = applyAPIProject(apiProject, &overrideValue, true, false)
 			if err != nil {
 				loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
 					Message:   fmt.Sprintf("Error while processing(validate and update xds) api artifact - %s during startup : %v", apiProjectFile.Name(), err.Error()),
@@ -159,6 +238,7 @@ func ProcessMountedAPIProjects() (artifactsMap map[string]model.ProjectAPI, err
 				})
 				continue
 			}
+			logStartupDeploymentResult(apiProjectFile.Name(), result)
 			artifactsMap[apiProjectFile.Name()] = apiProject
 			continue
 		} else if !strings.HasSuffix(apiProjectFile.Name(), zipExt) {
@@ -176,7 +256,8 @@ func ProcessMountedAPIProjects() (artifactsMap map[string]model.ProjectAPI, err
 
 		// logger.LoggerMgw.Debugf("API artifact  - %s is read successfully.", file.Name())
 		overrideAPIParam := true
-		apiProject, err := ApplyAPIProjectInStandaloneMode(data, &overrideAPIParam)
+		apiProject, result, err := ApplyAPIProjectInStandaloneMode(data, &overrideAPIParam, true, false,
+			UpdateStrategyReplace)
 		if err != nil {
 			loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
 				Message:   fmt.Sprintf("Error while processing(apply api project in standalone mode) api artifact - %s during startup : %v", apiProjectFile.Name(), err.Error()),
@@ -185,12 +266,58 @@ func ProcessMountedAPIProjects() (artifactsMap map[string]model.ProjectAPI, err
 			})
 			continue
 		}
+		logStartupDeploymentResult(apiProjectFile.Name(), result)
 		artifactsMap[apiProjectFile.Name()] = apiProject
 	}
 	return artifactsMap, nil
 }
 
-func validateAndUpdateXds(apiProject model.ProjectAPI, override *bool) (updatedAPIProject model.ProjectAPI, err error) {
+// logStartupDeploymentResult logs whether the API project mounted in the artifacts directory is created, updated or
+// unchanged when it is applied during the startup.
+func logStartupDeploymentResult(projectName string, result DeploymentResult) {
+	loggers.LoggerAPI.Infof("API artifact - %s is applied during startup with the outcome %s.", projectName,
+		result.Outcome)
+}
+
+// readMountedAPIProject reads the API project mounted as a directory, without validating it.
+func readMountedAPIProject(projectDir string) (apiProject model.ProjectAPI, err error) {
+	apiProject = model.ProjectAPI{
+		EndpointCerts: make(map[string]string),
+		UpstreamCerts: make(map[string][]byte),
+		Policies:      make(map[string]model.PolicyContainer),
+	}
+	budget := newExtractionBudget()
+	hasher := newDefinitionHasher()
+	err = filepath.Walk(projectDir, func(path string, info os.FileInfo, err error) error {
+
+		if info.IsDir() {
+			return nil
+		}
+		if !isProjectFileProcessed(path) {
+			recordUnsupportedProjectFile(&apiProject, path)
+			if relativePath, err := filepath.Rel(projectDir, path); err == nil {
+				recordSkippedProjectFile(&apiProject, relativePath)
+			}
+			return nil
+		}
+		fileContent, err := readMountedFile(path, info.Size(), budget)
+		if err != nil {
+			return err
+		}
+		hasher.addMountedFile(projectDir, path, fileContent)
+		return processFileInsideProject(&apiProject, fileContent, path)
+	})
+	apiProject.DefinitionHash = hasher.sum()
+	return apiProject, err
+}
+
+// validateAndUpdateXds validates the API project and deploys it in the vhosts and environments of its deployments.
+// If dryRun is true, the xDS updates are only computed and returned as the deployment plan, without applying them.
+// If staged is true, the API is stored without serving traffic until it is activated.
+// Unless force is true, the vhosts in which the API is already deployed with the same content are skipped, and
+// errAPIUnchanged is returned if the API is not changed in any of the vhosts.
+func validateAndUpdateXds(apiProject model.ProjectAPI, override *bool, force bool, dryRun bool, staged bool) (
+	updatedAPIProject model.ProjectAPI, deploymentPlan []*xds.APIUpdatePlan, err error) {
 	apiYaml := apiProject.APIYaml.Data
 
 	// handle panic
@@ -208,63 +335,138 @@ func validateAndUpdateXds(apiProject model.ProjectAPI, override *bool) (updatedA
 		overrideValue = *override
 	}
 
-	// when deployment-environments is missing in the API Project, definition we deploy to default
-	// environment
-	if apiProject.Deployments == nil {
-		vhost, _, _ := config.GetDefaultVhost(config.DefaultGatewayName)
-		deployment := model.Deployment{
-			DisplayOnDevportal:    true,
-			DeploymentEnvironment: config.DefaultGatewayName,
-			DeploymentVhost:       vhost,
-		}
-		apiProject.Deployments = []model.Deployment{deployment}
-	}
+	setDefaultDeployment(&apiProject)
 
-	if !overrideValue {
-		// if the API already exists in at least one of the vhosts, break deployment of the API
-		exists := false
-		for _, deployment := range apiProject.Deployments {
-			if xds.IsAPIExist(deployment.DeploymentVhost, apiYaml.ID, apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID) {
-				exists = true
-				break
-			}
-		}
-
-		if exists {
-			loggers.LoggerAPI.Infof("Error creating new API. API %v:%v already exists.",
-				apiYaml.Name, apiYaml.Version)
-			return updatedAPIProject, errors.New(constants.AlreadyExists)
-		}
-	}
 	vhostToEnvsMap := make(map[string][]string)
 	for _, environment := range apiProject.Deployments {
 		vhostToEnvsMap[environment.DeploymentVhost] =
 			append(vhostToEnvsMap[environment.DeploymentVhost], environment.DeploymentEnvironment)
 	}
 
-	// Updating cache one API by one API, if one API failed to update cache continue with others.
-	for vhost, environments := range vhostToEnvsMap {
-		_, err = xds.UpdateAPI(vhost, apiProject, environments)
+	if dryRun {
+		// The plan is computed in the deployment queue of the API, so that it is not computed while the API is
+		// deployed.
+		err = xds.ExecuteInDeploymentQueue(xds.GenerateIdentifierForAPIWithoutVhost(apiYaml.Name, apiYaml.Version), func() error {
+			if err := validateAPIExistence(apiProject, overrideValue); err != nil {
+				return err
+			}
+			if err := xds.ValidateAPIQuota(apiYaml.OrganizationID, apiYaml.ID, apiYaml.Name, apiYaml.Version); err != nil {
+				return err
+			}
+			for vhost, environments := range vhostToEnvsMap {
+				plan, err := xds.PlanAPIUpdate(vhost, apiProject, environments)
+				if err != nil {
+					return err
+				}
+				deploymentPlan = append(deploymentPlan, plan)
+			}
+			return nil
+		})
 		if err != nil {
-			return
+			return updatedAPIProject, nil, err
+		}
+		loggers.LoggerAPI.Infof("Computed the deployment of API %v:%v in dry run mode for %d vhost(s).",
+			apiYaml.Name, apiYaml.Version, len(deploymentPlan))
+		return apiProject, deploymentPlan, nil
+	}
+
+	err = xds.ExecuteInDeploymentQueue(xds.GenerateIdentifierForAPIWithoutVhost(apiYaml.Name, apiYaml.Version), func() error {
+		if err := validateAPIExistence(apiProject, overrideValue); err != nil {
+			return err
+		}
+		if err := xds.ValidateAPIQuota(apiYaml.OrganizationID, apiYaml.ID, apiYaml.Name, apiYaml.Version); err != nil {
+			return err
+		}
+		unchangedVhostCount := 0
+		// Updating cache one API by one API, if one API failed to update cache continue with others.
+		for vhost, environments := range vhostToEnvsMap {
+			if !force && xds.IsAPIUnchanged(vhost, apiProject, environments, staged) {
+				loggers.LoggerAPI.Infof("API %v:%v is already deployed in vhost %v with the same content. "+
+					"Hence the deployment is skipped.", apiYaml.Name, apiYaml.Version, vhost)
+				unchangedVhostCount++
+				continue
+			}
+			if _, err := xds.UpdateAPI(vhost, apiProject, environments, staged); err != nil {
+				return err
+			}
+		}
+		// The deployments of an updated API replace its current deployments
+		undeployed := false
+		if overrideValue {
+			var err error
+			if undeployed, err = undeployUnlistedDeployments(apiProject); err != nil {
+				return err
+			}
 		}
+		if unchangedVhostCount == len(vhostToEnvsMap) && !undeployed {
+			return errAPIUnchanged
+		}
+		return nil
+	})
+	if err == errAPIUnchanged {
+		return apiProject, nil, err
+	} else if err != nil {
+		return
 	}
 	updatedAPIProject = apiProject
-	return updatedAPIProject, nil
+	return updatedAPIProject, nil, nil
+}
+
+// setDefaultDeployment sets the default vhost of the default environment as the deployment of the API project, when
+// deployment-environments is missing in the API project.
+func setDefaultDeployment(apiProject *model.ProjectAPI) {
+	if apiProject.Deployments != nil {
+		return
+	}
+	vhost, _, _ := config.GetDefaultVhost(config.DefaultGatewayName)
+	deployment := model.Deployment{
+		DisplayOnDevportal:    true,
+		DeploymentEnvironment: config.DefaultGatewayName,
+		DeploymentVhost:       vhost,
+	}
+	apiProject.Deployments = []model.Deployment{deployment}
+}
+
+// validateAPIExistence returns an error if the API already exists in at least one of the vhosts of its deployments,
+// unless the API is deployed with override.
+func validateAPIExistence(apiProject model.ProjectAPI, override bool) error {
+	if override {
+		return nil
+	}
+	apiYaml := apiProject.APIYaml.Data
+	for _, deployment := range apiProject.Deployments {
+		if xds.IsAPIExist(deployment.DeploymentVhost, apiYaml.ID, apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID) {
+			loggers.LoggerAPI.Infof("Error creating new API. API %v:%v already exists.",
+				apiYaml.Name, apiYaml.Version)
+			return errors.New(constants.AlreadyExists)
+		}
+	}
+	return nil
 }
 
 // ApplyAPIProjectFromAPIM accepts an apictl project (as a byte array), list of vhosts with respective environments
-// and updates the xds servers based upon the content.
+// and updates the xds servers based upon the content. If staged is true, the API is stored without serving traffic
+// until it is activated via ActivateAPI.
 func ApplyAPIProjectFromAPIM(
 	payload []byte,
 	vhostToEnvsMap map[string][]string,
 	apiEnvs map[string]map[string]synchronizer.APIEnvProps,
+	staged bool,
 ) (deployedRevisionList []*notifier.DeployedAPIRevision, err error) {
 	apiProject, err := extractAPIProject(payload)
 	if err != nil {
 		return nil, err
 	}
 	apiYaml := &apiProject.APIYaml.Data
+	if err = validateVhostToEnvsMap(vhostToEnvsMap); err != nil {
+		loggers.LogAPIError(&loggers.LoggerAPI, apiYaml.ID, logging.ErrorDetails{
+			Message: fmt.Sprintf("Invalid deployments of the API %s:%s with UUID \"%v\" in Organization %s. %v",
+				apiYaml.Name, apiYaml.Version, apiYaml.ID, apiYaml.OrganizationID, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 1237,
+		})
+		return nil, err
+	}
 	if apiEnvProps, found := apiEnvs[apiProject.APIYaml.Data.ID]; found {
 		loggers.LoggerAPI.Infof("Environment specific values found for the API %v ", apiProject.APIYaml.Data.ID)
 		apiProject.APIEnvProps = apiEnvProps
@@ -280,6 +482,78 @@ func ApplyAPIProjectFromAPIM(
 
 	loggers.LoggerAPI.Infof("Deploying api %s:%s in Organization %s", apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID)
 
+	err = xds.ExecuteInDeploymentQueue(apiYaml.ID, func() error {
+		if err := xds.ValidateAPIQuota(apiYaml.OrganizationID, apiYaml.ID, apiYaml.Name, apiYaml.Version); err != nil {
+			return err
+		}
+		deployedRevisionList, err = applyAPIProjectToVhosts(apiProject, vhostToEnvsMap, staged)
+		return err
+	})
+	if err == nil && !staged {
+		notifier.SendDeploymentEvent(getDeploymentEvent(apiYaml.ID, apiYaml.RevisionID, vhostToEnvsMap))
+	} else if err != nil && err != xds.ErrDeploymentQueueFull {
+		loggers.LogAPIError(&loggers.LoggerAPI, apiYaml.ID, logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while deploying the API %s:%s with UUID \"%v\" in Organization %s. %v",
+				apiYaml.Name, apiYaml.Version, apiYaml.ID, apiYaml.OrganizationID, err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1238,
+		})
+	}
+	return deployedRevisionList, err
+}
+
+// validateVhostToEnvsMap checks whether each environment is listed only once across all the vhosts of the
+// deployments. An environment deployed to multiple vhosts would undeploy the API from one vhost while deploying
+// it to the other.
+func validateVhostToEnvsMap(vhostToEnvsMap map[string][]string) error {
+	vhosts := make([]string, 0, len(vhostToEnvsMap))
+	for vhost := range vhostToEnvsMap {
+		vhosts = append(vhosts, vhost)
+	}
+	sort.Strings(vhosts)
+
+	envToVhost := make(map[string]string)
+	for _, vhost := range vhosts {
+		for _, env := range vhostToEnvsMap[vhost] {
+			existingVhost, found := envToVhost[env]
+			if !found {
+				envToVhost[env] = vhost
+				continue
+			}
+			if existingVhost == vhost {
+				return fmt.Errorf("the environment %q is listed in multiple deployments with the vhost %q", env, vhost)
+			}
+			return fmt.Errorf("the environment %q is listed in deployments with different vhosts %q and %q",
+				env, existingVhost, vhost)
+		}
+	}
+	return nil
+}
+
+// getDeploymentEvent creates the deployment webhook event of an API deployed to the given vhosts and environments.
+func getDeploymentEvent(apiID string, revisionID int, vhostToEnvsMap map[string][]string) notifier.DeploymentEvent {
+	event := notifier.DeploymentEvent{
+		APIID:        apiID,
+		RevisionID:   strconv.Itoa(revisionID),
+		Action:       notifier.DeployAction,
+		Environments: []string{},
+		Vhosts:       []string{},
+	}
+	for vhost, environments := range vhostToEnvsMap {
+		event.Vhosts = append(event.Vhosts, vhost)
+		event.Environments = append(event.Environments, environments...)
+	}
+	sort.Strings(event.Vhosts)
+	sort.Strings(event.Environments)
+	return event
+}
+
+// applyAPIProjectToVhosts deploys the API project in the provided vhosts and environments, and undeploys it
+// from the other vhosts of the same environments unless the adapter is configured to keep the API in those vhosts.
+func applyAPIProjectToVhosts(apiProject model.ProjectAPI, vhostToEnvsMap map[string][]string, staged bool) (
+	deployedRevisionList []*notifier.DeployedAPIRevision, err error) {
+	conf, _ := config.ReadConfigs()
+	apiYaml := &apiProject.APIYaml.Data
 	// vhostsToRemove contains vhosts and environments to undeploy
 	vhostsToRemove := make(map[string][]string)
 
@@ -291,6 +565,11 @@ func ApplyAPIProjectFromAPIM(
 				loggers.LoggerAPI.Infof("API %v:%v with UUID \"%v\" already deployed to vhost: %v",
 					apiYaml.Name, apiYaml.Version, apiYaml.ID, existingVhost)
 				if vhost != existingVhost {
+					if conf.Adapter.KeepAPIInPreviousVhost {
+						loggers.LoggerAPI.Infof("API %v:%v with UUID \"%v\" is kept deployed to vhost: %v along with vhost: %v",
+							apiYaml.Name, apiYaml.Version, apiYaml.ID, existingVhost, vhost)
+						continue
+					}
 					loggers.LoggerAPI.Infof("Un-deploying API %v:%v with UUID \"%v\" which is already deployed to vhost: %v",
 						apiYaml.Name, apiYaml.Version, apiYaml.ID, existingVhost)
 					vhostsToRemove[existingVhost] = append(vhostsToRemove[existingVhost], env)
@@ -312,8 +591,18 @@ func ApplyAPIProjectFromAPIM(
 			// `isDefaultVersion` prop is anyway updated for deployment events.
 			loggers.LoggerAPI.Debugf("API %s is not found in API Metadata map.", apiYaml.ID)
 		}
+		if xds.IsAPIUnchanged(vhost, apiProject, allEnvironments, staged) {
+			// The revision is acknowledged again, as the control plane expects an acknowledgement for each deployment.
+			loggers.LoggerAPI.Infof("API %v:%v with UUID \"%v\" is already deployed in vhost %v with the same content. "+
+				"Hence the deployment is skipped.", apiYaml.Name, apiYaml.Version, apiYaml.ID, vhost)
+			if !staged {
+				deployedRevisionList = append(deployedRevisionList,
+					notifier.UpdateDeployedRevisions(apiYaml.ID, apiYaml.RevisionID, allEnvironments, vhost))
+			}
+			continue
+		}
 		// first update the API for vhost
-		deployedRevision, err := xds.UpdateAPI(vhost, apiProject, allEnvironments)
+		deployedRevision, err := xds.UpdateAPI(vhost, apiProject, allEnvironments, staged)
 		if err != nil {
 			return deployedRevisionList, fmt.Errorf("%v:%v with UUID \"%v\"", apiYaml.Name, apiYaml.Version, apiYaml.ID)
 		}
@@ -323,12 +612,17 @@ func ApplyAPIProjectFromAPIM(
 	}
 
 	// undeploy APIs with other vhosts in the same gateway environment
+	if len(vhostsToRemove) > 0 && config.IsReadOnlyMode() {
+		loggers.LoggerAPI.Infof("API %v:%v with UUID \"%v\" is not un-deployed from the vhosts %v as the adapter is in read-only mode.",
+			apiYaml.Name, apiYaml.Version, apiYaml.ID, vhostsToRemove)
+		return deployedRevisionList, nil
+	}
 	for vhost, environments := range vhostsToRemove {
 		if vhost == "" {
 			// ignore if vhost is empty, since it deletes all vhosts of API
 			continue
 		}
-		if err := xds.DeleteAPIsWithUUID(vhost, apiYaml.ID, environments, apiYaml.OrganizationID); err != nil {
+		if _, err := xds.DeleteAPIsWithUUID(vhost, apiYaml.ID, environments, apiYaml.OrganizationID); err != nil {
 			return deployedRevisionList, err
 		}
 	}
@@ -336,33 +630,315 @@ func ApplyAPIProjectFromAPIM(
 }
 
 // ApplyAPIProjectInStandaloneMode is called by the rest implementation to differentiate
-// between create and update using the override param
-func ApplyAPIProjectInStandaloneMode(payload []byte, override *bool) (apiProject model.ProjectAPI, err error) {
+// between create and update using the override param. If staged is true, the API is stored without serving
+// traffic until it is activated via ActivateAPI. The deployment is classified as created, updated or unchanged,
+// where the deployment is skipped if the API is already deployed with the same content, unless force is true.
+// The vhosts and environments of an API which is already deployed are decided by the update strategy.
+func ApplyAPIProjectInStandaloneMode(payload []byte, override *bool, force bool, staged bool, updateStrategy string) (
+	apiProject model.ProjectAPI, result DeploymentResult, err error) {
 	apiProject, err = extractAPIProject(payload)
 	if err != nil {
-		return apiProject, err
+		return apiProject, result, err
+	}
+	if apiProject.Deployments, err = resolveDeployments(apiProject, updateStrategy); err != nil {
+		return apiProject, result, err
+	}
+	return applyAPIProject(apiProject, override, force, staged)
+}
+
+// ActivateAPI starts serving the traffic of an API which was applied as staged. APIs deployed without a UUID are
+// identified by the hash of the API name and version.
+func ActivateAPI(apiID, organizationID string) error {
+	return xds.ExecuteInDeploymentQueue(apiID, func() error {
+		return xds.ActivateAPI(apiID, organizationID)
+	})
+}
+
+// ListApis calls the ListApis method in xds_server.go, filtering the APIs by the API types given in the query
+// as "type:<API type>[,<API type>...]". An error is returned if the query is malformed.
+func ListApis(query *string, limit *int64, organizationID string) (*apiModel.APIMeta, error) {
+	var apiTypes []string
+	if query != nil && *query != "" {
+		var err error
+		if apiTypes, err = parseAPITypeFilter(*query); err != nil {
+			return nil, err
+		}
+	}
+	return xds.ListApis(apiTypes, organizationID, limit), nil
+}
+
+// parseAPITypeFilter returns the upper cased API types of a query in the form "type:<API type>[,<API type>...]"
+func parseAPITypeFilter(query string) ([]string, error) {
+	queryPair := strings.SplitN(query, ":", 2)
+	if !strings.EqualFold(strings.TrimSpace(queryPair[0]), apiTypeFilterKey) {
+		return nil, fmt.Errorf("unsupported filter %q in the query. Only filtering by the API type is supported "+
+			"as \"%s:<API type>\"", queryPair[0], apiTypeFilterKey)
+	}
+	if len(queryPair) != 2 {
+		return nil, fmt.Errorf("API type is not given in the query %q. The query should be in the form "+
+			"\"%s:<API type>[,<API type>...]\"", query, apiTypeFilterKey)
+	}
+	var apiTypes []string
+	for _, apiType := range strings.Split(queryPair[1], ",") {
+		apiType = strings.ToUpper(strings.TrimSpace(apiType))
+		if apiType == "" {
+			return nil, fmt.Errorf("empty API type in the query %q", query)
+		}
+		apiTypes = append(apiTypes, apiType)
+	}
+	return apiTypes, nil
+}
+
+// GetAPI returns the details of the API currently deployed with the given UUID, along with the warnings reported
+// while parsing its API definition. The second return value is false if the API is not currently deployed.
+func GetAPI(apiID string) (*apiModel.APIInfo, bool) {
+	mgwSwagger, isDeployed := xds.GetDeployedAPI(apiID)
+	if !isDeployed {
+		return nil, false
+	}
+	return &apiModel.APIInfo{
+		APIID:                          apiID,
+		APIName:                        mgwSwagger.GetTitle(),
+		Version:                        mgwSwagger.GetVersion(),
+		APIType:                        mgwSwagger.GetAPIType(),
+		Context:                        mgwSwagger.GetXWso2Basepath(),
+		ContextAliases:                 mgwSwagger.GetContextAliases(),
+		DefinitionHash:                 mgwSwagger.GetDefinitionHash(),
+		ParseWarnings:                  getParseWarningModels(mgwSwagger.GetParseWarnings()),
+		Resources:                      getResourceSecurityModels(mgwSwagger.GetResources()),
+		SubscriptionValidationDisabled: mgwSwagger.IsSubscriptionValidationDisabled(),
+	}, true
+}
+
+// getResourceSecurityModels returns whether the security is disabled for each operation of the resources, either
+// by the API definition or by a public path pattern, along with the throttling tier applied to it if so.
+func getResourceSecurityModels(resources []*model.Resource) []*apiModel.ResourceSecurity {
+	resourceSecurities := make([]*apiModel.ResourceSecurity, 0, len(resources))
+	for _, resource := range resources {
+		for _, operation := range resource.GetMethod() {
+			resourceSecurities = append(resourceSecurities, &apiModel.ResourceSecurity{
+				Path:                resource.GetPath(),
+				Method:              operation.GetMethod(),
+				SecurityDisabled:    operation.GetDisableSecurity(),
+				PublicPathPattern:   operation.GetPublicPathPattern(),
+				AnonymousTier:       operation.GetAnonymousTier(),
+				AnalyticsProperties: operation.GetAnalyticsProperties(),
+			})
+		}
+	}
+	return resourceSecurities
+}
+
+// GetParseWarningsOfAPIProject returns the warnings reported while parsing the API definition of the deployed
+// API project.
+func GetParseWarningsOfAPIProject(apiProject model.ProjectAPI) []*apiModel.ParseWarning {
+	apiYaml := apiProject.APIYaml.Data
+	apiID := apiYaml.ID
+	if apiID == "" {
+		apiID = xds.GenerateHashedAPINameVersionIDWithoutVhost(apiYaml.Name, apiYaml.Version)
+	}
+	mgwSwagger, _ := xds.GetDeployedAPI(apiID)
+	return getParseWarningModels(mgwSwagger.GetParseWarnings())
+}
+
+func getParseWarningModels(parseWarnings []model.ParseWarning) []*apiModel.ParseWarning {
+	warnings := make([]*apiModel.ParseWarning, 0, len(parseWarnings))
+	for _, warning := range parseWarnings {
+		warnings = append(warnings, &apiModel.ParseWarning{Code: warning.Code, Message: warning.Message})
+	}
+	return warnings
+}
+
+// GetResourceUsage returns the resource usage of the router configurations computed in xds_server.go
+func GetResourceUsage() *apiModel.ResourceUsage {
+	usage := xds.GetResourceUsage()
+	resourceUsage := &apiModel.ResourceUsage{
+		Snapshots:     make([]*apiModel.SnapshotResourceUsage, 0, len(usage.Snapshots)),
+		Organizations: make([]*apiModel.OrganizationResourceUsage, 0, len(usage.Organizations)),
+		Apis:          make([]*apiModel.APIResourceUsage, 0, len(usage.APIs)),
+	}
+	for _, snapshotUsage := range usage.Snapshots {
+		resourceUsage.Snapshots = append(resourceUsage.Snapshots, &apiModel.SnapshotResourceUsage{
+			Label:       snapshotUsage.Label,
+			SizeInBytes: int64(snapshotUsage.SizeInBytes),
+		})
+	}
+	for _, orgUsage := range usage.Organizations {
+		resourceUsage.Organizations = append(resourceUsage.Organizations, &apiModel.OrganizationResourceUsage{
+			OrganizationID: orgUsage.OrganizationID,
+			APICount:       int64(orgUsage.APIs),
+			Routes:         int64(orgUsage.Routes),
+			Clusters:       int64(orgUsage.Clusters),
+			Endpoints:      int64(orgUsage.Endpoints),
+			SizeInBytes:    int64(orgUsage.SizeInBytes),
+		})
+	}
+	for _, apiUsage := range usage.APIs {
+		resourceUsage.Apis = append(resourceUsage.Apis, &apiModel.APIResourceUsage{
+			APIIdentifier:  apiUsage.APIIdentifier,
+			OrganizationID: apiUsage.OrganizationID,
+			Routes:         int64(apiUsage.Routes),
+			Clusters:       int64(apiUsage.Clusters),
+			Endpoints:      int64(apiUsage.Endpoints),
+			SizeInBytes:    int64(apiUsage.SizeInBytes),
+		})
+	}
+	return resourceUsage
+}
+
+// GetAPIDeployments returns the vhosts and environments in which the API is currently deployed. The second
+// return value is false if the API is not known to the adapter.
+func GetAPIDeployments(apiID string) (*apiModel.APIDeploymentList, bool) {
+	deployments, isKnown := xds.GetAPIDeployments(apiID)
+	if !isKnown {
+		return nil, false
+	}
+	deploymentList := &apiModel.APIDeploymentList{
+		APIID: apiID,
+		Count: int64(len(deployments)),
+		List:  make([]*apiModel.APIDeployment, 0, len(deployments)),
+	}
+	for _, deployment := range deployments {
+		deploymentList.List = append(deploymentList.List, getAPIDeploymentModel(deployment))
+	}
+	return deploymentList, true
+}
+
+func getAPIDeploymentModel(deployment xds.APIDeployment) *apiModel.APIDeployment {
+	return &apiModel.APIDeployment{
+		Vhost:            deployment.Vhost,
+		Environments:     deployment.Environments,
+		RevisionID:       deployment.RevisionID,
+		UpstreamBasepath: deployment.UpstreamBasepath,
+		DeployedTime:     deployment.DeployedTime.Format(time.RFC3339),
+	}
+}
+
+// GetAPIStatuses returns the deployment status of each of the requested API revisions. APIs which are not known to
+// the adapter are reported with the status UNKNOWN. An error is returned if more API revisions than
+// maxAPIStatusRequestSize are requested.
+func GetAPIStatuses(request *apiModel.APIStatusRequest) (*apiModel.APIStatusList, error) {
+	if len(request.List) > maxAPIStatusRequestSize {
+		return nil, fmt.Errorf("the status of %d APIs is requested, but at most %d APIs are accepted in a request",
+			len(request.List), maxAPIStatusRequestSize)
+	}
+	apiRevisions := make([]xds.APIRevision, 0, len(request.List))
+	for _, apiRevision := range request.List {
+		if apiRevision == nil || apiRevision.APIID == nil {
+			return nil, errors.New("the UUID of the API is not provided in the request")
+		}
+		apiRevisions = append(apiRevisions, xds.APIRevision{
+			APIID:      *apiRevision.APIID,
+			RevisionID: apiRevision.RevisionID,
+		})
+	}
+	statuses := xds.GetAPIStatuses(apiRevisions)
+	statusList := &apiModel.APIStatusList{
+		Count: int64(len(statuses)),
+		List:  make([]*apiModel.APIStatus, 0, len(statuses)),
+	}
+	for _, status := range statuses {
+		deployments := make([]*apiModel.APIDeployment, 0, len(status.Deployments))
+		for _, deployment := range status.Deployments {
+			deployments = append(deployments, getAPIDeploymentModel(deployment))
+		}
+		statusList.List = append(statusList.List, &apiModel.APIStatus{
+			APIID:       status.APIID,
+			RevisionID:  status.RevisionID,
+			Status:      status.Status,
+			Deployments: deployments,
+		})
 	}
-	return validateAndUpdateXds(apiProject, override)
+	return statusList, nil
 }
 
-// ListApis calls the ListApis method in xds_server.go
-func ListApis(query *string, limit *int64, organizationID string) *apiModel.APIMeta {
-	var apiType string
-	if query != nil {
-		queryPair := strings.Split(*query, ":")
-		if queryPair[0] == apiTypeFilterKey {
-			apiType = strings.ToUpper(queryPair[1])
-			return xds.ListApis(apiType, organizationID, limit)
+// GetAPIProvenance returns the sources of the effective configurations of the API in each vhost it is currently
+// deployed. The second return value is false if the API is not known to the adapter.
+func GetAPIProvenance(apiID string) (*apiModel.APIProvenanceList, bool) {
+	provenance, isKnown := xds.GetAPIProvenance(apiID)
+	if !isKnown {
+		return nil, false
+	}
+	provenanceList := &apiModel.APIProvenanceList{
+		APIID: apiID,
+		List:  make([]*apiModel.APIProvenance, 0, len(provenance)),
+	}
+	for _, apiProvenance := range provenance {
+		configs := make([]*apiModel.ConfigProvenance, 0, len(apiProvenance.Configs))
+		for _, configProvenance := range apiProvenance.Configs {
+			overridden := make([]*apiModel.OverriddenConfig, 0, len(configProvenance.Overridden))
+			for _, overriddenConfig := range configProvenance.Overridden {
+				overridden = append(overridden, &apiModel.OverriddenConfig{
+					Source: overriddenConfig.Source,
+					Value:  overriddenConfig.Value,
+				})
+			}
+			configs = append(configs, &apiModel.ConfigProvenance{
+				Field:      configProvenance.Field,
+				Source:     configProvenance.Source,
+				Value:      configProvenance.Value,
+				Overridden: overridden,
+			})
+		}
+		skippedFiles := apiProvenance.SkippedFiles
+		if skippedFiles == nil {
+			skippedFiles = []string{}
 		}
+		provenanceList.List = append(provenanceList.List, &apiModel.APIProvenance{
+			Vhost:        apiProvenance.Vhost,
+			Configs:      configs,
+			SkippedFiles: skippedFiles,
+		})
 	}
-	return xds.ListApis("", organizationID, limit)
+	return provenanceList, true
 }
 
-func readZipFile(zf *zip.File) ([]byte, error) {
+func readZipFile(zf *zip.File, budget *extractionBudget) ([]byte, error) {
 	f, err := zf.Open()
 	if err != nil {
 		return nil, err
 	}
 	defer f.Close()
-	return ioutil.ReadAll(f)
+	return budget.readFile(zf.Name, f, int64(zf.UncompressedSize64))
+}
+
+func readMountedFile(path string, size int64, budget *extractionBudget) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return budget.readFile(path, f, size)
+}
+
+// UpdateTLSCertificate replaces the TLS certificate served by the router for the vhost of the given certificate,
+// and returns the details of the served certificate.
+func UpdateTLSCertificate(certificate *apiModel.TLSCertificate) (*apiModel.TLSCertificateInfo, error) {
+	tlsCertificate, err := xds.UpdateTLSCertificate(certificate.Vhost, []byte(*certificate.Certificate),
+		[]byte(*certificate.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	return getTLSCertificateInfo(certificate.Vhost, tlsCertificate), nil
+}
+
+// RollbackTLSCertificate restores the TLS certificate of the vhost which was replaced by the last update, and
+// returns the details of the restored certificate. Only the vhost is returned if the configured certificate of the
+// router is restored, or if the vhost does not have a certificate of its own anymore.
+func RollbackTLSCertificate(vhost string) (*apiModel.TLSCertificateInfo, error) {
+	tlsCertificate, err := xds.RollbackTLSCertificate(vhost)
+	if err != nil {
+		return nil, err
+	}
+	return getTLSCertificateInfo(vhost, tlsCertificate), nil
+}
+
+func getTLSCertificateInfo(vhost string, certificate *xds.TLSCertificate) *apiModel.TLSCertificateInfo {
+	certificateInfo := &apiModel.TLSCertificateInfo{Vhost: vhost}
+	if certificate != nil {
+		certificateInfo.Subject = certificate.Leaf.Subject.String()
+		certificateInfo.Issuer = certificate.Leaf.Issuer.String()
+		certificateInfo.NotAfter = strfmt.DateTime(certificate.Leaf.NotAfter)
+	}
+	return certificateInfo
 }
@@ -0,0 +1,630 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	apiModel "github.com/wso2/product-microgateway/adapter/internal/api/models"
+	xds "github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
+)
+
+// readTestAPIProject reads the API project in the test-resources/apiprojects directory the same way
+// the mounted API projects are read.
+func readTestAPIProject(t *testing.T, projectName string) model.ProjectAPI {
+	projectDir := filepath.FromSlash(config.GetMgwHome() + "/../adapter/test-resources/apiprojects/" + projectName)
+	apiProject := model.ProjectAPI{
+		EndpointCerts:   make(map[string]string),
+		UpstreamCerts:   make(map[string][]byte),
+		DownstreamCerts: make(map[string][]byte),
+		Policies:        make(map[string]model.PolicyContainer),
+	}
+	err := filepath.Walk(projectDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		fileContent, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return processFileInsideProject(&apiProject, fileContent, path)
+	})
+	assert.Nil(t, err, "Error while reading the test API project %v", projectName)
+	return apiProject
+}
+
+func TestValidateAndUpdateXdsInDryRunMode(t *testing.T) {
+	apiProject := readTestAPIProject(t, "petstore")
+	apiYaml := apiProject.APIYaml.Data
+	vhost, _, _ := config.GetDefaultVhost(config.DefaultGatewayName)
+	override := false
+
+	_, plan, err := validateAndUpdateXds(apiProject, &override, false, true, false)
+	assert.Nil(t, err, "Dry run should not return an error for a valid API project")
+	assert.Len(t, plan, 1, "Plan should contain an entry for the default vhost")
+	assert.Equal(t, vhost, plan[0].VHost)
+	assert.Equal(t, []string{config.DefaultGatewayName}, plan[0].Environments)
+	assert.False(t, plan[0].IsUpdate, "API is not deployed yet, hence the plan should not be an update")
+	assert.NotEmpty(t, plan[0].Routes, "Routes should be computed in dry run")
+	assert.NotEmpty(t, plan[0].Clusters, "Clusters should be computed in dry run")
+	assert.False(t, xds.IsAPIExist(vhost, apiYaml.ID, apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID),
+		"API should not be deployed in dry run")
+	assert.Empty(t, xds.GetDeployedEnvironments(apiYaml.ID), "API should not be deployed to any environment in dry run")
+}
+
+func TestValidateAndUpdateXdsWithParseWarnings(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousFailOnParseWarnings := conf.Adapter.FailOnParseWarnings
+	defer func() {
+		conf.Adapter.FailOnParseWarnings = previousFailOnParseWarnings
+	}()
+	// operationIds are not defined for the operations of the petstore API
+	apiProject := readTestAPIProject(t, "petstore")
+	override := false
+
+	conf.Adapter.FailOnParseWarnings = []string{}
+	_, _, err := validateAndUpdateXds(apiProject, &override, false, true, false)
+	assert.Nil(t, err, "Parse warnings should not fail the API by default")
+
+	conf.Adapter.FailOnParseWarnings = []string{model.ParseWarningUnusedComponent}
+	_, _, err = validateAndUpdateXds(apiProject, &override, false, true, false)
+	assert.Nil(t, err, "Parse warnings of the API which are not configured should not fail the API")
+
+	conf.Adapter.FailOnParseWarnings = []string{model.ParseWarningMissingOperationID}
+	_, _, err = validateAndUpdateXds(apiProject, &override, false, true, false)
+	assert.NotNil(t, err, "Parse warnings configured in failOnParseWarnings should fail the API")
+}
+
+func TestExtractAPIProjectWithUnsupportedFeatures(t *testing.T) {
+	apiProject, err := extractAPIProject(zipTestAPIProject(t, "petstore"))
+	assert.Nil(t, err, "Error while extracting the API project")
+	assert.Empty(t, apiProject.GetUnsupportedFeatures(), "Petstore API should not use unsupported features")
+
+	payload := zipTestAPIProjectWithEntry(t, "petstore", "petstore/Sequences/in-sequence/log-in.xml", 64)
+	apiProject, err = extractAPIProject(payload)
+	assert.Nil(t, err, "Mediation sequences should not fail the extraction of the API project")
+	if assert.Len(t, apiProject.GetUnsupportedFeatures(), 1) {
+		assert.Equal(t, model.UnsupportedFeatureMediationSequence, apiProject.GetUnsupportedFeatures()[0].Name)
+	}
+
+	override := false
+	_, _, err = validateAndUpdateXds(apiProject, &override, false, true, false)
+	assert.Nil(t, err, "Unsupported features should not fail the API by default")
+}
+
+func TestValidateAndUpdateXdsWithClientCertificates(t *testing.T) {
+	apiProject := readTestAPIProject(t, "petstore-mtls")
+	assert.ElementsMatch(t, []model.CertificateDetails{
+		{Alias: "gold-client", Tier: "Gold", CertificateName: "gold-client.pem"},
+		{Alias: "bronze-client", Tier: "Bronze", CertificateName: "bronze-client.pem"},
+	}, apiProject.ClientCerts)
+	override := false
+
+	_, _, err := validateAndUpdateXds(apiProject, &override, false, true, false)
+	assert.Nil(t, err, "Dry run should not return an error for an API project with client certificates")
+
+	duplicateAliasProject := apiProject
+	duplicateAliasProject.ClientCerts = append([]model.CertificateDetails{}, apiProject.ClientCerts...)
+	duplicateAliasProject.ClientCerts[1].Alias = "gold-client"
+	_, _, err = validateAndUpdateXds(duplicateAliasProject, &override, false, true, false)
+	assert.NotNil(t, err, "Duplicate client certificate aliases should fail the validation")
+
+	missingCertProject := apiProject
+	missingCertProject.DownstreamCerts = map[string][]byte{"gold-client.pem": apiProject.DownstreamCerts["gold-client.pem"]}
+	_, _, err = validateAndUpdateXds(missingCertProject, &override, false, true, false)
+	assert.NotNil(t, err, "Client certificate alias referring to a missing file should fail the validation")
+
+	duplicateAliasFile := []byte(`type: client_certificates
+version: v4
+data:
+ - alias: gold-client
+   certificate: gold-client.pem
+   tierName: Gold
+ - alias: gold-client
+   certificate: bronze-client.pem
+   tierName: Bronze
+`)
+	err = processFileInsideProject(&model.ProjectAPI{}, duplicateAliasFile,
+		filepath.FromSlash("petstore-mtls/Client-certificates/client_certificates.yaml"))
+	assert.NotNil(t, err, "Duplicate client certificate aliases should be rejected while reading the project")
+}
+
+func TestValidateAndUpdateXdsWithUnicodeAPIName(t *testing.T) {
+	apiProject := readTestAPIProject(t, "pagos-mexico")
+	override := false
+
+	_, plan, err := validateAndUpdateXds(apiProject, &override, false, true, false)
+	assert.Nil(t, err, "Dry run should not return an error for an API with an unicode name")
+	assert.Len(t, plan, 1)
+	for _, cluster := range plan[0].Clusters {
+		assert.Regexp(t, `^[a-zA-Z0-9_.\-]+$`, cluster, "Cluster names should contain only the allowed characters")
+	}
+
+	// redeploying the API with the name in a different Unicode form and case should not change its identity
+	redeployedProject := apiProject
+	redeployedProject.APIYaml.Data.Name = "PAGOS ME\u0301XICO"
+	_, redeployPlan, err := validateAndUpdateXds(redeployedProject, &override, false, true, false)
+	assert.Nil(t, err, "Error while computing the redeployment of the API")
+	assert.Len(t, redeployPlan, 1)
+	assert.Equal(t, plan[0].APIIdentifier, redeployPlan[0].APIIdentifier)
+
+	invalidProject := apiProject
+	invalidProject.APIYaml.Data.Name = "Pagos\nMéxico"
+	assert.NotNil(t, invalidProject.APIYaml.ValidateMandatoryFields(),
+		"API names with control characters should be rejected")
+}
+
+func TestApplyAPIProjectToVhostsWithVhostChange(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousKeepAPIInPreviousVhost := conf.Adapter.KeepAPIInPreviousVhost
+	defer func() {
+		conf.Adapter.KeepAPIInPreviousVhost = previousKeepAPIInPreviousVhost
+	}()
+
+	tests := []struct {
+		name                   string
+		keepAPIInPreviousVhost bool
+		apiUUID                string
+	}{
+		{
+			name:                   "Undeploy the API from the previous vhost",
+			keepAPIInPreviousVhost: false,
+			apiUUID:                "vhost-change-undeploy-previous",
+		},
+		{
+			name:                   "Keep the API in the previous vhost",
+			keepAPIInPreviousVhost: true,
+			apiUUID:                "vhost-change-keep-previous",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf.Adapter.KeepAPIInPreviousVhost = test.keepAPIInPreviousVhost
+			apiProject := readTestAPIProject(t, "petstore")
+			apiProject.APIYaml.Data.ID = test.apiUUID
+			apiYaml := apiProject.APIYaml.Data
+			previousVhost := test.apiUUID + ".previous.wso2.com"
+			newVhost := test.apiUUID + ".new.wso2.com"
+
+			_, err := applyAPIProjectToVhosts(apiProject,
+				map[string][]string{previousVhost: {config.DefaultGatewayName}}, false)
+			assert.Nil(t, err, "Error while deploying the API to the previous vhost")
+			assert.True(t, xds.IsAPIExist(previousVhost, apiYaml.ID, apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID))
+
+			_, err = applyAPIProjectToVhosts(apiProject,
+				map[string][]string{newVhost: {config.DefaultGatewayName}}, false)
+			assert.Nil(t, err, "Error while deploying the API to the new vhost")
+			assert.True(t, xds.IsAPIExist(newVhost, apiYaml.ID, apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID),
+				"API should be deployed to the new vhost")
+			assert.Equal(t, test.keepAPIInPreviousVhost,
+				xds.IsAPIExist(previousVhost, apiYaml.ID, apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID),
+				"API should be kept in the previous vhost only if it is configured")
+			vhost, _ := xds.GetVhostOfAPI(apiYaml.ID, config.DefaultGatewayName)
+			assert.Equal(t, newVhost, vhost, "New vhost should be the vhost of the API in the environment")
+
+			// undeploying the API from the environment removes it from all the vhosts
+			xds.DeleteAPIWithAPIMEvent(apiYaml.ID, apiYaml.OrganizationID, []string{config.DefaultGatewayName}, "")
+			assert.False(t, xds.IsAPIExist(newVhost, apiYaml.ID, apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID))
+			assert.False(t, xds.IsAPIExist(previousVhost, apiYaml.ID, apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID))
+		})
+	}
+}
+
+func TestGetAPIDeployments(t *testing.T) {
+	apiProject := readTestAPIProject(t, "petstore")
+	apiProject.APIYaml.Data.ID = "get-api-deployments"
+	apiYaml := apiProject.APIYaml.Data
+	vhost := "deployments.wso2.com"
+
+	_, isKnown := GetAPIDeployments(apiYaml.ID)
+	assert.False(t, isKnown, "API should not be known before it is deployed")
+
+	_, err := applyAPIProjectToVhosts(apiProject, map[string][]string{vhost: {config.DefaultGatewayName}}, false)
+	assert.Nil(t, err, "Error while deploying the API")
+	deployments, isKnown := GetAPIDeployments(apiYaml.ID)
+	assert.True(t, isKnown)
+	assert.Equal(t, apiYaml.ID, deployments.APIID)
+	assert.Equal(t, int64(1), deployments.Count)
+	if assert.Len(t, deployments.List, 1) {
+		assert.Equal(t, vhost, deployments.List[0].Vhost)
+		assert.Equal(t, []string{config.DefaultGatewayName}, deployments.List[0].Environments)
+		assert.Equal(t, "/v2", deployments.List[0].UpstreamBasepath)
+		assert.NotEmpty(t, deployments.List[0].DeployedTime)
+	}
+
+	xds.DeleteAPIWithAPIMEvent(apiYaml.ID, apiYaml.OrganizationID, []string{config.DefaultGatewayName}, "")
+	deployments, isKnown = GetAPIDeployments(apiYaml.ID)
+	assert.True(t, isKnown, "Undeployed API should still be known to the adapter")
+	assert.Equal(t, int64(0), deployments.Count)
+	assert.NotNil(t, deployments.List)
+	assert.Empty(t, deployments.List)
+}
+
+func TestGetAPIStatuses(t *testing.T) {
+	apiProject := readTestAPIProject(t, "petstore")
+	apiProject.APIYaml.Data.ID = "get-api-statuses"
+	apiYaml := apiProject.APIYaml.Data
+	vhost := "statuses.wso2.com"
+
+	_, err := applyAPIProjectToVhosts(apiProject, map[string][]string{vhost: {config.DefaultGatewayName}}, false)
+	assert.Nil(t, err, "Error while deploying the API")
+	defer xds.DeleteAPIWithAPIMEvent(apiYaml.ID, apiYaml.OrganizationID, []string{config.DefaultGatewayName}, "")
+
+	apiID := apiYaml.ID
+	unknownAPIID := "get-api-statuses-unknown"
+	statuses, err := GetAPIStatuses(&apiModel.APIStatusRequest{List: []*apiModel.APIRevision{
+		{APIID: &unknownAPIID, RevisionID: "1"},
+		{APIID: &apiID},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), statuses.Count)
+	if assert.Len(t, statuses.List, 2) {
+		assert.Equal(t, unknownAPIID, statuses.List[0].APIID)
+		assert.Equal(t, xds.APIStatusUnknown, statuses.List[0].Status)
+		assert.NotNil(t, statuses.List[0].Deployments)
+		assert.Empty(t, statuses.List[0].Deployments)
+
+		assert.Equal(t, apiID, statuses.List[1].APIID)
+		assert.Equal(t, xds.APIStatusDeployed, statuses.List[1].Status)
+		if assert.Len(t, statuses.List[1].Deployments, 1) {
+			assert.Equal(t, vhost, statuses.List[1].Deployments[0].Vhost)
+			assert.Equal(t, []string{config.DefaultGatewayName}, statuses.List[1].Deployments[0].Environments)
+			assert.NotEmpty(t, statuses.List[1].Deployments[0].DeployedTime)
+		}
+	}
+
+	apiRevisions := make([]*apiModel.APIRevision, maxAPIStatusRequestSize+1)
+	for i := range apiRevisions {
+		apiRevisions[i] = &apiModel.APIRevision{APIID: &apiID}
+	}
+	_, err = GetAPIStatuses(&apiModel.APIStatusRequest{List: apiRevisions})
+	assert.NotNil(t, err, "Requests with more API revisions than the maximum should be rejected")
+	_, err = GetAPIStatuses(&apiModel.APIStatusRequest{List: apiRevisions[:maxAPIStatusRequestSize]})
+	assert.Nil(t, err)
+}
+
+func TestGetAPIProvenance(t *testing.T) {
+	apiProject := readTestAPIProject(t, "petstore")
+	apiProject.APIYaml.Data.ID = "get-api-provenance"
+	apiProject.APIEnvProps = map[string]synchronizer.APIEnvProps{
+		config.DefaultGatewayName: {APIConfigs: synchronizer.APIConfigs{
+			ProductionEndpoint: "https://env-props.petstore.io:8443/v2",
+			ProductionEndpointSecurity: &synchronizer.EndpointSecurity{
+				Username: "admin",
+				Password: "env-props-secret",
+			},
+		}},
+	}
+	apiParams := `
+environments:
+  - name: ` + config.DefaultGatewayName + `
+    configs:
+      endpoints:
+        production:
+          url: https://api-params.petstore.io:8443/v2
+        sandbox:
+          url: https://api-params-sandbox.petstore.io:8443/v2
+      security:
+        sandbox:
+          enabled: true
+          type: basic
+          username: api-params-user
+          password: api-params-secret
+`
+	assert.Nil(t, processFileInsideProject(&apiProject, []byte(apiParams), "petstore/api_params.yaml"))
+	apiProject.SkippedFiles = []string{"petstore/Docs/docs.json"}
+	apiYaml := apiProject.APIYaml.Data
+	vhost := "provenance.wso2.com"
+	apiParamsSource := model.GetAPIParamsProvenanceSource(config.DefaultGatewayName)
+
+	_, isKnown := GetAPIProvenance(apiYaml.ID)
+	assert.False(t, isKnown, "API should not be known before it is deployed")
+
+	_, err := applyAPIProjectToVhosts(apiProject, map[string][]string{vhost: {config.DefaultGatewayName}}, false)
+	assert.Nil(t, err, "Error while deploying the API")
+	defer xds.DeleteAPIWithAPIMEvent(apiYaml.ID, apiYaml.OrganizationID, []string{config.DefaultGatewayName}, "")
+	provenance, isKnown := GetAPIProvenance(apiYaml.ID)
+	assert.True(t, isKnown)
+	if !assert.Len(t, provenance.List, 1) {
+		return
+	}
+	assert.Equal(t, vhost, provenance.List[0].Vhost)
+	assert.Equal(t, []string{"petstore/Docs/docs.json"}, provenance.List[0].SkippedFiles)
+	configs := make(map[string]*apiModel.ConfigProvenance)
+	for _, configProvenance := range provenance.List[0].Configs {
+		configs[configProvenance.Field] = configProvenance
+	}
+
+	assert.Equal(t, model.ProvenanceSourceDeploymentEnvironments, configs[model.ProvenanceFieldVhost].Source)
+	assert.Equal(t, model.ProvenanceSourceAPIYaml, configs[model.ProvenanceFieldAuthorizationHeader].Source)
+	assert.Equal(t, "Authorization", configs[model.ProvenanceFieldAuthorizationHeader].Value)
+	sandEndpoints := configs[model.ProvenanceFieldSandboxEndpoints]
+	if assert.NotNil(t, sandEndpoints) {
+		assert.Equal(t, apiParamsSource, sandEndpoints.Source)
+		assert.Equal(t, "https://api-params-sandbox.petstore.io:8443/v2", sandEndpoints.Value)
+		if assert.Len(t, sandEndpoints.Overridden, 1) {
+			assert.Equal(t, model.ProvenanceSourceAPIYaml, sandEndpoints.Overridden[0].Source)
+			assert.Equal(t, "http://petstore-sandbox.swagger.io/v2", sandEndpoints.Overridden[0].Value)
+		}
+	}
+	sandSecurity := configs[model.ProvenanceFieldSandboxEndpointSecurity]
+	if assert.NotNil(t, sandSecurity) {
+		assert.Equal(t, apiParamsSource, sandSecurity.Source)
+		assert.Equal(t, "type=BASIC, username=api-params-user, password=******", sandSecurity.Value)
+	}
+
+	// api.yaml -> api_params -> APIEnvProps
+	prodEndpoints := configs[model.ProvenanceFieldProductionEndpoints]
+	if assert.NotNil(t, prodEndpoints) {
+		assert.Equal(t, model.ProvenanceSourceAPIEnvProps, prodEndpoints.Source)
+		assert.Equal(t, "https://env-props.petstore.io:8443/v2", prodEndpoints.Value)
+		if assert.Len(t, prodEndpoints.Overridden, 2) {
+			assert.Equal(t, model.ProvenanceSourceAPIYaml, prodEndpoints.Overridden[0].Source)
+			assert.Equal(t, "http://petstore.swagger.io/v2", prodEndpoints.Overridden[0].Value)
+			assert.Equal(t, apiParamsSource, prodEndpoints.Overridden[1].Source)
+			assert.Equal(t, "https://api-params.petstore.io:8443/v2", prodEndpoints.Overridden[1].Value)
+		}
+	}
+	prodSecurity := configs[model.ProvenanceFieldProductionEndpointSecurity]
+	if assert.NotNil(t, prodSecurity) {
+		assert.Equal(t, model.ProvenanceSourceAPIEnvProps, prodSecurity.Source)
+		assert.Equal(t, "type=BASIC, username=admin, password=******", prodSecurity.Value)
+	}
+
+	_, isKnown = GetAPIProvenance("unknown-api")
+	assert.False(t, isKnown)
+}
+
+func TestGetAppliedAPIConfig(t *testing.T) {
+	apiProject := readTestAPIProject(t, "petstore")
+	apiProject.APIYaml.Data.ID = "get-applied-api-config"
+	apiYaml := apiProject.APIYaml.Data
+	vhost := "applied-config.wso2.com"
+
+	_, err := xds.GetAppliedAPIConfig(apiYaml.ID, apiYaml.OrganizationID)
+	if assert.NotNil(t, err, "Applied config of an API which is not deployed should not be found") {
+		assert.Equal(t, constants.NotFound, err.Error())
+	}
+
+	_, err = applyAPIProjectToVhosts(apiProject, map[string][]string{vhost: {config.DefaultGatewayName}}, false)
+	assert.Nil(t, err, "Error while deploying the API")
+	appliedConfig, err := xds.GetAppliedAPIConfig(apiYaml.ID, apiYaml.OrganizationID)
+	assert.Nil(t, err)
+	appliedConfigs, ok := appliedConfig.([]xds.AppliedAPIConfig)
+	if assert.True(t, ok) && assert.Len(t, appliedConfigs, 1) {
+		assert.Equal(t, vhost, appliedConfigs[0].Vhost)
+		assert.Equal(t, []string{config.DefaultGatewayName}, appliedConfigs[0].Environments)
+		assert.NotEmpty(t, appliedConfigs[0].Routes, "Routes generated for the API should be returned")
+		assert.NotEmpty(t, appliedConfigs[0].Clusters, "Clusters generated for the API should be returned")
+		assert.NotNil(t, appliedConfigs[0].EnforcerAPI, "Enforcer API generated for the API should be returned")
+	}
+	_, err = xds.GetAppliedAPIConfig(apiYaml.ID, "unknown-organization")
+	assert.NotNil(t, err, "Applied config of an API should not be found in the other organizations")
+
+	xds.DeleteAPIWithAPIMEvent(apiYaml.ID, apiYaml.OrganizationID, []string{config.DefaultGatewayName}, "")
+	_, err = xds.GetAppliedAPIConfig(apiYaml.ID, apiYaml.OrganizationID)
+	assert.NotNil(t, err, "Applied config of an undeployed API should not be found")
+}
+
+func TestDeleteAPIsWithUUIDIsIdempotent(t *testing.T) {
+	apiProject := readTestAPIProject(t, "petstore")
+	apiProject.APIYaml.Data.ID = "idempotent-undeploy"
+	apiYaml := apiProject.APIYaml.Data
+	vhost := "idempotent-undeploy.wso2.com"
+	environments := []string{config.DefaultGatewayName}
+
+	_, err := applyAPIProjectToVhosts(apiProject, map[string][]string{vhost: environments}, false)
+	assert.Nil(t, err, "Error while deploying the API")
+	status, err := xds.DeleteAPIsWithUUID(vhost, apiYaml.ID, environments, apiYaml.OrganizationID)
+	assert.Nil(t, err)
+	assert.Equal(t, xds.Undeployed, status)
+	assert.False(t, xds.IsAPIExist(vhost, apiYaml.ID, apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID))
+
+	// redelivered undeploy requests succeed without removing anything
+	status, err = xds.DeleteAPIsWithUUID(vhost, apiYaml.ID, environments, apiYaml.OrganizationID)
+	assert.Nil(t, err, "Deleting an already undeployed API should not fail")
+	assert.Equal(t, xds.AlreadyUndeployed, status)
+	status, err = xds.DeleteAPIs(vhost, apiYaml.Name, apiYaml.Version, environments, apiYaml.OrganizationID)
+	assert.Nil(t, err, "Deleting an already undeployed API should not fail")
+	assert.Equal(t, xds.AlreadyUndeployed, status)
+}
+
+func TestDeleteAPIsWithUUIDConcurrently(t *testing.T) {
+	apiProject := readTestAPIProject(t, "petstore")
+	apiProject.APIYaml.Data.ID = "concurrent-undeploy"
+	apiYaml := apiProject.APIYaml.Data
+	vhost := "concurrent-undeploy.wso2.com"
+	environments := []string{config.DefaultGatewayName}
+
+	_, err := applyAPIProjectToVhosts(apiProject, map[string][]string{vhost: environments}, false)
+	assert.Nil(t, err, "Error while deploying the API")
+
+	const deleteRequests = 5
+	statuses := make(chan xds.UndeployStatus, deleteRequests)
+	errs := make(chan error, deleteRequests)
+	var wg sync.WaitGroup
+	for i := 0; i < deleteRequests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, err := xds.DeleteAPIsWithUUID(vhost, apiYaml.ID, environments, apiYaml.OrganizationID)
+			statuses <- status
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+	close(errs)
+	for err := range errs {
+		assert.Nil(t, err, "Concurrent undeploy requests of the same API should not fail")
+	}
+	undeployedCount := 0
+	for status := range statuses {
+		if status == xds.Undeployed {
+			undeployedCount++
+		}
+	}
+	assert.Equal(t, 1, undeployedCount, "The API should be removed by only one of the undeploy requests")
+	assert.False(t, xds.IsAPIExist(vhost, apiYaml.ID, apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID))
+}
+
+func TestValidateVhostToEnvsMap(t *testing.T) {
+	tests := []struct {
+		name           string
+		vhostToEnvsMap map[string][]string
+		isExpError     bool
+	}{
+		{
+			name: "Different environments in different vhosts",
+			vhostToEnvsMap: map[string][]string{
+				"us.wso2.com": {"Default", "us-region"},
+				"eu.wso2.com": {"eu-region"},
+			},
+			isExpError: false,
+		},
+		{
+			name:           "Same vhost in multiple deployments of different environments",
+			vhostToEnvsMap: map[string][]string{"wso2.com": {"Default", "us-region", "eu-region"}},
+			isExpError:     false,
+		},
+		{
+			name:           "Same vhost in multiple deployments of the same environment",
+			vhostToEnvsMap: map[string][]string{"wso2.com": {"Default", "us-region", "Default"}},
+			isExpError:     true,
+		},
+		{
+			name: "Same environment in deployments with different vhosts",
+			vhostToEnvsMap: map[string][]string{
+				"us.wso2.com": {"Default", "us-region"},
+				"eu.wso2.com": {"eu-region", "us-region"},
+			},
+			isExpError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateVhostToEnvsMap(test.vhostToEnvsMap)
+			if test.isExpError {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), "environment", "Error should name the overlapping environment")
+				}
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestParseAPITypeFilter(t *testing.T) {
+	tests := []struct {
+		query            string
+		expectedAPITypes []string
+		isError          bool
+	}{
+		{query: "type:http", expectedAPITypes: []string{"HTTP"}},
+		{query: "type:WS", expectedAPITypes: []string{"WS"}},
+		{query: "Type:http,ws", expectedAPITypes: []string{"HTTP", "WS"}},
+		{query: "type", isError: true},
+		{query: "type:", isError: true},
+		{query: "type:http,", isError: true},
+		{query: "kind:http", isError: true},
+	}
+	for _, test := range tests {
+		apiTypes, err := parseAPITypeFilter(test.query)
+		if test.isError {
+			assert.NotNil(t, err, "Query %q should be rejected", test.query)
+			continue
+		}
+		assert.Nil(t, err, "Query %q should be accepted", test.query)
+		assert.Equal(t, test.expectedAPITypes, apiTypes, "Unexpected API types of the query %q", test.query)
+	}
+
+	query := "type"
+	_, err := ListApis(&query, nil, "carbon.super")
+	assert.NotNil(t, err, "Query without the API type should be rejected")
+	apis, err := ListApis(nil, nil, "carbon.super")
+	assert.Nil(t, err, "APIs should be listed without a query")
+	assert.NotNil(t, apis)
+}
+
+// zipTestAPIProject zips the API project in the test-resources/apiprojects directory.
+func zipTestAPIProject(t *testing.T, projectName string) []byte {
+	projectsDir := filepath.FromSlash(config.GetMgwHome() + "/../adapter/test-resources/apiprojects")
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	err := filepath.Walk(filepath.Join(projectsDir, projectName), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		fileContent, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relativePath, _ := filepath.Rel(projectsDir, path)
+		fileWriter, err := zipWriter.Create(filepath.ToSlash(relativePath))
+		if err != nil {
+			return err
+		}
+		_, err = fileWriter.Write(fileContent)
+		return err
+	})
+	assert.Nil(t, err, "Error while zipping the test API project %v", projectName)
+	assert.Nil(t, zipWriter.Close(), "Error while zipping the test API project %v", projectName)
+	return buffer.Bytes()
+}
+
+func TestExtractAPIProjectWithMaxSize(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousMaxSize := conf.Adapter.MaxAPIProjectSizeInMB
+	defer func() {
+		conf.Adapter.MaxAPIProjectSizeInMB = previousMaxSize
+	}()
+	conf.Adapter.MaxAPIProjectSizeInMB = 1
+
+	payload := zipTestAPIProject(t, "petstore")
+	apiProject, err := extractAPIProject(payload)
+	assert.Nil(t, err, "API project within the maximum size should be accepted")
+	assert.Equal(t, "PetStore", apiProject.APIYaml.Data.Name)
+	readPayload, err := ReadAPIProject(bytes.NewReader(payload))
+	assert.Nil(t, err, "API project within the maximum size should be read")
+	assert.Equal(t, payload, readPayload)
+
+	oversizePayload := make([]byte, 1024*1024+1)
+	_, err = extractAPIProject(oversizePayload)
+	assert.Equal(t, ErrAPIProjectTooLarge, err, "API project exceeding the maximum size should be rejected")
+	_, err = ReadAPIProject(bytes.NewReader(oversizePayload))
+	assert.Equal(t, ErrAPIProjectTooLarge, err, "API project exceeding the maximum size should not be read")
+
+	conf.Adapter.MaxAPIProjectSizeInMB = 0
+	readPayload, err = ReadAPIProject(bytes.NewReader(oversizePayload))
+	assert.Nil(t, err, "API project of any size should be read when the maximum size is not set")
+	assert.Equal(t, len(oversizePayload), len(readPayload))
+}
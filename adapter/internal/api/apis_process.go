@@ -17,10 +17,12 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/vektah/gqlparser/v2"
@@ -40,8 +42,10 @@ const (
 	asyncAPIFilename           string = "asyncapi."
 	graphQLAPIFilename         string = "schema."
 	graphQLComplexityFileName  string = "graphql-complexity"
+	definitionReferenceFile    string = "definition_reference."
 	apiYAMLFile                string = "api.yaml"
 	deploymentsYAMLFile        string = "deployment_environments.yaml"
+	apiParamsYAMLFile          string = "api_params.yaml"
 	endpointCertFile           string = "endpoint_certificates."
 	clientCertFile             string = "client_certificates."
 	apiJSONFile                string = "api.json"
@@ -49,6 +53,7 @@ const (
 	clientCertDir              string = "Client-certificates"
 	interceptorCertDir         string = "Endpoint-certificates/interceptors"
 	policiesDir                string = "Policies"
+	sequencesDir               string = "Sequences"
 	policyDefFileExtension     string = ".gotmpl"
 	crtExtension               string = ".crt"
 	pemExtension               string = ".pem"
@@ -65,6 +70,17 @@ const (
 	jsonExt                    string = ".json"
 )
 
+// readCertificateBundle splits a PEM bundle of certificates and joins its certificates back in the same order, each
+// ending with a new line. Hence the full chain of a bundle is trusted by the router and the enforcer, while the
+// content other than the certificates (ex: the bag attributes of exported bundles) is dropped.
+func readCertificateBundle(fileContent []byte) ([]byte, error) {
+	certs, err := tlsutils.SplitPEMCertificates(fileContent)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Join(certs, nil), nil
+}
+
 // processFileInsideProject method process one file at a time and
 // update the apiProject instance appropriately. Files could be: /petstore,
 // /petstore/Definition, /petstore/Definition/swagger.yaml, /petstore/api.yaml, etc.
@@ -85,6 +101,21 @@ func processFileInsideProject(apiProject *model.ProjectAPI, fileContent []byte,
 		apiProject.Deployments = deployments
 	}
 
+	// Params file of apictl overriding the configurations per environment
+	if strings.Contains(fileName, apiParamsYAMLFile) {
+		loggers.LoggerAPI.Debug("Setting api_params of API")
+		apiParams, err := parseAPIParams(fileContent)
+		if err != nil {
+			loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error occurred while parsing the api_params: %v %v", fileName, err.Error()),
+				Severity:  logging.MINOR,
+				ErrorCode: 1241,
+			})
+			return err
+		}
+		apiProject.APIParams = apiParams
+	}
+
 	// API definition file
 	if strings.Contains(fileName, apiDefinitionDir+string(os.PathSeparator)+openAPIFilename) ||
 		strings.Contains(fileName, apiDefinitionDir+string(os.PathSeparator)+asyncAPIFilename) {
@@ -100,6 +131,28 @@ func processFileInsideProject(apiProject *model.ProjectAPI, fileContent []byte,
 			return conversionErr
 		}
 		apiProject.APIDefinition = swaggerJsn
+		// API definition referenced by URL
+	} else if strings.Contains(fileName, apiDefinitionDir+string(os.PathSeparator)+definitionReferenceFile) {
+		loggers.LoggerAPI.Debugf("API definition reference file : %v", fileName)
+		definition, err := readRemoteDefinitionReference(fileContent)
+		if err != nil {
+			loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while reading the API definition referenced in %v: %v", fileName, err.Error()),
+				Severity:  logging.MINOR,
+				ErrorCode: 1231,
+			})
+			return err
+		}
+		swaggerJsn, conversionErr := utills.ToJSON(definition)
+		if conversionErr != nil {
+			loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error converting the referenced api file to json: %v", conversionErr.Error()),
+				Severity:  logging.MINOR,
+				ErrorCode: 1213,
+			})
+			return conversionErr
+		}
+		apiProject.APIDefinition = swaggerJsn
 		// Interceptor certs
 	} else if strings.Contains(fileName, interceptorCertDir+string(os.PathSeparator)) &&
 		(strings.HasSuffix(fileName, crtExtension) || strings.HasSuffix(fileName, pemExtension)) {
@@ -151,7 +204,16 @@ func processFileInsideProject(apiProject *model.ProjectAPI, fileContent []byte,
 			}
 			if fileNameArray := strings.Split(fileName, string(os.PathSeparator)); len(fileNameArray) > 0 {
 				certFileName := fileNameArray[len(fileNameArray)-1]
-				apiProject.UpstreamCerts[certFileName] = fileContent
+				certChain, err := readCertificateBundle(fileContent)
+				if err != nil {
+					loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+						Message:   fmt.Sprintf("Error while reading the certificates of the bundle %v: %v", fileName, err.Error()),
+						Severity:  logging.MINOR,
+						ErrorCode: 1217,
+					})
+					return errors.New("certificate Validation Error")
+				}
+				apiProject.UpstreamCerts[certFileName] = certChain
 			}
 		}
 
@@ -179,7 +241,18 @@ func processFileInsideProject(apiProject *model.ProjectAPI, fileContent []byte,
 				})
 				return err
 			} else if clientCertificates != nil && len(clientCertificates.Data) > 0 {
+				aliases := make(map[string]struct{})
 				for _, val := range clientCertificates.Data {
+					if _, found := aliases[val.Alias]; found {
+						loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+							Message: fmt.Sprintf("Duplicate client certificate alias %v found for the API %s - %s:%s", val.Alias,
+								apiProject.APIYaml.Data.ID, apiProject.APIYaml.Data.Name, apiProject.APIYaml.Data.Version),
+							Severity:  logging.MINOR,
+							ErrorCode: 1232,
+						})
+						return fmt.Errorf("duplicate client certificate alias %v", val.Alias)
+					}
+					aliases[val.Alias] = struct{}{}
 					var certDetails model.CertificateDetails
 					certDetails.Alias = val.Alias
 					certDetails.Tier = val.TierName
@@ -199,7 +272,18 @@ func processFileInsideProject(apiProject *model.ProjectAPI, fileContent []byte,
 			}
 			if fileNameArray := strings.Split(fileName, string(os.PathSeparator)); len(fileNameArray) > 0 {
 				certFileName := fileNameArray[len(fileNameArray)-1]
-				apiProject.DownstreamCerts[certFileName] = fileContent
+				certChain, err := readCertificateBundle(fileContent)
+				if err != nil {
+					loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+						Message: fmt.Sprintf("Error while reading the certificates of the bundle %v for the API %s - %s:%s: %v",
+							fileName, apiProject.APIYaml.Data.ID, apiProject.APIYaml.Data.Name, apiProject.APIYaml.Data.Version,
+							err.Error()),
+						Severity:  logging.MINOR,
+						ErrorCode: 1224,
+					})
+					return errors.New("Error while validating the client certificate. " + err.Error())
+				}
+				apiProject.DownstreamCerts[certFileName] = certChain
 			}
 		}
 
@@ -299,11 +383,108 @@ func processFileInsideProject(apiProject *model.ProjectAPI, fileContent []byte,
 			return unmarshalErr
 		}
 		apiProject.GraphQLComplexities = gqlComplexityYaml
+		// Definition files referred from the API definition via $refs
+	} else if isBundledDefinitionFile(fileName) {
+		loggers.LoggerAPI.Debugf("Bundled definition file : %v", fileName)
+		definitionFileJSN, conversionErr := utills.ToJSON(fileContent)
+		if conversionErr != nil {
+			loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error converting the bundled definition file %v to json: %v", fileName, conversionErr.Error()),
+				Severity:  logging.MINOR,
+				ErrorCode: 1235,
+			})
+			return conversionErr
+		}
+		if apiProject.DefinitionFiles == nil {
+			apiProject.DefinitionFiles = make(map[string][]byte)
+		}
+		definitionDirPrefix := apiDefinitionDir + string(os.PathSeparator)
+		relativePath := fileName[strings.Index(fileName, definitionDirPrefix)+len(definitionDirPrefix):]
+		apiProject.DefinitionFiles[filepath.ToSlash(relativePath)] = definitionFileJSN
 	}
 
 	return nil
 }
 
+// isBundledDefinitionFile returns whether the file is a YAML or JSON file inside the Definitions directory, other
+// than the files of the API definition itself, which could be referred from the API definition via $refs.
+func isBundledDefinitionFile(fileName string) bool {
+	separator := string(os.PathSeparator)
+	if !strings.Contains(fileName, apiDefinitionDir+separator) ||
+		(!strings.HasSuffix(fileName, yamlExt) && !strings.HasSuffix(fileName, jsonExt)) {
+		return false
+	}
+	return !strings.Contains(fileName, apiDefinitionDir+separator+openAPIFilename) &&
+		!strings.Contains(fileName, apiDefinitionDir+separator+asyncAPIFilename) &&
+		!strings.Contains(fileName, apiDefinitionDir+separator+definitionReferenceFile) &&
+		!strings.Contains(fileName, apiDefinitionDir+separator+graphQLComplexityFileName) &&
+		!strings.Contains(fileName, apiYAMLFile) && !strings.Contains(fileName, apiJSONFile)
+}
+
+// isProjectFileProcessed returns whether the content of the file is used by processFileInsideProject, so that the
+// other files of the API project such as the documents and the images are skipped without being read into memory.
+func isProjectFileProcessed(fileName string) bool {
+	separator := string(os.PathSeparator)
+	isCertificate := strings.HasSuffix(fileName, crtExtension) || strings.HasSuffix(fileName, pemExtension)
+	switch {
+	case strings.Contains(fileName, deploymentsYAMLFile), strings.Contains(fileName, apiParamsYAMLFile):
+		return true
+	case strings.Contains(fileName, apiDefinitionDir+separator+openAPIFilename),
+		strings.Contains(fileName, apiDefinitionDir+separator+asyncAPIFilename),
+		strings.Contains(fileName, apiDefinitionDir+separator+definitionReferenceFile),
+		strings.Contains(fileName, apiDefinitionDir+separator+graphQLAPIFilename),
+		strings.Contains(fileName, apiDefinitionDir+separator+graphQLComplexityFileName),
+		isBundledDefinitionFile(fileName):
+		return true
+	case strings.Contains(fileName, endpointCertDir+separator):
+		// includes the interceptor certificates
+		return strings.Contains(fileName, endpointCertFile) || isCertificate
+	case strings.Contains(fileName, clientCertDir+separator):
+		return strings.Contains(fileName, clientCertFile) || isCertificate
+	case (strings.Contains(fileName, apiYAMLFile) || strings.Contains(fileName, apiJSONFile)) &&
+		!strings.Contains(fileName, apiDefinitionDir):
+		return true
+	case strings.Contains(fileName, policiesDir+separator):
+		return strings.HasSuffix(fileName, jsonExt) || strings.HasSuffix(fileName, yamlExt) ||
+			strings.HasSuffix(fileName, policyDefFileExtension)
+	}
+	return false
+}
+
+// recordUnsupportedProjectFile records the unsupported feature of a file of the API project which is not processed,
+// such as the mediation sequences exported from APIM.
+func recordUnsupportedProjectFile(apiProject *model.ProjectAPI, fileName string) {
+	separator := string(os.PathSeparator)
+	if strings.Contains(fileName, sequencesDir+separator) && !strings.HasSuffix(fileName, separator) {
+		apiProject.AddUnsupportedFeature(model.UnsupportedFeatureMediationSequence)
+	}
+}
+
+// recordSkippedProjectFile records a file of the API project skipped without being read, so that it is reported with
+// the provenance of the deployed API. The directories of the zipped API projects are not recorded.
+func recordSkippedProjectFile(apiProject *model.ProjectAPI, fileName string) {
+	if strings.HasSuffix(fileName, "/") {
+		return
+	}
+	apiProject.SkippedFiles = append(apiProject.SkippedFiles, filepath.ToSlash(fileName))
+}
+
+// parseAPIParams parses the api_params.yaml of apictl into the configurations overridden per environment.
+func parseAPIParams(data []byte) (map[string]model.APIParamsConfigs, error) {
+	apiParams := &model.APIParams{}
+	if err := yaml.Unmarshal(data, apiParams); err != nil {
+		return nil, err
+	}
+	paramsPerEnv := make(map[string]model.APIParamsConfigs, len(apiParams.Environments))
+	for _, environment := range apiParams.Environments {
+		if environment.Name == "" {
+			return nil, errors.New("environment name is not provided in api_params.yaml")
+		}
+		paramsPerEnv[environment.Name] = environment.Configs
+	}
+	return paramsPerEnv, nil
+}
+
 func parseDeployments(data []byte) ([]model.Deployment, error) {
 	// deployEnvsFromAPI represents deployments read from API Project
 	deployEnvsFromAPI := &model.DeploymentEnvironments{}
@@ -0,0 +1,148 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// ArtifactDecryptor decrypts the API projects wrapped in an encryption envelope. The format of the envelope is
+// identified by the magic header with which the envelope starts.
+type ArtifactDecryptor interface {
+	// MagicHeader returns the bytes with which the envelopes decrypted by the decryptor start
+	MagicHeader() []byte
+	// Decrypt returns the zipped API project wrapped in the envelope
+	Decrypt(envelope []byte) ([]byte, error)
+}
+
+// ArtifactDecryptionError is returned when an encrypted API project cannot be decrypted.
+type ArtifactDecryptionError struct {
+	Err error
+}
+
+func (err *ArtifactDecryptionError) Error() string {
+	return fmt.Sprintf("error while decrypting the API project. %v", err.Err)
+}
+
+// errArtifactDecryptionKeyNotFound is returned when an API project is encrypted, but the key is not configured.
+var errArtifactDecryptionKeyNotFound = errors.New("the API project is encrypted, but the decryption key is not " +
+	"configured in adapter.artifactEncryption")
+
+// aesGCMMagicHeader starts the envelopes of the API projects encrypted with AES-256-GCM. The envelope consists of
+// the magic header, the 12 byte nonce and the encrypted zip followed by the authentication tag. The magic header is
+// authenticated as the additional data.
+var aesGCMMagicHeader = []byte("cc-encryption/aes-256-gcm/v1\n")
+
+// artifactDecryptors are the decryptors of the supported envelope formats
+var artifactDecryptors = []ArtifactDecryptor{aesGCMArtifactDecryptor{}}
+
+// RegisterArtifactDecryptor adds the decryptor of an envelope format, so that the API projects wrapped in the
+// envelope are decrypted before those are extracted. This should be called before the API projects are deployed.
+func RegisterArtifactDecryptor(decryptor ArtifactDecryptor) {
+	artifactDecryptors = append(artifactDecryptors, decryptor)
+}
+
+// decryptAPIProject returns the zipped API project, decrypting it if it is wrapped in the envelope of one of the
+// decryptors. Plaintext API projects are returned as they are.
+func decryptAPIProject(payload []byte) ([]byte, error) {
+	for _, decryptor := range artifactDecryptors {
+		if !bytes.HasPrefix(payload, decryptor.MagicHeader()) {
+			continue
+		}
+		decryptedPayload, err := decryptor.Decrypt(payload)
+		if err != nil {
+			loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while decrypting the API project. %v", err.Error()),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1234,
+			})
+			return nil, &ArtifactDecryptionError{Err: err}
+		}
+		loggers.LoggerAPI.Debug("Encrypted API project is decrypted.")
+		return decryptedPayload, nil
+	}
+	return payload, nil
+}
+
+// aesGCMArtifactDecryptor decrypts the API projects encrypted with AES-256-GCM using the configured key.
+type aesGCMArtifactDecryptor struct{}
+
+func (aesGCMArtifactDecryptor) MagicHeader() []byte {
+	return aesGCMMagicHeader
+}
+
+func (aesGCMArtifactDecryptor) Decrypt(envelope []byte) ([]byte, error) {
+	key, err := getArtifactDecryptionKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := envelope[len(aesGCMMagicHeader):]
+	if len(encrypted) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, errors.New("the encrypted API project is truncated")
+	}
+	nonce, ciphertext := encrypted[:gcm.NonceSize()], encrypted[gcm.NonceSize():]
+	payload, err := gcm.Open(nil, nonce, ciphertext, aesGCMMagicHeader)
+	if err != nil {
+		return nil, errors.New("the API project is not encrypted with the configured key or it is corrupted")
+	}
+	return payload, nil
+}
+
+// getArtifactDecryptionKey returns the 256 bit AES key given in the adapter config, or in the file referred by the
+// config. The key is read for each API project, so that a rotated key of a mounted secret is picked up.
+func getArtifactDecryptionKey() ([]byte, error) {
+	conf, _ := config.ReadConfigs()
+	encodedKey := conf.Adapter.ArtifactEncryption.Key
+	if encodedKey == "" && conf.Adapter.ArtifactEncryption.KeyPath != "" {
+		keyFileContent, err := ioutil.ReadFile(conf.Adapter.ArtifactEncryption.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading the decryption key file. %v", err)
+		}
+		encodedKey = string(keyFileContent)
+	}
+	encodedKey = strings.TrimSpace(encodedKey)
+	if encodedKey == "" {
+		return nil, errArtifactDecryptionKeyNotFound
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("the decryption key is not base64 encoded. %v", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("the decryption key should be 256 bits, but it is %d bits", len(key)*8)
+	}
+	return key, nil
+}
@@ -0,0 +1,138 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+// generateArtifactEncryptionKey returns a random 256 bit key
+func generateArtifactEncryptionKey(t *testing.T) []byte {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	assert.Nil(t, err, "Error while generating the encryption key")
+	return key
+}
+
+// encryptAPIProject wraps the zipped API project in the AES-256-GCM envelope the same way the CI pipelines do.
+func encryptAPIProject(t *testing.T, key, payload []byte) []byte {
+	block, err := aes.NewCipher(key)
+	assert.Nil(t, err, "Error while creating the cipher")
+	gcm, err := cipher.NewGCM(block)
+	assert.Nil(t, err, "Error while creating the cipher")
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	assert.Nil(t, err, "Error while generating the nonce")
+	envelope := append(append([]byte{}, aesGCMMagicHeader...), nonce...)
+	return gcm.Seal(envelope, nonce, payload, aesGCMMagicHeader)
+}
+
+func TestExtractAPIProjectWithEncryption(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousEncryption := conf.Adapter.ArtifactEncryption
+	defer func() {
+		conf.Adapter.ArtifactEncryption = previousEncryption
+	}()
+	key := generateArtifactEncryptionKey(t)
+	payload := zipTestAPIProject(t, "petstore")
+	encryptedPayload := encryptAPIProject(t, key, payload)
+
+	// The key is given in the config
+	conf.Adapter.ArtifactEncryption.Key = base64.StdEncoding.EncodeToString(key)
+	conf.Adapter.ArtifactEncryption.KeyPath = ""
+	apiProject, err := extractAPIProject(encryptedPayload)
+	assert.Nil(t, err, "Encrypted API project should be decrypted with the configured key")
+	assert.Equal(t, "PetStore", apiProject.APIYaml.Data.Name)
+
+	apiProject, err = extractAPIProject(payload)
+	assert.Nil(t, err, "Plaintext API project should be extracted without decryption")
+	assert.Equal(t, "PetStore", apiProject.APIYaml.Data.Name)
+
+	// The key is given in a mounted file
+	keyFile := filepath.Join(t.TempDir(), "artifact-key")
+	err = ioutil.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600)
+	assert.Nil(t, err, "Error while writing the key file")
+	conf.Adapter.ArtifactEncryption.Key = ""
+	conf.Adapter.ArtifactEncryption.KeyPath = keyFile
+	apiProject, err = extractAPIProject(encryptedPayload)
+	assert.Nil(t, err, "Encrypted API project should be decrypted with the key in the key file")
+	assert.Equal(t, "PetStore", apiProject.APIYaml.Data.Name)
+
+	// The key is not configured
+	conf.Adapter.ArtifactEncryption.KeyPath = ""
+	_, err = extractAPIProject(encryptedPayload)
+	if assert.IsType(t, &ArtifactDecryptionError{}, err, "Encrypted API project should be rejected without the key") {
+		assert.Equal(t, errArtifactDecryptionKeyNotFound, err.(*ArtifactDecryptionError).Err)
+	}
+	_, err = extractAPIProject(payload)
+	assert.Nil(t, err, "Plaintext API project should be extracted without the key")
+
+	// The API project is encrypted with another key
+	conf.Adapter.ArtifactEncryption.Key = base64.StdEncoding.EncodeToString(generateArtifactEncryptionKey(t))
+	_, err = extractAPIProject(encryptedPayload)
+	assert.IsType(t, &ArtifactDecryptionError{}, err, "Encrypted API project should be rejected with another key")
+
+	// The envelope is tampered or truncated
+	conf.Adapter.ArtifactEncryption.Key = base64.StdEncoding.EncodeToString(key)
+	tamperedPayload := append([]byte{}, encryptedPayload...)
+	tamperedPayload[len(tamperedPayload)-1] ^= 1
+	_, err = extractAPIProject(tamperedPayload)
+	assert.IsType(t, &ArtifactDecryptionError{}, err, "Tampered API project should be rejected")
+	_, err = extractAPIProject(encryptedPayload[:len(aesGCMMagicHeader)+8])
+	assert.IsType(t, &ArtifactDecryptionError{}, err, "Truncated API project should be rejected")
+
+	// The key is not a 256 bit key
+	conf.Adapter.ArtifactEncryption.Key = base64.StdEncoding.EncodeToString(key[:16])
+	_, err = extractAPIProject(encryptedPayload)
+	if assert.IsType(t, &ArtifactDecryptionError{}, err, "Keys other than 256 bit keys should be rejected") {
+		assert.Contains(t, err.Error(), "256 bits")
+	}
+}
+
+func TestProcessMountedAPIProjectsWithEncryption(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousEncryption := conf.Adapter.ArtifactEncryption
+	previousArtifactsDir := conf.Adapter.ArtifactsDirectory
+	defer func() {
+		conf.Adapter.ArtifactEncryption = previousEncryption
+		conf.Adapter.ArtifactsDirectory = previousArtifactsDir
+	}()
+	artifactsDir := t.TempDir()
+	err := os.Mkdir(filepath.Join(artifactsDir, apisArtifactDir), 0700)
+	assert.Nil(t, err, "Error while creating the artifacts directory")
+	encryptedPayload := encryptAPIProject(t, generateArtifactEncryptionKey(t), zipTestAPIProject(t, "petstore"))
+	err = ioutil.WriteFile(filepath.Join(artifactsDir, apisArtifactDir, "petstore.zip"), encryptedPayload, 0600)
+	assert.Nil(t, err, "Error while writing the encrypted API project")
+	conf.Adapter.ArtifactsDirectory = artifactsDir
+
+	// Mounted API projects which cannot be decrypted are skipped
+	conf.Adapter.ArtifactEncryption.Key = ""
+	conf.Adapter.ArtifactEncryption.KeyPath = ""
+	artifactsMap, err := ProcessMountedAPIProjects()
+	assert.Nil(t, err, "Mounted API projects which cannot be decrypted should not fail the startup")
+	assert.Empty(t, artifactsMap, "Mounted API project which cannot be decrypted should not be deployed")
+}
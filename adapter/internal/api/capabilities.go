@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"runtime/debug"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	apiModel "github.com/wso2/product-microgateway/adapter/internal/api/models"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// Optional features of the adapter reported in the capabilities, so that the tooling can check whether the adapter
+// supports a feature before calling its operations.
+const (
+	// featureDeploy deploys and undeploys the APIs through the REST API, which is not available in read-only mode
+	featureDeploy = "deploy"
+	// featureTLSCertificates updates the TLS certificates of the vhosts, which is not available in read-only mode
+	featureTLSCertificates = "tlsCertificates"
+	// featureBulkStatus returns the deployment status of a list of API revisions
+	featureBulkStatus = "bulkStatus"
+	// featureProvenance returns the sources of the effective configuration of the deployed APIs
+	featureProvenance = "provenance"
+	// featureResourceUsage returns the resource usage of the router configurations
+	featureResourceUsage = "resourceUsage"
+	// featureRemoteDefinitions fetches the API definitions referenced by URL in the API projects
+	featureRemoteDefinitions = "remoteDefinitions"
+	// featureArtifactEncryption decrypts the encrypted API projects
+	featureArtifactEncryption = "artifactEncryption"
+)
+
+// adapterVersion is the version of the adapter build, set by the build (-ldflags "-X ...api.adapterVersion=1.3.0").
+// The version of the main module is used if it is not set.
+var adapterVersion string
+
+// unknownAdapterVersion is reported when the version of the adapter build is not known.
+const unknownAdapterVersion = "unknown"
+
+// GetCapabilities returns the version of the adapter, the supported api.yaml schema versions, the optional features
+// enabled by the adapter config and the limits applied to the API projects. The versions of the REST API are set by
+// the REST server.
+func GetCapabilities() *apiModel.Capabilities {
+	conf, _ := config.ReadConfigs()
+	return &apiModel.Capabilities{
+		Version:               getAdapterVersion(),
+		APIYamlSchemaVersions: model.GetSupportedAPIYamlSchemaVersions(),
+		Features:              getEnabledFeatures(conf),
+		Limits: &apiModel.CapabilityLimits{
+			MaxAPIProjectSizeInBytes: getMaxAPIProjectSize(),
+			MaxAPIsPerOrganization:   int64(conf.Adapter.APIQuota.DefaultMaxAPIs),
+		},
+	}
+}
+
+// getAdapterVersion returns the version set by the build, or else the version of the main module.
+func getAdapterVersion() string {
+	if adapterVersion != "" {
+		return adapterVersion
+	}
+	if buildInfo, ok := debug.ReadBuildInfo(); ok && buildInfo.Main.Version != "" &&
+		buildInfo.Main.Version != "(devel)" {
+		return buildInfo.Main.Version
+	}
+	return unknownAdapterVersion
+}
+
+// getEnabledFeatures returns the optional features enabled by the adapter config, in the order of their
+// declaration.
+func getEnabledFeatures(conf *config.Config) []string {
+	features := []string{}
+	if !config.IsReadOnlyMode() {
+		features = append(features, featureDeploy, featureTLSCertificates)
+	}
+	features = append(features, featureBulkStatus, featureProvenance)
+	if conf.Adapter.ResourceUsage.Enabled {
+		features = append(features, featureResourceUsage)
+	}
+	if conf.Adapter.RemoteDefinition.Enabled {
+		features = append(features, featureRemoteDefinitions)
+	}
+	if conf.Adapter.ArtifactEncryption.Key != "" || conf.Adapter.ArtifactEncryption.KeyPath != "" {
+		features = append(features, featureArtifactEncryption)
+	}
+	return features
+}
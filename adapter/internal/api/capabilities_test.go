@@ -0,0 +1,75 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+func TestGetCapabilities(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousAdapterConfig := conf.Adapter
+	previousReadOnlyMode := config.IsReadOnlyMode()
+	previousAdapterVersion := adapterVersion
+	defer func() {
+		conf.Adapter = previousAdapterConfig
+		config.SetReadOnlyMode(previousReadOnlyMode)
+		adapterVersion = previousAdapterVersion
+	}()
+
+	adapterVersion = "1.3.0"
+	config.SetReadOnlyMode(false)
+	conf.Adapter.ResourceUsage.Enabled = false
+	conf.Adapter.RemoteDefinition.Enabled = false
+	conf.Adapter.ArtifactEncryption.Key = ""
+	conf.Adapter.ArtifactEncryption.KeyPath = ""
+	conf.Adapter.MaxAPIProjectSizeInMB = 10
+	conf.Adapter.APIQuota.DefaultMaxAPIs = 0
+
+	capabilities := GetCapabilities()
+	assert.Equal(t, "1.3.0", capabilities.Version)
+	assert.Equal(t, []string{"v4"}, capabilities.APIYamlSchemaVersions)
+	assert.Equal(t, []string{featureDeploy, featureTLSCertificates, featureBulkStatus, featureProvenance},
+		capabilities.Features)
+	if assert.NotNil(t, capabilities.Limits) {
+		assert.Equal(t, int64(10*1024*1024), capabilities.Limits.MaxAPIProjectSizeInBytes)
+		assert.Equal(t, int64(0), capabilities.Limits.MaxAPIsPerOrganization, "APIs should not be limited")
+	}
+
+	config.SetReadOnlyMode(true)
+	conf.Adapter.ResourceUsage.Enabled = true
+	conf.Adapter.RemoteDefinition.Enabled = true
+	conf.Adapter.ArtifactEncryption.KeyPath = "/home/wso2/security/artifact-key"
+	conf.Adapter.MaxAPIProjectSizeInMB = 0
+	conf.Adapter.APIQuota.DefaultMaxAPIs = 50
+
+	capabilities = GetCapabilities()
+	assert.Equal(t, []string{featureBulkStatus, featureProvenance, featureResourceUsage, featureRemoteDefinitions,
+		featureArtifactEncryption}, capabilities.Features,
+		"Features enabled by the config should be included, excluding the features unavailable in read-only mode")
+	if assert.NotNil(t, capabilities.Limits) {
+		assert.Equal(t, int64(0), capabilities.Limits.MaxAPIProjectSizeInBytes, "Project size should not be limited")
+		assert.Equal(t, int64(50), capabilities.Limits.MaxAPIsPerOrganization)
+	}
+
+	adapterVersion = ""
+	assert.NotEmpty(t, GetCapabilities().Version, "Version should be reported even if it is not set by the build")
+}
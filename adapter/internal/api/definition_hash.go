@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// errAPIUnchanged is returned when an API project is redeployed with the same content it is already deployed with,
+// hence the deployment is skipped.
+var errAPIUnchanged = errors.New("API is already deployed with the same content")
+
+// definitionHasher computes the canonical hash of an API project from the files read while it is extracted.
+// Only the names of the files relative to the project root and their content are hashed, so that the volatile
+// metadata of the project such as the name of the root directory, the order of the files and the modification
+// times recorded in the zip file do not change the hash.
+type definitionHasher struct {
+	// file path relative to the project root -> SHA-256 digest of the file content
+	digests map[string][sha256.Size]byte
+}
+
+func newDefinitionHasher() *definitionHasher {
+	return &definitionHasher{digests: make(map[string][sha256.Size]byte)}
+}
+
+// addZipFile adds a file of a zipped API project, whose name starts with the root directory of the project.
+func (hasher *definitionHasher) addZipFile(fileName string, content []byte) {
+	fileName = filepath.ToSlash(fileName)
+	if i := strings.Index(fileName, "/"); i >= 0 {
+		fileName = fileName[i+1:]
+	}
+	hasher.digests[fileName] = sha256.Sum256(content)
+}
+
+// addMountedFile adds a file of an API project mounted as a directory in the given path.
+func (hasher *definitionHasher) addMountedFile(projectDir, path string, content []byte) {
+	fileName, err := filepath.Rel(projectDir, path)
+	if err != nil {
+		fileName = path
+	}
+	hasher.digests[filepath.ToSlash(fileName)] = sha256.Sum256(content)
+}
+
+// sum returns the hex encoded SHA-256 hash of the sorted file names along with the digests of their content.
+func (hasher *definitionHasher) sum() string {
+	fileNames := make([]string, 0, len(hasher.digests))
+	for fileName := range hasher.digests {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	hash := sha256.New()
+	for _, fileName := range fileNames {
+		digest := hasher.digests[fileName]
+		hash.Write([]byte(fileName))
+		hash.Write([]byte{0})
+		hash.Write(digest[:])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,125 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	xds "github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// rezipAPIProject zips the files of the zipped API project again in the reverse order under the given root
+// directory, with a new modification time. The content of the files are updated by the change function.
+func rezipAPIProject(t *testing.T, payload []byte, rootDir string,
+	change func(fileName string, content []byte) []byte) []byte {
+	zipReader, err := zip.NewReader(bytes.NewReader(payload), int64(len(payload)))
+	assert.Nil(t, err, "Error while reading the zipped API project")
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	for i := len(zipReader.File) - 1; i >= 0; i-- {
+		file := zipReader.File[i]
+		reader, err := file.Open()
+		assert.Nil(t, err, "Error while opening %v", file.Name)
+		content, err := ioutil.ReadAll(reader)
+		assert.Nil(t, err, "Error while reading %v", file.Name)
+		_ = reader.Close()
+		fileName := rootDir + file.Name[strings.Index(file.Name, "/"):]
+		fileWriter, err := zipWriter.CreateHeader(&zip.FileHeader{Name: fileName, Method: zip.Deflate,
+			Modified: time.Now()})
+		assert.Nil(t, err, "Error while adding %v", fileName)
+		_, err = fileWriter.Write(change(fileName, content))
+		assert.Nil(t, err, "Error while writing %v", fileName)
+	}
+	assert.Nil(t, zipWriter.Close(), "Error while zipping the API project")
+	return buffer.Bytes()
+}
+
+func TestDefinitionHashOfAPIProject(t *testing.T) {
+	payload := zipTestAPIProject(t, "petstore")
+	apiProject, err := extractAPIProject(payload)
+	assert.Nil(t, err, "Error while extracting the API project")
+	assert.Len(t, apiProject.DefinitionHash, 64, "Definition hash should be a hex encoded SHA-256 hash")
+
+	unchanged := func(fileName string, content []byte) []byte { return content }
+	rezippedProject, err := extractAPIProject(rezipAPIProject(t, payload, "petstore-copy", unchanged))
+	assert.Nil(t, err, "Error while extracting the rezipped API project")
+	assert.Equal(t, apiProject.DefinitionHash, rezippedProject.DefinitionHash,
+		"Root directory, order and modification times of the files should not change the hash")
+
+	changedProject, err := extractAPIProject(rezipAPIProject(t, payload, "petstore",
+		func(fileName string, content []byte) []byte {
+			if strings.HasSuffix(fileName, "swagger.yaml") {
+				return append(content, '\n')
+			}
+			return content
+		}))
+	assert.Nil(t, err, "Error while extracting the changed API project")
+	assert.NotEqual(t, apiProject.DefinitionHash, changedProject.DefinitionHash,
+		"A one byte change of a file should change the hash")
+}
+
+func TestRedeployAPIProjectWithSameContent(t *testing.T) {
+	payload := zipTestAPIProject(t, "petstore")
+	changedPayload := rezipAPIProject(t, payload, "petstore", func(fileName string, content []byte) []byte {
+		if strings.HasSuffix(fileName, "swagger.yaml") {
+			return append(content, '\n')
+		}
+		return content
+	})
+	vhost := "redeploy.wso2.com"
+	deploy := func(payload []byte, force bool) (model.ProjectAPI, error) {
+		apiProject, err := extractAPIProject(payload)
+		assert.Nil(t, err, "Error while extracting the API project")
+		apiProject.APIYaml.Data.ID = "redeploy-same-content"
+		apiProject.Deployments = []model.Deployment{{DeploymentVhost: vhost,
+			DeploymentEnvironment: config.DefaultGatewayName}}
+		override := true
+		apiProject, _, err = validateAndUpdateXds(apiProject, &override, force, false, false)
+		return apiProject, err
+	}
+
+	apiProject, err := deploy(payload, false)
+	assert.Nil(t, err, "Error while deploying the API")
+	apiInfo, isDeployed := GetAPI("redeploy-same-content")
+	if assert.True(t, isDeployed) {
+		assert.Equal(t, apiProject.DefinitionHash, apiInfo.DefinitionHash,
+			"Definition hash of the deployed API should be returned")
+	}
+
+	redeployedProject, err := deploy(payload, false)
+	assert.Equal(t, errAPIUnchanged, err, "Redeploying the same content should not update the API")
+	assert.Equal(t, "PetStore", redeployedProject.APIYaml.Data.Name)
+	_, err = deploy(payload, true)
+	assert.Nil(t, err, "Redeploying the same content with force should update the API")
+
+	changedProject, err := deploy(changedPayload, false)
+	assert.Nil(t, err, "Redeploying a changed API project should update the API")
+	apiInfo, _ = GetAPI("redeploy-same-content")
+	assert.Equal(t, changedProject.DefinitionHash, apiInfo.DefinitionHash,
+		"Definition hash of the latest deployment should be returned")
+
+	xds.DeleteAPIWithAPIMEvent("redeploy-same-content", apiProject.APIYaml.Data.OrganizationID,
+		[]string{config.DefaultGatewayName}, "")
+}
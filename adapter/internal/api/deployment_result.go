@@ -0,0 +1,101 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"time"
+
+	xds "github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// DeploymentOutcome classifies the result of applying an API project in the standalone mode.
+type DeploymentOutcome string
+
+const (
+	// DeploymentCreated is the outcome when the API did not exist in any of the vhosts it is deployed to.
+	DeploymentCreated DeploymentOutcome = "CREATED"
+	// DeploymentUpdated is the outcome when the API already existed in at least one of the vhosts it is deployed to.
+	DeploymentUpdated DeploymentOutcome = "UPDATED"
+	// DeploymentUnchanged is the outcome when the API is already deployed with the same content, hence the
+	// deployment is skipped.
+	DeploymentUnchanged DeploymentOutcome = "UNCHANGED"
+)
+
+// DeploymentResult is the result of applying an API project in the standalone mode. The details of the previous
+// deployment are given only if the API is updated or unchanged.
+type DeploymentResult struct {
+	Outcome DeploymentOutcome
+	// PreviousRevisionID is the revision the API was deployed with, empty if the API did not have a revision
+	PreviousRevisionID string
+	// PreviousDefinitionHash is the canonical hash of the API project the API was deployed from
+	PreviousDefinitionHash string
+	// PreviousDeployedTime is the time of the previous deployment of the API
+	PreviousDeployedTime time.Time
+}
+
+// getPreviousDeployment classifies deploying the API project as an update, along with the details of the previous
+// deployment, if the API is currently deployed in at least one of the vhosts of its deployments. Otherwise it is
+// classified as a creation.
+func getPreviousDeployment(apiProject model.ProjectAPI) DeploymentResult {
+	apiYaml := apiProject.APIYaml.Data
+	vhosts := make(map[string]struct{})
+	for _, deployment := range apiProject.Deployments {
+		if xds.IsAPIExist(deployment.DeploymentVhost, apiYaml.ID, apiYaml.Name, apiYaml.Version,
+			apiYaml.OrganizationID) {
+			vhosts[deployment.DeploymentVhost] = struct{}{}
+		}
+	}
+	if len(vhosts) == 0 {
+		return DeploymentResult{Outcome: DeploymentCreated}
+	}
+
+	apiID := apiYaml.ID
+	if apiID == "" {
+		apiID = xds.GenerateHashedAPINameVersionIDWithoutVhost(apiYaml.Name, apiYaml.Version)
+	}
+	result := DeploymentResult{Outcome: DeploymentUpdated}
+	for _, deployment := range xds.GetAPIDeploymentsInOrganization(apiID, apiYaml.OrganizationID) {
+		if _, found := vhosts[deployment.Vhost]; found {
+			result.PreviousRevisionID = deployment.RevisionID
+			result.PreviousDeployedTime = deployment.DeployedTime
+			break
+		}
+	}
+	if deployedAPI, isDeployed := xds.GetDeployedAPI(apiID); isDeployed {
+		result.PreviousDefinitionHash = deployedAPI.GetDefinitionHash()
+	}
+	return result
+}
+
+// applyAPIProject validates the API project and deploys it in the vhosts and environments of its deployments, and
+// classifies the deployment by whether the API already existed in those vhosts. The previous deployment is
+// captured before the API is deployed, hence an API deployed concurrently with the same name and version may be
+// classified as created.
+func applyAPIProject(apiProject model.ProjectAPI, override *bool, force bool, staged bool) (
+	model.ProjectAPI, DeploymentResult, error) {
+	setDefaultDeployment(&apiProject)
+	result := getPreviousDeployment(apiProject)
+	updatedAPIProject, _, err := validateAndUpdateXds(apiProject, override, force, false, staged)
+	if err == errAPIUnchanged {
+		result.Outcome = DeploymentUnchanged
+		return updatedAPIProject, result, nil
+	} else if err != nil {
+		return updatedAPIProject, DeploymentResult{}, err
+	}
+	return updatedAPIProject, result, nil
+}
@@ -0,0 +1,81 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	xds "github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func TestApplyAPIProjectDeploymentResult(t *testing.T) {
+	const apiID = "deployment-result"
+	payload := zipTestAPIProject(t, "petstore")
+	changedPayload := rezipAPIProject(t, payload, "petstore", func(fileName string, content []byte) []byte {
+		if strings.HasSuffix(fileName, "swagger.yaml") {
+			return append(content, '\n')
+		}
+		return content
+	})
+	deploy := func(payload []byte, vhost string) (model.ProjectAPI, DeploymentResult, error) {
+		apiProject, err := extractAPIProject(payload)
+		assert.Nil(t, err, "Error while extracting the API project")
+		apiProject.APIYaml.Data.ID = apiID
+		apiProject.Deployments = []model.Deployment{{DeploymentVhost: vhost,
+			DeploymentEnvironment: config.DefaultGatewayName}}
+		override := true
+		return applyAPIProject(apiProject, &override, false, false)
+	}
+
+	apiProject, result, err := deploy(payload, "deployment-result.wso2.com")
+	assert.Nil(t, err, "Error while deploying the API")
+	defer xds.DeleteAPIWithAPIMEvent(apiID, apiProject.APIYaml.Data.OrganizationID,
+		[]string{config.DefaultGatewayName}, "")
+	assert.Equal(t, DeploymentResult{Outcome: DeploymentCreated}, result,
+		"API which is not deployed should be created without the details of a previous deployment")
+
+	_, result, err = deploy(payload, "deployment-result.wso2.com")
+	assert.Nil(t, err, "Redeploying the same content should not be an error")
+	assert.Equal(t, DeploymentUnchanged, result.Outcome)
+	assert.Equal(t, apiProject.DefinitionHash, result.PreviousDefinitionHash,
+		"Definition hash of the current deployment should be given")
+
+	changedProject, result, err := deploy(changedPayload, "deployment-result.wso2.com")
+	assert.Nil(t, err, "Error while updating the API")
+	assert.Equal(t, DeploymentUpdated, result.Outcome)
+	assert.Equal(t, apiProject.DefinitionHash, result.PreviousDefinitionHash,
+		"Definition hash of the previous deployment should be given")
+	assert.NotEqual(t, changedProject.DefinitionHash, result.PreviousDefinitionHash)
+	assert.False(t, result.PreviousDeployedTime.IsZero(), "Time of the previous deployment should be given")
+
+	movedProject, result, err := deploy(payload, "deployment-result.other.wso2.com")
+	assert.Nil(t, err, "Error while deploying the API to another vhost")
+	assert.Equal(t, DeploymentCreated, result.Outcome,
+		"API deployed to a vhost in which it does not exist should be created")
+	assert.False(t, xds.IsAPIExist("deployment-result.wso2.com", apiID, apiProject.APIYaml.Data.Name,
+		apiProject.APIYaml.Data.Version, apiProject.APIYaml.Data.OrganizationID),
+		"API should be undeployed from the vhost which is not listed in its deployments")
+
+	override := false
+	_, result, err = applyAPIProject(movedProject, &override, false, false)
+	assert.NotNil(t, err, "Existing API should not be deployed without override")
+	assert.Equal(t, DeploymentResult{}, result, "Deployment should not be classified when it fails")
+}
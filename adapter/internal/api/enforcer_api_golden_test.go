@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	xds "github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser"
+	"github.com/wso2/product-microgateway/adapter/internal/testutil"
+	discoveryAPI "github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/api"
+)
+
+// TestEnforcerAPIGolden compares the API resources sent to the enforcer for the API projects in the
+// test-resources/apiprojects directory with the golden files in the test-resources/golden/enforcerapi directory.
+// Run the test with -update to regenerate the golden files after an intended change of the API resources.
+func TestEnforcerAPIGolden(t *testing.T) {
+	tests := []struct {
+		name        string
+		projectName string
+	}{
+		{
+			name:        "HTTP API with operation policies",
+			projectName: "petstore-policies",
+		},
+		{
+			name:        "WebSocket API",
+			projectName: "chat-websocket",
+		},
+		{
+			name:        "Mocked API",
+			projectName: "petstore-mocked",
+		},
+		{
+			name:        "HTTP API with mutual SSL",
+			projectName: "petstore-mtls",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apiProject := readTestAPIProject(t, test.projectName)
+			apiYaml := apiProject.APIYaml.Data
+			vhost := "golden.wso2.com"
+			_, err := applyAPIProjectToVhosts(apiProject, map[string][]string{vhost: {config.DefaultGatewayName}}, false)
+			if !assert.Nil(t, err, "Error while deploying the API") {
+				return
+			}
+			defer xds.DeleteAPIWithAPIMEvent(apiYaml.ID, apiYaml.OrganizationID, []string{config.DefaultGatewayName}, "")
+
+			mgwSwagger, isDeployed := xds.GetDeployedAPI(apiYaml.ID)
+			if !assert.True(t, isDeployed, "API should be deployed") {
+				return
+			}
+			enforcerAPI := oasparser.GetEnforcerAPI(mgwSwagger, vhost)
+			// the IDs of the resources are generated whenever the API definition is parsed
+			for _, resource := range enforcerAPI.GetResources() {
+				resource.Id = ""
+				for _, operation := range resource.GetMethods() {
+					sortMockedAPIConfig(operation.GetMockedApiConfig())
+				}
+			}
+			actual, err := testutil.CanonicalJSON(enforcerAPI)
+			if !assert.Nil(t, err, "Error while serializing the API resources") {
+				return
+			}
+			testutil.AssertGolden(t, filepath.FromSlash(config.GetMgwHome()+
+				"/../adapter/test-resources/golden/enforcerapi/"+test.projectName+".json"), actual)
+		})
+	}
+}
+
+// sortMockedAPIConfig sorts the mocked responses, which are read from the maps of the API definition in a random
+// order, by the response code, the content type, the example reference and the header name.
+func sortMockedAPIConfig(mockedAPIConfig *discoveryAPI.MockedApiConfig) {
+	responses := mockedAPIConfig.GetResponses()
+	sort.Slice(responses, func(i, j int) bool { return responses[i].Code < responses[j].Code })
+	for _, response := range responses {
+		sort.Slice(response.Content, func(i, j int) bool {
+			return response.Content[i].ContentType < response.Content[j].ContentType
+		})
+		for _, content := range response.Content {
+			sort.Slice(content.Examples, func(i, j int) bool { return content.Examples[i].Ref < content.Examples[j].Ref })
+		}
+		sort.Slice(response.Headers, func(i, j int) bool { return response.Headers[i].Name < response.Headers[j].Name })
+	}
+}
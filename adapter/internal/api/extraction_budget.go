@@ -0,0 +1,79 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+// ExtractedAPIProjectTooLargeError is returned when the files of an API project read into memory while it is
+// extracted exceed the configured maximum size.
+type ExtractedAPIProjectTooLargeError struct {
+	FileName string
+	MaxSize  int64
+}
+
+func (err *ExtractedAPIProjectTooLargeError) Error() string {
+	return fmt.Sprintf("the extracted files of the API project exceed the maximum size of %d bytes while reading %s",
+		err.MaxSize, err.FileName)
+}
+
+// extractionBudget accounts the size of the files of an API project read into memory while it is extracted, so
+// that the extraction is aborted before the files of a large API project are held in memory altogether.
+type extractionBudget struct {
+	// maxSize is the maximum size of the files in bytes, or 0 if the size is not limited
+	maxSize int64
+	used    int64
+}
+
+// newExtractionBudget returns the budget of extracting an API project, based on the adapter config.
+func newExtractionBudget() *extractionBudget {
+	conf, _ := config.ReadConfigs()
+	budget := &extractionBudget{}
+	if conf.Adapter.MaxExtractedAPIProjectSizeInMB > 0 {
+		budget.maxSize = int64(conf.Adapter.MaxExtractedAPIProjectSizeInMB) * 1024 * 1024
+	}
+	return budget
+}
+
+// readFile reads the file of the given size into memory and charges it to the budget. The file is rejected without
+// being read if its size exceeds the remaining budget. Otherwise it is read through a reader bounded by the
+// remaining budget, as the size given in a zip header may not match the size of the decompressed file.
+func (budget *extractionBudget) readFile(fileName string, reader io.Reader, size int64) ([]byte, error) {
+	if budget.maxSize <= 0 {
+		content, err := ioutil.ReadAll(reader)
+		budget.used += int64(len(content))
+		return content, err
+	}
+	remaining := budget.maxSize - budget.used
+	if size > remaining {
+		return nil, &ExtractedAPIProjectTooLargeError{FileName: fileName, MaxSize: budget.maxSize}
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(reader, remaining+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > remaining {
+		return nil, &ExtractedAPIProjectTooLargeError{FileName: fileName, MaxSize: budget.maxSize}
+	}
+	budget.used += int64(len(content))
+	return content, nil
+}
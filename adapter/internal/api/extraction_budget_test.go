@@ -0,0 +1,179 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+const syntheticEntrySize int64 = 200 * 1024 * 1024
+
+// zeroReader returns an endless stream of zeros
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+// countingReader counts the bytes read from the underlying reader
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (reader *countingReader) Read(p []byte) (int, error) {
+	n, err := reader.reader.Read(p)
+	reader.count += int64(n)
+	return n, err
+}
+
+// zipTestAPIProjectWithEntry zips the test API project along with an entry of the given size, which is streamed
+// into the zip without being held in memory.
+func zipTestAPIProjectWithEntry(t *testing.T, projectName, entryName string, entrySize int64) []byte {
+	project := zipTestAPIProject(t, projectName)
+	projectReader, err := zip.NewReader(bytes.NewReader(project), int64(len(project)))
+	assert.Nil(t, err, "Error while reading the zipped test API project")
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	for _, file := range projectReader.File {
+		assert.Nil(t, zipWriter.Copy(file), "Error while copying %v", file.Name)
+	}
+	entryWriter, err := zipWriter.CreateHeader(&zip.FileHeader{Name: entryName, Method: zip.Deflate})
+	assert.Nil(t, err, "Error while adding %v", entryName)
+	_, err = io.Copy(entryWriter, io.LimitReader(zeroReader{}, entrySize))
+	assert.Nil(t, err, "Error while writing %v", entryName)
+	assert.Nil(t, zipWriter.Close(), "Error while zipping the test API project %v", projectName)
+	return buffer.Bytes()
+}
+
+// allocatedBytes returns the bytes allocated by the function
+func allocatedBytes(function func()) uint64 {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	function()
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc
+}
+
+func TestExtractAPIProjectWithLargeEntries(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousMaxExtractedSize := conf.Adapter.MaxExtractedAPIProjectSizeInMB
+	defer func() {
+		conf.Adapter.MaxExtractedAPIProjectSizeInMB = previousMaxExtractedSize
+	}()
+	conf.Adapter.MaxExtractedAPIProjectSizeInMB = 4
+	const maxAllocatedBytes uint64 = 32 * 1024 * 1024
+
+	// Files which are not used by the adapter are skipped without being read
+	payload := zipTestAPIProjectWithEntry(t, "petstore", "petstore/Docs/FileContents/guide.pdf", syntheticEntrySize)
+	allocated := allocatedBytes(func() {
+		apiProject, err := extractAPIProject(payload)
+		assert.Nil(t, err, "Large documents of the API project should be skipped")
+		assert.Equal(t, "PetStore", apiProject.APIYaml.Data.Name)
+	})
+	assert.Less(t, allocated, maxAllocatedBytes, "Large documents of the API project should not be read into memory")
+
+	// Extraction is aborted once the files used by the adapter exceed the maximum size
+	payload = zipTestAPIProjectWithEntry(t, "petstore", "petstore/Endpoint-certificates/backend.pem",
+		syntheticEntrySize)
+	allocated = allocatedBytes(func() {
+		_, err := extractAPIProject(payload)
+		if assert.IsType(t, &ExtractedAPIProjectTooLargeError{}, err, "Large API project should be rejected") {
+			assert.Equal(t, "petstore/Endpoint-certificates/backend.pem", err.(*ExtractedAPIProjectTooLargeError).FileName)
+			assert.Equal(t, int64(4*1024*1024), err.(*ExtractedAPIProjectTooLargeError).MaxSize)
+		}
+	})
+	assert.Less(t, allocated, maxAllocatedBytes, "Large files of the API project should not be read into memory")
+}
+
+func TestExtractionBudgetReadFile(t *testing.T) {
+	const maxSize int64 = 4 * 1024 * 1024
+
+	// The size given in the zip header is smaller than the actual size of the file
+	budget := &extractionBudget{maxSize: maxSize}
+	reader := &countingReader{reader: io.LimitReader(zeroReader{}, syntheticEntrySize)}
+	_, err := budget.readFile("Definitions/swagger.yaml", reader, 1024)
+	assert.IsType(t, &ExtractedAPIProjectTooLargeError{}, err, "Files exceeding the budget should be rejected")
+	assert.LessOrEqual(t, reader.count, maxSize+1, "Files should not be read beyond the budget")
+	assert.Equal(t, int64(0), budget.used, "Rejected files should not be charged to the budget")
+
+	// The files are charged to the budget until it is exhausted
+	for i := 0; i < 4; i++ {
+		content, err := budget.readFile("Policies/policy.gotmpl", io.LimitReader(zeroReader{}, 1024*1024), 1024*1024)
+		assert.Nil(t, err, "Files within the budget should be read")
+		assert.Equal(t, 1024*1024, len(content))
+	}
+	assert.Equal(t, maxSize, budget.used)
+	reader = &countingReader{reader: io.LimitReader(zeroReader{}, 1)}
+	_, err = budget.readFile("api.yaml", reader, 1)
+	assert.IsType(t, &ExtractedAPIProjectTooLargeError{}, err, "Files exceeding the budget should be rejected")
+	assert.Equal(t, int64(0), reader.count, "Files exceeding the budget by their size should not be read")
+
+	// The files are read regardless of the size when the size is not limited
+	budget = &extractionBudget{}
+	content, err := budget.readFile("api.yaml", io.LimitReader(zeroReader{}, maxSize+1), maxSize+1)
+	assert.Nil(t, err, "Files should be read when the size is not limited")
+	assert.Equal(t, int(maxSize+1), len(content))
+	assert.Equal(t, maxSize+1, budget.used)
+}
+
+func TestIsProjectFileProcessed(t *testing.T) {
+	processedFiles := []string{
+		"petstore/api.yaml",
+		"petstore/api.json",
+		"petstore/deployment_environments.yaml",
+		"petstore/Definitions/swagger.yaml",
+		"petstore/Definitions/asyncapi.json",
+		"petstore/Definitions/definition_reference.yaml",
+		"petstore/Definitions/schema.graphql",
+		"petstore/Definitions/graphql-complexity.yaml",
+		"petstore/Definitions/schemas/pet.yaml",
+		"petstore/Endpoint-certificates/endpoint_certificates.yaml",
+		"petstore/Endpoint-certificates/backend.crt",
+		"petstore/Endpoint-certificates/interceptors/interceptor.pem",
+		"petstore/Client-certificates/client_certificates.yaml",
+		"petstore/Client-certificates/client.pem",
+		"petstore/Policies/addHeader_v1.yaml",
+		"petstore/Policies/addHeader_v1.gotmpl",
+	}
+	for _, fileName := range processedFiles {
+		assert.True(t, isProjectFileProcessed(fileName), "%v should be processed", fileName)
+	}
+	skippedFiles := []string{
+		"petstore/Docs/FileContents/guide.pdf",
+		"petstore/Docs/docs.yaml",
+		"petstore/Image/icon.png",
+		"petstore/WSDL/petstore.wsdl",
+		"petstore/Definitions/api.yaml",
+		"petstore/Endpoint-certificates/README.md",
+		"petstore/Policies/README.md",
+		"petstore/meta-information/api_meta.yaml",
+	}
+	for _, fileName := range skippedFiles {
+		assert.False(t, isProjectFileProcessed(fileName), "%v should be skipped", fileName)
+	}
+}
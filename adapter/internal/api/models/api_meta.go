@@ -40,6 +40,9 @@ type APIMeta struct {
 	// All or sub set of info about APIs in the MGW
 	List []*APIMetaListItem `json:"list"`
 
+	// quota
+	Quota *APIQuota `json:"quota,omitempty"`
+
 	// Total number of APIs available in the MGW
 	Total int64 `json:"total,omitempty"`
 }
@@ -52,6 +55,10 @@ func (m *APIMeta) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateQuota(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -82,6 +89,23 @@ func (m *APIMeta) validateList(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *APIMeta) validateQuota(formats strfmt.Registry) error {
+	if swag.IsZero(m.Quota) { // not required
+		return nil
+	}
+
+	if m.Quota != nil {
+		if err := m.Quota.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("quota")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ContextValidate validate this API meta based on the context it is used
 func (m *APIMeta) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
@@ -90,6 +114,10 @@ func (m *APIMeta) ContextValidate(ctx context.Context, formats strfmt.Registry)
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateQuota(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -114,6 +142,20 @@ func (m *APIMeta) contextValidateList(ctx context.Context, formats strfmt.Regist
 	return nil
 }
 
+func (m *APIMeta) contextValidateQuota(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Quota != nil {
+		if err := m.Quota.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("quota")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *APIMeta) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -41,9 +41,18 @@ type APIMetaListItem struct {
 	// context
 	Context string `json:"context,omitempty"`
 
+	// Additional contexts of the API set via x-wso2-context-aliases
+	ContextAliases []string `json:"contextAliases"`
+
+	// Operations excluded on the gateway labels of this adapter via x-wso2-exclude-on-gateways
+	ExcludedOperations []string `json:"excludedOperations"`
+
 	// gateway envs
 	GatewayEnvs []string `json:"gateway-envs"`
 
+	// Whether the requests to the API are allowed without validating the subscriptions
+	SubscriptionValidationDisabled bool `json:"subscriptionValidationDisabled,omitempty"`
+
 	// version
 	Version string `json:"version,omitempty"`
 
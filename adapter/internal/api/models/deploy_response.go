@@ -22,7 +22,9 @@ package models
 
 import (
 	"context"
+	"strconv"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -35,17 +37,142 @@ type DeployResponse struct {
 	// action
 	Action string `json:"action,omitempty"`
 
+	// Vhosts and environments in which the API is deployed as a result of the update strategy
+	Deployments []*APIDeployment `json:"deployments"`
+
 	// info
 	Info string `json:"info,omitempty"`
+
+	// Canonical hash of the API project the API was deployed from before it is updated
+	PreviousDefinitionHash string `json:"previousDefinitionHash,omitempty"`
+
+	// Revision the API was deployed with before it is updated, if the API had a revision
+	PreviousRevisionID string `json:"previousRevisionId,omitempty"`
+
+	// Update strategy applied to the deployments of the API
+	UpdateStrategy string `json:"updateStrategy,omitempty"`
+
+	// Warnings reported while parsing the API definition of the deployed API
+	Warnings []*ParseWarning `json:"warnings"`
 }
 
 // Validate validates this deploy response
 func (m *DeployResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDeployments(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateWarnings(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DeployResponse) validateDeployments(formats strfmt.Registry) error {
+	if swag.IsZero(m.Deployments) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Deployments); i++ {
+		if swag.IsZero(m.Deployments[i]) { // not required
+			continue
+		}
+
+		if m.Deployments[i] != nil {
+			if err := m.Deployments[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("deployments" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
-// ContextValidate validates this deploy response based on context it is used
+func (m *DeployResponse) validateWarnings(formats strfmt.Registry) error {
+	if swag.IsZero(m.Warnings) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Warnings); i++ {
+		if swag.IsZero(m.Warnings[i]) { // not required
+			continue
+		}
+
+		if m.Warnings[i] != nil {
+			if err := m.Warnings[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("warnings" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this deploy response based on the context it is used
 func (m *DeployResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateDeployments(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateWarnings(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DeployResponse) contextValidateDeployments(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Deployments); i++ {
+
+		if m.Deployments[i] != nil {
+			if err := m.Deployments[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("deployments" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *DeployResponse) contextValidateWarnings(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Warnings); i++ {
+
+		if m.Warnings[i] != nil {
+			if err := m.Warnings[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("warnings" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
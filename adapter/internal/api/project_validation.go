@@ -0,0 +1,102 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// ProjectValidationResult is the result of validating an API project mounted in the artifacts directory.
+type ProjectValidationResult struct {
+	// ProjectName is the name of the directory or the zip file of the API project
+	ProjectName string
+	// APIName and APIVersion are given by the api.yaml, if it could be read
+	APIName    string
+	APIVersion string
+	// Error is the reason the API project is invalid, or nil if it is valid
+	Error error
+}
+
+// IsValid returns true if the API project can be applied.
+func (result ProjectValidationResult) IsValid() bool {
+	return result.Error == nil
+}
+
+// ValidateMountedAPIProjects validates the API projects in the api artifacts directory the same way those are
+// validated during the startup (i.e. extraction, API type and mandatory fields), without applying those to the xDS
+// caches. Hence the API projects can be validated before restarting the adapter. The results are in the order of
+// the names of the API projects.
+func ValidateMountedAPIProjects() (results []ProjectValidationResult) {
+	conf, _ := config.ReadConfigs()
+	apisDirName := filepath.FromSlash(conf.Adapter.ArtifactsDirectory + "/" + apisArtifactDir)
+	files, err := ioutil.ReadDir(apisDirName)
+	if err != nil {
+		loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while reading API artifacts to validate. %v", err.Error()),
+			Severity:  logging.MINOR,
+			ErrorCode: 1240,
+		})
+		return nil
+	}
+
+	for _, apiProjectFile := range files {
+		// Ignore dot files and the files other than zip files, as those are not applied during the startup
+		if strings.HasPrefix(apiProjectFile.Name(), ".") ||
+			(!apiProjectFile.IsDir() && !strings.HasSuffix(apiProjectFile.Name(), zipExt)) {
+			continue
+		}
+		projectPath := filepath.FromSlash(apisDirName + "/" + apiProjectFile.Name())
+		apiProject, err := readAndValidateMountedAPIProject(projectPath, apiProjectFile.IsDir())
+		result := ProjectValidationResult{
+			ProjectName: apiProjectFile.Name(),
+			APIName:     apiProject.APIYaml.Data.Name,
+			APIVersion:  apiProject.APIYaml.Data.Version,
+			Error:       err,
+		}
+		if err != nil {
+			loggers.LoggerAPI.Warnf("Mounted API project %v is invalid. %v", apiProjectFile.Name(), err)
+		} else {
+			loggers.LoggerAPI.Debugf("Mounted API project %v is valid.", apiProjectFile.Name())
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// readAndValidateMountedAPIProject reads and validates the API project mounted as a directory or a zip file.
+func readAndValidateMountedAPIProject(projectPath string, isDir bool) (model.ProjectAPI, error) {
+	if !isDir {
+		data, err := ioutil.ReadFile(projectPath)
+		if err != nil {
+			return model.ProjectAPI{}, err
+		}
+		return extractAPIProject(data)
+	}
+	apiProject, err := readMountedAPIProject(projectPath)
+	if err != nil {
+		return apiProject, err
+	}
+	return apiProject, validateExtractedAPIProject(apiProject)
+}
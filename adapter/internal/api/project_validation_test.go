@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	xds "github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+)
+
+func TestValidateMountedAPIProjects(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousArtifactsDir := conf.Adapter.ArtifactsDirectory
+	defer func() {
+		conf.Adapter.ArtifactsDirectory = previousArtifactsDir
+	}()
+	artifactsDir := t.TempDir()
+	apisDir := filepath.Join(artifactsDir, apisArtifactDir)
+	conf.Adapter.ArtifactsDirectory = artifactsDir
+
+	petstoreDir := filepath.FromSlash(config.GetMgwHome() + "/../adapter/test-resources/apiprojects/petstore")
+	apiYaml, err := ioutil.ReadFile(filepath.Join(petstoreDir, "api.yaml"))
+	assert.Nil(t, err, "Error while reading the api.yaml of the test API project")
+	definition, err := ioutil.ReadFile(filepath.Join(petstoreDir, "Definitions", "swagger.yaml"))
+	assert.Nil(t, err, "Error while reading the API definition of the test API project")
+	writeProjectFile := func(path string, content []byte) {
+		path = filepath.Join(apisDir, filepath.FromSlash(path))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0700), "Error while creating the API project directory")
+		assert.Nil(t, ioutil.WriteFile(path, content, 0600), "Error while writing the API project file %v", path)
+	}
+
+	const apiID = "mounted-project-validation"
+	writeProjectFile("petstore/api.yaml", []byte(strings.Replace(string(apiYaml),
+		"id: 9a6e5e9c-1b8a-4f7a-9d8e-3a3f4c2d1e10", "id: "+apiID, 1)))
+	writeProjectFile("petstore/Definitions/swagger.yaml", definition)
+	writeProjectFile("petstore.zip", zipTestAPIProject(t, "petstore"))
+	// api.yaml without the context, which is mandatory
+	var withoutContext []string
+	for _, line := range strings.Split(string(apiYaml), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "context:") {
+			withoutContext = append(withoutContext, line)
+		}
+	}
+	writeProjectFile("without-context/api.yaml", []byte(strings.Join(withoutContext, "\n")))
+	writeProjectFile("without-context/Definitions/swagger.yaml", definition)
+	writeProjectFile("without-api-yaml/Definitions/swagger.yaml", definition)
+	writeProjectFile("corrupted.zip", []byte("not a zip file"))
+	// files which are not applied during the startup are not validated
+	writeProjectFile(".hidden.zip", []byte("not a zip file"))
+	writeProjectFile("README.md", []byte("API projects"))
+
+	results := ValidateMountedAPIProjects()
+	if !assert.Len(t, results, 5, "All the mounted API projects should be validated") {
+		return
+	}
+	expectedResults := []struct {
+		projectName string
+		valid       bool
+	}{
+		{"corrupted.zip", false},
+		{"petstore", true},
+		{"petstore.zip", true},
+		{"without-api-yaml", false},
+		{"without-context", false},
+	}
+	for i, expected := range expectedResults {
+		assert.Equal(t, expected.projectName, results[i].ProjectName)
+		assert.Equal(t, expected.valid, results[i].IsValid(), "Unexpected validation result of %v. %v",
+			expected.projectName, results[i].Error)
+	}
+	assert.Equal(t, "PetStore", results[1].APIName, "API name should be given for the valid API projects")
+	assert.Equal(t, "1.0.0", results[1].APIVersion)
+	assert.NotNil(t, results[4].Error, "Reason should be given for the invalid API projects")
+
+	defaultVhost, _, _ := config.GetDefaultVhost(config.DefaultGatewayName)
+	assert.False(t, xds.IsAPIExist(defaultVhost, apiID, results[1].APIName, results[1].APIVersion, "carbon.super"),
+		"Mounted API projects should not be applied while validating")
+}
@@ -0,0 +1,135 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"gopkg.in/yaml.v2"
+)
+
+// remoteDefinitionReference is the descriptor (Definitions/definition_reference.yaml) of an API definition
+// which is not bundled in the API project, but fetched from a remote URL.
+type remoteDefinitionReference struct {
+	URL string `yaml:"url"`
+}
+
+type cachedRemoteDefinition struct {
+	content   []byte
+	fetchedAt time.Time
+}
+
+var (
+	// URL -> fetched API definition. The definitions are evicted once the cache TTL has passed.
+	remoteDefinitionCache         = make(map[string]cachedRemoteDefinition)
+	mutexForRemoteDefinitionCache sync.Mutex
+)
+
+// readRemoteDefinitionReference parses the definition reference descriptor and fetches the referenced API definition.
+func readRemoteDefinitionReference(fileContent []byte) ([]byte, error) {
+	conf, _ := config.ReadConfigs()
+	if !conf.Adapter.RemoteDefinition.Enabled {
+		return nil, errors.New("fetching API definitions from remote URLs is disabled. " +
+			"Enable adapter.remoteDefinition to use definition references")
+	}
+	var reference remoteDefinitionReference
+	if err := yaml.Unmarshal(fileContent, &reference); err != nil {
+		return nil, fmt.Errorf("error while parsing the definition reference: %v", err)
+	}
+	parsedURL, err := url.Parse(reference.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return nil, fmt.Errorf("invalid API definition URL %q. An absolute http or https URL is expected", reference.URL)
+	}
+	return fetchRemoteDefinition(reference.URL)
+}
+
+// fetchRemoteDefinition returns the API definition at the URL. The definitions fetched within the configured
+// cache TTL are reused.
+func fetchRemoteDefinition(definitionURL string) ([]byte, error) {
+	conf, _ := config.ReadConfigs()
+	remoteDefConf := conf.Adapter.RemoteDefinition
+	cacheTTL := time.Duration(remoteDefConf.CacheTTLInSeconds) * time.Second
+
+	mutexForRemoteDefinitionCache.Lock()
+	cached, found := remoteDefinitionCache[definitionURL]
+	mutexForRemoteDefinitionCache.Unlock()
+	if found && time.Since(cached.fetchedAt) < cacheTTL {
+		loggers.LoggerAPI.Debugf("Using the cached API definition of %v", definitionURL)
+		return cached.content, nil
+	}
+
+	loggers.LoggerAPI.Infof("Fetching the API definition from %v", definitionURL)
+	client := &http.Client{Timeout: time.Duration(remoteDefConf.RequestTimeoutInSeconds) * time.Second}
+	resp, err := client.Get(definitionURL)
+	if err != nil {
+		loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while fetching the API definition from %v: %v", definitionURL, err.Error()),
+			Severity:  logging.MINOR,
+			ErrorCode: 1229,
+		})
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error while fetching the API definition from %v. Status code: %v",
+			definitionURL, resp.StatusCode)
+	}
+	// read one byte more than the limit to identify the definitions exceeding the limit
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, remoteDefConf.MaxSizeInBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > remoteDefConf.MaxSizeInBytes {
+		loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("API definition at %v exceeds the maximum size of %d bytes", definitionURL,
+				remoteDefConf.MaxSizeInBytes),
+			Severity:  logging.MINOR,
+			ErrorCode: 1230,
+		})
+		return nil, fmt.Errorf("API definition at %v exceeds the maximum size of %d bytes", definitionURL,
+			remoteDefConf.MaxSizeInBytes)
+	}
+
+	cacheRemoteDefinition(definitionURL, content, cacheTTL)
+	return content, nil
+}
+
+// cacheRemoteDefinition caches the API definition fetched from the URL, and evicts the definitions whose cache TTL
+// has passed. Hence the cache holds only the definitions fetched within the TTL, instead of the definitions of all
+// the URLs referred since the adapter was started.
+func cacheRemoteDefinition(definitionURL string, content []byte, cacheTTL time.Duration) {
+	mutexForRemoteDefinitionCache.Lock()
+	defer mutexForRemoteDefinitionCache.Unlock()
+	for cachedURL, cached := range remoteDefinitionCache {
+		if time.Since(cached.fetchedAt) >= cacheTTL {
+			delete(remoteDefinitionCache, cachedURL)
+		}
+	}
+	if cacheTTL > 0 {
+		remoteDefinitionCache[definitionURL] = cachedRemoteDefinition{content: content, fetchedAt: time.Now()}
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/utills"
+)
+
+const remotePetstoreDefinition = `openapi: 3.0.1
+info:
+  title: PetStore
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+`
+
+func TestProcessDefinitionReference(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		switch r.URL.Path {
+		case "/petstore.yaml":
+			_, _ = w.Write([]byte(remotePetstoreDefinition))
+		case "/large.yaml":
+			_, _ = w.Write(make([]byte, 2048))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	conf, _ := config.ReadConfigs()
+	previousConf := conf.Adapter.RemoteDefinition
+	defer func() {
+		conf.Adapter.RemoteDefinition = previousConf
+	}()
+	referenceFileName := filepath.FromSlash("petstore/Definitions/definition_reference.yaml")
+	petstoreReference := []byte("url: " + server.URL + "/petstore.yaml")
+
+	conf.Adapter.RemoteDefinition.Enabled = false
+	apiProject := model.ProjectAPI{}
+	err := processFileInsideProject(&apiProject, petstoreReference, referenceFileName)
+	assert.NotNil(t, err, "Definition reference should not be fetched when it is not enabled")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requestCount))
+
+	conf.Adapter.RemoteDefinition.Enabled = true
+	conf.Adapter.RemoteDefinition.RequestTimeoutInSeconds = 5
+	conf.Adapter.RemoteDefinition.MaxSizeInBytes = 1024
+	conf.Adapter.RemoteDefinition.CacheTTLInSeconds = 300
+	err = processFileInsideProject(&apiProject, petstoreReference, referenceFileName)
+	assert.Nil(t, err, "Error while processing the definition reference")
+	expectedDefinition, _ := utills.ToJSON([]byte(remotePetstoreDefinition))
+	assert.Equal(t, expectedDefinition, apiProject.APIDefinition)
+
+	// the second read should be served from the cache
+	anotherAPIProject := model.ProjectAPI{}
+	err = processFileInsideProject(&anotherAPIProject, petstoreReference, referenceFileName)
+	assert.Nil(t, err, "Error while processing the cached definition reference")
+	assert.Equal(t, expectedDefinition, anotherAPIProject.APIDefinition)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requestCount), "Definition should be fetched only once")
+
+	err = processFileInsideProject(&model.ProjectAPI{}, []byte("url: "+server.URL+"/large.yaml"), referenceFileName)
+	assert.NotNil(t, err, "Definitions exceeding the maximum size should be rejected")
+
+	err = processFileInsideProject(&model.ProjectAPI{}, []byte("url: "+server.URL+"/missing.yaml"), referenceFileName)
+	assert.NotNil(t, err, "Error should be returned when the definition is not found")
+
+	err = processFileInsideProject(&model.ProjectAPI{}, []byte("url: file:///etc/passwd"), referenceFileName)
+	assert.NotNil(t, err, "Only http and https URLs should be accepted")
+}
+
+func TestCacheRemoteDefinition(t *testing.T) {
+	mutexForRemoteDefinitionCache.Lock()
+	previousCache := remoteDefinitionCache
+	remoteDefinitionCache = map[string]cachedRemoteDefinition{
+		"https://example.com/expired.yaml": {content: []byte("expired"), fetchedAt: time.Now().Add(-time.Hour)},
+		"https://example.com/valid.yaml":   {content: []byte("valid"), fetchedAt: time.Now()},
+	}
+	mutexForRemoteDefinitionCache.Unlock()
+	defer func() {
+		mutexForRemoteDefinitionCache.Lock()
+		remoteDefinitionCache = previousCache
+		mutexForRemoteDefinitionCache.Unlock()
+	}()
+
+	cacheRemoteDefinition("https://example.com/petstore.yaml", []byte(remotePetstoreDefinition), 5*time.Minute)
+	assert.NotContains(t, remoteDefinitionCache, "https://example.com/expired.yaml",
+		"Definitions should be evicted once the cache TTL has passed")
+	assert.Contains(t, remoteDefinitionCache, "https://example.com/valid.yaml")
+	assert.Contains(t, remoteDefinitionCache, "https://example.com/petstore.yaml")
+
+	// the definitions are not cached when the cache TTL is not positive
+	cacheRemoteDefinition("https://example.com/uncached.yaml", []byte(remotePetstoreDefinition), 0)
+	assert.Empty(t, remoteDefinitionCache, "Definitions should not be cached without a cache TTL")
+}
@@ -0,0 +1,54 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package restserver
+
+import (
+	"net/http"
+	"strings"
+)
+
+// v2BasePath is the base path under which the operations of the REST API are served as v2, in addition to the base
+// path of the API definition (v1, /api/mgw/adapter/0.1).
+const v2BasePath = "/api/mgw/adapter/v2"
+
+// apiVersions are the versions of the REST API served by the adapter, reported in the capabilities.
+var apiVersions = []string{"v1", "v2"}
+
+// newVersionedHandler serves the requests to the v2 base path by the operations of the REST API, by rewriting the
+// v2 base path of the requests to the base path of the API definition. The requests to the v1 base path are served
+// as they are, so the routes of both versions are resolved by the same router.
+func newVersionedHandler(handler http.Handler, basePath string) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if path, found := rewriteV2Path(r.URL.Path, basePath); found {
+			v1Request := r.Clone(r.Context())
+			v1Request.URL.Path = path
+			v1Request.URL.RawPath, _ = rewriteV2Path(r.URL.RawPath, basePath)
+			r = v1Request
+		}
+		handler.ServeHTTP(rw, r)
+	})
+}
+
+// rewriteV2Path replaces the v2 base path of the path with the given base path. False is returned if the path is not
+// under the v2 base path.
+func rewriteV2Path(path, basePath string) (string, bool) {
+	if path != v2BasePath && !strings.HasPrefix(path, v2BasePath+"/") {
+		return "", false
+	}
+	return basePath + strings.TrimPrefix(path, v2BasePath), true
+}
@@ -20,7 +20,6 @@ package restserver
 import (
 	"crypto/tls"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 
 	// enable profiling endpoints
@@ -41,6 +40,7 @@ import (
 	apiServer "github.com/wso2/product-microgateway/adapter/internal/api"
 	"github.com/wso2/product-microgateway/adapter/internal/api/models"
 	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/adapter"
 	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/api_collection"
 	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/api_individual"
 	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/authorization"
@@ -48,12 +48,26 @@ import (
 	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
 )
 
 var (
 	mgwConfig *config.Config
 )
 
+// Actions of the responses of the deploy and undeploy requests
+const (
+	deployedAction          = "DEPLOYED"
+	updatedAction           = "UPDATED"
+	undeployedAction        = "UNDEPLOYED"
+	alreadyUndeployedAction = "ALREADY_UNDEPLOYED"
+	unchangedAction         = "UNCHANGED"
+)
+
+// tlsCertificateQueueKeyPrefix prefixes the vhost to derive the deployment queue key of the TLS certificate updates,
+// so that the updates of the certificate of a vhost are applied one after the other.
+const tlsCertificateQueueKeyPrefix = "tls-certificates:"
+
 //go:generate swagger generate server --target ../../api --name Restapi --spec ../../../../resources/adminAPI.yaml --server-package restserver --principal models.Principal
 
 func configureFlags(api *operations.RestapiAPI) {
@@ -129,6 +143,9 @@ func configureAPI(api *operations.RestapiAPI) http.Handler {
 	api.APIIndividualDeleteApisHandler = api_individual.DeleteApisHandlerFunc(func(
 		params api_individual.DeleteApisParams, principal *models.Principal) middleware.Responder {
 
+		if config.IsReadOnlyMode() {
+			return newReadOnlyModeResponder()
+		}
 		conf, _ := config.ReadConfigs()
 
 		if conf.ControlPlane.Enabled {
@@ -151,25 +168,41 @@ func configureAPI(api *operations.RestapiAPI) http.Handler {
 		if params.Environments != nil {
 			environments = strings.Split(*params.Environments, ":")
 		}
-		err := xds.DeleteAPIs(vhost, params.APIName, params.Version, environments, tenantDomain)
-		if err == nil {
-			return api_individual.NewDeleteApisOK()
+		var status xds.UndeployStatus
+		err := xds.ExecuteInDeploymentQueue(xds.GenerateIdentifierForAPIWithoutVhost(params.APIName, params.Version),
+			func() (err error) {
+				status, err = xds.DeleteAPIs(vhost, params.APIName, params.Version, environments, tenantDomain)
+				return err
+			})
+		if err == xds.ErrDeploymentQueueFull {
+			return newDeploymentQueueFullResponder()
 		}
-		switch err.Error() {
-		case constants.NotFound:
-			return api_individual.NewDeleteApisNotFound()
-		default:
+		if err != nil {
 			return api_individual.NewPostApisInternalServerError()
 		}
+		if status == xds.AlreadyUndeployed {
+			return api_individual.NewDeleteApisOK().WithPayload(&models.DeployResponse{
+				Action: alreadyUndeployedAction,
+				Info: fmt.Sprintf("API %s:%s is not deployed. Hence nothing is removed.", params.APIName,
+					params.Version),
+			})
+		}
+		return api_individual.NewDeleteApisOK().WithPayload(&models.DeployResponse{
+			Action: undeployedAction,
+			Info:   fmt.Sprintf("API %s:%s is undeployed.", params.APIName, params.Version),
+		})
 	})
 	api.APICollectionGetApisHandler = api_collection.GetApisHandlerFunc(func(
 		params api_collection.GetApisParams, principal *models.Principal) middleware.Responder {
 
-		return api_collection.NewGetApisOK().WithPayload(apiServer.ListApis(params.Query, params.Limit, tenantDomain))
+		return listApis(params, tenantDomain)
 	})
 	api.APIIndividualPostApisHandler = api_individual.PostApisHandlerFunc(func(
 		params api_individual.PostApisParams, principal *models.Principal) middleware.Responder {
 
+		if config.IsReadOnlyMode() {
+			return newReadOnlyModeResponder()
+		}
 		conf, _ := config.ReadConfigs()
 
 		if conf.ControlPlane.Enabled {
@@ -184,10 +217,54 @@ func configureAPI(api *operations.RestapiAPI) http.Handler {
 			return api_individual.NewDeleteApisBadRequest().WithPayload(&err)
 		}
 
-		jsonByteArray, _ := ioutil.ReadAll(params.File)
-		_, err := apiServer.ApplyAPIProjectInStandaloneMode(jsonByteArray, params.Override)
+		jsonByteArray, err := apiServer.ReadAPIProject(params.File)
+		if err == apiServer.ErrAPIProjectTooLarge {
+			errCode := int64(http.StatusRequestEntityTooLarge)
+			errMsg := fmt.Sprintf("API project exceeds the maximum size of %d MB.", conf.Adapter.MaxAPIProjectSizeInMB)
+			return api_individual.NewPostApisRequestEntityTooLarge().WithPayload(&models.Error{
+				Code:        &errCode,
+				Description: "Payload too large",
+				Message:     &errMsg,
+			})
+		} else if err != nil {
+			logger.LoggerAPI.Errorf("Error while reading the API project. %v", err)
+			return api_individual.NewPostApisInternalServerError()
+		}
+		force := params.Force != nil && *params.Force
+		updateStrategy := apiServer.UpdateStrategyReplace
+		if params.UpdateStrategy != nil {
+			updateStrategy = *params.UpdateStrategy
+		}
+		apiProject, result, err := apiServer.ApplyAPIProjectInStandaloneMode(jsonByteArray, params.Override, force,
+			false, updateStrategy)
 		if err != nil {
-			if err.Error() == constants.AlreadyExists {
+			if err == xds.ErrDeploymentQueueFull {
+				return newDeploymentQueueFullResponder()
+			} else if decryptionErr, isDecryptionError := err.(*apiServer.ArtifactDecryptionError); isDecryptionError {
+				errCode := int64(http.StatusBadRequest)
+				errMsg := decryptionErr.Error()
+				return api_individual.NewPostApisBadRequest().WithPayload(&models.Error{
+					Code:        &errCode,
+					Description: "Encrypted API project cannot be decrypted",
+					Message:     &errMsg,
+				})
+			} else if sizeErr, isTooLarge := err.(*apiServer.ExtractedAPIProjectTooLargeError); isTooLarge {
+				errCode := int64(http.StatusRequestEntityTooLarge)
+				errMsg := sizeErr.Error()
+				return api_individual.NewPostApisRequestEntityTooLarge().WithPayload(&models.Error{
+					Code:        &errCode,
+					Description: "Payload too large",
+					Message:     &errMsg,
+				})
+			} else if quotaErr, isQuotaExceeded := err.(*xds.QuotaExceededError); isQuotaExceeded {
+				errCode := int64(http.StatusForbidden)
+				errMsg := quotaErr.Error()
+				return api_individual.NewPostApisForbidden().WithPayload(&models.Error{
+					Code:        &errCode,
+					Description: "Organization has already deployed the maximum number of APIs allowed for it",
+					Message:     &errMsg,
+				})
+			} else if err.Error() == constants.AlreadyExists {
 				return api_individual.NewPostApisConflict()
 			} else if strings.HasPrefix(err.Error(), "An API exists with the same basepath") {
 				return api_individual.NewPostApisConflict()
@@ -195,14 +272,269 @@ func configureAPI(api *operations.RestapiAPI) http.Handler {
 				return api_individual.NewPostApisInternalServerError()
 			}
 		}
-		return api_individual.NewPostApisOK()
+		return newDeployResponder(apiProject, result, updateStrategy)
+	})
+
+	// Handler for /mode
+	api.AdapterPostModeHandler = adapter.PostModeHandlerFunc(func(
+		params adapter.PostModeParams, principal *models.Principal) middleware.Responder {
+
+		isReadOnly := *params.Mode.ReadOnly
+		config.SetReadOnlyMode(isReadOnly)
+		health.SetReadOnlyMode(isReadOnly)
+		if isReadOnly {
+			logger.LoggerAPI.Info("Adapter is switched to read-only mode.")
+		} else {
+			logger.LoggerAPI.Info("Adapter is switched to active mode.")
+		}
+		return adapter.NewPostModeOK().WithPayload(&models.AdapterMode{ReadOnly: &isReadOnly})
+	})
+
+	api.APIIndividualGetApisAPIIDHandler = api_individual.GetApisAPIIDHandlerFunc(func(
+		params api_individual.GetApisAPIIDParams, principal *models.Principal) middleware.Responder {
+
+		apiInfo, isDeployed := apiServer.GetAPI(params.APIID)
+		if !isDeployed {
+			errCode := int64(404)
+			errMsg := fmt.Sprintf("API %v is not deployed in the adapter", params.APIID)
+			err := models.Error{
+				Code:        &errCode,
+				Description: "Not found",
+				Message:     &errMsg,
+			}
+			return api_individual.NewGetApisAPIIDNotFound().WithPayload(&err)
+		}
+		return api_individual.NewGetApisAPIIDOK().WithPayload(apiInfo)
+	})
+
+	api.APIIndividualGetApisAPIIDDeploymentsHandler = api_individual.GetApisAPIIDDeploymentsHandlerFunc(func(
+		params api_individual.GetApisAPIIDDeploymentsParams, principal *models.Principal) middleware.Responder {
+
+		deployments, isKnown := apiServer.GetAPIDeployments(params.APIID)
+		if !isKnown {
+			errCode := int64(404)
+			errMsg := fmt.Sprintf("API %v is not known to the adapter", params.APIID)
+			err := models.Error{
+				Code:        &errCode,
+				Description: "Not found",
+				Message:     &errMsg,
+			}
+			return api_individual.NewGetApisAPIIDDeploymentsNotFound().WithPayload(&err)
+		}
+		return api_individual.NewGetApisAPIIDDeploymentsOK().WithPayload(deployments)
+	})
+
+	api.APICollectionPostApisStatusHandler = api_collection.PostApisStatusHandlerFunc(func(
+		params api_collection.PostApisStatusParams, principal *models.Principal) middleware.Responder {
+
+		statuses, err := apiServer.GetAPIStatuses(params.Apis)
+		if err != nil {
+			errCode := int64(400)
+			errMsg := err.Error()
+			return api_collection.NewPostApisStatusBadRequest().WithPayload(&models.Error{
+				Code:        &errCode,
+				Description: "Bad Request",
+				Message:     &errMsg,
+			})
+		}
+		return api_collection.NewPostApisStatusOK().WithPayload(statuses)
+	})
+
+	api.APIIndividualGetApisAPIIDProvenanceHandler = api_individual.GetApisAPIIDProvenanceHandlerFunc(func(
+		params api_individual.GetApisAPIIDProvenanceParams, principal *models.Principal) middleware.Responder {
+
+		provenance, isKnown := apiServer.GetAPIProvenance(params.APIID)
+		if !isKnown {
+			errCode := int64(404)
+			errMsg := fmt.Sprintf("API %v is not known to the adapter", params.APIID)
+			err := models.Error{
+				Code:        &errCode,
+				Description: "Not found",
+				Message:     &errMsg,
+			}
+			return api_individual.NewGetApisAPIIDProvenanceNotFound().WithPayload(&err)
+		}
+		return api_individual.NewGetApisAPIIDProvenanceOK().WithPayload(provenance)
+	})
+
+	// Handler for /debug/resource-usage
+	api.AdapterGetDebugResourceUsageHandler = adapter.GetDebugResourceUsageHandlerFunc(func(
+		params adapter.GetDebugResourceUsageParams, principal *models.Principal) middleware.Responder {
+
+		return adapter.NewGetDebugResourceUsageOK().WithPayload(apiServer.GetResourceUsage())
+	})
+
+	// Handler for /capabilities
+	api.AdapterGetCapabilitiesHandler = adapter.GetCapabilitiesHandlerFunc(func(
+		params adapter.GetCapabilitiesParams, principal *models.Principal) middleware.Responder {
+
+		capabilities := apiServer.GetCapabilities()
+		capabilities.APIVersions = append([]string{}, apiVersions...)
+		return adapter.NewGetCapabilitiesOK().WithPayload(capabilities)
+	})
+
+	// Handlers for /tls-certificates
+	api.AdapterPostTLSCertificatesHandler = adapter.PostTLSCertificatesHandlerFunc(func(
+		params adapter.PostTLSCertificatesParams, principal *models.Principal) middleware.Responder {
+
+		if config.IsReadOnlyMode() {
+			return newReadOnlyModeResponder()
+		}
+		var certificateInfo *models.TLSCertificateInfo
+		err := xds.ExecuteInDeploymentQueue(tlsCertificateQueueKeyPrefix+params.Certificate.Vhost,
+			func() (err error) {
+				certificateInfo, err = apiServer.UpdateTLSCertificate(params.Certificate)
+				return err
+			})
+		if err == xds.ErrDeploymentQueueFull {
+			return newDeploymentQueueFullResponder()
+		}
+		if err != nil {
+			errMsg := err.Error()
+			if _, isInvalid := err.(*xds.InvalidTLSCertificateError); isInvalid {
+				errCode := int64(http.StatusBadRequest)
+				return adapter.NewPostTLSCertificatesBadRequest().WithPayload(&models.Error{
+					Code:        &errCode,
+					Description: "Invalid TLS certificate",
+					Message:     &errMsg,
+				})
+			}
+			errCode := int64(http.StatusInternalServerError)
+			return adapter.NewPostTLSCertificatesInternalServerError().WithPayload(&models.Error{
+				Code:        &errCode,
+				Description: "Error while serving the TLS certificate",
+				Message:     &errMsg,
+			})
+		}
+		return adapter.NewPostTLSCertificatesOK().WithPayload(certificateInfo)
+	})
+
+	api.AdapterPostTLSCertificatesRollbackHandler = adapter.PostTLSCertificatesRollbackHandlerFunc(func(
+		params adapter.PostTLSCertificatesRollbackParams, principal *models.Principal) middleware.Responder {
+
+		if config.IsReadOnlyMode() {
+			return newReadOnlyModeResponder()
+		}
+		vhost := ""
+		if params.Vhost != nil {
+			vhost = *params.Vhost
+		}
+		var certificateInfo *models.TLSCertificateInfo
+		err := xds.ExecuteInDeploymentQueue(tlsCertificateQueueKeyPrefix+vhost, func() (err error) {
+			certificateInfo, err = apiServer.RollbackTLSCertificate(vhost)
+			return err
+		})
+		if err == xds.ErrDeploymentQueueFull {
+			return newDeploymentQueueFullResponder()
+		}
+		if err != nil {
+			errMsg := err.Error()
+			if err == xds.ErrNoPreviousTLSCertificate {
+				errCode := int64(http.StatusNotFound)
+				return adapter.NewPostTLSCertificatesRollbackNotFound().WithPayload(&models.Error{
+					Code:        &errCode,
+					Description: "Not found",
+					Message:     &errMsg,
+				})
+			}
+			errCode := int64(http.StatusInternalServerError)
+			return adapter.NewPostTLSCertificatesRollbackInternalServerError().WithPayload(&models.Error{
+				Code:        &errCode,
+				Description: "Error while serving the TLS certificate",
+				Message:     &errMsg,
+			})
+		}
+		return adapter.NewPostTLSCertificatesRollbackOK().WithPayload(certificateInfo)
 	})
 
 	api.PreServerShutdown = func() {}
 
 	api.ServerShutdown = func() {}
 
-	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
+	return setupGlobalMiddleware(newVersionedHandler(api.Serve(setupMiddlewares), api.Context().BasePath()))
+}
+
+// listApis lists the APIs of the organization, and responds with 400 Bad Request if the query is malformed.
+func listApis(params api_collection.GetApisParams, organizationID string) middleware.Responder {
+	apis, err := apiServer.ListApis(params.Query, params.Limit, organizationID)
+	if err != nil {
+		errCode := int64(http.StatusBadRequest)
+		errMsg := err.Error()
+		logger.LoggerAPI.Infof("Invalid query to list the APIs. %v", errMsg)
+		return api_collection.NewGetApisBadRequest().WithPayload(&models.Error{
+			Code:        &errCode,
+			Description: "Bad request",
+			Message:     &errMsg,
+		})
+	}
+	return api_collection.NewGetApisOK().WithPayload(apis)
+}
+
+// newDeployResponder responds with 201 Created if the API is deployed for the first time in the vhosts of its
+// deployments, or with 200 OK if the API is updated (along with the details of the previous deployment) or is
+// already deployed with the same content.
+func newDeployResponder(apiProject model.ProjectAPI, result apiServer.DeploymentResult,
+	updateStrategy string) middleware.Responder {
+	apiYaml := apiProject.APIYaml.Data
+	payload := &models.DeployResponse{
+		Warnings:       apiServer.GetParseWarningsOfAPIProject(apiProject),
+		UpdateStrategy: updateStrategy,
+		Deployments:    apiServer.GetDeploymentsOfAPIProject(apiProject),
+	}
+	switch result.Outcome {
+	case apiServer.DeploymentCreated:
+		payload.Action = deployedAction
+		payload.Info = fmt.Sprintf("API %s:%s is deployed.", apiYaml.Name, apiYaml.Version)
+		return api_individual.NewPostApisCreated().WithPayload(payload)
+	case apiServer.DeploymentUnchanged:
+		payload.Action = unchangedAction
+		payload.Info = fmt.Sprintf("API %s:%s is already deployed with the same content.", apiYaml.Name,
+			apiYaml.Version)
+	default:
+		payload.Action = updatedAction
+		payload.Info = fmt.Sprintf("API %s:%s is updated.", apiYaml.Name, apiYaml.Version)
+		payload.PreviousRevisionID = result.PreviousRevisionID
+		payload.PreviousDefinitionHash = result.PreviousDefinitionHash
+	}
+	return api_individual.NewPostApisOK().WithPayload(payload)
+}
+
+// newDeploymentQueueFullResponder responds with 503 Service Unavailable along with the Retry-After header,
+// when a deployment is rejected as the deployment queue is full.
+func newDeploymentQueueFullResponder() middleware.Responder {
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, producer runtime.Producer) {
+		errCode := int64(http.StatusServiceUnavailable)
+		errMsg := "The adapter is busy applying other deployments. Retry after some time."
+		payload := models.Error{
+			Code:        &errCode,
+			Description: "Service unavailable",
+			Message:     &errMsg,
+		}
+		rw.Header().Set("Retry-After", strconv.Itoa(int(xds.GetDeploymentQueueRetryAfter().Seconds())))
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		if err := producer.Produce(rw, &payload); err != nil {
+			logger.LoggerAPI.Errorf("Error while writing the deployment queue full response. %v", err)
+		}
+	})
+}
+
+// newReadOnlyModeResponder responds with 405 Method Not Allowed, when a request which changes the deployment
+// state is received while the adapter is in read-only mode.
+func newReadOnlyModeResponder() middleware.Responder {
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, producer runtime.Producer) {
+		errCode := int64(http.StatusMethodNotAllowed)
+		errMsg := "The adapter is running in read-only mode. APIs and TLS certificates cannot be updated."
+		payload := models.Error{
+			Code:        &errCode,
+			Description: "Method not allowed",
+			Message:     &errMsg,
+		}
+		logger.LoggerAPI.Info(errMsg)
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		if err := producer.Produce(rw, &payload); err != nil {
+			logger.LoggerAPI.Errorf("Error while writing the read-only mode response. %v", err)
+		}
+	})
 }
 
 // The TLS configuration before HTTPS server starts.
@@ -274,7 +606,8 @@ func StartRestServer(config *config.Config) {
 		}
 	}()
 
-	// expose new port for serving pprof based go profiling endpoints
+	// expose new port for serving pprof based go profiling endpoints and adapter debug endpoints
+	registerDebugHandlers()
 	go func() {
 		logger.LoggerAPI.Fatal(http.ListenAndServe("127.0.0.1:6060", nil))
 	}()
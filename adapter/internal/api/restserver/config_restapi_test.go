@@ -0,0 +1,197 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package restserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	apiServer "github.com/wso2/product-microgateway/adapter/internal/api"
+	"github.com/wso2/product-microgateway/adapter/internal/api/models"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/api_collection"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func TestListApisHandlerWithQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		expectedCode int
+	}{
+		{name: "Without the query", expectedCode: http.StatusOK},
+		{name: "Single API type", query: "type:http", expectedCode: http.StatusOK},
+		{name: "Upper case filter", query: "TYPE:WS", expectedCode: http.StatusOK},
+		{name: "Multiple API types", query: "type:HTTP,WS", expectedCode: http.StatusOK},
+		{name: "Without the API type", query: "type", expectedCode: http.StatusBadRequest},
+		{name: "Empty API type", query: "type:", expectedCode: http.StatusBadRequest},
+		{name: "Empty API type in the list", query: "type:HTTP,,WS", expectedCode: http.StatusBadRequest},
+		{name: "Unsupported filter", query: "name:Petstore", expectedCode: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := api_collection.GetApisParams{}
+			if test.query != "" {
+				params.Query = &test.query
+			}
+			recorder := httptest.NewRecorder()
+			assert.NotPanics(t, func() {
+				listApis(params, "carbon.super").WriteResponse(recorder, runtime.JSONProducer())
+			})
+			assert.Equal(t, test.expectedCode, recorder.Code)
+			if test.expectedCode == http.StatusOK {
+				var apis models.APIMeta
+				assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &apis), "Response should be an APIMeta object")
+				return
+			}
+			var errResponse models.Error
+			assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &errResponse), "Response should be an Error object")
+			assert.NotEmpty(t, errResponse.Message, "Error response should describe the invalid query")
+		})
+	}
+}
+
+func TestNewDeployResponder(t *testing.T) {
+	apiProject := model.ProjectAPI{}
+	apiProject.APIYaml.Data.Name = "PetStore"
+	apiProject.APIYaml.Data.Version = "1.0.0"
+	tests := []struct {
+		name                     string
+		result                   apiServer.DeploymentResult
+		expectedCode             int
+		expectedAction           string
+		expectedPreviousHash     string
+		expectedPreviousRevision string
+	}{
+		{
+			name:           "Created",
+			result:         apiServer.DeploymentResult{Outcome: apiServer.DeploymentCreated},
+			expectedCode:   http.StatusCreated,
+			expectedAction: deployedAction,
+		},
+		{
+			name: "Updated",
+			result: apiServer.DeploymentResult{Outcome: apiServer.DeploymentUpdated, PreviousRevisionID: "3",
+				PreviousDefinitionHash: "previous-hash"},
+			expectedCode:             http.StatusOK,
+			expectedAction:           updatedAction,
+			expectedPreviousHash:     "previous-hash",
+			expectedPreviousRevision: "3",
+		},
+		{
+			name: "Unchanged",
+			result: apiServer.DeploymentResult{Outcome: apiServer.DeploymentUnchanged,
+				PreviousDefinitionHash: "previous-hash"},
+			expectedCode:   http.StatusOK,
+			expectedAction: unchangedAction,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			newDeployResponder(apiProject, test.result, apiServer.UpdateStrategyReplace).
+				WriteResponse(recorder, runtime.JSONProducer())
+			assert.Equal(t, test.expectedCode, recorder.Code)
+			var response models.DeployResponse
+			assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response), "Response should be a DeployResponse")
+			assert.Equal(t, test.expectedAction, response.Action)
+			assert.Equal(t, test.expectedPreviousHash, response.PreviousDefinitionHash)
+			assert.Equal(t, test.expectedPreviousRevision, response.PreviousRevisionID)
+		})
+	}
+}
+
+func TestCapabilitiesHandlerWithAPIVersions(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousMgwConfig := mgwConfig
+	previousResourceUsage := conf.Adapter.ResourceUsage
+	defer func() {
+		mgwConfig = previousMgwConfig
+		conf.Adapter.ResourceUsage = previousResourceUsage
+	}()
+	testConfig := *conf
+	testConfig.Adapter.Server.Users = []config.APICtlUser{{Username: "capabilities-user", Password: "password"}}
+	mgwConfig = &testConfig
+
+	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
+	if !assert.Nil(t, err, "Error while loading the API definition") {
+		return
+	}
+	handler := configureAPI(operations.NewRestapiAPI(swaggerSpec))
+	getCapabilities := func(path string, authenticated bool) (int, models.Capabilities) {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		if authenticated {
+			request.SetBasicAuth("capabilities-user", "password")
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		var capabilities models.Capabilities
+		if recorder.Code == http.StatusOK {
+			assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &capabilities),
+				"Response should be a Capabilities object")
+		}
+		return recorder.Code, capabilities
+	}
+
+	for _, path := range []string{"/api/mgw/adapter/0.1/capabilities", "/api/mgw/adapter/v2/capabilities"} {
+		conf.Adapter.ResourceUsage.Enabled = true
+		code, capabilities := getCapabilities(path, true)
+		if assert.Equal(t, http.StatusOK, code, "Capabilities should be served under %s", path) {
+			assert.Equal(t, []string{"v1", "v2"}, capabilities.APIVersions)
+			assert.Contains(t, capabilities.Features, "resourceUsage")
+		}
+		conf.Adapter.ResourceUsage.Enabled = false
+		_, capabilities = getCapabilities(path, true)
+		assert.NotContains(t, capabilities.Features, "resourceUsage",
+			"Capabilities should change with the config")
+	}
+
+	code, _ := getCapabilities("/api/mgw/adapter/v2/capabilities", false)
+	assert.Equal(t, http.StatusUnauthorized, code, "v2 operations should be authenticated as the v1 operations")
+	code, _ = getCapabilities("/api/mgw/adapter/v3/capabilities", true)
+	assert.Equal(t, http.StatusNotFound, code, "Unknown versions should not be served")
+	code, _ = getCapabilities("/api/mgw/adapter/v2capabilities", true)
+	assert.Equal(t, http.StatusNotFound, code, "Paths only starting with the v2 base path should not be served")
+}
+
+func TestRewriteV2Path(t *testing.T) {
+	basePath := "/api/mgw/adapter/0.1"
+	tests := []struct {
+		path          string
+		expectedPath  string
+		expectedFound bool
+	}{
+		{path: "/api/mgw/adapter/v2/apis", expectedPath: basePath + "/apis", expectedFound: true},
+		{path: "/api/mgw/adapter/v2/apis/abc%2Fdef/provenance", expectedPath: basePath + "/apis/abc%2Fdef/provenance",
+			expectedFound: true},
+		{path: "/api/mgw/adapter/v2", expectedPath: basePath, expectedFound: true},
+		{path: "/api/mgw/adapter/0.1/apis"},
+		{path: "/api/mgw/adapter/v20/apis"},
+		{path: ""},
+	}
+	for _, test := range tests {
+		path, found := rewriteV2Path(test.path, basePath)
+		assert.Equal(t, test.expectedFound, found, "Unexpected result for the path %q", test.path)
+		assert.Equal(t, test.expectedPath, path, "Unexpected path for the path %q", test.path)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/envoyconf"
+)
+
+const (
+	generatedNamesDebugPath string = "/debug/generated-names"
+)
+
+// registerDebugHandlers registers the adapter debug endpoints in the default serve mux, which is
+// exposed only on the loopback interface along with the pprof endpoints.
+func registerDebugHandlers() {
+	http.HandleFunc(generatedNamesDebugPath, generatedNamesHandler)
+}
+
+// generatedNamesHandler responds with the mapping from the generated cluster and route names to API UUIDs.
+func generatedNamesHandler(w http.ResponseWriter, r *http.Request) {
+	writeDebugResponse(w, envoyconf.GetGeneratedNameToAPIUUIDMap())
+}
+
+// writeDebugResponse marshals the payload before writing the response, so that the status can still be
+// set if the payload cannot be marshalled.
+func writeDebugResponse(w http.ResponseWriter, payload interface{}) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.LoggerAPI.Errorf("Error while writing the debug endpoint response. %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(body); err != nil {
+		logger.LoggerAPI.Errorf("Error while writing the debug endpoint response. %v", err)
+	}
+}
@@ -56,12 +56,14 @@ type GetApisParams struct {
 	*/
 	Limit *int64
 	/*Optional - Condition to filter APIs. Currently only filtering
-	by API type (HTTP or WebSocket) is supported.
+	by API type is supported. Multiple API types can be given
+	separated by commas to list the APIs of any of those types.
 	"type:http" for HTTP type
 	"type:ws" for WebSocket type
+	"type:http,ws" for HTTP or WebSocket type
 
-	  Max Length: 9
-	  Pattern: ^[a-zA-Z:]*$
+	  Max Length: 64
+	  Pattern: ^[a-zA-Z:,]*$
 	  In: query
 	*/
 	Query *string
@@ -159,11 +161,11 @@ func (o *GetApisParams) bindQuery(rawData []string, hasKey bool, formats strfmt.
 // validateQuery carries on validations for parameter Query
 func (o *GetApisParams) validateQuery(formats strfmt.Registry) error {
 
-	if err := validate.MaxLength("query", "query", *o.Query, 9); err != nil {
+	if err := validate.MaxLength("query", "query", *o.Query, 64); err != nil {
 		return err
 	}
 
-	if err := validate.Pattern("query", "query", *o.Query, `^[a-zA-Z:]*$`); err != nil {
+	if err := validate.Pattern("query", "query", *o.Query, `^[a-zA-Z:,]*$`); err != nil {
 		return err
 	}
 
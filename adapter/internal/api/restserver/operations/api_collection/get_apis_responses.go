@@ -72,6 +72,52 @@ func (o *GetApisOK) WriteResponse(rw http.ResponseWriter, producer runtime.Produ
 	}
 }
 
+// GetApisBadRequestCode is the HTTP code returned for type GetApisBadRequest
+const GetApisBadRequestCode int = 400
+
+/*GetApisBadRequest Bad Request.
+Invalid query to filter the APIs
+
+
+swagger:response getApisBadRequest
+*/
+type GetApisBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewGetApisBadRequest creates GetApisBadRequest with default headers values
+func NewGetApisBadRequest() *GetApisBadRequest {
+
+	return &GetApisBadRequest{}
+}
+
+// WithPayload adds the payload to the get apis bad request response
+func (o *GetApisBadRequest) WithPayload(payload *models.Error) *GetApisBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get apis bad request response
+func (o *GetApisBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetApisBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // GetApisUnauthorizedCode is the HTTP code returned for type GetApisUnauthorized
 const GetApisUnauthorizedCode int = 401
 
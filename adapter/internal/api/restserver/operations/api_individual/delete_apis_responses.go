@@ -33,6 +33,7 @@ const DeleteApisOKCode int = 200
 
 /*DeleteApisOK OK.
 API successfully undeployed from the Microgateway.
+The action is ALREADY_UNDEPLOYED if the API is not deployed, in which case nothing is removed.
 
 
 swagger:response deleteApisOK
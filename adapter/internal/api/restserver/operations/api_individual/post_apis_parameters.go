@@ -30,6 +30,7 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // PostApisMaxParseMemory sets the maximum size in bytes for
@@ -62,11 +63,21 @@ type PostApisParams struct {
 	  In: formData
 	*/
 	File io.ReadCloser
+	/*Whether to deploy the API even if it is already deployed with the same content.
+
+	  In: query
+	*/
+	Force *bool
 	/*Whether to force create an API. When this is true, overrides if  an API already exists.
 
 	  In: query
 	*/
 	Override *bool
+	/*How the vhosts and environments of an already deployed API are updated. replace deploys the API to the deployments of the API project, keepDeployments keeps the current deployments of the API regardless of the API project and mergeDeployments deploys the API to the union of both. Defaults to replace.
+
+	  In: query
+	*/
+	UpdateStrategy *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -98,10 +109,20 @@ func (o *PostApisParams) BindRequest(r *http.Request, route *middleware.MatchedR
 		o.File = &runtime.File{Data: file, Header: fileHeader}
 	}
 
+	qForce, qhkForce, _ := qs.GetOK("force")
+	if err := o.bindForce(qForce, qhkForce, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qOverride, qhkOverride, _ := qs.GetOK("override")
 	if err := o.bindOverride(qOverride, qhkOverride, route.Formats); err != nil {
 		res = append(res, err)
 	}
+
+	qUpdateStrategy, qhkUpdateStrategy, _ := qs.GetOK("updateStrategy")
+	if err := o.bindUpdateStrategy(qUpdateStrategy, qhkUpdateStrategy, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -115,6 +136,29 @@ func (o *PostApisParams) bindFile(file multipart.File, header *multipart.FileHea
 	return nil
 }
 
+// bindForce binds and validates parameter Force from query.
+func (o *PostApisParams) bindForce(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("force", "query", "bool", raw)
+	}
+	o.Force = &value
+
+	return nil
+}
+
 // bindOverride binds and validates parameter Override from query.
 func (o *PostApisParams) bindOverride(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -137,3 +181,35 @@ func (o *PostApisParams) bindOverride(rawData []string, hasKey bool, formats str
 
 	return nil
 }
+
+// bindUpdateStrategy binds and validates parameter UpdateStrategy from query.
+func (o *PostApisParams) bindUpdateStrategy(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.UpdateStrategy = &raw
+
+	if err := o.validateUpdateStrategy(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateUpdateStrategy carries on validations for parameter UpdateStrategy
+func (o *PostApisParams) validateUpdateStrategy(formats strfmt.Registry) error {
+
+	if err := validate.Enum("updateStrategy", "query", *o.UpdateStrategy, []interface{}{"replace", "mergeDeployments", "keepDeployments"}); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -32,7 +32,8 @@ import (
 const PostApisOKCode int = 200
 
 /*PostApisOK Successful.
-API deployed or updated Successfully.
+API updated Successfully.
+The action is UNCHANGED if the API is already deployed with the same content, in which case nothing is updated.
 
 
 swagger:response postApisOK
@@ -74,6 +75,98 @@ func (o *PostApisOK) WriteResponse(rw http.ResponseWriter, producer runtime.Prod
 	}
 }
 
+// PostApisCreatedCode is the HTTP code returned for type PostApisCreated
+const PostApisCreatedCode int = 201
+
+/*PostApisCreated Created.
+API deployed Successfully.
+
+
+swagger:response postApisCreated
+*/
+type PostApisCreated struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.DeployResponse `json:"body,omitempty"`
+}
+
+// NewPostApisCreated creates PostApisCreated with default headers values
+func NewPostApisCreated() *PostApisCreated {
+
+	return &PostApisCreated{}
+}
+
+// WithPayload adds the payload to the post apis created response
+func (o *PostApisCreated) WithPayload(payload *models.DeployResponse) *PostApisCreated {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post apis created response
+func (o *PostApisCreated) SetPayload(payload *models.DeployResponse) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostApisCreated) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(201)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// PostApisBadRequestCode is the HTTP code returned for type PostApisBadRequest
+const PostApisBadRequestCode int = 400
+
+/*PostApisBadRequest Bad Request.
+Encrypted API project cannot be decrypted.
+
+
+swagger:response postApisBadRequest
+*/
+type PostApisBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewPostApisBadRequest creates PostApisBadRequest with default headers values
+func NewPostApisBadRequest() *PostApisBadRequest {
+
+	return &PostApisBadRequest{}
+}
+
+// WithPayload adds the payload to the post apis bad request response
+func (o *PostApisBadRequest) WithPayload(payload *models.Error) *PostApisBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post apis bad request response
+func (o *PostApisBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostApisBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // PostApisUnauthorizedCode is the HTTP code returned for type PostApisUnauthorized
 const PostApisUnauthorizedCode int = 401
 
@@ -118,6 +211,52 @@ func (o *PostApisUnauthorized) WriteResponse(rw http.ResponseWriter, producer ru
 	}
 }
 
+// PostApisForbiddenCode is the HTTP code returned for type PostApisForbidden
+const PostApisForbiddenCode int = 403
+
+/*PostApisForbidden Forbidden.
+Organization has already deployed the maximum number of APIs allowed for it.
+
+
+swagger:response postApisForbidden
+*/
+type PostApisForbidden struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewPostApisForbidden creates PostApisForbidden with default headers values
+func NewPostApisForbidden() *PostApisForbidden {
+
+	return &PostApisForbidden{}
+}
+
+// WithPayload adds the payload to the post apis forbidden response
+func (o *PostApisForbidden) WithPayload(payload *models.Error) *PostApisForbidden {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post apis forbidden response
+func (o *PostApisForbidden) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostApisForbidden) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(403)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // PostApisConflictCode is the HTTP code returned for type PostApisConflict
 const PostApisConflictCode int = 409
 
@@ -164,6 +303,52 @@ func (o *PostApisConflict) WriteResponse(rw http.ResponseWriter, producer runtim
 	}
 }
 
+// PostApisRequestEntityTooLargeCode is the HTTP code returned for type PostApisRequestEntityTooLarge
+const PostApisRequestEntityTooLargeCode int = 413
+
+/*PostApisRequestEntityTooLarge Payload Too Large.
+API project exceeds the maximum size configured in the adapter.
+
+
+swagger:response postApisRequestEntityTooLarge
+*/
+type PostApisRequestEntityTooLarge struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewPostApisRequestEntityTooLarge creates PostApisRequestEntityTooLarge with default headers values
+func NewPostApisRequestEntityTooLarge() *PostApisRequestEntityTooLarge {
+
+	return &PostApisRequestEntityTooLarge{}
+}
+
+// WithPayload adds the payload to the post apis request entity too large response
+func (o *PostApisRequestEntityTooLarge) WithPayload(payload *models.Error) *PostApisRequestEntityTooLarge {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post apis request entity too large response
+func (o *PostApisRequestEntityTooLarge) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostApisRequestEntityTooLarge) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(413)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // PostApisInternalServerErrorCode is the HTTP code returned for type PostApisInternalServerError
 const PostApisInternalServerErrorCode int = 500
 
@@ -30,7 +30,9 @@ import (
 
 // PostApisURL generates an URL for the post apis operation
 type PostApisURL struct {
-	Override *bool
+	Force          *bool
+	Override       *bool
+	UpdateStrategy *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -66,6 +68,14 @@ func (o *PostApisURL) Build() (*url.URL, error) {
 
 	qs := make(url.Values)
 
+	var forceQ string
+	if o.Force != nil {
+		forceQ = swag.FormatBool(*o.Force)
+	}
+	if forceQ != "" {
+		qs.Set("force", forceQ)
+	}
+
 	var overrideQ string
 	if o.Override != nil {
 		overrideQ = swag.FormatBool(*o.Override)
@@ -74,6 +84,14 @@ func (o *PostApisURL) Build() (*url.URL, error) {
 		qs.Set("override", overrideQ)
 	}
 
+	var updateStrategyQ string
+	if o.UpdateStrategy != nil {
+		updateStrategyQ = *o.UpdateStrategy
+	}
+	if updateStrategyQ != "" {
+		qs.Set("updateStrategy", updateStrategyQ)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
@@ -35,6 +35,7 @@ import (
 	"github.com/go-openapi/swag"
 
 	"github.com/wso2/product-microgateway/adapter/internal/api/models"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/adapter"
 	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/api_collection"
 	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/api_individual"
 	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/authorization"
@@ -69,12 +70,39 @@ func NewRestapiAPI(spec *loads.Document) *RestapiAPI {
 		APICollectionGetApisHandler: api_collection.GetApisHandlerFunc(func(params api_collection.GetApisParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation api_collection.GetApis has not yet been implemented")
 		}),
+		APIIndividualGetApisAPIIDHandler: api_individual.GetApisAPIIDHandlerFunc(func(params api_individual.GetApisAPIIDParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation api_individual.GetApisAPIID has not yet been implemented")
+		}),
+		APIIndividualGetApisAPIIDDeploymentsHandler: api_individual.GetApisAPIIDDeploymentsHandlerFunc(func(params api_individual.GetApisAPIIDDeploymentsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation api_individual.GetApisAPIIDDeployments has not yet been implemented")
+		}),
+		APIIndividualGetApisAPIIDProvenanceHandler: api_individual.GetApisAPIIDProvenanceHandlerFunc(func(params api_individual.GetApisAPIIDProvenanceParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation api_individual.GetApisAPIIDProvenance has not yet been implemented")
+		}),
+		AdapterGetCapabilitiesHandler: adapter.GetCapabilitiesHandlerFunc(func(params adapter.GetCapabilitiesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation adapter.GetCapabilities has not yet been implemented")
+		}),
+		AdapterGetDebugResourceUsageHandler: adapter.GetDebugResourceUsageHandlerFunc(func(params adapter.GetDebugResourceUsageParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation adapter.GetDebugResourceUsage has not yet been implemented")
+		}),
 		APIIndividualPostApisHandler: api_individual.PostApisHandlerFunc(func(params api_individual.PostApisParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation api_individual.PostApis has not yet been implemented")
 		}),
+		APICollectionPostApisStatusHandler: api_collection.PostApisStatusHandlerFunc(func(params api_collection.PostApisStatusParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation api_collection.PostApisStatus has not yet been implemented")
+		}),
+		AdapterPostModeHandler: adapter.PostModeHandlerFunc(func(params adapter.PostModeParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation adapter.PostMode has not yet been implemented")
+		}),
 		AuthorizationPostOauth2TokenHandler: authorization.PostOauth2TokenHandlerFunc(func(params authorization.PostOauth2TokenParams) middleware.Responder {
 			return middleware.NotImplemented("operation authorization.PostOauth2Token has not yet been implemented")
 		}),
+		AdapterPostTLSCertificatesHandler: adapter.PostTLSCertificatesHandlerFunc(func(params adapter.PostTLSCertificatesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation adapter.PostTLSCertificates has not yet been implemented")
+		}),
+		AdapterPostTLSCertificatesRollbackHandler: adapter.PostTLSCertificatesRollbackHandlerFunc(func(params adapter.PostTLSCertificatesRollbackParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation adapter.PostTLSCertificatesRollback has not yet been implemented")
+		}),
 
 		// Applies when the Authorization header is set with the Basic scheme
 		BasicAuthAuth: func(user string, pass string) (*models.Principal, error) {
@@ -140,10 +168,28 @@ type RestapiAPI struct {
 	APIIndividualDeleteApisHandler api_individual.DeleteApisHandler
 	// APICollectionGetApisHandler sets the operation handler for the get apis operation
 	APICollectionGetApisHandler api_collection.GetApisHandler
+	// APIIndividualGetApisAPIIDHandler sets the operation handler for the get apis API ID operation
+	APIIndividualGetApisAPIIDHandler api_individual.GetApisAPIIDHandler
+	// APIIndividualGetApisAPIIDDeploymentsHandler sets the operation handler for the get apis API ID deployments operation
+	APIIndividualGetApisAPIIDDeploymentsHandler api_individual.GetApisAPIIDDeploymentsHandler
+	// APIIndividualGetApisAPIIDProvenanceHandler sets the operation handler for the get apis API ID provenance operation
+	APIIndividualGetApisAPIIDProvenanceHandler api_individual.GetApisAPIIDProvenanceHandler
+	// AdapterGetCapabilitiesHandler sets the operation handler for the get capabilities operation
+	AdapterGetCapabilitiesHandler adapter.GetCapabilitiesHandler
+	// AdapterGetDebugResourceUsageHandler sets the operation handler for the get debug resource usage operation
+	AdapterGetDebugResourceUsageHandler adapter.GetDebugResourceUsageHandler
 	// APIIndividualPostApisHandler sets the operation handler for the post apis operation
 	APIIndividualPostApisHandler api_individual.PostApisHandler
+	// APICollectionPostApisStatusHandler sets the operation handler for the post apis status operation
+	APICollectionPostApisStatusHandler api_collection.PostApisStatusHandler
+	// AdapterPostModeHandler sets the operation handler for the post mode operation
+	AdapterPostModeHandler adapter.PostModeHandler
 	// AuthorizationPostOauth2TokenHandler sets the operation handler for the post oauth2 token operation
 	AuthorizationPostOauth2TokenHandler authorization.PostOauth2TokenHandler
+	// AdapterPostTLSCertificatesHandler sets the operation handler for the post TLS certificates operation
+	AdapterPostTLSCertificatesHandler adapter.PostTLSCertificatesHandler
+	// AdapterPostTLSCertificatesRollbackHandler sets the operation handler for the post TLS certificates rollback operation
+	AdapterPostTLSCertificatesRollbackHandler adapter.PostTLSCertificatesRollbackHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -237,12 +283,39 @@ func (o *RestapiAPI) Validate() error {
 	if o.APICollectionGetApisHandler == nil {
 		unregistered = append(unregistered, "api_collection.GetApisHandler")
 	}
+	if o.APIIndividualGetApisAPIIDHandler == nil {
+		unregistered = append(unregistered, "api_individual.GetApisAPIIDHandler")
+	}
+	if o.APIIndividualGetApisAPIIDDeploymentsHandler == nil {
+		unregistered = append(unregistered, "api_individual.GetApisAPIIDDeploymentsHandler")
+	}
+	if o.APIIndividualGetApisAPIIDProvenanceHandler == nil {
+		unregistered = append(unregistered, "api_individual.GetApisAPIIDProvenanceHandler")
+	}
+	if o.AdapterGetCapabilitiesHandler == nil {
+		unregistered = append(unregistered, "adapter.GetCapabilitiesHandler")
+	}
+	if o.AdapterGetDebugResourceUsageHandler == nil {
+		unregistered = append(unregistered, "adapter.GetDebugResourceUsageHandler")
+	}
 	if o.APIIndividualPostApisHandler == nil {
 		unregistered = append(unregistered, "api_individual.PostApisHandler")
 	}
+	if o.APICollectionPostApisStatusHandler == nil {
+		unregistered = append(unregistered, "api_collection.PostApisStatusHandler")
+	}
+	if o.AdapterPostModeHandler == nil {
+		unregistered = append(unregistered, "adapter.PostModeHandler")
+	}
 	if o.AuthorizationPostOauth2TokenHandler == nil {
 		unregistered = append(unregistered, "authorization.PostOauth2TokenHandler")
 	}
+	if o.AdapterPostTLSCertificatesHandler == nil {
+		unregistered = append(unregistered, "adapter.PostTLSCertificatesHandler")
+	}
+	if o.AdapterPostTLSCertificatesRollbackHandler == nil {
+		unregistered = append(unregistered, "adapter.PostTLSCertificatesRollbackHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -356,6 +429,26 @@ func (o *RestapiAPI) initHandlerCache() {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/apis"] = api_collection.NewGetApis(o.context, o.APICollectionGetApisHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/apis/{apiId}"] = api_individual.NewGetApisAPIID(o.context, o.APIIndividualGetApisAPIIDHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/apis/{apiId}/deployments"] = api_individual.NewGetApisAPIIDDeployments(o.context, o.APIIndividualGetApisAPIIDDeploymentsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/apis/{apiId}/provenance"] = api_individual.NewGetApisAPIIDProvenance(o.context, o.APIIndividualGetApisAPIIDProvenanceHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/capabilities"] = adapter.NewGetCapabilities(o.context, o.AdapterGetCapabilitiesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/debug/resource-usage"] = adapter.NewGetDebugResourceUsage(o.context, o.AdapterGetDebugResourceUsageHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -363,7 +456,23 @@ func (o *RestapiAPI) initHandlerCache() {
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/apis/status"] = api_collection.NewPostApisStatus(o.context, o.APICollectionPostApisStatusHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/mode"] = adapter.NewPostMode(o.context, o.AdapterPostModeHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/oauth2/token"] = authorization.NewPostOauth2Token(o.context, o.AuthorizationPostOauth2TokenHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/tls-certificates"] = adapter.NewPostTLSCertificates(o.context, o.AdapterPostTLSCertificatesHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/tls-certificates/rollback"] = adapter.NewPostTLSCertificatesRollback(o.context, o.AdapterPostTLSCertificatesRollbackHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
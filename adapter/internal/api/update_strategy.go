@@ -0,0 +1,154 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"fmt"
+	"sort"
+
+	apiModel "github.com/wso2/product-microgateway/adapter/internal/api/models"
+	xds "github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// Update strategies deciding the vhosts and environments of an API which is already deployed, when it is updated in
+// the standalone mode.
+const (
+	// UpdateStrategyReplace deploys the API to the deployments of the API project, which is the default vhost of
+	// the default environment if the API project does not have a deployment_environments.yaml. The API is
+	// undeployed from its current deployments not listed in the API project.
+	UpdateStrategyReplace = "replace"
+	// UpdateStrategyMergeDeployments deploys the API to the deployments of the API project, along with the current
+	// deployments of the environments not listed in the API project.
+	UpdateStrategyMergeDeployments = "mergeDeployments"
+	// UpdateStrategyKeepDeployments keeps the current deployments of the API regardless of the API project.
+	UpdateStrategyKeepDeployments = "keepDeployments"
+)
+
+// resolveDeployments returns the deployments of the API project according to the update strategy. The API is
+// deployed as in the replace strategy if it is not currently deployed in the organization.
+//
+// An environment is deployed with a single vhost, hence the vhost of an environment listed in both the API project
+// and the current deployments is taken from the API project when the deployments are merged.
+func resolveDeployments(apiProject model.ProjectAPI, updateStrategy string) ([]model.Deployment, error) {
+	switch updateStrategy {
+	case "", UpdateStrategyReplace:
+		return apiProject.Deployments, nil
+	case UpdateStrategyMergeDeployments, UpdateStrategyKeepDeployments:
+	default:
+		return nil, fmt.Errorf("unsupported update strategy %q", updateStrategy)
+	}
+
+	apiYaml := apiProject.APIYaml.Data
+	apiID := apiYaml.ID
+	if apiID == "" {
+		apiID = xds.GenerateHashedAPINameVersionIDWithoutVhost(apiYaml.Name, apiYaml.Version)
+	}
+	currentDeployments := xds.GetAPIDeploymentsInOrganization(apiID, apiYaml.OrganizationID)
+	if len(currentDeployments) == 0 {
+		return apiProject.Deployments, nil
+	}
+
+	var deployments []model.Deployment
+	listedEnvironments := make(map[string]struct{})
+	if updateStrategy == UpdateStrategyMergeDeployments {
+		for _, deployment := range apiProject.Deployments {
+			deployments = append(deployments, deployment)
+			listedEnvironments[deployment.DeploymentEnvironment] = struct{}{}
+		}
+	}
+	for _, currentDeployment := range currentDeployments {
+		for _, environment := range currentDeployment.Environments {
+			if _, found := listedEnvironments[environment]; found {
+				continue
+			}
+			deployments = append(deployments, model.Deployment{
+				DisplayOnDevportal:    true,
+				DeploymentVhost:       currentDeployment.Vhost,
+				DeploymentEnvironment: environment,
+			})
+		}
+	}
+	loggers.LoggerAPI.Infof("Deployments of the API %v:%v are resolved as %v with the update strategy %v.",
+		apiYaml.Name, apiYaml.Version, deployments, updateStrategy)
+	return deployments, nil
+}
+
+// undeployUnlistedDeployments undeploys the API from the vhosts and environments of its current deployments which
+// are not listed in the deployments of the API project, as the deployments resolved by the update strategy replace
+// the current deployments of the API. Returns whether the API is undeployed from any vhost.
+func undeployUnlistedDeployments(apiProject model.ProjectAPI) (bool, error) {
+	apiYaml := apiProject.APIYaml.Data
+	apiID := apiYaml.ID
+	if apiID == "" {
+		apiID = xds.GenerateHashedAPINameVersionIDWithoutVhost(apiYaml.Name, apiYaml.Version)
+	}
+	listedDeployments := make(map[model.Deployment]struct{})
+	for _, deployment := range apiProject.Deployments {
+		listedDeployments[model.Deployment{DeploymentVhost: deployment.DeploymentVhost,
+			DeploymentEnvironment: deployment.DeploymentEnvironment}] = struct{}{}
+	}
+	undeployed := false
+	for _, currentDeployment := range xds.GetAPIDeploymentsInOrganization(apiID, apiYaml.OrganizationID) {
+		var unlistedEnvironments []string
+		for _, environment := range currentDeployment.Environments {
+			if _, found := listedDeployments[model.Deployment{DeploymentVhost: currentDeployment.Vhost,
+				DeploymentEnvironment: environment}]; !found {
+				unlistedEnvironments = append(unlistedEnvironments, environment)
+			}
+		}
+		if len(unlistedEnvironments) == 0 {
+			continue
+		}
+		loggers.LoggerAPI.Infof("Undeploying the API %v:%v from the environments %v of the vhost %v, which are not "+
+			"listed in its deployments.", apiYaml.Name, apiYaml.Version, unlistedEnvironments, currentDeployment.Vhost)
+		if _, err := xds.DeleteAPIsWithUUID(currentDeployment.Vhost, apiID, unlistedEnvironments,
+			apiYaml.OrganizationID); err != nil {
+			return undeployed, err
+		}
+		undeployed = true
+	}
+	return undeployed, nil
+}
+
+// getDeploymentModels groups the deployments by the vhost, sorted by the vhost and the environment.
+func getDeploymentModels(deployments []model.Deployment) []*apiModel.APIDeployment {
+	deploymentModels := make([]*apiModel.APIDeployment, 0, len(deployments))
+	vhostDeployments := make(map[string]*apiModel.APIDeployment)
+	for _, deployment := range deployments {
+		deploymentModel, found := vhostDeployments[deployment.DeploymentVhost]
+		if !found {
+			deploymentModel = &apiModel.APIDeployment{Vhost: deployment.DeploymentVhost, Environments: []string{}}
+			vhostDeployments[deployment.DeploymentVhost] = deploymentModel
+			deploymentModels = append(deploymentModels, deploymentModel)
+		}
+		deploymentModel.Environments = append(deploymentModel.Environments, deployment.DeploymentEnvironment)
+	}
+	sort.Slice(deploymentModels, func(i, j int) bool {
+		return deploymentModels[i].Vhost < deploymentModels[j].Vhost
+	})
+	for _, deploymentModel := range deploymentModels {
+		sort.Strings(deploymentModel.Environments)
+	}
+	return deploymentModels
+}
+
+// GetDeploymentsOfAPIProject returns the vhosts and environments in which the API project is deployed.
+func GetDeploymentsOfAPIProject(apiProject model.ProjectAPI) []*apiModel.APIDeployment {
+	return getDeploymentModels(apiProject.Deployments)
+}
@@ -0,0 +1,131 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	apiModel "github.com/wso2/product-microgateway/adapter/internal/api/models"
+	xds "github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func TestApplyAPIProjectWithUpdateStrategy(t *testing.T) {
+	defaultVhost, _, _ := config.GetDefaultVhost(config.DefaultGatewayName)
+	const stagingEnvironment = "Staging"
+
+	tests := []struct {
+		name                string
+		updateStrategy      string
+		apiUUID             string
+		projectDeployments  []model.Deployment
+		expectedDeployments func(previousVhost, newVhost string) []*apiModel.APIDeployment
+	}{
+		{
+			name:           "Replace the deployments with the default deployment of the API project",
+			updateStrategy: UpdateStrategyReplace,
+			apiUUID:        "update-strategy-replace",
+			expectedDeployments: func(previousVhost, newVhost string) []*apiModel.APIDeployment {
+				return []*apiModel.APIDeployment{{Vhost: defaultVhost, Environments: []string{config.DefaultGatewayName}}}
+			},
+		},
+		{
+			name:           "Keep the current deployments when the API project does not have deployments",
+			updateStrategy: UpdateStrategyKeepDeployments,
+			apiUUID:        "update-strategy-keep",
+			expectedDeployments: func(previousVhost, newVhost string) []*apiModel.APIDeployment {
+				return []*apiModel.APIDeployment{{Vhost: previousVhost, Environments: []string{config.DefaultGatewayName}}}
+			},
+		},
+		{
+			name:           "Keep the current deployments regardless of the deployments of the API project",
+			updateStrategy: UpdateStrategyKeepDeployments,
+			apiUUID:        "update-strategy-keep-listed",
+			projectDeployments: []model.Deployment{
+				{DeploymentVhost: "update-strategy-keep-listed.new.wso2.com", DeploymentEnvironment: stagingEnvironment},
+			},
+			expectedDeployments: func(previousVhost, newVhost string) []*apiModel.APIDeployment {
+				return []*apiModel.APIDeployment{{Vhost: previousVhost, Environments: []string{config.DefaultGatewayName}}}
+			},
+		},
+		{
+			name:           "Merge the current deployments with the deployments of the API project",
+			updateStrategy: UpdateStrategyMergeDeployments,
+			apiUUID:        "update-strategy-merge",
+			projectDeployments: []model.Deployment{
+				{DeploymentVhost: "update-strategy-merge.new.wso2.com", DeploymentEnvironment: stagingEnvironment},
+			},
+			expectedDeployments: func(previousVhost, newVhost string) []*apiModel.APIDeployment {
+				return []*apiModel.APIDeployment{
+					{Vhost: newVhost, Environments: []string{stagingEnvironment}},
+					{Vhost: previousVhost, Environments: []string{config.DefaultGatewayName}},
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apiProject := readTestAPIProject(t, "petstore")
+			apiProject.APIYaml.Data.ID = test.apiUUID
+			apiYaml := apiProject.APIYaml.Data
+			previousVhost := test.apiUUID + ".previous.wso2.com"
+			newVhost := test.apiUUID + ".new.wso2.com"
+			_, err := applyAPIProjectToVhosts(apiProject,
+				map[string][]string{previousVhost: {config.DefaultGatewayName}}, false)
+			assert.Nil(t, err, "Error while deploying the API to the previous vhost")
+			defer xds.DeleteAPIWithAPIMEvent(apiYaml.ID, apiYaml.OrganizationID,
+				[]string{config.DefaultGatewayName, stagingEnvironment}, "")
+
+			apiProject.Deployments = test.projectDeployments
+			apiProject.Deployments, err = resolveDeployments(apiProject, test.updateStrategy)
+			assert.Nil(t, err)
+			override := true
+			updatedAPIProject, _, err := validateAndUpdateXds(apiProject, &override, true, false, false)
+			assert.Nil(t, err, "Error while updating the API")
+
+			expectedDeployments := test.expectedDeployments(previousVhost, newVhost)
+			assert.Equal(t, expectedDeployments, GetDeploymentsOfAPIProject(updatedAPIProject),
+				"Resulting deployments should be returned")
+			for _, deployment := range expectedDeployments {
+				assert.True(t, xds.IsAPIExist(deployment.Vhost, apiYaml.ID, apiYaml.Name, apiYaml.Version,
+					apiYaml.OrganizationID), "API should be deployed to the vhost %v", deployment.Vhost)
+			}
+			assert.Equal(t, test.updateStrategy != UpdateStrategyReplace, xds.IsAPIExist(previousVhost, apiYaml.ID,
+				apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID),
+				"API should be kept in the previous vhost unless the deployments are replaced")
+		})
+	}
+
+	_, err := resolveDeployments(readTestAPIProject(t, "petstore"), "unknown")
+	assert.NotNil(t, err, "Unsupported update strategies should be rejected")
+}
+
+func TestResolveDeploymentsOfUndeployedAPI(t *testing.T) {
+	apiProject := readTestAPIProject(t, "petstore")
+	apiProject.APIYaml.Data.ID = "update-strategy-undeployed"
+	apiProject.Deployments = []model.Deployment{
+		{DeploymentVhost: "undeployed.wso2.com", DeploymentEnvironment: config.DefaultGatewayName},
+	}
+	for _, updateStrategy := range []string{UpdateStrategyKeepDeployments, UpdateStrategyMergeDeployments} {
+		deployments, err := resolveDeployments(apiProject, updateStrategy)
+		assert.Nil(t, err)
+		assert.Equal(t, apiProject.Deployments, deployments,
+			"API which is not deployed should be deployed to the deployments of the API project")
+	}
+}
@@ -0,0 +1,345 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package xds
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/notifier"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// APIDeployment holds a vhost in which an API is currently deployed, along with the environments of the vhost and
+// the revision deployed.
+type APIDeployment struct {
+	Vhost            string
+	Environments     []string
+	RevisionID       string
+	UpstreamBasepath string
+	DeployedTime     time.Time
+	// Staged is true if the API is stored without serving traffic until it is activated.
+	Staged bool
+}
+
+// Deployment statuses of the APIs returned by GetAPIStatuses.
+const (
+	// APIStatusDeployed is the status of an API deployed with the requested revision in all of its vhosts.
+	APIStatusDeployed string = "DEPLOYED"
+	// APIStatusRevisionMismatch is the status of an API deployed with a revision other than the requested revision
+	// in at least one of its vhosts.
+	APIStatusRevisionMismatch string = "REVISION_MISMATCH"
+	// APIStatusUndeployed is the status of an API known to the adapter, but currently undeployed from all of its
+	// environments.
+	APIStatusUndeployed string = "UNDEPLOYED"
+	// APIStatusUnknown is the status of an API which has not been deployed since the adapter started.
+	APIStatusUnknown string = "UNKNOWN"
+)
+
+// APIRevision identifies a revision of an API of which the deployment status is requested. The revision is not
+// compared if it is empty.
+type APIRevision struct {
+	APIID      string
+	RevisionID string
+}
+
+// APIStatus holds the deployment status of a requested API revision, along with the current deployments of the API.
+type APIStatus struct {
+	APIID       string
+	RevisionID  string
+	Status      string
+	Deployments []APIDeployment
+}
+
+type apiDeploymentInfo struct {
+	revisionID       string
+	upstreamBasepath string
+	deployedTime     time.Time
+	staged           bool
+}
+
+var (
+	// organizationID -> Vhost:API_UUID -> revision and time of the latest deployment of the API
+	orgIDAPIDeploymentInfoMap = make(map[string]map[string]apiDeploymentInfo)
+	// UUIDs (or the name version hashes of the APIs without a UUID) of the APIs deployed since the adapter started.
+	// Entries are not removed when the APIs are undeployed, to distinguish the undeployed APIs from unknown APIs.
+	knownAPIs = make(map[string]struct{})
+)
+
+// recordAPIDeployment records the revision, the upstream basepath and the time of the deployment of an API, and
+// whether the API is staged. The caller should hold the lock of the internal maps.
+func recordAPIDeployment(organizationID, apiIdentifier, uniqueIdentifier string, revisionID int,
+	upstreamBasepath string, staged bool) {
+	info := apiDeploymentInfo{upstreamBasepath: upstreamBasepath, deployedTime: time.Now(), staged: staged}
+	if revisionID != 0 {
+		// APIs deployed from the mounted artifacts or apictl do not have a revision
+		info.revisionID = strconv.Itoa(revisionID)
+	}
+	if _, ok := orgIDAPIDeploymentInfoMap[organizationID]; !ok {
+		orgIDAPIDeploymentInfoMap[organizationID] = make(map[string]apiDeploymentInfo)
+	}
+	orgIDAPIDeploymentInfoMap[organizationID][apiIdentifier] = info
+	knownAPIs[uniqueIdentifier] = void
+}
+
+// isAPIStaged returns whether the API is staged, in which case its routes and enforcer API are not served.
+// The caller should hold the lock of the internal maps.
+func isAPIStaged(organizationID, apiIdentifier string) bool {
+	return orgIDAPIDeploymentInfoMap[organizationID][apiIdentifier].staged
+}
+
+// IsAPIUnchanged returns whether the API project is already deployed in the vhost with the same content, in the same
+// environments and staging state, in which case redeploying it would not change the xDS resources. The content is
+// compared by the definition hash of the API project.
+func IsAPIUnchanged(vHost string, apiProject model.ProjectAPI, environments []string, staged bool) bool {
+	if apiProject.DefinitionHash == "" {
+		return false
+	}
+	if len(environments) == 0 {
+		environments = []string{config.DefaultGatewayName}
+	}
+	apiYaml := apiProject.APIYaml.Data
+	organizationID := apiYaml.OrganizationID
+	apiIdentifier := GenerateIdentifierForAPIWithUUID(vHost, getUniqueIdentifier(apiYaml.ID, apiYaml.Name, apiYaml.Version))
+
+	mutexForInternalMapUpdate.RLock()
+	defer mutexForInternalMapUpdate.RUnlock()
+	mgwSwagger, found := orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier]
+	if !found || mgwSwagger.GetDefinitionHash() != apiProject.DefinitionHash ||
+		isAPIStaged(organizationID, apiIdentifier) != staged {
+		return false
+	}
+	existingLabels := orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier]
+	if len(existingLabels) != len(environments) {
+		return false
+	}
+	for _, env := range environments {
+		if !arrayContains(existingLabels, env) {
+			return false
+		}
+	}
+	return true
+}
+
+// ActivateAPI starts serving the traffic of an API staged in the organization, in all the vhosts it is staged.
+// APIs deployed without a UUID (i.e. from the mounted artifacts or apictl) are identified by the hash of the API
+// name and version. Activating an API which is already active does not change anything.
+func ActivateAPI(apiID, organizationID string) error {
+	deployedRevisionList, err := activateStagedAPI(apiID, organizationID)
+	if err != nil {
+		return err
+	}
+	// The revision of a staged API is acknowledged to the control plane once it serves traffic.
+	notifier.SendRevisionUpdateAck(deployedRevisionList)
+	return nil
+}
+
+// activateStagedAPI marks the staged deployments of the API as active and updates the xDS caches of their
+// environments. The revisions deployed to the control plane are returned.
+func activateStagedAPI(apiID, organizationID string) ([]*notifier.DeployedAPIRevision, error) {
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+
+	isDeployed := false
+	var labels []string
+	var deployedRevisionList []*notifier.DeployedAPIRevision
+	identifierSuffix := apiKeyFieldSeparator + apiID
+	for apiIdentifier, environments := range orgIDOpenAPIEnvoyMap[organizationID] {
+		if !strings.HasSuffix(apiIdentifier, identifierSuffix) {
+			continue
+		}
+		isDeployed = true
+		info := orgIDAPIDeploymentInfoMap[organizationID][apiIdentifier]
+		if !info.staged {
+			continue
+		}
+		info.staged = false
+		orgIDAPIDeploymentInfoMap[organizationID][apiIdentifier] = info
+		labels = append(labels, environments...)
+		logger.LoggerXds.Infof("Activated the staged API %v of organization %v", apiIdentifier, organizationID)
+		if revisionID, err := strconv.Atoi(info.revisionID); err == nil {
+			deployedRevisionList = append(deployedRevisionList, notifier.UpdateDeployedRevisions(apiID, revisionID,
+				environments, strings.TrimSuffix(apiIdentifier, identifierSuffix)))
+		}
+	}
+	if !isDeployed {
+		return nil, fmt.Errorf("API %v is not deployed in the organization %v", apiID, organizationID)
+	}
+	if len(labels) > 0 {
+		updateXdsCacheOnAPIAdd([]string{}, labels)
+	}
+	return deployedRevisionList, nil
+}
+
+// removeAPIDeploymentInfo removes the deployment details of an API undeployed from all of its environments.
+// The caller should hold the lock of the internal maps.
+func removeAPIDeploymentInfo(organizationID, apiIdentifier string) {
+	delete(orgIDAPIDeploymentInfoMap[organizationID], apiIdentifier)
+}
+
+// GetAPIDeployments returns the vhosts in which the API is currently deployed sorted by the vhost. APIs deployed
+// without a UUID (i.e. from the mounted artifacts or apictl) are identified by the hash of the API name and version.
+// The second return value is false if the API has not been deployed since the adapter started.
+func GetAPIDeployments(apiID string) ([]APIDeployment, bool) {
+	mutexForInternalMapUpdate.RLock()
+	defer mutexForInternalMapUpdate.RUnlock()
+
+	_, isKnown := knownAPIs[apiID]
+	deployments := []APIDeployment{}
+	for organizationID := range orgIDOpenAPIEnvoyMap {
+		deployments = append(deployments, getAPIDeploymentsInOrganization(apiID, organizationID)...)
+	}
+	if len(deployments) > 0 {
+		isKnown = true
+	}
+	sortAPIDeployments(deployments)
+	return deployments, isKnown
+}
+
+// GetAPIDeploymentsInOrganization returns the vhosts in which the API is currently deployed in the organization
+// sorted by the vhost. The API is identified the same way as in GetAPIDeployments.
+func GetAPIDeploymentsInOrganization(apiID, organizationID string) []APIDeployment {
+	mutexForInternalMapUpdate.RLock()
+	defer mutexForInternalMapUpdate.RUnlock()
+
+	deployments := getAPIDeploymentsInOrganization(apiID, organizationID)
+	sortAPIDeployments(deployments)
+	return deployments
+}
+
+// getAPIDeploymentsInOrganization returns the vhosts in which the API is currently deployed in the organization.
+// The caller should hold the lock of the internal maps.
+func getAPIDeploymentsInOrganization(apiID, organizationID string) []APIDeployment {
+	var deployments []APIDeployment
+	identifierSuffix := apiKeyFieldSeparator + apiID
+	for apiIdentifier, environments := range orgIDOpenAPIEnvoyMap[organizationID] {
+		if !strings.HasSuffix(apiIdentifier, identifierSuffix) || len(environments) == 0 {
+			continue
+		}
+		deployments = append(deployments, getAPIDeployment(organizationID, apiIdentifier,
+			strings.TrimSuffix(apiIdentifier, identifierSuffix), environments))
+	}
+	return deployments
+}
+
+// getAPIDeployment returns the deployment of an API in the vhost. The caller should hold the lock of the internal maps.
+func getAPIDeployment(organizationID, apiIdentifier, vhost string, environments []string) APIDeployment {
+	sortedEnvironments := append([]string{}, environments...)
+	sort.Strings(sortedEnvironments)
+	info := orgIDAPIDeploymentInfoMap[organizationID][apiIdentifier]
+	return APIDeployment{
+		Vhost:            vhost,
+		Environments:     sortedEnvironments,
+		RevisionID:       info.revisionID,
+		UpstreamBasepath: info.upstreamBasepath,
+		DeployedTime:     info.deployedTime,
+		Staged:           info.staged,
+	}
+}
+
+// GetAPIStatuses returns the deployment status of each of the API revisions, in the order those are given, so that
+// the control plane can reconcile the APIs it has deployed with the APIs deployed in the adapter. APIs are identified
+// the same way as in GetAPIDeployments. The deployments of all the APIs are read with a single acquisition of the
+// lock of the internal maps, visiting each deployment once regardless of the number of requested APIs.
+func GetAPIStatuses(apiRevisions []APIRevision) []APIStatus {
+	apiDeployments := make(map[string][]APIDeployment, len(apiRevisions))
+	for _, apiRevision := range apiRevisions {
+		apiDeployments[apiRevision.APIID] = nil
+	}
+	knownRequestedAPIs := make(map[string]struct{})
+
+	mutexForInternalMapUpdate.RLock()
+	for organizationID, apiMap := range orgIDOpenAPIEnvoyMap {
+		for apiIdentifier, environments := range apiMap {
+			separatorIndex := strings.LastIndex(apiIdentifier, apiKeyFieldSeparator)
+			apiID := apiIdentifier[separatorIndex+1:]
+			deployments, isRequested := apiDeployments[apiID]
+			if !isRequested || len(environments) == 0 {
+				continue
+			}
+			apiDeployments[apiID] = append(deployments, getAPIDeployment(organizationID, apiIdentifier,
+				apiIdentifier[:separatorIndex], environments))
+		}
+	}
+	for apiID := range apiDeployments {
+		if _, isKnown := knownAPIs[apiID]; isKnown {
+			knownRequestedAPIs[apiID] = void
+		}
+	}
+	mutexForInternalMapUpdate.RUnlock()
+
+	statuses := make([]APIStatus, 0, len(apiRevisions))
+	for _, apiRevision := range apiRevisions {
+		deployments := apiDeployments[apiRevision.APIID]
+		sortAPIDeployments(deployments)
+		status := APIStatus{
+			APIID:       apiRevision.APIID,
+			RevisionID:  apiRevision.RevisionID,
+			Status:      APIStatusDeployed,
+			Deployments: append([]APIDeployment{}, deployments...),
+		}
+		if len(deployments) == 0 {
+			status.Status = APIStatusUnknown
+			if _, isKnown := knownRequestedAPIs[apiRevision.APIID]; isKnown {
+				status.Status = APIStatusUndeployed
+			}
+		}
+		for _, deployment := range deployments {
+			if apiRevision.RevisionID != "" && deployment.RevisionID != apiRevision.RevisionID {
+				status.Status = APIStatusRevisionMismatch
+				break
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func sortAPIDeployments(deployments []APIDeployment) {
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].Vhost < deployments[j].Vhost
+	})
+}
+
+// GetDeployedAPI returns the model of the API currently deployed with the given UUID (or the hash of the API name and
+// version for the APIs deployed without a UUID). If the API is deployed in multiple vhosts, the model of the first
+// vhost in the sorted order is returned. The second return value is false if the API is not currently deployed.
+func GetDeployedAPI(apiID string) (model.MgwSwagger, bool) {
+	mutexForInternalMapUpdate.RLock()
+	defer mutexForInternalMapUpdate.RUnlock()
+
+	var deployedAPI model.MgwSwagger
+	deployedAPIIdentifier := ""
+	identifierSuffix := apiKeyFieldSeparator + apiID
+	for _, apiMap := range orgIDAPIMgwSwaggerMap {
+		for apiIdentifier, mgwSwagger := range apiMap {
+			if !strings.HasSuffix(apiIdentifier, identifierSuffix) {
+				continue
+			}
+			if deployedAPIIdentifier == "" || apiIdentifier < deployedAPIIdentifier {
+				deployedAPIIdentifier = apiIdentifier
+				deployedAPI = mgwSwagger
+			}
+		}
+	}
+	return deployedAPI, deployedAPIIdentifier != ""
+}
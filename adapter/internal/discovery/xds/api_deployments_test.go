@@ -0,0 +1,221 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package xds
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+func TestGetAPIDeployments(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousNodeGroups := conf.Adapter.NodeGroups
+	defer func() {
+		conf.Adapter.NodeGroups = previousNodeGroups
+		resetInternalMapsForNodeGroupTests()
+	}()
+	conf.Adapter.NodeGroups.DefaultGroup = ""
+	conf.Adapter.NodeGroups.Groups = nil
+	resetInternalMapsForNodeGroupTests()
+	orgIDAPIDeploymentInfoMap = make(map[string]map[string]apiDeploymentInfo)
+	knownAPIs = make(map[string]struct{})
+
+	apiID := "777-Inventory"
+	// API deployed from the control plane to two vhosts
+	addAPIForNodeGroupTests(GenerateIdentifierForAPIWithUUID("us.wso2.com", apiID), "/inventory-us",
+		[]string{"us-region", "Default"})
+	recordAPIDeployment(nodeGroupTestOrganization, GenerateIdentifierForAPIWithUUID("us.wso2.com", apiID), apiID, 3,
+		"/inventory/v1", false)
+	addAPIForNodeGroupTests(GenerateIdentifierForAPIWithUUID("eu.wso2.com", apiID), "/inventory-eu",
+		[]string{"eu-region"})
+	recordAPIDeployment(nodeGroupTestOrganization, GenerateIdentifierForAPIWithUUID("eu.wso2.com", apiID), apiID, 2,
+		"/inventory/v1", false)
+	// API deployed from the mounted artifacts, which is identified by the hash of the name and version
+	mountedAPIID := GenerateHashedAPINameVersionIDWithoutVhost("Mounted", "v1")
+	addAPIForNodeGroupTests(GenerateIdentifierForAPIWithUUID("localhost", mountedAPIID), "/mounted",
+		[]string{"Default"})
+	recordAPIDeployment(nodeGroupTestOrganization, GenerateIdentifierForAPIWithUUID("localhost", mountedAPIID),
+		mountedAPIID, 0, "", false)
+
+	deployments, isKnown := GetAPIDeployments(apiID)
+	if !isKnown || len(deployments) != 2 {
+		t.Fatalf("expected the deployments of the API in two vhosts, but found %v", deployments)
+	}
+	if deployments[0].Vhost != "eu.wso2.com" || deployments[0].RevisionID != "2" ||
+		!reflect.DeepEqual(deployments[0].Environments, []string{"eu-region"}) {
+		t.Errorf("unexpected deployment of the API in the vhost eu.wso2.com %v", deployments[0])
+	}
+	if deployments[1].Vhost != "us.wso2.com" || deployments[1].RevisionID != "3" ||
+		deployments[1].UpstreamBasepath != "/inventory/v1" ||
+		!reflect.DeepEqual(deployments[1].Environments, []string{"Default", "us-region"}) {
+		t.Errorf("unexpected deployment of the API in the vhost us.wso2.com %v", deployments[1])
+	}
+	if deployments[0].DeployedTime.IsZero() {
+		t.Error("deployed time of the API is not recorded")
+	}
+
+	deployments, isKnown = GetAPIDeployments(mountedAPIID)
+	if !isKnown || len(deployments) != 1 || deployments[0].Vhost != "localhost" || deployments[0].RevisionID != "" {
+		t.Errorf("unexpected deployments of the API deployed from the mounted artifacts %v", deployments)
+	}
+
+	if _, isDeployed := GetDeployedAPI(apiID); !isDeployed {
+		t.Error("model of the deployed API is not found")
+	}
+
+	// Undeployed APIs are known, but do not have any deployments
+	for _, vhost := range []string{"us.wso2.com", "eu.wso2.com"} {
+		if err := deleteAPI(GenerateIdentifierForAPIWithUUID(vhost, apiID), []string{},
+			nodeGroupTestOrganization); err != nil {
+			t.Fatalf("error while undeploying the API from the vhost %v: %v", vhost, err)
+		}
+	}
+	deployments, isKnown = GetAPIDeployments(apiID)
+	if !isKnown || len(deployments) != 0 {
+		t.Errorf("expected the undeployed API to be known without any deployments, but found %v, %v", isKnown,
+			deployments)
+	}
+
+	if _, isDeployed := GetDeployedAPI(apiID); isDeployed {
+		t.Error("model of the undeployed API should not be found")
+	}
+
+	if _, isKnown = GetAPIDeployments("888-Unknown"); isKnown {
+		t.Error("API which has not been deployed should not be known")
+	}
+}
+
+func TestActivateStagedAPI(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousNodeGroups := conf.Adapter.NodeGroups
+	defer func() {
+		conf.Adapter.NodeGroups = previousNodeGroups
+		resetInternalMapsForNodeGroupTests()
+	}()
+	conf.Adapter.NodeGroups.DefaultGroup = ""
+	conf.Adapter.NodeGroups.Groups = nil
+	resetInternalMapsForNodeGroupTests()
+	orgIDAPIDeploymentInfoMap = make(map[string]map[string]apiDeploymentInfo)
+	knownAPIs = make(map[string]struct{})
+
+	activeAPIIdentifier := GenerateIdentifierForAPIWithUUID("api.wso2.com", "111-Active")
+	addAPIForNodeGroupTests(activeAPIIdentifier, "/active", []string{"staging-env"})
+	recordAPIDeployment(nodeGroupTestOrganization, activeAPIIdentifier, "111-Active", 0, "", false)
+	stagedAPIID := "222-Staged"
+	stagedAPIIdentifier := GenerateIdentifierForAPIWithUUID("api.wso2.com", stagedAPIID)
+	addAPIForNodeGroupTests(stagedAPIIdentifier, "/staged", []string{"staging-env"})
+	recordAPIDeployment(nodeGroupTestOrganization, stagedAPIIdentifier, stagedAPIID, 0, "", true)
+	updateXdsCacheOnAPIAdd([]string{}, []string{"staging-env"})
+
+	// Routes of the staged API are not served until it is activated
+	if routes := getAPIRoutesServedToNode(t, "staging-env"); !reflect.DeepEqual(routes, []string{"/active"}) {
+		t.Errorf("expected only the routes of the active API to be served, but found %v", routes)
+	}
+	deployments, _ := GetAPIDeployments(stagedAPIID)
+	if len(deployments) != 1 || !deployments[0].Staged {
+		t.Errorf("expected the API to be deployed as staged, but found %v", deployments)
+	}
+
+	if err := ActivateAPI(stagedAPIID, nodeGroupTestOrganization); err != nil {
+		t.Fatalf("error while activating the staged API: %v", err)
+	}
+	routes := getAPIRoutesServedToNode(t, "staging-env")
+	sort.Strings(routes)
+	if !reflect.DeepEqual(routes, []string{"/active", "/staged"}) {
+		t.Errorf("expected the routes of the activated API to be served, but found %v", routes)
+	}
+	deployments, _ = GetAPIDeployments(stagedAPIID)
+	if len(deployments) != 1 || deployments[0].Staged {
+		t.Errorf("expected the API to be active after the activation, but found %v", deployments)
+	}
+
+	// Activating an active API does not change anything
+	if err := ActivateAPI(stagedAPIID, nodeGroupTestOrganization); err != nil {
+		t.Errorf("error while activating an active API: %v", err)
+	}
+	if err := ActivateAPI("333-Unknown", nodeGroupTestOrganization); err == nil {
+		t.Error("expected an error while activating an API which is not deployed")
+	}
+}
+
+func TestGetAPIStatuses(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousNodeGroups := conf.Adapter.NodeGroups
+	defer func() {
+		conf.Adapter.NodeGroups = previousNodeGroups
+		resetInternalMapsForNodeGroupTests()
+	}()
+	conf.Adapter.NodeGroups.DefaultGroup = ""
+	conf.Adapter.NodeGroups.Groups = nil
+	resetInternalMapsForNodeGroupTests()
+	orgIDAPIDeploymentInfoMap = make(map[string]map[string]apiDeploymentInfo)
+	knownAPIs = make(map[string]struct{})
+
+	apiID := "777-Inventory"
+	for vhost, revisionID := range map[string]int{"us.wso2.com": 3, "eu.wso2.com": 2} {
+		apiIdentifier := GenerateIdentifierForAPIWithUUID(vhost, apiID)
+		addAPIForNodeGroupTests(apiIdentifier, "/inventory-"+vhost, []string{"Default"})
+		recordAPIDeployment(nodeGroupTestOrganization, apiIdentifier, apiID, revisionID, "/inventory/v1", false)
+	}
+	petsAPIID := "555-Pets"
+	petsAPIIdentifier := GenerateIdentifierForAPIWithUUID("localhost", petsAPIID)
+	addAPIForNodeGroupTests(petsAPIIdentifier, "/pets", []string{"Default"})
+	recordAPIDeployment(nodeGroupTestOrganization, petsAPIIdentifier, petsAPIID, 4, "/pets", false)
+	undeployedAPIID := "666-Undeployed"
+	undeployedAPIIdentifier := GenerateIdentifierForAPIWithUUID("localhost", undeployedAPIID)
+	addAPIForNodeGroupTests(undeployedAPIIdentifier, "/undeployed", []string{"Default"})
+	recordAPIDeployment(nodeGroupTestOrganization, undeployedAPIIdentifier, undeployedAPIID, 1, "", false)
+	if err := deleteAPI(undeployedAPIIdentifier, []string{}, nodeGroupTestOrganization); err != nil {
+		t.Fatalf("error while undeploying the API: %v", err)
+	}
+
+	statuses := GetAPIStatuses([]APIRevision{
+		{APIID: petsAPIID, RevisionID: "4"},
+		{APIID: apiID, RevisionID: "3"},
+		{APIID: "888-Unknown", RevisionID: "1"},
+		{APIID: undeployedAPIID, RevisionID: "1"},
+		{APIID: apiID},
+	})
+	expectedStatuses := []string{APIStatusDeployed, APIStatusRevisionMismatch, APIStatusUnknown, APIStatusUndeployed,
+		APIStatusDeployed}
+	if len(statuses) != len(expectedStatuses) {
+		t.Fatalf("expected a status for each requested API, but found %v", statuses)
+	}
+	for i, expectedStatus := range expectedStatuses {
+		if statuses[i].Status != expectedStatus {
+			t.Errorf("expected the status %v of the API %v, but found %v", expectedStatus, statuses[i].APIID,
+				statuses[i].Status)
+		}
+	}
+	if statuses[0].APIID != petsAPIID || len(statuses[0].Deployments) != 1 ||
+		statuses[0].Deployments[0].Vhost != "localhost" {
+		t.Errorf("unexpected status of the API %v", statuses[0])
+	}
+	inventoryDeployments := statuses[1].Deployments
+	if len(inventoryDeployments) != 2 || inventoryDeployments[0].Vhost != "eu.wso2.com" ||
+		inventoryDeployments[0].RevisionID != "2" || inventoryDeployments[1].Vhost != "us.wso2.com" ||
+		inventoryDeployments[1].RevisionID != "3" {
+		t.Errorf("unexpected deployments of the API in the vhosts %v", inventoryDeployments)
+	}
+	if len(statuses[2].Deployments) != 0 || len(statuses[3].Deployments) != 0 {
+		t.Errorf("expected the unknown and undeployed APIs not to have deployments, but found %v, %v",
+			statuses[2].Deployments, statuses[3].Deployments)
+	}
+}
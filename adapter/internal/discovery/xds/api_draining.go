@@ -0,0 +1,144 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"fmt"
+	"time"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// drainingAPI holds the clusters of an API undeployed from the given labels. The clusters are kept in the router
+// until the drain period is over, so that the in-flight requests to the API can complete, while the routes of the
+// API are removed immediately so that no new requests are accepted.
+type drainingAPI struct {
+	labels    []string
+	clusters  []*clusterv3.Cluster
+	endpoints []*corev3.Address
+}
+
+var (
+	// organizationID -> Vhost:API_UUID -> APIs draining in the router (an API can be undeployed from different
+	// labels while it is draining)
+	orgIDDrainingAPIsMap = make(map[string]map[string][]*drainingAPI)
+	// afterDrainPeriod calls the function in its own goroutine once the drain period is over
+	afterDrainPeriod = func(drainPeriod time.Duration, f func()) {
+		time.AfterFunc(drainPeriod, f)
+	}
+)
+
+func getDrainPeriod() time.Duration {
+	conf, _ := config.ReadConfigs()
+	return time.Duration(conf.Envoy.Upstream.DrainPeriodInSeconds) * time.Second
+}
+
+// startDrainingAPI keeps the clusters of the API in the router for the drain period, when the API is undeployed
+// from the given labels. This should be called with the mutexForInternalMapUpdate locked, before the clusters of
+// the API are removed from the internal maps.
+func startDrainingAPI(organizationID, apiIdentifier string, labels []string) {
+	drainPeriod := getDrainPeriod()
+	clusters := orgIDOpenAPIClustersMap[organizationID][apiIdentifier]
+	if drainPeriod <= 0 || len(clusters) == 0 || len(labels) == 0 {
+		return
+	}
+	draining := &drainingAPI{
+		labels:    labels,
+		clusters:  clusters,
+		endpoints: orgIDOpenAPIEndpointsMap[organizationID][apiIdentifier],
+	}
+	if _, ok := orgIDDrainingAPIsMap[organizationID]; !ok {
+		orgIDDrainingAPIsMap[organizationID] = make(map[string][]*drainingAPI)
+	}
+	orgIDDrainingAPIsMap[organizationID][apiIdentifier] = append(orgIDDrainingAPIsMap[organizationID][apiIdentifier],
+		draining)
+	logger.LoggerXds.Infof("Draining the clusters of the API %v of organization %v in the environments %v for %v",
+		apiIdentifier, organizationID, labels, drainPeriod)
+
+	afterDrainPeriod(drainPeriod, func() {
+		mutexForInternalMapUpdate.Lock()
+		defer mutexForInternalMapUpdate.Unlock()
+		if !removeDrainingAPI(organizationID, apiIdentifier, draining) {
+			return
+		}
+		logger.LoggerXds.Infof("Drain period of the API %v of organization %v is over. Hence removing its clusters "+
+			"from the environments %v", apiIdentifier, organizationID, labels)
+		if err := updateXdsCacheOnAPIDelete(labels); err != nil {
+			logger.LoggerXds.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Error while removing the drained clusters of the API %v of organization %v. %v",
+					apiIdentifier, organizationID, err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1420,
+			})
+		}
+	})
+}
+
+// removeDrainingAPI removes the draining clusters of the API, and returns whether those were found
+func removeDrainingAPI(organizationID, apiIdentifier string, draining *drainingAPI) bool {
+	drainingAPIs := orgIDDrainingAPIsMap[organizationID][apiIdentifier]
+	for i, existing := range drainingAPIs {
+		if existing != draining {
+			continue
+		}
+		drainingAPIs = append(drainingAPIs[:i:i], drainingAPIs[i+1:]...)
+		if len(drainingAPIs) > 0 {
+			orgIDDrainingAPIsMap[organizationID][apiIdentifier] = drainingAPIs
+		} else {
+			delete(orgIDDrainingAPIsMap[organizationID], apiIdentifier)
+		}
+		return true
+	}
+	return false
+}
+
+// appendDrainingClusters appends the clusters of the APIs draining in the given node group. The clusters already
+// in the node group are not appended again, as an API could be redeployed with the same clusters while draining.
+func appendDrainingClusters(nodeGroup string, clusters []*clusterv3.Cluster,
+	endpoints []*corev3.Address) ([]*clusterv3.Cluster, []*corev3.Address) {
+	clusterNames := make(map[string]struct{}, len(clusters))
+	for _, cluster := range clusters {
+		clusterNames[cluster.GetName()] = struct{}{}
+	}
+	for _, drainingAPIMap := range orgIDDrainingAPIsMap {
+		for _, drainingAPIs := range drainingAPIMap {
+			for _, draining := range drainingAPIs {
+				if !isServedToNodeGroup(draining.labels, nodeGroup) {
+					continue
+				}
+				isAppended := false
+				for _, cluster := range draining.clusters {
+					if _, found := clusterNames[cluster.GetName()]; found {
+						continue
+					}
+					clusterNames[cluster.GetName()] = struct{}{}
+					clusters = append(clusters, cluster)
+					isAppended = true
+				}
+				if isAppended {
+					endpoints = append(endpoints, draining.endpoints...)
+				}
+			}
+		}
+	}
+	return clusters, endpoints
+}
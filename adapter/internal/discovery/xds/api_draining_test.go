@@ -0,0 +1,114 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"testing"
+	"time"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+func containsClusterResource(clusters []types.Resource, clusterName string) bool {
+	for _, cluster := range clusters {
+		if cluster.(*clusterv3.Cluster).GetName() == clusterName {
+			return true
+		}
+	}
+	return false
+}
+
+func TestUndeployAPIWithDrainPeriod(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousNodeGroups := conf.Adapter.NodeGroups
+	previousDrainPeriod := conf.Envoy.Upstream.DrainPeriodInSeconds
+	previousAfterDrainPeriod := afterDrainPeriod
+	defer func() {
+		conf.Adapter.NodeGroups = previousNodeGroups
+		conf.Envoy.Upstream.DrainPeriodInSeconds = previousDrainPeriod
+		afterDrainPeriod = previousAfterDrainPeriod
+		orgIDDrainingAPIsMap = make(map[string]map[string][]*drainingAPI)
+		resetInternalMapsForNodeGroupTests()
+	}()
+	conf.Adapter.NodeGroups.DefaultGroup = ""
+	conf.Adapter.NodeGroups.Groups = nil
+
+	var drainPeriods []time.Duration
+	var drainedFuncs []func()
+	afterDrainPeriod = func(drainPeriod time.Duration, f func()) {
+		drainPeriods = append(drainPeriods, drainPeriod)
+		drainedFuncs = append(drainedFuncs, f)
+	}
+	addAPI := func() {
+		resetInternalMapsForNodeGroupTests()
+		orgIDDrainingAPIsMap = make(map[string]map[string][]*drainingAPI)
+		addAPIForNodeGroupTests("api.wso2.com:draining-api", "/draining", []string{"Default"})
+		orgIDOpenAPIClustersMap[nodeGroupTestOrganization] = map[string][]*clusterv3.Cluster{
+			"api.wso2.com:draining-api": {{Name: "clusterProd_draining"}},
+		}
+		drainPeriods = nil
+		drainedFuncs = nil
+	}
+
+	// The clusters of the API are kept until the drain period is over, while the routes are removed immediately
+	conf.Envoy.Upstream.DrainPeriodInSeconds = 30
+	addAPI()
+	if err := deleteAPI("api.wso2.com:draining-api", []string{}, nodeGroupTestOrganization); err != nil {
+		t.Fatalf("error while undeploying the API: %v", err)
+	}
+	if len(drainPeriods) != 1 || drainPeriods[0] != 30*time.Second {
+		t.Fatalf("expected the clusters to be drained for 30s but found the drain periods %v", drainPeriods)
+	}
+	_, clusters, _, _ := GenerateEnvoyResoucesForNodeGroup("Default")
+	if !containsClusterResource(clusters, "clusterProd_draining") {
+		t.Error("cluster of the API is removed before the drain period is over")
+	}
+	if routes := getAPIRoutesServedToNode(t, "Default"); len(routes) != 0 {
+		t.Errorf("expected the routes of the API to be removed immediately but found %v", routes)
+	}
+	// Clusters of the draining API are not served to the node groups the API was not deployed to
+	if _, clusters, _, _ = GenerateEnvoyResoucesForNodeGroup("us-region"); containsClusterResource(clusters,
+		"clusterProd_draining") {
+		t.Error("cluster of the draining API is served to a node group the API was not deployed to")
+	}
+
+	drainedFuncs[0]()
+	if _, clusters, _, _ = GenerateEnvoyResoucesForNodeGroup("Default"); containsClusterResource(clusters,
+		"clusterProd_draining") {
+		t.Error("cluster of the API is not removed after the drain period is over")
+	}
+	if len(orgIDDrainingAPIsMap[nodeGroupTestOrganization]) != 0 {
+		t.Errorf("draining API is not removed after the drain period is over %v", orgIDDrainingAPIsMap)
+	}
+
+	// The clusters are removed immediately when the drain period is not configured
+	conf.Envoy.Upstream.DrainPeriodInSeconds = 0
+	addAPI()
+	if err := deleteAPI("api.wso2.com:draining-api", []string{}, nodeGroupTestOrganization); err != nil {
+		t.Fatalf("error while undeploying the API: %v", err)
+	}
+	if len(drainedFuncs) != 0 {
+		t.Errorf("expected the clusters not to be drained but found the drain periods %v", drainPeriods)
+	}
+	if _, clusters, _, _ = GenerateEnvoyResoucesForNodeGroup("Default"); containsClusterResource(clusters,
+		"clusterProd_draining") {
+		t.Error("cluster of the API is not removed immediately when the drain period is not configured")
+	}
+}
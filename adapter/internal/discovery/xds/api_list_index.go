@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package xds
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
+)
+
+// apiListEntry holds the metadata of a deployed API required to list the API. Entries are immutable once indexed,
+// hence they can be read after releasing the lock of the internal maps.
+type apiListEntry struct {
+	apiIdentifier      string
+	uniqueIdentifier   string
+	name               string
+	version            string
+	apiType            string
+	context            string
+	vhost              string
+	excludedOperations []string
+	contextAliases     []string
+	// subscriptions of the requests to the API are not validated
+	subscriptionValidationDisabled bool
+}
+
+// organizationID -> list entries of the APIs of the organization sorted by Vhost:API_UUID
+var orgIDAPIListIndex = make(map[string][]*apiListEntry)
+
+// indexAPIForListing adds or replaces the list entry of a deployed API in the index of its organization.
+// The caller should hold the lock of the internal maps.
+func indexAPIForListing(organizationID, apiIdentifier string, mgwSwagger model.MgwSwagger) {
+	vhost := "ERROR"
+	uniqueIdentifier := apiIdentifier
+	if vh, err := ExtractVhostFromAPIIdentifier(apiIdentifier); err == nil {
+		vhost = vh
+		uniqueIdentifier = strings.TrimPrefix(apiIdentifier, vh+apiKeyFieldSeparator)
+	}
+	entry := &apiListEntry{
+		apiIdentifier:                  apiIdentifier,
+		uniqueIdentifier:               uniqueIdentifier,
+		name:                           mgwSwagger.GetTitle(),
+		version:                        mgwSwagger.GetVersion(),
+		apiType:                        mgwSwagger.GetAPIType(),
+		context:                        mgwSwagger.GetXWso2Basepath(),
+		vhost:                          vhost,
+		excludedOperations:             mgwSwagger.GetExcludedOperations(),
+		contextAliases:                 mgwSwagger.GetContextAliases(),
+		subscriptionValidationDisabled: mgwSwagger.IsSubscriptionValidationDisabled(),
+	}
+	entries := orgIDAPIListIndex[organizationID]
+	i := sort.Search(len(entries), func(i int) bool { return entries[i].apiIdentifier >= apiIdentifier })
+	if i < len(entries) && entries[i].apiIdentifier == apiIdentifier {
+		entries[i] = entry
+		return
+	}
+	entries = append(entries, nil)
+	copy(entries[i+1:], entries[i:])
+	entries[i] = entry
+	orgIDAPIListIndex[organizationID] = entries
+	metrics.SetOrganizationDeployedAPIs(organizationID, countDeployedAPIs(organizationID))
+}
+
+// removeAPIFromListingIndex removes the list entry of an API undeployed from all of its environments.
+// The caller should hold the lock of the internal maps.
+func removeAPIFromListingIndex(organizationID, apiIdentifier string) {
+	entries := orgIDAPIListIndex[organizationID]
+	i := sort.Search(len(entries), func(i int) bool { return entries[i].apiIdentifier >= apiIdentifier })
+	if i == len(entries) || entries[i].apiIdentifier != apiIdentifier {
+		return
+	}
+	if len(entries) == 1 {
+		delete(orgIDAPIListIndex, organizationID)
+		metrics.DeleteOrganizationDeployedAPIs(organizationID)
+		return
+	}
+	orgIDAPIListIndex[organizationID] = append(entries[:i], entries[i+1:]...)
+	metrics.SetOrganizationDeployedAPIs(organizationID, countDeployedAPIs(organizationID))
+}
@@ -0,0 +1,183 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package xds
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// deployAPIForListingTests updates the internal maps read by ListApis the same way UpdateAPI does.
+func deployAPIForListingTests(organizationID, vhost, name string, labels []string) {
+	var mgwSwagger model.MgwSwagger
+	mgwSwagger.SetName(name)
+	mgwSwagger.SetVersion("v1")
+	apiIdentifier := GenerateIdentifierForAPIWithUUID(vhost, name)
+
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	if _, ok := orgIDAPIMgwSwaggerMap[organizationID]; !ok {
+		orgIDAPIMgwSwaggerMap[organizationID] = make(map[string]model.MgwSwagger)
+		orgIDOpenAPIEnvoyMap[organizationID] = make(map[string][]string)
+	}
+	orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier] = mgwSwagger
+	orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier] = labels
+	indexAPIForListing(organizationID, apiIdentifier, mgwSwagger)
+}
+
+// undeployAPIForListingTests removes an API from the internal maps read by ListApis the same way
+// cleanMapResources does.
+func undeployAPIForListingTests(organizationID, vhost, name string) {
+	apiIdentifier := GenerateIdentifierForAPIWithUUID(vhost, name)
+
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	removeAPIFromListingIndex(organizationID, apiIdentifier)
+	delete(orgIDOpenAPIEnvoyMap[organizationID], apiIdentifier)
+	delete(orgIDAPIMgwSwaggerMap[organizationID], apiIdentifier)
+}
+
+func TestListApis(t *testing.T) {
+	resetInternalMapsForNodeGroupTests()
+	defer resetInternalMapsForNodeGroupTests()
+
+	deployAPIForListingTests("org1", "us.wso2.com", "Pets", []string{"us-region"})
+	deployAPIForListingTests("org1", "eu.wso2.com", "Pets", []string{"eu-region"})
+	deployAPIForListingTests("org1", "eu.wso2.com", "Inventory", []string{"Default"})
+	deployAPIForListingTests("org2", "eu.wso2.com", "Orders", []string{"Default"})
+	// Redeploying an API replaces its entry
+	deployAPIForListingTests("org1", "eu.wso2.com", "Inventory", []string{"Default", "eu-region"})
+
+	apis := ListApis(nil, "org1", nil)
+	if apis.Total != 3 || apis.Count != 3 {
+		t.Fatalf("expected 3 APIs of the organization org1, but found total %v and count %v", apis.Total, apis.Count)
+	}
+	listedAPIs := []string{}
+	for _, api := range apis.List {
+		listedAPIs = append(listedAPIs, api.Vhost+" "+api.APIName)
+	}
+	if !reflect.DeepEqual(listedAPIs, []string{"eu.wso2.com Inventory", "eu.wso2.com Pets", "us.wso2.com Pets"}) {
+		t.Errorf("unexpected APIs listed for the organization org1 %v", listedAPIs)
+	}
+	if !reflect.DeepEqual(apis.List[0].GatewayEnvs, []string{"Default", "eu-region"}) {
+		t.Errorf("unexpected gateway environments of the redeployed API %v", apis.List[0].GatewayEnvs)
+	}
+
+	limit := int64(2)
+	apis = ListApis(nil, "org1", &limit)
+	if apis.Total != 3 || apis.Count != 2 || apis.List[1].APIName != "Pets" {
+		t.Errorf("unexpected APIs listed with the limit %v: total %v, count %v", limit, apis.Total, apis.Count)
+	}
+
+	orgIDAPIListIndex["org1"][1].apiType = "WS"
+	apis = ListApis([]string{"WS"}, "org1", nil)
+	if apis.Count != 1 || apis.List[0].Vhost != "eu.wso2.com" || apis.List[0].APIName != "Pets" {
+		t.Errorf("unexpected APIs listed for the API type WS %v", apis.List)
+	}
+	orgIDAPIListIndex["org1"][0].apiType = "HTTP"
+	orgIDAPIListIndex["org1"][2].apiType = "GRAPHQL"
+	apis = ListApis([]string{"HTTP", "WS"}, "org1", nil)
+	if apis.Total != 3 || apis.Count != 2 || apis.List[0].APIName != "Inventory" || apis.List[1].APIType != "WS" {
+		t.Errorf("unexpected APIs listed for the API types HTTP and WS %v", apis.List)
+	}
+
+	undeployAPIForListingTests("org1", "eu.wso2.com", "Pets")
+	apis = ListApis(nil, "org1", nil)
+	if apis.Total != 2 || apis.List[0].APIName != "Inventory" || apis.List[1].Vhost != "us.wso2.com" {
+		t.Errorf("unexpected APIs listed after undeploying an API %v", apis.List)
+	}
+	undeployAPIForListingTests("org2", "eu.wso2.com", "Orders")
+	if apis = ListApis(nil, "org2", nil); apis.Total != 0 || len(apis.List) != 0 {
+		t.Errorf("expected no APIs for the organization org2, but found %v", apis.List)
+	}
+	if _, ok := orgIDAPIListIndex["org2"]; ok {
+		t.Error("index of an organization without APIs is not removed")
+	}
+}
+
+func TestListApisWithConcurrentDeployments(t *testing.T) {
+	resetInternalMapsForNodeGroupTests()
+	defer resetInternalMapsForNodeGroupTests()
+
+	const deployers = 4
+	const apisPerDeployer = 50
+	var wg sync.WaitGroup
+	for d := 0; d < deployers; d++ {
+		wg.Add(1)
+		go func(d int) {
+			defer wg.Done()
+			for i := 0; i < apisPerDeployer; i++ {
+				name := fmt.Sprintf("api-%d-%d", d, i)
+				deployAPIForListingTests("org1", "localhost", name, []string{"Default"})
+				if i%2 == 1 {
+					undeployAPIForListingTests("org1", "localhost", name)
+				}
+			}
+		}(d)
+	}
+	errs := make(chan string, deployers)
+	for l := 0; l < deployers; l++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < apisPerDeployer; i++ {
+				apis := ListApis(nil, "org1", nil)
+				if int(apis.Count) != len(apis.List) || apis.Count != apis.Total {
+					errs <- fmt.Sprintf("inconsistent API list: total %v, count %v", apis.Total, apis.Count)
+					return
+				}
+				if !sort.SliceIsSorted(apis.List, func(i, j int) bool {
+					return apis.List[i].APIName < apis.List[j].APIName
+				}) {
+					errs <- "API list is not sorted"
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if apis := ListApis(nil, "org1", nil); apis.Total != deployers*apisPerDeployer/2 {
+		t.Errorf("expected %v APIs after the deployments, but found %v", deployers*apisPerDeployer/2, apis.Total)
+	}
+}
+
+func BenchmarkListApis(b *testing.B) {
+	resetInternalMapsForNodeGroupTests()
+	defer resetInternalMapsForNodeGroupTests()
+	for org := 0; org < 100; org++ {
+		for i := 0; i < 100; i++ {
+			deployAPIForListingTests(fmt.Sprintf("org-%d", org), "localhost", fmt.Sprintf("api-%d", i),
+				[]string{"Default"})
+		}
+	}
+	limit := int64(25)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ListApis(nil, "org-42", &limit)
+	}
+}
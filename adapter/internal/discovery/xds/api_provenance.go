@@ -0,0 +1,64 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// APIProvenance holds the sources of the effective configurations of an API deployed in a vhost, along with the files
+// of the API project skipped without being read.
+type APIProvenance struct {
+	Vhost        string
+	Configs      []model.ConfigProvenance
+	SkippedFiles []string
+}
+
+// GetAPIProvenance returns the provenance of the configurations of the API in each vhost it is currently deployed,
+// sorted by the vhost. The API is identified the same way as in GetAPIDeployments. The second return value is false
+// if the API is not known to the adapter.
+func GetAPIProvenance(apiID string) ([]APIProvenance, bool) {
+	mutexForInternalMapUpdate.RLock()
+	defer mutexForInternalMapUpdate.RUnlock()
+
+	_, isKnown := knownAPIs[apiID]
+	provenance := []APIProvenance{}
+	identifierSuffix := apiKeyFieldSeparator + apiID
+	for _, apiMap := range orgIDAPIMgwSwaggerMap {
+		for apiIdentifier, mgwSwagger := range apiMap {
+			if !strings.HasSuffix(apiIdentifier, identifierSuffix) {
+				continue
+			}
+			provenance = append(provenance, APIProvenance{
+				Vhost:        strings.TrimSuffix(apiIdentifier, identifierSuffix),
+				Configs:      mgwSwagger.GetProvenance(),
+				SkippedFiles: mgwSwagger.GetSkippedFiles(),
+			})
+		}
+	}
+	if len(provenance) > 0 {
+		isKnown = true
+	}
+	sort.Slice(provenance, func(i, j int) bool {
+		return provenance[i].Vhost < provenance[j].Vhost
+	})
+	return provenance, isKnown
+}
@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"fmt"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+)
+
+// QuotaExceededError is returned when a new API is deployed to an organization which has already deployed the
+// maximum number of APIs allowed for it.
+type QuotaExceededError struct {
+	OrganizationID string
+	MaxAPIs        int
+}
+
+func (err *QuotaExceededError) Error() string {
+	return fmt.Sprintf("organization %v has already deployed the maximum of %d APIs", err.OrganizationID,
+		err.MaxAPIs)
+}
+
+// GetMaxAPIs returns the maximum number of APIs the organization can deploy, or 0 if the number is not limited.
+// The quota is read for each deployment, hence the changes of the quota apply to the subsequent deployments only.
+func GetMaxAPIs(organizationID string) int {
+	conf, _ := config.ReadConfigs()
+	if maxAPIs, found := conf.Adapter.APIQuota.Organizations[organizationID]; found {
+		return maxAPIs
+	}
+	return conf.Adapter.APIQuota.DefaultMaxAPIs
+}
+
+// countDeployedAPIs returns the number of APIs deployed by the organization, where an API deployed to multiple
+// vhosts is counted once. The caller should hold the lock of the internal maps.
+func countDeployedAPIs(organizationID string) int {
+	deployedAPIs := make(map[string]struct{})
+	for _, entry := range orgIDAPIListIndex[organizationID] {
+		deployedAPIs[entry.uniqueIdentifier] = struct{}{}
+	}
+	return len(deployedAPIs)
+}
+
+// ValidateAPIQuota returns a QuotaExceededError if the API is not deployed yet, and the organization has already
+// deployed the maximum number of APIs allowed for it. Updates of the deployed APIs are always accepted, and the
+// APIs exceeding a reduced quota are not undeployed.
+func ValidateAPIQuota(organizationID, apiUUID, apiName, apiVersion string) error {
+	maxAPIs := GetMaxAPIs(organizationID)
+	if maxAPIs <= 0 {
+		return nil
+	}
+	uniqueIdentifier := getUniqueIdentifier(apiUUID, apiName, apiVersion)
+
+	mutexForInternalMapUpdate.RLock()
+	defer mutexForInternalMapUpdate.RUnlock()
+	for _, entry := range orgIDAPIListIndex[organizationID] {
+		if entry.uniqueIdentifier == uniqueIdentifier {
+			return nil
+		}
+	}
+	if countDeployedAPIs(organizationID) < maxAPIs {
+		return nil
+	}
+	logger.LoggerXds.Infof("API %v:%v of organization %v is rejected as the organization has already deployed "+
+		"the maximum of %d APIs", apiName, apiVersion, organizationID, maxAPIs)
+	return &QuotaExceededError{OrganizationID: organizationID, MaxAPIs: maxAPIs}
+}
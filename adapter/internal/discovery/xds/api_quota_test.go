@@ -0,0 +1,80 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"testing"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+func TestValidateAPIQuota(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousQuota := conf.Adapter.APIQuota
+	resetInternalMapsForNodeGroupTests()
+	defer func() {
+		conf.Adapter.APIQuota = previousQuota
+		resetInternalMapsForNodeGroupTests()
+	}()
+	conf.Adapter.APIQuota.DefaultMaxAPIs = 2
+	conf.Adapter.APIQuota.Organizations = map[string]int{"org2": 1, "org3": 0}
+
+	// An API deployed to multiple vhosts is counted once
+	deployAPIForListingTests("org1", "us.wso2.com", "Pets", []string{"us-region"})
+	deployAPIForListingTests("org1", "eu.wso2.com", "Pets", []string{"eu-region"})
+	if err := ValidateAPIQuota("org1", "Inventory", "Inventory", "v1"); err != nil {
+		t.Errorf("expected the API to be accepted within the quota, but found %v", err)
+	}
+	deployAPIForListingTests("org1", "eu.wso2.com", "Inventory", []string{"Default"})
+
+	err := ValidateAPIQuota("org1", "Orders", "Orders", "v1")
+	quotaErr, isQuotaExceeded := err.(*QuotaExceededError)
+	if !isQuotaExceeded || quotaErr.OrganizationID != "org1" || quotaErr.MaxAPIs != 2 {
+		t.Errorf("expected a quota exceeded error for the organization org1, but found %v", err)
+	}
+	// Updates of the deployed APIs are accepted
+	if err = ValidateAPIQuota("org1", "Pets", "Pets", "v1"); err != nil {
+		t.Errorf("expected an update of a deployed API to be accepted, but found %v", err)
+	}
+
+	// The quota of the organization overrides the default quota, and 0 does not limit the number of APIs
+	deployAPIForListingTests("org2", "eu.wso2.com", "Orders", []string{"Default"})
+	if _, isQuotaExceeded = ValidateAPIQuota("org2", "Pets", "Pets", "v1").(*QuotaExceededError); !isQuotaExceeded {
+		t.Error("expected the API to be rejected by the quota of the organization org2")
+	}
+	deployAPIForListingTests("org3", "eu.wso2.com", "Orders", []string{"Default"})
+	deployAPIForListingTests("org3", "eu.wso2.com", "Pets", []string{"Default"})
+	if err = ValidateAPIQuota("org3", "Inventory", "Inventory", "v1"); err != nil {
+		t.Errorf("expected the API to be accepted as the organization org3 is not limited, but found %v", err)
+	}
+
+	apis := ListApis(nil, "org1", nil)
+	if apis.Quota == nil || apis.Quota.DeployedApis != 2 || apis.Quota.MaxApis != 2 {
+		t.Errorf("unexpected quota listed for the organization org1 %v", apis.Quota)
+	}
+
+	// Undeploying an API releases its quota
+	undeployAPIForListingTests("org1", "eu.wso2.com", "Pets")
+	if err = ValidateAPIQuota("org1", "Orders", "Orders", "v1"); err == nil {
+		t.Error("expected the API deployed to another vhost to be still counted against the quota")
+	}
+	undeployAPIForListingTests("org1", "us.wso2.com", "Pets")
+	if err = ValidateAPIQuota("org1", "Orders", "Orders", "v1"); err != nil {
+		t.Errorf("expected the API to be accepted after undeploying an API, but found %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package xds
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"google.golang.org/protobuf/proto"
+)
+
+// AppliedAPIConfig holds the router and enforcer configurations generated for an API deployed in a vhost.
+type AppliedAPIConfig struct {
+	Vhost        string
+	Environments []string
+	Routes       []*routev3.Route
+	Clusters     []*clusterv3.Cluster
+	Endpoints    []*corev3.Address
+	EnforcerAPI  types.Resource
+}
+
+// GetAppliedAPIConfig returns the configurations currently applied for the API in each vhost the API is deployed,
+// as a list of AppliedAPIConfig sorted by the vhost. APIs deployed without a UUID (i.e. from the mounted artifacts
+// or apictl) are identified by the hash of the API name and version. The returned configurations are copies, hence
+// those are not updated with the later deployments of the API.
+//
+// An error with the message constants.NotFound is returned, if the API is not deployed in the organization.
+func GetAppliedAPIConfig(apiID, organizationID string) (interface{}, error) {
+	mutexForInternalMapUpdate.RLock()
+	defer mutexForInternalMapUpdate.RUnlock()
+
+	appliedConfigs := []AppliedAPIConfig{}
+	identifierSuffix := apiKeyFieldSeparator + apiID
+	for apiIdentifier, environments := range orgIDOpenAPIEnvoyMap[organizationID] {
+		if !strings.HasSuffix(apiIdentifier, identifierSuffix) || len(environments) == 0 {
+			continue
+		}
+		appliedConfig := AppliedAPIConfig{
+			Vhost:        strings.TrimSuffix(apiIdentifier, identifierSuffix),
+			Environments: append([]string{}, environments...),
+		}
+		sort.Strings(appliedConfig.Environments)
+		for _, route := range orgIDOpenAPIRoutesMap[organizationID][apiIdentifier] {
+			appliedConfig.Routes = append(appliedConfig.Routes, proto.Clone(route).(*routev3.Route))
+		}
+		for _, cluster := range orgIDOpenAPIClustersMap[organizationID][apiIdentifier] {
+			appliedConfig.Clusters = append(appliedConfig.Clusters, proto.Clone(cluster).(*clusterv3.Cluster))
+		}
+		for _, endpoint := range orgIDOpenAPIEndpointsMap[organizationID][apiIdentifier] {
+			appliedConfig.Endpoints = append(appliedConfig.Endpoints, proto.Clone(endpoint).(*corev3.Address))
+		}
+		if enforcerAPI, ok := orgIDOpenAPIEnforcerApisMap[organizationID][apiIdentifier]; ok && enforcerAPI != nil {
+			appliedConfig.EnforcerAPI = proto.Clone(enforcerAPI)
+		}
+		appliedConfigs = append(appliedConfigs, appliedConfig)
+	}
+	if len(appliedConfigs) == 0 {
+		return nil, errors.New(constants.NotFound)
+	}
+	sort.Slice(appliedConfigs, func(i, j int) bool {
+		return appliedConfigs[i].Vhost < appliedConfigs[j].Vhost
+	})
+	return appliedConfigs, nil
+}
@@ -192,9 +192,9 @@ func updateCluster(apiKey string, clusterName string, organizationID string, que
 func updateXDSClusterCache(apiKey string, organizationID string) {
 	for key, envoyLabelList := range orgIDOpenAPIEnvoyMap[organizationID] {
 		if key == apiKey {
-			for _, label := range envoyLabelList {
-				listeners, clusters, routes, endpoints, _ := GenerateEnvoyResoucesForLabel(label)
-				UpdateXdsCacheWithLock(label, endpoints, clusters, routes, listeners)
+			for _, nodeGroup := range getNodeGroupsOfLabels(envoyLabelList) {
+				listeners, clusters, routes, endpoints := GenerateEnvoyResoucesForNodeGroup(nodeGroup)
+				UpdateXdsCacheWithLock(nodeGroup, endpoints, clusters, routes, listeners)
 				logger.LoggerXds.Info("Updated XDS cache by consul service discovery for API: ", apiKey)
 			}
 		}
@@ -0,0 +1,163 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
+)
+
+// ErrDeploymentQueueFull is returned when a deployment task is rejected as the deployment queue is full.
+var ErrDeploymentQueueFull = errors.New("deployment queue is full")
+
+// deploymentTask is an operation which mutates the xDS resources (deploy, undeploy, etc.).
+type deploymentTask struct {
+	execute    func() error
+	result     chan error
+	enqueuedAt time.Time
+}
+
+var (
+	deploymentWorkerQueues   []chan *deploymentTask
+	deploymentQueueDepth     int32
+	initDeploymentQueueOnce  sync.Once
+	deploymentQueueRetryTime time.Duration
+)
+
+// initDeploymentQueue starts the deployment workers. Each worker owns a buffered queue, so that the tasks
+// submitted for the same API are applied in the order those are submitted.
+func initDeploymentQueue() {
+	conf, _ := config.ReadConfigs()
+	workerCount := conf.Adapter.DeploymentQueue.WorkerCount
+	if workerCount < 1 {
+		workerCount = 1
+	}
+	queueSize := conf.Adapter.DeploymentQueue.QueueSizePerWorker
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	retryAfter := conf.Adapter.DeploymentQueue.RetryAfterInSeconds
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	deploymentQueueRetryTime = time.Duration(retryAfter) * time.Second
+	deploymentWorkerQueues = make([]chan *deploymentTask, workerCount)
+	for i := range deploymentWorkerQueues {
+		deploymentWorkerQueues[i] = make(chan *deploymentTask, queueSize)
+		go runDeploymentWorker(deploymentWorkerQueues[i])
+	}
+	logger.LoggerXds.Infof("Started %d deployment workers with a queue size of %d per worker.", workerCount, queueSize)
+}
+
+func runDeploymentWorker(queue chan *deploymentTask) {
+	for task := range queue {
+		metrics.SetDeploymentQueueDepth(int(atomic.AddInt32(&deploymentQueueDepth, -1)))
+		metrics.ObserveDeploymentQueueWaitTime(time.Since(task.enqueuedAt))
+		task.result <- executeDeploymentTask(task)
+	}
+}
+
+func executeDeploymentTask(task *deploymentTask) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.LoggerXds.Error("Recovered from panic while applying a deployment task. ", r)
+			err = fmt.Errorf("error while applying the deployment task: %v", r)
+		}
+	}()
+	return task.execute()
+}
+
+// ExecuteInDeploymentQueue submits the task to the deployment queue and waits until it is applied.
+// The key identifies the API the task belongs to (API UUID, or the name and version for the APIs without
+// an UUID) and the tasks of the same key are applied one after the other, in the submitted order.
+// ErrDeploymentQueueFull is returned without waiting if the queue has no capacity for the task.
+// The task must not submit other tasks to the queue.
+func ExecuteInDeploymentQueue(key string, task func() error) error {
+	return submitDeploymentTask(key, task, false)
+}
+
+// ExecuteInDeploymentQueueBlocking submits the task to the deployment queue similar to ExecuteInDeploymentQueue,
+// but waits until the queue has capacity for the task, instead of returning ErrDeploymentQueueFull. The waiting
+// tasks are accepted in the order those are submitted, hence the tasks of the same API are still applied in the
+// submitted order. This is used for the events which cannot be rejected back to their source.
+func ExecuteInDeploymentQueueBlocking(key string, task func() error) error {
+	return submitDeploymentTask(key, task, true)
+}
+
+// submitDeploymentTask submits the task to the queue of the worker of the key and waits until it is applied. If
+// the queue is full, it waits until the queue has capacity for the task if block is true, and returns
+// ErrDeploymentQueueFull otherwise.
+func submitDeploymentTask(key string, task func() error, block bool) error {
+	initDeploymentQueueOnce.Do(initDeploymentQueue)
+	queuedTask := &deploymentTask{
+		execute:    task,
+		result:     make(chan error, 1),
+		enqueuedAt: time.Now(),
+	}
+	queue := deploymentWorkerQueues[getDeploymentWorkerIndex(key)]
+	metrics.SetDeploymentQueueDepth(int(atomic.AddInt32(&deploymentQueueDepth, 1)))
+	if block {
+		queue <- queuedTask
+		return <-queuedTask.result
+	}
+	select {
+	case queue <- queuedTask:
+	default:
+		metrics.SetDeploymentQueueDepth(int(atomic.AddInt32(&deploymentQueueDepth, -1)))
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Deployment task for the API %s is rejected as the deployment queue is full.", key),
+			Severity:  logging.MINOR,
+			ErrorCode: 1417,
+		})
+		return ErrDeploymentQueueFull
+	}
+	return <-queuedTask.result
+}
+
+// IsDeploymentQueueFull returns true if the queue of any of the deployment workers is full.
+func IsDeploymentQueueFull() bool {
+	initDeploymentQueueOnce.Do(initDeploymentQueue)
+	for _, queue := range deploymentWorkerQueues {
+		if len(queue) == cap(queue) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDeploymentQueueRetryAfter returns the time the clients should wait before retrying a deployment
+// which was rejected as the deployment queue is full.
+func GetDeploymentQueueRetryAfter() time.Duration {
+	initDeploymentQueueOnce.Do(initDeploymentQueue)
+	return deploymentQueueRetryTime
+}
+
+func getDeploymentWorkerIndex(key string) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(len(deploymentWorkerQueues)))
+}
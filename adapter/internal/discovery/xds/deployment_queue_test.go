@@ -0,0 +1,120 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package xds
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecuteInDeploymentQueueSerializesTasksOfSameAPI(t *testing.T) {
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := ExecuteInDeploymentQueue("111-PetStore-org1", func() error {
+				current := atomic.AddInt32(&running, 1)
+				for {
+					max := atomic.LoadInt32(&maxRunning)
+					if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return nil
+			})
+			if err != nil && err != ErrDeploymentQueueFull {
+				t.Errorf("Unexpected error while executing the deployment task: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if maxRunning != 1 {
+		t.Errorf("Deployment tasks of the same API should not run concurrently, but %d tasks ran at once", maxRunning)
+	}
+}
+
+func TestExecuteInDeploymentQueueWhenQueueIsFull(t *testing.T) {
+	initDeploymentQueueOnce.Do(initDeploymentQueue)
+	existingQueues := deploymentWorkerQueues
+	defer func() {
+		deploymentWorkerQueues = existingQueues
+	}()
+	// a queue without a worker, which is already full
+	deploymentWorkerQueues = []chan *deploymentTask{make(chan *deploymentTask, 1)}
+	deploymentWorkerQueues[0] <- &deploymentTask{}
+
+	if !IsDeploymentQueueFull() {
+		t.Error("Deployment queue should be reported as full")
+	}
+	executed := false
+	err := ExecuteInDeploymentQueue("111-PetStore-org1", func() error {
+		executed = true
+		return nil
+	})
+	if err != ErrDeploymentQueueFull {
+		t.Errorf("Expected %v, but got %v", ErrDeploymentQueueFull, err)
+	}
+	if executed {
+		t.Error("Rejected deployment task should not be executed")
+	}
+}
+
+func TestExecuteInDeploymentQueueBlockingWhenQueueIsFull(t *testing.T) {
+	initDeploymentQueueOnce.Do(initDeploymentQueue)
+	existingQueues := deploymentWorkerQueues
+	defer func() {
+		deploymentWorkerQueues = existingQueues
+	}()
+	// a queue without a worker, which is already full
+	deploymentWorkerQueues = []chan *deploymentTask{make(chan *deploymentTask, 1)}
+	deploymentWorkerQueues[0] <- &deploymentTask{}
+
+	var executed int32
+	result := make(chan error, 1)
+	go func() {
+		result <- ExecuteInDeploymentQueueBlocking("111-PetStore-org1", func() error {
+			atomic.StoreInt32(&executed, 1)
+			return nil
+		})
+	}()
+	select {
+	case err := <-result:
+		t.Errorf("Deployment task should wait until the queue has capacity, but returned %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// the worker takes the task in the queue, which lets the waiting task into the queue
+	<-deploymentWorkerQueues[0]
+	select {
+	case task := <-deploymentWorkerQueues[0]:
+		task.result <- executeDeploymentTask(task)
+	case <-time.After(time.Second):
+		t.Fatal("Waiting deployment task is not accepted after the queue has capacity")
+	}
+	if err := <-result; err != nil {
+		t.Errorf("Unexpected error while executing the deployment task: %v", err)
+	}
+	if atomic.LoadInt32(&executed) != 1 {
+		t.Error("Waiting deployment task should be executed")
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+// NodeGroupHash uses the node group of the node ID as the node hash, so that all the routers
+// of a node group are served the same snapshot.
+type NodeGroupHash struct{}
+
+// ID returns the node group of the node ID. If the node ID is not listed in any of the configured
+// node groups, the node ID itself is used, hence the router is served the snapshot of the environment
+// having the same name.
+func (NodeGroupHash) ID(node *corev3.Node) string {
+	if node == nil {
+		return "unknown"
+	}
+	return getNodeGroupOfNodeID(node.Id)
+}
+
+var _ envoy_cachev3.NodeHash = NodeGroupHash{}
+
+func getNodeGroupOfNodeID(nodeID string) string {
+	conf, _ := config.ReadConfigs()
+	for _, nodeGroup := range conf.Adapter.NodeGroups.Groups {
+		if arrayContains(nodeGroup.NodeIDs, nodeID) {
+			return nodeGroup.Name
+		}
+	}
+	return nodeID
+}
+
+// getNodeGroupsOfLabels returns the node groups which receive the APIs deployed to the given labels
+// (gateway environments). A label which is not mapped to any node group is served to the default node group,
+// or to the node group named after the label if the default node group is not configured.
+func getNodeGroupsOfLabels(labels []string) []string {
+	conf, _ := config.ReadConfigs()
+	nodeGroups := make([]string, 0, len(labels))
+	for _, label := range labels {
+		isMapped := false
+		for _, nodeGroup := range conf.Adapter.NodeGroups.Groups {
+			if arrayContains(nodeGroup.Environments, label) {
+				isMapped = true
+				if !arrayContains(nodeGroups, nodeGroup.Name) {
+					nodeGroups = append(nodeGroups, nodeGroup.Name)
+				}
+			}
+		}
+		if isMapped {
+			continue
+		}
+		defaultNodeGroup := conf.Adapter.NodeGroups.DefaultGroup
+		if defaultNodeGroup == "" {
+			defaultNodeGroup = label
+		}
+		if !arrayContains(nodeGroups, defaultNodeGroup) {
+			nodeGroups = append(nodeGroups, defaultNodeGroup)
+		}
+	}
+	return nodeGroups
+}
+
+// isServedToNodeGroup checks whether an API deployed to the given labels is included in the snapshot
+// of the node group.
+func isServedToNodeGroup(labels []string, nodeGroup string) bool {
+	return arrayContains(getNodeGroupsOfLabels(labels), nodeGroup)
+}
@@ -0,0 +1,280 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoy_cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	envoy_resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/server/stream/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+const nodeGroupTestOrganization = "node-group-test-org"
+
+func resetInternalMapsForNodeGroupTests() {
+	orgIDAPIMgwSwaggerMap = make(map[string]map[string]model.MgwSwagger)
+	orgIDOpenAPIEnvoyMap = make(map[string]map[string][]string)
+	orgIDOpenAPIRoutesMap = make(map[string]map[string][]*routev3.Route)
+	orgIDOpenAPIClustersMap = make(map[string]map[string][]*clusterv3.Cluster)
+	orgIDOpenAPIEndpointsMap = make(map[string]map[string][]*corev3.Address)
+	orgIDOpenAPIEnforcerApisMap = make(map[string]map[string]types.Resource)
+	orgIDvHostBasepathMap = make(map[string]map[string]string)
+	envoyListenerConfigMap = make(map[string][]*listenerv3.Listener)
+	envoyRouteConfigMap = make(map[string]*routev3.RouteConfiguration)
+	orgIDAPIListIndex = make(map[string][]*apiListEntry)
+}
+
+func addAPIForNodeGroupTests(apiIdentifier, routeName string, labels []string) {
+	if _, ok := orgIDAPIMgwSwaggerMap[nodeGroupTestOrganization]; !ok {
+		orgIDAPIMgwSwaggerMap[nodeGroupTestOrganization] = make(map[string]model.MgwSwagger)
+		orgIDOpenAPIEnvoyMap[nodeGroupTestOrganization] = make(map[string][]string)
+		orgIDOpenAPIRoutesMap[nodeGroupTestOrganization] = make(map[string][]*routev3.Route)
+	}
+	orgIDAPIMgwSwaggerMap[nodeGroupTestOrganization][apiIdentifier] = model.MgwSwagger{}
+	indexAPIForListing(nodeGroupTestOrganization, apiIdentifier, model.MgwSwagger{})
+	orgIDOpenAPIEnvoyMap[nodeGroupTestOrganization][apiIdentifier] = labels
+	orgIDOpenAPIRoutesMap[nodeGroupTestOrganization][apiIdentifier] = []*routev3.Route{{
+		Name:  routeName,
+		Match: &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_Prefix{Prefix: routeName}},
+	}}
+}
+
+// getAPIRoutesServedToNode simulates a router with the given node ID requesting the route configuration,
+// and returns the names of the API routes (excluding the system routes) of the response.
+func getAPIRoutesServedToNode(t *testing.T, nodeID string) []string {
+	responseChan := make(chan envoy_cachev3.Response, 1)
+	request := &envoy_cachev3.Request{Node: &corev3.Node{Id: nodeID}, TypeUrl: envoy_resource.RouteType}
+	if cancel := cache.CreateWatch(request, stream.NewStreamState(false, nil), responseChan); cancel != nil {
+		defer cancel()
+	}
+	var response envoy_cachev3.Response
+	select {
+	case response = <-responseChan:
+	default:
+		t.Fatalf("no snapshot is served to the node ID %v", nodeID)
+	}
+	routeNames := []string{}
+	for _, resource := range response.(*envoy_cachev3.RawResponse).Resources {
+		routeConfig := resource.Resource.(*routev3.RouteConfiguration)
+		for _, vhost := range routeConfig.GetVirtualHosts() {
+			if vhost.GetName() != "api.wso2.com" {
+				continue
+			}
+			for _, route := range vhost.GetRoutes() {
+				routeNames = append(routeNames, route.GetName())
+			}
+		}
+	}
+	return routeNames
+}
+
+func TestGetNodeGroupsOfLabels(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousNodeGroups := conf.Adapter.NodeGroups
+	defer func() {
+		conf.Adapter.NodeGroups = previousNodeGroups
+	}()
+	conf.Adapter.NodeGroups.Groups = []config.NodeGroup{
+		{Name: "public", NodeIDs: []string{"public-router"}, Environments: []string{"Default", "Public"}},
+		{Name: "private", NodeIDs: []string{"private-router"}, Environments: []string{"Default", "Private"}},
+	}
+
+	tests := []struct {
+		name         string
+		defaultGroup string
+		labels       []string
+		nodeGroups   []string
+	}{
+		{
+			name:       "Environment mapped to multiple node groups",
+			labels:     []string{"Default"},
+			nodeGroups: []string{"public", "private"},
+		},
+		{
+			name:       "Environments mapped to the same node group",
+			labels:     []string{"Public", "Default"},
+			nodeGroups: []string{"public", "private"},
+		},
+		{
+			name:       "Unmapped environment without a default node group",
+			labels:     []string{"us-region", "Private"},
+			nodeGroups: []string{"us-region", "private"},
+		},
+		{
+			name:         "Unmapped environment with a default node group",
+			defaultGroup: "public",
+			labels:       []string{"us-region", "eu-region"},
+			nodeGroups:   []string{"public"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf.Adapter.NodeGroups.DefaultGroup = test.defaultGroup
+			nodeGroups := getNodeGroupsOfLabels(test.labels)
+			if !reflect.DeepEqual(nodeGroups, test.nodeGroups) {
+				t.Errorf("expected node groups %v but found %v", test.nodeGroups, nodeGroups)
+			}
+		})
+	}
+
+	if nodeGroup := (NodeGroupHash{}).ID(&corev3.Node{Id: "private-router"}); nodeGroup != "private" {
+		t.Errorf("expected the node group private for the node ID private-router but found %v", nodeGroup)
+	}
+	if nodeGroup := (NodeGroupHash{}).ID(&corev3.Node{Id: "Default"}); nodeGroup != "Default" {
+		t.Errorf("expected the node ID to be used as the node group when it is not mapped, but found %v", nodeGroup)
+	}
+}
+
+func TestSnapshotsPerNodeGroup(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousNodeGroups := conf.Adapter.NodeGroups
+	defer func() {
+		conf.Adapter.NodeGroups = previousNodeGroups
+		resetInternalMapsForNodeGroupTests()
+	}()
+	conf.Adapter.NodeGroups.DefaultGroup = ""
+	conf.Adapter.NodeGroups.Groups = []config.NodeGroup{
+		{Name: "public", NodeIDs: []string{"public-router-1", "public-router-2"}, Environments: []string{"Public"}},
+		{Name: "private", NodeIDs: []string{"private-router"}, Environments: []string{"Private"}},
+	}
+	resetInternalMapsForNodeGroupTests()
+
+	addAPIForNodeGroupTests("api.wso2.com:public-api", "/public", []string{"Public"})
+	addAPIForNodeGroupTests("api.wso2.com:private-api", "/private", []string{"Private"})
+	addAPIForNodeGroupTests("api.wso2.com:shared-api", "/shared", []string{"Public", "Private"})
+	addAPIForNodeGroupTests("api.wso2.com:regional-api", "/regional", []string{"us-region"})
+	updateXdsCacheOnAPIAdd([]string{}, []string{"Public", "Private", "us-region"})
+
+	tests := []struct {
+		nodeID string
+		routes []string
+	}{
+		{nodeID: "public-router-1", routes: []string{"/public", "/shared"}},
+		{nodeID: "public-router-2", routes: []string{"/public", "/shared"}},
+		{nodeID: "private-router", routes: []string{"/private", "/shared"}},
+		// Unmapped environments are served to the routers having the environment name as the node ID
+		{nodeID: "us-region", routes: []string{"/regional"}},
+	}
+	for _, test := range tests {
+		routes := getAPIRoutesServedToNode(t, test.nodeID)
+		sortedRoutes := append([]string{}, routes...)
+		sort.Strings(sortedRoutes)
+		if !reflect.DeepEqual(sortedRoutes, test.routes) {
+			t.Errorf("expected the routes %v to be served to the node ID %v but found %v", test.routes,
+				test.nodeID, routes)
+		}
+	}
+
+	// Undeploying the API from all the environments removes it from the snapshots of all the node groups
+	if err := deleteAPI("api.wso2.com:shared-api", []string{}, nodeGroupTestOrganization); err != nil {
+		t.Fatalf("error while undeploying the API: %v", err)
+	}
+	for nodeID, expectedRoutes := range map[string][]string{
+		"public-router-1": {"/public"},
+		"private-router":  {"/private"},
+	} {
+		if routes := getAPIRoutesServedToNode(t, nodeID); !reflect.DeepEqual(routes, expectedRoutes) {
+			t.Errorf("expected the routes %v to be served to the node ID %v after undeploying but found %v",
+				expectedRoutes, nodeID, routes)
+		}
+	}
+}
+
+func TestRouteOrderOfUnmatchedRequestRoutes(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousNodeGroups := conf.Adapter.NodeGroups
+	previousUnmatchedRequests := conf.Envoy.UnmatchedRequests
+	defer func() {
+		conf.Adapter.NodeGroups = previousNodeGroups
+		conf.Envoy.UnmatchedRequests = previousUnmatchedRequests
+		resetInternalMapsForNodeGroupTests()
+	}()
+	conf.Adapter.NodeGroups.DefaultGroup = ""
+	conf.Adapter.NodeGroups.Groups = []config.NodeGroup{}
+	resetInternalMapsForNodeGroupTests()
+
+	addAPIWithRoutes := func(apiIdentifier string, isDefaultVersion bool, vhostCatchAll bool, routeNames ...string) {
+		conf.Envoy.UnmatchedRequests.VhostCatchAll.Enabled = vhostCatchAll
+		var mgwSwagger model.MgwSwagger
+		if err := mgwSwagger.SetXWso2Extensions(); err != nil {
+			t.Fatalf("error while setting the vendor extensions: %v", err)
+		}
+		mgwSwagger.IsDefaultVersion = isDefaultVersion
+		addAPIForNodeGroupTests(apiIdentifier, routeNames[0], []string{"Default"})
+		orgIDAPIMgwSwaggerMap[nodeGroupTestOrganization][apiIdentifier] = mgwSwagger
+		var routes []*routev3.Route
+		for _, routeName := range routeNames {
+			routes = append(routes, &routev3.Route{
+				Name:  routeName,
+				Match: &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_Prefix{Prefix: "/"}},
+			})
+		}
+		orgIDOpenAPIRoutesMap[nodeGroupTestOrganization][apiIdentifier] = routes
+	}
+
+	addAPIWithRoutes("api.wso2.com:pets-api", false, false, "/pets/v1/pets", "methodNotAllowed:^/pets/v1/pets",
+		"/pets/v1/pets/{id}", "methodNotAllowed:^/pets/v1/pets/([^/]+)", "notFoundFallback:/pets/v1")
+	addAPIWithRoutes("api.wso2.com:stores-api", true, true, "/stores/v1/stores", "methodNotAllowed:^/stores/v1/stores")
+	addAPIWithRoutes("api.wso2.com:orders-api", false, false, "/orders/v1/orders", "notFoundFallback:/orders/v1")
+	updateXdsCacheOnAPIAdd([]string{}, []string{"Default"})
+
+	routes := getAPIRoutesServedToNode(t, "Default")
+	if len(routes) != 10 {
+		t.Fatalf("expected 10 routes to be served but found %v", routes)
+	}
+	// The routes of the APIs are evaluated prior to any route generated for the unmatched requests, hence the
+	// method not allowed routes of an API do not shadow the routes of the other APIs.
+	sortedAPIRoutes := append([]string{}, routes[:4]...)
+	sort.Strings(sortedAPIRoutes)
+	expectedAPIRoutes := []string{"/orders/v1/orders", "/pets/v1/pets", "/pets/v1/pets/{id}", "/stores/v1/stores"}
+	if !reflect.DeepEqual(sortedAPIRoutes, expectedAPIRoutes) {
+		t.Errorf("expected the API routes %v to be served first but found %v", expectedAPIRoutes, routes)
+	}
+	// The routes of the default version API are evaluated last
+	if routes[3] != "/stores/v1/stores" {
+		t.Errorf("expected the routes of the default version API to be the last API routes but found %v", routes)
+	}
+	// The method not allowed routes of an API retain the order of the API routes
+	expectedMethodNotAllowedRoutes := []string{"methodNotAllowed:^/pets/v1/pets", "methodNotAllowed:^/pets/v1/pets/([^/]+)",
+		"methodNotAllowed:^/stores/v1/stores"}
+	if !reflect.DeepEqual(routes[4:7], expectedMethodNotAllowedRoutes) {
+		t.Errorf("expected the method not allowed routes %v after the API routes but found %v",
+			expectedMethodNotAllowedRoutes, routes)
+	}
+	sortedFallbackRoutes := append([]string{}, routes[7:9]...)
+	sort.Strings(sortedFallbackRoutes)
+	expectedFallbackRoutes := []string{"notFoundFallback:/orders/v1", "notFoundFallback:/pets/v1"}
+	if !reflect.DeepEqual(sortedFallbackRoutes, expectedFallbackRoutes) {
+		t.Errorf("expected the fallback routes %v after the method not allowed routes but found %v",
+			expectedFallbackRoutes, routes)
+	}
+	// The catch-all route is added once per vhost, as it is enabled for one of the APIs of the vhost
+	if routes[9] != "vhostCatchAll:api.wso2.com" {
+		t.Errorf("expected the catch-all route of the vhost to be the last route but found %v", routes)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"errors"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// OrgStats holds the deployment statistics of an organization, used for capacity planning.
+type OrgStats struct {
+	OrganizationID string
+	// APIs is the number of APIs deployed by the organization, where an API deployed to multiple vhosts is
+	// counted once.
+	APIs int
+	// Deployments is the number of vhosts the APIs of the organization are deployed to, summed over the APIs.
+	Deployments int
+	// Operations and Endpoints are summed over the deployments, as the router serves each deployment separately.
+	Operations int
+	Endpoints  int
+}
+
+// GetOrganizationStats returns the number of APIs, operations and endpoints deployed by the organization.
+//
+// An error with the message constants.NotFound is returned, if the organization has not deployed any API.
+func GetOrganizationStats(organizationID string) (OrgStats, error) {
+	mutexForInternalMapUpdate.RLock()
+	defer mutexForInternalMapUpdate.RUnlock()
+
+	stats := OrgStats{OrganizationID: organizationID}
+	if len(orgIDAPIMgwSwaggerMap[organizationID]) == 0 {
+		return stats, errors.New(constants.NotFound)
+	}
+	stats.APIs = countDeployedAPIs(organizationID)
+	for apiIdentifier, mgwSwagger := range orgIDAPIMgwSwaggerMap[organizationID] {
+		stats.Deployments++
+		for _, resource := range mgwSwagger.GetResources() {
+			stats.Operations += len(resource.GetOperations())
+		}
+		stats.Endpoints += len(orgIDOpenAPIEndpointsMap[organizationID][apiIdentifier])
+	}
+	return stats, nil
+}
@@ -0,0 +1,88 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+const (
+	petsOpenAPIForStatsTests = `{"openapi": "3.0.0", "info": {"title": "Pets", "version": "v1"},
+		"paths": {
+			"/pets": {"get": {"responses": {"200": {"description": "OK"}}},
+				"post": {"responses": {"201": {"description": "Created"}}}},
+			"/pets/{petId}": {"get": {"responses": {"200": {"description": "OK"}}}}}}`
+	inventoryOpenAPIForStatsTests = `{"openapi": "3.0.0", "info": {"title": "Inventory", "version": "v1"},
+		"paths": {"/items": {"get": {"responses": {"200": {"description": "OK"}}}}}}`
+)
+
+// deployAPIForStatsTests adds an API with the operations of the OpenAPI definition and the given number of
+// endpoints to the internal maps, the same way UpdateAPI does.
+func deployAPIForStatsTests(t *testing.T, organizationID, vhost, openAPI string, endpointCount int) {
+	var mgwSwagger model.MgwSwagger
+	if err := mgwSwagger.GetMgwSwagger([]byte(openAPI)); err != nil {
+		t.Fatalf("error while parsing the OpenAPI definition %v", err)
+	}
+	deployAPIForListingTests(organizationID, vhost, mgwSwagger.GetTitle(), []string{"Default"})
+	_, _, endpoints, _ := getTestXdsResources(mgwSwagger.GetTitle(), 0, 0, endpointCount)
+	apiIdentifier := GenerateIdentifierForAPIWithUUID(vhost, mgwSwagger.GetTitle())
+
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier] = mgwSwagger
+	if _, ok := orgIDOpenAPIEndpointsMap[organizationID]; !ok {
+		orgIDOpenAPIEndpointsMap[organizationID] = make(map[string][]*corev3.Address)
+	}
+	orgIDOpenAPIEndpointsMap[organizationID][apiIdentifier] = endpoints
+}
+
+func TestGetOrganizationStats(t *testing.T) {
+	resetInternalMapsForNodeGroupTests()
+	defer resetInternalMapsForNodeGroupTests()
+
+	deployAPIForStatsTests(t, "org1", "us.wso2.com", petsOpenAPIForStatsTests, 2)
+	deployAPIForStatsTests(t, "org1", "eu.wso2.com", petsOpenAPIForStatsTests, 2)
+	deployAPIForStatsTests(t, "org1", "eu.wso2.com", inventoryOpenAPIForStatsTests, 1)
+	deployAPIForStatsTests(t, "org2", "eu.wso2.com", inventoryOpenAPIForStatsTests, 3)
+
+	expectedStats := map[string]OrgStats{
+		// An API deployed to multiple vhosts is counted once, but its operations and endpoints for each vhost
+		"org1": {OrganizationID: "org1", APIs: 2, Deployments: 3, Operations: 7, Endpoints: 5},
+		"org2": {OrganizationID: "org2", APIs: 1, Deployments: 1, Operations: 1, Endpoints: 3},
+	}
+	for organizationID, expected := range expectedStats {
+		if stats, err := GetOrganizationStats(organizationID); err != nil || stats != expected {
+			t.Errorf("expected the stats %+v of the organization %v, but found %+v, error %v", expected,
+				organizationID, stats, err)
+		}
+	}
+
+	undeployAPIForListingTests("org1", "us.wso2.com", "Pets")
+	expected := OrgStats{OrganizationID: "org1", APIs: 2, Deployments: 2, Operations: 4, Endpoints: 3}
+	if stats, err := GetOrganizationStats("org1"); err != nil || stats != expected {
+		t.Errorf("expected the stats %+v after undeploying an API, but found %+v, error %v", expected, stats, err)
+	}
+
+	if _, err := GetOrganizationStats("org3"); err == nil || err.Error() != constants.NotFound {
+		t.Errorf("expected the organization without APIs not to be found, but found error %v", err)
+	}
+}
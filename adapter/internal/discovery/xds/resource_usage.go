@@ -0,0 +1,211 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"sort"
+	"sync"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
+	"google.golang.org/protobuf/proto"
+)
+
+// APIResourceUsage holds the number of router resources generated for an API and their serialized size.
+type APIResourceUsage struct {
+	APIIdentifier  string
+	OrganizationID string
+	Routes         int
+	Clusters       int
+	Endpoints      int
+	SizeInBytes    int
+}
+
+// OrganizationResourceUsage holds the number of router resources generated for the APIs of an organization
+// and their serialized size.
+type OrganizationResourceUsage struct {
+	OrganizationID string
+	APIs           int
+	Routes         int
+	Clusters       int
+	Endpoints      int
+	SizeInBytes    int
+}
+
+// SnapshotResourceUsage holds the serialized size of the latest router configuration snapshot of a label.
+type SnapshotResourceUsage struct {
+	Label       string
+	SizeInBytes int
+}
+
+// ResourceUsage holds the resource usage of the snapshots, organizations and APIs, each sorted by the size
+// in the descending order.
+type ResourceUsage struct {
+	Snapshots     []SnapshotResourceUsage
+	Organizations []OrganizationResourceUsage
+	APIs          []APIResourceUsage
+}
+
+var (
+	mutexForResourceUsage sync.RWMutex
+	// organizationID -> Vhost:API_UUID -> resource usage of the API
+	orgIDAPIResourceUsageMap = make(map[string]map[string]APIResourceUsage)
+	// label -> serialized size of the latest snapshot
+	snapshotSizeMap = make(map[string]int)
+)
+
+func isResourceUsageEnabled() bool {
+	conf, _ := config.ReadConfigs()
+	return conf.Adapter.ResourceUsage.Enabled
+}
+
+// updateAPIResourceUsage accounts the router resources generated for an API. The size is computed using the
+// proto Size of each resource, hence the resources are not marshalled for the accounting.
+func updateAPIResourceUsage(organizationID, apiIdentifier string, routes []*routev3.Route,
+	clusters []*clusterv3.Cluster, endpoints []*corev3.Address) {
+	if !isResourceUsageEnabled() {
+		return
+	}
+	usage := APIResourceUsage{
+		APIIdentifier:  apiIdentifier,
+		OrganizationID: organizationID,
+		Routes:         len(routes),
+		Clusters:       len(clusters),
+		Endpoints:      len(endpoints),
+	}
+	for _, route := range routes {
+		usage.SizeInBytes += proto.Size(route)
+	}
+	for _, cluster := range clusters {
+		usage.SizeInBytes += proto.Size(cluster)
+	}
+	for _, endpoint := range endpoints {
+		usage.SizeInBytes += proto.Size(endpoint)
+	}
+
+	mutexForResourceUsage.Lock()
+	defer mutexForResourceUsage.Unlock()
+	if _, ok := orgIDAPIResourceUsageMap[organizationID]; !ok {
+		orgIDAPIResourceUsageMap[organizationID] = make(map[string]APIResourceUsage)
+	}
+	orgIDAPIResourceUsageMap[organizationID][apiIdentifier] = usage
+	metrics.SetAPIXdsResourceUsage(organizationID, apiIdentifier, usage.Routes, usage.Clusters, usage.Endpoints,
+		usage.SizeInBytes)
+	updateOrganizationResourceUsageMetrics(organizationID)
+}
+
+// removeAPIResourceUsage removes the accounting of an undeployed API.
+func removeAPIResourceUsage(organizationID, apiIdentifier string) {
+	mutexForResourceUsage.Lock()
+	defer mutexForResourceUsage.Unlock()
+	if _, ok := orgIDAPIResourceUsageMap[organizationID][apiIdentifier]; !ok {
+		return
+	}
+	delete(orgIDAPIResourceUsageMap[organizationID], apiIdentifier)
+	metrics.DeleteAPIXdsResourceUsage(organizationID, apiIdentifier)
+	if len(orgIDAPIResourceUsageMap[organizationID]) == 0 {
+		delete(orgIDAPIResourceUsageMap, organizationID)
+		metrics.DeleteOrganizationXdsResourceUsage(organizationID)
+		return
+	}
+	updateOrganizationResourceUsageMetrics(organizationID)
+}
+
+// updateSnapshotResourceUsage accounts the serialized size of the snapshot published for the label.
+func updateSnapshotResourceUsage(label string, resourceArrays ...[]types.Resource) {
+	if !isResourceUsageEnabled() {
+		return
+	}
+	size := 0
+	for _, resources := range resourceArrays {
+		for _, resource := range resources {
+			size += proto.Size(resource)
+		}
+	}
+	mutexForResourceUsage.Lock()
+	defer mutexForResourceUsage.Unlock()
+	snapshotSizeMap[label] = size
+	metrics.SetXdsSnapshotSize(label, size)
+}
+
+// updateOrganizationResourceUsageMetrics should be called while holding the mutexForResourceUsage.
+func updateOrganizationResourceUsageMetrics(organizationID string) {
+	usage := getOrganizationResourceUsage(organizationID)
+	metrics.SetOrganizationXdsResourceUsage(organizationID, usage.Routes, usage.Clusters, usage.Endpoints,
+		usage.SizeInBytes)
+}
+
+func getOrganizationResourceUsage(organizationID string) OrganizationResourceUsage {
+	usage := OrganizationResourceUsage{OrganizationID: organizationID}
+	for _, apiUsage := range orgIDAPIResourceUsageMap[organizationID] {
+		usage.APIs++
+		usage.Routes += apiUsage.Routes
+		usage.Clusters += apiUsage.Clusters
+		usage.Endpoints += apiUsage.Endpoints
+		usage.SizeInBytes += apiUsage.SizeInBytes
+	}
+	return usage
+}
+
+// GetResourceUsage returns the resource usage of the snapshots, organizations and APIs sorted by the size,
+// so that the largest contributors are listed first.
+func GetResourceUsage() ResourceUsage {
+	mutexForResourceUsage.RLock()
+	defer mutexForResourceUsage.RUnlock()
+	usage := ResourceUsage{
+		Snapshots:     make([]SnapshotResourceUsage, 0, len(snapshotSizeMap)),
+		Organizations: make([]OrganizationResourceUsage, 0, len(orgIDAPIResourceUsageMap)),
+		APIs:          make([]APIResourceUsage, 0),
+	}
+	for label, size := range snapshotSizeMap {
+		usage.Snapshots = append(usage.Snapshots, SnapshotResourceUsage{Label: label, SizeInBytes: size})
+	}
+	for organizationID, apiUsageMap := range orgIDAPIResourceUsageMap {
+		usage.Organizations = append(usage.Organizations, getOrganizationResourceUsage(organizationID))
+		for _, apiUsage := range apiUsageMap {
+			usage.APIs = append(usage.APIs, apiUsage)
+		}
+	}
+	// Ties are ordered by the name to keep the response stable
+	sort.Slice(usage.Snapshots, func(i, j int) bool {
+		if usage.Snapshots[i].SizeInBytes != usage.Snapshots[j].SizeInBytes {
+			return usage.Snapshots[i].SizeInBytes > usage.Snapshots[j].SizeInBytes
+		}
+		return usage.Snapshots[i].Label < usage.Snapshots[j].Label
+	})
+	sort.Slice(usage.Organizations, func(i, j int) bool {
+		if usage.Organizations[i].SizeInBytes != usage.Organizations[j].SizeInBytes {
+			return usage.Organizations[i].SizeInBytes > usage.Organizations[j].SizeInBytes
+		}
+		return usage.Organizations[i].OrganizationID < usage.Organizations[j].OrganizationID
+	})
+	sort.Slice(usage.APIs, func(i, j int) bool {
+		if usage.APIs[i].SizeInBytes != usage.APIs[j].SizeInBytes {
+			return usage.APIs[i].SizeInBytes > usage.APIs[j].SizeInBytes
+		}
+		if usage.APIs[i].OrganizationID != usage.APIs[j].OrganizationID {
+			return usage.APIs[i].OrganizationID < usage.APIs[j].OrganizationID
+		}
+		return usage.APIs[i].APIIdentifier < usage.APIs[j].APIIdentifier
+	})
+	return usage
+}
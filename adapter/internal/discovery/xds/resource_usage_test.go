@@ -0,0 +1,155 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"fmt"
+	"testing"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"google.golang.org/protobuf/proto"
+)
+
+func getTestXdsResources(apiName string, routeCount, clusterCount, endpointCount int) ([]*routev3.Route,
+	[]*clusterv3.Cluster, []*corev3.Address, int) {
+	var routes []*routev3.Route
+	var clusters []*clusterv3.Cluster
+	var endpoints []*corev3.Address
+	size := 0
+	for i := 0; i < routeCount; i++ {
+		route := &routev3.Route{Name: fmt.Sprintf("/%s/resource%d", apiName, i)}
+		routes = append(routes, route)
+		size += proto.Size(route)
+	}
+	for i := 0; i < clusterCount; i++ {
+		cluster := &clusterv3.Cluster{Name: fmt.Sprintf("carbon.super_clusterProd_localhost_%s%d", apiName, i)}
+		clusters = append(clusters, cluster)
+		size += proto.Size(cluster)
+	}
+	for i := 0; i < endpointCount; i++ {
+		endpoint := &corev3.Address{Address: &corev3.Address_SocketAddress{SocketAddress: &corev3.SocketAddress{
+			Address:       fmt.Sprintf("%s-backend-%d.wso2.com", apiName, i),
+			PortSpecifier: &corev3.SocketAddress_PortValue{PortValue: 443},
+		}}}
+		endpoints = append(endpoints, endpoint)
+		size += proto.Size(endpoint)
+	}
+	return routes, clusters, endpoints, size
+}
+
+func resetResourceUsage() {
+	mutexForResourceUsage.Lock()
+	defer mutexForResourceUsage.Unlock()
+	orgIDAPIResourceUsageMap = make(map[string]map[string]APIResourceUsage)
+	snapshotSizeMap = make(map[string]int)
+}
+
+func TestResourceUsageAccounting(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousEnabled := conf.Adapter.ResourceUsage.Enabled
+	defer func() {
+		conf.Adapter.ResourceUsage.Enabled = previousEnabled
+		resetResourceUsage()
+	}()
+	conf.Adapter.ResourceUsage.Enabled = true
+	resetResourceUsage()
+
+	petstoreRoutes, petstoreClusters, petstoreEndpoints, petstoreSize := getTestXdsResources("petstore", 2, 1, 1)
+	inventoryRoutes, inventoryClusters, inventoryEndpoints, inventorySize := getTestXdsResources("inventory", 12, 3, 4)
+	pizzaRoutes, pizzaClusters, pizzaEndpoints, pizzaSize := getTestXdsResources("pizza", 5, 2, 2)
+	updateAPIResourceUsage("org1", "localhost:petstore", petstoreRoutes, petstoreClusters, petstoreEndpoints)
+	updateAPIResourceUsage("org1", "localhost:inventory", inventoryRoutes, inventoryClusters, inventoryEndpoints)
+	updateAPIResourceUsage("org2", "localhost:pizza", pizzaRoutes, pizzaClusters, pizzaEndpoints)
+
+	usage := GetResourceUsage()
+	expectedAPIOrder := []APIResourceUsage{
+		{APIIdentifier: "localhost:inventory", OrganizationID: "org1", Routes: 12, Clusters: 3, Endpoints: 4, SizeInBytes: inventorySize},
+		{APIIdentifier: "localhost:pizza", OrganizationID: "org2", Routes: 5, Clusters: 2, Endpoints: 2, SizeInBytes: pizzaSize},
+		{APIIdentifier: "localhost:petstore", OrganizationID: "org1", Routes: 2, Clusters: 1, Endpoints: 1, SizeInBytes: petstoreSize},
+	}
+	if len(usage.APIs) != len(expectedAPIOrder) {
+		t.Fatalf("expected the resource usage of %v APIs but found %v", len(expectedAPIOrder), len(usage.APIs))
+	}
+	for i, expected := range expectedAPIOrder {
+		if usage.APIs[i] != expected {
+			t.Errorf("expected the resource usage %+v at index %v but found %+v", expected, i, usage.APIs[i])
+		}
+	}
+
+	expectedOrgOrder := []OrganizationResourceUsage{
+		{OrganizationID: "org1", APIs: 2, Routes: 14, Clusters: 4, Endpoints: 5, SizeInBytes: inventorySize + petstoreSize},
+		{OrganizationID: "org2", APIs: 1, Routes: 5, Clusters: 2, Endpoints: 2, SizeInBytes: pizzaSize},
+	}
+	if len(usage.Organizations) != len(expectedOrgOrder) {
+		t.Fatalf("expected the resource usage of %v organizations but found %v", len(expectedOrgOrder),
+			len(usage.Organizations))
+	}
+	for i, expected := range expectedOrgOrder {
+		if usage.Organizations[i] != expected {
+			t.Errorf("expected the resource usage %+v at index %v but found %+v", expected, i, usage.Organizations[i])
+		}
+	}
+
+	// Redeploying an API replaces its previous accounting
+	petstoreRoutes, petstoreClusters, petstoreEndpoints, petstoreSize = getTestXdsResources("petstore", 1, 1, 1)
+	updateAPIResourceUsage("org1", "localhost:petstore", petstoreRoutes, petstoreClusters, petstoreEndpoints)
+	removeAPIResourceUsage("org2", "localhost:pizza")
+	usage = GetResourceUsage()
+	if len(usage.APIs) != 2 || len(usage.Organizations) != 1 {
+		t.Fatalf("expected the resource usage of 2 APIs in 1 organization but found %v APIs in %v organizations",
+			len(usage.APIs), len(usage.Organizations))
+	}
+	if usage.Organizations[0].Routes != 13 || usage.Organizations[0].SizeInBytes != inventorySize+petstoreSize {
+		t.Errorf("organization resource usage is not updated after the redeployment, found %+v", usage.Organizations[0])
+	}
+
+	routes := []types.Resource{inventoryRoutes[0], inventoryRoutes[1]}
+	clusters := []types.Resource{inventoryClusters[0]}
+	updateSnapshotResourceUsage("Default", routes, clusters)
+	updateSnapshotResourceUsage("us-region", routes)
+	usage = GetResourceUsage()
+	expectedDefaultSize := proto.Size(inventoryRoutes[0]) + proto.Size(inventoryRoutes[1]) + proto.Size(inventoryClusters[0])
+	if len(usage.Snapshots) != 2 || usage.Snapshots[0].Label != "Default" ||
+		usage.Snapshots[0].SizeInBytes != expectedDefaultSize {
+		t.Errorf("expected the snapshot of Default label with %v bytes first but found %+v", expectedDefaultSize,
+			usage.Snapshots)
+	}
+}
+
+func TestResourceUsageAccountingWhenDisabled(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousEnabled := conf.Adapter.ResourceUsage.Enabled
+	defer func() {
+		conf.Adapter.ResourceUsage.Enabled = previousEnabled
+		resetResourceUsage()
+	}()
+	conf.Adapter.ResourceUsage.Enabled = false
+	resetResourceUsage()
+
+	routes, clusters, endpoints, _ := getTestXdsResources("petstore", 2, 1, 1)
+	updateAPIResourceUsage("org1", "localhost:petstore", routes, clusters, endpoints)
+	updateSnapshotResourceUsage("Default", []types.Resource{routes[0]})
+	usage := GetResourceUsage()
+	if len(usage.APIs) != 0 || len(usage.Organizations) != 0 || len(usage.Snapshots) != 0 {
+		t.Errorf("resource usage should not be computed when disabled, found %+v", usage)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -32,6 +33,7 @@ import (
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	rlv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	envoy_cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 
@@ -60,7 +62,7 @@ var (
 	mutexForXdsUpdate   sync.Mutex
 	mutexForCacheUpdate sync.Mutex
 
-	mutexForInternalMapUpdate sync.Mutex
+	mutexForInternalMapUpdate sync.RWMutex
 
 	cache                              envoy_cachev3.SnapshotCache
 	enforcerCache                      wso2_cache.SnapshotCache
@@ -91,10 +93,10 @@ var (
 
 	// Envoy Label as map key
 	envoyUpdateVersionMap  map[string]int64                       // GW-Label -> XDS version map
-	envoyListenerConfigMap map[string][]*listenerv3.Listener      // GW-Label -> Listener Configuration map
-	envoyRouteConfigMap    map[string]*routev3.RouteConfiguration // GW-Label -> Routes Configuration map
-	envoyClusterConfigMap  map[string][]*clusterv3.Cluster        // GW-Label -> Global Cluster Configuration map
-	envoyEndpointConfigMap map[string][]*corev3.Address           // GW-Label -> Global Endpoint Configuration map
+	envoyListenerConfigMap map[string][]*listenerv3.Listener      // Node group -> Listener Configuration map
+	envoyRouteConfigMap    map[string]*routev3.RouteConfiguration // Node group -> Routes Configuration map
+	envoyClusterConfigMap  map[string][]*clusterv3.Cluster        // GW-Label or Node group -> Global Cluster Configuration map
+	envoyEndpointConfigMap map[string][]*corev3.Address           // GW-Label or Node group -> Global Endpoint Configuration map
 
 	// Common Enforcer Label as map key
 	enforcerConfigMap                map[string][]types.Resource
@@ -136,7 +138,7 @@ func (IDHash) ID(node *corev3.Node) string {
 var _ envoy_cachev3.NodeHash = IDHash{}
 
 func init() {
-	cache = envoy_cachev3.NewSnapshotCache(false, IDHash{}, nil)
+	cache = envoy_cachev3.NewSnapshotCache(false, NodeGroupHash{}, nil)
 	enforcerCache = wso2_cache.NewSnapshotCache(false, IDHash{}, nil)
 	enforcerSubscriptionCache = wso2_cache.NewSnapshotCache(false, IDHash{}, nil)
 	enforcerApplicationCache = wso2_cache.NewSnapshotCache(false, IDHash{}, nil)
@@ -240,18 +242,14 @@ func GetEnforcerThrottleDataCache() wso2_cache.SnapshotCache {
 func DeployReadinessAPI(envs []string) {
 	logger.LoggerXds.Infof("Finished fetching APIs from the Control Plane. Deploying the readiness endpoint...")
 	isReady = true
-	for _, env := range envs {
-		listeners, clusters, routes, endpoints, apis := GenerateEnvoyResoucesForLabel(env)
-		UpdateXdsCacheWithLock(env, endpoints, clusters, routes, listeners)
-		UpdateEnforcerApis(env, apis, "")
-	}
+	UpdateXdsCacheForLabels(envs)
 }
 
-// UpdateAPI updates the Xds Cache when OpenAPI Json content is provided
-func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string) (*notifier.DeployedAPIRevision, error) {
-	var mgwSwagger model.MgwSwagger
+// UpdateAPI updates the Xds Cache when OpenAPI Json content is provided. If staged is true, the API is validated and
+// stored without serving its routes until it is activated via ActivateAPI.
+func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string, staged bool) (*notifier.DeployedAPIRevision,
+	error) {
 	var deployedRevision *notifier.DeployedAPIRevision
-	var err error
 	var newLabels []string
 	apiYaml := apiProject.APIYaml.Data
 
@@ -266,6 +264,196 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 		environments = []string{config.DefaultGatewayName}
 	}
 
+	mgwSwagger, err := populateMgwSwagger(vHost, apiProject, environments)
+	if err != nil {
+		return nil, err
+	}
+	organizationID := apiYaml.OrganizationID
+	uniqueIdentifier := getUniqueIdentifier(apiYaml.ID, apiYaml.Name, apiYaml.Version)
+
+	reverseAPINameVersionMap[GenerateIdentifierForAPIWithoutVhost(apiYaml.Name, apiYaml.Version)] = uniqueIdentifier
+	apiIdentifier := GenerateIdentifierForAPIWithUUID(vHost, uniqueIdentifier)
+
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+
+	// -------- Begin updating maps
+
+	err = addBasepathToMap(mgwSwagger, organizationID, vHost, apiIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the map from organizationID map.
+	if _, ok := orgIDAPIMgwSwaggerMap[organizationID]; ok {
+		orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier] = mgwSwagger
+	} else {
+		mgwSwaggerMap := make(map[string]model.MgwSwagger)
+		mgwSwaggerMap[apiIdentifier] = mgwSwagger
+		orgIDAPIMgwSwaggerMap[organizationID] = mgwSwaggerMap
+	}
+	indexAPIForListing(organizationID, apiIdentifier, mgwSwagger)
+
+	//TODO: (VirajSalaka) Handle OpenAPIs which does not have label (Current Impl , it will be labelled as default)
+	// TODO: commented the following line as the implementation is not supported yet.
+	//newLabels = model.GetXWso2Label(openAPIV3Struct.ExtensionProps)
+	//:TODO: since currently labels are not taking from x-wso2-label, I have made it to be taken from the method
+	// argument.
+	newLabels = environments
+	logger.LoggerXds.Infof("Added/Updated the content for Organization : %v under OpenAPI Key : %v", organizationID, apiIdentifier)
+	logger.LoggerXds.Debugf("Newly added labels for Organization : %v for the OpenAPI Key : %v are %v", organizationID, apiIdentifier, newLabels)
+	oldLabels, _ := orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier]
+	logger.LoggerXds.Debugf("Already existing labels for the OpenAPI Key : %v are %v", apiIdentifier, oldLabels)
+
+	if _, ok := orgIDOpenAPIEnvoyMap[organizationID]; ok {
+		orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier] = newLabels
+	} else {
+		openAPIEnvoyMap := make(map[string][]string)
+		openAPIEnvoyMap[apiIdentifier] = newLabels
+		orgIDOpenAPIEnvoyMap[organizationID] = openAPIEnvoyMap
+	}
+	updateVhostInternalMaps(apiYaml.ID, apiYaml.Name, apiYaml.Version, vHost, newLabels)
+	recordAPIDeployment(organizationID, apiIdentifier, uniqueIdentifier, apiYaml.RevisionID,
+		mgwSwagger.GetUpstreamBasepath(), staged)
+
+	certMap, interceptCertMap := getCertMaps(apiProject)
+
+	// Names generated for the previous deployment of the API are released, so that those are reused
+	// with the latest naming template.
+	envoyconf.ReleaseGeneratedNames(mgwSwagger, vHost)
+	routes, clusters, endpoints, err := oasParser.GetRoutesClustersEndpoints(mgwSwagger, certMap,
+		interceptCertMap, vHost, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("Error while deploying API. Name: %s Version: %s, OrgID: %s, Error: %s",
+			mgwSwagger.GetTitle(), mgwSwagger.GetVersion(), organizationID, err.Error())
+	}
+
+	if _, ok := orgIDOpenAPIRoutesMap[organizationID]; ok {
+		orgIDOpenAPIRoutesMap[organizationID][apiIdentifier] = routes
+	} else {
+		routesMap := make(map[string][]*routev3.Route)
+		routesMap[apiIdentifier] = routes
+		orgIDOpenAPIRoutesMap[organizationID] = routesMap
+	}
+
+	if _, ok := orgIDOpenAPIClustersMap[organizationID]; ok {
+		orgIDOpenAPIClustersMap[organizationID][apiIdentifier] = clusters
+	} else {
+		clustersMap := make(map[string][]*clusterv3.Cluster)
+		clustersMap[apiIdentifier] = clusters
+		orgIDOpenAPIClustersMap[organizationID] = clustersMap
+	}
+
+	if _, ok := orgIDOpenAPIEndpointsMap[organizationID]; ok {
+		orgIDOpenAPIEndpointsMap[organizationID][apiIdentifier] = endpoints
+	} else {
+		endpointMap := make(map[string][]*corev3.Address)
+		endpointMap[apiIdentifier] = endpoints
+		orgIDOpenAPIEndpointsMap[organizationID] = endpointMap
+	}
+	updateAPIResourceUsage(organizationID, apiIdentifier, routes, clusters, endpoints)
+
+	if _, ok := orgIDOpenAPIEnforcerApisMap[organizationID]; ok {
+		orgIDOpenAPIEnforcerApisMap[organizationID][apiIdentifier] = oasParser.GetEnforcerAPI(mgwSwagger, vHost)
+	} else {
+		enforcerAPIMap := make(map[string]types.Resource)
+		enforcerAPIMap[apiIdentifier] = oasParser.GetEnforcerAPI(mgwSwagger, vHost)
+		orgIDOpenAPIEnforcerApisMap[organizationID] = enforcerAPIMap
+	}
+
+	// TODO: (VirajSalaka) Fault tolerance mechanism implementation
+	revisionStatus := updateXdsCacheOnAPIAdd(oldLabels, newLabels)
+	if staged {
+		logger.LoggerXds.Infof("API %v of organization %v is staged. It is served once activated.", apiIdentifier,
+			organizationID)
+	} else if revisionStatus {
+		// send updated revision to control plane
+		deployedRevision = notifier.UpdateDeployedRevisions(apiYaml.ID, apiYaml.RevisionID, environments,
+			vHost)
+	}
+	if svcdiscovery.IsServiceDiscoveryEnabled {
+		startConsulServiceDiscovery(organizationID) //consul service discovery starting point
+	}
+	return deployedRevision, nil
+}
+
+// APIUpdatePlan is the summary of the changes an API deployment would apply to a vhost.
+type APIUpdatePlan struct {
+	APIIdentifier  string
+	OrganizationID string
+	VHost          string
+	Environments   []string
+	// ExistingEnvironments are the environments the API is already deployed in the vhost.
+	// Empty if the deployment would create the API.
+	ExistingEnvironments []string
+	IsUpdate             bool
+	Routes               []string
+	Clusters             []string
+	EndpointCount        int
+}
+
+// PlanAPIUpdate computes the routes, clusters and endpoints that UpdateAPI would apply for the given API project,
+// vhost and environments, without updating the internal maps or the xDS caches. It should be called in the
+// deployment queue of the API, so that the plan is not computed while the API is deployed.
+func PlanAPIUpdate(vHost string, apiProject model.ProjectAPI, environments []string) (*APIUpdatePlan, error) {
+	apiYaml := apiProject.APIYaml.Data
+	if len(environments) == 0 {
+		environments = []string{config.DefaultGatewayName}
+	}
+
+	mgwSwagger, err := populateMgwSwagger(vHost, apiProject, environments)
+	if err != nil {
+		return nil, err
+	}
+	organizationID := apiYaml.OrganizationID
+	apiIdentifier := GenerateIdentifierForAPIWithUUID(vHost, getUniqueIdentifier(apiYaml.ID, apiYaml.Name, apiYaml.Version))
+
+	mutexForInternalMapUpdate.Lock()
+	existingLabels, isUpdate := orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier]
+	// The basepath would be rejected by UpdateAPI if it is used by another API
+	err = validateBasepath(mgwSwagger, organizationID, vHost, apiIdentifier)
+	mutexForInternalMapUpdate.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	certMap, interceptCertMap := getCertMaps(apiProject)
+	var routes []*routev3.Route
+	var clusters []*clusterv3.Cluster
+	var endpoints []*corev3.Address
+	// The names generated for the API should not be kept, as the plan is not applied.
+	envoyconf.PlanGeneratedNames(mgwSwagger, vHost, func() {
+		routes, clusters, endpoints, err = oasParser.GetRoutesClustersEndpoints(mgwSwagger, certMap,
+			interceptCertMap, vHost, organizationID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error while computing the deployment of API. Name: %s Version: %s, OrgID: %s, Error: %s",
+			mgwSwagger.GetTitle(), mgwSwagger.GetVersion(), organizationID, err.Error())
+	}
+
+	plan := &APIUpdatePlan{
+		APIIdentifier:        apiIdentifier,
+		OrganizationID:       organizationID,
+		VHost:                vHost,
+		Environments:         environments,
+		ExistingEnvironments: existingLabels,
+		IsUpdate:             isUpdate,
+		EndpointCount:        len(endpoints),
+	}
+	for _, route := range routes {
+		plan.Routes = append(plan.Routes, route.GetName())
+	}
+	for _, cluster := range clusters {
+		plan.Clusters = append(plan.Clusters, cluster.GetName())
+	}
+	return plan, nil
+}
+
+// populateMgwSwagger creates the MgwSwagger struct of the API project to be deployed in the given vhost and
+// environments and validates it. The API project is not modified.
+func populateMgwSwagger(vHost string, apiProject model.ProjectAPI, environments []string) (mgwSwagger model.MgwSwagger,
+	err error) {
+	apiYaml := apiProject.APIYaml.Data
 	var apiEnvProps synchronizer.APIEnvProps
 
 	// TODO(amali) under the assumption vhost has one environment at the moment
@@ -276,24 +464,30 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 	err = apiProject.APIYaml.ValidateAPIType()
 	if err != nil {
 		logger.LoggerXds.Error("Error while populating swagger from api.yaml. ", err)
-		return nil, err
+		return mgwSwagger, err
 	}
 
+	mgwSwagger.RecordProvenanceValue(model.ProvenanceFieldVhost, getVhostProvenanceSource(vHost, environments[0]), vHost)
+	mgwSwagger.SetSkippedFiles(apiProject.SkippedFiles)
 	err = mgwSwagger.PopulateFromAPIYaml(apiProject.APIYaml)
 	if err != nil {
-		return nil, err
+		return mgwSwagger, err
 	}
+	mgwSwagger.RecordProvenance(model.ProvenanceSourceAPIYaml)
 
 	err = mgwSwagger.GetMgwSwagger(apiProject.APIDefinition)
 	if err != nil {
 		logger.LoggerXds.Error("Error while populating swagger from api definition. ", err)
-		return nil, err
+		return mgwSwagger, err
 	}
+	mgwSwagger.RecordProvenance(model.ProvenanceSourceAPIDefinition)
+	mgwSwagger.AddUnsupportedFeatureWarnings(apiProject.GetUnsupportedFeatures())
 
 	// Set the following in case they were overridden by the above line
 	mgwSwagger.SetID(apiYaml.ID)
 	mgwSwagger.SetName(apiYaml.Name)
 	mgwSwagger.SetVersion(apiYaml.Version)
+	mgwSwagger.SetDefinitionHash(apiProject.DefinitionHash)
 
 	if apiYaml.APIType == constants.HTTP || apiYaml.APIType == constants.GRAPHQL || apiYaml.APIType == constants.SOAP {
 		// avoid the following for AsyncAPI types
@@ -304,6 +498,10 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 		isYamlMutualssl := false
 		isYamlMutualsslMandatory := false
 		isYamlOauthBasicAuthAPIKeyMandatory := false
+		if unrecognizedSchemes := apiProject.APIYaml.GetUnrecognizedSecuritySchemes(); len(unrecognizedSchemes) > 0 {
+			logger.LoggerXds.Warnf("Security schemes %v in api.yaml are not recognized, hence ignored for API %v:%v",
+				unrecognizedSchemes, apiYaml.Name, apiYaml.Version)
+		}
 		for _, value := range apiYaml.SecurityScheme {
 			switch value {
 			case constants.APIMAPIKeyType:
@@ -335,15 +533,39 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 				Severity:  logging.MINOR,
 				ErrorCode: 1416,
 			})
-			return nil, err
+			return mgwSwagger, err
 		}
+	} else if err = mgwSwagger.ValidateOperationPolicies(apiProject); err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Operation policies are not compatible with the %s API %s:%s of Organization %s. %s",
+				apiYaml.APIType, apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 1419,
+		})
+		return mgwSwagger, err
 	}
 
 	if apiYaml.APIType == constants.GRAPHQL {
 		mgwSwagger.GraphQLComplexities = apiProject.GraphQLComplexities
 	}
 	mgwSwagger.SetXWso2AuthHeader(apiYaml.AuthorizationHeader)
+	mgwSwagger.RecordProvenance(model.ProvenanceSourceAPIYaml)
+	apiParams, apiParamsFound := apiProject.APIParams[environments[0]]
+	apiParamsSource := model.GetAPIParamsProvenanceSource(environments[0])
+	if apiParamsFound {
+		if err = mgwSwagger.SetAPIParamsEndpoints(apiParams); err != nil {
+			logger.LoggerXds.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Invalid endpoints in api_params of the environment %v for the API %s:%s of Organization %s. %v",
+					environments[0], apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID, err),
+				Severity:  logging.MINOR,
+				ErrorCode: 1424,
+			})
+			return mgwSwagger, err
+		}
+		mgwSwagger.RecordProvenance(apiParamsSource)
+	}
 	mgwSwagger.SetEnvLabelProperties(apiEnvProps)
+	mgwSwagger.RecordProvenance(model.ProvenanceSourceAPIEnvProps)
 	mgwSwagger.OrganizationID = apiYaml.OrganizationID
 	organizationID := apiYaml.OrganizationID
 	apiHashValue := generateHashValue(apiYaml.Name, apiYaml.Version)
@@ -361,8 +583,50 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 			mgwSwagger.GetSandEndpoints().SecurityConfig = apiYaml.EndpointConfig.APIEndpointSecurity.Sandbox
 		}
 	}
+	mgwSwagger.RecordProvenance(model.ProvenanceSourceAPIYaml)
+
+	if apiParamsFound {
+		if err = mgwSwagger.SetAPIParamsEndpointSecurity(apiParams); err != nil {
+			logger.LoggerXds.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Invalid endpoint security in api_params of the environment %v for the API %s:%s of Organization %s. %v",
+					environments[0], apiYaml.Name, apiYaml.Version, organizationID, err),
+				Severity:  logging.MINOR,
+				ErrorCode: 1424,
+			})
+			return mgwSwagger, err
+		}
+		mgwSwagger.RecordProvenance(apiParamsSource)
+	}
 
+	if err = mgwSwagger.SetEnvLabelEndpointSecurity(apiEnvProps); err != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Invalid endpoint security in env properties of the environment %v for the API %s:%s of Organization %s. %v",
+				environments[0], apiYaml.Name, apiYaml.Version, organizationID, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 1418,
+		})
+		return mgwSwagger, err
+	}
+	mgwSwagger.RecordProvenance(model.ProvenanceSourceAPIEnvProps)
 	mgwSwagger.SetEnvVariables(apiHashValue)
+	mgwSwagger.RecordProvenance(model.ProvenanceSourceEnvVariables)
+	conf, _ := config.ReadConfigs()
+	if mgwSwagger.GetXWSO2AuthHeader() == "" {
+		mgwSwagger.RecordProvenanceValue(model.ProvenanceFieldAuthorizationHeader, model.ProvenanceSourceAdapterDefault,
+			conf.Enforcer.Security.AuthHeader.AuthorizationHeader)
+	}
+	mgwSwagger.AddUnprotectedBackendWarnings()
+	mgwSwagger.AddUpstreamDecompressionWarnings()
+	mgwSwagger.AddResponseCacheWarnings()
+	if err = mgwSwagger.ValidateParseWarnings(conf.Adapter.FailOnParseWarnings); err != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("API %s:%s of Organization %s is rejected due to the warnings of its API definition. %v",
+				apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 1421,
+		})
+		return mgwSwagger, err
+	}
 
 	validationErr := mgwSwagger.Validate()
 	if validationErr != nil {
@@ -371,93 +635,88 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 			Severity:  logging.MINOR,
 			ErrorCode: 1405,
 		})
-		return nil, validationErr
+		return mgwSwagger, validationErr
 	}
 
-	// create client map for API
-	var clientCerts []model.Certificate
-	if len(apiProject.ClientCerts) > 0 && len(apiProject.DownstreamCerts) > 0 {
-		for _, certFile := range apiProject.ClientCerts {
-			var certificate model.Certificate
-			if certBytes, found := apiProject.DownstreamCerts[certFile.CertificateName]; found {
-				certificate.Alias = certFile.Alias
-				certificate.Tier = certFile.Tier
-				certificate.Content = certBytes
-				clientCerts = append(clientCerts, certificate)
-				delete(apiProject.DownstreamCerts, certFile.CertificateName)
-			} else {
-				logger.LoggerXds.ErrorC(logging.ErrorDetails{
-					Message: fmt.Sprintf("Certificate file %v not found for the alias %v in the API %s - %s:%s", certFile.CertificateName,
-						certFile.Alias, apiYaml.ID, apiYaml.Name, apiYaml.Version),
-					Severity:  logging.MINOR,
-					ErrorCode: 1415,
-				})
-			}
-		}
+	placeholderValues := model.PlaceholderValues{
+		Vhost:           vHost,
+		Context:         mgwSwagger.GetXWso2Basepath(),
+		Version:         apiYaml.Version,
+		EnvironmentName: environments[0],
 	}
-
-	mgwSwagger.SetClientCerts(clientCerts)
-
-	// -------- Finished updating mgwSwagger struct
-
-	uniqueIdentifier := apiYaml.ID
-
-	if uniqueIdentifier == "" {
-		// If API is imported from apictl generate hash as the unique ID
-		uniqueIdentifier = GenerateHashedAPINameVersionIDWithoutVhost(apiYaml.Name, apiYaml.Version)
+	if err = mgwSwagger.ResolvePlaceholders(placeholderValues); err != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while resolving the placeholders of the API %s:%s of Organization %s. %v",
+				apiYaml.Name, apiYaml.Version, organizationID, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 1422,
+		})
+		return mgwSwagger, err
 	}
 
-	reverseAPINameVersionMap[GenerateIdentifierForAPIWithoutVhost(apiYaml.Name, apiYaml.Version)] = uniqueIdentifier
-	apiIdentifier := GenerateIdentifierForAPIWithUUID(vHost, uniqueIdentifier)
-
-	mutexForInternalMapUpdate.Lock()
-	defer mutexForInternalMapUpdate.Unlock()
-
-	// -------- Begin updating maps
-
-	err = addBasepathToMap(mgwSwagger, organizationID, vHost, apiIdentifier)
-	if err != nil {
-		return nil, err
+	if err = envoyconf.ValidateRouteRegexes(mgwSwagger); err != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while generating the route regexes of the API %s:%s of Organization %s. %v",
+				apiYaml.Name, apiYaml.Version, organizationID, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 1423,
+		})
+		return mgwSwagger, err
 	}
 
-	// Get the map from organizationID map.
-	if _, ok := orgIDAPIMgwSwaggerMap[organizationID]; ok {
-		orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier] = mgwSwagger
-	} else {
-		mgwSwaggerMap := make(map[string]model.MgwSwagger)
-		mgwSwaggerMap[apiIdentifier] = mgwSwagger
-		orgIDAPIMgwSwaggerMap[organizationID] = mgwSwaggerMap
+	clientCerts, err := getClientCertificates(apiProject)
+	if err != nil {
+		return mgwSwagger, err
 	}
+	mgwSwagger.SetClientCerts(clientCerts)
+	return mgwSwagger, nil
+}
 
-	//TODO: (VirajSalaka) Handle OpenAPIs which does not have label (Current Impl , it will be labelled as default)
-	// TODO: commented the following line as the implementation is not supported yet.
-	//newLabels = model.GetXWso2Label(openAPIV3Struct.ExtensionProps)
-	//:TODO: since currently labels are not taking from x-wso2-label, I have made it to be taken from the method
-	// argument.
-	newLabels = environments
-	logger.LoggerXds.Infof("Added/Updated the content for Organization : %v under OpenAPI Key : %v", organizationID, apiIdentifier)
-	logger.LoggerXds.Debugf("Newly added labels for Organization : %v for the OpenAPI Key : %v are %v", organizationID, apiIdentifier, newLabels)
-	oldLabels, _ := orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier]
-	logger.LoggerXds.Debugf("Already existing labels for the OpenAPI Key : %v are %v", apiIdentifier, oldLabels)
-
-	if _, ok := orgIDOpenAPIEnvoyMap[organizationID]; ok {
-		orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier] = newLabels
-	} else {
-		openAPIEnvoyMap := make(map[string][]string)
-		openAPIEnvoyMap[apiIdentifier] = newLabels
-		orgIDOpenAPIEnvoyMap[organizationID] = openAPIEnvoyMap
+// getClientCertificates maps each alias in client_certificates.yaml to its certificate file and tier, so that
+// the enforcer can apply the tier of the certificate presented by the client. An error is returned if an alias
+// is duplicated or refers to a certificate file which is not in the API project.
+func getClientCertificates(apiProject model.ProjectAPI) ([]model.Certificate, error) {
+	apiYaml := apiProject.APIYaml.Data
+	var clientCerts []model.Certificate
+	aliases := make(map[string]struct{})
+	for _, certFile := range apiProject.ClientCerts {
+		if _, found := aliases[certFile.Alias]; found {
+			return nil, fmt.Errorf("duplicate client certificate alias %v in the API %s - %s:%s", certFile.Alias,
+				apiYaml.ID, apiYaml.Name, apiYaml.Version)
+		}
+		aliases[certFile.Alias] = struct{}{}
+		certBytes, found := apiProject.DownstreamCerts[certFile.CertificateName]
+		if !found {
+			logger.LoggerXds.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Certificate file %v not found for the alias %v in the API %s - %s:%s", certFile.CertificateName,
+					certFile.Alias, apiYaml.ID, apiYaml.Name, apiYaml.Version),
+				Severity:  logging.MINOR,
+				ErrorCode: 1415,
+			})
+			return nil, fmt.Errorf("certificate file %v not found for the client certificate alias %v",
+				certFile.CertificateName, certFile.Alias)
+		}
+		clientCerts = append(clientCerts, model.Certificate{
+			Alias:   certFile.Alias,
+			Tier:    certFile.Tier,
+			Content: certBytes,
+		})
 	}
-	updateVhostInternalMaps(apiYaml.ID, apiYaml.Name, apiYaml.Version, vHost, newLabels)
+	return clientCerts, nil
+}
 
-	// create cert map for API
-	certMap := make(map[string][]byte)
-	interceptCertMap := make(map[string][]byte)
+// getCertMaps creates the upstream and interceptor certificate maps of the API project. The certificates mapped to
+// endpoint URLs are included against the URL and all the other certificates are included as the "default" entry.
+func getCertMaps(apiProject model.ProjectAPI) (certMap map[string][]byte, interceptCertMap map[string][]byte) {
+	certMap = make(map[string][]byte)
+	interceptCertMap = make(map[string][]byte)
+	mappedCertFiles := make(map[string]struct{})
 	if len(apiProject.EndpointCerts) > 0 && len(apiProject.UpstreamCerts) > 0 {
 		for url, certFile := range apiProject.EndpointCerts {
 			if certBytes, found := apiProject.UpstreamCerts[certFile]; found {
 				certMap[url] = certBytes
 				interceptCertMap[url] = certBytes
-				delete(apiProject.UpstreamCerts, certFile)
+				mappedCertFiles[certFile] = void
 			} else {
 				logger.LoggerXds.ErrorC(logging.ErrorDetails{
 					Message:   fmt.Sprintf("Certificate file %v not found for the url %v", certFile, url),
@@ -468,62 +727,34 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 		}
 	}
 	newLineByteArray := []byte("\n")
-	for _, certBytes := range apiProject.UpstreamCerts {
+	for certFile, certBytes := range apiProject.UpstreamCerts {
+		if _, mapped := mappedCertFiles[certFile]; mapped {
+			continue
+		}
 		certMap["default"] = append(certMap["default"], certBytes...)
 		certMap["default"] = append(certMap["default"], newLineByteArray...)
 	}
 	interceptCertMap["default"] = apiProject.InterceptorCerts
+	return certMap, interceptCertMap
+}
 
-	routes, clusters, endpoints, err := oasParser.GetRoutesClustersEndpoints(mgwSwagger, certMap,
-		interceptCertMap, vHost, organizationID)
-	if err != nil {
-		return nil, fmt.Errorf("Error while deploying API. Name: %s Version: %s, OrgID: %s, Error: %s",
-			mgwSwagger.GetTitle(), mgwSwagger.GetVersion(), organizationID, err.Error())
-	}
-
-	if _, ok := orgIDOpenAPIRoutesMap[organizationID]; ok {
-		orgIDOpenAPIRoutesMap[organizationID][apiIdentifier] = routes
-	} else {
-		routesMap := make(map[string][]*routev3.Route)
-		routesMap[apiIdentifier] = routes
-		orgIDOpenAPIRoutesMap[organizationID] = routesMap
-	}
-
-	if _, ok := orgIDOpenAPIClustersMap[organizationID]; ok {
-		orgIDOpenAPIClustersMap[organizationID][apiIdentifier] = clusters
-	} else {
-		clustersMap := make(map[string][]*clusterv3.Cluster)
-		clustersMap[apiIdentifier] = clusters
-		orgIDOpenAPIClustersMap[organizationID] = clustersMap
-	}
-
-	if _, ok := orgIDOpenAPIEndpointsMap[organizationID]; ok {
-		orgIDOpenAPIEndpointsMap[organizationID][apiIdentifier] = endpoints
-	} else {
-		endpointMap := make(map[string][]*corev3.Address)
-		endpointMap[apiIdentifier] = endpoints
-		orgIDOpenAPIEndpointsMap[organizationID] = endpointMap
-	}
-
-	if _, ok := orgIDOpenAPIEnforcerApisMap[organizationID]; ok {
-		orgIDOpenAPIEnforcerApisMap[organizationID][apiIdentifier] = oasParser.GetEnforcerAPI(mgwSwagger, vHost)
-	} else {
-		enforcerAPIMap := make(map[string]types.Resource)
-		enforcerAPIMap[apiIdentifier] = oasParser.GetEnforcerAPI(mgwSwagger, vHost)
-		orgIDOpenAPIEnforcerApisMap[organizationID] = enforcerAPIMap
+// getVhostProvenanceSource returns the source of the vhost of an API deployment, which is the adapter default when
+// the vhost is the default vhost of the environment, as the API projects without deployment_environments.yaml are
+// deployed to the default vhost.
+func getVhostProvenanceSource(vHost, environment string) string {
+	if defaultVhost, _, err := config.GetDefaultVhost(environment); err == nil && defaultVhost == vHost {
+		return model.ProvenanceSourceAdapterDefault
 	}
+	return model.ProvenanceSourceDeploymentEnvironments
+}
 
-	// TODO: (VirajSalaka) Fault tolerance mechanism implementation
-	revisionStatus := updateXdsCacheOnAPIAdd(oldLabels, newLabels)
-	if revisionStatus {
-		// send updated revision to control plane
-		deployedRevision = notifier.UpdateDeployedRevisions(apiYaml.ID, apiYaml.RevisionID, environments,
-			vHost)
-	}
-	if svcdiscovery.IsServiceDiscoveryEnabled {
-		startConsulServiceDiscovery(organizationID) //consul service discovery starting point
+// getUniqueIdentifier returns the API UUID. If the API is imported from apictl without an UUID, the hash of
+// the API name and version is returned.
+func getUniqueIdentifier(apiUUID, apiName, apiVersion string) string {
+	if apiUUID == "" {
+		return GenerateHashedAPINameVersionIDWithoutVhost(apiName, apiVersion)
 	}
-	return deployedRevision, nil
+	return apiUUID
 }
 
 // GetAllEnvironments returns all the environments merging new environments with already deployed environments
@@ -563,53 +794,82 @@ func GetVhostOfAPI(apiUUID, environment string) (vhost string, exists bool) {
 	return "", false
 }
 
-func addBasepathToMap(mgwSwagger model.MgwSwagger, organizationID, vHost, apiIdentifier string) error {
-	newBasepath := mgwSwagger.GetXWso2Basepath()
+// getContextsOfAPI returns the basepath of the API followed by its context aliases. All of these are checked for
+// collisions with the contexts of the other APIs deployed in the same vhost.
+func getContextsOfAPI(mgwSwagger model.MgwSwagger) []string {
+	return append([]string{mgwSwagger.GetXWso2Basepath()}, mgwSwagger.GetContextAliases()...)
+}
 
-	// Check if the basepath exists
-	if existingAPIIdentifier, ok := orgIDvHostBasepathMap[organizationID][vHost+":"+newBasepath]; ok {
-		// Check if it is NOT just an update for the already existing API
-		if existingAPIIdentifier != apiIdentifier {
-			logger.LoggerXds.ErrorC(logging.ErrorDetails{
-				Message:   fmt.Sprintf("An API exists with the same basepath. Basepath: %v Existing_API: %v New_API: %v orgID: %v VHost: %v", newBasepath, existingAPIIdentifier, apiIdentifier, organizationID, vHost),
-				Severity:  logging.MINOR,
-				ErrorCode: 1407,
-			})
-			err := errors.New("An API exists with the same basepath. Existing_API: " + existingAPIIdentifier + "New_API:" + apiIdentifier +
-				" orgID: " + organizationID + " VHost: " + vHost)
-			return err
+// validateBasepath returns an error if the basepath, or any of the context aliases, of the API is used by another
+// API deployed in the vhost. The internal maps are not updated.
+func validateBasepath(mgwSwagger model.MgwSwagger, organizationID, vHost, apiIdentifier string) error {
+	for _, newBasepath := range getContextsOfAPI(mgwSwagger) {
+		if existingAPIIdentifier, ok := orgIDvHostBasepathMap[organizationID][vHost+":"+newBasepath]; ok {
+			// Check if it is NOT just an update for the already existing API
+			if existingAPIIdentifier != apiIdentifier {
+				logger.LoggerXds.ErrorC(logging.ErrorDetails{
+					Message:   fmt.Sprintf("An API exists with the same basepath. Basepath: %v Existing_API: %v New_API: %v orgID: %v VHost: %v", newBasepath, existingAPIIdentifier, apiIdentifier, organizationID, vHost),
+					Severity:  logging.MINOR,
+					ErrorCode: 1407,
+				})
+				err := errors.New("An API exists with the same basepath. Existing_API: " + existingAPIIdentifier + "New_API:" + apiIdentifier +
+					" orgID: " + organizationID + " VHost: " + vHost)
+				return err
+			}
 		}
 	}
+	return nil
+}
+
+func addBasepathToMap(mgwSwagger model.MgwSwagger, organizationID, vHost, apiIdentifier string) error {
+	if err := validateBasepath(mgwSwagger, organizationID, vHost, apiIdentifier); err != nil {
+		return err
+	}
+	newBasepaths := getContextsOfAPI(mgwSwagger)
 
-	// Remove the old basepath anyway
+	// Remove the old basepaths anyway
 	if oldMgwSwagger, ok := orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier]; ok {
-		oldBasepath := oldMgwSwagger.GetXWso2Basepath()
-		delete(orgIDvHostBasepathMap[organizationID], vHost+":"+oldBasepath)
+		for _, oldBasepath := range getContextsOfAPI(oldMgwSwagger) {
+			delete(orgIDvHostBasepathMap[organizationID], vHost+":"+oldBasepath)
+		}
 	}
 
-	// Add the new basepath
-	if _, ok := orgIDvHostBasepathMap[organizationID]; ok {
+	// Add the new basepaths
+	if _, ok := orgIDvHostBasepathMap[organizationID]; !ok {
+		orgIDvHostBasepathMap[organizationID] = make(map[string]string)
+	}
+	for _, newBasepath := range newBasepaths {
 		orgIDvHostBasepathMap[organizationID][vHost+":"+newBasepath] = apiIdentifier
-	} else {
-		vHostBasepathMap := make(map[string]string)
-		vHostBasepathMap[vHost+":"+newBasepath] = apiIdentifier
-		orgIDvHostBasepathMap[organizationID] = vHostBasepathMap
 	}
 	return nil
 }
 
-// DeleteAPIs deletes an API, its resources and updates the caches of given environments
-func DeleteAPIs(vhost, apiName, version string, environments []string, organizationID string) error {
+// UndeployStatus is the status of an undeploy request which is completed without an error.
+type UndeployStatus int
+
+const (
+	// Undeployed is the status when the API is removed from the requested vhosts and environments.
+	Undeployed UndeployStatus = iota
+	// AlreadyUndeployed is the status when the API is not deployed in the requested vhost, hence nothing is removed.
+	// Undeploy requests may be retried (e.g. the redelivered undeploy events of the control plane), hence removing
+	// an API which does not exist is not treated as a failure.
+	AlreadyUndeployed
+)
+
+// DeleteAPIs deletes an API, its resources and updates the caches of given environments. Deleting an API which
+// is not deployed in the given vhost succeeds with the status AlreadyUndeployed.
+func DeleteAPIs(vhost, apiName, version string, environments []string, organizationID string) (UndeployStatus, error) {
 	apiNameVersionID := GenerateIdentifierForAPIWithoutVhost(apiName, version)
-	apiNameVersionHashedID := reverseAPINameVersionMap[apiNameVersionID]
 
 	mutexForInternalMapUpdate.Lock()
 	defer mutexForInternalMapUpdate.Unlock()
 
+	apiNameVersionHashedID := reverseAPINameVersionMap[apiNameVersionID]
 	vhosts, found := apiToVhostsMap[apiNameVersionHashedID]
 	if !found {
-		logger.LoggerXds.Infof("Unable to delete API %v from Organization %v. API does not exist.", apiNameVersionID, organizationID)
-		return errors.New(constants.NotFound)
+		logger.LoggerXds.Infof("API %v of Organization %v is already undeployed. Hence nothing is deleted.",
+			apiNameVersionID, organizationID)
+		return AlreadyUndeployed, nil
 	}
 
 	if vhost == "" {
@@ -619,7 +879,7 @@ func DeleteAPIs(vhost, apiName, version string, environments []string, organizat
 		for vh := range vhosts {
 			apiIdentifier := GenerateIdentifierForAPIWithUUID(vh, apiNameVersionHashedID)
 			// Updating cache one API by one API, if one API failed to update cache continue with others.
-			if err := deleteAPI(apiIdentifier, environments, organizationID); err != nil {
+			if err := deleteAPI(apiIdentifier, environments, organizationID); err != nil && err.Error() != constants.NotFound {
 				// Update apiToVhostsMap with already deleted vhosts in the loop
 				logger.LoggerXds.ErrorC(logging.ErrorDetails{
 					Message:   fmt.Sprintf("Error deleting API: %v of organization: %v", apiIdentifier, organizationID),
@@ -635,7 +895,7 @@ func DeleteAPIs(vhost, apiName, version string, environments []string, organizat
 					}
 				}
 				apiToVhostsMap[apiNameVersionHashedID] = remainingVhosts
-				return err
+				return Undeployed, err
 			}
 			deletedVhosts[vh] = void
 
@@ -647,12 +907,18 @@ func DeleteAPIs(vhost, apiName, version string, environments []string, organizat
 				}
 			}
 		}
-		return nil
+		return Undeployed, nil
 	}
 
 	apiIdentifier := GenerateIdentifierForAPIWithUUID(vhost, apiNameVersionHashedID)
+	status := Undeployed
 	if err := deleteAPI(apiIdentifier, environments, organizationID); err != nil {
-		return err
+		if err.Error() != constants.NotFound {
+			return Undeployed, err
+		}
+		logger.LoggerXds.Infof("API %v of Organization %v is already undeployed from the vhost %v. Hence nothing is "+
+			"deleted.", apiNameVersionID, organizationID, vhost)
+		status = AlreadyUndeployed
 	}
 
 	if _, ok := vhosts[vhost]; ok {
@@ -665,19 +931,21 @@ func DeleteAPIs(vhost, apiName, version string, environments []string, organizat
 			delete(apiToVhostsMap[apiNameVersionHashedID], vhost)
 		}
 	}
-	return nil
+	return status, nil
 }
 
-// DeleteAPIsWithUUID deletes an API, its resources and updates the caches of given environments
-func DeleteAPIsWithUUID(vhost, uuid string, environments []string, organizationID string) error {
+// DeleteAPIsWithUUID deletes an API, its resources and updates the caches of given environments. Deleting an API
+// which is not deployed in the given vhost succeeds with the status AlreadyUndeployed.
+func DeleteAPIsWithUUID(vhost, uuid string, environments []string, organizationID string) (UndeployStatus, error) {
 
 	mutexForInternalMapUpdate.Lock()
 	defer mutexForInternalMapUpdate.Unlock()
 
 	vhosts, found := apiToVhostsMap[uuid]
 	if !found {
-		logger.LoggerXds.Infof("Unable to delete API with UUID %v from Organization %v. API does not exist.", uuid, organizationID)
-		return errors.New(constants.NotFound)
+		logger.LoggerXds.Infof("API with UUID %v of Organization %v is already undeployed. Hence nothing is deleted.",
+			uuid, organizationID)
+		return AlreadyUndeployed, nil
 	}
 
 	if vhost == "" {
@@ -687,7 +955,7 @@ func DeleteAPIsWithUUID(vhost, uuid string, environments []string, organizationI
 		for vh := range vhosts {
 			apiIdentifier := GenerateIdentifierForAPIWithUUID(vh, uuid)
 			// Updating cache one API by one API, if one API failed to update cache continue with others.
-			if err := deleteAPI(apiIdentifier, environments, organizationID); err != nil {
+			if err := deleteAPI(apiIdentifier, environments, organizationID); err != nil && err.Error() != constants.NotFound {
 				// Update apiToVhostsMap with already deleted vhosts in the loop
 				logger.LoggerXds.ErrorC(logging.ErrorDetails{
 					Message:   fmt.Sprintf("Error deleting API: %v of organization: %v", apiIdentifier, organizationID),
@@ -703,17 +971,23 @@ func DeleteAPIsWithUUID(vhost, uuid string, environments []string, organizationI
 					}
 				}
 				apiToVhostsMap[uuid] = remainingVhosts
-				return err
+				return Undeployed, err
 			}
 			deletedVhosts[vh] = void
 		}
 		delete(apiToVhostsMap, uuid)
-		return nil
+		return Undeployed, nil
 	}
 
 	apiIdentifier := GenerateIdentifierForAPIWithUUID(vhost, uuid)
+	status := Undeployed
 	if err := deleteAPI(apiIdentifier, environments, organizationID); err != nil {
-		return err
+		if err.Error() != constants.NotFound {
+			return Undeployed, err
+		}
+		logger.LoggerXds.Infof("API with UUID %v of Organization %v is already undeployed from the vhost %v. Hence "+
+			"nothing is deleted.", uuid, organizationID, vhost)
+		status = AlreadyUndeployed
 	}
 
 	if _, ok := vhosts[vhost]; ok {
@@ -726,12 +1000,63 @@ func DeleteAPIsWithUUID(vhost, uuid string, environments []string, organizationI
 			delete(apiToVhostsMap[uuid], vhost)
 		}
 	}
+	return status, nil
+}
+
+// UndeployAPIFromEnvironments undeploys the API with the given UUID and vhost only from the given environments,
+// while keeping it deployed in the other environments. An error is returned if the API is not deployed in any of
+// the given environments.
+func UndeployAPIFromEnvironments(apiID, vhost string, environments []string, organizationID string) error {
+	if len(environments) == 0 {
+		return errors.New("environments to undeploy the API are not provided")
+	}
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+
+	apiIdentifier := GenerateIdentifierForAPIWithUUID(vhost, apiID)
+	existingEnvs, found := orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier]
+	if !found {
+		logger.LoggerXds.Infof("Unable to undeploy API: %v from Organization: %v. API does not exist.", apiIdentifier,
+			organizationID)
+		return errors.New(constants.NotFound)
+	}
+	var missingEnvs []string
+	for _, environment := range environments {
+		if !arrayContains(existingEnvs, environment) {
+			missingEnvs = append(missingEnvs, environment)
+		}
+	}
+	if len(missingEnvs) > 0 {
+		return fmt.Errorf("API %v of Organization %v is not deployed in the environments %v", apiIdentifier,
+			organizationID, missingEnvs)
+	}
+
+	if err := deleteAPI(apiIdentifier, environments, organizationID); err != nil {
+		return err
+	}
+	logger.LoggerXds.Infof("Undeployed API %v of Organization %v from the environments %v", apiIdentifier,
+		organizationID, environments)
+
+	// update internal vhost maps
+	for _, environment := range environments {
+		if apiUUIDToGatewayToVhosts[apiID][environment] == vhost {
+			delete(apiUUIDToGatewayToVhosts[apiID], environment)
+		}
+	}
+	if _, exists := orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier]; !exists {
+		// the API is undeployed from all of its environments of the vhost
+		delete(apiToVhostsMap[apiID], vhost)
+		if len(apiToVhostsMap[apiID]) == 0 {
+			delete(apiToVhostsMap, apiID)
+		}
+	}
 	return nil
 }
 
 // DeleteAPIWithAPIMEvent deletes API with the given UUID from the given gw environments
 func DeleteAPIWithAPIMEvent(uuid, organizationID string, environments []string, revisionUUID string) {
-	apiIdentifiers := make(map[string]struct{})
+	// API identifier -> vhost
+	apiIdentifiers := make(map[string]string)
 	mutexForInternalMapUpdate.Lock()
 	defer mutexForInternalMapUpdate.Unlock()
 
@@ -739,10 +1064,28 @@ func DeleteAPIWithAPIMEvent(uuid, organizationID string, environments []string,
 		// delete from only specified environments
 		if arrayContains(environments, gw) {
 			id := GenerateIdentifierForAPIWithUUID(vhost, uuid)
-			apiIdentifiers[id] = void
+			apiIdentifiers[id] = vhost
+		}
+	}
+	// The API may still be deployed to the previous vhosts of the environments, if the adapter is configured
+	// to keep the API in the previous vhost when the vhost is changed.
+	for vhost := range apiToVhostsMap[uuid] {
+		id := GenerateIdentifierForAPIWithUUID(vhost, uuid)
+		for _, environment := range environments {
+			if arrayContains(orgIDOpenAPIEnvoyMap[organizationID][id], environment) {
+				apiIdentifiers[id] = vhost
+				break
+			}
 		}
 	}
-	for apiIdentifier := range apiIdentifiers {
+	if len(apiIdentifiers) == 0 {
+		logger.LoggerXds.Infof("API %v of Organization %v is already undeployed from the environments %v. Hence "+
+			"nothing is deleted.", uuid, organizationID, environments)
+		return
+	}
+	isUndeployed := false
+	undeployedVhosts := []string{}
+	for apiIdentifier, vhost := range apiIdentifiers {
 		if err := deleteAPI(apiIdentifier, environments, organizationID); err != nil {
 			logger.LoggerXds.ErrorC(logging.ErrorDetails{
 				Message:   fmt.Sprintf("Error undeploying API %v of Organization %v from environments %v", apiIdentifier, organizationID, environments),
@@ -750,15 +1093,27 @@ func DeleteAPIWithAPIMEvent(uuid, organizationID string, environments []string,
 				ErrorCode: 1410,
 			})
 		} else {
-			// if no error, update internal vhost maps
 			// error only happens when API not found in deleteAPI func
 			logger.LoggerXds.Infof("Successfully undeployed the revision %s of API %v under Organization %s and environment %s ", revisionUUID, apiIdentifier, organizationID, environments)
-			for _, environment := range environments {
-				// delete environment if exists
-				delete(apiUUIDToGatewayToVhosts[uuid], environment)
-				notifier.SendRevisionUndeployAck(uuid, revisionUUID, environment)
-			}
+			isUndeployed = true
+			undeployedVhosts = append(undeployedVhosts, vhost)
+		}
+	}
+	if isUndeployed {
+		// if no error, update internal vhost maps
+		for _, environment := range environments {
+			// delete environment if exists
+			delete(apiUUIDToGatewayToVhosts[uuid], environment)
+			notifier.SendRevisionUndeployAck(uuid, revisionUUID, environment)
 		}
+		sort.Strings(undeployedVhosts)
+		notifier.SendDeploymentEvent(notifier.DeploymentEvent{
+			APIID:        uuid,
+			RevisionID:   revisionUUID,
+			Action:       notifier.UndeployAction,
+			Environments: environments,
+			Vhosts:       undeployedVhosts,
+		})
 	}
 }
 
@@ -778,40 +1133,52 @@ func deleteAPI(apiIdentifier string, environments []string, organizationID strin
 		if isAllowedToDelete {
 			// do not delete from all environments, hence do not clear routes, clusters, endpoints, enforcerAPIs
 			orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier] = toBeKeptEnvs
-			updateXdsCacheOnAPIAdd(toBeDelEnvs, []string{})
 			existingLabels = orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier]
 			if len(existingLabels) != 0 {
-				return nil
+				startDrainingAPI(organizationID, apiIdentifier, toBeDelEnvs)
+				return updateXdsCacheOnAPIDelete(toBeDelEnvs)
 			}
 			logger.LoggerXds.Infof("API identifier: %v does not have any gateways. Hence deleting the API.", apiIdentifier)
-			cleanMapResources(apiIdentifier, organizationID, toBeDelEnvs)
-			return nil
+			return cleanMapResources(apiIdentifier, organizationID, toBeDelEnvs)
 		}
 	}
 
 	//clean maps of routes, clusters, endpoints, enforcerAPIs
 	if len(environments) == 0 {
-		cleanMapResources(apiIdentifier, organizationID, toBeDelEnvs)
+		return cleanMapResources(apiIdentifier, organizationID, toBeDelEnvs)
 	}
 	return nil
 }
 
-func cleanMapResources(apiIdentifier string, organizationID string, toBeDelEnvs []string) {
+// cleanMapResources removes the API from the internal maps and updates the caches of the given environments.
+// The API is removed from the internal maps even if the caches could not be updated, in which case an error
+// is returned.
+func cleanMapResources(apiIdentifier string, organizationID string, toBeDelEnvs []string) error {
+	startDrainingAPI(organizationID, apiIdentifier, toBeDelEnvs)
 	delete(orgIDOpenAPIRoutesMap[organizationID], apiIdentifier)
 	delete(orgIDOpenAPIClustersMap[organizationID], apiIdentifier)
 	delete(orgIDOpenAPIEndpointsMap[organizationID], apiIdentifier)
 	delete(orgIDOpenAPIEnforcerApisMap[organizationID], apiIdentifier)
+	removeAPIResourceUsage(organizationID, apiIdentifier)
+	removeAPIDeploymentInfo(organizationID, apiIdentifier)
+	removeAPIFromListingIndex(organizationID, apiIdentifier)
 
-	//updateXdsCacheOnAPIAdd is called after cleaning maps of routes, clusters, endpoints, enforcerAPIs.
+	//updateXdsCacheOnAPIDelete is called after cleaning maps of routes, clusters, endpoints, enforcerAPIs.
 	//Therefore resources that belongs to the deleting API do not exist. Caches updated only with
 	//resources that belongs to the remaining APIs
-	updateXdsCacheOnAPIAdd(toBeDelEnvs, []string{})
+	err := updateXdsCacheOnAPIDelete(toBeDelEnvs)
 
 	deleteBasepathForVHost(organizationID, apiIdentifier)
+	if oldMgwSwagger, ok := orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier]; ok {
+		if vHost, err := ExtractVhostFromAPIIdentifier(apiIdentifier); err == nil {
+			envoyconf.ReleaseGeneratedNames(oldMgwSwagger, vHost)
+		}
+	}
 	delete(orgIDOpenAPIEnvoyMap[organizationID], apiIdentifier)  //delete labels
 	delete(orgIDAPIMgwSwaggerMap[organizationID], apiIdentifier) //delete mgwSwagger
 	//TODO: (SuKSW) clean any remaining in label wise maps, if this is the last API of that label
 	logger.LoggerXds.Infof("Deleted API %v of organization %v", apiIdentifier, organizationID)
+	return err
 }
 
 func deleteBasepathForVHost(organizationID, apiIdentifier string) {
@@ -819,8 +1186,9 @@ func deleteBasepathForVHost(organizationID, apiIdentifier string) {
 	if oldMgwSwagger, ok := orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier]; ok {
 		s := strings.Split(apiIdentifier, apiKeyFieldSeparator)
 		vHost := s[0]
-		oldBasepath := oldMgwSwagger.GetXWso2Basepath()
-		delete(orgIDvHostBasepathMap[organizationID], vHost+":"+oldBasepath)
+		for _, oldBasepath := range getContextsOfAPI(oldMgwSwagger) {
+			delete(orgIDvHostBasepathMap[organizationID], vHost+":"+oldBasepath)
+		}
 	}
 }
 
@@ -857,40 +1225,101 @@ func updateXdsCacheOnAPIAdd(oldLabels []string, newLabels []string) bool {
 	revisionStatus := false
 	// TODO: (VirajSalaka) check possible optimizations, Since the number of labels are low by design it should not be an issue
 	for _, newLabel := range newLabels {
-		listeners, clusters, routes, endpoints, apis := GenerateEnvoyResoucesForLabel(newLabel)
-		UpdateEnforcerApis(newLabel, apis, "")
-		success := UpdateXdsCacheWithLock(newLabel, endpoints, clusters, routes, listeners)
-		logger.LoggerXds.Debugf("Xds Cache is updated for the newly added label : %v", newLabel)
+		UpdateEnforcerApis(newLabel, generateEnforcerAPIsForLabel(newLabel), "")
+	}
+	for _, oldLabel := range oldLabels {
+		if !arrayContains(newLabels, oldLabel) {
+			UpdateEnforcerApis(oldLabel, generateEnforcerAPIsForLabel(oldLabel), "")
+		}
+	}
+	// Router snapshots are maintained per node group. Labels mapped to the same node group share a snapshot.
+	newNodeGroups := getNodeGroupsOfLabels(newLabels)
+	for _, newNodeGroup := range newNodeGroups {
+		listeners, clusters, routes, endpoints := GenerateEnvoyResoucesForNodeGroup(newNodeGroup)
+		success := UpdateXdsCacheWithLock(newNodeGroup, endpoints, clusters, routes, listeners)
+		logger.LoggerXds.Debugf("Xds Cache is updated for the node group of the newly added labels : %v", newNodeGroup)
 		if success {
-			// if even one label was updated with latest revision, we take the revision as deployed.
-			// (other labels also will get updated successfully)
+			// if even one node group was updated with latest revision, we take the revision as deployed.
+			// (other node groups also will get updated successfully)
 			revisionStatus = success
 			continue
 		}
 	}
-	for _, oldLabel := range oldLabels {
-		if !arrayContains(newLabels, oldLabel) {
-			listeners, clusters, routes, endpoints, apis := GenerateEnvoyResoucesForLabel(oldLabel)
-			UpdateEnforcerApis(oldLabel, apis, "")
-			UpdateXdsCacheWithLock(oldLabel, endpoints, clusters, routes, listeners)
-			logger.LoggerXds.Debugf("Xds Cache is updated for the already existing label : %v", oldLabel)
+	for _, oldNodeGroup := range getNodeGroupsOfLabels(oldLabels) {
+		if !arrayContains(newNodeGroups, oldNodeGroup) {
+			listeners, clusters, routes, endpoints := GenerateEnvoyResoucesForNodeGroup(oldNodeGroup)
+			UpdateXdsCacheWithLock(oldNodeGroup, endpoints, clusters, routes, listeners)
+			logger.LoggerXds.Debugf("Xds Cache is updated for the node group of the already existing labels : %v",
+				oldNodeGroup)
 		}
 	}
 	return revisionStatus
 }
 
-// GenerateEnvoyResoucesForLabel generates envoy resources for a given label
-// This method will list out all APIs mapped to the label. and generate envoy resources for all of these APIs.
-func GenerateEnvoyResoucesForLabel(label string) ([]types.Resource, []types.Resource, []types.Resource,
-	[]types.Resource, []types.Resource) {
+// updateXdsCacheOnAPIDelete updates the enforcer APIs of the labels from which an API is removed, and the router
+// snapshots of the node groups to which the labels are served. An error is returned if the router snapshot of any
+// of the node groups could not be updated.
+func updateXdsCacheOnAPIDelete(labels []string) error {
+	for _, label := range labels {
+		UpdateEnforcerApis(label, generateEnforcerAPIsForLabel(label), "")
+	}
+	var failedNodeGroups []string
+	for _, nodeGroup := range getNodeGroupsOfLabels(labels) {
+		listeners, clusters, routes, endpoints := GenerateEnvoyResoucesForNodeGroup(nodeGroup)
+		if !UpdateXdsCacheWithLock(nodeGroup, endpoints, clusters, routes, listeners) {
+			failedNodeGroups = append(failedNodeGroups, nodeGroup)
+		}
+	}
+	if len(failedNodeGroups) > 0 {
+		return fmt.Errorf("error while updating the router snapshots of the node groups %v", failedNodeGroups)
+	}
+	return nil
+}
+
+// UpdateXdsCacheForLabels updates the enforcer APIs of the given labels and the router snapshots of the
+// node groups to which the labels are served.
+func UpdateXdsCacheForLabels(labels []string) {
+	updateXdsCacheOnAPIAdd([]string{}, labels)
+}
+
+// generateEnforcerAPIsForLabel generates the enforcer API resources of all the APIs mapped to the label.
+func generateEnforcerAPIsForLabel(label string) []types.Resource {
+	var apis []types.Resource
+	for organizationID, entityMap := range orgIDOpenAPIEnvoyMap {
+		for apiKey, labels := range entityMap {
+			if !arrayContains(labels, label) {
+				continue
+			}
+			if _, err := ExtractVhostFromAPIIdentifier(apiKey); err != nil {
+				continue
+			}
+			if _, ok := orgIDAPIMgwSwaggerMap[organizationID][apiKey]; !ok || isAPIStaged(organizationID, apiKey) {
+				continue
+			}
+			if enforcerAPI, ok := orgIDOpenAPIEnforcerApisMap[organizationID][apiKey]; ok {
+				apis = append(apis, enforcerAPI)
+			}
+		}
+	}
+	return apis
+}
+
+// GenerateEnvoyResoucesForNodeGroup generates envoy resources for a given node group
+// This method will list out all APIs mapped to the labels served to the node group. and generate envoy resources
+// for all of these APIs.
+func GenerateEnvoyResoucesForNodeGroup(nodeGroup string) ([]types.Resource, []types.Resource, []types.Resource,
+	[]types.Resource) {
 	var clusterArray []*clusterv3.Cluster
 	var vhostToRouteArrayMap = make(map[string][]*routev3.Route)
+	var vhostToMethodNotAllowedRouteArrayMap = make(map[string][]*routev3.Route)
+	var vhostToFallbackRouteArrayMap = make(map[string][]*routev3.Route)
+	var vhostsWithCatchAllRoute = make(map[string]struct{})
+	var vhostToLocalRateLimitDescriptors = make(map[string][]*rlv3.LocalRateLimitDescriptor)
 	var endpointArray []*corev3.Address
-	var apis []types.Resource
 
 	for organizationID, entityMap := range orgIDOpenAPIEnvoyMap {
 		for apiKey, labels := range entityMap {
-			if arrayContains(labels, label) {
+			if isServedToNodeGroup(labels, nodeGroup) && !isAPIStaged(organizationID, apiKey) {
 				vhost, err := ExtractVhostFromAPIIdentifier(apiKey)
 				if err != nil {
 					logger.LoggerXds.ErrorC(logging.ErrorDetails{
@@ -903,6 +1332,13 @@ func GenerateEnvoyResoucesForLabel(label string) ([]types.Resource, []types.Reso
 				isDefaultVersion := false
 				if enforcerAPISwagger, ok := orgIDAPIMgwSwaggerMap[organizationID][apiKey]; ok {
 					isDefaultVersion = enforcerAPISwagger.IsDefaultVersion
+					if enforcerAPISwagger.IsVhostCatchAllEnabled() {
+						vhostsWithCatchAllRoute[vhost] = struct{}{}
+					}
+					if descriptor := envoyconf.CreateLocalRateLimitDescriptor(&enforcerAPISwagger); descriptor != nil {
+						vhostToLocalRateLimitDescriptors[vhost] = append(vhostToLocalRateLimitDescriptors[vhost],
+							descriptor)
+					}
 				} else {
 					// If the mgwSwagger is not found, proceed with other APIs. (Unreachable condition at this point)
 					// If that happens, there is no purpose in processing clusters too.
@@ -911,22 +1347,45 @@ func GenerateEnvoyResoucesForLabel(label string) ([]types.Resource, []types.Reso
 				// If it is a default versioned API, the routes are added to the end of the existing array.
 				// Otherwise the routes would be added to the front.
 				// /fooContext/2.0.0/* resource path should be matched prior to the /fooContext/* .
+				// Method not allowed routes and fallback routes for unmatched sub-paths are kept aside to be added
+				// after all the API routes, so that a route of another API matching the request is not shadowed.
+				var apiRoutes, methodNotAllowedRoutes []*routev3.Route
+				for _, route := range orgIDOpenAPIRoutesMap[organizationID][apiKey] {
+					if envoyconf.IsNotFoundFallbackRoute(route) {
+						vhostToFallbackRouteArrayMap[vhost] = append(vhostToFallbackRouteArrayMap[vhost], route)
+					} else if envoyconf.IsMethodNotAllowedRoute(route) {
+						methodNotAllowedRoutes = append(methodNotAllowedRoutes, route)
+					} else {
+						apiRoutes = append(apiRoutes, route)
+					}
+				}
 				if isDefaultVersion {
-					vhostToRouteArrayMap[vhost] = append(vhostToRouteArrayMap[vhost], orgIDOpenAPIRoutesMap[organizationID][apiKey]...)
+					vhostToRouteArrayMap[vhost] = append(vhostToRouteArrayMap[vhost], apiRoutes...)
+					vhostToMethodNotAllowedRouteArrayMap[vhost] = append(vhostToMethodNotAllowedRouteArrayMap[vhost],
+						methodNotAllowedRoutes...)
 				} else {
-					vhostToRouteArrayMap[vhost] = append(orgIDOpenAPIRoutesMap[organizationID][apiKey], vhostToRouteArrayMap[vhost]...)
+					vhostToRouteArrayMap[vhost] = append(apiRoutes, vhostToRouteArrayMap[vhost]...)
+					vhostToMethodNotAllowedRouteArrayMap[vhost] = append(methodNotAllowedRoutes,
+						vhostToMethodNotAllowedRouteArrayMap[vhost]...)
 				}
 				clusterArray = append(clusterArray, orgIDOpenAPIClustersMap[organizationID][apiKey]...)
 				endpointArray = append(endpointArray, orgIDOpenAPIEndpointsMap[organizationID][apiKey]...)
-				enfocerAPI, ok := orgIDOpenAPIEnforcerApisMap[organizationID][apiKey]
-				if ok {
-					apis = append(apis, enfocerAPI)
-				}
 				// listenerArrays = append(listenerArrays, openAPIListenersMap[apiKey])
 			}
 		}
 	}
 
+	clusterArray, endpointArray = appendDrainingClusters(nodeGroup, clusterArray, endpointArray)
+
+	// The routes of a vhost are ordered as API routes, method not allowed routes, fallback routes of the APIs
+	// and the catch-all route of the vhost, which is added after the system routes.
+	for vhost, methodNotAllowedRoutes := range vhostToMethodNotAllowedRouteArrayMap {
+		vhostToRouteArrayMap[vhost] = append(vhostToRouteArrayMap[vhost], methodNotAllowedRoutes...)
+	}
+	for vhost, fallbackRoutes := range vhostToFallbackRouteArrayMap {
+		vhostToRouteArrayMap[vhost] = append(vhostToRouteArrayMap[vhost], sortNotFoundFallbackRoutes(fallbackRoutes)...)
+	}
+
 	// If the token endpoint is enabled, the token endpoint also needs to be added.
 	conf, errReadConfig := config.ReadConfigs()
 	if errReadConfig != nil {
@@ -954,32 +1413,39 @@ func GenerateEnvoyResoucesForLabel(label string) ([]types.Resource, []types.Reso
 		readynessEndpoint := envoyconf.CreateReadyEndpoint()
 		vhostToRouteArrayMap[systemHost] = append(vhostToRouteArrayMap[systemHost], readynessEndpoint)
 	}
+	for vhost := range vhostsWithCatchAllRoute {
+		vhostToRouteArrayMap[vhost] = append(vhostToRouteArrayMap[vhost], envoyconf.CreateVhostCatchAllRoute(vhost))
+	}
 
-	listenerArray, listenerFound := envoyListenerConfigMap[label]
-	routesConfig, routesConfigFound := envoyRouteConfigMap[label]
+	listenerArray, listenerFound := envoyListenerConfigMap[nodeGroup]
+	routesConfig, routesConfigFound := envoyRouteConfigMap[nodeGroup]
 	if !listenerFound && !routesConfigFound {
-		listenerArray, routesConfig = oasParser.GetProductionListenerAndRouteConfig(vhostToRouteArrayMap)
-		envoyListenerConfigMap[label] = listenerArray
-		envoyRouteConfigMap[label] = routesConfig
+		listenerArray, routesConfig = oasParser.GetProductionListenerAndRouteConfig(vhostToRouteArrayMap,
+			vhostToLocalRateLimitDescriptors, getTLSVhosts())
+		envoyListenerConfigMap[nodeGroup] = listenerArray
+		envoyRouteConfigMap[nodeGroup] = routesConfig
 	} else {
 		// If the routesConfig exists, the listener exists too
-		oasParser.UpdateRoutesConfig(routesConfig, vhostToRouteArrayMap)
+		oasParser.UpdateRoutesConfig(routesConfig, vhostToRouteArrayMap, vhostToLocalRateLimitDescriptors)
 	}
-	clusterArray = append(clusterArray, envoyClusterConfigMap[label]...)
-	endpointArray = append(endpointArray, envoyEndpointConfigMap[label]...)
+	clusterArray = append(clusterArray, envoyClusterConfigMap[nodeGroup]...)
+	endpointArray = append(endpointArray, envoyEndpointConfigMap[nodeGroup]...)
 	endpoints, clusters, listeners, routeConfigs := oasParser.GetCacheResources(endpointArray, clusterArray, listenerArray, routesConfig)
-	return endpoints, clusters, listeners, routeConfigs, apis
+	return endpoints, clusters, listeners, routeConfigs
 }
 
-// GenerateGlobalClusters generates the globally available clusters and endpoints.
+// GenerateGlobalClusters generates the globally available clusters and endpoints for the label
+// and the node groups to which the label is served.
 func GenerateGlobalClusters(label string) {
-	clusters, endpoints := oasParser.GetGlobalClusters()
-	envoyClusterConfigMap[label] = clusters
-	envoyEndpointConfigMap[label] = endpoints
+	for _, key := range append([]string{label}, getNodeGroupsOfLabels([]string{label})...) {
+		clusters, endpoints := oasParser.GetGlobalClusters()
+		envoyClusterConfigMap[key] = clusters
+		envoyEndpointConfigMap[key] = endpoints
+	}
 }
 
 // use UpdateXdsCacheWithLock to avoid race conditions
-func updateXdsCache(label string, endpoints []types.Resource, clusters []types.Resource, routes []types.Resource, listeners []types.Resource) bool {
+func updateXdsCache(nodeGroup string, endpoints []types.Resource, clusters []types.Resource, routes []types.Resource, listeners []types.Resource) bool {
 	version := rand.Intn(maxRandomInt)
 	// TODO: (VirajSalaka) kept same version for all the resources as we are using simple cache implementation.
 	// Will be updated once decide to move to incremental XDS
@@ -988,6 +1454,7 @@ func updateXdsCache(label string, endpoints []types.Resource, clusters []types.R
 		envoy_resource.ClusterType:  clusters,
 		envoy_resource.ListenerType: listeners,
 		envoy_resource.RouteType:    routes,
+		envoy_resource.SecretType:   getListenerSecretResources(),
 	})
 	if errNewSnap != nil {
 		logger.LoggerXds.ErrorC(logging.ErrorDetails{
@@ -999,7 +1466,7 @@ func updateXdsCache(label string, endpoints []types.Resource, clusters []types.R
 	}
 	snap.Consistent()
 	//TODO: (VirajSalaka) check
-	errSetSnap := cache.SetSnapshot(context.Background(), label, snap)
+	errSetSnap := cache.SetSnapshot(context.Background(), nodeGroup, snap)
 	if errSetSnap != nil {
 		logger.LoggerXds.ErrorC(logging.ErrorDetails{
 			Message:   fmt.Sprintf("Error while setting the snapshot : %v", errSetSnap.Error()),
@@ -1008,7 +1475,8 @@ func updateXdsCache(label string, endpoints []types.Resource, clusters []types.R
 		})
 		return false
 	}
-	logger.LoggerXds.Infof("New Router cache updated for the label: " + label + " version: " + fmt.Sprint(version))
+	updateSnapshotResourceUsage(nodeGroup, endpoints, clusters, routes, listeners)
+	logger.LoggerXds.Infof("New Router cache updated for the node group: " + nodeGroup + " version: " + fmt.Sprint(version))
 	return true
 }
 
@@ -1218,47 +1686,65 @@ func UpdateEnforcerApplicationKeyMappings(applicationKeyMappings *subscription.A
 }
 
 // UpdateXdsCacheWithLock uses mutex and lock to avoid different go routines updating XDS at the same time
-func UpdateXdsCacheWithLock(label string, endpoints []types.Resource, clusters []types.Resource, routes []types.Resource,
+func UpdateXdsCacheWithLock(nodeGroup string, endpoints []types.Resource, clusters []types.Resource, routes []types.Resource,
 	listeners []types.Resource) bool {
 	mutexForXdsUpdate.Lock()
 	defer mutexForXdsUpdate.Unlock()
-	return updateXdsCache(label, endpoints, clusters, routes, listeners)
+	return updateXdsCache(nodeGroup, endpoints, clusters, routes, listeners)
 }
 
-// ListApis returns a list of objects that holds info about each API
-func ListApis(apiType string, organizationID string, limit *int64) *apiModel.APIMeta {
-	var limitValue int
-	if limit == nil {
-		limitValue = len(orgIDAPIMgwSwaggerMap[organizationID])
-	} else {
+// ListApis returns a list of objects that holds info about each API of the organization sorted by the vhost and
+// the API UUID. Only the APIs of any of the given API types are listed, and all the APIs are listed if no API
+// types are given. Only the index of the given organization is read, and the lock of the internal maps is held only
+// while the metadata of the listed APIs is copied.
+func ListApis(apiTypes []string, organizationID string, limit *int64) *apiModel.APIMeta {
+	type listedAPI struct {
+		entry       *apiListEntry
+		gatewayEnvs []string
+	}
+
+	mutexForInternalMapUpdate.RLock()
+	entries := orgIDAPIListIndex[organizationID]
+	limitValue := len(entries)
+	if limit != nil && int(*limit) < limitValue {
 		limitValue = int(*limit)
 	}
-	var apisArray []*apiModel.APIMetaListItem
-	i := 0
-	for apiIdentifier, mgwSwagger := range orgIDAPIMgwSwaggerMap[organizationID] {
-		if i == limitValue {
+	listedAPIs := make([]listedAPI, 0, limitValue)
+	for _, entry := range entries {
+		if len(listedAPIs) >= limitValue {
 			break
 		}
-		if apiType == "" || mgwSwagger.GetAPIType() == apiType {
-			var apiMetaListItem apiModel.APIMetaListItem
-			apiMetaListItem.APIName = mgwSwagger.GetTitle()
-			apiMetaListItem.Version = mgwSwagger.GetVersion()
-			apiMetaListItem.APIType = mgwSwagger.GetAPIType()
-			apiMetaListItem.Context = mgwSwagger.GetXWso2Basepath()
-			apiMetaListItem.GatewayEnvs = orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier]
-			vhost := "ERROR"
-			if vh, err := ExtractVhostFromAPIIdentifier(apiIdentifier); err == nil {
-				vhost = vh
-			}
-			apiMetaListItem.Vhost = vhost
-			apisArray = append(apisArray, &apiMetaListItem)
-			i++
+		if len(apiTypes) == 0 || arrayContains(apiTypes, entry.apiType) {
+			gatewayEnvs := append([]string{}, orgIDOpenAPIEnvoyMap[organizationID][entry.apiIdentifier]...)
+			listedAPIs = append(listedAPIs, listedAPI{entry: entry, gatewayEnvs: gatewayEnvs})
 		}
 	}
+	total := len(entries)
+	deployedAPIs := countDeployedAPIs(organizationID)
+	mutexForInternalMapUpdate.RUnlock()
+
+	apisArray := make([]*apiModel.APIMetaListItem, 0, len(listedAPIs))
+	for _, listed := range listedAPIs {
+		apisArray = append(apisArray, &apiModel.APIMetaListItem{
+			APIName:                        listed.entry.name,
+			Version:                        listed.entry.version,
+			APIType:                        listed.entry.apiType,
+			Context:                        listed.entry.context,
+			ContextAliases:                 listed.entry.contextAliases,
+			GatewayEnvs:                    listed.gatewayEnvs,
+			ExcludedOperations:             listed.entry.excludedOperations,
+			Vhost:                          listed.entry.vhost,
+			SubscriptionValidationDisabled: listed.entry.subscriptionValidationDisabled,
+		})
+	}
 	var apiMetaObject apiModel.APIMeta
-	apiMetaObject.Total = int64(len(orgIDAPIMgwSwaggerMap[organizationID]))
+	apiMetaObject.Total = int64(total)
 	apiMetaObject.Count = int64(len(apisArray))
 	apiMetaObject.List = apisArray
+	apiMetaObject.Quota = &apiModel.APIQuota{
+		DeployedApis: int64(deployedAPIs),
+		MaxApis:      int64(GetMaxAPIs(organizationID)),
+	}
 	return &apiMetaObject
 }
 
@@ -1275,7 +1761,8 @@ func IsAPIExist(vhost, uuid, apiName, apiVersion, organizationID string) (exists
 
 // GenerateIdentifierForAPI generates an identifier unique to the API
 func GenerateIdentifierForAPI(vhost, name, version string) string {
-	return fmt.Sprint(vhost, apiKeyFieldSeparator, name, apiKeyFieldSeparator, version)
+	return fmt.Sprint(vhost, apiKeyFieldSeparator, normalizeAPIIdentityField(name), apiKeyFieldSeparator,
+		normalizeAPIIdentityField(version))
 }
 
 // GenerateIdentifierForAPIWithUUID generates an identifier unique to the API
@@ -1285,12 +1772,12 @@ func GenerateIdentifierForAPIWithUUID(vhost, uuid string) string {
 
 // GenerateIdentifierForAPIWithoutVhost generates an identifier unique to the API name and version
 func GenerateIdentifierForAPIWithoutVhost(name, version string) string {
-	return fmt.Sprint(name, apiKeyFieldSeparator, version)
+	return fmt.Sprint(normalizeAPIIdentityField(name), apiKeyFieldSeparator, normalizeAPIIdentityField(version))
 }
 
 // GenerateHashedAPINameVersionIDWithoutVhost generates a hashed identifier unique to the API Name and Version
 func GenerateHashedAPINameVersionIDWithoutVhost(name, version string) string {
-	return generateHashValue(name, version)
+	return generateHashValue(normalizeAPIIdentityField(name), normalizeAPIIdentityField(version))
 }
 
 func generateHashValue(apiName string, apiVersion string) string {
@@ -20,6 +20,10 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
 )
 
 func TestGetVhostOfAPI(t *testing.T) {
@@ -176,3 +180,133 @@ func setupInternalMemoryMapsWithTestSamples() {
 		},
 	}
 }
+
+func TestGetClientCertificates(t *testing.T) {
+	apiProject := model.ProjectAPI{
+		ClientCerts: []model.CertificateDetails{
+			{Alias: "gold-client", Tier: "Gold", CertificateName: "gold-client.pem"},
+			{Alias: "bronze-client", Tier: "Bronze", CertificateName: "bronze-client.pem"},
+		},
+		DownstreamCerts: map[string][]byte{
+			"gold-client.pem":   []byte("gold"),
+			"bronze-client.pem": []byte("bronze"),
+		},
+	}
+	clientCerts, err := getClientCertificates(apiProject)
+	if err != nil {
+		t.Fatalf("Unexpected error while mapping the client certificates: %v", err)
+	}
+	expected := []model.Certificate{
+		{Alias: "gold-client", Tier: "Gold", Content: []byte("gold")},
+		{Alias: "bronze-client", Tier: "Bronze", Content: []byte("bronze")},
+	}
+	if !reflect.DeepEqual(expected, clientCerts) {
+		t.Errorf("Expected client certificates %v, but got %v", expected, clientCerts)
+	}
+
+	apiProject.ClientCerts[1].Alias = "gold-client"
+	if _, err := getClientCertificates(apiProject); err == nil {
+		t.Error("Duplicate client certificate aliases should return an error")
+	}
+
+	apiProject.ClientCerts[1].Alias = "bronze-client"
+	delete(apiProject.DownstreamCerts, "bronze-client.pem")
+	if _, err := getClientCertificates(apiProject); err == nil {
+		t.Error("Client certificate alias referring to a missing file should return an error")
+	}
+}
+
+func TestUndeployAPIFromEnvironments(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousNodeGroups := conf.Adapter.NodeGroups
+	defer func() {
+		conf.Adapter.NodeGroups = previousNodeGroups
+		resetInternalMapsForNodeGroupTests()
+	}()
+	conf.Adapter.NodeGroups.DefaultGroup = ""
+	conf.Adapter.NodeGroups.Groups = nil
+	resetInternalMapsForNodeGroupTests()
+
+	apiID := "555-Weather"
+	vhost := "api.wso2.com"
+	apiIdentifier := GenerateIdentifierForAPIWithUUID(vhost, apiID)
+	apiToVhostsMap = map[string]map[string]struct{}{apiID: {vhost: void}}
+	apiUUIDToGatewayToVhosts = map[string]map[string]string{apiID: {"Default": vhost, "us-region": vhost}}
+	addAPIForNodeGroupTests(apiIdentifier, "/weather", []string{"Default", "us-region"})
+	updateXdsCacheOnAPIAdd([]string{}, []string{"Default", "us-region"})
+
+	if err := UndeployAPIFromEnvironments(apiID, vhost, []string{"Default", "eu-region"},
+		nodeGroupTestOrganization); err == nil {
+		t.Error("undeploying the API from an environment it is not deployed should return an error")
+	}
+	if err := UndeployAPIFromEnvironments("666-NotFound", vhost, []string{"Default"},
+		nodeGroupTestOrganization); err == nil || err.Error() != constants.NotFound {
+		t.Errorf("expected the error %v for an API which does not exist, but found %v", constants.NotFound, err)
+	}
+	if labels := orgIDOpenAPIEnvoyMap[nodeGroupTestOrganization][apiIdentifier]; len(labels) != 2 {
+		t.Fatalf("failed undeployments should not change the environments of the API, but found %v", labels)
+	}
+
+	// Undeploy from one of the two environments
+	if err := UndeployAPIFromEnvironments(apiID, vhost, []string{"Default"}, nodeGroupTestOrganization); err != nil {
+		t.Fatalf("error while undeploying the API from the Default environment: %v", err)
+	}
+	if labels := orgIDOpenAPIEnvoyMap[nodeGroupTestOrganization][apiIdentifier]; !reflect.DeepEqual(labels,
+		[]string{"us-region"}) {
+		t.Errorf("expected the API to be kept in the environment us-region, but found %v", labels)
+	}
+	if envToVhost := apiUUIDToGatewayToVhosts[apiID]; !reflect.DeepEqual(envToVhost,
+		map[string]string{"us-region": vhost}) {
+		t.Errorf("expected the vhost of only the environment us-region, but found %v", envToVhost)
+	}
+	if _, ok := apiToVhostsMap[apiID][vhost]; !ok {
+		t.Error("vhost of the API should be kept as the API is still deployed in an environment")
+	}
+	if routes := getAPIRoutesServedToNode(t, "Default"); len(routes) != 0 {
+		t.Errorf("expected no routes of the API to be served to the Default environment, but found %v", routes)
+	}
+	if routes := getAPIRoutesServedToNode(t, "us-region"); !reflect.DeepEqual(routes, []string{"/weather"}) {
+		t.Errorf("expected the routes of the API to be served to the us-region environment, but found %v", routes)
+	}
+
+	// Undeploy from the remaining environment
+	if err := UndeployAPIFromEnvironments(apiID, vhost, []string{"us-region"}, nodeGroupTestOrganization); err != nil {
+		t.Fatalf("error while undeploying the API from the us-region environment: %v", err)
+	}
+	if _, exists := orgIDAPIMgwSwaggerMap[nodeGroupTestOrganization][apiIdentifier]; exists {
+		t.Error("API should be deleted when it is undeployed from all of its environments")
+	}
+	if _, exists := apiToVhostsMap[apiID]; exists {
+		t.Error("vhosts of the API should be deleted when it is undeployed from all of its environments")
+	}
+}
+
+func TestValidateBasepath(t *testing.T) {
+	resetInternalMapsForNodeGroupTests()
+	var apiYaml model.APIYaml
+	apiYaml.Data.Name = "PetStore"
+	apiYaml.Data.Version = "1.0.0"
+	apiYaml.Data.Context = "/petstore"
+	var mgwSwagger model.MgwSwagger
+	if err := mgwSwagger.PopulateFromAPIYaml(apiYaml); err != nil {
+		t.Fatalf("error while populating the MgwSwagger: %v", err)
+	}
+	if err := addBasepathToMap(mgwSwagger, "org1", "localhost", "localhost:petstore-uuid"); err != nil {
+		t.Fatalf("error while adding the basepath: %v", err)
+	}
+
+	if err := validateBasepath(mgwSwagger, "org1", "localhost", "localhost:petstore-uuid"); err != nil {
+		t.Errorf("basepath of the same API should be valid, but got %v", err)
+	}
+	if err := validateBasepath(mgwSwagger, "org1", "localhost", "localhost:petstore-copy-uuid"); err == nil {
+		t.Errorf("basepath used by another API should not be valid")
+	}
+	if err := validateBasepath(mgwSwagger, "org1", "other.host", "other.host:petstore-copy-uuid"); err != nil {
+		t.Errorf("basepath used in another vhost should be valid, but got %v", err)
+	}
+	expectedBasepaths := map[string]string{"localhost:/petstore/1.0.0": "localhost:petstore-uuid"}
+	if !reflect.DeepEqual(expectedBasepaths, orgIDvHostBasepathMap["org1"]) {
+		t.Errorf("basepaths should not be updated by the validation, expected %v but got %v", expectedBasepaths,
+			orgIDvHostBasepathMap["org1"])
+	}
+}
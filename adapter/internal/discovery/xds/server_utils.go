@@ -17,9 +17,38 @@
 package xds
 
 import (
+	"sort"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
 )
 
+// normalizeAPIIdentityField normalizes an API name or version which is used to identify the API, so that
+// the values differing only in the Unicode form (or in case, when configured) identify the same API.
+func normalizeAPIIdentityField(value string) string {
+	normalized := norm.NFC.String(value)
+	conf, _ := config.ReadConfigs()
+	if conf.Adapter.APIIdentity.CaseInsensitive {
+		normalized = cases.Fold().String(normalized)
+	}
+	return normalized
+}
+
+// sortNotFoundFallbackRoutes orders the fallback routes of a vhost so that the routes of the longer API
+// contexts are evaluated first, as /fooContext/bar/* should be matched prior to the /fooContext/* .
+func sortNotFoundFallbackRoutes(routes []*routev3.Route) []*routev3.Route {
+	sort.SliceStable(routes, func(i, j int) bool {
+		if len(routes[i].GetName()) != len(routes[j].GetName()) {
+			return len(routes[i].GetName()) > len(routes[j].GetName())
+		}
+		return routes[i].GetName() < routes[j].GetName()
+	})
+	return routes
+}
+
 // getEnvironmentsToBeDeleted returns an slice of environments APIs to be u-deployed from
 // by considering existing environments list and environments that APIs are wished to be un-deployed
 func getEnvironmentsToBeDeleted(existingEnvs, deleteEnvs []string) (toBeDel []string, toBeKept []string) {
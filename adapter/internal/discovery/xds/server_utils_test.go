@@ -19,6 +19,9 @@ package xds
 import (
 	"reflect"
 	"testing"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
 )
 
 func TestGetEnvironmentsToBeDeleted(t *testing.T) {
@@ -108,3 +111,52 @@ func TestUpdateVhostInternalMaps(t *testing.T) {
 		t.Errorf("expected the vhost %v of the API is but found %v", vhost1, vhost)
 	}
 }
+
+func TestGenerateHashedAPINameVersionIDWithUnicodeNames(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousCaseInsensitive := conf.Adapter.APIIdentity.CaseInsensitive
+	defer func() {
+		conf.Adapter.APIIdentity.CaseInsensitive = previousCaseInsensitive
+	}()
+	// "é" as a single code point (NFC) and as "e" followed by a combining acute accent (NFD)
+	nfcName := "Pagos México"
+	nfdName := "Pagos Me\u0301xico"
+	upperCaseName := "PAGOS MÉXICO"
+
+	conf.Adapter.APIIdentity.CaseInsensitive = true
+	id := GenerateHashedAPINameVersionIDWithoutVhost(nfcName, "v2")
+	if id != GenerateHashedAPINameVersionIDWithoutVhost(nfdName, "v2") {
+		t.Error("API names differing only in the Unicode form should have the same identity")
+	}
+	if id != GenerateHashedAPINameVersionIDWithoutVhost(upperCaseName, "V2") {
+		t.Error("API names and versions differing only in case should have the same identity")
+	}
+	if GenerateIdentifierForAPIWithoutVhost(nfdName, "v2") != GenerateIdentifierForAPIWithoutVhost(upperCaseName, "V2") {
+		t.Error("API name and version identifiers should be compared case-insensitively")
+	}
+
+	conf.Adapter.APIIdentity.CaseInsensitive = false
+	if GenerateHashedAPINameVersionIDWithoutVhost(nfcName, "v2") != GenerateHashedAPINameVersionIDWithoutVhost(nfdName, "v2") {
+		t.Error("API names differing only in the Unicode form should have the same identity")
+	}
+	if GenerateHashedAPINameVersionIDWithoutVhost(nfcName, "v2") == GenerateHashedAPINameVersionIDWithoutVhost(upperCaseName, "V2") {
+		t.Error("API names and versions should be compared case-sensitively when case folding is disabled")
+	}
+}
+
+func TestSortNotFoundFallbackRoutes(t *testing.T) {
+	routes := []*routev3.Route{
+		{Name: "notFoundFallback:/foo"},
+		{Name: "notFoundFallback:/foo/bar/v1"},
+		{Name: "notFoundFallback:/baz"},
+		{Name: "notFoundFallback:/foo/bar"},
+	}
+	expectedOrder := []string{"notFoundFallback:/foo/bar/v1", "notFoundFallback:/foo/bar", "notFoundFallback:/baz",
+		"notFoundFallback:/foo"}
+	sortedRoutes := sortNotFoundFallbackRoutes(routes)
+	for i, route := range sortedRoutes {
+		if route.GetName() != expectedOrder[i] {
+			t.Errorf("expected the route %v at index %v but found %v", expectedOrder[i], i, route.GetName())
+		}
+	}
+}
@@ -0,0 +1,271 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package xds
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/envoyconf"
+)
+
+// TLSCertificate is a certificate served by the secured listener of the router for a vhost. The empty vhost refers
+// to the certificate served for the vhosts without a certificate of their own.
+type TLSCertificate struct {
+	Vhost            string
+	CertificateChain []byte
+	PrivateKey       []byte
+	// Leaf is the first certificate of the chain, which is presented to the clients
+	Leaf *x509.Certificate
+}
+
+// InvalidTLSCertificateError is returned when a certificate cannot be served by the router.
+type InvalidTLSCertificateError struct {
+	Err error
+}
+
+func (err *InvalidTLSCertificateError) Error() string {
+	return fmt.Sprintf("the TLS certificate is invalid. %v", err.Err)
+}
+
+// ErrNoPreviousTLSCertificate is returned when the certificate of a vhost is rolled back, but the certificate has
+// not been replaced since the last rollback.
+var ErrNoPreviousTLSCertificate = errors.New("the TLS certificate of the vhost has not been replaced")
+
+// tlsCertificateExpiryWarningPeriod is the period before the expiry of a served certificate, within which a warning
+// is logged when the certificate is served.
+const tlsCertificateExpiryWarningPeriod = 30 * 24 * time.Hour
+
+// tlsCertificateVersions holds the certificate served for a vhost, and the certificate it replaced to be restored
+// by a rollback. A nil certificate refers to the configured certificate of the router for the empty vhost, and to
+// serving the certificate of the empty vhost for the other vhosts.
+type tlsCertificateVersions struct {
+	current     *TLSCertificate
+	previous    *TLSCertificate
+	hasPrevious bool
+}
+
+var (
+	listenerTLSCertificates map[string]*tlsCertificateVersions // Vhost -> TLS certificates map
+	mutexForTLSCertificates sync.RWMutex
+)
+
+func init() {
+	listenerTLSCertificates = make(map[string]*tlsCertificateVersions)
+}
+
+// UpdateTLSCertificate validates the PEM encoded certificate chain and private key, and serves those for the vhost
+// from the secured listener of the router. The certificate is sent to the router as an SDS secret, hence the
+// connections are not drained when a certificate is replaced. The replaced certificate is retained to be restored
+// via RollbackTLSCertificate.
+func UpdateTLSCertificate(vhost string, certificateChain, privateKey []byte) (*TLSCertificate, error) {
+	if err := validateTLSVhost(vhost); err != nil {
+		return nil, &InvalidTLSCertificateError{Err: err}
+	}
+	leaf, err := validateTLSCertificate(certificateChain, privateKey, time.Now())
+	if err != nil {
+		logger.LoggerXds.Infof("TLS certificate of the vhost %q is rejected. %v", vhost, err)
+		return nil, &InvalidTLSCertificateError{Err: err}
+	}
+	certificate := &TLSCertificate{
+		Vhost:            vhost,
+		CertificateChain: certificateChain,
+		PrivateKey:       privateKey,
+		Leaf:             leaf,
+	}
+
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	mutexForTLSCertificates.Lock()
+	versions, found := listenerTLSCertificates[vhost]
+	if !found {
+		versions = &tlsCertificateVersions{}
+		listenerTLSCertificates[vhost] = versions
+	}
+	listenersChanged := vhost != "" && versions.current == nil
+	versions.previous, versions.hasPrevious = versions.current, true
+	versions.current = certificate
+	mutexForTLSCertificates.Unlock()
+
+	logger.LoggerXds.Infof("TLS certificate of the vhost %q is replaced with the certificate %q expiring at %v",
+		vhost, leaf.Subject.String(), leaf.NotAfter)
+	warnIfTLSCertificateExpiresSoon(certificate)
+	return certificate, updateXdsCacheOnTLSCertificateChange(listenersChanged)
+}
+
+// RollbackTLSCertificate restores the certificate of the vhost which was replaced by the last update, and returns
+// it. Nil is returned if the configured certificate of the router is restored, or if the vhost does not have a
+// certificate of its own anymore.
+func RollbackTLSCertificate(vhost string) (*TLSCertificate, error) {
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	mutexForTLSCertificates.Lock()
+	versions, found := listenerTLSCertificates[vhost]
+	if !found || !versions.hasPrevious {
+		mutexForTLSCertificates.Unlock()
+		return nil, ErrNoPreviousTLSCertificate
+	}
+	listenersChanged := vhost != "" && (versions.current == nil) != (versions.previous == nil)
+	versions.current, versions.previous, versions.hasPrevious = versions.previous, nil, false
+	restored := versions.current
+	if restored == nil {
+		delete(listenerTLSCertificates, vhost)
+	}
+	mutexForTLSCertificates.Unlock()
+
+	if restored != nil {
+		logger.LoggerXds.Infof("TLS certificate of the vhost %q is rolled back to the certificate %q", vhost,
+			restored.Leaf.Subject.String())
+		warnIfTLSCertificateExpiresSoon(restored)
+	} else {
+		logger.LoggerXds.Infof("TLS certificate of the vhost %q is rolled back to the configured certificate", vhost)
+	}
+	return restored, updateXdsCacheOnTLSCertificateChange(listenersChanged)
+}
+
+// GetServedTLSCertificates returns the certificates served for the vhosts, sorted by the vhost. The configured
+// certificate of the router is not included.
+func GetServedTLSCertificates() []*TLSCertificate {
+	mutexForTLSCertificates.RLock()
+	defer mutexForTLSCertificates.RUnlock()
+	certificates := make([]*TLSCertificate, 0, len(listenerTLSCertificates))
+	for _, versions := range listenerTLSCertificates {
+		if versions.current != nil {
+			certificates = append(certificates, versions.current)
+		}
+	}
+	sort.Slice(certificates, func(i, j int) bool {
+		return certificates[i].Vhost < certificates[j].Vhost
+	})
+	return certificates
+}
+
+// getTLSVhosts returns the vhosts having a certificate of their own, for which a filter chain is added to the
+// secured listener.
+func getTLSVhosts() []string {
+	var vhosts []string
+	for _, certificate := range GetServedTLSCertificates() {
+		if certificate.Vhost != "" {
+			vhosts = append(vhosts, certificate.Vhost)
+		}
+	}
+	return vhosts
+}
+
+// getListenerSecretResources returns the SDS secrets of the certificates served by the secured listener. The
+// secret of the configured certificate is returned unless it is replaced.
+func getListenerSecretResources() []types.Resource {
+	secrets := []types.Resource{}
+	defaultCertificateReplaced := false
+	for _, certificate := range GetServedTLSCertificates() {
+		if certificate.Vhost == "" {
+			defaultCertificateReplaced = true
+		}
+		secrets = append(secrets, envoyconf.CreateListenerSecret(certificate.Vhost, certificate.CertificateChain,
+			certificate.PrivateKey))
+	}
+	if !defaultCertificateReplaced {
+		secrets = append(secrets, envoyconf.CreateDefaultListenerSecret())
+	}
+	return secrets
+}
+
+// updateXdsCacheOnTLSCertificateChange updates the router snapshots of all the node groups with the served
+// certificates. The listeners are regenerated only if the vhosts having a certificate of their own are changed, as
+// the filter chains of the other vhosts are retained by the router. The caller should hold the lock of the
+// internal maps.
+func updateXdsCacheOnTLSCertificateChange(listenersChanged bool) error {
+	nodeGroups := make([]string, 0, len(envoyListenerConfigMap))
+	for nodeGroup := range envoyListenerConfigMap {
+		nodeGroups = append(nodeGroups, nodeGroup)
+	}
+	sort.Strings(nodeGroups)
+
+	var failedNodeGroups []string
+	for _, nodeGroup := range nodeGroups {
+		if listenersChanged {
+			delete(envoyListenerConfigMap, nodeGroup)
+			delete(envoyRouteConfigMap, nodeGroup)
+		}
+		listeners, clusters, routes, endpoints := GenerateEnvoyResoucesForNodeGroup(nodeGroup)
+		if !UpdateXdsCacheWithLock(nodeGroup, endpoints, clusters, routes, listeners) {
+			failedNodeGroups = append(failedNodeGroups, nodeGroup)
+		}
+	}
+	if len(failedNodeGroups) > 0 {
+		return fmt.Errorf("error while updating the router snapshots of the node groups %v", failedNodeGroups)
+	}
+	return nil
+}
+
+// validateTLSVhost returns an error if the vhost cannot be matched with the server name requested by the clients.
+func validateTLSVhost(vhost string) error {
+	if strings.ContainsAny(vhost, " \t/:") {
+		return fmt.Errorf("the vhost %q is not a valid host name", vhost)
+	}
+	return nil
+}
+
+// validateTLSCertificate returns the leaf certificate of the PEM encoded certificate chain, if the private key
+// matches the leaf certificate, each certificate of the chain is issued by the certificate following it, and all
+// the certificates of the chain are valid at the given time.
+func validateTLSCertificate(certificateChain, privateKey []byte, now time.Time) (*x509.Certificate, error) {
+	keyPair, err := tls.X509KeyPair(certificateChain, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	chain := make([]*x509.Certificate, 0, len(keyPair.Certificate))
+	for i, derCertificate := range keyPair.Certificate {
+		certificate, err := x509.ParseCertificate(derCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("certificate %d of the chain cannot be parsed. %v", i+1, err)
+		}
+		if now.Before(certificate.NotBefore) {
+			return nil, fmt.Errorf("certificate %d of the chain %q is not valid before %v", i+1,
+				certificate.Subject.String(), certificate.NotBefore)
+		}
+		if now.After(certificate.NotAfter) {
+			return nil, fmt.Errorf("certificate %d of the chain %q has expired at %v", i+1,
+				certificate.Subject.String(), certificate.NotAfter)
+		}
+		chain = append(chain, certificate)
+	}
+	for i := 0; i < len(chain)-1; i++ {
+		if err := chain[i].CheckSignatureFrom(chain[i+1]); err != nil {
+			return nil, fmt.Errorf("certificate %d of the chain %q is not issued by the certificate following it. "+
+				"The chain should be ordered from the leaf certificate to the root. %v", i+1,
+				chain[i].Subject.String(), err)
+		}
+	}
+	return chain[0], nil
+}
+
+// warnIfTLSCertificateExpiresSoon logs a warning if the served certificate expires within the warning period.
+func warnIfTLSCertificateExpiresSoon(certificate *TLSCertificate) {
+	if time.Until(certificate.Leaf.NotAfter) < tlsCertificateExpiryWarningPeriod {
+		logger.LoggerXds.Warnf("TLS certificate %q served for the vhost %q expires at %v",
+			certificate.Leaf.Subject.String(), certificate.Vhost, certificate.Leaf.NotAfter)
+	}
+}
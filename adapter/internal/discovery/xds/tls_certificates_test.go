@@ -0,0 +1,231 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package xds
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	pemBytes    []byte
+	key         *ecdsa.PrivateKey
+	keyPEMBytes []byte
+}
+
+// generateTestCertificate generates a certificate valid between the given times, which is issued by the given
+// issuer or self signed if the issuer is nil.
+func generateTestCertificate(t *testing.T, commonName string, isCA bool, issuer *testCertificate,
+	notBefore, notAfter time.Time) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error while generating the key of %s. %v", commonName, err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		DNSNames:              []string{commonName},
+	}
+	parent, signingKey := template, key
+	if issuer != nil {
+		parent, signingKey = issuer.certificate, issuer.key
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signingKey)
+	if err != nil {
+		t.Fatalf("error while generating the certificate of %s. %v", commonName, err)
+	}
+	certificate, _ := x509.ParseCertificate(derBytes)
+	keyBytes, _ := x509.MarshalECPrivateKey(key)
+	return &testCertificate{
+		certificate: certificate,
+		pemBytes:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes}),
+		key:         key,
+		keyPEMBytes: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}),
+	}
+}
+
+func TestValidateTLSCertificate(t *testing.T) {
+	now := time.Now()
+	rootCA := generateTestCertificate(t, "Root CA", true, nil, now.Add(-time.Hour), now.Add(48*time.Hour))
+	intermediateCA := generateTestCertificate(t, "Intermediate CA", true, rootCA, now.Add(-time.Hour),
+		now.Add(48*time.Hour))
+	leaf := generateTestCertificate(t, "pets.wso2.com", false, intermediateCA, now.Add(-time.Hour),
+		now.Add(24*time.Hour))
+	otherLeaf := generateTestCertificate(t, "pets.wso2.com", false, intermediateCA, now.Add(-time.Hour),
+		now.Add(24*time.Hour))
+	expiredLeaf := generateTestCertificate(t, "pets.wso2.com", false, intermediateCA, now.Add(-2*time.Hour),
+		now.Add(-time.Hour))
+
+	tests := []struct {
+		name             string
+		certificateChain []byte
+		privateKey       []byte
+		isErrorExpected  bool
+	}{
+		{
+			name:             "Chain ordered from the leaf to the root",
+			certificateChain: bytes.Join([][]byte{leaf.pemBytes, intermediateCA.pemBytes, rootCA.pemBytes}, nil),
+			privateKey:       leaf.keyPEMBytes,
+			isErrorExpected:  false,
+		},
+		{
+			name:             "Leaf certificate without the chain",
+			certificateChain: leaf.pemBytes,
+			privateKey:       leaf.keyPEMBytes,
+			isErrorExpected:  false,
+		},
+		{
+			name:             "Private key of another certificate",
+			certificateChain: leaf.pemBytes,
+			privateKey:       otherLeaf.keyPEMBytes,
+			isErrorExpected:  true,
+		},
+		{
+			name:             "Chain ordered from the root to the leaf",
+			certificateChain: bytes.Join([][]byte{leaf.pemBytes, rootCA.pemBytes, intermediateCA.pemBytes}, nil),
+			privateKey:       leaf.keyPEMBytes,
+			isErrorExpected:  true,
+		},
+		{
+			name:             "Expired leaf certificate",
+			certificateChain: bytes.Join([][]byte{expiredLeaf.pemBytes, intermediateCA.pemBytes}, nil),
+			privateKey:       expiredLeaf.keyPEMBytes,
+			isErrorExpected:  true,
+		},
+		{
+			name:             "Certificate which is not PEM encoded",
+			certificateChain: []byte("not a certificate"),
+			privateKey:       leaf.keyPEMBytes,
+			isErrorExpected:  true,
+		},
+	}
+	for _, test := range tests {
+		certificate, err := validateTLSCertificate(test.certificateChain, test.privateKey, now)
+		if test.isErrorExpected && err == nil {
+			t.Errorf("%s: expected an error, but the certificate is accepted", test.name)
+		}
+		if !test.isErrorExpected && (err != nil || certificate.Subject.CommonName != "pets.wso2.com") {
+			t.Errorf("%s: expected the leaf certificate to be returned, but found %v", test.name, err)
+		}
+	}
+}
+
+func getServedListenerSecrets() map[string]*tlsv3.Secret {
+	secrets := make(map[string]*tlsv3.Secret)
+	for _, resource := range getListenerSecretResources() {
+		secret := resource.(*tlsv3.Secret)
+		secrets[secret.Name] = secret
+	}
+	return secrets
+}
+
+func TestUpdateAndRollbackTLSCertificate(t *testing.T) {
+	resetInternalMapsForNodeGroupTests()
+	mutexForTLSCertificates.Lock()
+	listenerTLSCertificates = make(map[string]*tlsCertificateVersions)
+	mutexForTLSCertificates.Unlock()
+	defer func() {
+		mutexForTLSCertificates.Lock()
+		listenerTLSCertificates = make(map[string]*tlsCertificateVersions)
+		mutexForTLSCertificates.Unlock()
+		resetInternalMapsForNodeGroupTests()
+	}()
+	now := time.Now()
+	first := generateTestCertificate(t, "pets.wso2.com", false, nil, now.Add(-time.Hour), now.Add(24*time.Hour))
+	second := generateTestCertificate(t, "pets.wso2.com", false, nil, now.Add(-time.Hour), now.Add(48*time.Hour))
+
+	// Only the configured certificate is served initially
+	secrets := getServedListenerSecrets()
+	if len(secrets) != 1 || secrets["DefaultListenerSecret"] == nil {
+		t.Errorf("expected only the configured certificate to be served, but found %v", secrets)
+	}
+	if _, err := RollbackTLSCertificate("pets.wso2.com"); err != ErrNoPreviousTLSCertificate {
+		t.Errorf("expected the rollback to be rejected before the certificate is replaced, but found %v", err)
+	}
+
+	// Invalid certificates are rejected without changing the served certificates
+	_, err := UpdateTLSCertificate("pets.wso2.com", first.pemBytes, second.keyPEMBytes)
+	if _, isInvalid := err.(*InvalidTLSCertificateError); !isInvalid {
+		t.Errorf("expected the certificate with a mismatching key to be rejected, but found %v", err)
+	}
+	if _, err = UpdateTLSCertificate("pets.wso2.com/v1", first.pemBytes, first.keyPEMBytes); err == nil {
+		t.Error("expected the certificate of an invalid vhost to be rejected")
+	}
+	if vhosts := getTLSVhosts(); len(vhosts) != 0 {
+		t.Errorf("expected no vhosts with a certificate of their own, but found %v", vhosts)
+	}
+
+	// A filter chain is added for the vhost with its first certificate
+	if _, err = UpdateTLSCertificate("pets.wso2.com", first.pemBytes, first.keyPEMBytes); err != nil {
+		t.Fatalf("expected the certificate to be served, but found %v", err)
+	}
+	if vhosts := getTLSVhosts(); len(vhosts) != 1 || vhosts[0] != "pets.wso2.com" {
+		t.Errorf("expected the vhost pets.wso2.com to have a certificate of its own, but found %v", vhosts)
+	}
+	secret := getServedListenerSecrets()["ListenerSecret_pets.wso2.com"]
+	if secret == nil || !bytes.Equal(secret.GetTlsCertificate().GetCertificateChain().GetInlineBytes(), first.pemBytes) {
+		t.Errorf("expected the first certificate to be served for the vhost, but found %v", secret)
+	}
+
+	// The replaced certificate is restored by the rollback
+	if _, err = UpdateTLSCertificate("pets.wso2.com", second.pemBytes, second.keyPEMBytes); err != nil {
+		t.Fatalf("expected the certificate to be replaced, but found %v", err)
+	}
+	secret = getServedListenerSecrets()["ListenerSecret_pets.wso2.com"]
+	if !bytes.Equal(secret.GetTlsCertificate().GetCertificateChain().GetInlineBytes(), second.pemBytes) {
+		t.Error("expected the second certificate to be served for the vhost")
+	}
+	restored, err := RollbackTLSCertificate("pets.wso2.com")
+	if err != nil || restored == nil || !bytes.Equal(restored.CertificateChain, first.pemBytes) {
+		t.Errorf("expected the first certificate to be restored, but found %v", err)
+	}
+	if _, err = RollbackTLSCertificate("pets.wso2.com"); err != ErrNoPreviousTLSCertificate {
+		t.Errorf("expected only the last update to be rolled back, but found %v", err)
+	}
+
+	// The configured certificate is restored when the replaced default certificate is rolled back
+	if _, err = UpdateTLSCertificate("", second.pemBytes, second.keyPEMBytes); err != nil {
+		t.Fatalf("expected the default certificate to be replaced, but found %v", err)
+	}
+	secret = getServedListenerSecrets()["DefaultListenerSecret"]
+	if !bytes.Equal(secret.GetTlsCertificate().GetCertificateChain().GetInlineBytes(), second.pemBytes) {
+		t.Error("expected the uploaded certificate to be served as the default certificate")
+	}
+	if restored, err = RollbackTLSCertificate(""); err != nil || restored != nil {
+		t.Errorf("expected the configured certificate to be restored, but found %v", err)
+	}
+	secret = getServedListenerSecrets()["DefaultListenerSecret"]
+	if secret.GetTlsCertificate().GetCertificateChain().GetFilename() == "" {
+		t.Error("expected the configured certificate to be served as the default certificate")
+	}
+}
@@ -36,10 +36,13 @@ func handleAPIEventsFromGA(channel chan APIEvent) {
 			configuredEnvs = append(configuredEnvs, config.DefaultGatewayName)
 		}
 		if !event.IsDeployEvent {
-			xds.DeleteAPIWithAPIMEvent(event.APIUUID, event.OrganizationUUID, configuredEnvs, event.RevisionUUID)
-			for _, env := range configuredEnvs {
-				xds.DeleteAPIAndReturnList(event.APIUUID, event.OrganizationUUID, env)
-			}
+			_ = xds.ExecuteInDeploymentQueueBlocking(event.APIUUID, func() error {
+				xds.DeleteAPIWithAPIMEvent(event.APIUUID, event.OrganizationUUID, configuredEnvs, event.RevisionUUID)
+				for _, env := range configuredEnvs {
+					xds.DeleteAPIAndReturnList(event.APIUUID, event.OrganizationUUID, env)
+				}
+				return nil
+			})
 			continue
 		}
 
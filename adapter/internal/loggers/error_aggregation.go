@@ -0,0 +1,128 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package loggers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// aggregationKey identifies the errors which are aggregated together.
+type aggregationKey struct {
+	apiUUID   string
+	errorCode int
+}
+
+// aggregatedError holds the errors of an API with an error code, which are repeated within the current window.
+type aggregatedError struct {
+	logger      *logging.Log
+	windowStart time.Time
+	// count is the number of errors in the window, including the first error logged in full
+	count     int
+	lastError logging.ErrorDetails
+}
+
+// errorAggregator summarizes the identical errors of the APIs repeated within a window.
+type errorAggregator struct {
+	window time.Duration
+	mutex  sync.Mutex
+	errors map[aggregationKey]*aggregatedError
+}
+
+var (
+	apiErrorAggregator     *errorAggregator
+	onceAPIErrorAggregator sync.Once
+)
+
+func newErrorAggregator(window time.Duration) *errorAggregator {
+	return &errorAggregator{window: window, errors: make(map[aggregationKey]*aggregatedError)}
+}
+
+// getAPIErrorAggregator returns the aggregator configured for the adapter, or nil if the aggregation is disabled.
+func getAPIErrorAggregator() *errorAggregator {
+	onceAPIErrorAggregator.Do(func() {
+		conf, _ := config.ReadConfigs()
+		aggregationConf := conf.Adapter.ErrorLogAggregation
+		if aggregationConf.Enabled && aggregationConf.WindowInSeconds > 0 {
+			apiErrorAggregator = newErrorAggregator(time.Duration(aggregationConf.WindowInSeconds) * time.Second)
+		}
+	})
+	return apiErrorAggregator
+}
+
+// LogAPIError logs a deployment error of the API. If the error log aggregation is enabled, the first error of the API
+// with the error code is logged in full, while the identical errors repeated within the window are counted and
+// logged as a single summary line at the end of the window. Blocker errors are never aggregated.
+func LogAPIError(logger *logging.Log, apiUUID string, errorDetails logging.ErrorDetails) {
+	aggregator := getAPIErrorAggregator()
+	if aggregator == nil || errorDetails.Severity == logging.BLOCKER {
+		logger.ErrorC(errorDetails)
+		return
+	}
+	aggregator.logError(logger, apiUUID, errorDetails)
+}
+
+// logError logs the error in full if it is the first error of the API with the error code in the window. Otherwise
+// the error is counted to be summarized at the end of the window.
+func (aggregator *errorAggregator) logError(logger *logging.Log, apiUUID string, errorDetails logging.ErrorDetails) {
+	key := aggregationKey{apiUUID: apiUUID, errorCode: errorDetails.ErrorCode}
+	aggregator.mutex.Lock()
+	if aggregated, found := aggregator.errors[key]; found {
+		aggregated.count++
+		aggregated.lastError = errorDetails
+		aggregator.mutex.Unlock()
+		return
+	}
+	windowStart := time.Now()
+	aggregator.errors[key] = &aggregatedError{logger: logger, windowStart: windowStart, count: 1,
+		lastError: errorDetails}
+	aggregator.mutex.Unlock()
+
+	logger.ErrorC(errorDetails)
+	time.AfterFunc(aggregator.window, func() {
+		aggregator.flush(key, windowStart)
+	})
+}
+
+// flush ends the window of the errors of the API with the error code started at the given time, and logs the summary
+// of the errors suppressed within the window.
+func (aggregator *errorAggregator) flush(key aggregationKey, windowStart time.Time) {
+	aggregator.mutex.Lock()
+	aggregated, found := aggregator.errors[key]
+	if !found || !aggregated.windowStart.Equal(windowStart) {
+		aggregator.mutex.Unlock()
+		return
+	}
+	delete(aggregator.errors, key)
+	aggregator.mutex.Unlock()
+
+	if aggregated.count == 1 {
+		// The only error of the window is already logged in full
+		return
+	}
+	aggregated.logger.ErrorC(logging.ErrorDetails{
+		Message: fmt.Sprintf("API %s failed %d times in last %v, last error: %s", key.apiUUID, aggregated.count,
+			aggregator.window, aggregated.lastError.Message),
+		Severity:  aggregated.lastError.Severity,
+		ErrorCode: key.errorCode,
+	})
+}
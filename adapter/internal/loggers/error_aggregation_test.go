@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package loggers
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// syncBuffer is a buffer which can be written by the timers of the aggregator while the test reads it.
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+type errorLogLine struct {
+	ErrorCode int    `json:"error_code"`
+	Msg       string `json:"msg"`
+}
+
+func (b *syncBuffer) lines(t *testing.T) []errorLogLine {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	var lines []errorLogLine
+	for _, line := range strings.Split(strings.TrimSpace(b.buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var logLine errorLogLine
+		assert.Nil(t, json.Unmarshal([]byte(line), &logLine), "Error while parsing the log line %v", line)
+		lines = append(lines, logLine)
+	}
+	return lines
+}
+
+func newTestLogger() (*logging.Log, *syncBuffer) {
+	buffer := &syncBuffer{}
+	logger := &logging.Log{Logger: logrus.New()}
+	logger.SetFormatter(new(logrus.JSONFormatter))
+	logger.SetOutput(buffer)
+	return logger, buffer
+}
+
+func TestErrorAggregation(t *testing.T) {
+	window := 200 * time.Millisecond
+	aggregator := newErrorAggregator(window)
+	logger, buffer := newTestLogger()
+	brokenRevisionError := logging.ErrorDetails{Message: "broken revision", Severity: logging.MAJOR, ErrorCode: 1238}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 27; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			aggregator.logError(logger, "api-1", brokenRevisionError)
+		}()
+	}
+	wg.Wait()
+	// Errors of another API and with another error code are not aggregated together with the errors of the API
+	aggregator.logError(logger, "api-2", brokenRevisionError)
+	aggregator.logError(logger, "api-1", logging.ErrorDetails{Message: "invalid deployments",
+		Severity: logging.MINOR, ErrorCode: 1237})
+
+	lines := buffer.lines(t)
+	if assert.Equal(t, 3, len(lines), "Only the first error of each API and error code should be logged") {
+		assert.Equal(t, "broken revision", lines[0].Msg, "First error should be logged in full")
+		assert.Equal(t, 1238, lines[0].ErrorCode)
+	}
+
+	// Summaries are logged at the end of the window
+	time.Sleep(window + 100*time.Millisecond)
+	lines = buffer.lines(t)
+	if assert.Equal(t, 4, len(lines), "Summary should be logged only for the repeated errors") {
+		assert.Equal(t, "API api-1 failed 27 times in last 200ms, last error: broken revision", lines[3].Msg)
+		assert.Equal(t, 1238, lines[3].ErrorCode)
+	}
+
+	// The first error of the next window is logged in full
+	aggregator.logError(logger, "api-1", brokenRevisionError)
+	lines = buffer.lines(t)
+	if assert.Equal(t, 5, len(lines)) {
+		assert.Equal(t, "broken revision", lines[4].Msg, "First error of a new window should be logged in full")
+	}
+	time.Sleep(window + 100*time.Millisecond)
+	assert.Equal(t, 5, len(buffer.lines(t)), "Summary should not be logged for a single error in the window")
+}
@@ -48,6 +48,10 @@ func SendRevisionUpdateAck(deployedRevisionList []*DeployedAPIRevision) {
 	if len(deployedRevisionList) < 1 || !cpConfigs.Enabled || !cpConfigs.SendRevisionUpdate {
 		return
 	}
+	if config.IsReadOnlyMode() {
+		logger.LoggerNotifier.Debugf("Revision deployed message is not sent to Control plane as the adapter is in read-only mode")
+		return
+	}
 
 	logger.LoggerNotifier.Debugf("Revision deployed message is sending to Control plane")
 
@@ -106,6 +110,10 @@ func SendRevisionUndeployAck(apiUUID string, revisionUUID string, environment st
 	if apiUUID == "" || revisionUUID == "" || environment == "" || !cpConfigs.Enabled || !cpConfigs.SendRevisionUpdate {
 		return
 	}
+	if config.IsReadOnlyMode() {
+		logger.LoggerNotifier.Debugf("Revision un-deployed message is not sent to Control plane as the adapter is in read-only mode")
+		return
+	}
 	revisionEP := cpConfigs.ServiceURL
 	if strings.HasSuffix(revisionEP, "/") {
 		revisionEP += unDeployedRevisionEP
@@ -0,0 +1,57 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package notifier
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+func TestNotificationsInReadOnlyMode(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+	}))
+	defer server.Close()
+
+	conf, _ := config.ReadConfigs()
+	previousCPConf := conf.ControlPlane
+	previousReadOnlyMode := config.IsReadOnlyMode()
+	defer func() {
+		conf.ControlPlane = previousCPConf
+		config.SetReadOnlyMode(previousReadOnlyMode)
+	}()
+	conf.ControlPlane.Enabled = true
+	conf.ControlPlane.SendRevisionUpdate = true
+	conf.ControlPlane.ServiceURL = server.URL
+	deployedRevisions := []*DeployedAPIRevision{UpdateDeployedRevisions("111-PetStore", 1, []string{"Default"}, "localhost")}
+
+	config.SetReadOnlyMode(true)
+	SendRevisionUpdateAck(deployedRevisions)
+	SendRevisionUndeployAck("111-PetStore", "222-Revision", "Default")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requestCount), "Notifications should not be sent in read-only mode")
+
+	config.SetReadOnlyMode(false)
+	SendRevisionUpdateAck(deployedRevisions)
+	SendRevisionUndeployAck("111-PetStore", "222-Revision", "Default")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requestCount), "Notifications should be resumed in active mode")
+}
@@ -0,0 +1,114 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"github.com/wso2/product-microgateway/adapter/pkg/tlsutils"
+)
+
+const (
+	// DeployAction is the action of the deployment event sent after an API is deployed
+	DeployAction string = "DEPLOY"
+	// UndeployAction is the action of the deployment event sent after an API is undeployed
+	UndeployAction string = "UNDEPLOY"
+)
+
+// SendDeploymentEvent posts the deployment event to the configured deployment webhook. The event is sent
+// asynchronously, hence the deployment is not delayed by the retries of the webhook.
+func SendDeploymentEvent(event DeploymentEvent) {
+	conf, _ := config.ReadConfigs()
+	if !conf.Adapter.DeploymentWebhook.Enabled {
+		return
+	}
+	if config.IsReadOnlyMode() {
+		logger.LoggerNotifier.Debugf("Deployment event of the API %v is not sent as the adapter is in read-only mode",
+			event.APIID)
+		return
+	}
+	if event.Timestamp == 0 {
+		event.Timestamp = time.Now().UnixMilli()
+	}
+	go postDeploymentEvent(event)
+}
+
+// postDeploymentEvent posts the deployment event to the webhook, retrying with an exponential backoff until
+// a 2xx response is received or the retries are exhausted. Returns whether the event is delivered.
+func postDeploymentEvent(event DeploymentEvent) bool {
+	conf, _ := config.ReadConfigs()
+	webhookConf := conf.Adapter.DeploymentWebhook
+	jsonValue, err := json.Marshal(event)
+	if err != nil {
+		logger.LoggerNotifier.Errorf("Error while marshalling the deployment event of the API %v: %v", event.APIID, err)
+		return false
+	}
+
+	retryInterval := time.Duration(webhookConf.RetryIntervalInMillis) * time.Millisecond
+	for attempt := 1; attempt <= webhookConf.MaxRetries+1; attempt++ {
+		err = invokeDeploymentWebhook(webhookConf.URL, jsonValue, webhookConf.Headers,
+			time.Duration(webhookConf.RequestTimeoutInSeconds)*time.Second, webhookConf.SkipSSLVerification)
+		if err == nil {
+			logger.LoggerNotifier.Infof("%v event of the API %v is sent to the deployment webhook for attempt %v",
+				event.Action, event.APIID, attempt)
+			return true
+		}
+		logger.LoggerNotifier.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while sending the %v event of the API %v to the deployment webhook %v "+
+				"for attempt %v : %v", event.Action, event.APIID, webhookConf.URL, attempt, err.Error()),
+			Severity:  logging.MINOR,
+			ErrorCode: 2102,
+		})
+		if attempt <= webhookConf.MaxRetries {
+			time.Sleep(retryInterval)
+			retryInterval *= 2
+		}
+	}
+	return false
+}
+
+func invokeDeploymentWebhook(url string, body []byte, headers map[string]string, timeout time.Duration,
+	skipSSL bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set(contentTypeHeader, "application/json")
+	resp, err := tlsutils.InvokeControlPlane(req, skipSSL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("error response status code %v", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package notifier
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+func setDeploymentWebhookConfigs(url string) func() {
+	conf, _ := config.ReadConfigs()
+	previousWebhookConf := conf.Adapter.DeploymentWebhook
+	conf.Adapter.DeploymentWebhook.Enabled = true
+	conf.Adapter.DeploymentWebhook.URL = url
+	conf.Adapter.DeploymentWebhook.Headers = map[string]string{"Authorization": "Bearer test-token"}
+	conf.Adapter.DeploymentWebhook.MaxRetries = 2
+	conf.Adapter.DeploymentWebhook.RetryIntervalInMillis = 10
+	conf.Adapter.DeploymentWebhook.RequestTimeoutInSeconds = 5
+	return func() {
+		conf.Adapter.DeploymentWebhook = previousWebhookConf
+	}
+}
+
+func TestSendDeploymentEvent(t *testing.T) {
+	receivedEvents := make(chan DeploymentEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get(contentTypeHeader))
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		body, _ := ioutil.ReadAll(r.Body)
+		var event DeploymentEvent
+		assert.Nil(t, json.Unmarshal(body, &event))
+		receivedEvents <- event
+	}))
+	defer server.Close()
+	defer setDeploymentWebhookConfigs(server.URL)()
+
+	SendDeploymentEvent(DeploymentEvent{
+		APIID:        "111-PetStore",
+		RevisionID:   "2",
+		Action:       DeployAction,
+		Environments: []string{"Default", "Internal"},
+		Vhosts:       []string{"localhost"},
+	})
+	select {
+	case event := <-receivedEvents:
+		assert.Equal(t, "111-PetStore", event.APIID)
+		assert.Equal(t, "2", event.RevisionID)
+		assert.Equal(t, DeployAction, event.Action)
+		assert.Equal(t, []string{"Default", "Internal"}, event.Environments)
+		assert.Equal(t, []string{"localhost"}, event.Vhosts)
+		assert.NotZero(t, event.Timestamp)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Deployment event is not received by the webhook")
+	}
+}
+
+func TestDeploymentEventRetry(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requestCount, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	defer setDeploymentWebhookConfigs(server.URL)()
+
+	event := DeploymentEvent{APIID: "111-PetStore", RevisionID: "222-Revision", Action: UndeployAction}
+	assert.True(t, postDeploymentEvent(event), "Event should be delivered when a retry succeeds")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requestCount))
+
+	atomic.StoreInt32(&requestCount, -10)
+	assert.False(t, postDeploymentEvent(event), "Event should not be delivered when all the retries fail")
+	assert.Equal(t, int32(-7), atomic.LoadInt32(&requestCount), "Event should be sent once and retried twice")
+}
+
+func TestDeploymentEventWhenDisabled(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+	}))
+	defer server.Close()
+	defer setDeploymentWebhookConfigs(server.URL)()
+	conf, _ := config.ReadConfigs()
+	conf.Adapter.DeploymentWebhook.Enabled = false
+
+	SendDeploymentEvent(DeploymentEvent{APIID: "111-PetStore", Action: DeployAction})
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requestCount), "Events should not be sent when the webhook is disabled")
+}
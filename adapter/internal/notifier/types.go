@@ -19,3 +19,13 @@ type UnDeployedAPIRevision struct {
 	RevisionUUID string `json:"revisionUUID"`
 	Environment  string `json:"environment"`
 }
+
+// DeploymentEvent is posted to the deployment webhook after an API is deployed or undeployed
+type DeploymentEvent struct {
+	APIID        string   `json:"apiId"`
+	RevisionID   string   `json:"revisionId"`
+	Action       string   `json:"action"`
+	Environments []string `json:"environments"`
+	Vhosts       []string `json:"vhosts"`
+	Timestamp    int64    `json:"timestamp"`
+}
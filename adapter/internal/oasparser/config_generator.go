@@ -25,6 +25,7 @@ import (
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	rlv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/wso2/product-microgateway/adapter/config"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
@@ -68,6 +69,16 @@ func GetGlobalClusters() ([]*clusterv3.Cluster, []*corev3.Address) {
 		}
 	}
 
+	if conf.Envoy.RateLimit.Enabled {
+		logger.LoggerOasparser.Debug("Creating global cluster - Rate Limit Service")
+		if c, e, err := envoyconf.CreateRateLimitCluster(conf); err == nil {
+			clusters = append(clusters, c)
+			endpoints = append(endpoints, e...)
+		} else {
+			logger.LoggerOasparser.Error("Failed to initialize the rate limit service cluster. ", err)
+		}
+	}
+
 	logger.LoggerOasparser.Debug("Creating global cluster - Aws Lambda")
 	if c, e, err := envoyconf.CreateAwsLambdaCluster(conf); err == nil {
 		clusters = append(clusters, c)
@@ -85,9 +96,13 @@ func GetGlobalClusters() ([]*clusterv3.Cluster, []*corev3.Address) {
 // The provided set of envoy routes will be assigned under the virtual host
 //
 // The RouteConfiguration is named as "default"
-func GetProductionListenerAndRouteConfig(vhostToRouteArrayMap map[string][]*routev3.Route) ([]*listenerv3.Listener, *routev3.RouteConfiguration) {
-	listeners := envoy.CreateListenersWithRds()
-	vHosts := envoy.CreateVirtualHosts(vhostToRouteArrayMap)
+//
+// The secured listener serves the certificates of the provided TLS vhosts to the clients requesting those via SNI.
+func GetProductionListenerAndRouteConfig(vhostToRouteArrayMap map[string][]*routev3.Route,
+	vhostToLocalRateLimitDescriptors map[string][]*rlv3.LocalRateLimitDescriptor,
+	tlsVhosts []string) ([]*listenerv3.Listener, *routev3.RouteConfiguration) {
+	listeners := envoy.CreateListenersWithRds(tlsVhosts)
+	vHosts := envoy.CreateVirtualHosts(vhostToRouteArrayMap, vhostToLocalRateLimitDescriptors)
 	routeConfig := envoy.CreateRoutesConfigForRds(vHosts)
 
 	return listeners, routeConfig
@@ -120,8 +135,9 @@ func GetCacheResources(endpoints []*corev3.Address, clusters []*clusterv3.Cluste
 
 // UpdateRoutesConfig updates the existing routes configuration with the provided map of vhost to array of routes.
 // All the already existing routes (within the routeConfiguration) will be removed.
-func UpdateRoutesConfig(routeConfig *routev3.RouteConfiguration, vhostToRouteArrayMap map[string][]*routev3.Route) {
-	routeConfig.VirtualHosts = envoy.CreateVirtualHosts(vhostToRouteArrayMap)
+func UpdateRoutesConfig(routeConfig *routev3.RouteConfiguration, vhostToRouteArrayMap map[string][]*routev3.Route,
+	vhostToLocalRateLimitDescriptors map[string][]*rlv3.LocalRateLimitDescriptor) {
+	routeConfig.VirtualHosts = envoy.CreateVirtualHosts(vhostToRouteArrayMap, vhostToLocalRateLimitDescriptors)
 }
 
 // GetEnforcerAPI retrieves the ApiDS object model for a given swagger definition
@@ -161,7 +177,7 @@ func GetEnforcerAPI(mgwSwagger model.MgwSwagger, vhost string) *api.Api {
 	for _, res := range mgwSwagger.GetResources() {
 		var operations = make([]*api.Operation, len(res.GetMethod()))
 		for i, op := range res.GetMethod() {
-			operations[i] = GetEnforcerAPIOperation(*op, isMockedAPI)
+			operations[i] = GetEnforcerAPIOperation(*op, mgwSwagger.IsMockedOperation(op))
 		}
 		resource := &api.Resource{
 			Id:      res.GetID(),
@@ -208,36 +224,38 @@ func GetEnforcerAPI(mgwSwagger model.MgwSwagger, vhost string) *api.Api {
 	}
 
 	return &api.Api{
-		Id:                    mgwSwagger.GetID(),
-		Title:                 mgwSwagger.GetTitle(),
-		Description:           mgwSwagger.GetDescription(),
-		BasePath:              mgwSwagger.GetXWso2Basepath(),
-		Version:               mgwSwagger.GetVersion(),
-		ApiType:               mgwSwagger.GetAPIType(),
-		ProductionEndpoints:   generateRPCEndpointCluster(mgwSwagger.GetProdEndpoints()),
-		SandboxEndpoints:      generateRPCEndpointCluster(mgwSwagger.GetSandEndpoints()),
-		Resources:             resources,
-		ApiLifeCycleState:     mgwSwagger.LifecycleStatus,
-		Tier:                  mgwSwagger.GetXWso2ThrottlingTier(),
-		SecurityScheme:        securitySchemes,
-		Security:              securityList,
-		EndpointSecurity:      endpointSecurityDetails,
-		AuthorizationHeader:   mgwSwagger.GetXWSO2AuthHeader(),
-		DisableSecurity:       mgwSwagger.GetDisableSecurity(),
-		OrganizationId:        mgwSwagger.OrganizationID,
-		Vhost:                 vhost,
-		IsMockedApi:           isMockedAPI,
-		ClientCertificates:    clientCertificates,
-		MutualSSL:             mgwSwagger.GetXWSO2MutualSSL(),
-		ApplicationSecurity:   mgwSwagger.GetXWSO2ApplicationSecurity(),
-		GraphQLSchema:         mgwSwagger.GraphQLSchema,
-		GraphqlComplexityInfo: mgwSwagger.GraphQLComplexities.Data.List,
-		EndpointType:          mgwSwagger.GetEndpointType(),
+		Id:                            mgwSwagger.GetID(),
+		Title:                         mgwSwagger.GetTitle(),
+		Description:                   mgwSwagger.GetDescription(),
+		BasePath:                      mgwSwagger.GetXWso2Basepath(),
+		Version:                       mgwSwagger.GetVersion(),
+		ApiType:                       mgwSwagger.GetAPIType(),
+		ProductionEndpoints:           generateRPCEndpointCluster(mgwSwagger.GetProdEndpoints()),
+		SandboxEndpoints:              generateRPCEndpointCluster(mgwSwagger.GetSandEndpoints()),
+		Resources:                     resources,
+		ApiLifeCycleState:             mgwSwagger.LifecycleStatus,
+		Tier:                          mgwSwagger.GetXWso2ThrottlingTier(),
+		SecurityScheme:                securitySchemes,
+		Security:                      securityList,
+		EndpointSecurity:              endpointSecurityDetails,
+		AuthorizationHeader:           mgwSwagger.GetXWSO2AuthHeader(),
+		DisableSecurity:               mgwSwagger.GetDisableSecurity(),
+		OrganizationId:                mgwSwagger.OrganizationID,
+		Vhost:                         vhost,
+		IsMockedApi:                   isMockedAPI,
+		ClientCertificates:            clientCertificates,
+		MutualSSL:                     mgwSwagger.GetXWSO2MutualSSL(),
+		ApplicationSecurity:           mgwSwagger.GetXWSO2ApplicationSecurity(),
+		GraphQLSchema:                 mgwSwagger.GraphQLSchema,
+		GraphqlComplexityInfo:         mgwSwagger.GraphQLComplexities.Data.List,
+		EndpointType:                  mgwSwagger.GetEndpointType(),
+		DisableSubscriptionValidation: mgwSwagger.IsSubscriptionValidationDisabled(),
 	}
 }
 
-// GetEnforcerAPIOperation builds the operation object expected by the proto definition
-func GetEnforcerAPIOperation(operation mgw.Operation, isMockedAPI bool) *api.Operation {
+// GetEnforcerAPIOperation builds the operation object expected by the proto definition. The mocked API config is
+// only set for the mocked operations, which are served by the enforcer instead of the backend.
+func GetEnforcerAPIOperation(operation mgw.Operation, isMockedOperation bool) *api.Operation {
 	secSchemas := make([]*api.SecurityList, len(operation.GetSecurity()))
 	for i, security := range operation.GetSecurity() {
 		mapOfSecurity := make(map[string]*api.Scopes)
@@ -254,7 +272,7 @@ func GetEnforcerAPIOperation(operation mgw.Operation, isMockedAPI bool) *api.Ope
 	}
 
 	var mockedAPIConfig *api.MockedApiConfig
-	if isMockedAPI {
+	if isMockedOperation {
 		mockedAPIConfig = operation.GetMockedAPIConfig()
 	}
 
@@ -263,10 +281,15 @@ func GetEnforcerAPIOperation(operation mgw.Operation, isMockedAPI bool) *api.Ope
 		Response: castPoliciesToEnforcerPolicies(operation.GetPolicies().Response),
 		Fault:    castPoliciesToEnforcerPolicies(operation.GetPolicies().Fault),
 	}
+	// Operations whose security is disabled bypass the subscription throttling, hence throttled by the anonymous tier
+	tier := operation.GetTier()
+	if operation.GetAnonymousTier() != "" {
+		tier = operation.GetAnonymousTier()
+	}
 	apiOperation := api.Operation{
 		Method:          operation.GetMethod(),
 		Security:        secSchemas,
-		Tier:            operation.GetTier(),
+		Tier:            tier,
 		DisableSecurity: operation.GetDisableSecurity(),
 		Policies:        policies,
 		MockedApiConfig: mockedAPIConfig,
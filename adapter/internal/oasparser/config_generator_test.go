@@ -0,0 +1,131 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package oasparser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/api"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetEnforcerAPIWithPrototypedOperations(t *testing.T) {
+	definition := `openapi: 3.0.1
+info:
+  title: Store
+  version: v1
+paths:
+  /orders:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              example:
+                orderId: 1
+    post:
+      responses:
+        "201":
+          description: Created
+`
+	var apiYaml model.APIYaml
+	apiYaml.Data.Name = "Store"
+	apiYaml.Data.Context = "/store"
+	apiYaml.Data.Version = "v1"
+	apiYaml.Data.APIType = constants.HTTP
+	apiYaml.Data.LifeCycleStatus = constants.Prototyped
+	apiYaml.Data.EndpointConfig.ImplementationStatus = constants.Prototyped
+	apiYaml.Data.EndpointConfig.ProductionEndpoints = []model.EndpointInfo{{Endpoint: "https://prototype.store.com"}}
+	var mgwSwagger model.MgwSwagger
+	assert.Nil(t, mgwSwagger.PopulateFromAPIYaml(apiYaml))
+	assert.Nil(t, mgwSwagger.GetMgwSwagger([]byte(definition)))
+
+	enforcerAPI := GetEnforcerAPI(mgwSwagger, "localhost")
+	assert.False(t, enforcerAPI.GetIsMockedApi(), "Prototyped API should not be mocked as a whole")
+	if assert.Equal(t, 1, len(enforcerAPI.GetResources())) {
+		for _, operation := range enforcerAPI.GetResources()[0].GetMethods() {
+			switch operation.GetMethod() {
+			case "GET":
+				if assert.NotNil(t, operation.GetMockedApiConfig(), "Prototyped operation should serve the mock") {
+					assert.Equal(t, "200", operation.GetMockedApiConfig().GetResponses()[0].GetCode())
+				}
+			case "POST":
+				assert.Nil(t, operation.GetMockedApiConfig(),
+					"Operation without mocked responses should be proxied to the prototype endpoint")
+			}
+		}
+	}
+}
+
+func TestGetEnforcerAPIWithSubscriptionValidationDisabled(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousForceSubscriptionValidation := conf.Adapter.ForceSubscriptionValidation
+	defer func() {
+		conf.Adapter.ForceSubscriptionValidation = previousForceSubscriptionValidation
+	}()
+
+	tests := []struct {
+		name                        string
+		apiYaml                     string
+		forceSubscriptionValidation bool
+		disabledExpected            bool
+	}{
+		{
+			name:             "Subscription validation disabled by the api.yaml",
+			apiYaml:          `{"name": "Store", "version": "v1", "context": "/store", "disableSubscriptionValidation": true}`,
+			disabledExpected: true,
+		},
+		{
+			name:                        "Subscription validation forced by the adapter",
+			apiYaml:                     `{"name": "Store", "version": "v1", "context": "/store", "disableSubscriptionValidation": true}`,
+			forceSubscriptionValidation: true,
+			disabledExpected:            false,
+		},
+		{
+			name:             "Subscription validation not disabled by the api.yaml",
+			apiYaml:          `{"name": "Store", "version": "v1", "context": "/store"}`,
+			disabledExpected: false,
+		},
+	}
+	for _, test := range tests {
+		conf.Adapter.ForceSubscriptionValidation = test.forceSubscriptionValidation
+		var apiYaml model.APIYaml
+		if !assert.Nil(t, json.Unmarshal([]byte(test.apiYaml), &apiYaml.Data), test.name) {
+			continue
+		}
+		apiYaml.Data.EndpointImplementationType = constants.MockedOASEndpointType
+		var mgwSwagger model.MgwSwagger
+		assert.Nil(t, mgwSwagger.PopulateFromAPIYaml(apiYaml), test.name)
+		assert.Equal(t, test.disabledExpected, mgwSwagger.IsSubscriptionValidationDisabled(), test.name)
+
+		// The flag should be carried to the enforcer through the discovery model
+		marshalledAPI, err := proto.Marshal(GetEnforcerAPI(mgwSwagger, "localhost"))
+		if !assert.Nil(t, err, test.name) {
+			continue
+		}
+		var enforcerAPI api.Api
+		if assert.Nil(t, proto.Unmarshal(marshalledAPI, &enforcerAPI), test.name) {
+			assert.Equal(t, test.disabledExpected, enforcerAPI.GetDisableSubscriptionValidation(), test.name)
+		}
+	}
+}
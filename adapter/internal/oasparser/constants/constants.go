@@ -27,6 +27,16 @@ const (
 	SecurityConfig        string = "securityConfig"
 )
 
+// Session management types of the load balanced endpoints given in the api.yaml
+const (
+	SessionManagementNone      string = "none"
+	SessionManagementTransport string = "transport"
+	SessionManagementCookie    string = "cookie"
+	// DefaultSessionCookieName is the cookie generated by the router to route the requests of a session to the same
+	// endpoint, unless the api.yaml gives the name of the cookie
+	DefaultSessionCookieName string = "CHOREO_CONNECT_SESSION"
+)
+
 // Constants for OpenAPI vendor extension keys and values
 const (
 	XWso2ProdEndpoints                string = "x-wso2-production-endpoints"
@@ -48,6 +58,21 @@ const (
 	XScopes                           string = "x-scopes"
 	XWso2PassRequestPayloadToEnforcer string = "x-wso2-pass-request-payload-to-enforcer"
 	XUriMapping                       string = "x-uri-mapping"
+	XWso2ExcludeOnGateways            string = "x-wso2-exclude-on-gateways"
+	XWso2NotFoundResponse             string = "x-wso2-not-found-response"
+	XWso2StripRequestHeaders          string = "x-wso2-strip-request-headers"
+	XWso2UnmatchedRequests            string = "x-wso2-unmatched-requests"
+	XWso2StripAuthHeader              string = "x-wso2-strip-auth-header"
+	XWso2ContextAliases               string = "x-wso2-context-aliases"
+	XWso2WebSocket                    string = "x-wso2-websocket"
+	XWso2WebSocketIdleTimeout         string = "x-wso2-websocket-idle-timeout"
+	XWso2PublicPaths                  string = "x-wso2-public-paths"
+	XWso2AnonymousTier                string = "x-wso2-anonymous-tier"
+	XWso2MockResponseSelection        string = "x-wso2-mock-response-selection"
+	XWso2AnalyticsProperties          string = "x-wso2-analytics-properties"
+	XWso2FallbackEndpoint             string = "x-wso2-fallback-endpoint"
+	XWso2UpstreamDecompression        string = "x-wso2-upstream-decompression"
+	XWso2HTTP3                        string = "x-wso2-http3"
 )
 
 // cluster name prefixes
@@ -71,6 +96,7 @@ const (
 	APIMMutualSSLType                    string = "mutualssl"
 	APIMMutualSSLMandatoryType           string = "mutualssl_mandatory"
 	APIOauthBasicAuthAPIKeyMandatoryType string = "oauth_basic_auth_api_key_mandatory"
+	APIMBasicAuthType                    string = "basic_auth"
 )
 
 // sub-property keys mentioned under x-wso2-request-interceptor and x-wso2-response-interceptor
@@ -81,6 +107,8 @@ const (
 	ClusterTimeout            string = "clusterTimeout"
 	RequestTimeout            string = "requestTimeout"
 	Includes                  string = "includes"
+	APILevelInterceptor       string = "api"
+	ResourceLevelInterceptor  string = "resource"
 	OperationLevelInterceptor string = "operation"
 )
 
@@ -97,6 +125,8 @@ const (
 	ActionRewriteMethod      string = "REWRITE_RESOURCE_METHOD"
 	ActionInterceptorService string = "CALL_INTERCEPTOR_SERVICE"
 	ActionRewritePath        string = "REWRITE_RESOURCE_PATH"
+	ActionPayloadToXML       string = "PAYLOAD_TO_XML"
+	ActionPayloadToJSON      string = "PAYLOAD_TO_JSON"
 
 	RewritePathResourcePath    string = "resourcePath"
 	InterceptorServiceURL      string = "interceptorServiceURL"
@@ -106,6 +136,9 @@ const (
 	HeaderValue                string = "headerValue"
 	CurrentMethod              string = "currentMethod"
 	UpdatedMethod              string = "updatedMethod"
+	PayloadRootElementName     string = "rootElementName"
+	PayloadAttributePrefix     string = "attributePrefix"
+	PayloadIgnoreAttributes    string = "ignoreAttributes"
 )
 
 // Constants that occur as values in api.yaml
@@ -121,6 +154,47 @@ const (
 	AwsLambda             string = "awslambda"
 	TemplateEndpointType  string = "TEMPLATE"
 	InlineEndpointType    string = "INLINE"
+	PublicVisibility      string = "PUBLIC"
+	PrivateVisibility     string = "PRIVATE"
+	RestrictedVisibility  string = "RESTRICTED"
+	UnlimitedTier         string = "Unlimited"
+)
+
+// Features of the API projects whose support depends on the API type. Those are checked against the
+// APITypeAllowedFeatures matrix.
+const (
+	// APIFeatureOperationPolicies are the operation policies of the api.yaml. The policies allowed for an API type
+	// are further restricted by the supported API types of the policy specifications.
+	APIFeatureOperationPolicies   string = "operationPolicies"
+	APIFeatureMockedEndpoint      string = "mockedEndpoint"
+	APIFeatureRequiredQueryParams string = "requiredQueryParams"
+	APIFeatureRewritePath         string = "rewritePath"
+	APIFeatureAwsLambda           string = "awsLambda"
+	APIFeatureRemapStatus         string = "remapStatus"
+	APIFeatureBufferRequest       string = "bufferRequest"
+	// APIFeatureMediaTypes are the consumes and produces lists of the API definition
+	APIFeatureMediaTypes   string = "mediaTypes"
+	APIFeatureInterceptors string = "interceptors"
+	// APIFeatureHTTPExtensions are the vendor extensions of the API definition which are only applied to HTTP APIs
+	APIFeatureHTTPExtensions string = "httpExtensions"
+)
+
+// APITypeAllowedFeatures is the matrix of the features allowed for the API types whose features are restricted.
+// API types which are not in the matrix are allowed to use all the features.
+var APITypeAllowedFeatures = map[string][]string{
+	WS:      {APIFeatureOperationPolicies},
+	WEBHOOK: {},
+}
+
+// UnixSocketURLType is the scheme of the endpoints specified as unix domain sockets (i.e. unix:///path/to.sock)
+const UnixSocketURLType string = "unix"
+
+// Access methods of the credentials used to invoke AWS Lambda functions
+const (
+	// AwsLambdaRoleSupplied uses the IAM role of the router, optionally assuming the given role
+	AwsLambdaRoleSupplied string = "role-supplied"
+	// AwsLambdaStored uses the access keys referred by the AWS Lambda configuration
+	AwsLambdaStored string = "stored"
 )
 
 // Constants used for version identification of API definitions
@@ -140,3 +214,10 @@ const (
 	Mandatory string = "mandatory"
 	Optional  string = "optional"
 )
+
+// Policies to resolve the basepath of an API when the basepath of the API definition conflicts with the api.yaml context
+const (
+	BasepathResolutionServersWins string = "serversWins"
+	BasepathResolutionContextWins string = "contextWins"
+	BasepathResolutionError       string = "error"
+)
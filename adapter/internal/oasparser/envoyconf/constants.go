@@ -23,6 +23,36 @@ const (
 	tracingClusterName      string = "wso2_cc_trace"
 	extAuthzHTTPClusterName string = "ext_authz_http_cluster"
 	awslambdaClusterName    string = "wso2_lambda_egress_gateway"
+	rateLimitClusterName    string = "wso2_cc_ratelimit"
+)
+
+// awsLambdaClusterPrefix - endpoint prefix of the clusters to the Lambda endpoints of the regions used by an API
+const awsLambdaClusterPrefix string = "awslambda_"
+
+// awsLambdaMetadataNamespace - namespace of the route metadata describing the AWS Lambda function of the route
+const awsLambdaMetadataNamespace string = "com.wso2.aws_lambda"
+
+// keyTypeMetadataNamespace - namespace of the route and cluster metadata describing the key types served by the
+// clusters
+const keyTypeMetadataNamespace string = "com.wso2.key_type"
+
+// fallbackOverprovisioningFactor - overprovisioning factor (in percentage) of the clusters having a fallback endpoint,
+// which keeps the requests in a priority as long as at least 1% of its endpoints are healthy
+const fallbackOverprovisioningFactor uint32 = 10000
+
+// analyticsMetadataNamespace - namespace of the route metadata holding the analytics properties of the operation
+const analyticsMetadataNamespace string = "com.wso2.analytics"
+
+// Key types of the requests, which decide whether the production or the sandbox cluster serves the request
+const (
+	keyTypeProduction string = "PRODUCTION"
+	keyTypeSandbox    string = "SANDBOX"
+)
+
+// Fields of the key type metadata
+const (
+	keyTypesMetadataKey      string = "key_types"
+	clusterHeaderMetadataKey string = "cluster_header"
 )
 
 const (
@@ -30,6 +60,7 @@ const (
 	luaFilterName              string = "envoy.filters.http.lua"
 	awsLambdaFilterName        string = "envoy.filters.http.aws_lambda"
 	transportSocketName        string = "envoy.transport_sockets.tls"
+	quicTransportSocketName    string = "envoy.transport_sockets.quic"
 	fileAccessLogName          string = "envoy.access_loggers.file"
 	grpcAccessLogName          string = "envoy.http_grpc_access_log"
 	httpConManagerStartPrefix  string = "ingress_http"
@@ -46,22 +77,52 @@ const (
 	mgwWebSocketWASM           string = "/home/wso2/wasm/websocket/mgw-websocket.wasm"
 	compressorFilterName       string = "envoy.filters.http.compressor"
 	localRatelimitFilterName   string = "envoy.filters.http.local_ratelimit"
+	bufferPerRouteName         string = "type.googleapis.com/envoy.extensions.filters.http.buffer.v3.BufferPerRoute"
+	decompressorFilterName     string = "envoy.filters.http.decompressor"
+	cacheFilterName            string = "envoy.filters.http.cache"
+)
+
+// The routes of the APIs having the x-wso2-upstream-decompression extension add the upstream decompression header
+// to the responses, which enables the decompressor filter for those responses. The Lua filter removes the header
+// once the responses are decompressed.
+const (
+	upstreamDecompressionHeader  string = "x-wso2-upstream-decompression"
+	upstreamDecompressionEnabled string = "enabled"
+)
+
+// altSvcHeader advertises HTTP/3 in the responses of the APIs having the x-wso2-http3 extension.
+const altSvcHeader string = "alt-svc"
+
+const (
+	localRateLimitStatPrefix            string = "http_local_rate_limiter"
+	jwksRateLimitStatPrefix             string = "jwks_rate_limit"
+	jwksRateLimitEnabledRuntimeKey      string = "jwks_ratelimit_enabled"
+	jwksRateLimitEnforcedRuntimeKey     string = "jwks_ratelimit_enforced"
+	apiLocalRateLimitEnabledRuntimeKey  string = "api_local_ratelimit_enabled"
+	apiLocalRateLimitEnforcedRuntimeKey string = "api_local_ratelimit_enforced"
+	jwksPathAtEnforcer                  string = "/jwks"
 )
 
+// Descriptor keys of the rate limit actions of the API routes
 const (
-	localRateLimitStatPrefix        string = "http_local_rate_limiter"
-	jwksRateLimitStatPrefix         string = "jwks_rate_limit"
-	jwksRateLimitEnabledRuntimeKey  string = "jwks_ratelimit_enabled"
-	jwksRateLimitEnforcedRuntimeKey string = "jwks_ratelimit_enforced"
-	jwksPathAtEnforcer              string = "/jwks"
+	rateLimitAPIDescriptorKey string = "api"
+	rateLimitKeyDescriptorKey string = "rateLimitKey"
+	// rateLimitKeyMetadataKey is the key of the dynamic metadata of the enforcer (ext_authz filter) holding the value
+	// of the claim used as the rate limit key of the API
+	rateLimitKeyMetadataKey string = "x-wso2-rate-limit-key"
+	// localRateLimitStage is the stage of the rate limit actions read by the local rate limit filter, while the rate
+	// limit service is sent the actions of the default stage (0)
+	localRateLimitStage uint32 = 1
 )
 
 const (
 	defaultRdsConfigName            string = "default"
 	defaultHTTPListenerName         string = "HTTPListener"
 	defaultHTTPSListenerName        string = "HTTPSListener"
+	defaultHTTP3ListenerName        string = "HTTP3Listener"
 	defaultAccessLogPath            string = "/tmp/envoy.access.log"
 	defaultListenerSecretConfigName string = "DefaultListenerSecret"
+	vhostListenerSecretConfigPrefix string = "ListenerSecret_"
 )
 
 // cluster prefixes
@@ -83,6 +144,25 @@ const (
 	apiNameContextExtension         string = "name"
 	prodClusterNameContextExtension string = "prodClusterName"
 	sandClusterNameContextExtension string = "sandClusterName"
+	visibleRolesContextExtension    string = "visibleRoles"
+	// timeout of the operation, which the enforcer should not override with the timeout of the endpoint
+	operationTimeoutContextExtension string = "operationTimeoutInMillis"
+	// request header selecting the mocked response of the operation, and the prefix of the context extensions mapping
+	// the values of the header to the preferences of the mocked responses (e.g. "mockResponse:not-found": "code=404")
+	mockSelectorHeaderContextExtension string = "mockSelectorHeader"
+	mockResponseContextExtensionPrefix string = "mockResponse:"
+	// prefix of the context extensions holding the analytics properties of the operation, which the enforcer adds
+	// to the analytics events
+	analyticsPropertyContextExtensionPrefix string = "analyticsProperty:"
+	// maximum size of the request headers of the API in KiB
+	maxRequestHeadersKbContextExtension string = "maxRequestHeadersKb"
+	// JWKS endpoint of the API and the interval at which its keys are refreshed
+	jwksURLContextExtension             string = "jwksUrl"
+	jwksRefreshIntervalContextExtension string = "jwksRefreshIntervalInSeconds"
+	// overrides enableOutboundAuthHeader of the enforcer for the API
+	enableOutboundAuthHeaderContextExtension string = "enableOutboundAuthHeader"
+	// JWT claim whose value the enforcer publishes as the rate limit key of the API
+	rateLimitClaimContextExtension  string = "rateLimitClaim"
 	retryPolicyRetriableStatusCodes string = "retriable-status-codes"
 )
 
@@ -95,6 +175,12 @@ const (
 	xWso2requestInterceptor string = "x-wso2-request-interceptor"
 	// xWso2responseInterceptor used to provide response interceptor details for api and resource level
 	xWso2responseInterceptor string = "x-wso2-response-interceptor"
+	// deprecationHeaderName the header which is added to the responses of deprecated operations
+	deprecationHeaderName string = "Deprecation"
+	// deprecationHeaderValue denotes that the operation is deprecated without a specific deprecation date
+	deprecationHeaderValue string = "true"
+	// varyHeaderName the header to which the cacheVaryHeaders of the operations are added
+	varyHeaderName string = "vary"
 )
 
 // interceptor levels
@@ -123,6 +209,21 @@ const (
 	readyEndpointResponse  = "{\"status\": \"ready\"}"
 )
 
+// notFoundFallbackRouteNamePrefix - prefix of the route returning the API level response for unmatched sub-paths
+const notFoundFallbackRouteNamePrefix string = "notFoundFallback:"
+
+// methodNotAllowedRouteNamePrefix - prefix of the route returning 405 for the unsupported methods of a resource
+const methodNotAllowedRouteNamePrefix string = "methodNotAllowed:"
+
+// vhostCatchAllRouteNamePrefix - prefix of the route returning the configured response for unmatched requests of a vhost
+const vhostCatchAllRouteNamePrefix string = "vhostCatchAll:"
+
+const (
+	allowHeaderName             string = "allow"
+	methodNotAllowedContentType string = "application/problem+json"
+	methodNotAllowedBody        string = `{"type":"about:blank","title":"Method Not Allowed","status":405}`
+)
+
 const (
 	defaultListenerHostAddress = "0.0.0.0"
 )
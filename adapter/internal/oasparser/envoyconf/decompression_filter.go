@@ -0,0 +1,172 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"errors"
+	"fmt"
+
+	matcherv3 "github.com/envoyproxy/go-control-plane/envoy/config/common/matcher/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extension_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/matching/v3"
+	gzip_decompressor "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/gzip/decompressor/v3"
+	matcher_action_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/common/matcher/action/v3"
+	decompressorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/decompressor/v3"
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	type_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// upstreamDecompressionLuaScript removes the upstream decompression header from the responses. The Lua filter is
+// placed before the decompressor, hence the responses are already decompressed when the header is removed, and the
+// clients do not receive the header. The response handler of the route's script (i.e. the interceptors or the wire
+// logs) is invoked after the header is removed.
+const upstreamDecompressionLuaScript = `
+local handle_response_with_upstream_decompression_header = envoy_on_response
+function envoy_on_response(response_handle)
+	response_handle:headers():remove("%s")
+	if handle_response_with_upstream_decompression_header ~= nil then
+		handle_response_with_upstream_decompression_header(response_handle)
+	end
+end
+`
+
+// getUpstreamDecompressionFilter returns the filter decompressing the gzip responses of the backends of the APIs
+// having the x-wso2-upstream-decompression extension. Since the decompressor filter does not support route specific
+// configurations, the filter is skipped unless the route of the response adds the upstream decompression header.
+// The filter is placed before the router, hence the interceptors and the policies read the decompressed responses.
+func getUpstreamDecompressionFilter() (*hcmv3.HttpFilter, error) {
+	conf, _ := config.ReadConfigs()
+	gzipConfig, err := anypb.New(&gzip_decompressor.Gzip{
+		MaxInflateRatio: wrapperspb.UInt32(conf.Envoy.Filters.UpstreamDecompression.MaxInflateRatio),
+	})
+	if err != nil {
+		return nil, errors.New("Error occurred while marshalling decompression library configurations. " + err.Error())
+	}
+	decompressorConfig, err := anypb.New(&decompressorv3.Decompressor{
+		DecompressorLibrary: &corev3.TypedExtensionConfig{
+			Name:        "upstream_gzip",
+			TypedConfig: gzipConfig,
+		},
+		// The requests are neither decompressed, nor advertised to accept compressed responses.
+		RequestDirectionConfig: &decompressorv3.Decompressor_RequestDirectionConfig{
+			CommonConfig: &decompressorv3.Decompressor_CommonDirectionConfig{
+				Enabled: &corev3.RuntimeFeatureFlag{
+					DefaultValue: wrapperspb.Bool(false),
+					RuntimeKey:   "request_decompressor_enabled",
+				},
+			},
+			AdvertiseAcceptEncoding: wrapperspb.Bool(false),
+		},
+	})
+	if err != nil {
+		return nil, errors.New("Error occurred while marshalling decompression filter configurations. " + err.Error())
+	}
+
+	skipFilter, err := anypb.New(&matcher_action_v3.SkipFilter{})
+	if err != nil {
+		return nil, errors.New("Error occurred while marshalling decompression filter matcher. " + err.Error())
+	}
+	headerInput, err := anypb.New(&type_matcher_v3.HttpResponseHeaderMatchInput{
+		HeaderName: upstreamDecompressionHeader,
+	})
+	if err != nil {
+		return nil, errors.New("Error occurred while marshalling decompression filter matcher. " + err.Error())
+	}
+	// The filter is skipped for the responses without the upstream decompression header.
+	headerPredicate := &matcherv3.Matcher_MatcherList_Predicate{
+		MatchType: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate_{
+			SinglePredicate: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate{
+				Input: &corev3.TypedExtensionConfig{
+					Name:        upstreamDecompressionHeader,
+					TypedConfig: headerInput,
+				},
+				Matcher: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate_ValueMatch{
+					ValueMatch: &type_matcher_v3.StringMatcher{
+						MatchPattern: &type_matcher_v3.StringMatcher_Exact{Exact: upstreamDecompressionEnabled},
+					},
+				},
+			},
+		},
+	}
+	filterConfig, err := anypb.New(&extension_matcher_v3.ExtensionWithMatcher{
+		Matcher: &matcherv3.Matcher{
+			MatcherType: &matcherv3.Matcher_MatcherList_{
+				MatcherList: &matcherv3.Matcher_MatcherList{
+					Matchers: []*matcherv3.Matcher_MatcherList_FieldMatcher{{
+						Predicate: &matcherv3.Matcher_MatcherList_Predicate{
+							MatchType: &matcherv3.Matcher_MatcherList_Predicate_NotMatcher{
+								NotMatcher: headerPredicate,
+							},
+						},
+						OnMatch: &matcherv3.Matcher_OnMatch{
+							OnMatch: &matcherv3.Matcher_OnMatch_Action{
+								Action: &corev3.TypedExtensionConfig{
+									Name:        "skip_upstream_decompression",
+									TypedConfig: skipFilter,
+								},
+							},
+						},
+					}},
+				},
+			},
+		},
+		ExtensionConfig: &corev3.TypedExtensionConfig{
+			Name:        decompressorFilterName,
+			TypedConfig: decompressorConfig,
+		},
+	})
+	if err != nil {
+		return nil, errors.New("Error occurred while marshalling decompression filter configurations. " + err.Error())
+	}
+	return &hcmv3.HttpFilter{
+		Name: decompressorFilterName,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: filterConfig,
+		},
+	}, nil
+}
+
+// addUpstreamDecompressionLuaScript appends the script removing the upstream decompression header from the
+// responses to the Lua filter configuration of a route.
+func addUpstreamDecompressionLuaScript(routeLuaConfig *lua.LuaPerRoute) {
+	script := routeLuaConfig.GetSourceCode().GetInlineString() +
+		fmt.Sprintf(upstreamDecompressionLuaScript, upstreamDecompressionHeader)
+	routeLuaConfig.Override = &lua.LuaPerRoute_SourceCode{
+		SourceCode: &corev3.DataSource{
+			Specifier: &corev3.DataSource_InlineString{
+				InlineString: script,
+			},
+		},
+	}
+}
+
+// getUpstreamDecompressionBufferLimit returns the buffer limit of the routes of an API decompressing the responses.
+// The buffer limit of a route applies to both the requests and the responses, hence the limit is not lowered below
+// the maximum size of the requests buffered for the APIs having the request buffering enabled.
+func getUpstreamDecompressionBufferLimit(bufferRequest bool) uint32 {
+	conf, _ := config.ReadConfigs()
+	bufferLimit := conf.Envoy.Filters.UpstreamDecompression.MaxDecompressedBytes
+	if bufferRequest && conf.Envoy.Filters.Buffer.MaxRequestBytes > bufferLimit {
+		bufferLimit = conf.Envoy.Filters.Buffer.MaxRequestBytes
+	}
+	return bufferLimit
+}
@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// endpointQueryParamsLuaScript appends the query parameters of the endpoints of the cluster selected for a request
+// (by the cluster header set by the enforcer) to the path of the request. The request handler of the route's script
+// (i.e. the interceptors or the wire logs) is invoked first, hence those see the request as sent by the client.
+const endpointQueryParamsLuaScript = `
+local endpoint_query_params = {
+%s}
+local handle_request_without_endpoint_query_params = envoy_on_request
+function envoy_on_request(request_handle)
+	if handle_request_without_endpoint_query_params ~= nil then
+		handle_request_without_endpoint_query_params(request_handle)
+	end
+	local query_params = endpoint_query_params[request_handle:headers():get("%s")]
+	if query_params == nil then
+		return
+	end
+	local path = request_handle:headers():get(":path")
+	local separator = "?"
+	if string.sub(path, -1) == "?" then
+		separator = ""
+	elseif string.find(path, "?", 1, true) ~= nil then
+		separator = "&"
+	end
+	request_handle:headers():replace(":path", path .. separator .. query_params)
+end
+`
+
+// addEndpointQueryParams records the query parameters of the endpoints of a cluster, if there are any. The
+// endpoints of a cluster have the same query parameters, as validated when the cluster is created.
+func addEndpointQueryParams(clusterQueryParams map[string]string, clusterName string,
+	endpointCluster *model.EndpointCluster) {
+	if clusterName == "" || endpointCluster == nil || len(endpointCluster.Endpoints) == 0 ||
+		endpointCluster.Endpoints[0].QueryParams == "" {
+		return
+	}
+	clusterQueryParams[clusterName] = endpointCluster.Endpoints[0].QueryParams
+}
+
+// addEndpointQueryParamsLuaScript appends the script appending the query parameters of the endpoints of the
+// production and sandbox clusters of a route to the Lua filter configuration of the route. The configuration is not
+// changed if the endpoints of neither cluster have query parameters.
+func addEndpointQueryParamsLuaScript(routeLuaConfig *lua.LuaPerRoute, clusterQueryParams map[string]string,
+	clusterNames ...string) {
+	routeQueryParams := make(map[string]string)
+	for _, clusterName := range clusterNames {
+		if queryParams, found := clusterQueryParams[clusterName]; found {
+			routeQueryParams[clusterName] = queryParams
+		}
+	}
+	if len(routeQueryParams) == 0 {
+		return
+	}
+	sortedClusterNames := make([]string, 0, len(routeQueryParams))
+	for clusterName := range routeQueryParams {
+		sortedClusterNames = append(sortedClusterNames, clusterName)
+	}
+	sort.Strings(sortedClusterNames)
+	var queryParams strings.Builder
+	for _, clusterName := range sortedClusterNames {
+		queryParams.WriteString(fmt.Sprintf("\t[%s] = %s,\n", quoteLuaString(clusterName),
+			quoteLuaString(routeQueryParams[clusterName])))
+	}
+	script := routeLuaConfig.GetSourceCode().GetInlineString() +
+		fmt.Sprintf(endpointQueryParamsLuaScript, queryParams.String(), clusterHeaderName)
+	routeLuaConfig.Override = &lua.LuaPerRoute_SourceCode{
+		SourceCode: &corev3.DataSource{
+			Specifier: &corev3.DataSource_InlineString{
+				InlineString: script,
+			},
+		},
+	}
+}
@@ -25,18 +25,33 @@ import (
 	"testing"
 	"time"
 
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	extension_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/matching/v3"
+	rlv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	gzip_decompressor "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/gzip/decompressor/v3"
+	matcher_action_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/common/matcher/action/v3"
+	bufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cache/v3"
 	cors_filter_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
+	decompressorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/decompressor/v3"
 	extAuthService "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	local_rate_limitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	ratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
+	simple_http_cache_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/cache/simple_http_cache/v3"
 	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	upstreams_http_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	envoy_type_matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/stretchr/testify/assert"
 	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -120,7 +135,7 @@ func TestCreateRoute(t *testing.T) {
 	assert.Equal(t, expectedRouteActionWithXWso2BasePath, generatedRouteWithXWso2BasePath.Action,
 		"Route generation mismatch when xWso2BasePath option is provided.")
 	assert.NotNil(t, generatedRouteWithXWso2BasePath.GetMatch().Headers, "Headers property should not be null")
-	assert.Equal(t, "^GET|OPTIONS$", generatedRouteWithXWso2BasePath.GetMatch().Headers[0].GetStringMatch().GetSafeRegex().Regex,
+	assert.Equal(t, "^(?:GET|OPTIONS)$", generatedRouteWithXWso2BasePath.GetMatch().Headers[0].GetStringMatch().GetSafeRegex().Regex,
 		"Assigned HTTP Method Regex is incorrect when single method is available.")
 
 	generatedRouteArrayWithoutXWso2BasePath, err := createRoutes(generateRouteCreateParamsForUnitTests(title, apiType, vHost, "", version,
@@ -129,7 +144,7 @@ func TestCreateRoute(t *testing.T) {
 	generatedRouteWithoutXWso2BasePath := generatedRouteArrayWithoutXWso2BasePath[0]
 	assert.NotNil(t, generatedRouteWithoutXWso2BasePath, "Route should not be null")
 	assert.NotNil(t, generatedRouteWithoutXWso2BasePath.GetMatch().Headers, "Headers property should not be null")
-	assert.Equal(t, "^GET|POST|OPTIONS$", generatedRouteWithoutXWso2BasePath.GetMatch().Headers[0].GetStringMatch().GetSafeRegex().Regex,
+	assert.Equal(t, "^(?:GET|POST|OPTIONS)$", generatedRouteWithoutXWso2BasePath.GetMatch().Headers[0].GetStringMatch().GetSafeRegex().Regex,
 		"Assigned HTTP Method Regex is incorrect when multiple methods are available.")
 
 	context := fmt.Sprintf("%s/%s", xWso2BasePath, version)
@@ -228,6 +243,606 @@ func TestCreateRouteExtAuthzContext(t *testing.T) {
 		"Sandbox Cluster mismatch in route ext authz context.")
 }
 
+func TestCreateRoutesWithContextAliases(t *testing.T) {
+	prodClusterName := "prodCluster"
+	sandClusterName := "sandCluster"
+	xWso2BasePath := "/xWso2BasePath"
+
+	resourceWithGet := model.CreateMinimalDummyResourceForTests("/resourcePath", []*model.Operation{model.NewOperation("GET", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+
+	routes, err := createRoutesWithContextAliases(generateRouteCreateParamsForUnitTests("WSO2", "HTTP", "localhost",
+		xWso2BasePath, "1.0.0", "/basepath", &resourceWithGet, prodClusterName, sandClusterName, nil, false),
+		[]string{"/alias1", "/alias2/"})
+	assert.Nil(t, err, "Error while creating routes with context aliases")
+	if !assert.Equal(t, 3, len(routes), "Routes should be created for the basepath and each context alias") {
+		return
+	}
+
+	for i, expectedContext := range []string{xWso2BasePath, "/alias1", "/alias2"} {
+		assert.True(t, strings.HasPrefix(routes[i].GetMatch().GetSafeRegex().GetRegex(), "^"+expectedContext+"/resourcePath"),
+			"Route %v should match the context %v", routes[i].GetMatch().GetSafeRegex().GetRegex(), expectedContext)
+		assert.Equal(t, clusterHeaderName, routes[i].GetRoute().GetClusterHeader(), "Route Cluster Header mismatch.")
+
+		extAuthPerRouteConfig := &extAuthService.ExtAuthzPerRoute{}
+		err = routes[i].TypedPerFilterConfig[wellknown.HTTPExternalAuthorization].UnmarshalTo(extAuthPerRouteConfig)
+		assert.Nilf(t, err, "Error while parsing ExtAuthzPerRouteConfig %v", extAuthPerRouteConfig)
+		contextExtensionMap := extAuthPerRouteConfig.GetCheckSettings().ContextExtensions
+		// The enforcer identifies the API by its basepath, irrespective of the context used to invoke it.
+		assert.Equal(t, xWso2BasePath, contextExtensionMap[basePathContextExtension],
+			"Basepath mismatch in route ext authz context.")
+		assert.Equal(t, prodClusterName, contextExtensionMap[prodClusterNameContextExtension],
+			"Production Cluster mismatch in route ext authz context.")
+		assert.Equal(t, sandClusterName, contextExtensionMap[sandClusterNameContextExtension],
+			"Sandbox Cluster mismatch in route ext authz context.")
+	}
+}
+
+func TestCreateRouteWithAPIVisibility(t *testing.T) {
+	resourceWithGet := model.CreateMinimalDummyResourceForTests("/resourcePath", []*model.Operation{model.NewOperation("GET", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+
+	getContextExtensions := func(visibility string, visibleRoles []string) map[string]string {
+		var apiYaml model.APIYaml
+		apiYaml.Data.Name = "WSO2"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/wso2"
+		apiYaml.Data.EndpointImplementationType = constants.MockedOASEndpointType
+		apiYaml.Data.Visibility = visibility
+		apiYaml.Data.VisibleRoles = visibleRoles
+		var mgwSwagger model.MgwSwagger
+		err := mgwSwagger.PopulateFromAPIYaml(apiYaml)
+		assert.Nil(t, err, "Error while populating the MgwSwagger from api.yaml")
+
+		routes, err := createRoutes(genRouteCreateParams(&mgwSwagger, &resourceWithGet, "localhost", "/basepath",
+			"prodCluster", "sandCluster", nil, nil, "carbon.super", false))
+		assert.Nil(t, err, "Error while creating routes")
+		extAuthPerRouteConfig := &extAuthService.ExtAuthzPerRoute{}
+		err = routes[0].TypedPerFilterConfig[wellknown.HTTPExternalAuthorization].UnmarshalTo(extAuthPerRouteConfig)
+		assert.Nilf(t, err, "Error while parsing ExtAuthzPerRouteConfig %v", extAuthPerRouteConfig)
+		return extAuthPerRouteConfig.GetCheckSettings().ContextExtensions
+	}
+
+	restrictedContext := getContextExtensions(constants.RestrictedVisibility, []string{"admin", "internal/publisher"})
+	assert.Equal(t, "admin,internal/publisher", restrictedContext[visibleRolesContextExtension],
+		"Restricted APIs should check the visible roles at the route.")
+
+	publicContext := getContextExtensions(constants.PublicVisibility, []string{"admin"})
+	assert.NotContains(t, publicContext, visibleRolesContextExtension, "Public APIs should not check roles at the route.")
+
+	privateContext := getContextExtensions(constants.PrivateVisibility, nil)
+	assert.NotContains(t, privateContext, visibleRolesContextExtension, "Private APIs should not check roles at the route.")
+}
+
+func TestCreateRouteWithRequestBuffering(t *testing.T) {
+	resourceWithPost := model.CreateMinimalDummyResourceForTests("/resourcePath", []*model.Operation{model.NewOperation("POST", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+
+	createRoutesWithBufferRequest := func(bufferRequest bool) []*routev3.Route {
+		var apiYaml model.APIYaml
+		apiYaml.Data.Name = "WSO2"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/wso2"
+		apiYaml.Data.EndpointImplementationType = constants.MockedOASEndpointType
+		apiYaml.Data.BufferRequest = bufferRequest
+		var mgwSwagger model.MgwSwagger
+		err := mgwSwagger.PopulateFromAPIYaml(apiYaml)
+		assert.Nil(t, err, "Error while populating the MgwSwagger from api.yaml")
+		routes, err := createRoutes(genRouteCreateParams(&mgwSwagger, &resourceWithPost, "localhost", "/basepath",
+			"prodCluster", "sandCluster", nil, nil, "carbon.super", false))
+		assert.Nil(t, err, "Error while creating routes")
+		return routes
+	}
+
+	conf, _ := config.ReadConfigs()
+	bufferingRoutes := createRoutesWithBufferRequest(true)
+	if assert.NotEmpty(t, bufferingRoutes) {
+		bufferPerRouteConfig := &bufferv3.BufferPerRoute{}
+		err := bufferingRoutes[0].GetTypedPerFilterConfig()[wellknown.Buffer].UnmarshalTo(bufferPerRouteConfig)
+		assert.Nil(t, err, "Error while parsing the buffer filter config of the route")
+		assert.Equal(t, conf.Envoy.Filters.Buffer.MaxRequestBytes,
+			bufferPerRouteConfig.GetBuffer().GetMaxRequestBytes().GetValue(),
+			"Requests of the API should be buffered up to the configured size.")
+	}
+
+	streamingRoutes := createRoutesWithBufferRequest(false)
+	if assert.NotEmpty(t, streamingRoutes) {
+		assert.NotContains(t, streamingRoutes[0].GetTypedPerFilterConfig(), wellknown.Buffer,
+			"Buffer filter should not be enabled for the routes of the APIs without request buffering.")
+	}
+
+	vHosts := CreateVirtualHosts(map[string][]*routev3.Route{"localhost": streamingRoutes}, nil)
+	if assert.Len(t, vHosts, 1) {
+		bufferPerRouteConfig := &bufferv3.BufferPerRoute{}
+		err := vHosts[0].GetTypedPerFilterConfig()[wellknown.Buffer].UnmarshalTo(bufferPerRouteConfig)
+		assert.Nil(t, err, "Error while parsing the buffer filter config of the virtual host")
+		assert.True(t, bufferPerRouteConfig.GetDisabled(), "Buffer filter should be disabled for the virtual hosts.")
+	}
+}
+
+func TestCreateRoutesWithLocalRateLimit(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+
+	createMgwSwagger := func(name string, localRateLimit *model.LocalRateLimit) *model.MgwSwagger {
+		var apiYaml model.APIYaml
+		apiYaml.Data.Name = name
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/" + name
+		apiYaml.Data.APIType = constants.HTTP
+		apiYaml.Data.LocalRateLimit = localRateLimit
+		var mgwSwagger model.MgwSwagger
+		err := mgwSwagger.PopulateFromAPIYaml(apiYaml)
+		assert.Nil(t, err, "Error while populating the MgwSwagger from api.yaml")
+		err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+		assert.Nil(t, err, "Error while populating the MgwSwagger from the API definition")
+		mgwSwagger.SetID("local-rate-limit-" + name)
+		return &mgwSwagger
+	}
+
+	rateLimitedSwagger := createMgwSwagger("petstore", &model.LocalRateLimit{RequestsPerUnit: 100, Unit: "Minute",
+		Burst: 20})
+	defer ReleaseGeneratedNames(*rateLimitedSwagger, "localhost")
+	rateLimitedRoutes, _, _, err := CreateRoutesWithClusters(*rateLimitedSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Greater(t, len(rateLimitedRoutes), 1, "API should have several routes")
+	for _, route := range rateLimitedRoutes {
+		assert.NotContains(t, route.GetTypedPerFilterConfig(), localRatelimitFilterName,
+			"Routes should not have their own token buckets.")
+		if assert.Len(t, route.GetRoute().GetRateLimits(), 1) {
+			rateLimit := route.GetRoute().GetRateLimits()[0]
+			assert.Equal(t, localRateLimitStage, rateLimit.GetStage().GetValue(),
+				"Local rate limit actions should not be sent to the rate limit service.")
+			if assert.Len(t, rateLimit.GetActions(), 1) {
+				genericKey := rateLimit.GetActions()[0].GetGenericKey()
+				assert.Equal(t, rateLimitAPIDescriptorKey, genericKey.GetDescriptorKey())
+				assert.Equal(t, "local-rate-limit-petstore", genericKey.GetDescriptorValue(),
+					"All the routes of the API should produce the descriptor of the API.")
+			}
+		}
+	}
+
+	swagger := createMgwSwagger("store", nil)
+	defer ReleaseGeneratedNames(*swagger, "localhost")
+	assert.Nil(t, CreateLocalRateLimitDescriptor(swagger))
+	routes, _, _, err := CreateRoutesWithClusters(*swagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	for _, route := range routes {
+		assert.Empty(t, route.GetRoute().GetRateLimits(),
+			"Routes of the APIs without a local rate limit should not produce a descriptor.")
+	}
+
+	descriptor := CreateLocalRateLimitDescriptor(rateLimitedSwagger)
+	vHosts := CreateVirtualHosts(map[string][]*routev3.Route{
+		"localhost":   append(rateLimitedRoutes, routes...),
+		"example.com": routes,
+	}, map[string][]*rlv3.LocalRateLimitDescriptor{"localhost": {descriptor}})
+	assert.Len(t, vHosts, 2)
+	for _, vHost := range vHosts {
+		if vHost.GetName() != "localhost" {
+			assert.NotContains(t, vHost.GetTypedPerFilterConfig(), localRatelimitFilterName,
+				"Local rate limit filter should not be enabled for the vhosts without locally rate limited APIs.")
+			continue
+		}
+		localRateLimitConfig := &local_rate_limitv3.LocalRateLimit{}
+		err := vHost.GetTypedPerFilterConfig()[localRatelimitFilterName].UnmarshalTo(localRateLimitConfig)
+		assert.Nil(t, err, "Error while parsing the local rate limit filter config of the virtual host")
+		assert.Equal(t, localRateLimitStage, localRateLimitConfig.GetStage())
+		assert.Equal(t, uint32(100), localRateLimitConfig.GetFilterEnforced().GetDefaultValue().GetNumerator(),
+			"Local rate limit should be enforced for the routes of the API.")
+		if assert.Len(t, localRateLimitConfig.GetDescriptors(), 1) {
+			apiDescriptor := localRateLimitConfig.GetDescriptors()[0]
+			if assert.Len(t, apiDescriptor.GetEntries(), 1) {
+				assert.Equal(t, rateLimitAPIDescriptorKey, apiDescriptor.GetEntries()[0].GetKey())
+				assert.Equal(t, "local-rate-limit-petstore", apiDescriptor.GetEntries()[0].GetValue())
+			}
+			assert.Equal(t, uint32(120), apiDescriptor.GetTokenBucket().GetMaxTokens(),
+				"Token bucket should allow the requests per unit and the burst.")
+			assert.Equal(t, uint32(100), apiDescriptor.GetTokenBucket().GetTokensPerFill().GetValue(),
+				"Token bucket should be refilled with the requests per unit.")
+			assert.Equal(t, time.Minute, apiDescriptor.GetTokenBucket().GetFillInterval().AsDuration(),
+				"Token bucket should be refilled once per unit.")
+			assert.Zero(t, apiDescriptor.GetTokenBucket().GetFillInterval().AsDuration()%
+				localRateLimitConfig.GetTokenBucket().GetFillInterval().AsDuration(),
+				"Fill interval of the API should be a multiple of the fill interval of the default token bucket.")
+		}
+	}
+}
+
+func TestCreateRouteWithResponseHeaders(t *testing.T) {
+	resourceWithGet := model.CreateMinimalDummyResourceForTests("/resourcePath", []*model.Operation{model.NewOperation("GET", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+
+	createRoutesWithResponseHeaders := func(responseHeaders map[string]string) []*routev3.Route {
+		var apiYaml model.APIYaml
+		apiYaml.Data.Name = "WSO2"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/wso2"
+		apiYaml.Data.EndpointImplementationType = constants.MockedOASEndpointType
+		apiYaml.Data.ResponseHeaders = responseHeaders
+		var mgwSwagger model.MgwSwagger
+		err := mgwSwagger.PopulateFromAPIYaml(apiYaml)
+		assert.Nil(t, err, "Error while populating the MgwSwagger from api.yaml")
+		routes, err := createRoutes(genRouteCreateParams(&mgwSwagger, &resourceWithGet, "localhost", "/basepath",
+			"prodCluster", "sandCluster", nil, nil, "carbon.super", false))
+		assert.Nil(t, err, "Error while creating routes")
+		return routes
+	}
+
+	routes := createRoutesWithResponseHeaders(map[string]string{"X-Served-By": "choreo-connect", "X-API-Tier": "gold"})
+	if assert.NotEmpty(t, routes) {
+		for _, route := range routes {
+			responseHeadersToAdd := route.GetResponseHeadersToAdd()
+			if !assert.Len(t, responseHeadersToAdd, 2, "Response headers of the API should be added to the route.") {
+				continue
+			}
+			assert.Equal(t, "X-API-Tier", responseHeadersToAdd[0].GetHeader().GetKey(),
+				"Response headers should be added in the order of the names.")
+			assert.Equal(t, "gold", responseHeadersToAdd[0].GetHeader().GetValue())
+			assert.Equal(t, "X-Served-By", responseHeadersToAdd[1].GetHeader().GetKey())
+			assert.Equal(t, "choreo-connect", responseHeadersToAdd[1].GetHeader().GetValue())
+			for _, headerToAdd := range responseHeadersToAdd {
+				assert.Equal(t, corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD, headerToAdd.GetAppendAction(),
+					"Response headers of the API should overwrite the headers sent by the backend.")
+			}
+		}
+	}
+
+	routes = createRoutesWithResponseHeaders(nil)
+	if assert.NotEmpty(t, routes) {
+		assert.Empty(t, routes[0].GetResponseHeadersToAdd(),
+			"Response headers should not be added to the routes of the APIs without response headers.")
+	}
+}
+
+func TestCreateRouteWithUpstreamDecompression(t *testing.T) {
+	resourceWithGet := model.CreateMinimalDummyResourceForTests("/resourcePath", []*model.Operation{model.NewOperation("GET", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+
+	createRoutesWithUpstreamDecompression := func(upstreamDecompression, bufferRequest bool) []*routev3.Route {
+		var apiYaml model.APIYaml
+		apiYaml.Data.Name = "WSO2"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/wso2"
+		apiYaml.Data.EndpointImplementationType = constants.MockedOASEndpointType
+		var mgwSwagger model.MgwSwagger
+		err := mgwSwagger.PopulateFromAPIYaml(apiYaml)
+		assert.Nil(t, err, "Error while populating the MgwSwagger from api.yaml")
+		params := genRouteCreateParams(&mgwSwagger, &resourceWithGet, "localhost", "/basepath", "prodCluster",
+			"sandCluster", nil, nil, "carbon.super", false)
+		params.upstreamDecompression = upstreamDecompression
+		params.bufferRequest = bufferRequest
+		routes, err := createRoutes(params)
+		assert.Nil(t, err, "Error while creating routes")
+		return routes
+	}
+
+	conf, _ := config.ReadConfigs()
+	decompressingRoutes := createRoutesWithUpstreamDecompression(true, false)
+	if assert.NotEmpty(t, decompressingRoutes) {
+		for _, route := range decompressingRoutes {
+			responseHeadersToAdd := route.GetResponseHeadersToAdd()
+			if assert.Len(t, responseHeadersToAdd, 1, "Upstream decompression header should be added to the route.") {
+				assert.Equal(t, upstreamDecompressionHeader, responseHeadersToAdd[0].GetHeader().GetKey())
+				assert.Equal(t, upstreamDecompressionEnabled, responseHeadersToAdd[0].GetHeader().GetValue())
+				assert.Equal(t, corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD, responseHeadersToAdd[0].GetAppendAction(),
+					"Upstream decompression header sent by the backend should be overwritten.")
+			}
+			assert.Equal(t, conf.Envoy.Filters.UpstreamDecompression.MaxDecompressedBytes,
+				route.GetPerRequestBufferLimitBytes().GetValue(),
+				"Decompressed responses should be buffered up to the configured size.")
+			assert.NotContains(t, route.GetTypedPerFilterConfig(), decompressorFilterName,
+				"Decompressor filter does not support route specific configurations.")
+			luaPerRouteConfig := &luav3.LuaPerRoute{}
+			err := route.GetTypedPerFilterConfig()[wellknown.Lua].UnmarshalTo(luaPerRouteConfig)
+			assert.Nil(t, err, "Error while parsing the Lua filter configuration of the route.")
+			assert.Contains(t, luaPerRouteConfig.GetSourceCode().GetInlineString(),
+				fmt.Sprintf("response_handle:headers():remove(\"%s\")", upstreamDecompressionHeader),
+				"Upstream decompression header should not be sent to the clients.")
+		}
+	}
+
+	// The buffer limit of the routes is not lowered below the limit of the request buffering of the API.
+	bufferMaxRequestBytes := conf.Envoy.Filters.Buffer.MaxRequestBytes
+	defer func() {
+		conf.Envoy.Filters.Buffer.MaxRequestBytes = bufferMaxRequestBytes
+	}()
+	conf.Envoy.Filters.Buffer.MaxRequestBytes = conf.Envoy.Filters.UpstreamDecompression.MaxDecompressedBytes * 2
+	bufferingRoutes := createRoutesWithUpstreamDecompression(true, true)
+	if assert.NotEmpty(t, bufferingRoutes) {
+		assert.Equal(t, conf.Envoy.Filters.Buffer.MaxRequestBytes,
+			bufferingRoutes[0].GetPerRequestBufferLimitBytes().GetValue(),
+			"Buffer limit should not be lower than the maximum size of the buffered requests.")
+	}
+
+	routes := createRoutesWithUpstreamDecompression(false, false)
+	if assert.NotEmpty(t, routes) {
+		assert.Empty(t, routes[0].GetResponseHeadersToAdd(),
+			"Upstream decompression header should not be added to the routes of the APIs without decompression.")
+		assert.Nil(t, routes[0].GetPerRequestBufferLimitBytes(),
+			"Buffer limit should not be set for the routes of the APIs without decompression.")
+		luaPerRouteConfig := &luav3.LuaPerRoute{}
+		err := routes[0].GetTypedPerFilterConfig()[wellknown.Lua].UnmarshalTo(luaPerRouteConfig)
+		assert.Nil(t, err, "Error while parsing the Lua filter configuration of the route.")
+		assert.True(t, luaPerRouteConfig.GetDisabled(),
+			"Lua filter should be disabled for the routes of the APIs without decompression.")
+	}
+}
+
+func TestCreateRouteWithHTTP3(t *testing.T) {
+	resourceWithGet := model.CreateMinimalDummyResourceForTests("/resourcePath", []*model.Operation{model.NewOperation("GET", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+
+	createRoutesWithHTTP3 := func(http3 bool) []*routev3.Route {
+		var apiYaml model.APIYaml
+		apiYaml.Data.Name = "WSO2"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/wso2"
+		apiYaml.Data.EndpointImplementationType = constants.MockedOASEndpointType
+		var mgwSwagger model.MgwSwagger
+		err := mgwSwagger.PopulateFromAPIYaml(apiYaml)
+		assert.Nil(t, err, "Error while populating the MgwSwagger from api.yaml")
+		params := genRouteCreateParams(&mgwSwagger, &resourceWithGet, "localhost", "/basepath", "prodCluster",
+			"sandCluster", nil, nil, "carbon.super", false)
+		params.http3 = http3
+		routes, err := createRoutes(params)
+		assert.Nil(t, err, "Error while creating routes")
+		return routes
+	}
+
+	http3Routes := createRoutesWithHTTP3(true)
+	if assert.NotEmpty(t, http3Routes) {
+		for _, route := range http3Routes {
+			responseHeadersToAdd := route.GetResponseHeadersToAdd()
+			if assert.Len(t, responseHeadersToAdd, 1, "Alt-Svc header should be added to the route.") {
+				assert.Equal(t, altSvcHeader, responseHeadersToAdd[0].GetHeader().GetKey())
+				assert.Equal(t, `h3=":9095"; ma=86400`, responseHeadersToAdd[0].GetHeader().GetValue())
+				assert.Equal(t, corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD, responseHeadersToAdd[0].GetAppendAction(),
+					"Alt-Svc header sent by the backend should be overwritten.")
+			}
+		}
+	}
+
+	routes := createRoutesWithHTTP3(false)
+	if assert.NotEmpty(t, routes) {
+		assert.Empty(t, routes[0].GetResponseHeadersToAdd(),
+			"Alt-Svc header should not be added to the routes of the APIs without HTTP/3.")
+	}
+}
+
+func TestGetUpstreamDecompressionFilter(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	filter, err := getUpstreamDecompressionFilter()
+	assert.Nil(t, err, "Error while creating the upstream decompression filter")
+	assert.Equal(t, decompressorFilterName, filter.GetName())
+
+	filterConfig := &extension_matcher_v3.ExtensionWithMatcher{}
+	err = filter.GetTypedConfig().UnmarshalTo(filterConfig)
+	assert.Nil(t, err, "Error while parsing the upstream decompression filter config")
+
+	decompressorConfig := &decompressorv3.Decompressor{}
+	err = filterConfig.GetExtensionConfig().GetTypedConfig().UnmarshalTo(decompressorConfig)
+	assert.Nil(t, err, "Error while parsing the decompressor config")
+	assert.False(t, decompressorConfig.GetRequestDirectionConfig().GetCommonConfig().GetEnabled().GetDefaultValue().
+		GetValue(), "Requests should not be decompressed.")
+	gzipConfig := &gzip_decompressor.Gzip{}
+	err = decompressorConfig.GetDecompressorLibrary().GetTypedConfig().UnmarshalTo(gzipConfig)
+	assert.Nil(t, err, "Error while parsing the gzip decompressor config")
+	assert.Equal(t, conf.Envoy.Filters.UpstreamDecompression.MaxInflateRatio, gzipConfig.GetMaxInflateRatio().GetValue())
+
+	matchers := filterConfig.GetMatcher().GetMatcherList().GetMatchers()
+	if assert.Len(t, matchers, 1) {
+		headerPredicate := matchers[0].GetPredicate().GetNotMatcher().GetSinglePredicate()
+		assert.NotNil(t, headerPredicate, "Decompressor should be skipped unless the header is found.")
+		headerInput := &envoy_type_matcherv3.HttpResponseHeaderMatchInput{}
+		err = headerPredicate.GetInput().GetTypedConfig().UnmarshalTo(headerInput)
+		assert.Nil(t, err, "Error while parsing the matcher input")
+		assert.Equal(t, upstreamDecompressionHeader, headerInput.GetHeaderName())
+		assert.Equal(t, upstreamDecompressionEnabled, headerPredicate.GetValueMatch().GetExact())
+		assert.True(t, matchers[0].GetOnMatch().GetAction().GetTypedConfig().MessageIs(&matcher_action_v3.SkipFilter{}),
+			"Decompressor should be skipped for the responses without the header.")
+	}
+}
+
+func TestGetHTTPFiltersWithRateLimit(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	enabled := conf.Envoy.RateLimit.Enabled
+	defer func() {
+		conf.Envoy.RateLimit.Enabled = enabled
+	}()
+
+	conf.Envoy.RateLimit.Enabled = false
+	for _, filter := range getHTTPFilters() {
+		assert.NotEqual(t, wellknown.HTTPRateLimit, filter.GetName(), "Rate limit filter should not be added.")
+	}
+
+	conf.Envoy.RateLimit.Enabled = true
+	httpFilters := getHTTPFilters()
+	rateLimitFilterIndex := -1
+	for i, filter := range httpFilters {
+		if filter.GetName() == wellknown.HTTPRateLimit {
+			rateLimitFilterIndex = i
+		}
+	}
+	if assert.Greater(t, rateLimitFilterIndex, 0, "Rate limit filter should be added.") {
+		assert.Equal(t, wellknown.HTTPExternalAuthorization, httpFilters[rateLimitFilterIndex-1].GetName(),
+			"Rate limit filter should be placed after the ext_authz filter.")
+		rateLimitConfig := &ratelimitv3.RateLimit{}
+		err := httpFilters[rateLimitFilterIndex].GetTypedConfig().UnmarshalTo(rateLimitConfig)
+		assert.Nil(t, err, "Error while parsing the rate limit filter config")
+		assert.Equal(t, conf.Envoy.RateLimit.Domain, rateLimitConfig.GetDomain())
+		assert.Equal(t, rateLimitClusterName,
+			rateLimitConfig.GetRateLimitService().GetGrpcService().GetEnvoyGrpc().GetClusterName())
+	}
+	assert.Equal(t, wellknown.Router, httpFilters[len(httpFilters)-1].GetName(), "Router should be the last filter.")
+}
+
+func TestGetResponseCacheFilter(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousResponseCache := conf.Envoy.Filters.ResponseCache
+	defer func() {
+		conf.Envoy.Filters.ResponseCache = previousResponseCache
+	}()
+	conf.Envoy.Filters.ResponseCache.Enabled = true
+	conf.Envoy.Filters.ResponseCache.AllowedVaryHeaders = []string{"Accept-Language", " x-tenant "}
+
+	filter, err := getResponseCacheFilter()
+	assert.Nil(t, err, "Error while creating the cache filter")
+	assert.Equal(t, cacheFilterName, filter.GetName())
+	cacheConfig := &cachev3.CacheConfig{}
+	err = filter.GetTypedConfig().UnmarshalTo(cacheConfig)
+	assert.Nil(t, err, "Error while parsing the cache filter config")
+	assert.True(t, cacheConfig.GetTypedConfig().MessageIs(&simple_http_cache_v3.SimpleHttpCacheConfig{}),
+		"Responses should be cached in the memory of the router")
+	var allowedVaryHeaders []string
+	for _, matcher := range cacheConfig.GetAllowedVaryHeaders() {
+		allowedVaryHeaders = append(allowedVaryHeaders, matcher.GetExact())
+		assert.True(t, matcher.GetIgnoreCase(), "Vary headers should be matched ignoring the case")
+	}
+	assert.Equal(t, []string{"accept-language", "x-tenant"}, allowedVaryHeaders)
+
+	httpFilters := getHTTPFilters()
+	cacheFilterIndex, extAuthzFilterIndex := -1, -1
+	for i, filter := range httpFilters {
+		switch filter.GetName() {
+		case cacheFilterName:
+			cacheFilterIndex = i
+		case wellknown.HTTPExternalAuthorization:
+			extAuthzFilterIndex = i
+		}
+	}
+	assert.Greater(t, cacheFilterIndex, extAuthzFilterIndex,
+		"Cache filter should be placed after the ext_authz filter, to authenticate the cached requests")
+	assert.Equal(t, decompressorFilterName, httpFilters[cacheFilterIndex+1].GetName(),
+		"Decompressed responses should be cached")
+
+	conf.Envoy.Filters.ResponseCache.Enabled = false
+	for _, filter := range getHTTPFilters() {
+		assert.NotEqual(t, cacheFilterName, filter.GetName(), "Cache filter should not be added.")
+	}
+}
+
+func TestCreateRateLimitCluster(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	cluster, addresses, err := CreateRateLimitCluster(conf)
+	assert.Nil(t, err, "Error while creating the rate limit cluster")
+	assert.Equal(t, rateLimitClusterName, cluster.GetName())
+	assert.Equal(t, 1, len(addresses))
+	assert.Equal(t, conf.Envoy.RateLimit.Host, addresses[0].GetSocketAddress().GetAddress())
+	assert.Equal(t, conf.Envoy.RateLimit.Port, addresses[0].GetSocketAddress().GetPortValue())
+	assert.NotNil(t, cluster.GetTypedExtensionProtocolOptions()["envoy.extensions.upstreams.http.v3.HttpProtocolOptions"],
+		"Rate limit service should be called over HTTP/2.")
+}
+
+func TestCreateRouteWithMaxRequestHeadersKb(t *testing.T) {
+	resourceWithGet := model.CreateMinimalDummyResourceForTests("/resourcePath", []*model.Operation{model.NewOperation("GET", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+
+	getContextExtensions := func(maxRequestHeadersKb *int) map[string]string {
+		var apiYaml model.APIYaml
+		apiYaml.Data.Name = "WSO2"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/wso2"
+		apiYaml.Data.EndpointImplementationType = constants.MockedOASEndpointType
+		apiYaml.Data.MaxRequestHeadersKb = maxRequestHeadersKb
+		var mgwSwagger model.MgwSwagger
+		err := mgwSwagger.PopulateFromAPIYaml(apiYaml)
+		assert.Nil(t, err, "Error while populating the MgwSwagger from api.yaml")
+
+		routes, err := createRoutes(genRouteCreateParams(&mgwSwagger, &resourceWithGet, "localhost", "/basepath",
+			"prodCluster", "sandCluster", nil, nil, "carbon.super", false))
+		assert.Nil(t, err, "Error while creating routes")
+		extAuthPerRouteConfig := &extAuthService.ExtAuthzPerRoute{}
+		err = routes[0].TypedPerFilterConfig[wellknown.HTTPExternalAuthorization].UnmarshalTo(extAuthPerRouteConfig)
+		assert.Nilf(t, err, "Error while parsing ExtAuthzPerRouteConfig %v", extAuthPerRouteConfig)
+		return extAuthPerRouteConfig.GetCheckSettings().ContextExtensions
+	}
+
+	maxRequestHeadersKb := 16
+	limitedContext := getContextExtensions(&maxRequestHeadersKb)
+	assert.Equal(t, "16", limitedContext[maxRequestHeadersKbContextExtension],
+		"Header size limit of the API should be applied at the route.")
+
+	unlimitedContext := getContextExtensions(nil)
+	assert.NotContains(t, unlimitedContext, maxRequestHeadersKbContextExtension,
+		"Header size limit should not be applied at the route when the API does not set it.")
+}
+
+func TestGenerateOperationFilterConfigsWithMockResponseSelection(t *testing.T) {
+	extAuthzConfig := &extAuthService.ExtAuthzPerRoute{
+		Override: &extAuthService.ExtAuthzPerRoute_CheckSettings{
+			CheckSettings: &extAuthService.CheckSettings{
+				ContextExtensions: map[string]string{pathContextExtension: "/pets"},
+			},
+		},
+	}
+	mockResponseSelection := &model.MockResponseSelection{
+		Header: "X-Mock-Scenario",
+		Responses: map[string]model.MockResponsePreference{
+			"not-found":   {Code: "404"},
+			"premium-pet": {Code: "200", Example: "premium"},
+		},
+	}
+
+	filterConfigs := generateOperationFilterConfigs(nil, extAuthzConfig, false, 0,
+		mockResponseSelection, nil)
+	operationExtAuthzConfig := &extAuthService.ExtAuthzPerRoute{}
+	err := filterConfigs[wellknown.HTTPExternalAuthorization].UnmarshalTo(operationExtAuthzConfig)
+	assert.Nilf(t, err, "Error while parsing ExtAuthzPerRouteConfig %v", operationExtAuthzConfig)
+	assert.Equal(t, map[string]string{
+		pathContextExtension:                               "/pets",
+		mockSelectorHeaderContextExtension:                 "x-mock-scenario",
+		mockResponseContextExtensionPrefix + "not-found":   "code=404",
+		mockResponseContextExtensionPrefix + "premium-pet": "code=200, example=premium",
+	}, operationExtAuthzConfig.GetCheckSettings().ContextExtensions,
+		"Mocked responses selected by the header should be passed to the enforcer")
+	assert.Len(t, extAuthzConfig.GetCheckSettings().ContextExtensions, 1,
+		"ExtAuthzPerRoute config shared by the routes of the resource should not be modified")
+}
+
+func TestCreateRouteWithJwksConfig(t *testing.T) {
+	resourceWithGet := model.CreateMinimalDummyResourceForTests("/resourcePath", []*model.Operation{model.NewOperation("GET", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+
+	getContextExtensions := func(jwksConfig *model.JwksConfig) map[string]string {
+		var apiYaml model.APIYaml
+		apiYaml.Data.Name = "WSO2"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/wso2"
+		apiYaml.Data.EndpointImplementationType = constants.MockedOASEndpointType
+		apiYaml.Data.JwksConfig = jwksConfig
+		var mgwSwagger model.MgwSwagger
+		err := mgwSwagger.PopulateFromAPIYaml(apiYaml)
+		assert.Nil(t, err, "Error while populating the MgwSwagger from api.yaml")
+
+		routes, err := createRoutes(genRouteCreateParams(&mgwSwagger, &resourceWithGet, "localhost", "/basepath",
+			"prodCluster", "sandCluster", nil, nil, "carbon.super", false))
+		assert.Nil(t, err, "Error while creating routes")
+		extAuthPerRouteConfig := &extAuthService.ExtAuthzPerRoute{}
+		err = routes[0].TypedPerFilterConfig[wellknown.HTTPExternalAuthorization].UnmarshalTo(extAuthPerRouteConfig)
+		assert.Nilf(t, err, "Error while parsing ExtAuthzPerRouteConfig %v", extAuthPerRouteConfig)
+		return extAuthPerRouteConfig.GetCheckSettings().ContextExtensions
+	}
+
+	tenantContext := getContextExtensions(&model.JwksConfig{JwksURL: "https://idp.tenant1.com/oauth2/jwks",
+		RefreshIntervalInSeconds: 300})
+	assert.Equal(t, "https://idp.tenant1.com/oauth2/jwks", tenantContext[jwksURLContextExtension],
+		"JWKS endpoint of the API should be set at the route.")
+	assert.Equal(t, "300", tenantContext[jwksRefreshIntervalContextExtension],
+		"JWKS refresh interval of the API should be set at the route.")
+
+	defaultIntervalContext := getContextExtensions(&model.JwksConfig{JwksURL: "https://idp.tenant2.com/oauth2/jwks"})
+	assert.Equal(t, "https://idp.tenant2.com/oauth2/jwks", defaultIntervalContext[jwksURLContextExtension],
+		"JWKS endpoint of the API should be set at the route.")
+	assert.NotContains(t, defaultIntervalContext, jwksRefreshIntervalContextExtension,
+		"JWKS refresh interval should not be set at the route when the API does not set it.")
+
+	issuerContext := getContextExtensions(nil)
+	assert.NotContains(t, issuerContext, jwksURLContextExtension,
+		"JWKS endpoint should not be set at the route when the API does not set it.")
+}
+
 func TestGenerateTLSCert(t *testing.T) {
 	publicKeyPath := config.GetMgwHome() + "/adapter/security/localhost.pem"
 	privateKeyPath := config.GetMgwHome() + "/adapter/security/localhost.key"
@@ -514,6 +1129,132 @@ func TestGenerateRegexSegment(t *testing.T) {
 	}
 }
 
+func TestProcessEndpointsWithIdleTimeout(t *testing.T) {
+	getIdleTimeout := func(endpointConfig *model.EndpointConfig) *durationpb.Duration {
+		endpointCluster := &model.EndpointCluster{
+			Endpoints: []model.Endpoint{{Host: "petstore.swagger.io", URLType: "http", Port: 80, Basepath: "/v2"}},
+			Config:    endpointConfig,
+		}
+		cluster, _, err := processEndpoints("prodCluster", endpointCluster, nil, 20, "/v2")
+		if !assert.Nil(t, err, "Error while processing the endpoints") || !assert.NotNil(t, cluster) {
+			return nil
+		}
+		httpProtocolOptions := &upstreams_http_v3.HttpProtocolOptions{}
+		err = cluster.GetTypedExtensionProtocolOptions()["envoy.extensions.upstreams.http.v3.HttpProtocolOptions"].
+			UnmarshalTo(httpProtocolOptions)
+		assert.Nil(t, err, "Error while parsing the HTTP protocol options of the cluster")
+		return httpProtocolOptions.GetCommonHttpProtocolOptions().GetIdleTimeout()
+	}
+
+	idleTimeout := getIdleTimeout(&model.EndpointConfig{IdleTimeout: "1m30s"})
+	if assert.NotNil(t, idleTimeout, "Idle timeout of the endpoints should be applied to the cluster") {
+		assert.Equal(t, 90*time.Second, idleTimeout.AsDuration())
+	}
+	assert.Nil(t, getIdleTimeout(&model.EndpointConfig{}), "Default idle timeout of the router should be applied")
+	assert.Nil(t, getIdleTimeout(nil), "Default idle timeout of the router should be applied")
+}
+
+func TestProcessEndpointsWithQueryParams(t *testing.T) {
+	processEndpointsWithQueryParams := func(queryParams ...string) error {
+		endpointCluster := &model.EndpointCluster{EndpointType: "loadbalance"}
+		for _, endpointQueryParams := range queryParams {
+			endpointCluster.Endpoints = append(endpointCluster.Endpoints, model.Endpoint{Host: "petstore.swagger.io",
+				URLType: "http", Port: 80, Basepath: "/v2", QueryParams: endpointQueryParams})
+		}
+		_, _, err := processEndpoints("prodCluster", endpointCluster, nil, 20, "/v2")
+		return err
+	}
+
+	assert.Nil(t, processEndpointsWithQueryParams("apiKey=abc", "apiKey=abc"),
+		"Endpoints with the same query parameters should be allowed in a cluster")
+	assert.NotNil(t, processEndpointsWithQueryParams("apiKey=abc", "apiKey=xyz"),
+		"Endpoints with different query parameters should not be allowed in a cluster")
+	assert.NotNil(t, processEndpointsWithQueryParams("apiKey=abc", ""),
+		"Endpoints with and without query parameters should not be allowed in a cluster")
+}
+
+func TestProcessEndpointsWithTLSSkipVerify(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousAllowTLSSkipVerify := conf.Envoy.Upstream.TLS.AllowTLSSkipVerify
+	defer func() {
+		conf.Envoy.Upstream.TLS.AllowTLSSkipVerify = previousAllowTLSSkipVerify
+	}()
+
+	getUpstreamTLSContext := func(tlsSkipVerify bool) *tlsv3.UpstreamTlsContext {
+		endpointCluster := &model.EndpointCluster{
+			Endpoints:    []model.Endpoint{{Host: "dev.wso2.com", URLType: "https", Port: 443, Basepath: "/v2"}},
+			EndpointType: "loadbalance",
+			Config:       &model.EndpointConfig{TLSSkipVerify: tlsSkipVerify},
+		}
+		cluster, _, err := processEndpoints("prodCluster", endpointCluster, nil, 20, "/v2")
+		if !assert.Nil(t, err, "Error while processing the endpoints") ||
+			!assert.Len(t, cluster.GetTransportSocketMatches(), 1, "TLS context should be added for the endpoint") {
+			return nil
+		}
+		upstreamTLSContext := &tlsv3.UpstreamTlsContext{}
+		err = cluster.GetTransportSocketMatches()[0].GetTransportSocket().GetTypedConfig().UnmarshalTo(upstreamTLSContext)
+		assert.Nil(t, err, "Error while reading the TLS context")
+		return upstreamTLSContext
+	}
+
+	conf.Envoy.Upstream.TLS.AllowTLSSkipVerify = false
+	assert.NotNil(t, getUpstreamTLSContext(true).GetCommonTlsContext().GetValidationContext(),
+		"Certificates should be verified when skipping the verification is not allowed globally")
+	assert.NotNil(t, getUpstreamTLSContext(false).GetCommonTlsContext().GetValidationContext(),
+		"Certificates should be verified unless the endpoint skips the verification")
+
+	conf.Envoy.Upstream.TLS.AllowTLSSkipVerify = true
+	assert.Nil(t, getUpstreamTLSContext(true).GetCommonTlsContext().GetValidationContext(),
+		"Certificates should not be verified when the endpoint skips the verification and it is allowed globally")
+	upstreamTLSContext := getUpstreamTLSContext(false)
+	assert.NotNil(t, upstreamTLSContext.GetCommonTlsContext().GetValidationContext(),
+		"Certificates should be verified unless the endpoint skips the verification")
+	assert.NotEmpty(t, upstreamTLSContext.GetCommonTlsContext().GetValidationContext().GetMatchTypedSubjectAltNames(),
+		"Host name should be verified unless the endpoint skips the verification")
+}
+
+func TestProcessEndpointsWithFallbackEndpoint(t *testing.T) {
+	getCluster := func(fallbackEndpoint *model.Endpoint) *clusterv3.Cluster {
+		endpointCluster := &model.EndpointCluster{
+			Endpoints: []model.Endpoint{
+				{Host: "petstore1.swagger.io", URLType: "http", Port: 80, Basepath: "/v2"},
+				{Host: "petstore2.swagger.io", URLType: "http", Port: 80, Basepath: "/v2"},
+			},
+			EndpointType:     "loadbalance",
+			FallbackEndpoint: fallbackEndpoint,
+		}
+		cluster, _, err := processEndpoints("prodCluster", endpointCluster, nil, 20, "/v2")
+		assert.Nil(t, err, "Error while processing the endpoints")
+		return cluster
+	}
+
+	cluster := getCluster(&model.Endpoint{Host: "sorry.wso2.com", URLType: "https", Port: 443, Basepath: "/v2"})
+	localityLbEndpoints := cluster.GetLoadAssignment().GetEndpoints()
+	if assert.Len(t, localityLbEndpoints, 3, "Fallback endpoint should be added to the cluster") {
+		assert.Equal(t, uint32(0), localityLbEndpoints[0].GetPriority())
+		assert.Equal(t, uint32(0), localityLbEndpoints[1].GetPriority())
+		assert.Equal(t, uint32(1), localityLbEndpoints[2].GetPriority(),
+			"Fallback endpoint should have a lower priority than the production endpoints")
+		assert.Equal(t, "sorry.wso2.com", localityLbEndpoints[2].GetLbEndpoints()[0].GetEndpoint().GetAddress().
+			GetSocketAddress().GetAddress())
+	}
+	assert.NotEmpty(t, cluster.GetHealthChecks(), "Production endpoints should be health checked")
+	assert.Equal(t, fallbackOverprovisioningFactor,
+		cluster.GetLoadAssignment().GetPolicy().GetOverprovisioningFactor().GetValue(),
+		"Requests should fail over only when all the production endpoints are unhealthy")
+	assert.Len(t, cluster.GetTransportSocketMatches(), 1, "TLS should be enabled for the HTTPS fallback endpoint")
+
+	cluster = getCluster(nil)
+	localityLbEndpoints = cluster.GetLoadAssignment().GetEndpoints()
+	if assert.Len(t, localityLbEndpoints, 2, "Only the production endpoints should be added to the cluster") {
+		for _, localityLbEndpoint := range localityLbEndpoints {
+			assert.Equal(t, uint32(0), localityLbEndpoint.GetPriority())
+		}
+	}
+	assert.Nil(t, cluster.GetLoadAssignment().GetPolicy(),
+		"Default overprovisioning factor should be used without a fallback endpoint")
+}
+
 func TestCreateUpstreamTLSContext(t *testing.T) {
 	certFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/certs/testcrt.crt"
 	certByteArr, err := ioutil.ReadFile(certFilePath)
@@ -545,9 +1286,9 @@ func TestCreateUpstreamTLSContext(t *testing.T) {
 	}}
 
 	tlsCert := generateTLSCert(defaultMgwKeyPath, defaultMgwCertPath)
-	upstreamTLSContextWithCerts := createUpstreamTLSContext(certByteArr, hostNameAddress, false)
-	upstreamTLSContextWithoutCerts := createUpstreamTLSContext(nil, hostNameAddress, false)
-	upstreamTLSContextWithIP := createUpstreamTLSContext(certByteArr, hostNameAddressWithIP, false)
+	upstreamTLSContextWithCerts := createUpstreamTLSContext(certByteArr, hostNameAddress, false, nil, false)
+	upstreamTLSContextWithoutCerts := createUpstreamTLSContext(nil, hostNameAddress, false, nil, false)
+	upstreamTLSContextWithIP := createUpstreamTLSContext(certByteArr, hostNameAddressWithIP, false, nil, false)
 
 	assert.NotEmpty(t, upstreamTLSContextWithCerts, "Upstream TLS Context should not be null when certs provided")
 	assert.NotEmpty(t, upstreamTLSContextWithCerts.CommonTlsContext, "CommonTLSContext should not be "+
@@ -581,6 +1322,12 @@ func TestCreateUpstreamTLSContext(t *testing.T) {
 		"Upstream SAN mismatch.")
 	assert.Equal(t, tlsv3.SubjectAltNameMatcher_IP_ADDRESS, upstreamTLSContextWithIP.CommonTlsContext.GetValidationContext().GetMatchTypedSubjectAltNames()[0].SanType,
 		"Upstream SAN type mismatch.")
+
+	upstreamTLSContextWithCiphers := createUpstreamTLSContext(certByteArr, hostNameAddress, false,
+		[]string{" ECDHE-RSA-AES256-GCM-SHA384", "[ECDHE-RSA-AES128-GCM-SHA256|ECDHE-RSA-CHACHA20-POLY1305]"}, false)
+	assert.Equal(t, []string{"ECDHE-RSA-AES256-GCM-SHA384", "[ECDHE-RSA-AES128-GCM-SHA256|ECDHE-RSA-CHACHA20-POLY1305]"},
+		upstreamTLSContextWithCiphers.CommonTlsContext.TlsParams.CipherSuites,
+		"Cipher suites of the endpoint should override the global cipher suites")
 }
 
 func TestGetCorsPolicy(t *testing.T) {
@@ -671,6 +1418,24 @@ func TestGetCorsPolicy(t *testing.T) {
 	assert.Empty(t, corsConfig2.GetAllowCredentials(), "Cors AllowCredentials should be empty.")
 }
 
+func TestGenerateRequestHeadersToStrip(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousStripRequestHeaders := conf.Adapter.StripRequestHeaders
+	defer func() {
+		conf.Adapter.StripRequestHeaders = previousStripRequestHeaders
+	}()
+
+	conf.Adapter.StripRequestHeaders = []string{"X-Forwarded-For", ":path", "Host", " "}
+	assert.Equal(t, []string{"x-forwarded-for", "x-internal-route"},
+		generateRequestHeadersToStrip([]string{"x-FORWARDED-for", "X-Internal-Route", ":method"}))
+	assert.Equal(t, []string{"x-forwarded-for"}, generateRequestHeadersToStrip(nil))
+
+	conf.Adapter.StripRequestHeaders = []string{}
+	assert.Nil(t, generateRequestHeadersToStrip(nil), "No headers should be stripped by default")
+	assert.Equal(t, []string{"x-forwarded-for", "x-custom"},
+		mergeHeaderNames([]string{"X-Forwarded-For"}, []string{"x-custom", "X-FORWARDED-FOR"}))
+}
+
 func generateRouteCreateParamsForUnitTests(title string, apiType string, vhost string, xWso2Basepath string, version string, endpointBasepath string,
 	resource *model.Resource, prodClusterName string, sandClusterName string,
 	corsConfig *model.CorsConfig, isDefaultVersion bool) *routeCreateParams {
@@ -688,3 +1453,10 @@ func generateRouteCreateParamsForUnitTests(title string, apiType string, vhost s
 		isDefaultVersion: isDefaultVersion,
 	}
 }
+
+func TestQuoteLuaString(t *testing.T) {
+	assert.Equal(t, `"error"`, quoteLuaString("error"))
+	assert.Equal(t, `"{\"code\": \\d}"`, quoteLuaString(`{"code": \d}`))
+	assert.Equal(t, `"line\010break"`, quoteLuaString("line\nbreak"), "Control characters should be escaped")
+	assert.Equal(t, `"caf\195\169"`, quoteLuaString("café"), "Non ASCII characters should be escaped")
+}
@@ -26,10 +26,12 @@ import (
 
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	awslambdav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/aws_lambda/v3"
+	bufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
 	cors_filter_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
 	ext_authv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
 	local_ratelimit_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	ratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
 	routerv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
 	wasm_filter_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/wasm/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
@@ -37,8 +39,9 @@ import (
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
 
-	//rls "github.com/envoyproxy/go-control-plane/envoy/config/ratelimit/v3"
+	rls "github.com/envoyproxy/go-control-plane/envoy/config/ratelimit/v3"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/wso2/product-microgateway/adapter/config"
@@ -57,10 +60,12 @@ func getHTTPFilters() []*hcmv3.HttpFilter {
 	awsLambda := getAwsLambdaFilter()
 	cors := getCorsHTTPFilter()
 	localRateLimit := getHTTPLocalRateLimitFilter()
+	buffer := getBufferFilter()
 
 	httpFilters := []*hcmv3.HttpFilter{
 		cors,
 		localRateLimit,
+		buffer,
 		extAauth,
 		lua,
 		awsLambda,
@@ -69,6 +74,13 @@ func getHTTPFilters() []*hcmv3.HttpFilter {
 
 	conf, _ := config.ReadConfigs()
 
+	if conf.Envoy.RateLimit.Enabled {
+		// The rate limit filter is placed after the ext_authz filter, as the rate limit keys of the APIs can be read
+		// from the dynamic metadata of the enforcer.
+		httpFilters = append(httpFilters[:4], append([]*hcmv3.HttpFilter{getRateLimitFilter(conf)},
+			httpFilters[4:]...)...)
+	}
+
 	if conf.Envoy.Filters.Compression.Enabled {
 		compressionFilter, err := getCompressorFilter()
 		if err != nil {
@@ -83,6 +95,34 @@ func getHTTPFilters() []*hcmv3.HttpFilter {
 		httpFilters = append(httpFilters, compressionFilter)
 		httpFilters = append(httpFilters, router)
 	}
+
+	if conf.Envoy.Filters.ResponseCache.Enabled {
+		cacheFilter, err := getResponseCacheFilter()
+		if err != nil {
+			logger.LoggerXds.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error occurred while creating the cache filter: %v", err.Error()),
+				Severity:  logging.MINOR,
+				ErrorCode: 2243,
+			})
+			return httpFilters
+		}
+		// The cache filter is placed after the compressor, hence the responses are cached uncompressed and
+		// compressed as per each request.
+		httpFilters = append(httpFilters[:len(httpFilters)-1], cacheFilter, router)
+	}
+
+	decompressionFilter, err := getUpstreamDecompressionFilter()
+	if err != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error occurred while creating the upstream decompression filter: %v", err.Error()),
+			Severity:  logging.MINOR,
+			ErrorCode: 2242,
+		})
+		return httpFilters
+	}
+	// The decompressor is placed right before the router, hence the responses are decompressed before any other
+	// filter reads those.
+	httpFilters = append(httpFilters[:len(httpFilters)-1], decompressionFilter, router)
 	return httpFilters
 }
 
@@ -243,6 +283,36 @@ func getAwsLambdaFilter() *hcmv3.HttpFilter {
 	return &awsLambdaFilter
 }
 
+// getRateLimitFilter returns the rate limit filter calling the rate limit service with the descriptors of the rate
+// limit actions of the routes.
+func getRateLimitFilter(conf *config.Config) *hcmv3.HttpFilter {
+	rateLimitConfig := &ratelimitv3.RateLimit{
+		Domain:          conf.Envoy.RateLimit.Domain,
+		Timeout:         durationpb.New(time.Duration(conf.Envoy.RateLimit.RequestTimeoutInMillis) * time.Millisecond),
+		FailureModeDeny: conf.Envoy.RateLimit.FailureModeDeny,
+		RateLimitService: &rls.RateLimitServiceConfig{
+			GrpcService: &corev3.GrpcService{
+				TargetSpecifier: &corev3.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &corev3.GrpcService_EnvoyGrpc{
+						ClusterName: rateLimitClusterName,
+					},
+				},
+			},
+			TransportApiVersion: corev3.ApiVersion_V3,
+		},
+	}
+	marshalledRateLimitConfig, err := anypb.New(rateLimitConfig)
+	if err != nil {
+		logger.LoggerOasparser.Error("Error while generating the rate limit filter.", err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: wellknown.HTTPRateLimit,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: marshalledRateLimitConfig,
+		},
+	}
+}
+
 // getHTTPLocalRateLimitFilter returns the local rate limit filter which is used for JWKS endpoint specifically.
 func getHTTPLocalRateLimitFilter() *hcmv3.HttpFilter {
 	localRateLimitConfig := &local_ratelimit_v3.LocalRateLimit{
@@ -261,6 +331,25 @@ func getHTTPLocalRateLimitFilter() *hcmv3.HttpFilter {
 	return localRateLimitFilter
 }
 
+// getBufferFilter gets the buffer http filter. The filter is disabled in the virtual hosts, and enabled only for the
+// routes of the APIs which buffer the requests.
+func getBufferFilter() *hcmv3.HttpFilter {
+	conf, _ := config.ReadConfigs()
+	bufferConfig := &bufferv3.Buffer{
+		MaxRequestBytes: &wrappers.UInt32Value{Value: conf.Envoy.Filters.Buffer.MaxRequestBytes},
+	}
+	marshalledBufferConfig, err := anypb.New(bufferConfig)
+	if err != nil {
+		logger.LoggerOasparser.Error("Error while generating the buffer filter.", err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: wellknown.Buffer,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: marshalledBufferConfig,
+		},
+	}
+}
+
 func getMgwWebSocketWASMFilter() *hcmv3.HttpFilter {
 	config := &wrappers.StringValue{
 		Value: `{
@@ -24,10 +24,13 @@ import (
 // routeCreateParams is the DTO used to provide information to the envoy route create function
 type routeCreateParams struct {
 	organizationID               string
+	apiUUID                      string
+	apiKey                       string
 	title                        string
 	version                      string
 	apiType                      string
 	xWSO2BasePath                string
+	contextAlias                 string // alias context of the routes, empty for the routes of the basepath
 	vHost                        string
 	endpointBasePath             string
 	resource                     *model.Resource
@@ -44,4 +47,20 @@ type routeCreateParams struct {
 	isSandbox                    bool
 	endpointType                 string
 	amznResourceName             string
+	visibleRoles                 []string
+	requestHeadersToStrip        []string
+	stripAuthHeader              *bool
+	rateLimitKey                 *model.RateLimitKey
+	maxRequestHeadersKb          uint32
+	jwksConfig                   *model.JwksConfig
+	sessionAffinity              *model.SessionAffinity
+	bufferRequest                bool
+	localRateLimit               *model.LocalRateLimit
+	responseHeaders              map[string]string
+	upstreamDecompression        bool
+	http3                        bool
+	// AWS Lambda clusters of the API by the region
+	awsLambdaClusterNames map[string]string
+	// query parameters of the endpoints appended to the forwarded requests, by the name of the cluster
+	endpointQueryParams map[string]string
 }
@@ -20,6 +20,7 @@ package envoyconf
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -28,13 +29,16 @@ import (
 	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_config_trace_v3 "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
+	rlv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	quicv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/quic/v3"
 	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/wso2/product-microgateway/adapter/config"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -66,18 +70,18 @@ func CreateRoutesConfigForRds(vHosts []*routev3.VirtualHost) *routev3.RouteConfi
 // Listener Address, ListenerPort Value, SecuredListener Address, and  SecuredListenerPort Values are
 // fetched from the configuration accordingly.
 //
-// The relevant private keys and certificates (for securedListener) are fetched from the filepath
-// mentioned in the adapter configuration. These certificate, key values are added
-// as inline records (base64 encoded).
-func CreateListenersWithRds() []*listenerv3.Listener {
+// The certificate of the securedListener is resolved via SDS, so that it can be replaced without
+// draining the connections. A filter chain is added to the securedListener for each of the provided
+// TLS vhosts, which serves the certificate of the vhost to the clients requesting it via SNI.
+func CreateListenersWithRds(tlsVhosts []string) []*listenerv3.Listener {
 	conf, errReadConfig := config.ReadConfigs()
 	if errReadConfig != nil {
 		logger.LoggerOasparser.Fatal("Error loading configuration. ", errReadConfig)
 	}
-	return createListeners(conf)
+	return createListeners(conf, tlsVhosts)
 }
 
-func createListeners(conf *config.Config) []*listenerv3.Listener {
+func createListeners(conf *config.Config, tlsVhosts []string) []*listenerv3.Listener {
 	httpFilters := getHTTPFilters()
 	upgradeFilters := getUpgradeFilters()
 	accessLogs := getAccessLogs()
@@ -121,6 +125,10 @@ func createListeners(conf *config.Config) []*listenerv3.Listener {
 		StripMatchingHostPort: true,
 	}
 
+	if conf.Envoy.MaxRequestHeadersKb > 0 {
+		manager.MaxRequestHeadersKb = wrapperspb.UInt32(conf.Envoy.MaxRequestHeadersKb)
+	}
+
 	if len(accessLogs) > 0 {
 		manager.AccessLog = accessLogs
 	}
@@ -177,69 +185,31 @@ func createListeners(conf *config.Config) []*listenerv3.Listener {
 			},
 		}
 
+		// The default filter chain serves the requests of the vhosts without a certificate of their own
 		securedListener := listenerv3.Listener{
 			Name: defaultHTTPSListenerName,
 			Address: &corev3.Address{
 				Address: securedListenerAddress,
 			},
 			FilterChains: []*listenerv3.FilterChain{{
-				Filters: filters,
+				Filters:         filters,
+				TransportSocket: createDownstreamTransportSocket(conf, defaultListenerSecretConfigName),
 			},
 			},
 			PerConnectionBufferLimitBytes: wrapperspb.UInt32(conf.Envoy.PerConnectionBufferLimitBytes),
 		}
-
-		tlsCert := generateTLSCert(conf.Envoy.KeyStore.KeyPath, conf.Envoy.KeyStore.CertPath)
-		// Convert the cipher string to a string array
-		ciphersArray := strings.Split(conf.Envoy.Downstream.TLS.Ciphers, ",")
-		for i := range ciphersArray {
-			ciphersArray[i] = strings.TrimSpace(ciphersArray[i])
-		}
-
-		tlsFilter := &tlsv3.DownstreamTlsContext{
-			CommonTlsContext: &tlsv3.CommonTlsContext{
-				//TlsCertificateSdsSecretConfigs
-				TlsCertificates: []*tlsv3.TlsCertificate{tlsCert},
-				TlsParams: &tlsv3.TlsParameters{
-					TlsMinimumProtocolVersion: createTLSProtocolVersion(conf.Envoy.Downstream.TLS.MinimumProtocolVersion),
-					TlsMaximumProtocolVersion: createTLSProtocolVersion(conf.Envoy.Downstream.TLS.MaximumProtocolVersion),
-					CipherSuites:              ciphersArray,
+		sortedTLSVhosts := append([]string{}, tlsVhosts...)
+		sort.Strings(sortedTLSVhosts)
+		for _, vhost := range sortedTLSVhosts {
+			securedListener.FilterChains = append(securedListener.FilterChains, &listenerv3.FilterChain{
+				Name: GetListenerSecretName(vhost),
+				FilterChainMatch: &listenerv3.FilterChainMatch{
+					ServerNames: []string{vhost},
 				},
-			},
+				Filters:         filters,
+				TransportSocket: createDownstreamTransportSocket(conf, GetListenerSecretName(vhost)),
+			})
 		}
-
-		//TODO: (VirajSalaka) Make it configurable via SDS
-		if conf.Envoy.Downstream.TLS.MTLSAPIsEnabled {
-			// This is false since the authentication will be done at the enforcer
-			tlsFilter.RequireClientCertificate = &wrappers.BoolValue{
-				Value: false,
-			}
-			//For the purpose of including peer certificate into the request context
-			tlsFilter.CommonTlsContext.ValidationContextType = &tlsv3.CommonTlsContext_ValidationContext{
-				ValidationContext: &tlsv3.CertificateValidationContext{
-					TrustedCa: &corev3.DataSource{
-						Specifier: &corev3.DataSource_Filename{
-							Filename: conf.Envoy.Downstream.TLS.TrustedCertPath,
-						},
-					},
-				},
-			}
-		}
-
-		marshalledTLSFilter, err := anypb.New(tlsFilter)
-		if err != nil {
-			logger.LoggerOasparser.Fatal("Error while Marshalling the downstream TLS Context for the configuration.")
-		}
-
-		transportSocket := &corev3.TransportSocket{
-			Name: transportSocketName,
-			ConfigType: &corev3.TransportSocket_TypedConfig{
-				TypedConfig: marshalledTLSFilter,
-			},
-		}
-
-		// At the moment, the listener as only one filter chain
-		securedListener.FilterChains[0].TransportSocket = transportSocket
 		listeners = append(listeners, &securedListener)
 		logger.LoggerOasparser.Infof("Secured Listener is added. %s : %d", listenerHostAddress, conf.Envoy.SecuredListenerPort)
 	} else {
@@ -278,6 +248,18 @@ func createListeners(conf *config.Config) []*listenerv3.Listener {
 		logger.LoggerOasparser.Info("No Non-securedListenerPort is included.")
 	}
 
+	if conf.Envoy.HTTP3.Enabled {
+		if conf.Envoy.SecuredListenerPort > 0 {
+			http3Listener := createHTTP3Listener(conf, manager, tlsVhosts)
+			listeners = append(listeners, http3Listener)
+			logger.LoggerOasparser.Infof("HTTP/3 Listener is added. %s : %d (UDP)",
+				http3Listener.GetAddress().GetSocketAddress().GetAddress(), conf.Envoy.SecuredListenerPort)
+		} else {
+			logger.LoggerOasparser.Error("HTTP/3 Listener is not added, as HTTP/3 requires TLS and no " +
+				"SecuredListenerPort is included.")
+		}
+	}
+
 	if len(listeners) == 0 {
 		err := errors.New("no Listeners are configured as no port value is mentioned under securedListenerPort or ListenerPort")
 		logger.LoggerOasparser.Fatal(err)
@@ -287,31 +269,222 @@ func createListeners(conf *config.Config) []*listenerv3.Listener {
 
 // CreateVirtualHosts creates VirtualHost configurations for envoy which serves
 // request from the vHost domain. The routes array will be included as the routes
-// for the created virtual host.
-func CreateVirtualHosts(vhostToRouteArrayMap map[string][]*routev3.Route) []*routev3.VirtualHost {
+// for the created virtual host. The local rate limit descriptors of the APIs of a vhost
+// are added to the local rate limit filter configuration of the virtual host.
+func CreateVirtualHosts(vhostToRouteArrayMap map[string][]*routev3.Route,
+	vhostToLocalRateLimitDescriptors map[string][]*rlv3.LocalRateLimitDescriptor) []*routev3.VirtualHost {
 	virtualHosts := make([]*routev3.VirtualHost, 0, len(vhostToRouteArrayMap))
 	for vhost, routes := range vhostToRouteArrayMap {
 		virtualHost := &routev3.VirtualHost{
 			Name:    vhost,
 			Domains: []string{vhost, fmt.Sprint(vhost, ":*")},
 			Routes:  routes,
+			// Requests are streamed unless the buffer filter is enabled for the routes of the API.
+			TypedPerFilterConfig: map[string]*anypb.Any{
+				wellknown.Buffer: generateBufferPerRouteConfig(true),
+			},
+		}
+		if descriptors := vhostToLocalRateLimitDescriptors[vhost]; len(descriptors) > 0 {
+			// The local rate limit filter has no token bucket in the listeners, hence enabled only for the
+			// virtual hosts having locally rate limited APIs.
+			sortedDescriptors := append([]*rlv3.LocalRateLimitDescriptor{}, descriptors...)
+			sort.SliceStable(sortedDescriptors, func(i, j int) bool {
+				return sortedDescriptors[i].GetEntries()[0].GetValue() < sortedDescriptors[j].GetEntries()[0].GetValue()
+			})
+			virtualHost.TypedPerFilterConfig[localRatelimitFilterName] =
+				generateLocalRateLimitVhostConfig(sortedDescriptors)
 		}
 		virtualHosts = append(virtualHosts, virtualHost)
 	}
 	return virtualHosts
 }
 
-// TODO: (VirajSalaka) Still the following method is not utilized as Sds is not implement. Keeping the Implementation for future reference
-func generateDefaultSdsSecretFromConfigfile(privateKeyPath string, pulicKeyPath string) (*tlsv3.Secret, error) {
-	var secret tlsv3.Secret
-	tlsCert := generateTLSCert(privateKeyPath, pulicKeyPath)
-	secret = tlsv3.Secret{
+// createHTTP3Listener creates the UDP listener serving HTTP/3 (QUIC) on the port of the secured listener. The listener
+// has the same filter chains as the secured listener, hence it serves the same certificates for the vhosts.
+func createHTTP3Listener(conf *config.Config, manager *hcmv3.HttpConnectionManager,
+	tlsVhosts []string) *listenerv3.Listener {
+	listenerHostAddress := defaultListenerHostAddress
+	if len(conf.Envoy.SecuredListenerHost) > 0 {
+		listenerHostAddress = conf.Envoy.SecuredListenerHost
+	}
+	http3Manager := proto.Clone(manager).(*hcmv3.HttpConnectionManager)
+	http3Manager.CodecType = hcmv3.HttpConnectionManager_HTTP3
+	http3Manager.Http3ProtocolOptions = &corev3.Http3ProtocolOptions{}
+	http3Manager.HttpProtocolOptions = nil
+	// WebSocket upgrades are only supported over HTTP/1.1
+	http3Manager.UpgradeConfigs = nil
+	pbst, err := anypb.New(http3Manager)
+	if err != nil {
+		logger.LoggerOasparser.Fatal(err)
+	}
+	filters := []*listenerv3.Filter{{
+		Name: wellknown.HTTPConnectionManager,
+		ConfigType: &listenerv3.Filter_TypedConfig{
+			TypedConfig: pbst,
+		},
+	}}
+
+	http3Listener := listenerv3.Listener{
+		Name: defaultHTTP3ListenerName,
+		Address: &corev3.Address{
+			Address: &corev3.Address_SocketAddress{
+				SocketAddress: &corev3.SocketAddress{
+					Protocol: corev3.SocketAddress_UDP,
+					Address:  listenerHostAddress,
+					PortSpecifier: &corev3.SocketAddress_PortValue{
+						PortValue: conf.Envoy.SecuredListenerPort,
+					},
+				},
+			},
+		},
+		UdpListenerConfig: &listenerv3.UdpListenerConfig{
+			QuicOptions: &listenerv3.QuicProtocolOptions{},
+		},
+		FilterChains: []*listenerv3.FilterChain{{
+			Filters:         filters,
+			TransportSocket: createQuicDownstreamTransportSocket(conf, defaultListenerSecretConfigName),
+		},
+		},
+		PerConnectionBufferLimitBytes: wrapperspb.UInt32(conf.Envoy.PerConnectionBufferLimitBytes),
+	}
+	sortedTLSVhosts := append([]string{}, tlsVhosts...)
+	sort.Strings(sortedTLSVhosts)
+	for _, vhost := range sortedTLSVhosts {
+		http3Listener.FilterChains = append(http3Listener.FilterChains, &listenerv3.FilterChain{
+			Name: GetListenerSecretName(vhost),
+			FilterChainMatch: &listenerv3.FilterChainMatch{
+				ServerNames: []string{vhost},
+			},
+			Filters:         filters,
+			TransportSocket: createQuicDownstreamTransportSocket(conf, GetListenerSecretName(vhost)),
+		})
+	}
+	return &http3Listener
+}
+
+// createQuicDownstreamTransportSocket creates the QUIC transport socket of a filter chain of the HTTP/3 listener,
+// which serves the certificate of the given SDS secret.
+func createQuicDownstreamTransportSocket(conf *config.Config, secretName string) *corev3.TransportSocket {
+	quicTransport := &quicv3.QuicDownstreamTransport{
+		DownstreamTlsContext: createDownstreamTLSContext(conf, secretName),
+	}
+	marshalledQuicTransport, err := anypb.New(quicTransport)
+	if err != nil {
+		logger.LoggerOasparser.Fatal("Error while Marshalling the downstream QUIC transport for the configuration.")
+	}
+	return &corev3.TransportSocket{
+		Name: quicTransportSocketName,
+		ConfigType: &corev3.TransportSocket_TypedConfig{
+			TypedConfig: marshalledQuicTransport,
+		},
+	}
+}
+
+// createDownstreamTransportSocket creates the TLS transport socket of a filter chain of the secured listener, which
+// serves the certificate of the given SDS secret.
+func createDownstreamTransportSocket(conf *config.Config, secretName string) *corev3.TransportSocket {
+	marshalledTLSFilter, err := anypb.New(createDownstreamTLSContext(conf, secretName))
+	if err != nil {
+		logger.LoggerOasparser.Fatal("Error while Marshalling the downstream TLS Context for the configuration.")
+	}
+
+	return &corev3.TransportSocket{
+		Name: transportSocketName,
+		ConfigType: &corev3.TransportSocket_TypedConfig{
+			TypedConfig: marshalledTLSFilter,
+		},
+	}
+}
+
+// createDownstreamTLSContext creates the downstream TLS context serving the certificate of the given SDS secret.
+func createDownstreamTLSContext(conf *config.Config, secretName string) *tlsv3.DownstreamTlsContext {
+	// Convert the cipher string to a string array
+	ciphersArray := strings.Split(conf.Envoy.Downstream.TLS.Ciphers, ",")
+	for i := range ciphersArray {
+		ciphersArray[i] = strings.TrimSpace(ciphersArray[i])
+	}
+
+	tlsFilter := &tlsv3.DownstreamTlsContext{
+		CommonTlsContext: &tlsv3.CommonTlsContext{
+			TlsCertificateSdsSecretConfigs: []*tlsv3.SdsSecretConfig{{
+				Name: secretName,
+				SdsConfig: &corev3.ConfigSource{
+					ConfigSourceSpecifier: &corev3.ConfigSource_Ads{
+						Ads: &corev3.AggregatedConfigSource{},
+					},
+					ResourceApiVersion: corev3.ApiVersion_V3,
+				},
+			}},
+			TlsParams: &tlsv3.TlsParameters{
+				TlsMinimumProtocolVersion: createTLSProtocolVersion(conf.Envoy.Downstream.TLS.MinimumProtocolVersion),
+				TlsMaximumProtocolVersion: createTLSProtocolVersion(conf.Envoy.Downstream.TLS.MaximumProtocolVersion),
+				CipherSuites:              ciphersArray,
+			},
+		},
+	}
+
+	//TODO: (VirajSalaka) Make it configurable via SDS
+	if conf.Envoy.Downstream.TLS.MTLSAPIsEnabled {
+		// This is false since the authentication will be done at the enforcer
+		tlsFilter.RequireClientCertificate = &wrappers.BoolValue{
+			Value: false,
+		}
+		//For the purpose of including peer certificate into the request context
+		tlsFilter.CommonTlsContext.ValidationContextType = &tlsv3.CommonTlsContext_ValidationContext{
+			ValidationContext: &tlsv3.CertificateValidationContext{
+				TrustedCa: &corev3.DataSource{
+					Specifier: &corev3.DataSource_Filename{
+						Filename: conf.Envoy.Downstream.TLS.TrustedCertPath,
+					},
+				},
+			},
+		}
+	}
+
+	return tlsFilter
+}
+
+// GetListenerSecretName returns the name of the SDS secret holding the certificate served by the secured listener
+// for the vhost. The empty vhost refers to the certificate served for the vhosts without a certificate of their own.
+func GetListenerSecretName(vhost string) string {
+	if vhost == "" {
+		return defaultListenerSecretConfigName
+	}
+	return vhostListenerSecretConfigPrefix + vhost
+}
+
+// CreateDefaultListenerSecret creates the SDS secret of the certificate configured for the secured listener.
+// The certificate and the private key are read by the router from the files mentioned in the configuration.
+func CreateDefaultListenerSecret() *tlsv3.Secret {
+	conf, _ := config.ReadConfigs()
+	return &tlsv3.Secret{
 		Name: defaultListenerSecretConfigName,
 		Type: &tlsv3.Secret_TlsCertificate{
-			TlsCertificate: tlsCert,
+			TlsCertificate: generateTLSCert(conf.Envoy.KeyStore.KeyPath, conf.Envoy.KeyStore.CertPath),
+		},
+	}
+}
+
+// CreateListenerSecret creates the SDS secret of a certificate served by the secured listener for the vhost, with
+// the PEM encoded certificate chain and private key added as inline records.
+func CreateListenerSecret(vhost string, certificateChain, privateKey []byte) *tlsv3.Secret {
+	return &tlsv3.Secret{
+		Name: GetListenerSecretName(vhost),
+		Type: &tlsv3.Secret_TlsCertificate{
+			TlsCertificate: &tlsv3.TlsCertificate{
+				CertificateChain: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineBytes{
+						InlineBytes: certificateChain,
+					},
+				},
+				PrivateKey: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineBytes{
+						InlineBytes: privateKey,
+					},
+				},
+			},
 		},
 	}
-	return &secret, nil
 }
 
 // generateTLSCert generates the TLS Certiificate with given private key filepath and the corresponding public Key filepath.
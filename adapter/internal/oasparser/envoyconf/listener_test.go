@@ -20,9 +20,13 @@ package envoyconf
 import (
 	"testing"
 
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_config_trace_v3 "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
 	cors_filter_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	quicv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/quic/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/stretchr/testify/assert"
 	"github.com/wso2/product-microgateway/adapter/config"
@@ -31,7 +35,7 @@ import (
 
 func TestCreateListenerWithRds(t *testing.T) {
 	// TODO: (Vajira) Add more test scenarios
-	listeners := CreateListenersWithRds()
+	listeners := CreateListenersWithRds(nil)
 	assert.NotEmpty(t, listeners, "Listeners creation has been failed")
 	assert.Equal(t, 2, len(listeners), "Two listeners are not created.")
 
@@ -49,6 +53,12 @@ func TestCreateListenerWithRds(t *testing.T) {
 	assert.Equal(t, uint32(1048576), securedListener.PerConnectionBufferLimitBytes.GetValue(),
 		"Buffered payload limit mismatch for secured Listener.")
 
+	manager := &hcmv3.HttpConnectionManager{}
+	err := securedListener.FilterChains[0].Filters[0].GetTypedConfig().UnmarshalTo(manager)
+	assert.Nil(t, err, "Error while parsing the HTTP connection manager of secured Listener.")
+	assert.Equal(t, uint32(60), manager.GetMaxRequestHeadersKb().GetValue(),
+		"Request headers size limit mismatch for secured Listener.")
+
 	nonSecuredListener := listeners[1]
 	if nonSecuredListener.Validate() != nil {
 		t.Error("Listener validation failed")
@@ -64,6 +74,86 @@ func TestCreateListenerWithRds(t *testing.T) {
 		"Buffered payload limit mismatch for non-secured Listener.")
 }
 
+func TestCreateListenerWithTLSVhosts(t *testing.T) {
+	listeners := CreateListenersWithRds([]string{"pets.wso2.com"})
+	assert.Equal(t, 2, len(listeners), "Two listeners are not created.")
+
+	securedListener := listeners[0]
+	if securedListener.Validate() != nil {
+		t.Error("Listener validation failed")
+	}
+	assert.Equal(t, 2, len(securedListener.FilterChains),
+		"A filter chain should be added for the vhost with a certificate of its own.")
+	expectedSecretNames := []string{"DefaultListenerSecret", "ListenerSecret_pets.wso2.com"}
+	for i, filterChain := range securedListener.FilterChains {
+		tlsContext := &tlsv3.DownstreamTlsContext{}
+		err := filterChain.GetTransportSocket().GetTypedConfig().UnmarshalTo(tlsContext)
+		assert.Nil(t, err, "Error while parsing the TLS context of secured Listener.")
+		if assert.Equal(t, 1, len(tlsContext.GetCommonTlsContext().GetTlsCertificateSdsSecretConfigs())) {
+			assert.Equal(t, expectedSecretNames[i],
+				tlsContext.GetCommonTlsContext().GetTlsCertificateSdsSecretConfigs()[0].GetName(),
+				"SDS secret mismatch for the filter chain of secured Listener.")
+		}
+	}
+	assert.Nil(t, securedListener.FilterChains[0].GetFilterChainMatch(),
+		"Default filter chain should match all the server names.")
+	assert.Equal(t, []string{"pets.wso2.com"}, securedListener.FilterChains[1].GetFilterChainMatch().GetServerNames(),
+		"Filter chain of the vhost should match the server name of the vhost.")
+	assert.Equal(t, 1, len(listeners[1].FilterChains), "Non-secured listener should have a single filter chain.")
+}
+
+func TestCreateListenerWithHTTP3(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	http3Conf := *conf
+	http3Conf.Envoy.HTTP3.Enabled = true
+	listeners := createListeners(&http3Conf, []string{"pets.wso2.com"})
+	if !assert.Equal(t, 3, len(listeners), "HTTP/3 listener is not created.") {
+		return
+	}
+
+	http3Listener := listeners[2]
+	if http3Listener.Validate() != nil {
+		t.Error("Listener validation failed")
+	}
+	assert.Equal(t, defaultHTTP3ListenerName, http3Listener.GetName())
+	assert.Equal(t, corev3.SocketAddress_UDP, http3Listener.GetAddress().GetSocketAddress().GetProtocol(),
+		"HTTP/3 listener should listen on UDP.")
+	assert.Equal(t, uint32(9095), http3Listener.GetAddress().GetSocketAddress().GetPortValue(),
+		"HTTP/3 listener should listen on the port of the secured listener.")
+	assert.NotNil(t, http3Listener.GetUdpListenerConfig().GetQuicOptions(), "QUIC options are not set.")
+
+	expectedSecretNames := []string{"DefaultListenerSecret", "ListenerSecret_pets.wso2.com"}
+	if !assert.Equal(t, len(expectedSecretNames), len(http3Listener.FilterChains)) {
+		return
+	}
+	for i, filterChain := range http3Listener.FilterChains {
+		assert.Equal(t, quicTransportSocketName, filterChain.GetTransportSocket().GetName())
+		quicTransport := &quicv3.QuicDownstreamTransport{}
+		err := filterChain.GetTransportSocket().GetTypedConfig().UnmarshalTo(quicTransport)
+		assert.Nil(t, err, "Error while parsing the QUIC transport of HTTP/3 Listener.")
+		sdsSecretConfigs := quicTransport.GetDownstreamTlsContext().GetCommonTlsContext().GetTlsCertificateSdsSecretConfigs()
+		if assert.Equal(t, 1, len(sdsSecretConfigs)) {
+			assert.Equal(t, expectedSecretNames[i], sdsSecretConfigs[0].GetName(),
+				"SDS secret mismatch for the filter chain of HTTP/3 Listener.")
+		}
+
+		manager := &hcmv3.HttpConnectionManager{}
+		err = filterChain.Filters[0].GetTypedConfig().UnmarshalTo(manager)
+		assert.Nil(t, err, "Error while parsing the HTTP connection manager of HTTP/3 Listener.")
+		assert.Equal(t, hcmv3.HttpConnectionManager_HTTP3, manager.GetCodecType())
+		assert.NotNil(t, manager.GetHttp3ProtocolOptions(), "HTTP/3 protocol options are not set.")
+		assert.Empty(t, manager.GetUpgradeConfigs(), "WebSocket upgrades should not be configured for HTTP/3.")
+	}
+
+	// HTTP/3 requires TLS
+	http3Conf.Envoy.SecuredListenerPort = 0
+	listeners = createListeners(&http3Conf, nil)
+	assert.Equal(t, 1, len(listeners), "HTTP/3 listener should not be created without the secured listener.")
+	for _, listener := range listeners {
+		assert.NotEqual(t, defaultHTTP3ListenerName, listener.GetName())
+	}
+}
+
 func TestCreateVirtualHost(t *testing.T) {
 	// TODO: (Vajira) Add more test scenarios
 
@@ -71,7 +161,7 @@ func TestCreateVirtualHost(t *testing.T) {
 		"*":           testCreateRoutesForUnitTests(t),
 		"mg.wso2.com": testCreateRoutesForUnitTests(t),
 	}
-	vHosts := CreateVirtualHosts(vhostToRouteArrayMap)
+	vHosts := CreateVirtualHosts(vhostToRouteArrayMap, nil)
 
 	if len(vHosts) != 2 {
 		t.Error("Virtual Host creation failed")
@@ -96,7 +186,7 @@ func TestCreateRoutesConfigForRds(t *testing.T) {
 		"*":           testCreateRoutesForUnitTests(t),
 		"mg.wso2.com": testCreateRoutesForUnitTests(t),
 	}
-	vHosts := CreateVirtualHosts(vhostToRouteArrayMap)
+	vHosts := CreateVirtualHosts(vhostToRouteArrayMap, nil)
 	rConfig := CreateRoutesConfigForRds(vHosts)
 
 	assert.NotNil(t, rConfig, "CreateRoutesConfigForRds is failed")
@@ -0,0 +1,337 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// Placeholders supported in the router resource naming template.
+const (
+	namingPlaceholderOrgID        string = "{orgId}"
+	namingPlaceholderVHost        string = "{vhost}"
+	namingPlaceholderAPIName      string = "{apiName}"
+	namingPlaceholderVersion      string = "{version}"
+	namingPlaceholderEndpointType string = "{endpointType}"
+	namingPlaceholderResourceID   string = "{resourceId}"
+
+	routeNameEndpointType        string = "route"
+	sandboxRouteNameEndpointType string = "sandRoute"
+)
+
+// disallowedNameCharsRegex matches the characters which are not allowed in generated
+// cluster and route names. Those are replaced with an underscore.
+var disallowedNameCharsRegex = regexp.MustCompile(`[^a-zA-Z0-9_.\-]`)
+
+// generatedName holds the owner details of a cluster or route name generated for an API.
+type generatedName struct {
+	apiUUID string
+	apiKey  string
+	owner   string
+}
+
+// generatedNameReservations holds the names reserved while the resources of an API are planned.
+type generatedNameReservations struct {
+	// generated name -> owner details
+	names map[string]generatedName
+	// owner (apiKey + name parameters) -> generated name
+	owners map[string]string
+}
+
+var (
+	// generated name -> owner details
+	generatedNames map[string]generatedName
+	// owner (apiKey + name parameters) -> generated name
+	generatedNameOwners map[string]string
+	// apiKey -> names reserved while the resources of the API are planned
+	plannedNames             map[string]*generatedNameReservations
+	mutexForGeneratedNameMap sync.RWMutex
+)
+
+func init() {
+	generatedNames = make(map[string]generatedName)
+	generatedNameOwners = make(map[string]string)
+	plannedNames = make(map[string]*generatedNameReservations)
+}
+
+// nameTemplateParams holds the values which are substituted into the naming template.
+type nameTemplateParams struct {
+	organizationID string
+	vHost          string
+	apiName        string
+	version        string
+	endpointType   string
+	resourceID     string
+}
+
+// renderNameTemplate substitutes the placeholders of the template with the provided values and
+// replaces the characters which are not allowed in a router resource name.
+func renderNameTemplate(template string, params nameTemplateParams) string {
+	replacer := strings.NewReplacer(
+		namingPlaceholderOrgID, params.organizationID,
+		namingPlaceholderVHost, params.vHost,
+		namingPlaceholderAPIName, params.apiName,
+		namingPlaceholderVersion, params.version,
+		namingPlaceholderEndpointType, params.endpointType,
+		namingPlaceholderResourceID, params.resourceID,
+	)
+	return sanitizeGeneratedName(replacer.Replace(template))
+}
+
+// sanitizeGeneratedName replaces the disallowed characters of a generated name with underscores.
+func sanitizeGeneratedName(name string) string {
+	return disallowedNameCharsRegex.ReplaceAllString(strings.TrimSpace(name), "_")
+}
+
+// sanitizeLegacyName sanitizes a name of the legacy naming scheme. The legacy names are not made unique when
+// reserved, hence a short hash of the name is appended if it was changed by the sanitization. Otherwise the names
+// differing only by the replaced characters (e.g. "Pagos México" and "Pagos Mèxico", or "a/b" and "a_b") would get
+// the same name.
+func sanitizeLegacyName(name string) string {
+	sanitizedName := sanitizeGeneratedName(name)
+	if sanitizedName == strings.TrimSpace(name) {
+		return sanitizedName
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(name))
+	return sanitizedName + "_" + fmt.Sprintf("%08x", hash.Sum32())
+}
+
+// getAPIKeyForGeneratedNames returns the key used to track the names generated for an API
+// deployed in the given vhost. APIs deployed via apictl without an UUID are tracked by name and version.
+func getAPIKeyForGeneratedNames(mgwSwagger *model.MgwSwagger, vHost string) string {
+	if mgwSwagger.GetID() != "" {
+		return vHost + ":" + mgwSwagger.GetID()
+	}
+	return vHost + ":" + mgwSwagger.GetTitle() + ":" + mgwSwagger.GetVersion()
+}
+
+// getAPIUUIDForGeneratedNames returns the value which is exposed against a generated name.
+func getAPIUUIDForGeneratedNames(mgwSwagger *model.MgwSwagger) string {
+	if mgwSwagger.GetID() != "" {
+		return mgwSwagger.GetID()
+	}
+	return mgwSwagger.GetTitle() + ":" + mgwSwagger.GetVersion()
+}
+
+// reserveGeneratedName records the provided name against the API. If the name is already reserved by
+// another API (or by a different resource of the same API), a numeric suffix is appended to make it unique.
+// The same owner always receives the same name until the names of the API are released. While the resources of the
+// API are planned, the name is recorded in the planned names of the API instead.
+func reserveGeneratedName(name, apiUUID, apiKey, owner string) string {
+	mutexForGeneratedNameMap.Lock()
+	defer mutexForGeneratedNameMap.Unlock()
+	ownerKey := apiKey + "|" + owner
+	planned := plannedNames[apiKey]
+	if planned != nil {
+		if existingName, found := planned.owners[ownerKey]; found {
+			return existingName
+		}
+	}
+	if existingName, found := generatedNameOwners[ownerKey]; found {
+		return existingName
+	}
+	uniqueName := name
+	for i := 1; ; i++ {
+		_, found := generatedNames[uniqueName]
+		if !found && planned != nil {
+			_, found = planned.names[uniqueName]
+		}
+		if !found {
+			break
+		}
+		uniqueName = name + "_" + strconv.Itoa(i)
+	}
+	details := generatedName{apiUUID: apiUUID, apiKey: apiKey, owner: ownerKey}
+	if planned != nil {
+		planned.names[uniqueName] = details
+		planned.owners[ownerKey] = uniqueName
+		return uniqueName
+	}
+	generatedNames[uniqueName] = details
+	generatedNameOwners[ownerKey] = uniqueName
+	return uniqueName
+}
+
+// recordGeneratedName records a name generated with the legacy naming scheme against the API,
+// without altering it. While the resources of the API are planned, the name is not recorded.
+func recordGeneratedName(name, apiUUID, apiKey, owner string) {
+	mutexForGeneratedNameMap.Lock()
+	defer mutexForGeneratedNameMap.Unlock()
+	if _, found := plannedNames[apiKey]; found {
+		return
+	}
+	ownerKey := apiKey + "|" + owner
+	generatedNames[name] = generatedName{apiUUID: apiUUID, apiKey: apiKey, owner: ownerKey}
+	generatedNameOwners[ownerKey] = name
+}
+
+// ReleaseGeneratedNames removes the cluster and route names generated for the API deployed in the given vhost,
+// so that those can be reused. This should be called when the API is removed or before its resources are
+// regenerated.
+func ReleaseGeneratedNames(mgwSwagger model.MgwSwagger, vHost string) {
+	apiKey := getAPIKeyForGeneratedNames(&mgwSwagger, vHost)
+	mutexForGeneratedNameMap.Lock()
+	defer mutexForGeneratedNameMap.Unlock()
+	for name, details := range generatedNames {
+		if details.apiKey == apiKey {
+			delete(generatedNames, name)
+			delete(generatedNameOwners, details.owner)
+		}
+	}
+}
+
+// PlanGeneratedNames calls generate, which generates the resources of the API deployed in the given vhost without
+// applying those (i.e. in a dry run). The names of the deployed resources of the API are used during the generation,
+// while the new names are reserved only until generate returns, without being recorded against the API. Hence the
+// names of the deployed APIs, including the API itself, are not changed. The resources of the API must not be
+// generated concurrently for a deployment (i.e. generate should be called in the deployment queue of the API).
+func PlanGeneratedNames(mgwSwagger model.MgwSwagger, vHost string, generate func()) {
+	apiKey := getAPIKeyForGeneratedNames(&mgwSwagger, vHost)
+	mutexForGeneratedNameMap.Lock()
+	plannedNames[apiKey] = &generatedNameReservations{
+		names:  make(map[string]generatedName),
+		owners: make(map[string]string),
+	}
+	mutexForGeneratedNameMap.Unlock()
+	defer func() {
+		mutexForGeneratedNameMap.Lock()
+		delete(plannedNames, apiKey)
+		mutexForGeneratedNameMap.Unlock()
+	}()
+	generate()
+}
+
+// GetGeneratedNameToAPIUUIDMap returns a copy of the mapping from the generated cluster and route names
+// to the UUID of the API those belong to.
+func GetGeneratedNameToAPIUUIDMap() map[string]string {
+	mutexForGeneratedNameMap.RLock()
+	defer mutexForGeneratedNameMap.RUnlock()
+	nameMap := make(map[string]string, len(generatedNames))
+	for name, details := range generatedNames {
+		nameMap[name] = details.apiUUID
+	}
+	return nameMap
+}
+
+// getClusterName returns the name of the cluster. If a naming template is configured, the name is generated
+// from the template. Otherwise the legacy naming scheme is used.
+func getClusterName(mgwSwagger *model.MgwSwagger, epPrefix string, organizationID string, vHost string,
+	resourceID string) string {
+	apiKey := getAPIKeyForGeneratedNames(mgwSwagger, vHost)
+	apiUUID := getAPIUUIDForGeneratedNames(mgwSwagger)
+	resourceNameID := getResourceNameID(mgwSwagger, resourceID)
+	owner := "cluster|" + epPrefix + "|" + resourceNameID
+	conf, _ := config.ReadConfigs()
+	template := conf.Envoy.ResourceNamingTemplate
+	if template == "" {
+		name := getLegacyClusterName(epPrefix, organizationID, vHost, mgwSwagger.GetTitle(), mgwSwagger.GetVersion(),
+			resourceID)
+		recordGeneratedName(name, apiUUID, apiKey, owner)
+		return name
+	}
+	name := renderNameTemplate(template, nameTemplateParams{
+		organizationID: organizationID,
+		vHost:          vHost,
+		apiName:        mgwSwagger.GetTitle(),
+		version:        mgwSwagger.GetVersion(),
+		endpointType:   epPrefix,
+		resourceID:     resourceNameID,
+	})
+	return reserveGeneratedName(name, apiUUID, apiKey, owner)
+}
+
+// getResourceNameID returns the value substituted for the {resourceId} placeholder for the resource, or the
+// operation of a resource, with the given ID. The IDs are generated whenever the API definition is parsed, hence
+// the methods and the path of the resource are used instead, so that a resource gets the same name whenever the
+// API is deployed.
+func getResourceNameID(mgwSwagger *model.MgwSwagger, resourceID string) string {
+	if resourceID == "" {
+		return ""
+	}
+	for _, resource := range mgwSwagger.GetResources() {
+		if resource.GetID() == resourceID {
+			return getResourceNameIDOfResource(resource)
+		}
+		for _, operation := range resource.GetMethod() {
+			if operation.GetID() == resourceID {
+				return operation.GetMethod() + resource.GetPath()
+			}
+		}
+	}
+	return resourceID
+}
+
+// getResourceNameIDOfResource returns the value substituted for the {resourceId} placeholder for the resource.
+func getResourceNameIDOfResource(resource *model.Resource) string {
+	return strings.Join(resource.GetMethodList(), "_") + resource.GetPath()
+}
+
+func getLegacyClusterName(epPrefix string, organizationID string, vHost string, swaggerTitle string, swaggerVersion string,
+	resourceID string) string {
+	// API names and versions may contain characters which are not allowed in cluster names (e.g. slashes and
+	// non-ASCII characters). Names containing only the allowed characters are not changed.
+	apiNameVersion := sanitizeLegacyName(strings.Replace(swaggerTitle, " ", "", -1) + swaggerVersion)
+	if resourceID != "" {
+		return strings.TrimSpace(organizationID+"_"+epPrefix+"_"+vHost+"_"+apiNameVersion) +
+			"_" + strings.Replace(resourceID, " ", "", -1) + "0"
+	}
+	return strings.TrimSpace(organizationID + "_" + epPrefix + "_" + vHost + "_" + apiNameVersion)
+}
+
+// getRouteName returns the name of the routes created for a resource. If a naming template is not configured,
+// the xWso2Basepath, or the context alias of the routes of an alias, is used as the route name.
+func getRouteName(params *routeCreateParams) string {
+	conf, _ := config.ReadConfigs()
+	template := conf.Envoy.ResourceNamingTemplate
+	if template == "" {
+		if params.contextAlias != "" {
+			return params.contextAlias
+		}
+		return params.xWSO2BasePath
+	}
+	resourceID := ""
+	if params.resource != nil {
+		resourceID = getResourceNameIDOfResource(params.resource)
+	}
+	endpointType := routeNameEndpointType
+	if params.isSandbox {
+		endpointType = sandboxRouteNameEndpointType
+	}
+	name := renderNameTemplate(template, nameTemplateParams{
+		organizationID: params.organizationID,
+		vHost:          params.vHost,
+		apiName:        params.title,
+		version:        params.version,
+		endpointType:   endpointType,
+		resourceID:     resourceID,
+	})
+	owner := "route|" + endpointType + "|" + resourceID
+	if params.contextAlias != "" {
+		owner += "|" + params.contextAlias
+	}
+	return reserveGeneratedName(name, params.apiUUID, params.apiKey, owner)
+}
@@ -0,0 +1,205 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func TestRenderNameTemplate(t *testing.T) {
+	name := renderNameTemplate("{orgId}_{apiName}_{version}_{endpointType}", nameTemplateParams{
+		organizationID: "carbon.super",
+		apiName:        "Pet Store:API",
+		version:        "1.0.0",
+		endpointType:   "clusterProd",
+	})
+	assert.Equal(t, "carbon.super_Pet_Store_API_1.0.0_clusterProd", name)
+}
+
+func TestGetClusterNameWithTemplate(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousTemplate := conf.Envoy.ResourceNamingTemplate
+	conf.Envoy.ResourceNamingTemplate = "{orgId}_{apiName}_{version}_{endpointType}"
+	defer func() {
+		conf.Envoy.ResourceNamingTemplate = previousTemplate
+	}()
+
+	var petstore, petstoreCopy model.MgwSwagger
+	petstore.SetID("petstore-uuid")
+	petstore.SetName("PetStore")
+	petstore.SetVersion("1.0.0")
+	petstoreCopy.SetID("petstore-copy-uuid")
+	petstoreCopy.SetName("PetStore")
+	petstoreCopy.SetVersion("1.0.0")
+
+	name := getClusterName(&petstore, "clusterProd", "org1", "localhost", "")
+	assert.Equal(t, "org1_PetStore_1.0.0_clusterProd", name)
+	assert.Equal(t, name, getClusterName(&petstore, "clusterProd", "org1", "localhost", ""),
+		"The same cluster of the same API should get the same name.")
+
+	collidingName := getClusterName(&petstoreCopy, "clusterProd", "org1", "localhost", "")
+	assert.Equal(t, "org1_PetStore_1.0.0_clusterProd_1", collidingName)
+
+	nameMap := GetGeneratedNameToAPIUUIDMap()
+	assert.Equal(t, "petstore-uuid", nameMap[name])
+	assert.Equal(t, "petstore-copy-uuid", nameMap[collidingName])
+
+	ReleaseGeneratedNames(petstore, "localhost")
+	ReleaseGeneratedNames(petstoreCopy, "localhost")
+	nameMap = GetGeneratedNameToAPIUUIDMap()
+	assert.NotContains(t, nameMap, name)
+	assert.NotContains(t, nameMap, collidingName)
+}
+
+func TestGetClusterNameWithoutTemplate(t *testing.T) {
+	var petstore model.MgwSwagger
+	petstore.SetID("petstore-uuid")
+	petstore.SetName("Pet Store")
+	petstore.SetVersion("1.0.0")
+
+	name := getClusterName(&petstore, "clusterProd", "org1", "localhost", "")
+	assert.Equal(t, "org1_clusterProd_localhost_PetStore1.0.0", name)
+	assert.Equal(t, "petstore-uuid", GetGeneratedNameToAPIUUIDMap()[name])
+	ReleaseGeneratedNames(petstore, "localhost")
+}
+
+func TestGetClusterNameWithUnicodeAPIName(t *testing.T) {
+	var pagos model.MgwSwagger
+	pagos.SetID("pagos-uuid")
+	pagos.SetName("Pagos México/Transfers")
+	pagos.SetVersion("v2")
+
+	name := getClusterName(&pagos, "clusterProd", "org1", "localhost", "")
+	assert.Regexp(t, "^org1_clusterProd_localhost_PagosM_xico_Transfersv2_[0-9a-f]{8}$", name)
+	assert.Equal(t, name, getClusterName(&pagos, "clusterProd", "org1", "localhost", ""),
+		"Cluster names should be sanitized deterministically.")
+	ReleaseGeneratedNames(pagos, "localhost")
+}
+
+func TestGetClusterNameWithSanitizedNameCollision(t *testing.T) {
+	tests := []struct {
+		name         string
+		apiName      string
+		otherAPIName string
+	}{
+		{
+			name:         "Names differing by non-ASCII characters",
+			apiName:      "Pagos México",
+			otherAPIName: "Pagos Mèxico",
+		},
+		{
+			name:         "Names differing by a replaced character",
+			apiName:      "a/b",
+			otherAPIName: "a_b",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var api, otherAPI model.MgwSwagger
+			api.SetID("api-uuid")
+			api.SetName(test.apiName)
+			api.SetVersion("v2")
+			otherAPI.SetID("other-api-uuid")
+			otherAPI.SetName(test.otherAPIName)
+			otherAPI.SetVersion("v2")
+			defer ReleaseGeneratedNames(api, "localhost")
+			defer ReleaseGeneratedNames(otherAPI, "localhost")
+
+			name := getClusterName(&api, "clusterProd", "org1", "localhost", "")
+			otherName := getClusterName(&otherAPI, "clusterProd", "org1", "localhost", "")
+			assert.NotEqual(t, name, otherName, "Cluster names of different APIs should not collide.")
+			nameMap := GetGeneratedNameToAPIUUIDMap()
+			assert.Equal(t, "api-uuid", nameMap[name])
+			assert.Equal(t, "other-api-uuid", nameMap[otherName])
+		})
+	}
+}
+
+func TestPlanGeneratedNames(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousTemplate := conf.Envoy.ResourceNamingTemplate
+	conf.Envoy.ResourceNamingTemplate = "{orgId}_{apiName}_{version}_{endpointType}"
+	defer func() {
+		conf.Envoy.ResourceNamingTemplate = previousTemplate
+	}()
+
+	var petstore, petstoreCopy model.MgwSwagger
+	petstore.SetID("petstore-uuid")
+	petstore.SetName("PetStore")
+	petstore.SetVersion("1.0.0")
+	petstoreCopy.SetID("petstore-copy-uuid")
+	petstoreCopy.SetName("PetStore")
+	petstoreCopy.SetVersion("1.0.0")
+	defer ReleaseGeneratedNames(petstore, "localhost")
+	defer ReleaseGeneratedNames(petstoreCopy, "localhost")
+
+	prodName := getClusterName(&petstore, "clusterProd", "org1", "localhost", "")
+	var sandName, copySandName string
+	PlanGeneratedNames(petstore, "localhost", func() {
+		assert.Equal(t, prodName, getClusterName(&petstore, "clusterProd", "org1", "localhost", ""),
+			"Names of the deployed API should be used while generating the resources.")
+		sandName = getClusterName(&petstore, "clusterSand", "org1", "localhost", "")
+		assert.Equal(t, sandName, getClusterName(&petstore, "clusterSand", "org1", "localhost", ""),
+			"The same cluster should get the same name during the generation.")
+		assert.NotContains(t, GetGeneratedNameToAPIUUIDMap(), sandName,
+			"Names reserved during the generation should not be recorded.")
+
+		// The names reserved during the generation are not reserved for the other APIs deployed meanwhile.
+		copySandName = getClusterName(&petstoreCopy, "clusterSand", "org1", "localhost", "")
+		assert.Equal(t, sandName, copySandName)
+	})
+	nameMap := GetGeneratedNameToAPIUUIDMap()
+	assert.Equal(t, "petstore-uuid", nameMap[prodName], "Names of the deployed API should be kept.")
+	assert.Equal(t, "petstore-copy-uuid", nameMap[copySandName],
+		"Names of the other APIs should not be discarded.")
+	for name, apiUUID := range nameMap {
+		if apiUUID == "petstore-uuid" {
+			assert.Equal(t, prodName, name, "Names reserved during the generation should not be kept.")
+		}
+	}
+	assert.Equal(t, prodName, getClusterName(&petstore, "clusterProd", "org1", "localhost", ""))
+}
+
+func TestGetClusterNameWithResourceIDTemplate(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousTemplate := conf.Envoy.ResourceNamingTemplate
+	conf.Envoy.ResourceNamingTemplate = "{apiName}_{endpointType}_{resourceId}"
+	defer func() {
+		conf.Envoy.ResourceNamingTemplate = previousTemplate
+	}()
+
+	getResourceClusterName := func() string {
+		operation := model.NewOperation("GET", nil, nil)
+		resource := model.CreateMinimalDummyResourceForTests("/pets/{petId}", []*model.Operation{operation},
+			uuid.New().String(), nil, nil)
+		petstore := model.CreateDummyMgwSwaggerForAWSLambdaTests([]*model.Resource{&resource})
+		petstore.SetID("petstore-uuid")
+		petstore.SetName("PetStore")
+		defer ReleaseGeneratedNames(*petstore, "localhost")
+		return getClusterName(petstore, "clusterProd", "org1", "localhost", resource.GetID())
+	}
+	name := getResourceClusterName()
+	assert.Equal(t, "PetStore_clusterProd_GET_pets__petId_", name)
+	assert.Equal(t, name, getResourceClusterName(),
+		"The same resource should get the same name whenever the API definition is parsed.")
+}
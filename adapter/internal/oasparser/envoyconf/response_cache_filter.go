@@ -0,0 +1,77 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"errors"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cache/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	simple_http_cache_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/cache/simple_http_cache/v3"
+	type_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// getResponseCacheFilter returns the filter caching the responses of the APIs in the memory of the router. The
+// responses vary only by the allowed vary headers. Since the cache filter does not support route specific
+// configurations, the cacheVaryHeaders of the operations are added to the vary header of the responses by their
+// routes (see generateCacheVaryHeaderToAdd). The filter is placed after the ext_authz filter, hence the requests are
+// authenticated even if those are served from the cache.
+func getResponseCacheFilter() (*hcmv3.HttpFilter, error) {
+	conf, _ := config.ReadConfigs()
+	simpleCacheConfig, err := anypb.New(&simple_http_cache_v3.SimpleHttpCacheConfig{})
+	if err != nil {
+		return nil, errors.New("Error occurred while marshalling the cache configurations. " + err.Error())
+	}
+	var allowedVaryHeaders []*type_matcher_v3.StringMatcher
+	for _, header := range conf.Envoy.Filters.ResponseCache.AllowedVaryHeaders {
+		allowedVaryHeaders = append(allowedVaryHeaders, &type_matcher_v3.StringMatcher{
+			MatchPattern: &type_matcher_v3.StringMatcher_Exact{Exact: strings.ToLower(strings.TrimSpace(header))},
+			IgnoreCase:   true,
+		})
+	}
+	cacheConfig, err := anypb.New(&cachev3.CacheConfig{
+		TypedConfig:        simpleCacheConfig,
+		AllowedVaryHeaders: allowedVaryHeaders,
+	})
+	if err != nil {
+		return nil, errors.New("Error occurred while marshalling the cache filter configurations. " + err.Error())
+	}
+	return &hcmv3.HttpFilter{
+		Name: cacheFilterName,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: cacheConfig,
+		},
+	}, nil
+}
+
+// generateCacheVaryHeaderToAdd returns the router config to add the cacheVaryHeaders of an operation to the vary
+// header of its responses, by which the cache filter varies the cached responses of the operation. The vary header
+// sent by the backend is retained.
+func generateCacheVaryHeaderToAdd(cacheVaryHeaders []string) *corev3.HeaderValueOption {
+	return &corev3.HeaderValueOption{
+		Header: &corev3.HeaderValue{
+			Key:   varyHeaderName,
+			Value: strings.Join(cacheVaryHeaders, ", "),
+		},
+		AppendAction: corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD,
+	}
+}
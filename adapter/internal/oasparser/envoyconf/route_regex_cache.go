@@ -0,0 +1,128 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
+)
+
+// maxRouteRegexCacheEntries bounds the number of route regexes kept in the route regex cache
+const maxRouteRegexCacheEntries = 10000
+
+// routeRegexes caches the route regexes generated for the path templates of all the APIs, hence the APIs having the
+// same path templates (e.g. the same API deployed in several vhosts) share the route regexes.
+var routeRegexes = newRouteRegexCache(maxRouteRegexCacheEntries)
+
+// routeRegexCache is a concurrency safe LRU cache of the route regexes, keyed by the normalized path templates.
+// The route regexes are strings, hence the cached regexes cannot be modified by the routes they are added to.
+type routeRegexCache struct {
+	mutex      sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	// route regexes in the order they were used, where the most recently used route regex is at the front
+	usage  *list.List
+	hits   uint64
+	misses uint64
+}
+
+type routeRegexCacheEntry struct {
+	key   string
+	regex string
+}
+
+func newRouteRegexCache(maxEntries int) *routeRegexCache {
+	return &routeRegexCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		usage:      list.New(),
+	}
+}
+
+// getOrGenerate returns the cached route regex of the key, or generates the route regex and caches it, evicting the
+// least recently used route regex if the cache is full.
+func (cache *routeRegexCache) getOrGenerate(key string, generate func() string) string {
+	if regex, found := cache.get(key); found {
+		metrics.IncRouteRegexCacheRequests(true)
+		return regex
+	}
+	metrics.IncRouteRegexCacheRequests(false)
+	regex := generate()
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, found := cache.entries[key]; found {
+		// generated concurrently for another route
+		cache.usage.MoveToFront(element)
+		return element.Value.(*routeRegexCacheEntry).regex
+	}
+	cache.entries[key] = cache.usage.PushFront(&routeRegexCacheEntry{key: key, regex: regex})
+	if cache.usage.Len() > cache.maxEntries {
+		leastRecentlyUsed := cache.usage.Back()
+		cache.usage.Remove(leastRecentlyUsed)
+		delete(cache.entries, leastRecentlyUsed.Value.(*routeRegexCacheEntry).key)
+	}
+	metrics.SetRouteRegexCacheEntries(cache.usage.Len())
+	return regex
+}
+
+func (cache *routeRegexCache) get(key string) (string, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	element, found := cache.entries[key]
+	if !found {
+		cache.misses++
+		return "", false
+	}
+	cache.hits++
+	cache.usage.MoveToFront(element)
+	return element.Value.(*routeRegexCacheEntry).regex, true
+}
+
+// stats returns the number of cache hits and misses, and the number of cached route regexes.
+func (cache *routeRegexCache) stats() (hits, misses uint64, entries int) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.hits, cache.misses, cache.usage.Len()
+}
+
+// normalizePathTemplate replaces the path parameters of the path template with "{*}", as the names of the path
+// parameters do not change the route regex (e.g. /items/{id} and /items/{itemId} are normalized to /items/{*}).
+// The path parameters are identified the same way as pathParamRegex, hence "{}" is not a path parameter.
+func normalizePathTemplate(pathTemplate string) string {
+	if !strings.Contains(pathTemplate, "{") {
+		return pathTemplate
+	}
+	var normalized strings.Builder
+	normalized.Grow(len(pathTemplate))
+	for i := 0; i < len(pathTemplate); {
+		if pathTemplate[i] == '{' {
+			if end := strings.IndexByte(pathTemplate[i+1:], '}'); end > 0 {
+				normalized.WriteString("{*}")
+				i += end + 2
+				continue
+			}
+		}
+		normalized.WriteByte(pathTemplate[i])
+		i++
+	}
+	return normalized.String()
+}
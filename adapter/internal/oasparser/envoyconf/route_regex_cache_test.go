@@ -0,0 +1,142 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePathTemplate(t *testing.T) {
+	dataItems := []struct {
+		pathTemplate string
+		normalized   string
+	}{
+		{"/items", "/items"},
+		{"/items/{id}", "/items/{*}"},
+		{"/items/{itemId}/parts/{partId}", "/items/{*}/parts/{*}"},
+		{"/items/{*}", "/items/{*}"},
+		{"/items/{}", "/items/{}"},
+		{"/items/{id", "/items/{id"},
+		{"/items/{{id}", "/items/{*}"},
+		{"/items/{id}}", "/items/{*}}"},
+	}
+	for _, item := range dataItems {
+		assert.Equal(t, item.normalized, normalizePathTemplate(item.pathTemplate), item.pathTemplate)
+		assert.Equal(t, generateRouteRegex("/base", item.pathTemplate),
+			generateRouteRegex("/base", item.normalized),
+			"Normalized path template of %v should have the same route regex", item.pathTemplate)
+	}
+}
+
+func TestRouteRegexCache(t *testing.T) {
+	cache := newRouteRegexCache(2)
+	generations := 0
+	getOrGenerate := func(basePath, resourcePath string) string {
+		key := normalizePathTemplate(basePath) + "\x00" + normalizePathTemplate(resourcePath)
+		return cache.getOrGenerate(key, func() string {
+			generations++
+			return generateRouteRegex(basePath, resourcePath)
+		})
+	}
+
+	itemsRegex := getOrGenerate("/shop", "/items/{id}")
+	assert.Equal(t, generateRouteRegex("/shop", "/items/{id}"), itemsRegex)
+	assert.Equal(t, itemsRegex, getOrGenerate("/shop", "/items/{itemId}"),
+		"Path templates differing only by the path parameter names should share the route regex")
+	assert.Equal(t, 1, generations)
+	assert.Equal(t, "^/shop/items/{}[/]{0,1}", getOrGenerate("/shop", "/items/{}"),
+		"Literal braces should not share the route regex of the path parameters")
+	assert.Equal(t, 2, generations)
+
+	getOrGenerate("/shop", "/orders/{id}")
+	hits, misses, entries := cache.stats()
+	assert.Equal(t, uint64(1), hits)
+	assert.Equal(t, uint64(3), misses)
+	assert.Equal(t, 2, entries, "Cache should not exceed the maximum number of entries")
+
+	getOrGenerate("/shop", "/items/{}")
+	assert.Equal(t, 3, generations, "Recently used route regex should be kept")
+	getOrGenerate("/shop", "/items/{id}")
+	assert.Equal(t, 4, generations, "Least recently used route regex should be evicted")
+}
+
+func TestRouteRegexCacheConcurrently(t *testing.T) {
+	cache := newRouteRegexCache(10)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resourcePath := fmt.Sprintf("/items%d/{id}", i%20)
+			regex := cache.getOrGenerate(normalizePathTemplate(resourcePath), func() string {
+				return generateRouteRegex("/shop", resourcePath)
+			})
+			assert.Equal(t, generateRouteRegex("/shop", resourcePath), regex)
+		}(i)
+	}
+	wg.Wait()
+	_, _, entries := cache.stats()
+	assert.Equal(t, 10, entries)
+}
+
+// templatedPaths returns the path templates of n resources, where the resources of the same kind differ only by
+// the names of their path parameters, as in the APIs generated from the same template.
+func templatedPaths(n int) []string {
+	paths := make([]string, n)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("/kind%d/{id%d}/items/{itemId%d}", i%100, i, i)
+	}
+	return paths
+}
+
+// generateRoutePathWithoutCache generates the route path the way it was generated before the route regexes were
+// cached, which compiled the path parameter regex for each path.
+func generateRoutePathWithoutCache(basePath, resourcePath string) string {
+	matcher := regexp.MustCompile(`{([^}]+)}`)
+	return "^" + matcher.ReplaceAllString(basePath+resourcePath, "([^/]+)") + "[/]{0,1}"
+}
+
+func BenchmarkGenerateRoutePathWithoutCache(b *testing.B) {
+	paths := templatedPaths(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, path := range paths {
+			generateRoutePathWithoutCache("/shop", path)
+		}
+	}
+}
+
+func BenchmarkGenerateRoutePath(b *testing.B) {
+	paths := templatedPaths(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, path := range paths {
+			generateRoutePath("/shop", path)
+		}
+	}
+	b.StopTimer()
+	hits, misses, _ := routeRegexes.stats()
+	b.ReportMetric(float64(hits)/float64(hits+misses), "hit-rate")
+}
@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// maxRouteRegexCompilesInProgress is the maximum number of route regexes compiled concurrently by the regex guard.
+const maxRouteRegexCompilesInProgress int = 8
+
+// routeRegexCompileSlots caps the route regex compilations in progress. A compilation cannot be cancelled when it
+// exceeds the compile budget, hence it keeps running in the background, holding its slot, until it completes. The
+// capacity bounds the number of such goroutines, and the routes are rejected without being compiled while all
+// the slots are held.
+var routeRegexCompileSlots = make(chan struct{}, maxRouteRegexCompilesInProgress)
+
+// compileRouteRegex compiles a route regex the same way as the router, which uses RE2 as well.
+var compileRouteRegex = func(routeRegex string) error {
+	_, err := regexp.Compile(routeRegex)
+	return err
+}
+
+// ValidateRouteRegexes generates the route regexes of the resources of the API and returns an error quoting the path
+// template of the first resource whose route regex is invalid or is not compiled within the regex compile budget.
+// The time budget is not applied if it is set to 0. A route regex which exceeds the budget is still compiled in the
+// background until it completes, and at most maxRouteRegexCompilesInProgress route regexes are compiled at once.
+func ValidateRouteRegexes(mgwSwagger model.MgwSwagger) error {
+	conf, _ := config.ReadConfigs()
+	budget := time.Duration(conf.Adapter.PathTemplateLimits.RegexCompileBudgetInMillis) * time.Millisecond
+	basePath := strings.TrimSuffix(mgwSwagger.GetXWso2Basepath(), "/")
+	for _, resource := range mgwSwagger.GetResources() {
+		routeRegex := generateRouteRegex(basePath, resource.GetPath())
+		if budget <= 0 {
+			if err := compileRouteRegex(routeRegex); err != nil {
+				return fmt.Errorf("route regex of the path template %q is invalid. %v", resource.GetPath(), err)
+			}
+			continue
+		}
+		compile, slots := compileRouteRegex, routeRegexCompileSlots
+		select {
+		case slots <- struct{}{}:
+		default:
+			return fmt.Errorf("route regex of the path template %q is not compiled as %d route regexes are "+
+				"already being compiled", resource.GetPath(), cap(slots))
+		}
+		compiled := make(chan error, 1)
+		go func() {
+			defer func() { <-slots }()
+			compiled <- compile(routeRegex)
+		}()
+		select {
+		case err := <-compiled:
+			if err != nil {
+				return fmt.Errorf("route regex of the path template %q is invalid. %v", resource.GetPath(), err)
+			}
+		case <-time.After(budget):
+			return fmt.Errorf("route regex of the path template %q is not compiled within %v", resource.GetPath(),
+				budget)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func TestValidateRouteRegexes(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousBudget := conf.Adapter.PathTemplateLimits.RegexCompileBudgetInMillis
+	previousCompile := compileRouteRegex
+	defer func() {
+		conf.Adapter.PathTemplateLimits.RegexCompileBudgetInMillis = previousBudget
+		compileRouteRegex = previousCompile
+	}()
+	openAPI := `{"openapi": "3.0.0", "info": {"title": "PetStore", "version": "1.0.0"},
+		"servers": [{"url": "https://petstore.io/petstore/v1"}],
+		"paths": {"/pets/{petId}/owners/{ownerId}": {"get": {"responses": {"200": {"description": "OK"}}}}}}`
+	var mgwSwagger model.MgwSwagger
+	if !assert.Nil(t, mgwSwagger.GetMgwSwagger([]byte(openAPI))) {
+		return
+	}
+
+	conf.Adapter.PathTemplateLimits.RegexCompileBudgetInMillis = 100
+	assert.Nil(t, ValidateRouteRegexes(mgwSwagger), "Route regex compiled within the budget should be valid")
+
+	compileRouteRegex = func(routeRegex string) error {
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	}
+	err := ValidateRouteRegexes(mgwSwagger)
+	if assert.NotNil(t, err, "Route regex not compiled within the budget should be rejected") {
+		assert.Contains(t, err.Error(), `"/pets/{petId}/owners/{ownerId}"`)
+	}
+
+	conf.Adapter.PathTemplateLimits.RegexCompileBudgetInMillis = 0
+	assert.Nil(t, ValidateRouteRegexes(mgwSwagger), "Budget set to 0 should not be applied")
+}
+
+func TestValidateRouteRegexesWithCompilesInProgress(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousBudget := conf.Adapter.PathTemplateLimits.RegexCompileBudgetInMillis
+	previousCompile := compileRouteRegex
+	previousSlots := routeRegexCompileSlots
+	defer func() {
+		conf.Adapter.PathTemplateLimits.RegexCompileBudgetInMillis = previousBudget
+		compileRouteRegex = previousCompile
+		routeRegexCompileSlots = previousSlots
+	}()
+	openAPI := `{"openapi": "3.0.0", "info": {"title": "PetStore", "version": "1.0.0"},
+		"paths": {"/pets/{petId}": {"get": {"responses": {"200": {"description": "OK"}}}}}}`
+	var mgwSwagger model.MgwSwagger
+	if !assert.Nil(t, mgwSwagger.GetMgwSwagger([]byte(openAPI))) {
+		return
+	}
+
+	conf.Adapter.PathTemplateLimits.RegexCompileBudgetInMillis = 50
+	routeRegexCompileSlots = make(chan struct{}, 1)
+	unblock := make(chan struct{})
+	compileRouteRegex = func(routeRegex string) error {
+		<-unblock
+		return nil
+	}
+	err := ValidateRouteRegexes(mgwSwagger)
+	if assert.NotNil(t, err, "Route regex not compiled within the budget should be rejected") {
+		assert.Contains(t, err.Error(), "is not compiled within")
+	}
+	err = ValidateRouteRegexes(mgwSwagger)
+	if assert.NotNil(t, err, "Route regex should be rejected while the compilations in progress are capped") {
+		assert.Contains(t, err.Error(), "already being compiled")
+	}
+
+	close(unblock)
+	assert.Eventually(t, func() bool { return len(routeRegexCompileSlots) == 0 }, time.Second, 10*time.Millisecond,
+		"Slot should be released when the compilation completes")
+	assert.Nil(t, ValidateRouteRegexes(mgwSwagger))
+}
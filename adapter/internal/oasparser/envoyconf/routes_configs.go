@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	extAuthService "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
 	envoy_type_matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	metadatav3 "github.com/envoyproxy/go-control-plane/envoy/type/metadata/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/any"
@@ -64,6 +66,93 @@ func generateRouteConfig(routeName string, match *routev3.RouteMatch, action *ro
 	return route
 }
 
+// generateRateLimits returns the rate limit actions of the API routes, which produce a descriptor having the API and
+// the rate limit key of the request. The value of a claim is read from the dynamic metadata set by the enforcer
+// (ext_authz filter), which publishes the value of the claim given by the context extension of the route. Nil is
+// returned if the API does not have a rate limit key, hence the requests are rate limited by the API and the
+// application.
+func generateRateLimits(apiUUID string, rateLimitKey *model.RateLimitKey) []*routev3.RateLimit {
+	if rateLimitKey == nil {
+		return nil
+	}
+	var keyAction *routev3.RateLimit_Action
+	if header := strings.TrimSpace(rateLimitKey.Header); header != "" {
+		keyAction = &routev3.RateLimit_Action{
+			ActionSpecifier: &routev3.RateLimit_Action_RequestHeaders_{
+				RequestHeaders: &routev3.RateLimit_Action_RequestHeaders{
+					HeaderName:    header,
+					DescriptorKey: rateLimitKeyDescriptorKey,
+				},
+			},
+		}
+	} else {
+		keyAction = &routev3.RateLimit_Action{
+			ActionSpecifier: &routev3.RateLimit_Action_Metadata{
+				Metadata: &routev3.RateLimit_Action_MetaData{
+					DescriptorKey: rateLimitKeyDescriptorKey,
+					MetadataKey: &metadatav3.MetadataKey{
+						Key: extAuthzFilterName,
+						Path: []*metadatav3.MetadataKey_PathSegment{{
+							Segment: &metadatav3.MetadataKey_PathSegment_Key{
+								Key: rateLimitKeyMetadataKey,
+							},
+						}},
+					},
+					Source: routev3.RateLimit_Action_MetaData_DYNAMIC,
+				},
+			},
+		}
+	}
+	apiAction := &routev3.RateLimit_Action{
+		ActionSpecifier: &routev3.RateLimit_Action_GenericKey_{
+			GenericKey: &routev3.RateLimit_Action_GenericKey{
+				DescriptorKey:   rateLimitAPIDescriptorKey,
+				DescriptorValue: apiUUID,
+			},
+		},
+	}
+	return []*routev3.RateLimit{{
+		Actions: []*routev3.RateLimit_Action{apiAction, keyAction},
+	}}
+}
+
+// generateLocalRateLimits returns the rate limit actions of the routes of a locally rate limited API, which produce
+// the descriptor of the API matched by the local rate limit filter configuration of the virtual host. The actions are
+// of a separate stage, hence not sent to the rate limit service.
+func generateLocalRateLimits(apiUUID string) []*routev3.RateLimit {
+	return []*routev3.RateLimit{{
+		Stage: wrapperspb.UInt32(localRateLimitStage),
+		Actions: []*routev3.RateLimit_Action{{
+			ActionSpecifier: &routev3.RateLimit_Action_GenericKey_{
+				GenericKey: &routev3.RateLimit_Action_GenericKey{
+					DescriptorKey:   rateLimitAPIDescriptorKey,
+					DescriptorValue: apiUUID,
+				},
+			},
+		}},
+	}}
+}
+
+// generateSessionAffinityHashPolicies returns the hash policies of the API routes, which hash the session cookie so
+// that the ring hash load balancer of the API clusters routes the requests of a session to the same endpoint. The
+// router generates the cookie if a request does not have it. Nil is returned if the API does not have the session
+// affinity.
+func generateSessionAffinityHashPolicies(sessionAffinity *model.SessionAffinity) []*routev3.RouteAction_HashPolicy {
+	if sessionAffinity == nil {
+		return nil
+	}
+	return []*routev3.RouteAction_HashPolicy{{
+		PolicySpecifier: &routev3.RouteAction_HashPolicy_Cookie_{
+			Cookie: &routev3.RouteAction_HashPolicy_Cookie{
+				Name: sessionAffinity.CookieName,
+				Ttl:  durationpb.New(sessionAffinity.CookieTTL),
+				Path: "/",
+			},
+		},
+		Terminal: true,
+	}}
+}
+
 func generateRouteMatch(routeRegex string) *routev3.RouteMatch {
 	match := &routev3.RouteMatch{
 		PathSpecifier: &routev3.RouteMatch_SafeRegex{
@@ -75,6 +164,24 @@ func generateRouteMatch(routeRegex string) *routev3.RouteMatch {
 	return match
 }
 
+// generateQueryParamPresentMatchers returns the query parameter matchers of a route, so that only the requests
+// having all the given query parameters match the route.
+func generateQueryParamPresentMatchers(queryParams []string) []*routev3.QueryParameterMatcher {
+	if len(queryParams) == 0 {
+		return nil
+	}
+	queryParamMatchers := make([]*routev3.QueryParameterMatcher, len(queryParams))
+	for i, queryParam := range queryParams {
+		queryParamMatchers[i] = &routev3.QueryParameterMatcher{
+			Name: queryParam,
+			QueryParameterMatchSpecifier: &routev3.QueryParameterMatcher_PresentMatch{
+				PresentMatch: true,
+			},
+		}
+	}
+	return queryParamMatchers
+}
+
 func generateRouteAction(apiType string, prodRouteConfig, sandRouteConfig *model.EndpointConfig, endpointType string) (action *routev3.Route_Route) {
 
 	config, _ := config.ReadConfigs()
@@ -129,8 +236,14 @@ func generateRouteAction(apiType string, prodRouteConfig, sandRouteConfig *model
 	return action
 }
 
-func generateHTTPMethodMatcher(methodRegex string, isSandbox bool, sandClusterName string) []*routev3.HeaderMatcher {
-	headerMatcher := generateHeaderMatcher(httpMethodHeader, methodRegex)
+// generateHTTPMethodMatcher generates the header matcher of the :method header, which exactly matches one of the
+// given HTTP methods. The methods are quoted, since custom methods could contain regex meta characters.
+func generateHTTPMethodMatcher(methods []string, isSandbox bool, sandClusterName string) []*routev3.HeaderMatcher {
+	quotedMethods := make([]string, len(methods))
+	for i, method := range methods {
+		quotedMethods[i] = regexp.QuoteMeta(method)
+	}
+	headerMatcher := generateHeaderMatcher(httpMethodHeader, "(?:"+strings.Join(quotedMethods, "|")+")")
 	headerMatcherArray := []*routev3.HeaderMatcher{headerMatcher}
 	// if sandbox route, add additional header match based on cluster name header
 	if isSandbox {
@@ -176,6 +289,17 @@ func generateRegexMatchAndSubstitute(routePath, endpointBasePath,
 	}
 }
 
+// generateRegexRewriteForRewritePath returns the regex rewrite of the rewritePath of an operation. The pattern
+// and the substitution are validated when the operation is created.
+func generateRegexRewriteForRewritePath(rewritePath *model.RewritePathConfig) *envoy_type_matcherv3.RegexMatchAndSubstitute {
+	return &envoy_type_matcherv3.RegexMatchAndSubstitute{
+		Pattern: &envoy_type_matcherv3.RegexMatcher{
+			Regex: rewritePath.Pattern,
+		},
+		Substitution: rewritePath.Substitution,
+	}
+}
+
 // Router configs for Operational Policies
 
 // generateHeaderToAddRouteConfig returns Router config for SET_HEADER
@@ -202,6 +326,43 @@ func generateHeaderToAddRouteConfig(policyParams interface{}) (*corev3.HeaderVal
 	return &headerToAdd, nil
 }
 
+// generateDeprecationHeaderToAdd returns Router config to add the Deprecation header to the responses
+// of a deprecated operation
+func generateDeprecationHeaderToAdd() *corev3.HeaderValueOption {
+	return &corev3.HeaderValueOption{
+		Header: &corev3.HeaderValue{
+			Key:   deprecationHeaderName,
+			Value: deprecationHeaderValue,
+		},
+		AppendAction: *corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD.Enum(),
+	}
+}
+
+// generateAPIResponseHeadersToAdd returns Router config to add the response headers of the API to all the responses
+// of the API, overwriting the headers sent by the backends. The headers are sorted by the names to generate the same
+// config for the same headers.
+func generateAPIResponseHeadersToAdd(responseHeaders map[string]string) []*corev3.HeaderValueOption {
+	if len(responseHeaders) == 0 {
+		return nil
+	}
+	headerNames := make([]string, 0, len(responseHeaders))
+	for headerName := range responseHeaders {
+		headerNames = append(headerNames, headerName)
+	}
+	sort.Strings(headerNames)
+	headersToAdd := make([]*corev3.HeaderValueOption, 0, len(headerNames))
+	for _, headerName := range headerNames {
+		headersToAdd = append(headersToAdd, &corev3.HeaderValueOption{
+			Header: &corev3.HeaderValue{
+				Key:   headerName,
+				Value: responseHeaders[headerName],
+			},
+			AppendAction: *corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD.Enum(),
+		})
+	}
+	return headersToAdd
+}
+
 func generateHeaderToRemoveString(policyParams interface{}) (string, error) {
 	var paramsToRemoveHeader map[string]interface{}
 	var ok bool
@@ -216,6 +377,44 @@ func generateHeaderToRemoveString(policyParams interface{}) (string, error) {
 	return requestHeaderToRemove, nil
 }
 
+// generateRequestHeadersToStrip returns the request headers removed before the requests are sent to the backends of
+// an API, i.e. the headers configured globally followed by the headers of the x-wso2-strip-request-headers extension.
+// Pseudo headers and the host header are skipped, as those cannot be removed by the router.
+func generateRequestHeadersToStrip(apiLevelHeaders []string) []string {
+	conf, _ := config.ReadConfigs()
+	var headersToStrip []string
+	for _, headerName := range append(append([]string{}, conf.Adapter.StripRequestHeaders...), apiLevelHeaders...) {
+		headerName = strings.TrimSpace(headerName)
+		if headerName == "" {
+			continue
+		}
+		if strings.HasPrefix(headerName, ":") || strings.EqualFold(headerName, "host") {
+			logger.LoggerOasparser.Warnf("Header %v cannot be stripped from the requests. Hence it is skipped.", headerName)
+			continue
+		}
+		headersToStrip = append(headersToStrip, headerName)
+	}
+	return mergeHeaderNames(headersToStrip)
+}
+
+// mergeHeaderNames merges the header name lists, removing the duplicates case-insensitively. Header names are
+// lower cased, as the headers are matched case-insensitively by the router.
+func mergeHeaderNames(headerLists ...[]string) []string {
+	var mergedHeaders []string
+	headerSet := make(map[string]struct{})
+	for _, headers := range headerLists {
+		for _, headerName := range headers {
+			headerName = strings.ToLower(headerName)
+			if _, found := headerSet[headerName]; found {
+				continue
+			}
+			headerSet[headerName] = struct{}{}
+			mergedHeaders = append(mergedHeaders, headerName)
+		}
+	}
+	return mergedHeaders
+}
+
 func generateRewritePathRouteConfig(routePath, resourcePath, endpointBasepath string,
 	policyParams interface{}) (*envoy_type_matcherv3.RegexMatchAndSubstitute, error) {
 
@@ -20,6 +20,7 @@ package envoyconf
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"regexp"
 	"strconv"
@@ -35,7 +36,9 @@ import (
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	rlv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
 	awslambdav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/aws_lambda/v3"
+	bufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
 	cors_filter_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
 	extAuthService "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
 	local_rate_limitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
@@ -103,6 +106,10 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 
 	apiLevelClusterNameProd := ""
 	apiLevelClusterNameSand := ""
+	// key types served by the clusters, which are tagged on the clusters
+	clusterKeyTypes := make(map[string][]string)
+	// query parameters of the endpoints of the clusters, which are appended to the forwarded requests
+	clusterQueryParams := make(map[string]string)
 
 	apiLevelProdEndpoints := mgwSwagger.GetProdEndpoints()
 	apiLevelSandEndpoints := mgwSwagger.GetSandEndpoints()
@@ -110,9 +117,10 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 	// check API level production endpoints available
 	if apiLevelProdEndpoints != nil && len(apiLevelProdEndpoints.Endpoints) > 0 {
 		apiLevelProdEndpoints.HTTP2BackendEnabled = mgwSwagger.GetXWso2HTTP2BackendEnabled()
+		apiLevelProdEndpoints.SessionAffinity = mgwSwagger.GetSessionAffinity()
+		apiLevelProdEndpoints.FallbackEndpoint = mgwSwagger.GetFallbackEndpoint()
 		apiLevelBasePathProd = strings.TrimSuffix(apiLevelProdEndpoints.Endpoints[0].Basepath, "/")
-		apiLevelClusterNameProd = getClusterName(apiLevelProdEndpoints.EndpointPrefix, organizationID, vHost, apiTitle,
-			apiVersion, "")
+		apiLevelClusterNameProd = getClusterName(&mgwSwagger, apiLevelProdEndpoints.EndpointPrefix, organizationID, vHost, "")
 		if !strings.Contains(apiLevelProdEndpoints.EndpointPrefix, xWso2EPClustersConfigNamePrefix) {
 			cluster, address, err := processEndpoints(apiLevelClusterNameProd, apiLevelProdEndpoints,
 				upstreamCerts, timeout, apiLevelBasePathProd)
@@ -126,6 +134,7 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 			} else {
 				clusters = append(clusters, cluster)
 				endpoints = append(endpoints, address...)
+				addEndpointQueryParams(clusterQueryParams, apiLevelClusterNameProd, apiLevelProdEndpoints)
 			}
 		}
 	} else {
@@ -135,6 +144,7 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 	if apiLevelSandEndpoints != nil && len(apiLevelSandEndpoints.Endpoints) > 0 {
 		selectedBasePathSand := apiLevelBasePathProd
 		apiLevelSandEndpoints.HTTP2BackendEnabled = mgwSwagger.GetXWso2HTTP2BackendEnabled()
+		apiLevelSandEndpoints.SessionAffinity = mgwSwagger.GetSessionAffinity()
 		if apiLevelBasePathProd == "" && apiLevelClusterNameProd == "" {
 			// no production endpoint, assign sandbox endpoint basepath as apiLevelbasePath
 			apiLevelBasePathProd = strings.TrimSuffix(apiLevelSandEndpoints.Endpoints[0].Basepath, "/")
@@ -148,8 +158,7 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 		}
 		apiLevelClusterNameSand = apiLevelClusterNameProd
 		if isSandboxClusterRequired(apiLevelProdEndpoints, apiLevelSandEndpoints) {
-			apiLevelClusterNameSand = getClusterName(apiLevelSandEndpoints.EndpointPrefix, organizationID, vHost,
-				apiTitle, apiVersion, "")
+			apiLevelClusterNameSand = getClusterName(&mgwSwagger, apiLevelSandEndpoints.EndpointPrefix, organizationID, vHost, "")
 			if !strings.Contains(apiLevelSandEndpoints.EndpointPrefix, xWso2EPClustersConfigNamePrefix) {
 				cluster, address, err := processEndpoints(apiLevelClusterNameSand, apiLevelSandEndpoints,
 					upstreamCerts, timeout, selectedBasePathSand)
@@ -163,6 +172,7 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 				} else {
 					clusters = append(clusters, cluster)
 					endpoints = append(endpoints, address...)
+					addEndpointQueryParams(clusterQueryParams, apiLevelClusterNameSand, apiLevelSandEndpoints)
 				}
 			}
 		}
@@ -177,8 +187,7 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 			if apiLevelBasePathProd == "" && apiLevelClusterNameProd == "" {
 				apiLevelBasePathProd = strings.TrimSuffix(endpointCluster.Endpoints[0].Basepath, "/")
 			}
-			epClusterName := getClusterName(endpointCluster.EndpointPrefix, organizationID, vHost, apiTitle,
-				apiVersion, "")
+			epClusterName := getClusterName(&mgwSwagger, endpointCluster.EndpointPrefix, organizationID, vHost, "")
 			cluster, addresses, err := processEndpoints(epClusterName, endpointCluster, upstreamCerts, timeout, apiLevelBasePathProd)
 			if err != nil {
 				logger.LoggerOasparser.Errorf("Error while adding x-wso2-endpoints cluster %v for %s. %v ", epName, apiTitle, err.Error())
@@ -186,6 +195,7 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 				strictBasePath = true
 				clusters = append(clusters, cluster)
 				endpoints = append(endpoints, addresses...)
+				addEndpointQueryParams(clusterQueryParams, epClusterName, endpointCluster)
 			}
 		}
 	}
@@ -202,8 +212,10 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 	// No topic level endpoints.
 	if mgwSwagger.GetAPIType() == constants.WS {
 		for _, resource := range mgwSwagger.GetResources() {
-			routesP, err := createRoutes(genRouteCreateParams(&mgwSwagger, resource, vHost, apiLevelBasePathProd, apiLevelClusterNameProd,
-				apiLevelClusterNameSand, nil, nil, organizationID, false))
+			routeParams := genRouteCreateParams(&mgwSwagger, resource, vHost, apiLevelBasePathProd, apiLevelClusterNameProd,
+				apiLevelClusterNameSand, nil, nil, organizationID, false)
+			routeParams.endpointQueryParams = clusterQueryParams
+			routesP, err := createRoutesWithContextAliases(routeParams, mgwSwagger.GetContextAliases())
 			if err != nil {
 				logger.LoggerXds.ErrorC(logging.ErrorDetails{
 					Message: fmt.Sprintf("Error while creating routes for Websocket API. For path: %s Error: %s",
@@ -215,6 +227,8 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 			}
 			routes = append(routes, routesP...)
 		}
+		addClusterKeyTypes(clusterKeyTypes, apiLevelClusterNameProd, apiLevelClusterNameSand)
+		setClusterKeyTypeMetadata(clusters, clusterKeyTypes)
 		return routes, clusters, endpoints, nil
 
 	}
@@ -236,7 +250,8 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 				resource.SetAmznResourceName(amznResourceName)
 			}
 
-			routesX, err := createRoutes(genRouteCreateParams(&mgwSwagger, resource, vHost, "", awslambdaClusterName, awslambdaClusterName, nil, nil, organizationID, false))
+			routesX, err := createRoutesWithContextAliases(genRouteCreateParams(&mgwSwagger, resource, vHost, "", awslambdaClusterName, awslambdaClusterName, nil, nil, organizationID, false),
+				mgwSwagger.GetContextAliases())
 			if err != nil {
 				logger.LoggerXds.ErrorC(logging.ErrorDetails{
 					Message: fmt.Sprintf("Error while creating routes for AWS Lambda API : %s version : %s. Error: %s",
@@ -252,8 +267,10 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 	}
 
 	if mgwSwagger.GetAPIType() == constants.GRAPHQL {
-		routesP, err := createRoutes(genRouteCreateParams(&mgwSwagger, nil, vHost, apiLevelBasePathProd, apiLevelClusterNameProd,
-			apiLevelClusterNameSand, nil, nil, organizationID, false))
+		routeParams := genRouteCreateParams(&mgwSwagger, nil, vHost, apiLevelBasePathProd, apiLevelClusterNameProd,
+			apiLevelClusterNameSand, nil, nil, organizationID, false)
+		routeParams.endpointQueryParams = clusterQueryParams
+		routesP, err := createRoutesWithContextAliases(routeParams, mgwSwagger.GetContextAliases())
 		if err != nil {
 			logger.LoggerXds.ErrorC(logging.ErrorDetails{
 				Message: fmt.Sprintf("Error while creating routes for GraphQL API : %s version : %s. Error: %s",
@@ -264,9 +281,13 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 			return nil, nil, nil, fmt.Errorf("error while creating routes for GraphQL API : %s version : %s. %v", apiTitle, apiVersion, err)
 		}
 		routes = append(routes, routesP...)
+		addClusterKeyTypes(clusterKeyTypes, apiLevelClusterNameProd, apiLevelClusterNameSand)
+		setClusterKeyTypeMetadata(clusters, clusterKeyTypes)
 		return routes, clusters, endpoints, nil
 	}
 
+	// AWS Lambda clusters by the region, created for the operations invoking functions as the backend
+	awsLambdaClusterNames := make(map[string]string)
 	for _, resource := range mgwSwagger.GetResources() {
 		clusterNameProd := apiLevelClusterNameProd
 		clusterNameSand := apiLevelClusterNameSand
@@ -291,11 +312,10 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 			if resourceBasePath == "" {
 				resourceBasePath = strings.TrimSuffix(endpointProd.Endpoints[0].Basepath, "/")
 			}
-			clusterNameProd = getClusterName(endpointProd.EndpointPrefix, organizationID, vHost,
-				mgwSwagger.GetTitle(), apiVersion, "")
+			clusterNameProd = getClusterName(&mgwSwagger, endpointProd.EndpointPrefix, organizationID, vHost, "")
 			if !strings.Contains(endpointProd.EndpointPrefix, xWso2EPClustersConfigNamePrefix) {
-				clusterNameProd = getClusterName(endpointProd.EndpointPrefix, organizationID, vHost,
-					mgwSwagger.GetTitle(), apiVersion, resource.GetID())
+				clusterNameProd = getClusterName(&mgwSwagger, endpointProd.EndpointPrefix, organizationID,
+					vHost, resource.GetID())
 				clusterProd, addressProd, err := processEndpoints(clusterNameProd, endpointProd, upstreamCerts, timeout, resourceBasePath)
 				if err != nil {
 					clusterNameProd = apiLevelClusterNameProd
@@ -306,6 +326,7 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 				} else {
 					clusters = append(clusters, clusterProd)
 					endpoints = append(endpoints, addressProd...)
+					addEndpointQueryParams(clusterQueryParams, clusterNameProd, endpointProd)
 				}
 			}
 		}
@@ -327,8 +348,8 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 			}
 			clusterNameSand = apiLevelClusterNameSand
 			if isSandboxClusterRequired(resource.GetProdEndpoints(), resource.GetSandEndpoints()) {
-				clusterNameSand = getClusterName(endpointSand.EndpointPrefix, organizationID, vHost, apiTitle,
-					apiVersion, resource.GetID())
+				clusterNameSand = getClusterName(&mgwSwagger, endpointSand.EndpointPrefix, organizationID,
+					vHost, resource.GetID())
 				clusterSand, addressSand, err := processEndpoints(clusterNameSand, endpointSand, upstreamCerts, timeout, resourceBasePathSand)
 				if err != nil {
 					clusterNameSand = apiLevelClusterNameSand
@@ -339,6 +360,7 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 				} else {
 					clusters = append(clusters, clusterSand)
 					endpoints = append(endpoints, addressSand...)
+					addEndpointQueryParams(clusterQueryParams, clusterNameSand, endpointSand)
 					isResourceBasePathSandAvailable = true
 				}
 			} else if resource.GetSandEndpoints() != nil && len(resource.GetSandEndpoints().Endpoints) > 0 {
@@ -389,8 +411,37 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 		clusters = append(clusters, clustersI...)
 		endpoints = append(endpoints, endpointsI...)
 
-		routeP, err := createRoutes(genRouteCreateParams(&mgwSwagger, resource, vHost, resourceBasePath, clusterNameProd,
-			clusterNameSand, *operationalReqInterceptors, *operationalRespInterceptorVal, organizationID, false))
+		for _, operation := range resource.GetOperations() {
+			awsLambda := operation.GetAwsLambdaConfig()
+			if awsLambda == nil {
+				continue
+			}
+			if _, found := awsLambdaClusterNames[awsLambda.Region]; found {
+				continue
+			}
+			clusterName := getClusterName(&mgwSwagger, awsLambdaClusterPrefix+awsLambda.Region, organizationID, vHost, "")
+			cluster, addresses, err := createAwsLambdaCluster(clusterName, awsLambda.Region, timeout)
+			if err != nil {
+				logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+					Message: fmt.Sprintf("Error while creating the AWS Lambda cluster of the region %s for API %s %s. %v",
+						awsLambda.Region, apiTitle, apiVersion, err.Error()),
+					Severity:  logging.MAJOR,
+					ErrorCode: 2214,
+				})
+				return nil, nil, nil, fmt.Errorf("error while creating the AWS Lambda cluster of the region %s. %v",
+					awsLambda.Region, err)
+			}
+			clusters = append(clusters, cluster)
+			endpoints = append(endpoints, addresses...)
+			awsLambdaClusterNames[awsLambda.Region] = clusterName
+		}
+
+		routeParams := genRouteCreateParams(&mgwSwagger, resource, vHost, resourceBasePath, clusterNameProd,
+			clusterNameSand, *operationalReqInterceptors, *operationalRespInterceptorVal, organizationID, false)
+		routeParams.awsLambdaClusterNames = awsLambdaClusterNames
+		routeParams.endpointQueryParams = clusterQueryParams
+		addClusterKeyTypes(clusterKeyTypes, clusterNameProd, clusterNameSand)
+		routeP, err := createRoutesWithContextAliases(routeParams, mgwSwagger.GetContextAliases())
 		if err != nil {
 			logger.LoggerXds.ErrorC(logging.ErrorDetails{
 				Message: fmt.Sprintf("Error while creating routes for API %s %s for path: %s Error: %s",
@@ -402,8 +453,11 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 		}
 		if apiLevelBasePathSand != "" || isResourceBasePathSandAvailable {
 			logger.LoggerOasparser.Debugf("Creating sandbox route for : %v:%v:%v - %v", apiTitle, apiVersion, resource.GetPath(), resourceBasePathSand)
-			routeS, err := createRoutes(genRouteCreateParams(&mgwSwagger, resource, vHost, resourceBasePathSand, clusterNameProd,
-				clusterNameSand, *operationalReqInterceptors, *operationalRespInterceptorVal, organizationID, true))
+			routeParamsSand := genRouteCreateParams(&mgwSwagger, resource, vHost, resourceBasePathSand, clusterNameProd,
+				clusterNameSand, *operationalReqInterceptors, *operationalRespInterceptorVal, organizationID, true)
+			routeParamsSand.awsLambdaClusterNames = awsLambdaClusterNames
+			routeParamsSand.endpointQueryParams = clusterQueryParams
+			routeS, err := createRoutesWithContextAliases(routeParamsSand, mgwSwagger.GetContextAliases())
 			if err != nil {
 				logger.LoggerXds.ErrorC(logging.ErrorDetails{
 					Message: fmt.Sprintf("Error while creating sandbox cluster routes for API %s %s for path: %s Error: %s",
@@ -417,19 +471,24 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 			routes = append(routes, routeS...)
 		}
 		routes = append(routes, routeP...)
+		if mgwSwagger.IsMethodNotAllowedEnabled() && mgwSwagger.GetAPIType() == constants.HTTP {
+			for _, contextAlias := range append([]string{""}, mgwSwagger.GetContextAliases()...) {
+				if methodNotAllowedRoute := createMethodNotAllowedRoute(&mgwSwagger, resource, contextAlias); methodNotAllowedRoute != nil {
+					routes = append(routes, methodNotAllowedRoute)
+				}
+			}
+		}
 	}
 
-	return routes, clusters, endpoints, nil
-}
-
-func getClusterName(epPrefix string, organizationID string, vHost string, swaggerTitle string, swaggerVersion string,
-	resourceID string) string {
-	if resourceID != "" {
-		return strings.TrimSpace(organizationID+"_"+epPrefix+"_"+vHost+"_"+strings.Replace(swaggerTitle, " ", "", -1)+swaggerVersion) +
-			"_" + strings.Replace(resourceID, " ", "", -1) + "0"
+	if notFoundResponse := mgwSwagger.GetNotFoundResponseConfig(); notFoundResponse != nil {
+		routes = append(routes, createNotFoundFallbackRoute(mgwSwagger.GetXWso2Basepath(), notFoundResponse))
+		for _, contextAlias := range mgwSwagger.GetContextAliases() {
+			routes = append(routes, createNotFoundFallbackRoute(contextAlias, notFoundResponse))
+		}
 	}
-	return strings.TrimSpace(organizationID + "_" + epPrefix + "_" + vHost + "_" + strings.Replace(swaggerTitle, " ", "", -1) +
-		swaggerVersion)
+
+	setClusterKeyTypeMetadata(clusters, clusterKeyTypes)
+	return routes, clusters, endpoints, nil
 }
 
 // CreateLuaCluster creates lua cluster configuration.
@@ -440,16 +499,26 @@ func CreateLuaCluster(interceptorCerts map[string][]byte, endpoint model.Interce
 
 // CreateAwsLambdaCluster creates AWS Lambda cluster configuration.
 func CreateAwsLambdaCluster(conf *config.Config) (*clusterv3.Cluster, []*corev3.Address, error) {
-	epTimeout := conf.Envoy.ClusterTimeoutInSeconds
+	return createAwsLambdaCluster(awslambdaClusterName, conf.Envoy.AwsLambda.AwsRegion,
+		conf.Envoy.ClusterTimeoutInSeconds)
+}
+
+// createAwsLambdaCluster creates a cluster to the Lambda endpoint of the region. The cluster is marked as an egress
+// gateway for the AWS Lambda filter, so that the requests routed to it are transformed to signed function invocations.
+func createAwsLambdaCluster(clusterName string, region string, timeout time.Duration) (*clusterv3.Cluster,
+	[]*corev3.Address, error) {
 	epCluster := &model.EndpointCluster{
 		Endpoints: []model.Endpoint{{
-			Host:    "lambda." + conf.Envoy.AwsLambda.AwsRegion + ".amazonaws.com",
+			Host:    "lambda." + region + ".amazonaws.com",
 			URLType: httpsURLType,
 			Port:    uint32(443),
 		}},
 	}
 
-	cluster, address, err := processEndpoints(awslambdaClusterName, epCluster, nil, epTimeout, "")
+	cluster, address, err := processEndpoints(clusterName, epCluster, nil, timeout, "")
+	if err != nil {
+		return nil, nil, err
+	}
 	cluster.Metadata = &corev3.Metadata{
 		FilterMetadata: map[string]*structpb.Struct{
 			"com.amazonaws.lambda": {
@@ -502,6 +571,44 @@ func CreateTracingCluster(conf *config.Config) (*clusterv3.Cluster, []*corev3.Ad
 	return processEndpoints(tracingClusterName, epCluster, nil, epTimeout, epPath)
 }
 
+// CreateRateLimitCluster creates the cluster of the gRPC rate limit service, which is called by the rate limit
+// filter.
+func CreateRateLimitCluster(conf *config.Config) (*clusterv3.Cluster, []*corev3.Address, error) {
+	if strings.TrimSpace(conf.Envoy.RateLimit.Host) == "" || conf.Envoy.RateLimit.Port == 0 {
+		return nil, nil, errors.New("invalid host or port provided for the rate limit service")
+	}
+	epCluster := &model.EndpointCluster{
+		Endpoints: []model.Endpoint{{
+			Host:    conf.Envoy.RateLimit.Host,
+			URLType: "http",
+			Port:    conf.Envoy.RateLimit.Port,
+		}},
+		HTTP2BackendEnabled: true,
+	}
+	return processEndpoints(rateLimitClusterName, epCluster, nil, conf.Envoy.ClusterTimeoutInSeconds, "")
+}
+
+// getEndpointPriorities returns the priorities of the endpoints of the cluster. The endpoints of a failover cluster
+// are prioritized in their order, unless the priorities are given for the endpoints.
+func getEndpointPriorities(clusterDetails *model.EndpointCluster) []uint32 {
+	priorities := make([]uint32, len(clusterDetails.Endpoints))
+	// epType {loadbalance, failover}
+	if !strings.HasPrefix(clusterDetails.EndpointType, "failover") {
+		return priorities
+	}
+	hasPriorities := false
+	for i, ep := range clusterDetails.Endpoints {
+		priorities[i] = ep.Priority
+		hasPriorities = hasPriorities || ep.Priority != 0
+	}
+	if !hasPriorities {
+		for i := range priorities {
+			priorities[i] = uint32(i)
+		}
+	}
+	return priorities
+}
+
 // processEndpoints creates cluster configuration. AddressConfiguration, cluster name and
 // urlType (http or https) is required to be provided.
 // timeout cluster timeout
@@ -511,25 +618,53 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 	var transportSocketMatches []*clusterv3.Cluster_TransportSocketMatch
 	// create loadbalanced/failover endpoints
 	var lbEPs []*endpointv3.LocalityLbEndpoints
-	// failover priority
-	priority := 0
-	// epType {loadbalance, failover}
-	epType := clusterDetails.EndpointType
+	// failover priorities
+	priorities := getEndpointPriorities(clusterDetails)
+	clusterEndpoints := clusterDetails.Endpoints
+	if clusterDetails.FallbackEndpoint != nil {
+		// the fallback endpoint has the lowest priority, hence it receives the requests only when the endpoints of
+		// all the other priorities are unhealthy
+		fallbackPriority := uint32(0)
+		for _, priority := range priorities {
+			if priority >= fallbackPriority {
+				fallbackPriority = priority + 1
+			}
+		}
+		clusterEndpoints = append(append([]model.Endpoint{}, clusterEndpoints...), *clusterDetails.FallbackEndpoint)
+		priorities = append(priorities, fallbackPriority)
+	}
 
 	addresses := []*corev3.Address{}
+	// the endpoints specified as unix domain sockets are not resolved by DNS, hence those cannot be combined with the
+	// other endpoints in a cluster
+	isUnixSocketCluster := len(clusterDetails.Endpoints) > 0 && clusterDetails.Endpoints[0].IsUnixSocket()
 
-	for i, ep := range clusterDetails.Endpoints {
+	for i, ep := range clusterEndpoints {
 		// validating the basepath to be same for all upstreams of an api
 		if strings.TrimSuffix(ep.Basepath, "/") != basePath {
 			return nil, nil, errors.New("endpoint basepath mismatched for " + ep.RawURL + ". expected : " + basePath + " but found : " + ep.Basepath)
 		}
+		// the query parameters are appended by the route, before the endpoint of the cluster is selected
+		if ep.QueryParams != clusterEndpoints[0].QueryParams {
+			return nil, nil, errors.New("endpoint query parameters mismatched for " + ep.RawURL + ". expected : " +
+				clusterEndpoints[0].QueryParams + " but found : " + ep.QueryParams)
+		}
+		if ep.IsUnixSocket() != isUnixSocketCluster {
+			return nil, nil, errors.New("unix domain socket endpoints cannot be combined with the other endpoints " +
+				"of the cluster " + clusterName)
+		}
 		// create addresses for endpoints
-		address := createAddress(ep.Host, ep.Port)
+		var address *corev3.Address
+		if ep.IsUnixSocket() {
+			address = createPipeAddress(ep.Host)
+		} else {
+			address = createAddress(ep.Host, ep.Port)
+		}
 		addresses = append(addresses, address)
 
 		// create loadbalance / failover endpoints
 		localityLbEndpoints := &endpointv3.LocalityLbEndpoints{
-			Priority: uint32(priority),
+			Priority: priorities[i],
 			LbEndpoints: []*endpointv3.LbEndpoint{
 				{
 					HostIdentifier: &endpointv3.LbEndpoint_Endpoint{
@@ -550,7 +685,12 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 				epCert = defaultCerts
 			}
 
-			upstreamtlsContext := createUpstreamTLSContext(epCert, address, clusterDetails.HTTP2BackendEnabled)
+			var cipherSuites []string
+			if clusterDetails.Config != nil {
+				cipherSuites = clusterDetails.Config.TLSCipherSuites
+			}
+			upstreamtlsContext := createUpstreamTLSContext(epCert, address, clusterDetails.HTTP2BackendEnabled,
+				cipherSuites, isTLSSkipVerifyApplied(clusterName, clusterDetails))
 			marshalledTLSContext, err := anypb.New(upstreamtlsContext)
 			if err != nil {
 				return nil, nil, errors.New("internal Error while marshalling the upstream TLS Context")
@@ -581,11 +721,6 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 			}
 		}
 		lbEPs = append(lbEPs, localityLbEndpoints)
-
-		// set priority for next endpoint
-		if strings.HasPrefix(epType, "failover") {
-			priority = priority + 1
-		}
 	}
 	conf, _ := config.ReadConfigs()
 
@@ -618,6 +753,15 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 		}
 	}
 
+	if clusterDetails.Config != nil && clusterDetails.Config.IdleTimeout != "" {
+		// the idle timeout is validated when the endpoints are parsed
+		if idleTimeout, err := time.ParseDuration(clusterDetails.Config.IdleTimeout); err == nil {
+			httpProtocolOptions.CommonHttpProtocolOptions = &corev3.HttpProtocolOptions{
+				IdleTimeout: durationpb.New(idleTimeout),
+			}
+		}
+	}
+
 	ext, err2 := proto.Marshal(httpProtocolOptions)
 	if err2 != nil {
 		logger.LoggerOasparser.Error(err2)
@@ -644,9 +788,29 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 		},
 	}
 
-	if len(clusterDetails.Endpoints) > 1 {
+	if clusterDetails.SessionAffinity != nil {
+		// The requests having the same session cookie are routed to the same endpoint by hashing the cookie
+		cluster.LbPolicy = clusterv3.Cluster_RING_HASH
+	}
+
+	if isUnixSocketCluster {
+		cluster.ClusterDiscoveryType = &clusterv3.Cluster_Type{Type: clusterv3.Cluster_STATIC}
+		cluster.DnsLookupFamily = clusterv3.Cluster_AUTO
+		cluster.DnsRefreshRate = nil
+		cluster.RespectDnsTtl = false
+	}
+
+	if clusterDetails.IsHealthChecked() {
 		cluster.HealthChecks = createHealthCheck()
 	}
+	if clusterDetails.FallbackEndpoint != nil {
+		// By default, the requests are shifted to the lower priorities as soon as a priority is less than ~70%
+		// healthy. Hence the overprovisioning factor is raised to keep the requests in a priority as long as any of
+		// its endpoints is healthy, instead of partially failing over to the fallback endpoint.
+		cluster.LoadAssignment.Policy = &endpointv3.ClusterLoadAssignment_Policy{
+			OverprovisioningFactor: wrapperspb.UInt32(fallbackOverprovisioningFactor),
+		}
+	}
 
 	if clusterDetails.Config != nil && clusterDetails.Config.CircuitBreakers != nil {
 		config := clusterDetails.Config.CircuitBreakers
@@ -700,7 +864,26 @@ func createHealthCheck() []*corev3.HealthCheck {
 	}
 }
 
-func createUpstreamTLSContext(upstreamCerts []byte, address *corev3.Address, hTTP2BackendEnabled bool) *tlsv3.UpstreamTlsContext {
+// isTLSSkipVerifyApplied returns true if the certificates of the endpoints of the cluster are not verified, as
+// requested by the tlsSkipVerify endpoint config. The verification is skipped only if it is allowed globally.
+func isTLSSkipVerifyApplied(clusterName string, clusterDetails *model.EndpointCluster) bool {
+	if clusterDetails.Config == nil || !clusterDetails.Config.TLSSkipVerify {
+		return false
+	}
+	conf, _ := config.ReadConfigs()
+	if !conf.Envoy.Upstream.TLS.AllowTLSSkipVerify {
+		logger.LoggerOasparser.Warnf("The certificates of the endpoints of the cluster %s are verified, as skipping "+
+			"the verification is not allowed by router.upstream.tls.allowTlsSkipVerify", clusterName)
+		return false
+	}
+	return true
+}
+
+// createUpstreamTLSContext creates the TLS context of an upstream endpoint. The cipher suites configured globally are
+// used, unless cipher suites are provided for the endpoint. The certificate of the endpoint is not verified if
+// skipVerify is true or the SSL verification is disabled globally.
+func createUpstreamTLSContext(upstreamCerts []byte, address *corev3.Address, hTTP2BackendEnabled bool,
+	cipherSuites []string, skipVerify bool) *tlsv3.UpstreamTlsContext {
 	conf, errReadConfig := config.ReadConfigs()
 	//TODO: (VirajSalaka) Error Handling
 	if errReadConfig != nil {
@@ -710,6 +893,9 @@ func createUpstreamTLSContext(upstreamCerts []byte, address *corev3.Address, hTT
 	tlsCert := generateTLSCert(conf.Envoy.KeyStore.KeyPath, conf.Envoy.KeyStore.CertPath)
 	// Convert the cipher string to a string array
 	ciphersArray := strings.Split(conf.Envoy.Upstream.TLS.Ciphers, ",")
+	if len(cipherSuites) > 0 {
+		ciphersArray = append([]string{}, cipherSuites...)
+	}
 	for i := range ciphersArray {
 		ciphersArray[i] = strings.TrimSpace(ciphersArray[i])
 	}
@@ -737,7 +923,8 @@ func createUpstreamTLSContext(upstreamCerts []byte, address *corev3.Address, hTT
 		sanType = tlsv3.SubjectAltNameMatcher_DNS
 	}
 
-	if !conf.Envoy.Upstream.TLS.DisableSslVerification {
+	disableSslVerification := conf.Envoy.Upstream.TLS.DisableSslVerification || skipVerify
+	if !disableSslVerification {
 		var trustedCASrc *corev3.DataSource
 
 		if len(upstreamCerts) > 0 {
@@ -761,7 +948,7 @@ func createUpstreamTLSContext(upstreamCerts []byte, address *corev3.Address, hTT
 		}
 	}
 
-	if conf.Envoy.Upstream.TLS.VerifyHostName && !conf.Envoy.Upstream.TLS.DisableSslVerification {
+	if conf.Envoy.Upstream.TLS.VerifyHostName && !disableSslVerification {
 		addressString := address.GetSocketAddress().GetAddress()
 		subjectAltNames := []*tlsv3.SubjectAltNameMatcher{
 			{
@@ -796,6 +983,25 @@ func createTLSProtocolVersion(tlsVersion string) tlsv3.TlsParameters_TlsProtocol
 // createRoutes creates route elements for the route configurations. API title, VHost, xWso2Basepath, API version,
 // endpoint's basePath, resource Object (Microgateway's internal representation), production clusterName and
 // sandbox clusterName needs to be provided.
+// createRoutesWithContextAliases creates the routes of the basepath of the API followed by the routes of each of
+// its context aliases. The routes of the context aliases point to the same clusters as the routes of the basepath.
+func createRoutesWithContextAliases(params *routeCreateParams, contextAliases []string) ([]*routev3.Route, error) {
+	routes, err := createRoutes(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, contextAlias := range contextAliases {
+		aliasParams := *params
+		aliasParams.contextAlias = contextAlias
+		aliasRoutes, err := createRoutes(&aliasParams)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, aliasRoutes...)
+	}
+	return routes, nil
+}
+
 func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error) {
 	title := params.title
 	version := params.version
@@ -813,14 +1019,13 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 	responseInterceptor := params.responseInterceptor
 	isDefaultVersion := params.isDefaultVersion
 	endpointType := params.endpointType
+	requestHeadersToStrip := generateRequestHeadersToStrip(params.requestHeadersToStrip)
 	amznResourceName := ""
 
 	if resource != nil {
 		amznResourceName = resource.GetAmznResourceName()
 	}
 
-	conf, _ := config.ReadConfigs()
-
 	logger.LoggerOasparser.Debug("creating a route....")
 	var (
 		// The following are common to all routes and does not get updated per operation
@@ -828,7 +1033,11 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 	)
 
 	basePath := strings.TrimSuffix(xWso2Basepath, "/")
-	if isDefaultVersion {
+	if params.contextAlias != "" {
+		// The routes of a context alias match the alias instead of the basepath, while the enforcer identifies the
+		// API by its basepath. The default version of the API is only served at the basepath.
+		basePath = strings.TrimSuffix(params.contextAlias, "/")
+	} else if isDefaultVersion {
 		basePath = getDefaultVersionBasepath(basePath, version)
 	}
 
@@ -841,6 +1050,7 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 		resourceMethods = resource.GetMethodList()
 	}
 	routePath := generateRoutePath(basePath, resourcePath)
+	routeName := getRouteName(params)
 
 	// route path could be empty only if there is no basePath for API or the endpoint available,
 	// and resourcePath is also an empty string.
@@ -869,6 +1079,33 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 	// to validate the key type component in the token.
 	contextExtensions[prodClusterNameContextExtension] = prodClusterName
 	contextExtensions[sandClusterNameContextExtension] = sandClusterName
+	// Only the callers with one of these roles in the roles claim of their JWT are allowed to invoke the API, which is
+	// checked by the enforcer.
+	if len(params.visibleRoles) > 0 {
+		contextExtensions[visibleRolesContextExtension] = strings.Join(params.visibleRoles, ",")
+	}
+	// The listeners only limit the size of the headers of all the APIs, hence the lower limit of the API is
+	// applied by the enforcer.
+	if params.maxRequestHeadersKb > 0 {
+		contextExtensions[maxRequestHeadersKbContextExtension] = strconv.FormatUint(uint64(params.maxRequestHeadersKb), 10)
+	}
+	// The authorization header is removed by the enforcer, as it knows the header used to authenticate the request.
+	if params.stripAuthHeader != nil {
+		contextExtensions[enableOutboundAuthHeaderContextExtension] = strconv.FormatBool(!*params.stripAuthHeader)
+	}
+	// The enforcer publishes the value of the claim in the dynamic metadata, which is read by the rate limit actions
+	// of the route.
+	if params.rateLimitKey != nil && strings.TrimSpace(params.rateLimitKey.Header) == "" {
+		contextExtensions[rateLimitClaimContextExtension] = strings.TrimSpace(params.rateLimitKey.Claim)
+	}
+	// The JWTs of the API are validated by the enforcer with the keys of the JWKS endpoint of the API.
+	if params.jwksConfig != nil {
+		contextExtensions[jwksURLContextExtension] = strings.TrimSpace(params.jwksConfig.JwksURL)
+		if params.jwksConfig.RefreshIntervalInSeconds > 0 {
+			contextExtensions[jwksRefreshIntervalContextExtension] =
+				strconv.Itoa(params.jwksConfig.RefreshIntervalInSeconds)
+		}
+	}
 
 	extAuthPerFilterConfig := extAuthService.ExtAuthzPerRoute{
 		Override: &extAuthService.ExtAuthzPerRoute_CheckSettings{
@@ -948,6 +1185,11 @@ end`
 		}
 	}
 
+	addEndpointQueryParamsLuaScript(&luaPerFilterConfig, params.endpointQueryParams, prodClusterName, sandClusterName)
+	if params.upstreamDecompression {
+		addUpstreamDecompressionLuaScript(&luaPerFilterConfig)
+	}
+
 	luaMarshelled := proto.NewBuffer(nil)
 	luaMarshelled.SetDeterministic(true)
 	_ = luaMarshelled.Marshal(&luaPerFilterConfig)
@@ -966,33 +1208,11 @@ end`
 	}
 
 	if endpointType == constants.AwsLambda {
-
-		var mode awslambdav3.Config_InvocationMode
-
-		if strings.ToUpper(conf.Envoy.AwsLambda.InvocationMode) == invocationModeSynchronous {
-			mode = awslambdav3.Config_SYNCHRONOUS
-		} else {
-			mode = awslambdav3.Config_ASYNCHRONOUS
-		}
-
-		awsLambdaPerFilterConfig := awslambdav3.PerRouteConfig{
-			InvokeConfig: &awslambdav3.Config{
-				Arn:                amznResourceName,
-				PayloadPassthrough: conf.Envoy.AwsLambda.PayloadPassthrough,
-				InvocationMode:     mode,
-			},
-		}
-
-		awsLambdaMarshelled := proto.NewBuffer(nil)
-		awsLambdaMarshelled.SetDeterministic(true)
-		_ = awsLambdaMarshelled.Marshal(&awsLambdaPerFilterConfig)
-
-		awsLambdaFilter := &any.Any{
-			TypeUrl: awsLambdaRouteName,
-			Value:   awsLambdaMarshelled.Bytes(),
-		}
-
-		perRouteFilterConfigs[awsLambdaFilterName] = awsLambdaFilter
+		perRouteFilterConfigs[awsLambdaFilterName] = generateAwsLambdaPerRouteConfig(amznResourceName)
+	}
+	if params.bufferRequest {
+		// The buffer filter is disabled in the virtual hosts, hence enabled only for the routes of the API.
+		perRouteFilterConfigs[wellknown.Buffer] = generateBufferPerRouteConfig(false)
 	}
 
 	logger.LoggerOasparser.Debug("adding route ", resourcePath)
@@ -1002,6 +1222,7 @@ end`
 
 		// Policies are per operation (HTTP method). Therefore, create route per HTTP method.
 		for _, operation := range resource.GetOperations() {
+			operationRouteCount := len(routes)
 			var requestHeadersToAdd []*corev3.HeaderValueOption
 			var requestHeadersToRemove []string
 			var responseHeadersToAdd []*corev3.HeaderValueOption
@@ -1010,6 +1231,7 @@ end`
 
 			hasMethodRewritePolicy := false
 			var newMethod string
+			passRequestPayloadToEnforcer := false
 
 			// Policies - for request flow
 			for _, requestPolicy := range operation.GetPolicies().Request {
@@ -1067,9 +1289,21 @@ end`
 					if err != nil {
 						return nil, err
 					}
+
+				case constants.ActionPayloadToXML, constants.ActionPayloadToJSON:
+					logger.LoggerOasparser.Debugf("Adding %s policy to request flow for %s %s",
+						requestPolicy.Action, resourcePath, operation.GetMethod())
+					// The request payload is transformed by the enforcer
+					passRequestPayloadToEnforcer = true
 				}
 			}
 
+			if rewritePath := operation.GetRewritePath(); rewritePath != nil {
+				logger.LoggerOasparser.Debugf("Adding the path rewrite %v -> %v for %s %s", rewritePath.Pattern,
+					rewritePath.Substitution, resourcePath, operation.GetMethod())
+				pathRewriteConfig = generateRegexRewriteForRewritePath(rewritePath)
+			}
+
 			// Policies - for response flow
 			for _, responsePolicy := range operation.GetPolicies().Response {
 				logger.LoggerOasparser.Debug("Adding response flow policies for ", resourcePath, operation.GetMethod())
@@ -1097,6 +1331,34 @@ end`
 				}
 			}
 
+			// Envoy removes the headers before adding the headers, hence the headers set by the policies are retained
+			// even if those are stripped.
+			requestHeadersToRemove = mergeHeaderNames(requestHeadersToStrip, requestHeadersToRemove)
+
+			if operation.IsDeprecated() {
+				responseHeadersToAdd = append(responseHeadersToAdd, generateDeprecationHeaderToAdd())
+			}
+			if cacheVaryHeaders := operation.GetCacheVaryHeaders(); len(cacheVaryHeaders) > 0 {
+				responseHeadersToAdd = append(responseHeadersToAdd, generateCacheVaryHeaderToAdd(cacheVaryHeaders))
+			}
+
+			operationFilterConfigs := perRouteFilterConfigs
+			if (passRequestPayloadToEnforcer && !params.passRequestPayloadToEnforcer) || operation.GetTimeout() > 0 ||
+				operation.GetMockResponseSelection() != nil || len(operation.GetAnalyticsProperties()) > 0 {
+				operationFilterConfigs = generateOperationFilterConfigs(perRouteFilterConfigs, &extAuthPerFilterConfig,
+					passRequestPayloadToEnforcer, operation.GetTimeout(), operation.GetMockResponseSelection(),
+					operation.GetAnalyticsProperties())
+			}
+			if operation.IsWebSocket() && params.bufferRequest {
+				// the upgraded connections are streamed, hence those are not buffered
+				operationFilterConfigs = copyFilterConfigs(operationFilterConfigs)
+				operationFilterConfigs[wellknown.Buffer] = generateBufferPerRouteConfig(true)
+			}
+			if statusRemaps := operation.GetStatusRemaps(); len(statusRemaps) > 0 {
+				operationFilterConfigs = copyFilterConfigs(operationFilterConfigs)
+				operationFilterConfigs[wellknown.Lua] = generateStatusRemapLuaConfig(&luaPerFilterConfig, statusRemaps)
+			}
+
 			// TODO: (suksw) preserve header key case?
 			if hasMethodRewritePolicy {
 				logger.LoggerOasparser.Debug("Creating two routes to support method rewrite for %s %s. New method: %s",
@@ -1105,7 +1367,7 @@ end`
 				match1.Headers = generateHTTPMethodMatcher(includeOptionsMethod(operation.GetMethod()), params.isSandbox,
 					sandClusterName)
 				match2 := generateRouteMatch(routePath)
-				match2.Headers = generateHTTPMethodMatcher(newMethod, params.isSandbox, sandClusterName)
+				match2.Headers = generateHTTPMethodMatcher([]string{newMethod}, params.isSandbox, sandClusterName)
 
 				//- external routes only accept requests if metadata "method-rewrite" is null
 				//- external routes adds the metadata "method-rewrite"
@@ -1114,14 +1376,20 @@ end`
 				match1.DynamicMetadata = generateMetadataMatcherForExternalRoutes()
 				metadataValue := operation.GetMethod() + "_to_" + newMethod
 				match2.DynamicMetadata = generateMetadataMatcherForInternalRoutes(metadataValue)
+				match1.QueryParameters = generateQueryParamPresentMatchers(operation.GetRequiredQueryParams())
+				match2.QueryParameters = generateQueryParamPresentMatchers(operation.GetRequiredQueryParams())
 
 				action1 := generateRouteAction(apiType, prodRouteConfig, sandRouteConfig, endpointType)
 				action2 := generateRouteAction(apiType, prodRouteConfig, sandRouteConfig, endpointType)
+				setOperationTimeout(action1, operation.GetTimeout())
+				setOperationTimeout(action2, operation.GetTimeout())
+				setOperationWebSocketUpgrade(action1, operation)
+				setOperationWebSocketUpgrade(action2, operation)
 
 				// Create route1 for current method.
 				// Do not add policies to route config. Send via enforcer
-				route1 := generateRouteConfig(xWso2Basepath+"-"+operation.GetMethod(), match1, action1, nil, decorator,
-					perRouteFilterConfigs, nil, nil, nil, nil)
+				route1 := generateRouteConfig(routeName+"-"+operation.GetMethod(), match1, action1, nil, decorator,
+					operationFilterConfigs, nil, requestHeadersToStrip, nil, nil)
 
 				// Create route2 for new method.
 				// Add all policies to route config. Do not send via enforcer.
@@ -1131,7 +1399,7 @@ end`
 					action2.Route.RegexRewrite = generateRegexMatchAndSubstitute(routePath, endpointBasepath, resourcePath)
 				}
 				configToSkipEnforcer := generateFilterConfigToSkipEnforcer()
-				route2 := generateRouteConfig(xWso2Basepath+"-"+metadataValue, match2, action2, nil, decorator,
+				route2 := generateRouteConfig(routeName+"-"+metadataValue, match2, action2, nil, decorator,
 					configToSkipEnforcer, requestHeadersToAdd, requestHeadersToRemove, responseHeadersToAdd,
 					responseHeadersToRemove)
 
@@ -1145,33 +1413,113 @@ end`
 				match.Headers = generateHTTPMethodMatcher(includeOptionsMethod(operation.GetMethod()), params.isSandbox,
 					sandClusterName)
 				match.DynamicMetadata = generateMetadataMatcherForExternalRoutes()
+				match.QueryParameters = generateQueryParamPresentMatchers(operation.GetRequiredQueryParams())
 				if pathRewriteConfig != nil {
 					action.Route.RegexRewrite = pathRewriteConfig
 				} else {
 					action.Route.RegexRewrite = generateRegexMatchAndSubstitute(routePath, endpointBasepath, resourcePath)
 				}
-				route := generateRouteConfig(xWso2Basepath, match, action, nil, decorator, perRouteFilterConfigs,
+				var metadata *corev3.Metadata
+				if awsLambda := operation.GetAwsLambdaConfig(); awsLambda != nil {
+					clusterName, found := params.awsLambdaClusterNames[awsLambda.Region]
+					if !found {
+						return nil, fmt.Errorf("AWS Lambda cluster of the region %s is not found for the operation "+
+							"%s of resource %s", awsLambda.Region, operation.GetMethod(), resourcePath)
+					}
+					operationFilterConfigs = setAwsLambdaBackend(action, operationFilterConfigs, awsLambda, clusterName)
+					metadata = generateAwsLambdaRouteMetadata(awsLambda)
+				}
+				setOperationTimeout(action, operation.GetTimeout())
+				setOperationWebSocketUpgrade(action, operation)
+				route := generateRouteConfig(routeName, match, action, metadata, decorator, operationFilterConfigs,
 					requestHeadersToAdd, requestHeadersToRemove, responseHeadersToAdd, responseHeadersToRemove)
 				routes = append(routes, route)
 			}
-
+			for _, route := range routes[operationRouteCount:] {
+				setAnalyticsRouteMetadata(route, operation.GetAnalyticsProperties())
+			}
 		}
 	} else {
 		logger.LoggerOasparser.Debug("Creating routes for resource that has no policies")
 		// No policies defined for the resource. Therefore, create one route for all operations.
-		methodRegex := strings.Join(resourceMethods, "|")
 		match := generateRouteMatch(routePath)
-		match.Headers = generateHTTPMethodMatcher(includeOptionsMethod(methodRegex), params.isSandbox, sandClusterName)
+		match.Headers = generateHTTPMethodMatcher(includeOptionsMethod(resourceMethods...), params.isSandbox, sandClusterName)
 		action := generateRouteAction(apiType, prodRouteConfig, sandRouteConfig, endpointType)
 		action.Route.RegexRewrite = generateRegexMatchAndSubstitute(routePath, endpointBasepath, resourcePath)
 
-		route := generateRouteConfig(xWso2Basepath, match, action, nil, decorator, perRouteFilterConfigs,
-			nil, nil, nil, nil) // general headers to add and remove are included in this methods
+		route := generateRouteConfig(routeName, match, action, nil, decorator, perRouteFilterConfigs,
+			nil, requestHeadersToStrip, nil, nil) // general headers to add and remove are included in this methods
 		routes = append(routes, route)
 	}
+
+	if rateLimits := generateRateLimits(params.apiUUID, params.rateLimitKey); rateLimits != nil {
+		for _, route := range routes {
+			route.GetRoute().RateLimits = rateLimits
+		}
+	}
+	if params.localRateLimit != nil {
+		// The token bucket of the API is in the virtual host, as the token buckets of the routes are not shared.
+		for _, route := range routes {
+			route.GetRoute().RateLimits = append(route.GetRoute().RateLimits, generateLocalRateLimits(params.apiUUID)...)
+		}
+	}
+	if hashPolicies := generateSessionAffinityHashPolicies(params.sessionAffinity); hashPolicies != nil {
+		for _, route := range routes {
+			route.GetRoute().HashPolicy = hashPolicies
+		}
+	}
+	if len(params.responseHeaders) > 0 {
+		for _, route := range routes {
+			// The headers of the API are added before the headers of the operation policies, hence the policies can
+			// overwrite those.
+			route.ResponseHeadersToAdd = append(generateAPIResponseHeadersToAdd(params.responseHeaders),
+				route.ResponseHeadersToAdd...)
+		}
+	}
+	if params.upstreamDecompression {
+		bufferLimit := getUpstreamDecompressionBufferLimit(params.bufferRequest)
+		for _, route := range routes {
+			// The decompressor filter is enabled only for the responses having the upstream decompression header,
+			// and the decompressed responses buffered by the filters are limited by the buffer limit of the route.
+			// The header is removed by the Lua filter once the responses are decompressed.
+			route.ResponseHeadersToAdd = append(route.ResponseHeadersToAdd, &corev3.HeaderValueOption{
+				Header: &corev3.HeaderValue{
+					Key:   upstreamDecompressionHeader,
+					Value: upstreamDecompressionEnabled,
+				},
+				AppendAction: *corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD.Enum(),
+			})
+			if route.PerRequestBufferLimitBytes.GetValue() < bufferLimit {
+				route.PerRequestBufferLimitBytes = wrapperspb.UInt32(bufferLimit)
+			}
+		}
+	}
+	if params.http3 {
+		altSvcHeader := generateAltSvcHeaderToAdd()
+		for _, route := range routes {
+			route.ResponseHeadersToAdd = append(route.ResponseHeadersToAdd, altSvcHeader)
+		}
+	}
+	for _, route := range routes {
+		setKeyTypeRouteMetadata(route, prodClusterName, sandClusterName)
+	}
 	return routes, nil
 }
 
+// generateAltSvcHeaderToAdd generates the Alt-Svc response header advertising that the API is served over HTTP/3 on
+// the port of the secured listener. The Alt-Svc header sent by the backend is overwritten.
+func generateAltSvcHeaderToAdd() *corev3.HeaderValueOption {
+	conf, _ := config.ReadConfigs()
+	return &corev3.HeaderValueOption{
+		Header: &corev3.HeaderValue{
+			Key: altSvcHeader,
+			Value: fmt.Sprintf("h3=\":%d\"; ma=%d", conf.Envoy.SecuredListenerPort,
+				conf.Envoy.HTTP3.AltSvcMaxAgeInSeconds),
+		},
+		AppendAction: *corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD.Enum(),
+	}
+}
+
 func getInlineLuaScript(requestInterceptor map[string]model.InterceptEndpoint, responseInterceptor map[string]model.InterceptEndpoint,
 	requestContext *interceptor.InvocationContext) string {
 
@@ -1377,6 +1725,174 @@ func CreateHealthEndpoint() *routev3.Route {
 	return &router
 }
 
+// createNotFoundFallbackRoute generates a route matching any sub-path of the API context, which replies
+// with the configured response. It needs to be evaluated after all the operation routes of the vhost.
+func createNotFoundFallbackRoute(basePath string, notFoundResponse *model.NotFoundResponseConfig) *routev3.Route {
+	routePath := generateRoutePath(basePath, "/*")
+
+	perFilterConfig := extAuthService.ExtAuthzPerRoute{
+		Override: &extAuthService.ExtAuthzPerRoute_Disabled{
+			Disabled: true,
+		},
+	}
+	filter := marshalFilterConfig(&perFilterConfig)
+
+	router := routev3.Route{
+		Name:  notFoundFallbackRouteNamePrefix + basePath,
+		Match: generateRouteMatch(routePath),
+		Action: &routev3.Route_DirectResponse{
+			DirectResponse: &routev3.DirectResponseAction{
+				Status: notFoundResponse.StatusCode,
+				Body: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineString{
+						InlineString: notFoundResponse.Body,
+					},
+				},
+			},
+		},
+		ResponseHeadersToAdd: []*corev3.HeaderValueOption{
+			{
+				Header: &corev3.HeaderValue{
+					Key:   contentTypeHeaderName,
+					Value: notFoundResponse.ContentType,
+				},
+				AppendAction: *corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD.Enum(),
+			},
+		},
+		Decorator: &routev3.Decorator{
+			Operation: basePath,
+		},
+		TypedPerFilterConfig: map[string]*any.Any{
+			wellknown.HTTPExternalAuthorization: filter,
+		},
+	}
+	return &router
+}
+
+// createMethodNotAllowedRoute creates the route which replies with 405 for the requests matching the path of the
+// resource, but none of its methods. The route has to be evaluated after all the routes of the vhost, as the same
+// path could be matched by a resource of another API with the requested method.
+// Returns nil if an operation of the resource requires query parameters, as a request missing those
+// is not a method mismatch.
+func createMethodNotAllowedRoute(mgwSwagger *model.MgwSwagger, resource *model.Resource, contextAlias string) *routev3.Route {
+	for _, operation := range resource.GetOperations() {
+		if len(operation.GetRequiredQueryParams()) > 0 {
+			return nil
+		}
+	}
+	basePath := strings.TrimSuffix(mgwSwagger.GetXWso2Basepath(), "/")
+	if contextAlias != "" {
+		basePath = strings.TrimSuffix(contextAlias, "/")
+	} else if mgwSwagger.IsDefaultVersion {
+		basePath = getDefaultVersionBasepath(basePath, mgwSwagger.GetVersion())
+	}
+	routePath := generateRoutePath(basePath, resource.GetPath())
+
+	perFilterConfig := extAuthService.ExtAuthzPerRoute{
+		Override: &extAuthService.ExtAuthzPerRoute_Disabled{
+			Disabled: true,
+		},
+	}
+	filter := marshalFilterConfig(&perFilterConfig)
+
+	router := routev3.Route{
+		Name:  methodNotAllowedRouteNamePrefix + routePath,
+		Match: generateRouteMatch(routePath),
+		Action: &routev3.Route_DirectResponse{
+			DirectResponse: &routev3.DirectResponseAction{
+				Status: 405,
+				Body: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineString{
+						InlineString: methodNotAllowedBody,
+					},
+				},
+			},
+		},
+		ResponseHeadersToAdd: []*corev3.HeaderValueOption{
+			{
+				Header: &corev3.HeaderValue{
+					Key:   allowHeaderName,
+					Value: strings.Join(includeOptionsMethod(resource.GetMethodList()...), ", "),
+				},
+				AppendAction: *corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD.Enum(),
+			},
+			{
+				Header: &corev3.HeaderValue{
+					Key:   contentTypeHeaderName,
+					Value: methodNotAllowedContentType,
+				},
+				AppendAction: *corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD.Enum(),
+			},
+		},
+		Decorator: &routev3.Decorator{
+			Operation: routePath,
+		},
+		TypedPerFilterConfig: map[string]*any.Any{
+			wellknown.HTTPExternalAuthorization: filter,
+		},
+	}
+	return &router
+}
+
+// CreateVhostCatchAllRoute creates the route which replies with the configured response for the requests of the
+// vhost matching none of its routes. Hence the route has to be the last route of the vhost.
+func CreateVhostCatchAllRoute(vHost string) *routev3.Route {
+	conf, _ := config.ReadConfigs()
+	catchAllConfig := conf.Envoy.UnmatchedRequests.VhostCatchAll
+
+	perFilterConfig := extAuthService.ExtAuthzPerRoute{
+		Override: &extAuthService.ExtAuthzPerRoute_Disabled{
+			Disabled: true,
+		},
+	}
+	filter := marshalFilterConfig(&perFilterConfig)
+
+	router := routev3.Route{
+		Name: vhostCatchAllRouteNamePrefix + vHost,
+		Match: &routev3.RouteMatch{
+			PathSpecifier: &routev3.RouteMatch_Prefix{
+				Prefix: "/",
+			},
+		},
+		Action: &routev3.Route_DirectResponse{
+			DirectResponse: &routev3.DirectResponseAction{
+				Status: catchAllConfig.StatusCode,
+				Body: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineString{
+						InlineString: catchAllConfig.Body,
+					},
+				},
+			},
+		},
+		ResponseHeadersToAdd: []*corev3.HeaderValueOption{
+			{
+				Header: &corev3.HeaderValue{
+					Key:   contentTypeHeaderName,
+					Value: catchAllConfig.ContentType,
+				},
+				AppendAction: *corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD.Enum(),
+			},
+		},
+		Decorator: &routev3.Decorator{
+			Operation: vHost + ":/*",
+		},
+		TypedPerFilterConfig: map[string]*any.Any{
+			wellknown.HTTPExternalAuthorization: filter,
+		},
+	}
+	return &router
+}
+
+// IsMethodNotAllowedRoute returns true if the route is the route generated to reply with 405 for a resource.
+func IsMethodNotAllowedRoute(route *routev3.Route) bool {
+	return strings.HasPrefix(route.GetName(), methodNotAllowedRouteNamePrefix)
+}
+
+// IsNotFoundFallbackRoute returns true if the route is the fallback route generated for unmatched sub-paths of an API.
+func IsNotFoundFallbackRoute(route *routev3.Route) bool {
+	return strings.HasPrefix(route.GetName(), notFoundFallbackRouteNamePrefix)
+}
+
 // CreateReadyEndpoint generates a route for the router /ready endpoint
 // Replies with direct response.
 func CreateReadyEndpoint() *routev3.Route {
@@ -1432,9 +1948,18 @@ func CreateReadyEndpoint() *routev3.Route {
 	return &router
 }
 
-// generateRoutePath generates route paths for the api resources.
-// TODO: (VirajSalaka) Improve regex specifically for strings, integers etc.
+// generateRoutePath generates route paths for the api resources. The route paths are cached, as the same path
+// templates are repeated in the APIs and their deployments.
 func generateRoutePath(basePath, resourcePath string) string {
+	key := normalizePathTemplate(basePath) + "\x00" + normalizePathTemplate(resourcePath)
+	return routeRegexes.getOrGenerate(key, func() string {
+		return generateRouteRegex(basePath, resourcePath)
+	})
+}
+
+// generateRouteRegex generates the route regex matching the resource path of the API.
+// TODO: (VirajSalaka) Improve regex specifically for strings, integers etc.
+func generateRouteRegex(basePath, resourcePath string) string {
 	trailingSlashRegex := "[/]{0,1}"
 	if strings.Contains(resourcePath, "?") {
 		resourcePath = strings.Split(resourcePath, "?")[0]
@@ -1448,11 +1973,13 @@ func generateRoutePath(basePath, resourcePath string) string {
 	return "^" + newPath
 }
 
+// pathParamRegex matches the path parameters of a path template, capturing the name of the path parameter
+var pathParamRegex = regexp.MustCompile(`{([^}]+)}`)
+
 // replacePathParamsWithCaptureGroups updates paths like /pet/{petId} to /pet/([^/]+)
 func replacePathParamsWithCaptureGroups(resourcePath string) string {
 	pathParaRegex := "([^/]+)"
-	matcher := regexp.MustCompile(`{([^}]+)}`)
-	resourceRegex := matcher.ReplaceAllString(resourcePath, pathParaRegex)
+	resourceRegex := pathParamRegex.ReplaceAllString(resourcePath, pathParaRegex)
 	return resourceRegex
 }
 
@@ -1590,6 +2117,8 @@ func genRouteCreateParams(swagger *model.MgwSwagger, resource *model.Resource, v
 	responseInterceptor map[string]model.InterceptEndpoint, organizationID string, isSandbox bool) *routeCreateParams {
 	params := &routeCreateParams{
 		organizationID:               organizationID,
+		apiUUID:                      getAPIUUIDForGeneratedNames(swagger),
+		apiKey:                       getAPIKeyForGeneratedNames(swagger, vHost),
 		title:                        swagger.GetTitle(),
 		apiType:                      swagger.GetAPIType(),
 		version:                      swagger.GetVersion(),
@@ -1607,6 +2136,18 @@ func genRouteCreateParams(swagger *model.MgwSwagger, resource *model.Resource, v
 		isDefaultVersion:             swagger.IsDefaultVersion,
 		isSandbox:                    isSandbox,
 		endpointType:                 swagger.GetEndpointType(),
+		visibleRoles:                 getRolesAllowedToInvoke(swagger),
+		requestHeadersToStrip:        swagger.GetXWso2StripRequestHeaders(),
+		stripAuthHeader:              swagger.GetStripAuthHeader(),
+		rateLimitKey:                 swagger.GetRateLimitKey(),
+		maxRequestHeadersKb:          swagger.GetMaxRequestHeadersKb(),
+		jwksConfig:                   swagger.GetJwksConfig(),
+		sessionAffinity:              swagger.GetSessionAffinity(),
+		bufferRequest:                swagger.IsRequestBufferingEnabled(),
+		localRateLimit:               swagger.GetLocalRateLimit(),
+		responseHeaders:              swagger.GetResponseHeaders(),
+		upstreamDecompression:        swagger.IsUpstreamDecompressionEnabled(),
+		http3:                        swagger.IsHTTP3Enabled(),
 	}
 
 	if swagger.GetProdEndpoints() != nil {
@@ -1618,6 +2159,15 @@ func genRouteCreateParams(swagger *model.MgwSwagger, resource *model.Resource, v
 	return params
 }
 
+// getRolesAllowedToInvoke returns the roles which are allowed to invoke the API. Roles are checked only for the
+// APIs with RESTRICTED visibility, hence nil is returned for PUBLIC and PRIVATE APIs.
+func getRolesAllowedToInvoke(swagger *model.MgwSwagger) []string {
+	if swagger.GetVisibility() != constants.RestrictedVisibility {
+		return nil
+	}
+	return swagger.GetVisibleRoles()
+}
+
 // createAddress generates an address from the given host and port
 func createAddress(remoteHost string, port uint32) *corev3.Address {
 	address := corev3.Address{Address: &corev3.Address_SocketAddress{
@@ -1632,6 +2182,15 @@ func createAddress(remoteHost string, port uint32) *corev3.Address {
 	return &address
 }
 
+// createPipeAddress creates the address of a unix domain socket
+func createPipeAddress(socketPath string) *corev3.Address {
+	return &corev3.Address{Address: &corev3.Address_Pipe{
+		Pipe: &corev3.Pipe{
+			Path: socketPath,
+		},
+	}}
+}
+
 // getMaxStreamDuration configures a maximum duration for a websocket route.
 func getMaxStreamDuration(apiType string) *routev3.RouteAction_MaxStreamDuration {
 	var maxStreamDuration *routev3.RouteAction_MaxStreamDuration = nil
@@ -1687,8 +2246,7 @@ func createInterceptorAPIClusters(mgwSwagger model.MgwSwagger, interceptorCerts
 	// if lua filter exists on api level, add cluster
 	if apiRequestInterceptor.Enable {
 		logger.LoggerOasparser.Debugf("API level request interceptors found for %v : %v", apiTitle, apiVersion)
-		apiRequestInterceptor.ClusterName = getClusterName(requestInterceptClustersNamePrefix, organizationID, vHost,
-			apiTitle, apiVersion, "")
+		apiRequestInterceptor.ClusterName = getClusterName(&mgwSwagger, requestInterceptClustersNamePrefix, organizationID, vHost, "")
 		cluster, addresses, err := CreateLuaCluster(interceptorCerts, apiRequestInterceptor)
 		if err != nil {
 			apiRequestInterceptor = model.InterceptEndpoint{}
@@ -1703,8 +2261,8 @@ func createInterceptorAPIClusters(mgwSwagger model.MgwSwagger, interceptorCerts
 	// if lua filter exists on api level, add cluster
 	if apiResponseInterceptor.Enable {
 		logger.LoggerOasparser.Debugln("API level response interceptors found for " + mgwSwagger.GetID())
-		apiResponseInterceptor.ClusterName = getClusterName(responseInterceptClustersNamePrefix, organizationID, vHost,
-			apiTitle, apiVersion, "")
+		apiResponseInterceptor.ClusterName = getClusterName(&mgwSwagger, responseInterceptClustersNamePrefix, organizationID,
+			vHost, "")
 		cluster, addresses, err := CreateLuaCluster(interceptorCerts, apiResponseInterceptor)
 		if err != nil {
 			apiResponseInterceptor = model.InterceptEndpoint{}
@@ -1731,8 +2289,8 @@ func createInterceptorResourceClusters(mgwSwagger model.MgwSwagger, interceptorC
 	reqInterceptorVal := mgwSwagger.GetInterceptor(resource.GetVendorExtensions(), xWso2requestInterceptor, ResourceLevelInterceptor)
 	if reqInterceptorVal.Enable {
 		logger.LoggerOasparser.Debugf("Resource level request interceptors found for %v:%v-%v", apiTitle, apiVersion, resource.GetPath())
-		reqInterceptorVal.ClusterName = getClusterName(requestInterceptClustersNamePrefix, organizationID, vHost,
-			apiTitle, apiVersion, resource.GetID())
+		reqInterceptorVal.ClusterName = getClusterName(&mgwSwagger, requestInterceptClustersNamePrefix, organizationID,
+			vHost, resource.GetID())
 		cluster, addresses, err := CreateLuaCluster(interceptorCerts, reqInterceptorVal)
 		if err != nil {
 			logger.LoggerOasparser.Errorf("Error while adding resource level request intercept external cluster for %s. %v",
@@ -1751,7 +2309,7 @@ func createInterceptorResourceClusters(mgwSwagger model.MgwSwagger, interceptorC
 			logger.LoggerOasparser.Debugf("Operation level request interceptors found for %v:%v-%v-%v", apiTitle, apiVersion, resource.GetPath(),
 				opI.ClusterName)
 			opID := opI.ClusterName
-			opI.ClusterName = getClusterName(requestInterceptClustersNamePrefix, organizationID, vHost, apiTitle, apiVersion, opID)
+			opI.ClusterName = getClusterName(&mgwSwagger, requestInterceptClustersNamePrefix, organizationID, vHost, opID)
 			operationalReqInterceptors[method] = opI // since cluster name is updated
 			cluster, addresses, err := CreateLuaCluster(interceptorCerts, opI)
 			if err != nil {
@@ -1770,8 +2328,8 @@ func createInterceptorResourceClusters(mgwSwagger model.MgwSwagger, interceptorC
 	respInterceptorVal := mgwSwagger.GetInterceptor(resource.GetVendorExtensions(), xWso2responseInterceptor, ResourceLevelInterceptor)
 	if respInterceptorVal.Enable {
 		logger.LoggerOasparser.Debugf("Resource level response interceptors found for %v:%v-%v"+apiTitle, apiVersion, resource.GetPath())
-		respInterceptorVal.ClusterName = getClusterName(responseInterceptClustersNamePrefix, organizationID,
-			vHost, apiTitle, apiVersion, resource.GetID())
+		respInterceptorVal.ClusterName = getClusterName(&mgwSwagger, responseInterceptClustersNamePrefix, organizationID,
+			vHost, resource.GetID())
 		cluster, addresses, err := CreateLuaCluster(interceptorCerts, respInterceptorVal)
 		if err != nil {
 			logger.LoggerOasparser.Errorf("Error while adding resource level response intercept external cluster for %s. %v",
@@ -1791,7 +2349,7 @@ func createInterceptorResourceClusters(mgwSwagger model.MgwSwagger, interceptorC
 			logger.LoggerOasparser.Debugf("Operational level response interceptors found for %v:%v-%v-%v", apiTitle, apiVersion, resource.GetPath(),
 				opI.ClusterName)
 			opID := opI.ClusterName
-			opI.ClusterName = getClusterName(responseInterceptClustersNamePrefix, organizationID, vHost, apiTitle, apiVersion, opID)
+			opI.ClusterName = getClusterName(&mgwSwagger, responseInterceptClustersNamePrefix, organizationID, vHost, opID)
 			operationalRespInterceptorVal[method] = opI // since cluster name is updated
 			cluster, addresses, err := CreateLuaCluster(interceptorCerts, opI)
 			if err != nil {
@@ -1808,9 +2366,335 @@ func createInterceptorResourceClusters(mgwSwagger model.MgwSwagger, interceptorC
 	return clusters, endpoints, &operationalReqInterceptors, &operationalRespInterceptorVal
 }
 
-func includeOptionsMethod(methodRegex string) string {
-	if !strings.Contains(methodRegex, "OPTIONS") {
-		return methodRegex + "|OPTIONS"
+func includeOptionsMethod(methods ...string) []string {
+	for _, method := range methods {
+		if method == "OPTIONS" {
+			return methods
+		}
+	}
+	return append(append([]string{}, methods...), "OPTIONS")
+}
+
+// generateAwsLambdaPerRouteConfig returns the AWS Lambda filter configuration of a route invoking the given function.
+func generateAwsLambdaPerRouteConfig(functionARN string) *any.Any {
+	conf, _ := config.ReadConfigs()
+	var mode awslambdav3.Config_InvocationMode
+
+	if strings.ToUpper(conf.Envoy.AwsLambda.InvocationMode) == invocationModeSynchronous {
+		mode = awslambdav3.Config_SYNCHRONOUS
+	} else {
+		mode = awslambdav3.Config_ASYNCHRONOUS
+	}
+
+	awsLambdaPerFilterConfig := awslambdav3.PerRouteConfig{
+		InvokeConfig: &awslambdav3.Config{
+			Arn:                functionARN,
+			PayloadPassthrough: conf.Envoy.AwsLambda.PayloadPassthrough,
+			InvocationMode:     mode,
+		},
+	}
+
+	awsLambdaMarshelled := proto.NewBuffer(nil)
+	awsLambdaMarshelled.SetDeterministic(true)
+	_ = awsLambdaMarshelled.Marshal(&awsLambdaPerFilterConfig)
+
+	return &any.Any{
+		TypeUrl: awsLambdaRouteName,
+		Value:   awsLambdaMarshelled.Bytes(),
+	}
+}
+
+// generateBufferPerRouteConfig generates the buffer filter configuration which disables the buffering, or buffers
+// the requests up to the configured maximum size.
+func generateBufferPerRouteConfig(disabled bool) *any.Any {
+	bufferPerRouteConfig := &bufferv3.BufferPerRoute{}
+	if disabled {
+		bufferPerRouteConfig.Override = &bufferv3.BufferPerRoute_Disabled{Disabled: true}
+	} else {
+		conf, _ := config.ReadConfigs()
+		bufferPerRouteConfig.Override = &bufferv3.BufferPerRoute_Buffer{
+			Buffer: &bufferv3.Buffer{
+				MaxRequestBytes: wrapperspb.UInt32(conf.Envoy.Filters.Buffer.MaxRequestBytes),
+			},
+		}
+	}
+
+	bufferMarshalled := proto.NewBuffer(nil)
+	bufferMarshalled.SetDeterministic(true)
+	_ = bufferMarshalled.Marshal(bufferPerRouteConfig)
+
+	return &any.Any{
+		TypeUrl: bufferPerRouteName,
+		Value:   bufferMarshalled.Bytes(),
+	}
+}
+
+// CreateLocalRateLimitDescriptor returns the local rate limit descriptor of the API, whose token bucket allows the
+// requests per unit and the burst of the API, and is refilled with the requests per unit. The descriptor is matched by
+// the rate limit actions of all the routes of the API, hence the routes share the token bucket. Nil is returned if the
+// API is not rate limited locally.
+func CreateLocalRateLimitDescriptor(mgwSwagger *model.MgwSwagger) *rlv3.LocalRateLimitDescriptor {
+	localRateLimit := mgwSwagger.GetLocalRateLimit()
+	if localRateLimit == nil {
+		return nil
+	}
+	return &rlv3.LocalRateLimitDescriptor{
+		Entries: []*rlv3.RateLimitDescriptor_Entry{{
+			Key:   rateLimitAPIDescriptorKey,
+			Value: getAPIUUIDForGeneratedNames(mgwSwagger),
+		}},
+		TokenBucket: &typev3.TokenBucket{
+			MaxTokens:     uint32(localRateLimit.RequestsPerUnit + localRateLimit.Burst),
+			TokensPerFill: wrapperspb.UInt32(uint32(localRateLimit.RequestsPerUnit)),
+			FillInterval:  durationpb.New(localRateLimit.GetFillInterval()),
+		},
+	}
+}
+
+// generateLocalRateLimitVhostConfig generates the local rate limit filter configuration of a virtual host, having the
+// descriptors of the locally rate limited APIs of the virtual host. The routes of the other APIs do not produce a
+// descriptor, hence those are only limited by the default token bucket, which is not exhausted in practice.
+func generateLocalRateLimitVhostConfig(descriptors []*rlv3.LocalRateLimitDescriptor) *any.Any {
+	localRateLimit := &local_rate_limitv3.LocalRateLimit{
+		StatPrefix: localRateLimitStatPrefix,
+		TokenBucket: &typev3.TokenBucket{
+			MaxTokens:     math.MaxUint32,
+			TokensPerFill: wrapperspb.UInt32(math.MaxUint32),
+			// fill intervals of the descriptors should be multiples of the fill interval of the default bucket
+			FillInterval: durationpb.New(time.Second),
+		},
+		Descriptors: descriptors,
+		Stage:       localRateLimitStage,
+		FilterEnabled: &corev3.RuntimeFractionalPercent{
+			DefaultValue: &typev3.FractionalPercent{
+				Numerator:   100,
+				Denominator: typev3.FractionalPercent_HUNDRED,
+			},
+			RuntimeKey: apiLocalRateLimitEnabledRuntimeKey,
+		},
+		FilterEnforced: &corev3.RuntimeFractionalPercent{
+			DefaultValue: &typev3.FractionalPercent{
+				Numerator:   100,
+				Denominator: typev3.FractionalPercent_HUNDRED,
+			},
+			RuntimeKey: apiLocalRateLimitEnforcedRuntimeKey,
+		},
+	}
+
+	localRateLimitMarshalled := proto.NewBuffer(nil)
+	localRateLimitMarshalled.SetDeterministic(true)
+	_ = localRateLimitMarshalled.Marshal(localRateLimit)
+
+	return &any.Any{
+		TypeUrl: localRateLimitPerRouteName,
+		Value:   localRateLimitMarshalled.Bytes(),
+	}
+}
+
+// setAwsLambdaBackend routes the requests of an operation to the Lambda cluster of the region of the function,
+// instead of the cluster selected by the enforcer. The path is not rewritten as the AWS Lambda filter replaces it
+// with the invocation path of the function. Returns the per route filter configurations of the operation, which
+// include the AWS Lambda filter configuration.
+func setAwsLambdaBackend(action *routev3.Route_Route, perRouteFilterConfigs map[string]*any.Any,
+	awsLambda *model.AwsLambdaConfig, clusterName string) map[string]*any.Any {
+	action.Route.ClusterSpecifier = &routev3.RouteAction_Cluster{
+		Cluster: clusterName,
+	}
+	action.Route.HostRewriteSpecifier = nil
+	action.Route.RegexRewrite = nil
+	if awsLambda.TimeoutInMillis > 0 {
+		action.Route.Timeout = durationpb.New(time.Duration(awsLambda.TimeoutInMillis) * time.Millisecond)
+	}
+
+	// The filter configurations are shared by the routes of the resource, hence those are copied
+	operationFilterConfigs := make(map[string]*any.Any, len(perRouteFilterConfigs)+1)
+	for filterName, filterConfig := range perRouteFilterConfigs {
+		operationFilterConfigs[filterName] = filterConfig
+	}
+	operationFilterConfigs[awsLambdaFilterName] = generateAwsLambdaPerRouteConfig(awsLambda.FunctionARN)
+	return operationFilterConfigs
+}
+
+// generateOperationFilterConfigs returns a copy of the filter configurations of the route, where the ext_authz filter
+// buffers the request payload and passes it to the enforcer if passRequestPayload is set, and passes the timeout of
+// the operation, the mocked responses selected by a request header and the analytics properties of the operation to
+// the enforcer if those are set.
+func generateOperationFilterConfigs(perRouteFilterConfigs map[string]*any.Any,
+	extAuthzConfig *extAuthService.ExtAuthzPerRoute, passRequestPayload bool, timeout time.Duration,
+	mockResponseSelection *model.MockResponseSelection, analyticsProperties map[string]string) map[string]*any.Any {
+	operationExtAuthzConfig := proto.Clone(extAuthzConfig).(*extAuthService.ExtAuthzPerRoute)
+	contextExtensions := operationExtAuthzConfig.GetCheckSettings().ContextExtensions
+	if passRequestPayload {
+		operationExtAuthzConfig.GetCheckSettings().DisableRequestBodyBuffering = false
+	}
+	if timeout > 0 {
+		contextExtensions[operationTimeoutContextExtension] = strconv.FormatInt(timeout.Milliseconds(), 10)
+	}
+	if mockResponseSelection != nil {
+		// header names are received in lower case by the enforcer
+		contextExtensions[mockSelectorHeaderContextExtension] = strings.ToLower(mockResponseSelection.Header)
+		for value := range mockResponseSelection.Responses {
+			contextExtensions[mockResponseContextExtensionPrefix+value] = mockResponseSelection.GetPreference(value)
+		}
+	}
+	for key, value := range analyticsProperties {
+		contextExtensions[analyticsPropertyContextExtensionPrefix+key] = value
+	}
+
+	b := proto.NewBuffer(nil)
+	b.SetDeterministic(true)
+	_ = b.Marshal(operationExtAuthzConfig)
+
+	operationFilterConfigs := make(map[string]*any.Any, len(perRouteFilterConfigs))
+	for filterName, filterConfig := range perRouteFilterConfigs {
+		operationFilterConfigs[filterName] = filterConfig
+	}
+	operationFilterConfigs[wellknown.HTTPExternalAuthorization] = &any.Any{
+		TypeUrl: extAuthzPerRouteName,
+		Value:   b.Bytes(),
+	}
+	return operationFilterConfigs
+}
+
+// setOperationTimeout sets the timeout of the operation as the route timeout, which takes precedence over the API and
+// endpoint timeouts. The route timeout is not changed if the timeout of the operation is not set.
+func setOperationTimeout(action *routev3.Route_Route, timeout time.Duration) {
+	if timeout > 0 {
+		action.Route.Timeout = durationpb.New(timeout)
+	}
+}
+
+// setOperationWebSocketUpgrade enables the WebSocket upgrades in the route of an operation having the
+// x-wso2-websocket extension. The upgraded connections are proxied to the cluster of the API as in the other
+// operations, and those are kept open as in the routes of the WebSocket APIs unless the operation gives an idle timeout.
+func setOperationWebSocketUpgrade(action *routev3.Route_Route, operation *model.Operation) {
+	if !operation.IsWebSocket() {
+		return
+	}
+	action.Route.UpgradeConfigs = getUpgradeConfig(constants.WS)
+	action.Route.MaxStreamDuration = getMaxStreamDuration(constants.WS)
+	if idleTimeout := operation.GetWebSocketIdleTimeout(); idleTimeout > 0 {
+		action.Route.IdleTimeout = durationpb.New(idleTimeout)
+	}
+}
+
+// copyFilterConfigs returns a shallow copy of the per route filter configs, to be modified for a single route.
+func copyFilterConfigs(filterConfigs map[string]*any.Any) map[string]*any.Any {
+	copiedConfigs := make(map[string]*any.Any, len(filterConfigs))
+	for filterName, filterConfig := range filterConfigs {
+		copiedConfigs[filterName] = filterConfig
+	}
+	return copiedConfigs
+}
+
+// generateAwsLambdaRouteMetadata returns the route metadata describing how the invocations of the function are signed.
+// The access keys are given as the references to the secrets, which are resolved by the components signing the
+// invocations, hence the secrets are not included in the configuration.
+func generateAwsLambdaRouteMetadata(awsLambda *model.AwsLambdaConfig) *corev3.Metadata {
+	fields := map[string]*structpb.Value{
+		"functionArn":  structpb.NewStringValue(awsLambda.FunctionARN),
+		"region":       structpb.NewStringValue(awsLambda.Region),
+		"accessMethod": structpb.NewStringValue(awsLambda.AccessMethod),
+	}
+	if awsLambda.AccessMethod == constants.AwsLambdaStored {
+		fields["accessKey"] = structpb.NewStringValue(awsLambda.AccessKey)
+		fields["secretKey"] = structpb.NewStringValue(awsLambda.SecretKey)
+	} else if awsLambda.RoleARN != "" {
+		fields["roleArn"] = structpb.NewStringValue(awsLambda.RoleARN)
+		fields["roleSessionName"] = structpb.NewStringValue(awsLambda.RoleSessionName)
+	}
+	return &corev3.Metadata{
+		FilterMetadata: map[string]*structpb.Struct{
+			awsLambdaMetadataNamespace: {Fields: fields},
+		},
+	}
+}
+
+// setKeyTypeRouteMetadata adds the route metadata naming the clusters serving each key type of the route, so that
+// the router level filters can act on the key type. The header selecting the cluster is included if the cluster of
+// the route is selected dynamically. The metadata does not affect the routing decisions.
+func setKeyTypeRouteMetadata(route *routev3.Route, prodClusterName, sandClusterName string) {
+	fields := make(map[string]*structpb.Value)
+	if prodClusterName != "" {
+		fields[keyTypeProduction] = structpb.NewStringValue(prodClusterName)
+	}
+	if sandClusterName != "" {
+		fields[keyTypeSandbox] = structpb.NewStringValue(sandClusterName)
+	}
+	if clusterHeader := route.GetRoute().GetClusterHeader(); clusterHeader != "" {
+		fields[clusterHeaderMetadataKey] = structpb.NewStringValue(clusterHeader)
+	}
+	if len(fields) == 0 {
+		return
+	}
+	if route.Metadata == nil {
+		route.Metadata = &corev3.Metadata{}
+	}
+	if route.Metadata.FilterMetadata == nil {
+		route.Metadata.FilterMetadata = make(map[string]*structpb.Struct)
+	}
+	route.Metadata.FilterMetadata[keyTypeMetadataNamespace] = &structpb.Struct{Fields: fields}
+}
+
+// setAnalyticsRouteMetadata adds the analytics properties of the operation to the route metadata, so that the
+// properties are available to the access logs of the router.
+func setAnalyticsRouteMetadata(route *routev3.Route, analyticsProperties map[string]string) {
+	if len(analyticsProperties) == 0 {
+		return
+	}
+	fields := make(map[string]*structpb.Value, len(analyticsProperties))
+	for key, value := range analyticsProperties {
+		fields[key] = structpb.NewStringValue(value)
+	}
+	if route.Metadata == nil {
+		route.Metadata = &corev3.Metadata{}
+	}
+	if route.Metadata.FilterMetadata == nil {
+		route.Metadata.FilterMetadata = make(map[string]*structpb.Struct)
+	}
+	route.Metadata.FilterMetadata[analyticsMetadataNamespace] = &structpb.Struct{Fields: fields}
+}
+
+// addClusterKeyTypes records the key types served by the production and sandbox clusters of a route. The same
+// cluster serves both the key types if the sandbox endpoints are the same as the production endpoints.
+func addClusterKeyTypes(clusterKeyTypes map[string][]string, prodClusterName, sandClusterName string) {
+	addKeyType := func(clusterName, keyType string) {
+		if clusterName == "" {
+			return
+		}
+		for _, existingKeyType := range clusterKeyTypes[clusterName] {
+			if existingKeyType == keyType {
+				return
+			}
+		}
+		clusterKeyTypes[clusterName] = append(clusterKeyTypes[clusterName], keyType)
+	}
+	addKeyType(prodClusterName, keyTypeProduction)
+	addKeyType(sandClusterName, keyTypeSandbox)
+}
+
+// setClusterKeyTypeMetadata tags the clusters with the key types they serve, as recorded by addClusterKeyTypes. The
+// clusters which are not used as the production or sandbox cluster of a route are not tagged.
+func setClusterKeyTypeMetadata(clusters []*clusterv3.Cluster, clusterKeyTypes map[string][]string) {
+	for _, cluster := range clusters {
+		keyTypes, found := clusterKeyTypes[cluster.Name]
+		if !found {
+			continue
+		}
+		values := make([]*structpb.Value, len(keyTypes))
+		for i, keyType := range keyTypes {
+			values[i] = structpb.NewStringValue(keyType)
+		}
+		if cluster.Metadata == nil {
+			cluster.Metadata = &corev3.Metadata{}
+		}
+		if cluster.Metadata.FilterMetadata == nil {
+			cluster.Metadata.FilterMetadata = make(map[string]*structpb.Struct)
+		}
+		cluster.Metadata.FilterMetadata[keyTypeMetadataNamespace] = &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				keyTypesMetadataKey: structpb.NewListValue(&structpb.ListValue{Values: values}),
+			},
+		}
 	}
-	return methodRegex
 }
@@ -19,9 +19,17 @@ package envoyconf_test
 import (
 	"encoding/json"
 	"io/ioutil"
+	"regexp"
 	"strings"
 	"testing"
-
+	"time"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	extAuthService "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
@@ -454,6 +462,1125 @@ func TestCreateHealthEndpoint(t *testing.T) {
 	assert.Equal(t, uint32(200), route.GetDirectResponse().GetStatus(), "Health response status is incorrect.")
 }
 
+func TestCreateRoutesWithClustersWithNotFoundResponse(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoyroutes/openapi_with_not_found_response.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, 3, len(routes), "Number of routes incorrect")
+	if len(routes) != 3 {
+		return
+	}
+	for _, route := range routes[:2] {
+		assert.False(t, envoy.IsNotFoundFallbackRoute(route), "Operation route should not be a fallback route")
+	}
+
+	// The fallback route should be the last route of the API
+	fallbackRoute := routes[2]
+	assert.True(t, envoy.IsNotFoundFallbackRoute(fallbackRoute), "Fallback route is not generated")
+	assert.Equal(t, uint32(410), fallbackRoute.GetDirectResponse().GetStatus(), "Fallback response status is incorrect.")
+	assert.Equal(t, "{\"code\": 410, \"message\": \"This resource is no longer available in the Petstore API\"}",
+		fallbackRoute.GetDirectResponse().GetBody().GetInlineString(), "Fallback response body is incorrect.")
+	assert.Equal(t, 1, len(fallbackRoute.GetResponseHeadersToAdd()), "Fallback response headers are incorrect.")
+	assert.Equal(t, "application/problem+json", fallbackRoute.GetResponseHeadersToAdd()[0].GetHeader().GetValue(),
+		"Fallback response content type is incorrect.")
+
+	// Envoy expects the regex to match the complete path
+	fallbackRouteRegex := regexp.MustCompile(fallbackRoute.GetMatch().GetSafeRegex().GetRegex() + "$")
+	assert.True(t, fallbackRouteRegex.MatchString("/petstore/v1/unknown"), "Unmatched sub-path should match the fallback route")
+	assert.True(t, fallbackRouteRegex.MatchString("/petstore/v1/pets/1/owner"), "Unmatched sub-path should match the fallback route")
+	assert.True(t, fallbackRouteRegex.MatchString("/petstore/v1"), "API context should match the fallback route")
+	assert.False(t, fallbackRouteRegex.MatchString("/petstore/v10/pets"), "Paths of other contexts should not match the fallback route")
+	assert.False(t, fallbackRouteRegex.MatchString("/store/v1/pets"), "Paths of other contexts should not match the fallback route")
+}
+
+func TestCreateRoutesWithClustersWithoutNotFoundResponse(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi_with_extensions_only.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	routes, _, _, _ := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	for _, route := range routes {
+		assert.False(t, envoy.IsNotFoundFallbackRoute(route), "Fallback route should not be generated without %v",
+			"x-wso2-not-found-response")
+	}
+}
+
+func TestCreateRoutesWithClustersWithMethodNotAllowed(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoyroutes/openapi_with_unmatched_requests.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+	assert.True(t, mgwSwagger.IsMethodNotAllowedEnabled(), "Method not allowed should be enabled by the API")
+	assert.True(t, mgwSwagger.IsVhostCatchAllEnabled(), "Vhost catch-all should be enabled by the API")
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, 4, len(routes), "Number of routes incorrect")
+	if len(routes) != 4 {
+		return
+	}
+	// The method not allowed route of a resource follows the route of the resource
+	assert.False(t, envoy.IsMethodNotAllowedRoute(routes[0]), "Operation route should not be a method not allowed route")
+	assert.True(t, envoy.IsMethodNotAllowedRoute(routes[1]), "Method not allowed route is not generated for /pets")
+	assert.False(t, envoy.IsMethodNotAllowedRoute(routes[2]), "Operation route should not be a method not allowed route")
+	assert.True(t, envoy.IsMethodNotAllowedRoute(routes[3]), "Method not allowed route is not generated for /pets/{petId}")
+
+	expectedAllowHeaders := map[int]string{1: "GET, POST, OPTIONS", 3: "GET, OPTIONS"}
+	for index, allowHeader := range expectedAllowHeaders {
+		methodNotAllowedRoute := routes[index]
+		assert.Equal(t, routes[index-1].GetMatch().GetSafeRegex().GetRegex(),
+			methodNotAllowedRoute.GetMatch().GetSafeRegex().GetRegex(), "Path of the resource route should be matched")
+		assert.Empty(t, methodNotAllowedRoute.GetMatch().GetHeaders(), "Method should not be matched")
+		assert.Equal(t, uint32(405), methodNotAllowedRoute.GetDirectResponse().GetStatus(), "Response status is incorrect.")
+		headers := methodNotAllowedRoute.GetResponseHeadersToAdd()
+		assert.Equal(t, 2, len(headers), "Response headers are incorrect.")
+		if len(headers) == 2 {
+			assert.Equal(t, "allow", headers[0].GetHeader().GetKey())
+			assert.Equal(t, allowHeader, headers[0].GetHeader().GetValue(), "Allow header is incorrect.")
+			assert.Equal(t, "application/problem+json", headers[1].GetHeader().GetValue(), "Content type is incorrect.")
+		}
+	}
+}
+
+func TestCreateRoutesWithClustersWithMethodNotAllowedFromConfig(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousUnmatchedRequests := conf.Envoy.UnmatchedRequests
+	defer func() {
+		conf.Envoy.UnmatchedRequests = previousUnmatchedRequests
+	}()
+	conf.Envoy.UnmatchedRequests.MethodNotAllowedEnabled = true
+
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoyroutes/openapi_with_basepath.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+	assert.False(t, mgwSwagger.IsVhostCatchAllEnabled(), "Vhost catch-all should be disabled by default")
+
+	apiProject := model.ProjectAPI{}
+	apiProject.APIYaml.Data.Operations = []model.OperationYaml{
+		{Target: "/pets", Verb: "GET", RequiredQueryParams: []string{"beta"}},
+		{Target: "/pets", Verb: "POST"},
+		{Target: "/pets/{petId}", Verb: "GET"},
+	}
+	err = mgwSwagger.SetOperationPolicies(apiProject)
+	assert.Nil(t, err, "Error while setting operation policies")
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	// A request to /pets missing the required query params is not a method mismatch, hence the method not allowed
+	// route is generated only for /pets/{petId}
+	var methodNotAllowedRoutes []string
+	for _, route := range routes {
+		if envoy.IsMethodNotAllowedRoute(route) {
+			methodNotAllowedRoutes = append(methodNotAllowedRoutes, route.GetMatch().GetSafeRegex().GetRegex())
+		}
+	}
+	assert.Equal(t, []string{"^/petstore/v1/pets/([^/]+)[/]{0,1}"}, methodNotAllowedRoutes,
+		"Method not allowed routes are incorrect")
+}
+
+func TestCreateVhostCatchAllRoute(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	route := envoy.CreateVhostCatchAllRoute("localhost")
+	assert.Equal(t, "/", route.GetMatch().GetPrefix(), "Catch-all route should match all the paths")
+	assert.Empty(t, route.GetMatch().GetHeaders(), "Catch-all route should match all the methods")
+	assert.Equal(t, conf.Envoy.UnmatchedRequests.VhostCatchAll.StatusCode, route.GetDirectResponse().GetStatus(),
+		"Response status is incorrect.")
+	assert.Equal(t, `{"type":"about:blank","title":"Not Found","status":404}`,
+		route.GetDirectResponse().GetBody().GetInlineString(), "Response body is incorrect.")
+	assert.Equal(t, "application/problem+json", route.GetResponseHeadersToAdd()[0].GetHeader().GetValue(),
+		"Response content type is incorrect.")
+	assert.False(t, envoy.IsMethodNotAllowedRoute(route), "Catch-all route should not be a method not allowed route")
+	assert.False(t, envoy.IsNotFoundFallbackRoute(route), "Catch-all route should not be a fallback route")
+}
+
+func TestCreateRoutesWithClustersWithDeprecatedOperation(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	apiProject := model.ProjectAPI{}
+	apiProject.APIYaml.Data.Operations = []model.OperationYaml{
+		{Target: "/pets", Verb: "GET", Deprecated: true},
+		{Target: "/pets", Verb: "POST"},
+		{Target: "/pets/{petId}", Verb: "GET"},
+	}
+	err = mgwSwagger.SetOperationPolicies(apiProject)
+	assert.Nil(t, err, "Error while setting operation policies")
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	// A route per operation for /pets as it has a deprecated operation and a single route for /pets/{petId}
+	assert.Equal(t, 3, len(routes), "Number of routes incorrect")
+
+	deprecatedRouteCount := 0
+	for _, route := range routes {
+		isDeprecationHeaderAdded := false
+		for _, header := range route.GetResponseHeadersToAdd() {
+			if header.GetHeader().GetKey() == "Deprecation" {
+				isDeprecationHeaderAdded = true
+				assert.Equal(t, "true", header.GetHeader().GetValue(), "Deprecation header value is incorrect.")
+			}
+		}
+		methodRegex := route.GetMatch().GetHeaders()[0].GetStringMatch().GetSafeRegex().GetRegex()
+		isDeprecatedOperation := strings.HasSuffix(route.GetMatch().GetSafeRegex().GetRegex(), "/pets[/]{0,1}") &&
+			strings.Contains(methodRegex, "GET")
+		if isDeprecatedOperation {
+			deprecatedRouteCount++
+			assert.True(t, isDeprecationHeaderAdded, "Deprecation header should be added for the deprecated operation")
+		} else {
+			assert.False(t, isDeprecationHeaderAdded, "Deprecation header should not be added for the route %v %v",
+				route.GetMatch().GetSafeRegex().GetRegex(), methodRegex)
+		}
+	}
+	assert.Equal(t, 1, deprecatedRouteCount, "Route of the deprecated operation is not found")
+}
+
+func TestCreateRoutesWithClustersWithRequiredQueryParams(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	apiProject := model.ProjectAPI{}
+	apiProject.APIYaml.Data.Operations = []model.OperationYaml{
+		{Target: "/pets", Verb: "GET", RequiredQueryParams: []string{"beta", "feature_flag"}},
+		{Target: "/pets", Verb: "POST"},
+		{Target: "/pets/{petId}", Verb: "GET"},
+	}
+	err = mgwSwagger.SetOperationPolicies(apiProject)
+	assert.Nil(t, err, "Error while setting operation policies")
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	// A route per operation for /pets as it has required query params and a single route for /pets/{petId}
+	assert.Equal(t, 3, len(routes), "Number of routes incorrect")
+
+	queryParamMatchedRouteCount := 0
+	for _, route := range routes {
+		methodRegex := route.GetMatch().GetHeaders()[0].GetStringMatch().GetSafeRegex().GetRegex()
+		queryParamMatchers := route.GetMatch().GetQueryParameters()
+		if strings.HasSuffix(route.GetMatch().GetSafeRegex().GetRegex(), "/pets[/]{0,1}") &&
+			strings.Contains(methodRegex, "GET") {
+			queryParamMatchedRouteCount++
+			if assert.Equal(t, 2, len(queryParamMatchers), "Query parameter matchers are not added to the route") {
+				assert.Equal(t, "beta", queryParamMatchers[0].GetName())
+				assert.True(t, queryParamMatchers[0].GetPresentMatch(), "Query parameter should be matched by presence")
+				assert.Equal(t, "feature_flag", queryParamMatchers[1].GetName())
+				assert.True(t, queryParamMatchers[1].GetPresentMatch(), "Query parameter should be matched by presence")
+			}
+		} else {
+			assert.Empty(t, queryParamMatchers, "Query parameters should not be matched for the route %v %v",
+				route.GetMatch().GetSafeRegex().GetRegex(), methodRegex)
+		}
+	}
+	assert.Equal(t, 1, queryParamMatchedRouteCount, "Route of the operation with required query params is not found")
+}
+
+func TestCreateRoutesWithClustersWithStripRequestHeaders(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousStripRequestHeaders := conf.Adapter.StripRequestHeaders
+	defer func() {
+		conf.Adapter.StripRequestHeaders = previousStripRequestHeaders
+	}()
+	conf.Adapter.StripRequestHeaders = []string{"X-Forwarded-For", ":authority"}
+
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	openapiByteArr = append([]byte("x-wso2-strip-request-headers: [x-forwarded-for, X-Internal-Route]\n"),
+		openapiByteArr...)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	policySpec := model.PolicySpecification{}
+	policySpec.Data.Name = "addInternalRouteHeader"
+	policySpec.Data.Version = "v1"
+	policySpec.Data.ApplicableFlows = []string{"request"}
+	policySpec.Data.SupportedGateways = []string{"ChoreoConnect"}
+	apiProject := model.ProjectAPI{
+		Policies: map[string]model.PolicyContainer{
+			"addInternalRouteHeader_v1": {
+				Specification: policySpec,
+				Definition: model.PolicyDefinition{RawData: []byte(`
+definition:
+  action: SET_HEADER
+  parameters:
+    headerName: {{ .headerName }}
+    headerValue: {{ .headerValue }}
+`)},
+			},
+		},
+	}
+	apiProject.APIYaml.Data.Operations = []model.OperationYaml{
+		{Target: "/pets", Verb: "GET", OperationPolicies: model.OperationPolicies{Request: model.PolicyList{{
+			PolicyName:    "addInternalRouteHeader",
+			PolicyVersion: "v1",
+			Parameters:    map[string]interface{}{"headerName": "X-Internal-Route", "headerValue": "gateway"},
+		}}}},
+		{Target: "/pets", Verb: "POST"},
+		{Target: "/pets/{petId}", Verb: "GET"},
+	}
+	err = mgwSwagger.SetOperationPolicies(apiProject)
+	assert.Nil(t, err, "Error while setting operation policies")
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, 3, len(routes), "Number of routes incorrect")
+
+	policyRouteCount := 0
+	for _, route := range routes {
+		// pseudo headers are not removed and the duplicates are removed case-insensitively
+		assert.Equal(t, []string{"x-forwarded-for", "x-internal-route"}, route.GetRequestHeadersToRemove(),
+			"Headers to strip are incorrect for the route %v", route.GetMatch().GetSafeRegex().GetRegex())
+		for _, header := range route.GetRequestHeadersToAdd() {
+			if header.GetHeader().GetKey() == "X-Internal-Route" {
+				// the header is removed before it is added by the policy, hence the policy value is sent
+				policyRouteCount++
+				assert.Equal(t, "gateway", header.GetHeader().GetValue())
+			}
+		}
+	}
+	assert.Equal(t, 1, policyRouteCount, "Header added by the policy is not found")
+}
+
+func TestCreateRoutesWithClustersWithPayloadTransformation(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	policySpec := model.PolicySpecification{}
+	policySpec.Data.Name = "payloadToXML"
+	policySpec.Data.Version = "v1"
+	policySpec.Data.ApplicableFlows = []string{"request", "response"}
+	policySpec.Data.SupportedGateways = []string{"ChoreoConnect"}
+	apiProject := model.ProjectAPI{
+		Policies: map[string]model.PolicyContainer{
+			"payloadToXML_v1": {
+				Specification: policySpec,
+				Definition: model.PolicyDefinition{RawData: []byte(`
+definition:
+  action: PAYLOAD_TO_XML
+  parameters:
+    rootElementName: {{ .rootElementName }}
+`)},
+			},
+		},
+	}
+	apiProject.APIYaml.Data.Operations = []model.OperationYaml{
+		{Target: "/pets", Verb: "GET"},
+		{Target: "/pets", Verb: "POST", OperationPolicies: model.OperationPolicies{Request: model.PolicyList{{
+			PolicyName:    "payloadToXML",
+			PolicyVersion: "v1",
+			Parameters:    map[string]interface{}{"rootElementName": "pet"},
+		}}}},
+		{Target: "/pets/{petId}", Verb: "GET"},
+	}
+	err = mgwSwagger.SetOperationPolicies(apiProject)
+	assert.Nil(t, err, "Error while setting operation policies")
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, 3, len(routes), "Number of routes incorrect")
+
+	transformationRouteCount := 0
+	for _, route := range routes {
+		extAuthPerRouteConfig := &extAuthService.ExtAuthzPerRoute{}
+		err = route.GetTypedPerFilterConfig()[wellknown.HTTPExternalAuthorization].UnmarshalTo(extAuthPerRouteConfig)
+		assert.Nil(t, err, "Error while parsing ExtAuthzPerRouteConfig")
+		methodRegex := route.GetMatch().GetHeaders()[0].GetStringMatch().GetSafeRegex().GetRegex()
+		if strings.Contains(methodRegex, "POST") {
+			transformationRouteCount++
+			assert.False(t, extAuthPerRouteConfig.GetCheckSettings().GetDisableRequestBodyBuffering(),
+				"Request payload should be passed to the enforcer to be transformed")
+			continue
+		}
+		assert.True(t, extAuthPerRouteConfig.GetCheckSettings().GetDisableRequestBodyBuffering(),
+			"Request payload should not be passed to the enforcer for the route %v", methodRegex)
+	}
+	assert.Equal(t, 1, transformationRouteCount, "Route of the operation with the payload transformation is not found")
+}
+
+func TestCreateRoutesWithClustersWithStripAuthHeader(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		name                     string
+		apiYamlStripAuthHeader   *bool
+		extensions               string
+		enableOutboundAuthHeader string
+	}{
+		{
+			name:                     "Enforcer configuration is applied by default",
+			enableOutboundAuthHeader: "",
+		},
+		{
+			name:                     "Strip the auth header when enabled in api.yaml",
+			apiYamlStripAuthHeader:   &enabled,
+			enableOutboundAuthHeader: "false",
+		},
+		{
+			name:                     "Forward the auth header when disabled in api.yaml",
+			apiYamlStripAuthHeader:   &disabled,
+			enableOutboundAuthHeader: "true",
+		},
+		{
+			name:                     "Extension overrides api.yaml",
+			apiYamlStripAuthHeader:   &disabled,
+			extensions:               "x-wso2-strip-auth-header: true\n",
+			enableOutboundAuthHeader: "false",
+		},
+	}
+
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mgwSwagger := model.MgwSwagger{}
+			apiYaml := model.APIYaml{}
+			apiYaml.Data.Name = "petstore"
+			apiYaml.Data.Version = "1.0.0"
+			apiYaml.Data.Context = "/petstore"
+			apiYaml.Data.APIType = "HTTP"
+			apiYaml.Data.StripAuthHeader = test.apiYamlStripAuthHeader
+			err := mgwSwagger.PopulateFromAPIYaml(apiYaml)
+			assert.Nil(t, err, "Error while populating the MgwSwagger object from api.yaml")
+			err = mgwSwagger.GetMgwSwagger(append([]byte(test.extensions), openapiByteArr...))
+			assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+			routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+			assert.Nil(t, err, "Error while creating routes")
+			assert.NotEmpty(t, routes, "Routes are not created")
+			for _, route := range routes {
+				// The auth header is removed by the enforcer, not by the route.
+				assert.Empty(t, route.GetRequestHeadersToRemove(),
+					"Headers to strip are incorrect for the route %v", route.GetMatch().GetSafeRegex().GetRegex())
+				extAuthPerRouteConfig := &extAuthService.ExtAuthzPerRoute{}
+				err = route.TypedPerFilterConfig[wellknown.HTTPExternalAuthorization].UnmarshalTo(extAuthPerRouteConfig)
+				assert.Nil(t, err, "Error while parsing ExtAuthzPerRouteConfig")
+				assert.Equal(t, test.enableOutboundAuthHeader,
+					extAuthPerRouteConfig.GetCheckSettings().GetContextExtensions()["enableOutboundAuthHeader"],
+					"Outbound auth header of the API is incorrect for the route %v",
+					route.GetMatch().GetSafeRegex().GetRegex())
+			}
+		})
+	}
+}
+
+func TestCreateRoutesWithClustersWithRateLimitKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		rateLimitKey  *model.RateLimitKey
+		isRateLimited bool
+	}{
+		{
+			name:          "Rate limit by the API and the application by default",
+			isRateLimited: false,
+		},
+		{
+			name:          "Rate limit by a header",
+			rateLimitKey:  &model.RateLimitKey{Header: "X-Tenant-ID"},
+			isRateLimited: true,
+		},
+		{
+			name:          "Rate limit by a claim",
+			rateLimitKey:  &model.RateLimitKey{Claim: "sub"},
+			isRateLimited: true,
+		},
+	}
+
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mgwSwagger := model.MgwSwagger{}
+			apiYaml := model.APIYaml{}
+			apiYaml.Data.ID = "rate-limit-key-api"
+			apiYaml.Data.Name = "petstore"
+			apiYaml.Data.Version = "1.0.0"
+			apiYaml.Data.Context = "/petstore"
+			apiYaml.Data.APIType = "HTTP"
+			apiYaml.Data.RateLimitKey = test.rateLimitKey
+			err := mgwSwagger.PopulateFromAPIYaml(apiYaml)
+			assert.Nil(t, err, "Error while populating the MgwSwagger object from api.yaml")
+			err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+			assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+			routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+			assert.Nil(t, err, "Error while creating routes")
+			assert.NotEmpty(t, routes, "Routes are not created")
+			for _, route := range routes {
+				rateLimits := route.GetRoute().GetRateLimits()
+				if !test.isRateLimited {
+					assert.Empty(t, rateLimits, "Rate limits should not be added to the route %v", route.GetName())
+					continue
+				}
+				assert.Equal(t, 1, len(rateLimits), "Rate limits are incorrect for the route %v", route.GetName())
+				actions := rateLimits[0].GetActions()
+				assert.Equal(t, 2, len(actions), "Rate limit actions are incorrect for the route %v", route.GetName())
+				assert.Equal(t, "api", actions[0].GetGenericKey().GetDescriptorKey())
+				assert.Equal(t, "rate-limit-key-api", actions[0].GetGenericKey().GetDescriptorValue())
+				if test.rateLimitKey.Header != "" {
+					assert.Equal(t, "rateLimitKey", actions[1].GetRequestHeaders().GetDescriptorKey())
+					assert.Equal(t, "X-Tenant-ID", actions[1].GetRequestHeaders().GetHeaderName())
+					continue
+				}
+				metadata := actions[1].GetMetadata()
+				assert.Equal(t, "rateLimitKey", metadata.GetDescriptorKey())
+				assert.Equal(t, routev3.RateLimit_Action_MetaData_DYNAMIC, metadata.GetSource())
+				assert.Equal(t, "envoy.filters.http.ext_authz", metadata.GetMetadataKey().GetKey())
+				assert.Equal(t, 1, len(metadata.GetMetadataKey().GetPath()))
+				assert.Equal(t, "x-wso2-rate-limit-key", metadata.GetMetadataKey().GetPath()[0].GetKey())
+
+				// The enforcer publishes the value of the claim given by the context extension under the key.
+				var extAuthzPerRoute extAuthService.ExtAuthzPerRoute
+				err := route.GetTypedPerFilterConfig()[wellknown.HTTPExternalAuthorization].UnmarshalTo(&extAuthzPerRoute)
+				assert.Nil(t, err, "Error while reading the ext_authz config of the route %v", route.GetName())
+				assert.Equal(t, "sub",
+					extAuthzPerRoute.GetCheckSettings().GetContextExtensions()["rateLimitClaim"])
+			}
+		})
+	}
+}
+
+func TestCreateRoutesWithClustersWithSessionAffinity(t *testing.T) {
+	tests := []struct {
+		name               string
+		sessionManagement  string
+		sessionTimeOut     string
+		sessionCookieName  string
+		expectedCookieName string
+		expectedCookieTTL  time.Duration
+	}{
+		{
+			name:              "Load balanced without the session affinity",
+			sessionManagement: "none",
+		},
+		{
+			name:               "Session affinity on the default cookie",
+			sessionManagement:  "transport",
+			expectedCookieName: "CHOREO_CONNECT_SESSION",
+		},
+		{
+			name:               "Session affinity on the configured cookie with a timeout",
+			sessionManagement:  "Cookie",
+			sessionTimeOut:     "1800000",
+			sessionCookieName:  "LEGACY_SESSION",
+			expectedCookieName: "LEGACY_SESSION",
+			expectedCookieTTL:  30 * time.Minute,
+		},
+	}
+
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mgwSwagger := model.MgwSwagger{}
+			apiYaml := model.APIYaml{}
+			apiYaml.Data.Name = "petstore"
+			apiYaml.Data.Version = "1.0.0"
+			apiYaml.Data.Context = "/petstore"
+			apiYaml.Data.APIType = "HTTP"
+			apiYaml.Data.EndpointConfig.EndpointType = "load_balance"
+			apiYaml.Data.EndpointConfig.LoadBalanceSessionManagement = test.sessionManagement
+			apiYaml.Data.EndpointConfig.LoadBalanceSessionTimeOut = test.sessionTimeOut
+			apiYaml.Data.EndpointConfig.LoadBalanceSessionCookieName = test.sessionCookieName
+			err := mgwSwagger.PopulateFromAPIYaml(apiYaml)
+			assert.Nil(t, err, "Error while populating the MgwSwagger object from api.yaml")
+			err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+			assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+			routes, clusters, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+			assert.Nil(t, err, "Error while creating routes")
+			assert.NotEmpty(t, routes, "Routes are not created")
+			assert.Equal(t, 2, len(clusters), "Number of clusters created is incorrect.")
+			// The first cluster is the API level cluster, and the second is the resource level cluster
+			assert.Equal(t, clusterv3.Cluster_ROUND_ROBIN, clusters[1].GetLbPolicy(),
+				"Resource level cluster should not have the session affinity.")
+			if test.expectedCookieName == "" {
+				assert.Equal(t, clusterv3.Cluster_ROUND_ROBIN, clusters[0].GetLbPolicy(),
+					"API level cluster should be round robin load balanced without the session affinity.")
+				for _, route := range routes {
+					assert.Empty(t, route.GetRoute().GetHashPolicy(),
+						"Hash policies should not be added to the route %v", route.GetName())
+				}
+				return
+			}
+			assert.Equal(t, clusterv3.Cluster_RING_HASH, clusters[0].GetLbPolicy(),
+				"API level cluster should be ring hash load balanced with the session affinity.")
+			for _, route := range routes {
+				hashPolicies := route.GetRoute().GetHashPolicy()
+				if assert.Equal(t, 1, len(hashPolicies), "Hash policies are incorrect for the route %v", route.GetName()) {
+					assert.True(t, hashPolicies[0].GetTerminal())
+					assert.Equal(t, test.expectedCookieName, hashPolicies[0].GetCookie().GetName())
+					assert.Equal(t, "/", hashPolicies[0].GetCookie().GetPath())
+					assert.Equal(t, test.expectedCookieTTL, hashPolicies[0].GetCookie().GetTtl().AsDuration())
+				}
+			}
+		})
+	}
+}
+
+func TestCreateRoutesWithClustersWithRewritePath(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	apiProject := model.ProjectAPI{}
+	apiProject.APIYaml.Data.Operations = []model.OperationYaml{
+		{Target: "/pets", Verb: "GET"},
+		{Target: "/pets", Verb: "POST"},
+		{Target: "/pets/{petId}", Verb: "GET", RewritePath: &model.RewritePathConfig{
+			Pattern:      `^/pets/(\d+)$`,
+			Substitution: `/animals/\1/details`,
+		}},
+	}
+	err = mgwSwagger.SetOperationPolicies(apiProject)
+	assert.Nil(t, err, "Error while setting operation policies")
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, 2, len(routes), "Number of routes incorrect")
+
+	rewrittenRouteCount := 0
+	for _, route := range routes {
+		regexRewrite := route.GetRoute().GetRegexRewrite()
+		if !strings.Contains(route.GetMatch().GetSafeRegex().GetRegex(), "/pets/") {
+			assert.NotEqual(t, `^/pets/(\d+)$`, regexRewrite.GetPattern().GetRegex(),
+				"Path rewrite should not be applied to the other operations")
+			continue
+		}
+		rewrittenRouteCount++
+		assert.Equal(t, `^/pets/(\d+)$`, regexRewrite.GetPattern().GetRegex())
+		assert.Equal(t, `/animals/\1/details`, regexRewrite.GetSubstitution())
+		// Envoy refers the capture groups as \1, while Go refers those as ${1}
+		substitution := regexp.MustCompile(`\\(\d+)`).ReplaceAllString(regexRewrite.GetSubstitution(), "$${$1}")
+		assert.Equal(t, "/animals/123/details", regexp.MustCompile(regexRewrite.GetPattern().GetRegex()).
+			ReplaceAllString("/pets/123", substitution), "Path is not rewritten as expected")
+	}
+	assert.Equal(t, 1, rewrittenRouteCount, "Route of the operation with the path rewrite is not found")
+}
+
+func TestCreateRoutesWithClustersWithOperationTimeout(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousMaxRouteTimeout := conf.Envoy.Upstream.Timeouts.MaxRouteTimeoutInSeconds
+	defer func() {
+		conf.Envoy.Upstream.Timeouts.MaxRouteTimeoutInSeconds = previousMaxRouteTimeout
+	}()
+	conf.Envoy.Upstream.Timeouts.MaxRouteTimeoutInSeconds = 300
+
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	apiProject := model.ProjectAPI{}
+	apiProject.APIYaml.Data.Operations = []model.OperationYaml{
+		{Target: "/pets", Verb: "GET", Timeout: "2m30s"},
+		{Target: "/pets", Verb: "POST"},
+	}
+	err = mgwSwagger.SetOperationPolicies(apiProject)
+	assert.Nil(t, err, "Error while setting operation policies")
+	// The API has an endpoint timeout, which is applied by the enforcer for the other operations
+	mgwSwagger.GetProdEndpoints().Config = &model.EndpointConfig{TimeoutInMillis: 10000}
+
+	routeTimeout := time.Duration(conf.Envoy.Upstream.Timeouts.RouteTimeoutInSeconds) * time.Second
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, 3, len(routes), "Number of routes incorrect")
+
+	timeoutRouteCount := 0
+	for _, route := range routes {
+		extAuthPerRouteConfig := &extAuthService.ExtAuthzPerRoute{}
+		err = route.TypedPerFilterConfig[wellknown.HTTPExternalAuthorization].UnmarshalTo(extAuthPerRouteConfig)
+		assert.Nil(t, err, "Error while parsing ExtAuthzPerRouteConfig")
+		contextExtensions := extAuthPerRouteConfig.GetCheckSettings().GetContextExtensions()
+		methodRegex := route.GetMatch().GetHeaders()[0].GetStringMatch().GetSafeRegex().GetRegex()
+		if !strings.Contains(route.GetMatch().GetSafeRegex().GetRegex(), "/pets/") &&
+			strings.Contains(methodRegex, "GET") {
+			timeoutRouteCount++
+			assert.Equal(t, 150*time.Second, route.GetRoute().GetTimeout().AsDuration(),
+				"Operation timeout should take precedence over the API timeout")
+			assert.Equal(t, "150000", contextExtensions["operationTimeoutInMillis"],
+				"Operation timeout should be passed to the enforcer to take precedence over the endpoint timeout")
+			continue
+		}
+		assert.Equal(t, routeTimeout, route.GetRoute().GetTimeout().AsDuration(),
+			"Operation timeout should not be applied to the other operations")
+		assert.NotContains(t, contextExtensions, "operationTimeoutInMillis")
+	}
+	assert.Equal(t, 1, timeoutRouteCount, "Route of the operation with the timeout is not found")
+}
+
+func TestCreateRoutesWithClustersWithCacheVaryHeaders(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	apiProject := model.ProjectAPI{}
+	apiProject.APIYaml.Data.Operations = []model.OperationYaml{
+		{Target: "/pets", Verb: "GET", CacheVaryHeaders: []string{"Accept-Language", " X-Tenant ", "x-tenant"}},
+		{Target: "/pets", Verb: "POST"},
+	}
+	err = mgwSwagger.SetOperationPolicies(apiProject)
+	assert.Nil(t, err, "Error while setting operation policies")
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, 3, len(routes), "Number of routes incorrect")
+
+	varyRouteCount := 0
+	for _, route := range routes {
+		var varyHeaders []*corev3.HeaderValueOption
+		for _, header := range route.GetResponseHeadersToAdd() {
+			if header.GetHeader().GetKey() == "vary" {
+				varyHeaders = append(varyHeaders, header)
+			}
+		}
+		methodRegex := route.GetMatch().GetHeaders()[0].GetStringMatch().GetSafeRegex().GetRegex()
+		if !strings.Contains(route.GetMatch().GetSafeRegex().GetRegex(), "/pets/") &&
+			strings.Contains(methodRegex, "GET") {
+			varyRouteCount++
+			if assert.Len(t, varyHeaders, 1, "Cache vary headers should be added to the vary header") {
+				assert.Equal(t, "accept-language, x-tenant", varyHeaders[0].GetHeader().GetValue())
+				assert.Equal(t, corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD, varyHeaders[0].GetAppendAction(),
+					"Vary header of the backend should be retained")
+			}
+			continue
+		}
+		assert.Empty(t, varyHeaders, "Cache vary headers should not be applied to the other operations")
+	}
+	assert.Equal(t, 1, varyRouteCount, "Route of the operation with the cache vary headers is not found")
+}
+
+func TestCreateRoutesWithClustersWithStatusRemaps(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	apiProject := model.ProjectAPI{}
+	apiProject.APIYaml.Data.Operations = []model.OperationYaml{
+		{Target: "/pets", Verb: "GET", RemapStatus: []model.StatusRemapConfig{
+			{From: 200, To: 502, BodyContains: "\"error\""},
+			{From: 404, To: 204},
+		}},
+		{Target: "/pets", Verb: "POST"},
+	}
+	err = mgwSwagger.SetOperationPolicies(apiProject)
+	assert.Nil(t, err, "Error while setting operation policies")
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, 3, len(routes), "Number of routes incorrect")
+
+	remapRouteCount := 0
+	for _, route := range routes {
+		luaPerRouteConfig := &luav3.LuaPerRoute{}
+		err = route.GetTypedPerFilterConfig()[wellknown.Lua].UnmarshalTo(luaPerRouteConfig)
+		assert.Nil(t, err, "Error while parsing the Lua filter config of the route")
+		methodRegex := route.GetMatch().GetHeaders()[0].GetStringMatch().GetSafeRegex().GetRegex()
+		if !strings.Contains(route.GetMatch().GetSafeRegex().GetRegex(), "/pets/") &&
+			strings.Contains(methodRegex, "GET") {
+			remapRouteCount++
+			script := luaPerRouteConfig.GetSourceCode().GetInlineString()
+			assert.Contains(t, script, `{from = "200", to = "502", body_contains = "\"error\""},`,
+				"Status remap conditioned on the body should be added to the route")
+			assert.Contains(t, script, `{from = "404", to = "204"},`,
+				"Unconditional status remap should be added to the route")
+			assert.Contains(t, script, `response_handle:headers():replace(":status", remap.to)`)
+			continue
+		}
+		assert.True(t, luaPerRouteConfig.GetDisabled(),
+			"Status remaps should not be applied to the other operations")
+	}
+	assert.Equal(t, 1, remapRouteCount, "Route of the operation with the status remaps is not found")
+}
+
+func TestCreateRoutesWithClustersWithEndpointQueryParams(t *testing.T) {
+	openAPIDefinition := `openapi: 3.0.1
+info:
+  title: PetStore
+  version: 1.0.0
+servers:
+  - url: http://petstore-backend:8080/api?apiKey=abc%20123&version=2
+x-wso2-basePath: /petstore/1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+  /stores:
+    x-wso2-production-endpoints:
+      urls:
+        - http://stores-backend:8080/api
+    get:
+      responses:
+        "200":
+          description: OK
+`
+	mgwSwagger := model.MgwSwagger{}
+	err := mgwSwagger.GetMgwSwagger([]byte(openAPIDefinition))
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	routes, clusters, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, 2, len(clusters), "Number of clusters incorrect")
+
+	queryParamsRouteCount := 0
+	for _, route := range routes {
+		if isSandboxRoute(route) {
+			// Depending on the order of the resources, sandbox routes may be added without sandbox endpoints.
+			continue
+		}
+		luaPerRouteConfig := &luav3.LuaPerRoute{}
+		err = route.GetTypedPerFilterConfig()[wellknown.Lua].UnmarshalTo(luaPerRouteConfig)
+		assert.Nil(t, err, "Error while parsing the Lua filter config of the route")
+		if strings.Contains(route.GetMatch().GetSafeRegex().GetRegex(), "/stores") {
+			assert.True(t, luaPerRouteConfig.GetDisabled(),
+				"Query parameters should not be appended to the requests to the endpoints without query parameters")
+			continue
+		}
+		queryParamsRouteCount++
+		script := luaPerRouteConfig.GetSourceCode().GetInlineString()
+		assert.Contains(t, script, `["carbon.super_clusterProd_localhost_PetStore1.0.0"] = "apiKey=abc%20123&version=2",`,
+			"Query parameters of the endpoint should be appended to the requests to its cluster")
+		assert.Contains(t, script, `request_handle:headers():get("x-wso2-cluster-header")`)
+		assert.Contains(t, script, `request_handle:headers():replace(":path", path .. separator .. query_params)`)
+		assert.NotContains(t, route.GetRoute().GetRegexRewrite().GetSubstitution(), "apiKey",
+			"Query parameters should not be added to the rewritten path")
+	}
+	assert.Equal(t, 1, queryParamsRouteCount, "Route of the endpoint with the query parameters is not found")
+}
+
+// isSandboxRoute returns whether the route matches the requests to the sandbox cluster.
+func isSandboxRoute(route *routev3.Route) bool {
+	for _, header := range route.GetMatch().GetHeaders() {
+		if header.GetName() == "x-wso2-cluster-header" {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCreateRoutesWithClustersWithWebSocketOperation(t *testing.T) {
+	openAPIDefinition := `openapi: 3.0.1
+info:
+  title: Notifications
+  version: 1.0.0
+servers:
+  - url: http://notifications-backend:8080/api
+x-wso2-basePath: /notifications/1.0.0
+paths:
+  /notifications:
+    get:
+      responses:
+        "200":
+          description: OK
+  /notifications/stream:
+    get:
+      x-wso2-websocket: true
+      x-wso2-websocket-idle-timeout: 600
+      responses:
+        "101":
+          description: Switching Protocols
+    post:
+      responses:
+        "200":
+          description: OK
+`
+	mgwSwagger := model.MgwSwagger{}
+	err := mgwSwagger.GetMgwSwagger([]byte(openAPIDefinition))
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+	assert.Nil(t, mgwSwagger.Validate(), "WebSocket GET operations of HTTP APIs should be valid")
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	// A route per operation for /notifications/stream as it has a WebSocket operation and a single route for
+	// /notifications
+	assert.Equal(t, 3, len(routes), "Number of routes incorrect")
+
+	var webSocketRoute *routev3.Route
+	var httpRoutes []*routev3.Route
+	for _, route := range routes {
+		methodRegex := route.GetMatch().GetHeaders()[0].GetStringMatch().GetSafeRegex().GetRegex()
+		if strings.Contains(route.GetMatch().GetSafeRegex().GetRegex(), "/stream") &&
+			strings.Contains(methodRegex, "GET") {
+			webSocketRoute = route
+			continue
+		}
+		httpRoutes = append(httpRoutes, route)
+	}
+	if !assert.NotNil(t, webSocketRoute, "Route of the WebSocket operation is not found") {
+		return
+	}
+	upgradeConfigs := webSocketRoute.GetRoute().GetUpgradeConfigs()
+	if assert.Len(t, upgradeConfigs, 1) {
+		assert.Equal(t, "websocket", upgradeConfigs[0].GetUpgradeType(), "Upgrade type is incorrect")
+		assert.True(t, upgradeConfigs[0].GetEnabled().GetValue(), "WebSocket upgrades should be enabled")
+	}
+	assert.Equal(t, 600*time.Second, webSocketRoute.GetRoute().GetIdleTimeout().AsDuration(),
+		"Idle timeout of the WebSocket operation should be applied")
+	assert.Equal(t, 24*time.Hour, webSocketRoute.GetRoute().GetMaxStreamDuration().GetMaxStreamDuration().AsDuration(),
+		"Max stream duration of the WebSocket operation is incorrect")
+
+	conf, _ := config.ReadConfigs()
+	routeIdleTimeout := time.Duration(conf.Envoy.Upstream.Timeouts.RouteIdleTimeoutInSeconds) * time.Second
+	for _, route := range httpRoutes {
+		for _, upgradeConfig := range route.GetRoute().GetUpgradeConfigs() {
+			assert.False(t, upgradeConfig.GetEnabled().GetValue(),
+				"WebSocket upgrades should not be enabled for the other operations")
+		}
+		assert.Equal(t, routeIdleTimeout, route.GetRoute().GetIdleTimeout().AsDuration(),
+			"Idle timeout of the WebSocket operation should not be applied to the other operations")
+		assert.Nil(t, route.GetRoute().GetMaxStreamDuration(),
+			"Max stream duration should not be set for the other operations")
+		assert.Equal(t, route.GetRoute().GetClusterHeader(), webSocketRoute.GetRoute().GetClusterHeader(),
+			"WebSocket operation should be routed to the cluster of the API")
+		assert.Equal(t, route.GetRoute().GetCluster(), webSocketRoute.GetRoute().GetCluster(),
+			"WebSocket operation should be routed to the cluster of the API")
+	}
+}
+
+func TestCreateRoutesWithClustersWithAnalyticsProperties(t *testing.T) {
+	openAPIDefinition := `openapi: 3.0.1
+info:
+  title: PetStore
+  version: 1.0.0
+servers:
+  - url: http://petstore-backend:8080/api
+x-wso2-basePath: /petstore/1.0.0
+x-wso2-analytics-properties:
+  productLine: retail
+  channel: web
+paths:
+  /pets:
+    get:
+      x-wso2-analytics-properties:
+        channel: mobile
+      responses:
+        "200":
+          description: OK
+    post:
+      responses:
+        "200":
+          description: OK
+`
+	mgwSwagger := model.MgwSwagger{}
+	err := mgwSwagger.GetMgwSwagger([]byte(openAPIDefinition))
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, 2, len(routes), "A route per operation should be created for the analytics properties")
+
+	for _, route := range routes {
+		expectedProperties := map[string]string{"productLine": "retail", "channel": "web"}
+		methodRegex := route.GetMatch().GetHeaders()[0].GetStringMatch().GetSafeRegex().GetRegex()
+		if strings.Contains(methodRegex, "GET") {
+			// channel is overridden by the operation and productLine is inherited from the API
+			expectedProperties = map[string]string{"productLine": "retail", "channel": "mobile"}
+		}
+
+		analyticsMetadata := route.GetMetadata().GetFilterMetadata()["com.wso2.analytics"]
+		if !assert.NotNil(t, analyticsMetadata, "Analytics metadata is not added to the route %v", methodRegex) {
+			continue
+		}
+		properties := make(map[string]string)
+		for key, value := range analyticsMetadata.GetFields() {
+			properties[key] = value.GetStringValue()
+		}
+		assert.Equal(t, expectedProperties, properties, "Analytics properties of the route %v mismatch", methodRegex)
+
+		extAuthPerRouteConfig := &extAuthService.ExtAuthzPerRoute{}
+		err = route.TypedPerFilterConfig[wellknown.HTTPExternalAuthorization].UnmarshalTo(extAuthPerRouteConfig)
+		assert.Nilf(t, err, "Error while parsing ExtAuthzPerRouteConfig %v", extAuthPerRouteConfig)
+		contextExtensions := extAuthPerRouteConfig.GetCheckSettings().GetContextExtensions()
+		for key, value := range expectedProperties {
+			assert.Equal(t, value, contextExtensions["analyticsProperty:"+key],
+				"Analytics property %v should be passed to the enforcer", key)
+		}
+	}
+}
+
+func TestCreateRoutesWithClustersWithAwsLambdaOperations(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	functionARN := "arn:aws:lambda:us-west-2:123456789012:function:listPets"
+	apiProject := model.ProjectAPI{}
+	apiProject.APIYaml.Data.Operations = []model.OperationYaml{
+		{Target: "/pets", Verb: "GET", AwsLambda: &model.AwsLambdaConfig{FunctionARN: functionARN,
+			AccessMethod: "stored", AccessKey: "$env{pets_access_key}", SecretKey: "$env{pets_secret_key}",
+			TimeoutInMillis: 5000}},
+		{Target: "/pets", Verb: "POST"},
+		{Target: "/pets/{petId}", Verb: "GET", AwsLambda: &model.AwsLambdaConfig{
+			FunctionARN: "arn:aws:lambda:us-west-2:123456789012:function:getPet"}},
+	}
+	err = mgwSwagger.SetOperationPolicies(apiProject)
+	assert.Nil(t, err, "Error while setting operation policies")
+
+	routes, clusters, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	// A route per operation, as the operations of a resource may have different backends
+	assert.Equal(t, 3, len(routes), "Number of routes incorrect")
+
+	// A single Lambda cluster is created for the region, in addition to the clusters of the API endpoints
+	var lambdaClusterName string
+	for _, cluster := range clusters {
+		if !strings.Contains(cluster.GetName(), "awslambda_us-west-2") {
+			continue
+		}
+		assert.Empty(t, lambdaClusterName, "Lambda cluster should be created once per region")
+		lambdaClusterName = cluster.GetName()
+		assert.Equal(t, "lambda.us-west-2.amazonaws.com", cluster.GetLoadAssignment().GetEndpoints()[0].
+			GetLbEndpoints()[0].GetEndpoint().GetAddress().GetSocketAddress().GetAddress())
+		assert.True(t, cluster.GetMetadata().GetFilterMetadata()["com.amazonaws.lambda"].GetFields()["egress_gateway"].
+			GetBoolValue(), "Lambda cluster should be marked as an egress gateway")
+	}
+	if !assert.NotEmpty(t, lambdaClusterName, "Lambda cluster is not created") {
+		return
+	}
+
+	lambdaRouteCount := 0
+	for _, route := range routes {
+		methodRegex := route.GetMatch().GetHeaders()[0].GetStringMatch().GetSafeRegex().GetRegex()
+		lambdaMetadata := route.GetMetadata().GetFilterMetadata()["com.wso2.aws_lambda"]
+		if strings.Contains(methodRegex, "POST") {
+			// The HTTP operation is routed to the cluster selected by the enforcer
+			assert.NotEmpty(t, route.GetRoute().GetClusterHeader(), "HTTP operation should be routed using the cluster header")
+			assert.Nil(t, route.GetTypedPerFilterConfig()["envoy.filters.http.aws_lambda"],
+				"AWS Lambda filter should not be configured for the HTTP operation")
+			assert.Nil(t, lambdaMetadata, "AWS Lambda metadata should not be added to the HTTP operation")
+			continue
+		}
+		lambdaRouteCount++
+		assert.Equal(t, lambdaClusterName, route.GetRoute().GetCluster(), "Lambda operation should be routed to the Lambda cluster")
+		assert.Nil(t, route.GetRoute().GetRegexRewrite(), "Path of the Lambda operation should not be rewritten")
+		assert.NotNil(t, route.GetTypedPerFilterConfig()["envoy.filters.http.aws_lambda"],
+			"AWS Lambda filter is not configured for the Lambda operation")
+		if strings.HasSuffix(route.GetMatch().GetSafeRegex().GetRegex(), "/pets[/]{0,1}") {
+			assert.Equal(t, int64(5), route.GetRoute().GetTimeout().GetSeconds(), "Invocation timeout is incorrect")
+			assert.Equal(t, functionARN, lambdaMetadata.GetFields()["functionArn"].GetStringValue())
+			assert.Equal(t, "stored", lambdaMetadata.GetFields()["accessMethod"].GetStringValue())
+			// The secrets are referred, not included
+			assert.Equal(t, "$env{pets_secret_key}", lambdaMetadata.GetFields()["secretKey"].GetStringValue())
+		} else {
+			assert.Equal(t, "role-supplied", lambdaMetadata.GetFields()["accessMethod"].GetStringValue())
+			assert.Nil(t, lambdaMetadata.GetFields()["secretKey"], "Secret key should not be added for role-supplied")
+		}
+	}
+	assert.Equal(t, 2, lambdaRouteCount, "Routes of the Lambda operations are not found")
+}
+
+func TestSetOperationPoliciesWithInvalidAwsLambda(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	apiProject := model.ProjectAPI{}
+	apiProject.APIYaml.Data.Operations = []model.OperationYaml{
+		{Target: "/pets", Verb: "GET", AwsLambda: &model.AwsLambdaConfig{
+			FunctionARN:  "arn:aws:lambda:us-west-2:123456789012:function:listPets",
+			AccessMethod: "stored", AccessKey: "$env{pets_access_key}"}},
+	}
+	err = mgwSwagger.SetOperationPolicies(apiProject)
+	assert.EqualError(t, err, "invalid awsLambda of the operation GET /pets. secretKey is not provided for the "+
+		"stored access method")
+}
+
+func TestSetOperationPoliciesWithInvalidRequiredQueryParams(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+
+	for _, requiredQueryParams := range [][]string{{""}, {"feature flag"}, {"beta&debug=true"}, {"beta", "beta"}} {
+		mgwSwagger := model.MgwSwagger{}
+		err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+		assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+		apiProject := model.ProjectAPI{}
+		apiProject.APIYaml.Data.Operations = []model.OperationYaml{
+			{Target: "/pets", Verb: "GET", RequiredQueryParams: requiredQueryParams},
+		}
+		err = mgwSwagger.SetOperationPolicies(apiProject)
+		assert.NotNil(t, err, "Error expected for the required query params %q", requiredQueryParams)
+	}
+}
+
+func TestCreateRoutesWithClustersWithCustomMethods(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+
+	apiProject := model.ProjectAPI{}
+	apiProject.APIYaml.Data.Operations = []model.OperationYaml{
+		{Target: "/pets", Verb: "GET"},
+		{Target: "/pets", Verb: "POST"},
+		{Target: "/pets/{petId}", Verb: "GET"},
+		{Target: "/pets/{petId}", Verb: "REPORT"},
+	}
+	err = mgwSwagger.SetOperationPolicies(apiProject)
+	assert.Nil(t, err, "Error while setting operation policies")
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, 2, len(routes), "Number of routes incorrect")
+
+	for _, route := range routes {
+		methodRegex := route.GetMatch().GetHeaders()[0].GetStringMatch().GetSafeRegex().GetRegex()
+		if strings.Contains(route.GetMatch().GetSafeRegex().GetRegex(), "/pets/") {
+			assert.Equal(t, "^(?:GET|REPORT|OPTIONS)$", methodRegex, "Custom method is not matched exactly")
+		} else {
+			assert.Equal(t, "^(?:GET|POST|OPTIONS)$", methodRegex)
+		}
+	}
+}
+
 // TODO: (VirajSalaka) Fix the cause for the intermittent failure
 // func TestCreateRoutesWithClustersProdSandEp(t *testing.T) {
 // 	// Tested Features
@@ -532,6 +1659,39 @@ func TestCreateHealthEndpoint(t *testing.T) {
 // 		"Sandbox Cluster mismatch in route ext authz context. (Path Level Endpoints)")
 // }
 
+func TestCreateRoutesWithClustersWithUnixSocketEndpoints(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+
+	getAPILevelCluster := func(endpoints string) *clusterv3.Cluster {
+		mgwSwagger := model.MgwSwagger{}
+		err := mgwSwagger.GetMgwSwagger(append([]byte("x-wso2-production-endpoints:\n  urls: "+endpoints+"\n"),
+			openapiByteArr...))
+		assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+		_, clusters, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+		assert.Nil(t, err, "Error while creating routes")
+		for _, cluster := range clusters {
+			if cluster.GetName() == "carbon.super_clusterProd_localhost_SwaggerPetstore1.0.0" {
+				return cluster
+			}
+		}
+		return nil
+	}
+
+	cluster := getAPILevelCluster("[unix:///var/run/petstore.sock]")
+	if assert.NotNil(t, cluster, "Cluster of the unix domain socket endpoint is not created") {
+		address := cluster.GetLoadAssignment().GetEndpoints()[0].GetLbEndpoints()[0].GetEndpoint().GetAddress()
+		assert.Equal(t, "/var/run/petstore.sock", address.GetPipe().GetPath(), "Unix domain socket path mismatch")
+		assert.Nil(t, address.GetSocketAddress(), "Unix domain socket should not have a socket address")
+		assert.Equal(t, clusterv3.Cluster_STATIC, cluster.GetType(), "Unix domain socket cluster should be static")
+		assert.Empty(t, cluster.GetTransportSocketMatches(), "TLS should not be configured for unix domain sockets")
+	}
+
+	assert.Nil(t, getAPILevelCluster("[unix:///var/run/petstore.sock, http://petstore.io]"),
+		"Unix domain socket endpoints should not be combined with the other endpoints of a cluster")
+}
+
 func TestCreateRoutesWithClustersUsingAsyncAPI(t *testing.T) {
 
 	var mgwSwagger model.MgwSwagger
@@ -564,6 +1724,49 @@ func TestFailoverCluster(t *testing.T) {
 	commonTestForClusterPrioritiesInWebSocketAPIWithEnvProps(t, openapiFilePath)
 }
 
+func TestFailoverClusterWithPriorities(t *testing.T) {
+	apiYamlByteArr := []byte(`type: api
+version: v4.1.0
+data:
+  name: EchoWebSocket
+  context: /echowebsocket
+  version: "1.0"
+  type: WS
+  endpointConfig:
+    endpoint_type: failover
+    production_endpoints:
+      url: ws://primary.websocket.org:80
+    production_failovers:
+      - url: ws://third.websocket.org:80
+        priority: 3
+      - url: ws://second.websocket.org:80
+        priority: 1
+      - url: ws://unprioritized.websocket.org:80
+      - url: ws://also-second.websocket.org:80
+        priority: 1
+`)
+	apiYaml, err := model.NewAPIYaml(apiYamlByteArr)
+	assert.Nil(t, err, "Error occurred while processing api.yaml")
+	var mgwSwagger model.MgwSwagger
+	err = mgwSwagger.PopulateFromAPIYaml(apiYaml)
+	assert.Nil(t, err, "Error while populating the MgwSwagger object for web socket APIs")
+	_, clusters, _, _ := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Equal(t, 1, len(clusters), "Number of clusters created incorrect")
+
+	// The failover endpoint without a priority is ranked by its position, which is 3
+	expectedHosts := []string{"primary.websocket.org", "second.websocket.org", "also-second.websocket.org",
+		"third.websocket.org", "unprioritized.websocket.org"}
+	expectedPriorities := []uint32{0, 1, 1, 2, 2}
+	endpoints := clusters[0].GetLoadAssignment().GetEndpoints()
+	if assert.Equal(t, len(expectedHosts), len(endpoints), "Number of endpoints in the cluster mismatch") {
+		for i, endpoint := range endpoints {
+			host := endpoint.GetLbEndpoints()[0].GetEndpoint().GetAddress().GetSocketAddress().GetAddress()
+			assert.Equal(t, expectedHosts[i], host, "Endpoint host mismatch at %d", i)
+			assert.Equal(t, expectedPriorities[i], endpoint.GetPriority(), "Endpoint priority mismatch for %v", host)
+		}
+	}
+}
+
 // commonTestForClusterPriorities use to test loadbalance/failover in WS apis
 func commonTestForClusterPrioritiesInWebSocketAPI(t *testing.T, apiYamlFilePath string) {
 	apiYamlByteArr, err := ioutil.ReadFile(apiYamlFilePath)
@@ -697,3 +1900,110 @@ func testCreateRoutesWithClustersAPIClusters(t *testing.T) {
 
 	assert.Equal(t, 2, len(routes), "Number of routes created is incorrect")
 }
+
+// getKeyTypesOfClusters returns the key types tagged on the clusters by the cluster name
+func getKeyTypesOfClusters(clusters []*clusterv3.Cluster) map[string][]string {
+	keyTypesOfClusters := make(map[string][]string)
+	for _, cluster := range clusters {
+		keyTypeMetadata := cluster.GetMetadata().GetFilterMetadata()["com.wso2.key_type"]
+		for _, keyType := range keyTypeMetadata.GetFields()["key_types"].GetListValue().GetValues() {
+			keyTypesOfClusters[cluster.GetName()] = append(keyTypesOfClusters[cluster.GetName()], keyType.GetStringValue())
+		}
+	}
+	return keyTypesOfClusters
+}
+
+func TestCreateRoutesWithClustersWithKeyTypeMetadata(t *testing.T) {
+	openapiByteArr, err := ioutil.ReadFile(config.GetMgwHome() +
+		"/../adapter/test-resources/envoycodegen/openapi_prod_sand_clusters.yaml")
+	assert.Nil(t, err, "Error while reading the openapi file")
+	prodSandSwagger := model.MgwSwagger{}
+	assert.Nil(t, prodSandSwagger.GetMgwSwagger(openapiByteArr))
+
+	openapiByteArr, err = ioutil.ReadFile(config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml")
+	assert.Nil(t, err, "Error while reading the openapi file")
+	prodSwagger := model.MgwSwagger{}
+	assert.Nil(t, prodSwagger.GetMgwSwagger(openapiByteArr))
+
+	apiYamlByteArr, err := ioutil.ReadFile(config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/api.yaml")
+	assert.Nil(t, err, "Error while reading the api.yaml file")
+	apiYaml, err := model.NewAPIYaml(apiYamlByteArr)
+	assert.Nil(t, err, "Error occurred while processing api.yaml")
+	webSocketSwagger := model.MgwSwagger{}
+	assert.Nil(t, webSocketSwagger.PopulateFromAPIYaml(apiYaml))
+	asyncapiByteArr, err := ioutil.ReadFile(config.GetMgwHome() +
+		"/../adapter/test-resources/envoycodegen/asyncapi_websocket.yaml")
+	assert.Nil(t, err, "Error while reading the asyncapi file")
+	apiJsn, err := utills.ToJSON(asyncapiByteArr)
+	assert.Nil(t, err, "YAML to JSON conversion error")
+	var asyncapi model.AsyncAPI
+	assert.Nil(t, json.Unmarshal(apiJsn, &asyncapi))
+	assert.Nil(t, webSocketSwagger.SetInfoAsyncAPI(asyncapi))
+
+	tests := []struct {
+		name                       string
+		mgwSwagger                 model.MgwSwagger
+		expectedKeyTypesOfClusters map[string][]string
+	}{
+		{
+			name:       "Production endpoints which are used as the sandbox endpoints",
+			mgwSwagger: prodSandSwagger,
+			expectedKeyTypesOfClusters: map[string][]string{
+				"carbon.super_clusterProd_localhost_SwaggerPetstore1.0.0": {"PRODUCTION", "SANDBOX"},
+			},
+		},
+		{
+			name:       "Production endpoints without the sandbox endpoints",
+			mgwSwagger: prodSwagger,
+			expectedKeyTypesOfClusters: map[string][]string{
+				"carbon.super_clusterProd_localhost_SwaggerPetstore1.0.0": {"PRODUCTION"},
+			},
+		},
+		{
+			name:       "Separate production and sandbox endpoints",
+			mgwSwagger: webSocketSwagger,
+			expectedKeyTypesOfClusters: map[string][]string{
+				"carbon.super_clusterProd_localhost_EchoWebSocket1.0": {"PRODUCTION"},
+				"carbon.super_clusterSand_localhost_EchoWebSocket1.0": {"SANDBOX"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			routes, clusters, _, err := envoy.CreateRoutesWithClusters(test.mgwSwagger, nil, nil, "localhost",
+				"carbon.super")
+			assert.Nil(t, err, "Error while creating routes")
+			keyTypesOfClusters := getKeyTypesOfClusters(clusters)
+			for clusterName, expectedKeyTypes := range test.expectedKeyTypesOfClusters {
+				assert.Equal(t, expectedKeyTypes, keyTypesOfClusters[clusterName], "Key types of %v mismatch", clusterName)
+			}
+
+			assert.NotEmpty(t, routes, "Routes are not created")
+			for _, route := range routes {
+				// The cluster is still selected by the header set by the enforcer
+				assert.Equal(t, "x-wso2-cluster-header", route.GetRoute().GetClusterHeader(),
+					"Cluster selection of the route %v should not be changed", route.GetName())
+				keyTypeMetadata := route.GetMetadata().GetFilterMetadata()["com.wso2.key_type"]
+				if !assert.NotNil(t, keyTypeMetadata, "Key type metadata is not added to the route %v", route.GetName()) {
+					continue
+				}
+				assert.Equal(t, "x-wso2-cluster-header", keyTypeMetadata.GetFields()["cluster_header"].GetStringValue())
+				for keyType, clusterName := range keyTypeMetadata.GetFields() {
+					if keyType == "cluster_header" {
+						continue
+					}
+					assert.Contains(t, keyTypesOfClusters[clusterName.GetStringValue()], keyType,
+						"Cluster of the key type %v of the route %v is not tagged with the key type", keyType,
+						route.GetName())
+				}
+				for _, headerMatcher := range route.GetMatch().GetHeaders() {
+					// Sandbox routes are selected by the sandbox cluster set in the header
+					if headerMatcher.GetName() == "x-wso2-cluster-header" {
+						assert.Equal(t, keyTypeMetadata.GetFields()["SANDBOX"].GetStringValue(),
+							headerMatcher.GetStringMatch().GetExact())
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"fmt"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// statusRemapLuaScript remaps the status of the backend responses before the response handler of the route's
+// script (i.e. the interceptors or the wire logs) is invoked, hence those see the remapped status.
+const statusRemapLuaScript = `
+local status_remaps = {
+%s}
+local handle_remapped_response = envoy_on_response
+function envoy_on_response(response_handle)
+	local status = response_handle:headers():get(":status")
+	for _, remap in ipairs(status_remaps) do
+		if status == remap.from then
+			local matched = remap.body_contains == nil
+			if not matched then
+				local body = response_handle:body()
+				matched = body ~= nil and string.find(body:getBytes(0, body:length()), remap.body_contains, 1, true) ~= nil
+			end
+			if matched then
+				response_handle:headers():replace(":status", remap.to)
+				break
+			end
+		end
+	end
+	if handle_remapped_response ~= nil then
+		handle_remapped_response(response_handle)
+	end
+end
+`
+
+// generateStatusRemapLuaConfig returns the Lua filter configuration of a route of an operation remapping the status
+// of the backend responses. The remaps are appended to the script of the route, if the Lua filter is not disabled
+// for the route.
+func generateStatusRemapLuaConfig(routeLuaConfig *lua.LuaPerRoute, statusRemaps []model.StatusRemapConfig) *any.Any {
+	var remaps strings.Builder
+	for _, statusRemap := range statusRemaps {
+		remaps.WriteString(fmt.Sprintf("\t{from = \"%d\", to = \"%d\"", statusRemap.From, statusRemap.To))
+		if statusRemap.BodyContains != "" {
+			remaps.WriteString(", body_contains = " + quoteLuaString(statusRemap.BodyContains))
+		}
+		remaps.WriteString("},\n")
+	}
+	script := routeLuaConfig.GetSourceCode().GetInlineString() + fmt.Sprintf(statusRemapLuaScript, remaps.String())
+
+	luaPerRouteConfig := &lua.LuaPerRoute{
+		Override: &lua.LuaPerRoute_SourceCode{
+			SourceCode: &corev3.DataSource{
+				Specifier: &corev3.DataSource_InlineString{
+					InlineString: script,
+				},
+			},
+		},
+	}
+	luaMarshalled := proto.NewBuffer(nil)
+	luaMarshalled.SetDeterministic(true)
+	_ = luaMarshalled.Marshal(luaPerRouteConfig)
+	return &any.Any{
+		TypeUrl: luaPerRouteName,
+		Value:   luaMarshalled.Bytes(),
+	}
+}
+
+// quoteLuaString returns the value as a Lua string literal. The characters other than the printable ASCII
+// characters are escaped as decimal escapes, which are supported by all the Lua versions.
+func quoteLuaString(value string) string {
+	var quoted strings.Builder
+	quoted.WriteByte('"')
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '"' || c == '\\':
+			quoted.WriteByte('\\')
+			quoted.WriteByte(c)
+		case c < 0x20 || c > 0x7e:
+			quoted.WriteString(fmt.Sprintf("\\%03d", c))
+		default:
+			quoted.WriteByte(c)
+		}
+	}
+	quoted.WriteByte('"')
+	return quoted.String()
+}
@@ -0,0 +1,189 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package extensions decodes the vendor extensions (x-wso2-* etc.) of the API definitions into typed values.
+//
+// Each vendor extension is registered with the type its value is decoded to, and an optional validation.
+// The consumers call Extract instead of looking up and type asserting the raw values of the vendor extension map,
+// hence a malformed value results in a ValidationError carrying the JSON path of the invalid value.
+package extensions
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	parser "github.com/mitchellh/mapstructure"
+)
+
+// ValidationError is returned when the value of a vendor extension does not adhere to the registered type
+// or fails the registered validation.
+type ValidationError struct {
+	// Path is the JSON path of the invalid value, ex: $.x-wso2-cors.accessControlAllowOrigins[0]
+	Path    string
+	Message string
+}
+
+func (err *ValidationError) Error() string {
+	return fmt.Sprintf("invalid value at %s: %s", err.Path, err.Message)
+}
+
+// ValidationErrors holds all the validation errors found while decoding a vendor extension.
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+type extension struct {
+	valueType reflect.Type
+	validate  func(value interface{}) error
+}
+
+var (
+	registryLock sync.RWMutex
+	// vendor extension name -> registered extension
+	registry = make(map[string]extension)
+	// mapstructure errors are prefixed with the quoted field name, ex: 'urls[0]' expected type 'string' ...
+	decodeErrorFieldRegex = regexp.MustCompile(`^(?:cannot parse )?'([^']*)'`)
+)
+
+// Register adds a vendor extension to the registry. The value of the vendor extension is decoded to the type T,
+// using the mapstructure tags of T. validate is optional and it is called with the decoded value. A validate
+// function may return a ValidationError with the Path relative to the vendor extension, which is resolved to the
+// JSON path of the vendor extension by Extract.
+//
+// Registering a vendor extension which is already registered replaces the previous registration.
+func Register[T any](name string, validate func(value T) error) {
+	registered := extension{
+		valueType: reflect.TypeOf((*T)(nil)).Elem(),
+	}
+	if validate != nil {
+		registered.validate = func(value interface{}) error {
+			return validate(value.(T))
+		}
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry[name] = registered
+}
+
+// RegisteredExtensions returns the names of the registered vendor extensions in the sorted order.
+func RegisteredExtensions() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Extract decodes the vendor extension from the vendor extensions map into value. value should be initialized with
+// the default values, which are retained for the properties which are not provided within the vendor extension.
+//
+// found is false if the vendor extension is not present, and value is not modified unless the vendor extension is
+// decoded and validated successfully. If the vendor extension does not adhere to the registered type, the returned
+// error is of the type ValidationErrors. Extract never panics for malformed vendor extension values.
+func Extract[T any](vendorExtensions map[string]interface{}, name string, value *T) (found bool, err error) {
+	rawValue, found := vendorExtensions[name]
+	if !found {
+		return false, nil
+	}
+	registryLock.RLock()
+	registered, isRegistered := registry[name]
+	registryLock.RUnlock()
+	if !isRegistered {
+		return true, fmt.Errorf("vendor extension %s is not registered", name)
+	}
+	if valueType := reflect.TypeOf(value).Elem(); valueType != registered.valueType {
+		return true, fmt.Errorf("vendor extension %s is registered with the type %v, but extracted as %v", name,
+			registered.valueType, valueType)
+	}
+
+	rootPath := "$." + name
+	defer func() {
+		if r := recover(); r != nil {
+			err = ValidationErrors{{Path: rootPath, Message: fmt.Sprint(r)}}
+		}
+	}()
+
+	decodedValue := *value
+	decoder, err := parser.NewDecoder(&parser.DecoderConfig{
+		Result: &decodedValue,
+	})
+	if err != nil {
+		return true, err
+	}
+	if decodeErr := decoder.Decode(rawValue); decodeErr != nil {
+		return true, toValidationErrors(rootPath, decodeErr)
+	}
+	if registered.validate != nil {
+		if validationErr := registered.validate(decodedValue); validationErr != nil {
+			return true, toValidationErrors(rootPath, validationErr)
+		}
+	}
+	*value = decodedValue
+	return true, nil
+}
+
+func toValidationErrors(rootPath string, err error) ValidationErrors {
+	switch e := err.(type) {
+	case *parser.Error:
+		validationErrors := make(ValidationErrors, 0, len(e.Errors))
+		for _, message := range e.Errors {
+			validationErrors = append(validationErrors, toValidationError(rootPath, message))
+		}
+		return validationErrors
+	case *ValidationError:
+		return ValidationErrors{{Path: joinPath(rootPath, e.Path), Message: e.Message}}
+	case ValidationErrors:
+		validationErrors := make(ValidationErrors, len(e))
+		for i, validationErr := range e {
+			validationErrors[i] = &ValidationError{Path: joinPath(rootPath, validationErr.Path),
+				Message: validationErr.Message}
+		}
+		return validationErrors
+	default:
+		return ValidationErrors{toValidationError(rootPath, err.Error())}
+	}
+}
+
+func toValidationError(rootPath, message string) *ValidationError {
+	if match := decodeErrorFieldRegex.FindStringSubmatch(message); match != nil {
+		return &ValidationError{Path: joinPath(rootPath, match[1]), Message: message}
+	}
+	return &ValidationError{Path: rootPath, Message: message}
+}
+
+func joinPath(rootPath, field string) string {
+	if field == "" {
+		return rootPath
+	}
+	if strings.HasPrefix(field, "[") {
+		return rootPath + field
+	}
+	return rootPath + "." + field
+}
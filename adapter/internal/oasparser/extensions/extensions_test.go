@@ -0,0 +1,205 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package extensions
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testObjectExtension = "x-test-object"
+	testStringExtension = "x-test-string"
+	testArrayExtension  = "x-test-array"
+)
+
+type testObject struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Code    uint32   `mapstructure:"code"`
+	Origins []string `mapstructure:"origins"`
+	Nested  struct {
+		Name string `mapstructure:"name"`
+	} `mapstructure:"nested"`
+}
+
+func init() {
+	Register(testObjectExtension, func(value testObject) error {
+		if value.Code != 0 && value.Code < 400 {
+			return &ValidationError{Path: "code", Message: "code should be 400 or above"}
+		}
+		return nil
+	})
+	Register[string](testStringExtension, nil)
+	Register[[]string](testArrayExtension, nil)
+}
+
+func TestExtract(t *testing.T) {
+	vendorExtensions := map[string]interface{}{
+		testObjectExtension: map[string]interface{}{
+			"code":    404,
+			"origins": []interface{}{"http://test.com"},
+			"nested":  map[string]interface{}{"name": "test"},
+		},
+		testStringExtension: "value",
+		testArrayExtension:  []interface{}{"a", "b"},
+	}
+
+	object := testObject{Enabled: true}
+	found, err := Extract(vendorExtensions, testObjectExtension, &object)
+	assert.True(t, found)
+	assert.Nil(t, err)
+	assert.True(t, object.Enabled, "default value should be retained when the property is not provided")
+	assert.Equal(t, uint32(404), object.Code)
+	assert.Equal(t, []string{"http://test.com"}, object.Origins)
+	assert.Equal(t, "test", object.Nested.Name)
+
+	var stringValue string
+	found, err = Extract(vendorExtensions, testStringExtension, &stringValue)
+	assert.True(t, found)
+	assert.Nil(t, err)
+	assert.Equal(t, "value", stringValue)
+
+	var arrayValue []string
+	found, err = Extract(vendorExtensions, testArrayExtension, &arrayValue)
+	assert.True(t, found)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, arrayValue)
+
+	stringValue = "default"
+	found, err = Extract(map[string]interface{}{}, testStringExtension, &stringValue)
+	assert.False(t, found)
+	assert.Nil(t, err)
+	assert.Equal(t, "default", stringValue)
+}
+
+func TestExtractValidationErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		extension string
+		value     interface{}
+		path      string
+	}{
+		{
+			name:      "Invalid type of the extension",
+			extension: testStringExtension,
+			value:     12,
+			path:      "$.x-test-string",
+		},
+		{
+			name:      "Invalid array item",
+			extension: testArrayExtension,
+			value:     []interface{}{"a", true},
+			path:      "$.x-test-array[1]",
+		},
+		{
+			name:      "Object instead of an array",
+			extension: testArrayExtension,
+			value:     map[string]interface{}{"a": "b"},
+			path:      "$.x-test-array",
+		},
+		{
+			name:      "Invalid nested property",
+			extension: testObjectExtension,
+			value:     map[string]interface{}{"nested": map[string]interface{}{"name": []interface{}{}}},
+			path:      "$.x-test-object.nested.name",
+		},
+		{
+			name:      "Invalid array item of a property",
+			extension: testObjectExtension,
+			value:     map[string]interface{}{"origins": []interface{}{"a", "b", 3}},
+			path:      "$.x-test-object.origins[2]",
+		},
+		{
+			name:      "Negative value for an unsigned property",
+			extension: testObjectExtension,
+			value:     map[string]interface{}{"code": -1},
+			path:      "$.x-test-object.code",
+		},
+		{
+			name:      "Failed validation",
+			extension: testObjectExtension,
+			value:     map[string]interface{}{"code": 200},
+			path:      "$.x-test-object.code",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vendorExtensions := map[string]interface{}{test.extension: test.value}
+			var found bool
+			var err error
+			switch test.extension {
+			case testStringExtension:
+				value := "default"
+				found, err = Extract(vendorExtensions, test.extension, &value)
+				assert.Equal(t, "default", value, "value should not be modified when the extension is invalid")
+			case testArrayExtension:
+				var value []string
+				found, err = Extract(vendorExtensions, test.extension, &value)
+				assert.Nil(t, value, "value should not be modified when the extension is invalid")
+			default:
+				value := testObject{Enabled: true}
+				found, err = Extract(vendorExtensions, test.extension, &value)
+				assert.Equal(t, testObject{Enabled: true}, value,
+					"value should not be modified when the extension is invalid")
+			}
+			assert.True(t, found)
+			var validationErrors ValidationErrors
+			if assert.True(t, errors.As(err, &validationErrors), "a validation error is expected, found %v", err) {
+				assert.Equal(t, test.path, validationErrors[0].Path)
+			}
+		})
+	}
+}
+
+func TestExtractUnregisteredOrMismatchedType(t *testing.T) {
+	vendorExtensions := map[string]interface{}{"x-unregistered": "value", testStringExtension: "value"}
+	var value string
+	found, err := Extract(vendorExtensions, "x-unregistered", &value)
+	assert.True(t, found)
+	assert.NotNil(t, err, "unregistered extensions should not be extracted")
+
+	var boolValue bool
+	_, err = Extract(vendorExtensions, testStringExtension, &boolValue)
+	assert.NotNil(t, err, "extensions should be extracted only to the registered type")
+	assert.Contains(t, RegisteredExtensions(), testStringExtension)
+}
+
+func FuzzExtract(f *testing.F) {
+	f.Add(`{"enabled": true, "code": 404, "origins": ["a"], "nested": {"name": "test"}}`)
+	f.Add(`{"enabled": "true", "code": -1, "origins": "a", "nested": []}`)
+	f.Add(`{"code": 1e40, "origins": [null, {}, []], "nested": {"name": {"a": 1}}}`)
+	f.Add(`["a", 1, null, true]`)
+	f.Add(`"value"`)
+	f.Add(`null`)
+	f.Add(`12.5`)
+	f.Fuzz(func(t *testing.T, rawJSON string) {
+		var rawValue interface{}
+		if err := json.Unmarshal([]byte(rawJSON), &rawValue); err != nil {
+			t.Skip()
+		}
+		object := testObject{}
+		Extract(map[string]interface{}{testObjectExtension: rawValue}, testObjectExtension, &object)
+		var stringValue string
+		Extract(map[string]interface{}{testStringExtension: rawValue}, testStringExtension, &stringValue)
+		var arrayValue []string
+		Extract(map[string]interface{}{testArrayExtension: rawValue}, testArrayExtension, &arrayValue)
+	})
+}
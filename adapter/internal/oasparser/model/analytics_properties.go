@@ -0,0 +1,102 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/extensions"
+)
+
+const (
+	// maxAnalyticsProperties is the maximum number of analytics properties of an operation, including the
+	// properties inherited from the API
+	maxAnalyticsProperties = 10
+	// maxAnalyticsPropertyValueLength is the maximum length of the value of an analytics property
+	maxAnalyticsPropertyValueLength = 256
+)
+
+// analyticsPropertyKeyRegex matches the keys of the analytics properties, which are published as the names of the
+// custom dimensions of the analytics events
+var analyticsPropertyKeyRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,63}$`)
+
+func validateAnalyticsProperties(properties map[string]string) error {
+	if len(properties) > maxAnalyticsProperties {
+		return &extensions.ValidationError{
+			Message: fmt.Sprintf("%d analytics properties are given, which exceeds the limit of %d properties",
+				len(properties), maxAnalyticsProperties),
+		}
+	}
+	for key, value := range properties {
+		if !analyticsPropertyKeyRegex.MatchString(key) {
+			return &extensions.ValidationError{
+				Path: key,
+				Message: "invalid key. The key should start with a letter and contain only letters, digits and " +
+					"underscores, up to 64 characters",
+			}
+		}
+		if len(value) > maxAnalyticsPropertyValueLength {
+			return &extensions.ValidationError{
+				Path:    key,
+				Message: fmt.Sprintf("value exceeds the limit of %d characters", maxAnalyticsPropertyValueLength),
+			}
+		}
+	}
+	return nil
+}
+
+// setXWso2AnalyticsProperties sets the analytics properties of the operations, given by the
+// x-wso2-analytics-properties extensions of the API and the operations. The properties of the operation override the
+// properties of the API with the same key. An error is returned if the properties are invalid.
+func (swagger *MgwSwagger) setXWso2AnalyticsProperties() error {
+	var apiProperties map[string]string
+	if _, err := extensions.Extract(swagger.vendorExtensions, constants.XWso2AnalyticsProperties,
+		&apiProperties); err != nil {
+		return fmt.Errorf("invalid %s of the API. %v", constants.XWso2AnalyticsProperties, err)
+	}
+	for _, resource := range swagger.resources {
+		for _, operation := range resource.methods {
+			var operationProperties map[string]string
+			if _, err := extensions.Extract(operation.vendorExtensions, constants.XWso2AnalyticsProperties,
+				&operationProperties); err != nil {
+				return fmt.Errorf("invalid %s of the operation %s %s. %v", constants.XWso2AnalyticsProperties,
+					operation.method, resource.path, err)
+			}
+			if len(apiProperties) == 0 && len(operationProperties) == 0 {
+				continue
+			}
+			properties := make(map[string]string, len(apiProperties)+len(operationProperties))
+			for key, value := range apiProperties {
+				properties[key] = value
+			}
+			for key, value := range operationProperties {
+				properties[key] = value
+			}
+			if len(properties) > maxAnalyticsProperties {
+				return fmt.Errorf("operation %s %s has %d analytics properties along with the analytics properties "+
+					"of the API, which exceeds the limit of %d properties", operation.method, resource.path,
+					len(properties), maxAnalyticsProperties)
+			}
+			operation.analyticsProperties = properties
+			resource.hasPolicies = true // to add the properties only to the routes of this operation
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+func TestSetXWso2AnalyticsProperties(t *testing.T) {
+	newSwagger := func(apiProperties, getProperties map[string]interface{}) *MgwSwagger {
+		var apiExtensions, getExtensions map[string]interface{}
+		if apiProperties != nil {
+			apiExtensions = map[string]interface{}{constants.XWso2AnalyticsProperties: apiProperties}
+		}
+		if getProperties != nil {
+			getExtensions = map[string]interface{}{constants.XWso2AnalyticsProperties: getProperties}
+		}
+		return &MgwSwagger{
+			vendorExtensions: apiExtensions,
+			resources: []*Resource{
+				{path: "/pets", methods: []*Operation{NewOperation("GET", nil, getExtensions),
+					NewOperation("POST", nil, nil)}},
+			},
+		}
+	}
+
+	mgwSwagger := newSwagger(map[string]interface{}{"productLine": "retail", "channel": "web"},
+		map[string]interface{}{"channel": "mobile"})
+	assert.NoError(t, mgwSwagger.setXWso2AnalyticsProperties())
+	assert.Equal(t, map[string]string{"productLine": "retail", "channel": "mobile"},
+		mgwSwagger.resources[0].methods[0].GetAnalyticsProperties(),
+		"Properties of the operation should override the properties of the API")
+	assert.Equal(t, map[string]string{"productLine": "retail", "channel": "web"},
+		mgwSwagger.resources[0].methods[1].GetAnalyticsProperties(),
+		"Properties of the API should be inherited by the operations")
+	assert.True(t, mgwSwagger.resources[0].hasPolicies,
+		"Properties should be applied only to the routes of the operations")
+
+	withoutProperties := newSwagger(nil, nil)
+	assert.NoError(t, withoutProperties.setXWso2AnalyticsProperties())
+	assert.Nil(t, withoutProperties.resources[0].methods[0].GetAnalyticsProperties())
+	assert.False(t, withoutProperties.resources[0].hasPolicies)
+
+	tooManyProperties := make(map[string]interface{})
+	for i := 0; i <= maxAnalyticsProperties; i++ {
+		tooManyProperties[fmt.Sprintf("property%d", i)] = "value"
+	}
+	// properties within the limit separately, but exceeding the limit when merged
+	apiProperties := make(map[string]interface{})
+	for i := 0; i < maxAnalyticsProperties; i++ {
+		apiProperties[fmt.Sprintf("apiProperty%d", i)] = "value"
+	}
+	invalidSwaggers := map[string]*MgwSwagger{
+		"key starts with a digit":  newSwagger(map[string]interface{}{"1channel": "web"}, nil),
+		"key has a hyphen":         newSwagger(nil, map[string]interface{}{"product-line": "retail"}),
+		"value is not a string":    newSwagger(nil, map[string]interface{}{"channel": []string{"web"}}),
+		"API has too many":         newSwagger(tooManyProperties, nil),
+		"operation has too many":   newSwagger(nil, tooManyProperties),
+		"merged ones are too many": newSwagger(apiProperties, map[string]interface{}{"channel": "mobile"}),
+	}
+	for name, invalidSwagger := range invalidSwaggers {
+		assert.Error(t, invalidSwagger.setXWso2AnalyticsProperties(), "Properties should be rejected when the %s",
+			name)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// setAnonymousTiers sets the throttling tier of the operations whose security is disabled, which bypass the
+// subscription throttling otherwise. The tier is given by the x-wso2-anonymous-tier extension of the operation, or
+// else of the API, or else by the anonymous throttling tier of the adapter configuration.
+//
+// This needs to be called once the security of the operations is final (i.e. after the public paths are applied).
+// An error is returned if the extension is set for an operation whose security is enabled.
+func (swagger *MgwSwagger) setAnonymousTiers() error {
+	conf, _ := config.ReadConfigs()
+	apiTier, _ := getStringExtension(swagger.vendorExtensions, constants.XWso2AnonymousTier)
+	if apiTier == "" {
+		apiTier = conf.Adapter.AnonymousThrottlingTier
+	}
+	for _, resource := range swagger.resources {
+		for _, operation := range resource.methods {
+			operationTier, found := getStringExtension(operation.vendorExtensions, constants.XWso2AnonymousTier)
+			securityDisabled := swagger.disableSecurity || operation.disableSecurity
+			if found && !securityDisabled {
+				return fmt.Errorf("%s is set for the operation %s %s whose security is enabled",
+					constants.XWso2AnonymousTier, operation.method, resource.path)
+			}
+			if !securityDisabled {
+				continue
+			}
+			if operationTier == "" {
+				operationTier = apiTier
+			}
+			operation.anonymousTier = operationTier
+		}
+	}
+	return nil
+}
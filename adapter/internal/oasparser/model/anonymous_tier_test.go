@@ -0,0 +1,77 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+func TestSetAnonymousTiers(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousAnonymousTier := conf.Adapter.AnonymousThrottlingTier
+	defer func() {
+		conf.Adapter.AnonymousThrottlingTier = previousAnonymousTier
+	}()
+	conf.Adapter.AnonymousThrottlingTier = "Unauthenticated"
+
+	disableSecurity := map[string]interface{}{constants.XWso2DisableSecurity: true}
+	mgwSwagger := MgwSwagger{
+		title:   "PetStore",
+		version: "1.0.0",
+		vendorExtensions: map[string]interface{}{
+			constants.XWso2AnonymousTier: "10PerMin",
+		},
+		resources: []*Resource{
+			{path: "/pets", methods: []*Operation{NewOperation("GET", nil, nil),
+				NewOperation("POST", nil, disableSecurity)}},
+			{path: "/health", methods: []*Operation{NewOperation("GET", nil, map[string]interface{}{
+				constants.XWso2DisableSecurity: true,
+				constants.XWso2AnonymousTier:   "1000PerMin",
+			})}},
+		},
+	}
+	assert.NoError(t, mgwSwagger.setAnonymousTiers())
+	assert.Empty(t, mgwSwagger.resources[0].methods[0].GetAnonymousTier(),
+		"Anonymous tier should not be applied to the secured operations")
+	assert.Equal(t, "10PerMin", mgwSwagger.resources[0].methods[1].GetAnonymousTier(),
+		"Anonymous tier of the API should be applied to the operations without security")
+	assert.Equal(t, "1000PerMin", mgwSwagger.resources[1].methods[0].GetAnonymousTier(),
+		"Anonymous tier of the operation should override the anonymous tier of the API")
+
+	delete(mgwSwagger.vendorExtensions, constants.XWso2AnonymousTier)
+	assert.NoError(t, mgwSwagger.setAnonymousTiers())
+	assert.Equal(t, "Unauthenticated", mgwSwagger.resources[0].methods[1].GetAnonymousTier(),
+		"Anonymous tier of the config should be applied if the API does not set one")
+
+	mgwSwagger.resources[0].methods[0] = NewOperation("GET", nil, map[string]interface{}{
+		constants.XWso2AnonymousTier: "10PerMin",
+	})
+	err := mgwSwagger.setAnonymousTiers()
+	if assert.Error(t, err, "Anonymous tier should be rejected for the secured operations") {
+		assert.Contains(t, err.Error(), "GET /pets")
+	}
+
+	mgwSwagger.disableSecurity = true
+	assert.NoError(t, mgwSwagger.setAnonymousTiers(),
+		"Anonymous tier should be allowed for all the operations if the security of the API is disabled")
+	assert.Equal(t, "10PerMin", mgwSwagger.resources[0].methods[0].GetAnonymousTier())
+}
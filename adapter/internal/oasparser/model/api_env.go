@@ -77,10 +77,11 @@ func RetrieveEndpointBasicAuthCredentialsFromEnv(apiHashValue string, keyType st
 func resolveEnvValueForEndpointConfig(envKey string, defaultVal string) string {
 	envValue, exists := os.LookupEnv(envKey)
 	if exists {
-		loggers.LoggerAPI.Debugf("resolve env value %v", envValue)
+		// The value is not logged as it could be a credential
+		loggers.LoggerAPI.Debugf("resolve env value of %v", envKey)
 		envUnquoted, err := strconv.Unquote(envValue)
 		if err != nil {
-			loggers.LoggerAPI.Debugf("Unquoting string %v in env variables has failed. %v", envValue, err.Error())
+			loggers.LoggerAPI.Debugf("Unquoting the value of %v in env variables has failed. %v", envKey, err.Error())
 			// unquoting has failed usually means it was unquoted and in correct format originally
 			return envValue
 		}
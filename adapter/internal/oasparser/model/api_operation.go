@@ -23,8 +23,10 @@ import (
 	"encoding/json"
 	"errors"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-openapi/spec"
@@ -45,6 +47,33 @@ type Operation struct {
 	vendorExtensions map[string]interface{}
 	policies         OperationPolicies
 	mockedAPIConfig  *api.MockedApiConfig
+	deprecated       bool
+	// query parameters which should be present in a request to match the operation
+	requiredQueryParams []string
+	rewritePath         *RewritePathConfig
+	awsLambda           *AwsLambdaConfig
+	// route timeout of the operation, which overrides the API and endpoint timeouts. 0 if not set.
+	timeout time.Duration
+	// remaps of the status codes of the backend responses, applied in the order
+	statusRemaps []StatusRemapConfig
+	// request headers by which the cached responses of the operation vary
+	cacheVaryHeaders []string
+	// media types of the request and response payloads declared in the API definition
+	consumes []string
+	produces []string
+	// true if the operation upgrades the connection to a WebSocket, given by the x-wso2-websocket extension
+	webSocket bool
+	// idle timeout of the upgraded WebSocket connections of the operation. 0 if not set.
+	webSocketIdleTimeout time.Duration
+	// public path pattern matching the path of the operation, which disabled the security of the operation
+	publicPathPattern string
+	// throttling tier applied to the operation if its security is disabled, instead of the subscription throttling
+	anonymousTier string
+	// mocked responses selected by the value of a request header, given by the x-wso2-mock-response-selection
+	// extension. nil if not set.
+	mockResponseSelection *MockResponseSelection
+	// custom dimensions of the analytics events of the operation, including the properties inherited from the API
+	analyticsProperties map[string]string
 }
 
 // SetMockedAPIConfigOAS3 generate mock impl endpoint configurations
@@ -163,6 +192,40 @@ func (operation *Operation) SetMockedAPIConfigOAS2(openAPIOperation *spec.Operat
 	}
 }
 
+// SetMediaTypesOAS3 sets the media types of the request and response payloads declared in the OpenAPI 3 operation
+func (operation *Operation) SetMediaTypesOAS3(openAPIOperation *openapi3.Operation) {
+	if openAPIOperation.RequestBody != nil && openAPIOperation.RequestBody.Value != nil {
+		for mediaType := range openAPIOperation.RequestBody.Value.Content {
+			operation.consumes = append(operation.consumes, mediaType)
+		}
+	}
+	for _, responseRef := range openAPIOperation.Responses {
+		if responseRef == nil || responseRef.Value == nil {
+			continue
+		}
+		for mediaType := range responseRef.Value.Content {
+			if !arrayContains(operation.produces, mediaType) {
+				operation.produces = append(operation.produces, mediaType)
+			}
+		}
+	}
+	sort.Strings(operation.consumes)
+	sort.Strings(operation.produces)
+}
+
+// SetMediaTypesOAS2 sets the media types of the request and response payloads declared in the swagger 2 operation.
+// The media types declared for the API are used when those are not declared for the operation.
+func (operation *Operation) SetMediaTypesOAS2(openAPIOperation *spec.Operation, apiConsumes, apiProduces []string) {
+	operation.consumes = apiConsumes
+	if len(openAPIOperation.Consumes) > 0 {
+		operation.consumes = openAPIOperation.Consumes
+	}
+	operation.produces = apiProduces
+	if len(openAPIOperation.Produces) > 0 {
+		operation.produces = openAPIOperation.Produces
+	}
+}
+
 // convertToJSON parse interface to JSON string. returns error if a null value has passed
 func convertToJSON(data interface{}) (string, error) {
 	if data != nil {
@@ -190,6 +253,79 @@ func (operation *Operation) GetPolicies() *OperationPolicies {
 	return &operation.policies
 }
 
+// IsDeprecated returns true if the operation is marked as deprecated in the api.yaml
+func (operation *Operation) IsDeprecated() bool {
+	return operation.deprecated
+}
+
+// GetRequiredQueryParams returns the query parameters which should be present in a request to match the operation
+func (operation *Operation) GetRequiredQueryParams() []string {
+	return operation.requiredQueryParams
+}
+
+// GetRewritePath returns the regex substitution applied to the request path of the operation, if any
+func (operation *Operation) GetRewritePath() *RewritePathConfig {
+	return operation.rewritePath
+}
+
+// GetAwsLambdaConfig returns the AWS Lambda function invoked as the backend of the operation, if any
+func (operation *Operation) GetAwsLambdaConfig() *AwsLambdaConfig {
+	return operation.awsLambda
+}
+
+// GetTimeout returns the route timeout of the operation, or 0 if the API and endpoint timeouts are applied
+func (operation *Operation) GetTimeout() time.Duration {
+	return operation.timeout
+}
+
+// GetStatusRemaps returns the remaps of the status codes of the backend responses of the operation, if any
+func (operation *Operation) GetStatusRemaps() []StatusRemapConfig {
+	return operation.statusRemaps
+}
+
+// GetCacheVaryHeaders returns the request headers by which the cached responses of the operation vary, if any
+func (operation *Operation) GetCacheVaryHeaders() []string {
+	return operation.cacheVaryHeaders
+}
+
+// GetConsumes returns the media types of the request payload declared for the operation
+func (operation *Operation) GetConsumes() []string {
+	return operation.consumes
+}
+
+// GetProduces returns the media types of the response payload declared for the operation
+func (operation *Operation) GetProduces() []string {
+	return operation.produces
+}
+
+// IsWebSocket returns true if the operation upgrades the connection to a WebSocket
+func (operation *Operation) IsWebSocket() bool {
+	return operation.webSocket
+}
+
+// GetWebSocketIdleTimeout returns the idle timeout of the upgraded WebSocket connections of the operation, or 0 if
+// the route idle timeout is applied
+func (operation *Operation) GetWebSocketIdleTimeout() time.Duration {
+	return operation.webSocketIdleTimeout
+}
+
+// GetPublicPathPattern returns the public path pattern which disabled the security of the operation, if any
+func (operation *Operation) GetPublicPathPattern() string {
+	return operation.publicPathPattern
+}
+
+// GetAnonymousTier returns the throttling tier applied to the operation if its security is disabled, or an empty
+// string if no tier is applied
+func (operation *Operation) GetAnonymousTier() string {
+	return operation.anonymousTier
+}
+
+// GetAnalyticsProperties returns the analytics properties of the operation, which are merged with the analytics
+// properties of the API
+func (operation *Operation) GetAnalyticsProperties() map[string]string {
+	return operation.analyticsProperties
+}
+
 // GetSecurity returns the security schemas defined for the http opeartion
 func (operation *Operation) GetSecurity() []map[string][]string {
 	return operation.security
@@ -210,6 +346,12 @@ func (operation *Operation) GetMockedAPIConfig() *api.MockedApiConfig {
 	return operation.mockedAPIConfig
 }
 
+// GetMockResponseSelection returns the mocked responses of the operation selected by a request header, or nil if the
+// mocked responses are not selected by a header
+func (operation *Operation) GetMockResponseSelection() *MockResponseSelection {
+	return operation.mockResponseSelection
+}
+
 // GetVendorExtensions returns vendor extensions which are explicitly defined under
 // a given resource.
 func (operation *Operation) GetVendorExtensions() map[string]interface{} {
@@ -277,5 +419,14 @@ func NewOperation(method string, security []map[string][]string, extensions map[
 	tier := ResolveThrottlingTier(extensions)
 	disableSecurity := ResolveDisableSecurity(extensions)
 	id := uuid.New().String()
-	return &Operation{id, method, security, tier, disableSecurity, extensions, OperationPolicies{}, &api.MockedApiConfig{}}
+	return &Operation{
+		iD:               id,
+		method:           method,
+		security:         security,
+		tier:             tier,
+		disableSecurity:  disableSecurity,
+		vendorExtensions: extensions,
+		policies:         OperationPolicies{},
+		mockedAPIConfig:  &api.MockedApiConfig{},
+	}
 }
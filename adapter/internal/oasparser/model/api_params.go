@@ -0,0 +1,148 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// APIParams represents content of api_params.yaml file of an API_CTL project, which overrides the configurations of
+// the API per environment
+type APIParams struct {
+	Environments []APIParamsEnvironment `yaml:"environments"`
+}
+
+// APIParamsEnvironment represents the configurations of the API overridden for an environment in api_params.yaml
+type APIParamsEnvironment struct {
+	Name    string           `yaml:"name"`
+	Configs APIParamsConfigs `yaml:"configs"`
+}
+
+// APIParamsConfigs represents the endpoints and the endpoint security of the API overridden in api_params.yaml
+type APIParamsConfigs struct {
+	Endpoints APIParamsEndpoints `yaml:"endpoints"`
+	Security  APIParamsSecurity  `yaml:"security"`
+}
+
+// APIParamsEndpoints represents the production and sandbox endpoints in api_params.yaml
+type APIParamsEndpoints struct {
+	Production *APIParamsEndpoint `yaml:"production"`
+	Sandbox    *APIParamsEndpoint `yaml:"sandbox"`
+}
+
+// APIParamsEndpoint represents an endpoint in api_params.yaml
+type APIParamsEndpoint struct {
+	URL string `yaml:"url"`
+}
+
+// APIParamsSecurity represents the production and sandbox endpoint security in api_params.yaml
+type APIParamsSecurity struct {
+	Production *APIParamsEndpointSecurity `yaml:"production"`
+	Sandbox    *APIParamsEndpointSecurity `yaml:"sandbox"`
+}
+
+// APIParamsEndpointSecurity represents an endpoint security in api_params.yaml
+type APIParamsEndpointSecurity struct {
+	Enabled  bool   `yaml:"enabled"`
+	Type     string `yaml:"type"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// GetAPIParamsProvenanceSource returns the provenance source of the configurations overridden by api_params.yaml for
+// the environment.
+func GetAPIParamsProvenanceSource(environment string) string {
+	return ProvenanceSourceAPIParamsPrefix + environment
+}
+
+// SetAPIParamsEndpoints overrides the production and sandbox endpoints of the API by the endpoints of api_params.yaml.
+func (swagger *MgwSwagger) SetAPIParamsEndpoints(params APIParamsConfigs) error {
+	if params.Endpoints.Production != nil && params.Endpoints.Production.URL != "" {
+		endpoint, err := getHTTPEndpoint(params.Endpoints.Production.URL)
+		if err != nil {
+			return fmt.Errorf("invalid production endpoint in api_params.yaml. %v", err)
+		}
+		logger.LoggerOasparser.Infof("Production endpoints is overridden by api_params.yaml for %v : %v",
+			swagger.title, swagger.version)
+		swagger.productionEndpoints = generateEndpointCluster(constants.ProdClustersConfigNamePrefix,
+			[]Endpoint{*endpoint}, constants.LoadBalance)
+	}
+	if params.Endpoints.Sandbox != nil && params.Endpoints.Sandbox.URL != "" {
+		endpoint, err := getHTTPEndpoint(params.Endpoints.Sandbox.URL)
+		if err != nil {
+			return fmt.Errorf("invalid sandbox endpoint in api_params.yaml. %v", err)
+		}
+		logger.LoggerOasparser.Infof("Sandbox endpoints is overridden by api_params.yaml for %v : %v",
+			swagger.title, swagger.version)
+		swagger.sandboxEndpoints = generateEndpointCluster(constants.SandClustersConfigNamePrefix,
+			[]Endpoint{*endpoint}, constants.LoadBalance)
+	}
+	return nil
+}
+
+// SetAPIParamsEndpointSecurity overrides the production and sandbox endpoint security of the API by the endpoint
+// security of api_params.yaml. The endpoint security is disabled if it is not enabled in api_params.yaml.
+func (swagger *MgwSwagger) SetAPIParamsEndpointSecurity(params APIParamsConfigs) error {
+	prodSecurity, err := getAPIParamsEndpointSecurity(params.Security.Production, "production")
+	if err != nil {
+		return err
+	}
+	sandSecurity, err := getAPIParamsEndpointSecurity(params.Security.Sandbox, "sandbox")
+	if err != nil {
+		return err
+	}
+	if prodSecurity != nil && swagger.productionEndpoints != nil {
+		logger.LoggerOasparser.Infof("Production endpoint security is overridden by api_params.yaml for %v : %v",
+			swagger.title, swagger.version)
+		swagger.productionEndpoints.SecurityConfig = *prodSecurity
+	}
+	if sandSecurity != nil && swagger.sandboxEndpoints != nil {
+		logger.LoggerOasparser.Infof("Sandbox endpoint security is overridden by api_params.yaml for %v : %v",
+			swagger.title, swagger.version)
+		swagger.sandboxEndpoints.SecurityConfig = *sandSecurity
+	}
+	return nil
+}
+
+func getAPIParamsEndpointSecurity(paramsSecurity *APIParamsEndpointSecurity,
+	endpointType string) (*EndpointSecurity, error) {
+	if paramsSecurity == nil {
+		return nil, nil
+	}
+	if !paramsSecurity.Enabled {
+		return &EndpointSecurity{}, nil
+	}
+	securityType := strings.ToUpper(paramsSecurity.Type)
+	if securityType == "" {
+		securityType = "BASIC"
+	}
+	if securityType != "BASIC" {
+		return nil, fmt.Errorf("%v endpoint security type : %v in api_params.yaml is not currently supported with "+
+			"WSO2 Choreo Connect", endpointType, paramsSecurity.Type)
+	}
+	if paramsSecurity.Username == "" || paramsSecurity.Password == "" {
+		return nil, fmt.Errorf("username and password are not provided with the %v endpoint security in api_params.yaml",
+			endpointType)
+	}
+	return &EndpointSecurity{Enabled: true, Type: securityType, Username: paramsSecurity.Username,
+		Password: paramsSecurity.Password}, nil
+}
@@ -0,0 +1,205 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/utills"
+)
+
+// httpOnlyExtensions maps the vendor extensions of the API definitions, which are only applied to the HTTP APIs, to
+// the features of the APITypeAllowedFeatures matrix.
+var httpOnlyExtensions = map[string]string{
+	constants.XWso2RequestInterceptor:           constants.APIFeatureInterceptors,
+	constants.XWso2ResponseInterceptor:          constants.APIFeatureInterceptors,
+	constants.XMediationScript:                  constants.APIFeatureHTTPExtensions,
+	constants.XWso2PassRequestPayloadToEnforcer: constants.APIFeatureHTTPExtensions,
+	constants.XWso2NotFoundResponse:             constants.APIFeatureHTTPExtensions,
+	constants.XWso2UnmatchedRequests:            constants.APIFeatureHTTPExtensions,
+	constants.XWso2WebSocket:                    constants.APIFeatureHTTPExtensions,
+	constants.XWso2WebSocketIdleTimeout:         constants.APIFeatureHTTPExtensions,
+	constants.XWso2PublicPaths:                  constants.APIFeatureHTTPExtensions,
+	constants.XWso2UpstreamDecompression:        constants.APIFeatureHTTPExtensions,
+}
+
+// mediaTypeKeys are the keys of the API definitions listing the media types consumed and produced by the API.
+var mediaTypeKeys = []string{"consumes", "produces"}
+
+// APITypeFeatureViolation is a construct of an API project, which uses a feature not allowed for the API type.
+type APITypeFeatureViolation struct {
+	Feature  string
+	Location string
+}
+
+func (violation APITypeFeatureViolation) String() string {
+	return fmt.Sprintf("%s (%s)", violation.Location, violation.Feature)
+}
+
+// ValidateAPITypeFeatures validates that the API project only uses the features allowed for its API type by the
+// APITypeAllowedFeatures matrix, as the features of the other API types are partially applied, if at all. The API
+// project is rejected if it uses any other feature, unless the strict validation is disabled from the config, where
+// those are reported as the unsupported features of the API project.
+func (apiProject *ProjectAPI) ValidateAPITypeFeatures() error {
+	violations := apiProject.GetAPITypeFeatureViolations()
+	if len(violations) == 0 {
+		return nil
+	}
+	apiYaml := apiProject.APIYaml.Data
+	offendingConstructs := make([]string, len(violations))
+	for i, violation := range violations {
+		offendingConstructs[i] = violation.String()
+	}
+	errMsg := fmt.Sprintf("%s API %s:%s uses the constructs not supported for %s APIs: %s", apiYaml.APIType,
+		apiYaml.Name, apiYaml.Version, apiYaml.APIType, strings.Join(offendingConstructs, ", "))
+	conf, _ := config.ReadConfigs()
+	if conf.Adapter.StrictAPITypeFeatures {
+		return errors.New(errMsg)
+	}
+	loggers.LoggerOasparser.Warn(errMsg)
+	var features []string
+	locationsByFeature := make(map[string][]string)
+	for _, violation := range violations {
+		if _, found := locationsByFeature[violation.Feature]; !found {
+			features = append(features, violation.Feature)
+		}
+		locationsByFeature[violation.Feature] = append(locationsByFeature[violation.Feature], violation.Location)
+	}
+	for _, feature := range features {
+		apiProject.UnsupportedFeatures = appendUnsupportedFeatureWithMessage(apiProject.UnsupportedFeatures,
+			apiYaml.APIType+":"+feature, fmt.Sprintf("%s is not supported for %s APIs, hence ignored: %s", feature,
+				apiYaml.APIType, strings.Join(locationsByFeature[feature], ", ")))
+	}
+	return nil
+}
+
+// GetAPITypeFeatureViolations returns the constructs of the api.yaml and the API definition of the API project which
+// use the features not allowed for the API type, along with their locations.
+func (apiProject *ProjectAPI) GetAPITypeFeatureViolations() []APITypeFeatureViolation {
+	allowedFeatures, restricted := constants.APITypeAllowedFeatures[apiProject.APIYaml.Data.APIType]
+	if !restricted {
+		return nil
+	}
+	var violations []APITypeFeatureViolation
+	addViolation := func(feature, location string) {
+		if !arrayContains(allowedFeatures, feature) {
+			violations = append(violations, APITypeFeatureViolation{Feature: feature, Location: location})
+		}
+	}
+
+	apiYaml := apiProject.APIYaml.Data
+	if apiYaml.EndpointImplementationType == constants.MockedOASEndpointType {
+		addViolation(constants.APIFeatureMockedEndpoint, "api.yaml endpointImplementationType")
+	}
+	if apiYaml.EndpointConfig.EndpointType == constants.AwsLambda {
+		addViolation(constants.APIFeatureAwsLambda, "api.yaml endpointConfig.endpoint_type")
+	}
+	if apiYaml.BufferRequest {
+		addViolation(constants.APIFeatureBufferRequest, "api.yaml bufferRequest")
+	}
+	for i, operation := range apiYaml.Operations {
+		location := fmt.Sprintf("api.yaml operations[%d] (%s %s)", i, operation.Verb, operation.Target)
+		policies := operation.OperationPolicies
+		if len(policies.Request) > 0 || len(policies.Response) > 0 || len(policies.Fault) > 0 {
+			addViolation(constants.APIFeatureOperationPolicies, location+" operationPolicies")
+		}
+		if len(operation.RequiredQueryParams) > 0 {
+			addViolation(constants.APIFeatureRequiredQueryParams, location+" requiredQueryParams")
+		}
+		if operation.RewritePath != nil {
+			addViolation(constants.APIFeatureRewritePath, location+" rewritePath")
+		}
+		if operation.AwsLambda != nil {
+			addViolation(constants.APIFeatureAwsLambda, location+" awsLambda")
+		}
+		if len(operation.RemapStatus) > 0 {
+			addViolation(constants.APIFeatureRemapStatus, location+" remapStatus")
+		}
+	}
+
+	for _, definitionViolation := range getDefinitionFeatureViolations(apiProject.APIDefinition) {
+		addViolation(definitionViolation.Feature, definitionViolation.Location)
+	}
+	return violations
+}
+
+// getDefinitionFeatureViolations returns the HTTP only constructs of the API definition, which are looked up in the
+// root of the definition, the channels and the operations of the channels.
+func getDefinitionFeatureViolations(apiDefinition []byte) []APITypeFeatureViolation {
+	if len(apiDefinition) == 0 {
+		return nil
+	}
+	definitionJsn, err := utills.ToJSON(apiDefinition)
+	if err != nil {
+		return nil
+	}
+	var definition map[string]interface{}
+	if err = json.Unmarshal(definitionJsn, &definition); err != nil {
+		// Invalid definitions are reported when those are parsed.
+		return nil
+	}
+	violations := getObjectFeatureViolations(definition, "API definition")
+	channels, _ := definition["channels"].(map[string]interface{})
+	channelNames := make([]string, 0, len(channels))
+	for channelName := range channels {
+		channelNames = append(channelNames, channelName)
+	}
+	sort.Strings(channelNames)
+	for _, channelName := range channelNames {
+		channel, ok := channels[channelName].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		location := fmt.Sprintf("API definition channels.%s", channelName)
+		violations = append(violations, getObjectFeatureViolations(channel, location)...)
+		for _, operationName := range []string{"subscribe", "publish"} {
+			if operation, ok := channel[operationName].(map[string]interface{}); ok {
+				violations = append(violations, getObjectFeatureViolations(operation,
+					location+"."+operationName)...)
+			}
+		}
+	}
+	return violations
+}
+
+// getObjectFeatureViolations returns the HTTP only extensions and media type lists of an object of the API
+// definition.
+func getObjectFeatureViolations(object map[string]interface{}, location string) []APITypeFeatureViolation {
+	var violations []APITypeFeatureViolation
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if feature, found := httpOnlyExtensions[key]; found {
+			violations = append(violations, APITypeFeatureViolation{Feature: feature, Location: location + " " + key})
+		} else if arrayContains(mediaTypeKeys, key) {
+			violations = append(violations, APITypeFeatureViolation{Feature: constants.APIFeatureMediaTypes,
+				Location: location + " " + key})
+		}
+	}
+	return violations
+}
@@ -0,0 +1,106 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+const wsAPIDefinitionWithHTTPConstructs = `asyncapi: 2.0.0
+info:
+  title: Notifications
+  version: 1.0.0
+x-wso2-request-interceptor:
+  serviceURL: https://interceptor:8443
+channels:
+  /notifications:
+    x-wso2-public-paths:
+      - /notifications
+    subscribe:
+      x-auth-type: Any
+      x-wso2-not-found-response:
+        statusCode: 404
+`
+
+func getWSProjectWithHTTPConstructs() ProjectAPI {
+	apiProject := ProjectAPI{APIDefinition: []byte(wsAPIDefinitionWithHTTPConstructs)}
+	apiProject.APIYaml.Data.Name = "Notifications"
+	apiProject.APIYaml.Data.Version = "1.0.0"
+	apiProject.APIYaml.Data.APIType = constants.WS
+	apiProject.APIYaml.Data.EndpointImplementationType = constants.MockedOASEndpointType
+	apiProject.APIYaml.Data.Operations = []OperationYaml{{
+		Target:              "/notifications",
+		Verb:                "SUBSCRIBE",
+		RequiredQueryParams: []string{"topic"},
+		OperationPolicies: OperationPolicies{
+			Request: PolicyList{{PolicyName: "addHeader"}},
+		},
+	}}
+	return apiProject
+}
+
+func TestGetAPITypeFeatureViolations(t *testing.T) {
+	apiProject := getWSProjectWithHTTPConstructs()
+	violations := apiProject.GetAPITypeFeatureViolations()
+	assert.Equal(t, []APITypeFeatureViolation{
+		{constants.APIFeatureMockedEndpoint, "api.yaml endpointImplementationType"},
+		{constants.APIFeatureRequiredQueryParams, "api.yaml operations[0] (SUBSCRIBE /notifications) requiredQueryParams"},
+		{constants.APIFeatureInterceptors, "API definition x-wso2-request-interceptor"},
+		{constants.APIFeatureHTTPExtensions, "API definition channels./notifications x-wso2-public-paths"},
+		{constants.APIFeatureHTTPExtensions, "API definition channels./notifications.subscribe x-wso2-not-found-response"},
+	}, violations, "Operation policies are allowed for WS APIs, unlike the other HTTP only constructs")
+
+	apiProject.APIYaml.Data.APIType = constants.WEBHOOK
+	assert.Contains(t, apiProject.GetAPITypeFeatureViolations(), APITypeFeatureViolation{
+		constants.APIFeatureOperationPolicies, "api.yaml operations[0] (SUBSCRIBE /notifications) operationPolicies"},
+		"Operation policies should not be allowed for WEBHOOK APIs")
+
+	apiProject.APIYaml.Data.APIType = constants.HTTP
+	assert.Empty(t, apiProject.GetAPITypeFeatureViolations(), "HTTP APIs should be allowed to use all the features")
+}
+
+func TestValidateAPITypeFeatures(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousStrictAPITypeFeatures := conf.Adapter.StrictAPITypeFeatures
+	defer func() {
+		conf.Adapter.StrictAPITypeFeatures = previousStrictAPITypeFeatures
+	}()
+
+	conf.Adapter.StrictAPITypeFeatures = true
+	apiProject := getWSProjectWithHTTPConstructs()
+	err := apiProject.ValidateAPITypeFeatures()
+	if assert.Error(t, err, "WS APIs with HTTP only constructs should be rejected") {
+		assert.Contains(t, err.Error(), "api.yaml endpointImplementationType (mockedEndpoint)")
+	}
+	assert.Empty(t, apiProject.GetUnsupportedFeatures())
+
+	conf.Adapter.StrictAPITypeFeatures = false
+	apiProject = getWSProjectWithHTTPConstructs()
+	assert.NoError(t, apiProject.ValidateAPITypeFeatures(),
+		"WS APIs with HTTP only constructs should be allowed when the strict validation is disabled")
+	features := apiProject.GetUnsupportedFeatures()
+	if assert.Len(t, features, 4, "Unsupported features should be reported once per feature") {
+		assert.Equal(t, "WS:"+constants.APIFeatureHTTPExtensions, features[3].Name)
+		assert.Contains(t, features[3].Message, "channels./notifications x-wso2-public-paths")
+		assert.Contains(t, features[3].Message, "channels./notifications.subscribe x-wso2-not-found-response")
+	}
+}
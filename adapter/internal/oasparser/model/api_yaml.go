@@ -21,7 +21,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/wso2/product-microgateway/adapter/config"
 	"github.com/wso2/product-microgateway/adapter/internal/loggers"
@@ -49,6 +56,8 @@ type APIYaml struct {
 		OrganizationID             string   `json:"organizationId,omitempty"`
 		APIThrottlingPolicy        string   `json:"apiThrottlingPolicy,omitempty"`
 		IsDefaultVersion           bool     `json:"isDefaultVersion,omitempty"`
+		Visibility                 string   `json:"visibility,omitempty"`
+		VisibleRoles               []string `json:"visibleRoles,omitempty"`
 		CorsConfiguration          struct {
 			CorsConfigurationEnabled      bool     `json:"corsConfigurationEnabled,omitempty"`
 			AccessControlAllowOrigins     []string `json:"accessControlAllowOrigins,omitempty"`
@@ -63,6 +72,7 @@ type APIYaml struct {
 			LoadBalanceAlgo              string              `json:"algoCombo,omitempty"`
 			LoadBalanceSessionManagement string              `json:"sessionManagement,omitempty"`
 			LoadBalanceSessionTimeOut    string              `json:"sessionTimeOut,omitempty"`
+			LoadBalanceSessionCookieName string              `json:"sessionCookieName,omitempty"`
 			APIEndpointSecurity          APIEndpointSecurity `json:"endpoint_security,omitempty"`
 			RawProdEndpoints             interface{}         `json:"production_endpoints,omitempty"`
 			ProductionEndpoints          []EndpointInfo
@@ -73,7 +83,212 @@ type APIYaml struct {
 			ImplementationStatus         string         `json:"implementation_status,omitempty"`
 		} `json:"endpointConfig,omitempty"`
 		Operations []OperationYaml `json:"Operations,omitempty"`
+
+		// AdditionalProperties are the custom key value metadata attached to the API in APIM
+		AdditionalProperties AdditionalProperties `json:"additionalProperties,omitempty"`
+
+		// StripAuthHeader overrides enableOutboundAuthHeader of the enforcer for the API, i.e. the authorization
+		// header is removed from the requests sent to the backends if true, and retained if false
+		StripAuthHeader *bool `json:"stripAuthHeader,omitempty"`
+
+		// RateLimitKey is the source of the key used to rate limit the requests of the API, instead of the API
+		// and the application of the request
+		RateLimitKey *RateLimitKey `json:"rateLimitKey,omitempty"`
+
+		// MaxRequestHeadersKb is the maximum size of the request headers of the API in KiB, which can only be
+		// lower than the limit of the listeners
+		MaxRequestHeadersKb *int `json:"maxRequestHeadersKb,omitempty"`
+
+		// JwksConfig is the JWKS endpoint of the API, from which the keys used to validate the JWTs of the API
+		// are fetched instead of the JWKS endpoints of the configured token issuers
+		JwksConfig *JwksConfig `json:"jwksConfig,omitempty"`
+
+		// DisableSubscriptionValidation allows the requests with any valid token to the API, without validating
+		// whether the application of the token is subscribed to the API
+		DisableSubscriptionValidation bool `json:"disableSubscriptionValidation,omitempty"`
+
+		// BufferRequest buffers the complete requests of the API before sending those to the backends, instead of
+		// streaming those, for the backends which cannot handle streamed uploads
+		BufferRequest bool `json:"bufferRequest,omitempty"`
+
+		// LocalRateLimit limits the requests of the API at each router, which is enforced even when the rate
+		// limit service is unavailable
+		LocalRateLimit *LocalRateLimit `json:"localRateLimit,omitempty"`
+
+		// RateLimit is the explicit limit of the requests of the API at each router, which overrides the named
+		// throttling tiers of the API and its operations
+		RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+		// ResponseHeaders are added to all the responses of the API (e.g. X-Served-By), overwriting the headers
+		// with the same names sent by the backends
+		ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
 	} `json:"data"`
+
+	// UnsupportedFeatures are the features used in the api.yaml which are not supported, and hence ignored
+	UnsupportedFeatures []UnsupportedFeature `json:"-"`
+}
+
+// RateLimitKey specifies the request header or the JWT claim whose value is used as the rate limit key of an API.
+// Only one of those can be given.
+type RateLimitKey struct {
+	Header string `json:"header,omitempty"`
+	Claim  string `json:"claim,omitempty"`
+}
+
+// validate returns an error unless exactly one of the header and the claim is given.
+func (rateLimitKey *RateLimitKey) validate() error {
+	header := strings.TrimSpace(rateLimitKey.Header)
+	claim := strings.TrimSpace(rateLimitKey.Claim)
+	if header == "" && claim == "" {
+		return errors.New("either the header or the claim should be given")
+	}
+	if header != "" && claim != "" {
+		return errors.New("only one of the header and the claim can be given")
+	}
+	return nil
+}
+
+// LocalRateLimit specifies the number of requests of an API allowed per unit of time by each router, along with the
+// burst of requests allowed above that. The routes of the API share the limit.
+type LocalRateLimit struct {
+	RequestsPerUnit int `json:"requestsPerUnit"`
+	// Unit is one of second, minute, hour or day
+	Unit  string `json:"unit"`
+	Burst int    `json:"burst,omitempty"`
+}
+
+// localRateLimitUnits are the supported units of the local rate limits
+var localRateLimitUnits = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+}
+
+// validate returns an error unless the number of requests is positive, the burst is not negative and the unit is
+// supported.
+func (localRateLimit *LocalRateLimit) validate() error {
+	if localRateLimit.RequestsPerUnit <= 0 {
+		return fmt.Errorf("requestsPerUnit should be a positive number, but it is %d",
+			localRateLimit.RequestsPerUnit)
+	}
+	if localRateLimit.Burst < 0 {
+		return fmt.Errorf("burst should not be a negative number, but it is %d", localRateLimit.Burst)
+	}
+	if _, found := localRateLimitUnits[strings.ToLower(localRateLimit.Unit)]; !found {
+		return fmt.Errorf("unit %q is not supported. The unit should be one of second, minute, hour or day",
+			localRateLimit.Unit)
+	}
+	return nil
+}
+
+// GetFillInterval returns the interval at which the requests per unit are allowed again.
+func (localRateLimit *LocalRateLimit) GetFillInterval() time.Duration {
+	return localRateLimitUnits[strings.ToLower(localRateLimit.Unit)]
+}
+
+// RateLimit specifies the number of requests of an API allowed per unit of time, along with the burst of requests
+// allowed above that, given inline instead of a named throttling tier. Unlike the named tiers, it is not enforced by
+// the traffic manager or the rate limit service, but as the local rate limit of each router. Hence, the requests
+// allowed for the API grow with the number of routers.
+type RateLimit struct {
+	Count int `json:"count"`
+	// Unit is one of second, minute, hour or day
+	Unit  string `json:"unit"`
+	Burst int    `json:"burst,omitempty"`
+}
+
+// validate returns an error unless the count is positive, the burst is not negative and the unit is supported.
+func (rateLimit *RateLimit) validate() error {
+	if rateLimit.Count <= 0 {
+		return fmt.Errorf("count should be a positive number, but it is %d", rateLimit.Count)
+	}
+	return rateLimit.toLocalRateLimit().validate()
+}
+
+// toLocalRateLimit returns the limit enforced by each router for the explicit rate limit.
+func (rateLimit *RateLimit) toLocalRateLimit() *LocalRateLimit {
+	return &LocalRateLimit{
+		RequestsPerUnit: rateLimit.Count,
+		Unit:            rateLimit.Unit,
+		Burst:           rateLimit.Burst,
+	}
+}
+
+// JwksConfig specifies the JWKS endpoint of an API and how often the keys are fetched from it. The enforcer
+// validates the signatures of the JWTs of the API with these keys, while the issuers of the JWTs still need to be
+// configured in the enforcer.
+type JwksConfig struct {
+	JwksURL string `json:"jwksUrl"`
+	// RefreshIntervalInSeconds is the interval at which the cached keys are refreshed. The keys are refreshed
+	// hourly if it is not given.
+	RefreshIntervalInSeconds int `json:"refreshIntervalInSeconds,omitempty"`
+}
+
+// validate returns an error unless the JWKS URL is an absolute HTTP or HTTPS URL and the refresh interval is not
+// negative.
+func (jwksConfig *JwksConfig) validate() error {
+	jwksURL := strings.TrimSpace(jwksConfig.JwksURL)
+	if jwksURL == "" {
+		return errors.New("the jwksUrl should be given")
+	}
+	parsedURL, err := url.Parse(jwksURL)
+	if err != nil {
+		return fmt.Errorf("the jwksUrl %q cannot be parsed. %v", jwksURL, err)
+	}
+	if (parsedURL.Scheme != "https" && parsedURL.Scheme != "http") || parsedURL.Host == "" {
+		return fmt.Errorf("the jwksUrl %q should be an absolute HTTP or HTTPS URL", jwksURL)
+	}
+	if jwksConfig.RefreshIntervalInSeconds < 0 {
+		return fmt.Errorf("the refreshIntervalInSeconds should not be negative, but it is %d",
+			jwksConfig.RefreshIntervalInSeconds)
+	}
+	return nil
+}
+
+// AdditionalProperties holds the additionalProperties of the api.yaml as a map of property names to values.
+// APIM exports the additionalProperties either as a map, or as a list of objects having the name and the value
+// of each property. Both formats are supported.
+type AdditionalProperties map[string]string
+
+// UnmarshalJSON parses the additionalProperties given either as a map or as a list of name value pairs.
+func (additionalProperties *AdditionalProperties) UnmarshalJSON(data []byte) error {
+	var propertyMap map[string]interface{}
+	if err := json.Unmarshal(data, &propertyMap); err == nil {
+		properties := make(AdditionalProperties, len(propertyMap))
+		for name, value := range propertyMap {
+			properties[name] = additionalPropertyValueToString(value)
+		}
+		*additionalProperties = properties
+		return nil
+	}
+	var propertyList []struct {
+		Name  string      `json:"name"`
+		Value interface{} `json:"value"`
+	}
+	if err := json.Unmarshal(data, &propertyList); err != nil {
+		return fmt.Errorf("additionalProperties should be either a map or a list of name value pairs. %v", err)
+	}
+	properties := make(AdditionalProperties, len(propertyList))
+	for _, property := range propertyList {
+		if property.Name == "" {
+			continue
+		}
+		properties[property.Name] = additionalPropertyValueToString(property.Value)
+	}
+	*additionalProperties = properties
+	return nil
+}
+
+func additionalPropertyValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
 }
 
 // APIEndpointSecurity represents the structure of endpoint_security param in api.yaml
@@ -91,12 +306,32 @@ type EndpointSecurity struct {
 	CustomParameters map[string]string `json:"customparameters,omitempty" mapstructure:"customparameters"`
 }
 
+// String returns the endpoint security with the password masked, so that the credentials are not logged.
+func (endpointSecurity EndpointSecurity) String() string {
+	password := ""
+	if endpointSecurity.Password != "" {
+		password = "******"
+	}
+	return fmt.Sprintf("{Type:%s Enabled:%v Username:%s Password:%s CustomParameters:%v}", endpointSecurity.Type,
+		endpointSecurity.Enabled, endpointSecurity.Username, password, endpointSecurity.CustomParameters)
+}
+
 // EndpointInfo holds config values regards to the endpoint
 type EndpointInfo struct {
 	Endpoint string `json:"url,omitempty"`
+	// Priority orders the failover endpoints, where 1 is the highest priority. The failover endpoints having the
+	// same priority are load balanced.
+	Priority *int `json:"priority,omitempty"`
 	Config   struct {
 		ActionDuration string `json:"actionDuration,omitempty"`
 		RetryTimeOut   string `json:"retryTimeOut,omitempty"`
+		// TLSCipherSuites are the cipher suites used for the TLS connections to the endpoint
+		TLSCipherSuites []string `json:"tlsCipherSuites,omitempty"`
+		// IdleTimeout is the duration (e.g. 90s) after which the idle connections to the endpoint are closed
+		IdleTimeout string `json:"idleTimeout,omitempty"`
+		// TLSSkipVerify skips the verification of the certificate of the endpoint, e.g. for the self-signed
+		// certificates of the development backends. It is applied only if allowed in the router configuration.
+		TLSSkipVerify bool `json:"tlsSkipVerify,omitempty"`
 	} `json:"config,omitempty"`
 }
 
@@ -108,7 +343,30 @@ type OperationYaml struct {
 	AuthType          string            `json:"authType,omitempty"`
 	ThrottlingPolicy  string            `json:"throttlingPolicy,omitempty"`
 	Scopes            []string          `json:"scopes,omitempty"`
+	Deprecated        bool              `json:"deprecated,omitempty"`
 	OperationPolicies OperationPolicies `json:"operationPolicies,omitempty"`
+	// RequiredQueryParams are the query parameters which should be present in a request to match the operation
+	RequiredQueryParams []string `json:"requiredQueryParams,omitempty"`
+	// RewritePath rewrites the path of the requests of the operation before those are sent to the backend
+	RewritePath *RewritePathConfig `json:"rewritePath,omitempty"`
+	// AwsLambda invokes an AWS Lambda function as the backend of the operation instead of the API endpoints
+	AwsLambda *AwsLambdaConfig `json:"awsLambda,omitempty"`
+	// Timeout is the route timeout of the operation (e.g. 90s, 2m), which overrides the API and endpoint timeouts
+	Timeout string `json:"timeout,omitempty"`
+	// RemapStatus remaps the status codes of the backend responses of the operation, applying the first matching remap
+	RemapStatus []StatusRemapConfig `json:"remapStatus,omitempty"`
+	// CacheVaryHeaders are the request headers by which the cached responses of the operation vary. Those are added to
+	// the vary header of the responses of the operation, by which the cache filter of the router keys the responses.
+	// Those are reported as an unsupported feature if the cache filter is not enabled.
+	CacheVaryHeaders []string `json:"cacheVaryHeaders,omitempty"`
+}
+
+// RewritePathConfig holds the regex substitution applied to the request path. The pattern is matched against the
+// complete request path received by the router, and the substitution (which can refer the capture groups as \1, \2,
+// etc.) is the complete path sent to the backend.
+type RewritePathConfig struct {
+	Pattern      string `json:"pattern,omitempty"`
+	Substitution string `json:"substitution,omitempty"`
 }
 
 // OperationPolicies holds policies of the APIM operations
@@ -150,32 +408,86 @@ func NewAPIYaml(fileContent []byte) (apiYaml APIYaml, err error) {
 		return apiYaml, err
 	}
 
+	err = apiYaml.ValidateSchemaVersion()
+	if err != nil {
+		loggers.LoggerAPI.Errorf("%v", err)
+		return apiYaml, err
+	}
+
+	err = apiYaml.validateInlineEndpoints()
+	if err != nil {
+		loggers.LoggerAPI.Errorf("%v", err)
+		return apiYaml, err
+	}
+
+	apiYaml.setUnsupportedFeatures(apiJsn)
 	apiYaml.FormatAndUpdateInfo()
 	if apiYaml.Data.EndpointImplementationType != constants.MockedOASEndpointType {
-		apiYaml.PopulateEndpointsInfo()
+		err = apiYaml.PopulateEndpointsInfo()
+		if err != nil {
+			loggers.LoggerAPI.Errorf("%v", err)
+			return apiYaml, err
+		}
 	}
 	err = apiYaml.ValidateMandatoryFields()
 	if err != nil {
 		loggers.LoggerAPI.Errorf("%v", err)
 		return apiYaml, err
 	}
+	return apiYaml, nil
+}
 
-	if apiYaml.Data.EndpointImplementationType == constants.InlineEndpointType {
-		errmsg := "INLINE endpointImplementationType is not supported with Choreo Connect"
-		loggers.LoggerAPI.Warnf(errmsg)
-		err = errors.New(errmsg)
-		return apiYaml, err
+// supportedAPIYamlSchemaVersions are the major versions of the api.yaml schema which are parsed by the adapter.
+var supportedAPIYamlSchemaVersions = []string{"v4"}
+
+// GetSupportedAPIYamlSchemaVersions returns the major versions of the api.yaml schema which are parsed by the adapter.
+func GetSupportedAPIYamlSchemaVersions() []string {
+	return append([]string{}, supportedAPIYamlSchemaVersions...)
+}
+
+// apiYamlSchemaVersionRegex matches the schema versions in the form of v<major>, v<major>.<minor> or
+// v<major>.<minor>.<patch>, capturing the major version.
+var apiYamlSchemaVersionRegex = regexp.MustCompile(`^(v[0-9]+)(\.[0-9]+){0,2}$`)
+
+// ValidateSchemaVersion returns an error if the top level version of the api.yaml is not a supported schema version,
+// as the fields of an unsupported schema may not be parsed correctly. An api.yaml without a version is accepted with
+// a warning to support the descriptors written by hand.
+func (apiYaml *APIYaml) ValidateSchemaVersion() error {
+	schemaVersion := apiYaml.Version
+	if schemaVersion == "" {
+		loggers.LoggerAPI.Warnf("Schema version is not provided in api.yaml of the API %v %v. It is parsed as a "+
+			"%v api.yaml.", apiYaml.Data.Name, apiYaml.Data.Version, supportedAPIYamlSchemaVersions[0])
+		return nil
 	}
-	return apiYaml, nil
+	matches := apiYamlSchemaVersionRegex.FindStringSubmatch(schemaVersion)
+	if matches == nil {
+		return fmt.Errorf("invalid api.yaml schema version %q of the API %v %v", schemaVersion,
+			apiYaml.Data.Name, apiYaml.Data.Version)
+	}
+	if !arrayContains(supportedAPIYamlSchemaVersions, matches[1]) {
+		return fmt.Errorf("unsupported api.yaml schema version %q of the API %v %v. Supported versions: %v",
+			schemaVersion, apiYaml.Data.Name, apiYaml.Data.Version, strings.Join(supportedAPIYamlSchemaVersions, ", "))
+	}
+	return nil
 }
 
 // FormatAndUpdateInfo formats necessary parameters and update from config if null
 func (apiYaml *APIYaml) FormatAndUpdateInfo() {
 	apiYaml.Data.APIType = strings.ToUpper(apiYaml.Data.APIType)
 	apiYaml.Data.LifeCycleStatus = strings.ToUpper(apiYaml.Data.LifeCycleStatus)
+	apiYaml.Data.Visibility = strings.ToUpper(apiYaml.Data.Visibility)
+	for i := range apiYaml.Data.Operations {
+		apiYaml.Data.Operations[i].Verb = strings.ToUpper(apiYaml.Data.Operations[i].Verb)
+	}
 
 	if apiYaml.Data.OrganizationID == "" {
-		apiYaml.Data.OrganizationID = config.GetControlPlaneConnectedTenantDomain()
+		conf, _ := config.ReadConfigs()
+		if !conf.ControlPlane.Enabled && conf.Adapter.DefaultOrganizationID != "" {
+			// no control plane to derive the organization from
+			apiYaml.Data.OrganizationID = conf.Adapter.DefaultOrganizationID
+		} else {
+			apiYaml.Data.OrganizationID = config.GetControlPlaneConnectedTenantDomain()
+		}
 	}
 }
 
@@ -206,21 +518,186 @@ func (apiYaml *APIYaml) ValidateMandatoryFields() error {
 		errMsg = errMsg + "API production and sandbox endpoints "
 	}
 
+	if apiYaml.Data.Visibility == constants.RestrictedVisibility && len(apiYaml.Data.VisibleRoles) < 1 {
+		errMsg = errMsg + "API visible roles (when the visibility is " + constants.RestrictedVisibility + ") "
+	}
+
 	if errMsg != "" {
 		errMsg = errMsg + "fields cannot be empty for " + apiName + " " + apiVersion
 		return errors.New(errMsg)
 	}
 
+	if err := validateNameOrVersionCharacters("name", apiName); err != nil {
+		return err
+	}
+	if err := validateNameOrVersionCharacters("version", apiVersion); err != nil {
+		return err
+	}
+	if apiYaml.Data.RateLimitKey != nil {
+		if err := apiYaml.Data.RateLimitKey.validate(); err != nil {
+			return fmt.Errorf("rateLimitKey of the API %s %s is invalid. %v", apiName, apiVersion, err)
+		}
+	}
+	if apiYaml.Data.MaxRequestHeadersKb != nil {
+		if err := validateMaxRequestHeadersKb(*apiYaml.Data.MaxRequestHeadersKb); err != nil {
+			return fmt.Errorf("maxRequestHeadersKb of the API %s %s is invalid. %v", apiName, apiVersion, err)
+		}
+	}
+	if apiYaml.Data.LocalRateLimit != nil {
+		if err := apiYaml.Data.LocalRateLimit.validate(); err != nil {
+			return fmt.Errorf("localRateLimit of the API %s %s is invalid. %v", apiName, apiVersion, err)
+		}
+	}
+	if apiYaml.Data.RateLimit != nil {
+		if apiYaml.Data.LocalRateLimit != nil {
+			return fmt.Errorf("only one of rateLimit and localRateLimit can be given for the API %s %s",
+				apiName, apiVersion)
+		}
+		if err := apiYaml.Data.RateLimit.validate(); err != nil {
+			return fmt.Errorf("rateLimit of the API %s %s is invalid. %v", apiName, apiVersion, err)
+		}
+	}
+	if apiYaml.Data.JwksConfig != nil {
+		if err := apiYaml.Data.JwksConfig.validate(); err != nil {
+			return fmt.Errorf("jwksConfig of the API %s %s is invalid. %v", apiName, apiVersion, err)
+		}
+	}
+	if err := validateResponseHeaders(apiYaml.Data.ResponseHeaders); err != nil {
+		return fmt.Errorf("responseHeaders of the API %s %s is invalid. %v", apiName, apiVersion, err)
+	}
+	for _, operation := range apiYaml.Data.Operations {
+		if err := validateTargetPathTemplate(operation.Target); err != nil {
+			return fmt.Errorf("target %q of the %s operation of the API %s %s is invalid. %v", operation.Target,
+				operation.Verb, apiName, apiVersion, err)
+		}
+	}
+	sessionAffinity, err := apiYaml.getSessionAffinity()
+	if err != nil {
+		return fmt.Errorf("session management of the API %s %s is invalid. %v", apiName, apiVersion, err)
+	}
+	if sessionAffinity != nil {
+		apiYaml.warnIfSessionAffinityIsIneffective()
+	}
+
 	if apiYaml.Data.EndpointImplementationType != constants.MockedOASEndpointType {
+		conf, _ := config.ReadConfigs()
+		maxEndpoints := conf.Envoy.Upstream.MaxEndpointsPerCluster
+		// The failover endpoints are added to the same cluster as the endpoints.
+		prodEndpointCount := len(apiYaml.Data.EndpointConfig.ProductionEndpoints) +
+			len(apiYaml.Data.EndpointConfig.ProductionFailoverEndpoints)
+		if maxEndpoints > 0 && prodEndpointCount > maxEndpoints {
+			return fmt.Errorf("API %s %s has %d production endpoints including the failovers, which exceeds the "+
+				"maximum of %d endpoints per cluster", apiName, apiVersion, prodEndpointCount, maxEndpoints)
+		}
+		sandboxEndpointCount := len(apiYaml.Data.EndpointConfig.SandBoxEndpoints) +
+			len(apiYaml.Data.EndpointConfig.SandboxFailoverEndpoints)
+		if maxEndpoints > 0 && sandboxEndpointCount > maxEndpoints {
+			return fmt.Errorf("API %s %s has %d sandbox endpoints including the failovers, which exceeds the "+
+				"maximum of %d endpoints per cluster", apiName, apiVersion, sandboxEndpointCount, maxEndpoints)
+		}
 		for _, ep := range apiYaml.Data.EndpointConfig.ProductionEndpoints {
 			if strings.HasPrefix(ep.Endpoint, "/") || len(strings.TrimSpace(ep.Endpoint)) < 1 {
 				return errors.New("relative urls or empty values are not supported for API production endpoints")
 			}
+			if socketPath, isUnixSocket := getUnixSocketPath(ep.Endpoint); isUnixSocket {
+				if err := validateUnixSocketPath(socketPath); err != nil {
+					return fmt.Errorf("API production endpoint %q is invalid. %v", ep.Endpoint, err)
+				}
+			}
 		}
 		for _, ep := range apiYaml.Data.EndpointConfig.SandBoxEndpoints {
 			if strings.HasPrefix(ep.Endpoint, "/") || len(strings.TrimSpace(ep.Endpoint)) < 1 {
 				return errors.New("relative urls or empty values are not supported for API sandbox endpoints")
 			}
+			if socketPath, isUnixSocket := getUnixSocketPath(ep.Endpoint); isUnixSocket {
+				if err := validateUnixSocketPath(socketPath); err != nil {
+					return fmt.Errorf("API sandbox endpoint %q is invalid. %v", ep.Endpoint, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// defaultMaxRequestHeadersKb is the header size limit of the listeners when it is not configured, which is the
+// default of Envoy.
+const defaultMaxRequestHeadersKb uint32 = 60
+
+// validateMaxRequestHeadersKb returns an error unless the header size limit of an API is positive and within the
+// limit of the listeners, as the listeners reject the larger headers before the limit of the API is applied.
+func validateMaxRequestHeadersKb(maxRequestHeadersKb int) error {
+	if maxRequestHeadersKb <= 0 {
+		return fmt.Errorf("the limit should be a positive number, but it is %d", maxRequestHeadersKb)
+	}
+	conf, _ := config.ReadConfigs()
+	listenerLimit := conf.Envoy.MaxRequestHeadersKb
+	if listenerLimit == 0 {
+		listenerLimit = defaultMaxRequestHeadersKb
+	}
+	if uint32(maxRequestHeadersKb) > listenerLimit {
+		return fmt.Errorf("the limit %d KiB exceeds the limit of the listeners, %d KiB", maxRequestHeadersKb,
+			listenerLimit)
+	}
+	return nil
+}
+
+// pathParamNameRegex matches the valid names of the path parameters in the targets of the operations.
+var pathParamNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.\-]+$`)
+
+// validateTargetPathTemplate returns an error unless the braces of the path template are balanced without being
+// nested, and the path parameters enclosed in them have valid names.
+func validateTargetPathTemplate(target string) error {
+	paramStart := -1
+	for i, char := range target {
+		switch char {
+		case '{':
+			if paramStart >= 0 {
+				return fmt.Errorf("path parameter starting at position %d is not closed before the next '{'", paramStart)
+			}
+			paramStart = i
+		case '}':
+			if paramStart < 0 {
+				return fmt.Errorf("'}' at position %d does not close a path parameter", i)
+			}
+			if paramName := target[paramStart+1 : i]; !pathParamNameRegex.MatchString(paramName) {
+				return fmt.Errorf("path parameter name %q is invalid. Only letters, digits, '_', '.' and '-' "+
+					"are allowed", paramName)
+			}
+			paramStart = -1
+		}
+	}
+	if paramStart >= 0 {
+		return fmt.Errorf("path parameter starting at position %d is not closed", paramStart)
+	}
+	return nil
+}
+
+// headerNameRegex matches the valid HTTP header names, which are tokens as defined by RFC 7230.
+var headerNameRegex = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9a-zA-Z]+$")
+
+// validateResponseHeaders returns an error unless the names of the response headers are valid HTTP header names and
+// the values do not contain line breaks or null characters, which cannot be set by the router.
+func validateResponseHeaders(responseHeaders map[string]string) error {
+	for name, value := range responseHeaders {
+		if !headerNameRegex.MatchString(name) {
+			return fmt.Errorf("header name %q is not a valid HTTP header name", name)
+		}
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return fmt.Errorf("value of the header %q contains line breaks or null characters", name)
+		}
+	}
+	return nil
+}
+
+// validateNameOrVersionCharacters returns an error if the API name or version contains characters which cannot be
+// represented in the router and enforcer configurations, such as control characters or invalid UTF-8 sequences.
+func validateNameOrVersionCharacters(field, value string) error {
+	if !utf8.ValidString(value) {
+		return fmt.Errorf("API %s %q is not a valid UTF-8 string", field, value)
+	}
+	for _, char := range value {
+		if char == utf8.RuneError || !unicode.IsPrint(char) {
+			return fmt.Errorf("API %s %q contains the unsupported character %U", field, value, char)
 		}
 	}
 	return nil
@@ -228,7 +705,8 @@ func (apiYaml *APIYaml) ValidateMandatoryFields() error {
 
 // PopulateEndpointsInfo this will map sandbox and prod endpoint
 // This is done to fix the issue https://github.com/wso2/product-microgateway/issues/2288
-func (apiYaml *APIYaml) PopulateEndpointsInfo() {
+// An error is returned if the endpoint type does not match the endpoints.
+func (apiYaml *APIYaml) PopulateEndpointsInfo() error {
 	rawProdEndpoints := apiYaml.Data.EndpointConfig.RawProdEndpoints
 	if rawProdEndpoints != nil {
 		if val, ok := rawProdEndpoints.(map[string]interface{}); ok {
@@ -262,6 +740,232 @@ func (apiYaml *APIYaml) PopulateEndpointsInfo() {
 			loggers.LoggerAPI.Warn("No sandbox endpoints provided")
 		}
 	}
+	return apiYaml.validateEndpointType()
+}
+
+// validateInlineEndpoints checks that an API with the INLINE endpoint implementation does not declare the endpoints
+// the requests are proxied to, since it is ambiguous whether the requests should be served by the examples of the API
+// definition or by the endpoints.
+func (apiYaml *APIYaml) validateInlineEndpoints() error {
+	if apiYaml.Data.EndpointImplementationType != constants.InlineEndpointType {
+		return nil
+	}
+	endpointConfig := apiYaml.Data.EndpointConfig
+	var proxyEndpoints []string
+	if hasRawEndpointURL(endpointConfig.RawProdEndpoints) || hasEndpointURL(endpointConfig.ProductionFailoverEndpoints) {
+		proxyEndpoints = append(proxyEndpoints, "production endpoints")
+	}
+	if hasRawEndpointURL(endpointConfig.RawSandboxEndpoints) || hasEndpointURL(endpointConfig.SandboxFailoverEndpoints) {
+		proxyEndpoints = append(proxyEndpoints, "sandbox endpoints")
+	}
+	if endpointConfig.EndpointType == constants.AwsLambda {
+		proxyEndpoints = append(proxyEndpoints, "AWS Lambda endpoint")
+	}
+	if len(proxyEndpoints) == 0 {
+		return nil
+	}
+	return fmt.Errorf("API %s %s declares the %s endpoint implementation along with the %s. The %s endpoint "+
+		"implementation and the endpoints the requests are proxied to are mutually exclusive", apiYaml.Data.Name,
+		apiYaml.Data.Version, constants.InlineEndpointType, strings.Join(proxyEndpoints, " and "),
+		constants.InlineEndpointType)
+}
+
+// hasRawEndpointURL checks whether the production or sandbox endpoints of the api.yaml, given as an endpoint or a
+// list of endpoints, have an endpoint with a URL.
+func hasRawEndpointURL(rawEndpoints interface{}) bool {
+	switch endpoints := rawEndpoints.(type) {
+	case map[string]interface{}:
+		endpointURL, _ := endpoints["url"].(string)
+		return strings.TrimSpace(endpointURL) != ""
+	case []interface{}:
+		for _, endpoint := range endpoints {
+			if hasRawEndpointURL(endpoint) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasEndpointURL(endpoints []EndpointInfo) bool {
+	for _, endpoint := range endpoints {
+		if strings.TrimSpace(endpoint.Endpoint) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// validateEndpointType checks whether the endpoint type matches the endpoints of each of the production and sandbox
+// environments having endpoints. Load balanced endpoints should have multiple endpoints, and failover endpoints
+// should have both the endpoints and the failover endpoints. The production endpoints are validated first, so that
+// the same error is returned for the same api.yaml. The priorities of the endpoints are validated as well.
+func (apiYaml *APIYaml) validateEndpointType() error {
+	endpointConfig := apiYaml.Data.EndpointConfig
+	environments := []struct {
+		name      string
+		endpoints []EndpointInfo
+		failovers []EndpointInfo
+	}{
+		{"production", endpointConfig.ProductionEndpoints, endpointConfig.ProductionFailoverEndpoints},
+		{"sandbox", endpointConfig.SandBoxEndpoints, endpointConfig.SandboxFailoverEndpoints},
+	}
+	for _, environment := range environments {
+		if err := validateEndpointPriorities(environment.name, environment.endpoints, environment.failovers); err != nil {
+			return err
+		}
+		switch endpointConfig.EndpointType {
+		case constants.LoadBalance:
+			if len(environment.endpoints) == 1 {
+				return fmt.Errorf("endpoint_type %v requires multiple %v endpoints, but only one endpoint is provided",
+					constants.LoadBalance, environment.name)
+			}
+		case constants.FailOver:
+			if len(environment.endpoints) > 0 && len(environment.failovers) == 0 {
+				return fmt.Errorf("endpoint_type %v requires the %v failover endpoints, but none are provided",
+					constants.FailOver, environment.name)
+			}
+			if len(environment.endpoints) == 0 && len(environment.failovers) > 0 {
+				return fmt.Errorf("endpoint_type %v requires the %v endpoints along with the %v failover endpoints",
+					constants.FailOver, environment.name, environment.name)
+			}
+		}
+	}
+	return nil
+}
+
+// getSessionAffinity returns the cookie based session affinity of the load balanced endpoints given by the
+// sessionManagement, sessionTimeOut and sessionCookieName of the endpoint config. The session timeout is in
+// milliseconds as in APIM. Nil is returned if the session management is none.
+func (apiYaml *APIYaml) getSessionAffinity() (*SessionAffinity, error) {
+	endpointConfig := apiYaml.Data.EndpointConfig
+	switch sessionManagement := strings.ToLower(strings.TrimSpace(endpointConfig.LoadBalanceSessionManagement)); sessionManagement {
+	case "", constants.SessionManagementNone:
+		return nil, nil
+	case constants.SessionManagementCookie, constants.SessionManagementTransport:
+	default:
+		return nil, fmt.Errorf("the sessionManagement %q is not supported. It should be one of %s, %s or %s",
+			endpointConfig.LoadBalanceSessionManagement, constants.SessionManagementNone,
+			constants.SessionManagementCookie, constants.SessionManagementTransport)
+	}
+
+	sessionAffinity := &SessionAffinity{CookieName: constants.DefaultSessionCookieName}
+	if cookieName := strings.TrimSpace(endpointConfig.LoadBalanceSessionCookieName); cookieName != "" {
+		if strings.ContainsAny(cookieName, " \t;,=\"") {
+			return nil, fmt.Errorf("the sessionCookieName %q is not a valid cookie name", cookieName)
+		}
+		sessionAffinity.CookieName = cookieName
+	}
+	if sessionTimeOut := strings.TrimSpace(endpointConfig.LoadBalanceSessionTimeOut); sessionTimeOut != "" {
+		timeoutInMillis, err := strconv.ParseUint(sessionTimeOut, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("the sessionTimeOut %q should be a non negative number of milliseconds",
+				sessionTimeOut)
+		}
+		sessionAffinity.CookieTTL = time.Duration(timeoutInMillis) * time.Millisecond
+	}
+	return sessionAffinity, nil
+}
+
+// warnIfSessionAffinityIsIneffective logs a warning for each environment having a single endpoint, as the requests
+// of all the sessions are routed to the same endpoint regardless of the session affinity.
+func (apiYaml *APIYaml) warnIfSessionAffinityIsIneffective() {
+	endpointConfig := apiYaml.Data.EndpointConfig
+	environments := []struct {
+		name      string
+		endpoints []EndpointInfo
+	}{
+		{"production", endpointConfig.ProductionEndpoints},
+		{"sandbox", endpointConfig.SandBoxEndpoints},
+	}
+	for _, environment := range environments {
+		if len(environment.endpoints) == 1 {
+			loggers.LoggerAPI.Warnf("Session management %q of the API %s %s has no effect on the %s endpoints, as "+
+				"only one endpoint is provided", endpointConfig.LoadBalanceSessionManagement, apiYaml.Data.Name,
+				apiYaml.Data.Version, environment.name)
+		}
+	}
+}
+
+// validateEndpointPriorities returns an error if a priority is given to an endpoint other than the failover
+// endpoints, or if the priority of a failover endpoint is not positive.
+func validateEndpointPriorities(environment string, endpoints, failovers []EndpointInfo) error {
+	for _, endpoint := range endpoints {
+		if endpoint.Priority != nil {
+			return fmt.Errorf("priority of the %v endpoint %v is not supported, as only the failover endpoints can "+
+				"be prioritized", environment, endpoint.Endpoint)
+		}
+	}
+	for _, failover := range failovers {
+		if failover.Priority != nil && *failover.Priority < 1 {
+			return fmt.Errorf("priority of the %v failover endpoint %v should be a positive number, but it is %d",
+				environment, failover.Endpoint, *failover.Priority)
+		}
+	}
+	return nil
+}
+
+// prioritizeFailoverEndpoints sets the priorities of the endpoints processed from the given number of primary
+// endpoints followed by the failover endpoints, and orders those by the priority. The primary endpoints have the highest priority, 0. A
+// failover endpoint without a priority is prioritized by its position among the failover endpoints, and the
+// priorities are made contiguous as required by the router. The endpoints are returned as they are if none of the
+// failover endpoints has a priority, hence those are prioritized in their order.
+func prioritizeFailoverEndpoints(endpoints []Endpoint, primaryCount int, failovers []EndpointInfo) []Endpoint {
+	hasPriorities := false
+	for _, failover := range failovers {
+		hasPriorities = hasPriorities || failover.Priority != nil
+	}
+	if !hasPriorities {
+		return endpoints
+	}
+	if len(endpoints) != primaryCount+len(failovers) {
+		loggers.LoggerAPI.Warnf("Priorities of the failover endpoints are not applied, as some of the endpoints " +
+			"are not processed")
+		return endpoints
+	}
+
+	failoverIndexes := make([]int, len(failovers))
+	givenPriorities := make([]int, len(failovers))
+	for i, failover := range failovers {
+		failoverIndexes[i] = i
+		givenPriorities[i] = i + 1
+		if failover.Priority != nil {
+			givenPriorities[i] = *failover.Priority
+		}
+	}
+	sort.SliceStable(failoverIndexes, func(i, j int) bool {
+		return givenPriorities[failoverIndexes[i]] < givenPriorities[failoverIndexes[j]]
+	})
+
+	prioritizedEndpoints := append([]Endpoint{}, endpoints[:primaryCount]...)
+	var priority uint32
+	for i, failoverIndex := range failoverIndexes {
+		if i == 0 || givenPriorities[failoverIndex] != givenPriorities[failoverIndexes[i-1]] {
+			priority++
+		}
+		endpoint := endpoints[primaryCount+failoverIndex]
+		endpoint.Priority = priority
+		prioritizedEndpoints = append(prioritizedEndpoints, endpoint)
+	}
+	return prioritizedEndpoints
+}
+
+// recognizedSecuritySchemes are the api.yaml security schemes which are applied to the APIs.
+// The basic auth security is applied via the security schemes of the API definition.
+var recognizedSecuritySchemes = []string{constants.APIMAPIKeyType, constants.APIMOauth2Type,
+	constants.APIMBasicAuthType, constants.APIMMutualSSLType, constants.APIMMutualSSLMandatoryType,
+	constants.APIOauthBasicAuthAPIKeyMandatoryType}
+
+// GetUnrecognizedSecuritySchemes returns the security schemes of the api.yaml which are not recognized.
+// Those do not have any effect on the security of the API.
+func (apiYaml APIYaml) GetUnrecognizedSecuritySchemes() []string {
+	var unrecognizedSchemes []string
+	for _, securityScheme := range apiYaml.Data.SecurityScheme {
+		if !arrayContains(recognizedSecuritySchemes, securityScheme) {
+			unrecognizedSchemes = append(unrecognizedSchemes, securityScheme)
+		}
+	}
+	return unrecognizedSchemes
 }
 
 // ValidateAPIType checks if the apiProject is properly assigned with the type.
@@ -0,0 +1,1124 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+func getTestEndpoints(count int) []EndpointInfo {
+	endpoints := make([]EndpointInfo, count)
+	for i := range endpoints {
+		endpoints[i] = EndpointInfo{Endpoint: fmt.Sprintf("http://backend-%d.wso2.com:8080/v1", i)}
+	}
+	return endpoints
+}
+
+func TestValidateMandatoryFieldsWithMaxEndpointsPerCluster(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousMaxEndpoints := conf.Envoy.Upstream.MaxEndpointsPerCluster
+	defer func() {
+		conf.Envoy.Upstream.MaxEndpointsPerCluster = previousMaxEndpoints
+	}()
+
+	tests := []struct {
+		name             string
+		prodEndpoints    int
+		prodFailovers    int
+		sandboxEndpoints int
+		sandboxFailovers int
+		maxEndpoints     int
+		isErrorExpected  bool
+	}{
+		{
+			name:             "Endpoints within the limit",
+			prodEndpoints:    2,
+			sandboxEndpoints: 1,
+			maxEndpoints:     3,
+			isErrorExpected:  false,
+		},
+		{
+			name:             "Endpoints equal to the limit",
+			prodEndpoints:    3,
+			sandboxEndpoints: 3,
+			maxEndpoints:     3,
+			isErrorExpected:  false,
+		},
+		{
+			name:             "Production endpoints exceeding the limit",
+			prodEndpoints:    4,
+			sandboxEndpoints: 1,
+			maxEndpoints:     3,
+			isErrorExpected:  true,
+		},
+		{
+			name:             "Sandbox endpoints exceeding the limit",
+			prodEndpoints:    1,
+			sandboxEndpoints: 4,
+			maxEndpoints:     3,
+			isErrorExpected:  true,
+		},
+		{
+			name:             "Production failover endpoints exceeding the limit",
+			prodEndpoints:    2,
+			prodFailovers:    2,
+			sandboxEndpoints: 1,
+			maxEndpoints:     3,
+			isErrorExpected:  true,
+		},
+		{
+			name:             "Sandbox failover endpoints exceeding the limit",
+			prodEndpoints:    1,
+			sandboxEndpoints: 3,
+			sandboxFailovers: 1,
+			maxEndpoints:     3,
+			isErrorExpected:  true,
+		},
+		{
+			name:             "Failover endpoints within the limit",
+			prodEndpoints:    1,
+			prodFailovers:    2,
+			sandboxEndpoints: 2,
+			sandboxFailovers: 1,
+			maxEndpoints:     3,
+			isErrorExpected:  false,
+		},
+		{
+			name:             "Validation disabled",
+			prodEndpoints:    30,
+			sandboxEndpoints: 30,
+			maxEndpoints:     0,
+			isErrorExpected:  false,
+		},
+	}
+	for _, test := range tests {
+		conf.Envoy.Upstream.MaxEndpointsPerCluster = test.maxEndpoints
+		apiYaml := APIYaml{}
+		apiYaml.Data.Name = "PetStore"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/petstore/1.0.0"
+		apiYaml.Data.EndpointConfig.ProductionEndpoints = getTestEndpoints(test.prodEndpoints)
+		apiYaml.Data.EndpointConfig.SandBoxEndpoints = getTestEndpoints(test.sandboxEndpoints)
+		apiYaml.Data.EndpointConfig.ProductionFailoverEndpoints = getTestEndpoints(test.prodFailovers)
+		apiYaml.Data.EndpointConfig.SandboxFailoverEndpoints = getTestEndpoints(test.sandboxFailovers)
+		err := apiYaml.ValidateMandatoryFields()
+		if test.isErrorExpected {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestValidateMandatoryFieldsWithUnixSocketEndpoints(t *testing.T) {
+	apiYaml := APIYaml{}
+	apiYaml.Data.Name = "PetStore"
+	apiYaml.Data.Version = "1.0.0"
+	apiYaml.Data.Context = "/petstore/1.0.0"
+	apiYaml.Data.EndpointConfig.ProductionEndpoints = []EndpointInfo{{Endpoint: "unix:///var/run/petstore.sock"}}
+	assert.Nil(t, apiYaml.ValidateMandatoryFields(), "Absolute unix domain socket paths should be accepted")
+
+	apiYaml.Data.EndpointConfig.SandBoxEndpoints = []EndpointInfo{{Endpoint: "unix://petstore.sock"}}
+	err := apiYaml.ValidateMandatoryFields()
+	if assert.Error(t, err, "Relative unix domain socket paths should be rejected") {
+		assert.Contains(t, err.Error(), "sandbox endpoint \"unix://petstore.sock\"")
+	}
+}
+
+func TestValidateMandatoryFieldsWithRateLimitKey(t *testing.T) {
+	tests := []struct {
+		name            string
+		rateLimitKey    *RateLimitKey
+		isErrorExpected bool
+	}{
+		{
+			name:            "Without a rate limit key",
+			isErrorExpected: false,
+		},
+		{
+			name:            "Rate limit key from a header",
+			rateLimitKey:    &RateLimitKey{Header: "X-Tenant-ID"},
+			isErrorExpected: false,
+		},
+		{
+			name:            "Rate limit key from a claim",
+			rateLimitKey:    &RateLimitKey{Claim: "sub"},
+			isErrorExpected: false,
+		},
+		{
+			name:            "Rate limit key without a header or a claim",
+			rateLimitKey:    &RateLimitKey{Header: " "},
+			isErrorExpected: true,
+		},
+		{
+			name:            "Rate limit key with both a header and a claim",
+			rateLimitKey:    &RateLimitKey{Header: "X-Tenant-ID", Claim: "sub"},
+			isErrorExpected: true,
+		},
+	}
+	for _, test := range tests {
+		apiYaml := APIYaml{}
+		apiYaml.Data.Name = "PetStore"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/petstore/1.0.0"
+		apiYaml.Data.EndpointConfig.ProductionEndpoints = getTestEndpoints(1)
+		apiYaml.Data.RateLimitKey = test.rateLimitKey
+		err := apiYaml.ValidateMandatoryFields()
+		if test.isErrorExpected {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestValidateMandatoryFieldsWithMaxRequestHeadersKb(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	listenerLimit := conf.Envoy.MaxRequestHeadersKb
+	defer func() {
+		conf.Envoy.MaxRequestHeadersKb = listenerLimit
+	}()
+	conf.Envoy.MaxRequestHeadersKb = 96
+
+	limit := func(kb int) *int {
+		return &kb
+	}
+	tests := []struct {
+		name                string
+		maxRequestHeadersKb *int
+		isErrorExpected     bool
+	}{
+		{
+			name:            "Without a header size limit",
+			isErrorExpected: false,
+		},
+		{
+			name:                "Header size limit below the limit of the listeners",
+			maxRequestHeadersKb: limit(16),
+			isErrorExpected:     false,
+		},
+		{
+			name:                "Header size limit equal to the limit of the listeners",
+			maxRequestHeadersKb: limit(96),
+			isErrorExpected:     false,
+		},
+		{
+			name:                "Header size limit above the limit of the listeners",
+			maxRequestHeadersKb: limit(97),
+			isErrorExpected:     true,
+		},
+		{
+			name:                "Zero header size limit",
+			maxRequestHeadersKb: limit(0),
+			isErrorExpected:     true,
+		},
+		{
+			name:                "Negative header size limit",
+			maxRequestHeadersKb: limit(-1),
+			isErrorExpected:     true,
+		},
+	}
+	for _, test := range tests {
+		apiYaml := APIYaml{}
+		apiYaml.Data.Name = "PetStore"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/petstore/1.0.0"
+		apiYaml.Data.EndpointConfig.ProductionEndpoints = getTestEndpoints(1)
+		apiYaml.Data.MaxRequestHeadersKb = test.maxRequestHeadersKb
+		err := apiYaml.ValidateMandatoryFields()
+		if test.isErrorExpected {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestValidateMandatoryFieldsWithLocalRateLimit(t *testing.T) {
+	tests := []struct {
+		name            string
+		localRateLimit  *LocalRateLimit
+		isErrorExpected bool
+	}{
+		{
+			name:            "Without a local rate limit",
+			isErrorExpected: false,
+		},
+		{
+			name:            "Local rate limit with a burst",
+			localRateLimit:  &LocalRateLimit{RequestsPerUnit: 10, Unit: "second", Burst: 5},
+			isErrorExpected: false,
+		},
+		{
+			name:            "Local rate limit without a burst",
+			localRateLimit:  &LocalRateLimit{RequestsPerUnit: 1000, Unit: "Hour"},
+			isErrorExpected: false,
+		},
+		{
+			name:            "Zero requests per unit",
+			localRateLimit:  &LocalRateLimit{RequestsPerUnit: 0, Unit: "second"},
+			isErrorExpected: true,
+		},
+		{
+			name:            "Negative requests per unit",
+			localRateLimit:  &LocalRateLimit{RequestsPerUnit: -10, Unit: "second"},
+			isErrorExpected: true,
+		},
+		{
+			name:            "Negative burst",
+			localRateLimit:  &LocalRateLimit{RequestsPerUnit: 10, Unit: "second", Burst: -1},
+			isErrorExpected: true,
+		},
+		{
+			name:            "Unsupported unit",
+			localRateLimit:  &LocalRateLimit{RequestsPerUnit: 10, Unit: "week"},
+			isErrorExpected: true,
+		},
+	}
+	for _, test := range tests {
+		apiYaml := APIYaml{}
+		apiYaml.Data.Name = "PetStore"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/petstore/1.0.0"
+		apiYaml.Data.EndpointConfig.ProductionEndpoints = getTestEndpoints(1)
+		apiYaml.Data.LocalRateLimit = test.localRateLimit
+		err := apiYaml.ValidateMandatoryFields()
+		if test.isErrorExpected {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestValidateMandatoryFieldsWithRateLimit(t *testing.T) {
+	tests := []struct {
+		name            string
+		rateLimit       *RateLimit
+		localRateLimit  *LocalRateLimit
+		isErrorExpected bool
+	}{
+		{
+			name:            "Rate limit with a burst",
+			rateLimit:       &RateLimit{Count: 100, Unit: "minute", Burst: 20},
+			isErrorExpected: false,
+		},
+		{
+			name:            "Rate limit without a burst",
+			rateLimit:       &RateLimit{Count: 10, Unit: "Second"},
+			isErrorExpected: false,
+		},
+		{
+			name:            "Zero count",
+			rateLimit:       &RateLimit{Count: 0, Unit: "second"},
+			isErrorExpected: true,
+		},
+		{
+			name:            "Negative burst",
+			rateLimit:       &RateLimit{Count: 10, Unit: "second", Burst: -1},
+			isErrorExpected: true,
+		},
+		{
+			name:            "Unsupported unit",
+			rateLimit:       &RateLimit{Count: 10, Unit: "month"},
+			isErrorExpected: true,
+		},
+		{
+			name:            "Rate limit along with a local rate limit",
+			rateLimit:       &RateLimit{Count: 10, Unit: "second"},
+			localRateLimit:  &LocalRateLimit{RequestsPerUnit: 10, Unit: "second"},
+			isErrorExpected: true,
+		},
+	}
+	for _, test := range tests {
+		apiYaml := APIYaml{}
+		apiYaml.Data.Name = "PetStore"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/petstore/1.0.0"
+		apiYaml.Data.EndpointConfig.ProductionEndpoints = getTestEndpoints(1)
+		apiYaml.Data.RateLimit = test.rateLimit
+		apiYaml.Data.LocalRateLimit = test.localRateLimit
+		err := apiYaml.ValidateMandatoryFields()
+		if test.isErrorExpected {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestPopulateFromAPIYamlWithRateLimit(t *testing.T) {
+	apiYamlTemplate := `type: api
+data:
+  name: Store
+  context: /store
+  version: v1
+  type: HTTP
+  apiThrottlingPolicy: Gold
+%s
+  endpointConfig:
+    endpoint_type: http
+    production_endpoints:
+      url: http://store.wso2.com
+`
+	apiYaml, err := NewAPIYaml([]byte(fmt.Sprintf(apiYamlTemplate, "")))
+	assert.Nil(t, err)
+	var tieredSwagger MgwSwagger
+	assert.Nil(t, tieredSwagger.PopulateFromAPIYaml(apiYaml))
+	assert.Equal(t, "Gold", tieredSwagger.GetXWso2ThrottlingTier(), "API should be throttled by the named tier")
+	assert.Nil(t, tieredSwagger.GetRateLimit())
+	assert.Nil(t, tieredSwagger.GetLocalRateLimit())
+
+	apiYaml, err = NewAPIYaml([]byte(fmt.Sprintf(apiYamlTemplate, `  rateLimit:
+    count: 100
+    unit: minute
+    burst: 20`)))
+	assert.Nil(t, err)
+	assert.Equal(t, &RateLimit{Count: 100, Unit: "minute", Burst: 20}, apiYaml.Data.RateLimit,
+		"Explicit rate limit should be parsed")
+	var mgwSwagger MgwSwagger
+	assert.Nil(t, mgwSwagger.PopulateFromAPIYaml(apiYaml))
+	assert.Equal(t, apiYaml.Data.RateLimit, mgwSwagger.GetRateLimit())
+	assert.Equal(t, &LocalRateLimit{RequestsPerUnit: 100, Unit: "minute", Burst: 20}, mgwSwagger.GetLocalRateLimit(),
+		"Explicit rate limit should be enforced by each router")
+	assert.Equal(t, constants.UnlimitedTier, mgwSwagger.GetXWso2ThrottlingTier(),
+		"Explicit rate limit should take precedence over the tier of the api.yaml")
+
+	mgwSwagger.vendorExtensions = map[string]interface{}{constants.XWso2ThrottlingTier: "Silver"}
+	mgwSwagger.setXWso2ThrottlingTier()
+	assert.Equal(t, constants.UnlimitedTier, mgwSwagger.GetXWso2ThrottlingTier(),
+		"Explicit rate limit should take precedence over the tier of the API definition")
+
+	err = mgwSwagger.GetMgwSwagger([]byte(`openapi: 3.0.0
+info:
+  title: Store
+  version: v1
+x-throttling-tier: Silver
+paths:
+  /orders:
+    get:
+      x-throttling-tier: Gold
+      responses:
+        '200':
+          description: OK
+    post:
+      responses:
+        '200':
+          description: OK
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, constants.UnlimitedTier, mgwSwagger.GetXWso2ThrottlingTier())
+	assert.NotEmpty(t, mgwSwagger.GetResources(), "Resources are not read from the API definition")
+	for _, resource := range mgwSwagger.GetResources() {
+		for _, operation := range resource.GetMethod() {
+			assert.Equal(t, constants.UnlimitedTier, operation.GetTier(),
+				"Explicit rate limit should take precedence over the tier of the operation %s", operation.GetMethod())
+		}
+	}
+}
+
+func TestValidateMandatoryFieldsWithResponseHeaders(t *testing.T) {
+	tests := []struct {
+		name            string
+		responseHeaders map[string]string
+		isErrorExpected bool
+	}{
+		{
+			name:            "Without response headers",
+			isErrorExpected: false,
+		},
+		{
+			name:            "Valid response headers",
+			responseHeaders: map[string]string{"X-Served-By": "choreo-connect", "x-api-tier": "gold"},
+			isErrorExpected: false,
+		},
+		{
+			name:            "Empty header value",
+			responseHeaders: map[string]string{"X-Served-By": ""},
+			isErrorExpected: false,
+		},
+		{
+			name:            "Header name with a space",
+			responseHeaders: map[string]string{"X Served By": "choreo-connect"},
+			isErrorExpected: true,
+		},
+		{
+			name:            "Pseudo header",
+			responseHeaders: map[string]string{":status": "200"},
+			isErrorExpected: true,
+		},
+		{
+			name:            "Header value with a line break",
+			responseHeaders: map[string]string{"X-Served-By": "choreo-connect\r\nSet-Cookie: session=1"},
+			isErrorExpected: true,
+		},
+	}
+	for _, test := range tests {
+		apiYaml := APIYaml{}
+		apiYaml.Data.Name = "PetStore"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/petstore/1.0.0"
+		apiYaml.Data.EndpointConfig.ProductionEndpoints = getTestEndpoints(1)
+		apiYaml.Data.ResponseHeaders = test.responseHeaders
+		err := apiYaml.ValidateMandatoryFields()
+		if test.isErrorExpected {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestValidateMandatoryFieldsWithOperationTargets(t *testing.T) {
+	tests := []struct {
+		target          string
+		isErrorExpected bool
+	}{
+		{target: "/pets", isErrorExpected: false},
+		{target: "/*", isErrorExpected: false},
+		{target: "/users/{id}", isErrorExpected: false},
+		{target: "/users/{user-id}/orders/{order_id.v2}", isErrorExpected: false},
+		{target: "/users/{id", isErrorExpected: true},
+		{target: "/users/id}", isErrorExpected: true},
+		{target: "/users/{id}}", isErrorExpected: true},
+		{target: "/users/{{id}}", isErrorExpected: true},
+		{target: "/users/{}", isErrorExpected: true},
+		{target: "/users/{user id}", isErrorExpected: true},
+		{target: "/users/{id/orders}", isErrorExpected: true},
+	}
+	for _, test := range tests {
+		apiYaml := APIYaml{}
+		apiYaml.Data.Name = "PetStore"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/petstore/1.0.0"
+		apiYaml.Data.EndpointConfig.ProductionEndpoints = getTestEndpoints(1)
+		apiYaml.Data.Operations = []OperationYaml{{Target: "/pets", Verb: "GET"}, {Target: test.target, Verb: "POST"}}
+		err := apiYaml.ValidateMandatoryFields()
+		if test.isErrorExpected {
+			if assert.NotNil(t, err, test.target) {
+				assert.Contains(t, err.Error(), test.target, "Error should name the invalid target")
+			}
+		} else {
+			assert.Nil(t, err, test.target)
+		}
+	}
+}
+
+func TestValidateMandatoryFieldsWithJwksConfig(t *testing.T) {
+	tests := []struct {
+		name            string
+		jwksConfig      *JwksConfig
+		isErrorExpected bool
+	}{
+		{
+			name:            "Without a JWKS endpoint",
+			isErrorExpected: false,
+		},
+		{
+			name:            "HTTPS JWKS endpoint with a refresh interval",
+			jwksConfig:      &JwksConfig{JwksURL: "https://idp.tenant1.com/oauth2/jwks", RefreshIntervalInSeconds: 300},
+			isErrorExpected: false,
+		},
+		{
+			name:            "HTTP JWKS endpoint without a refresh interval",
+			jwksConfig:      &JwksConfig{JwksURL: "http://idp.tenant1.svc:9443/jwks"},
+			isErrorExpected: false,
+		},
+		{
+			name:            "Empty JWKS endpoint",
+			jwksConfig:      &JwksConfig{JwksURL: " ", RefreshIntervalInSeconds: 300},
+			isErrorExpected: true,
+		},
+		{
+			name:            "Relative JWKS endpoint",
+			jwksConfig:      &JwksConfig{JwksURL: "/oauth2/jwks"},
+			isErrorExpected: true,
+		},
+		{
+			name:            "JWKS endpoint with an unsupported scheme",
+			jwksConfig:      &JwksConfig{JwksURL: "file:///etc/jwks.json"},
+			isErrorExpected: true,
+		},
+		{
+			name:            "JWKS endpoint which cannot be parsed",
+			jwksConfig:      &JwksConfig{JwksURL: "https://idp.tenant1.com:port/jwks"},
+			isErrorExpected: true,
+		},
+		{
+			name:            "Negative refresh interval",
+			jwksConfig:      &JwksConfig{JwksURL: "https://idp.tenant1.com/oauth2/jwks", RefreshIntervalInSeconds: -1},
+			isErrorExpected: true,
+		},
+	}
+	for _, test := range tests {
+		apiYaml := APIYaml{}
+		apiYaml.Data.Name = "PetStore"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/petstore/1.0.0"
+		apiYaml.Data.EndpointConfig.ProductionEndpoints = getTestEndpoints(1)
+		apiYaml.Data.JwksConfig = test.jwksConfig
+		err := apiYaml.ValidateMandatoryFields()
+		if test.isErrorExpected {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestValidateMandatoryFieldsWithSessionManagement(t *testing.T) {
+	tests := []struct {
+		name              string
+		sessionManagement string
+		sessionTimeOut    string
+		sessionCookieName string
+		isErrorExpected   bool
+	}{
+		{
+			name:              "Without the session management",
+			sessionManagement: "",
+			isErrorExpected:   false,
+		},
+		{
+			name:              "None session management",
+			sessionManagement: "none",
+			sessionTimeOut:    "invalid",
+			isErrorExpected:   false,
+		},
+		{
+			name:              "Cookie session management with a timeout",
+			sessionManagement: "cookie",
+			sessionTimeOut:    "60000",
+			sessionCookieName: "JSESSIONID",
+			isErrorExpected:   false,
+		},
+		{
+			name:              "Transport session management without a timeout",
+			sessionManagement: "Transport",
+			isErrorExpected:   false,
+		},
+		{
+			name:              "Unknown session management",
+			sessionManagement: "soap",
+			isErrorExpected:   true,
+		},
+		{
+			name:              "Negative session timeout",
+			sessionManagement: "cookie",
+			sessionTimeOut:    "-1",
+			isErrorExpected:   true,
+		},
+		{
+			name:              "Session timeout which is not a number",
+			sessionManagement: "cookie",
+			sessionTimeOut:    "30m",
+			isErrorExpected:   true,
+		},
+		{
+			name:              "Invalid session cookie name",
+			sessionManagement: "cookie",
+			sessionCookieName: "SESSION;ID",
+			isErrorExpected:   true,
+		},
+	}
+	for _, test := range tests {
+		apiYaml := APIYaml{}
+		apiYaml.Data.Name = "PetStore"
+		apiYaml.Data.Version = "1.0.0"
+		apiYaml.Data.Context = "/petstore/1.0.0"
+		apiYaml.Data.EndpointConfig.ProductionEndpoints = getTestEndpoints(2)
+		apiYaml.Data.EndpointConfig.LoadBalanceSessionManagement = test.sessionManagement
+		apiYaml.Data.EndpointConfig.LoadBalanceSessionTimeOut = test.sessionTimeOut
+		apiYaml.Data.EndpointConfig.LoadBalanceSessionCookieName = test.sessionCookieName
+		err := apiYaml.ValidateMandatoryFields()
+		if test.isErrorExpected {
+			assert.NotNil(t, err, test.name)
+		} else {
+			assert.Nil(t, err, test.name)
+		}
+	}
+}
+
+func TestGetUnrecognizedSecuritySchemes(t *testing.T) {
+	apiYaml := APIYaml{}
+	apiYaml.Data.SecurityScheme = []string{"oauth2", "api_key", "mutualssl_mandatory"}
+	assert.Empty(t, apiYaml.GetUnrecognizedSecuritySchemes(), "Known security schemes should be recognized")
+
+	apiYaml.Data.SecurityScheme = []string{"oauth2", "api-key", "digest_auth"}
+	assert.Equal(t, []string{"api-key", "digest_auth"}, apiYaml.GetUnrecognizedSecuritySchemes(),
+		"Unknown security schemes should be reported")
+}
+
+func TestFormatAndUpdateInfoOperationVerbs(t *testing.T) {
+	apiYaml := APIYaml{}
+	apiYaml.Data.Operations = []OperationYaml{{Target: "/pets", Verb: "patch"}, {Target: "/pets", Verb: "Report"}}
+	apiYaml.FormatAndUpdateInfo()
+	assert.Equal(t, "PATCH", apiYaml.Data.Operations[0].Verb)
+	assert.Equal(t, "REPORT", apiYaml.Data.Operations[1].Verb)
+}
+
+func TestFormatAndUpdateInfoDefaultOrganization(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousControlPlaneEnabled := conf.ControlPlane.Enabled
+	previousDefaultOrganizationID := conf.Adapter.DefaultOrganizationID
+	defer func() {
+		conf.ControlPlane.Enabled = previousControlPlaneEnabled
+		conf.Adapter.DefaultOrganizationID = previousDefaultOrganizationID
+	}()
+
+	tests := []struct {
+		name                  string
+		controlPlaneEnabled   bool
+		defaultOrganizationID string
+		organizationID        string
+		expected              string
+	}{
+		{
+			name:                  "Configured default organization in standalone mode",
+			defaultOrganizationID: "air-gapped-org",
+			expected:              "air-gapped-org",
+		},
+		{
+			name:     "Control plane tenant in standalone mode without a default organization",
+			expected: config.GetControlPlaneConnectedTenantDomain(),
+		},
+		{
+			name:                  "Control plane tenant when connected to the control plane",
+			controlPlaneEnabled:   true,
+			defaultOrganizationID: "air-gapped-org",
+			expected:              config.GetControlPlaneConnectedTenantDomain(),
+		},
+		{
+			name:                  "Organization of the API project",
+			defaultOrganizationID: "air-gapped-org",
+			organizationID:        "project-org",
+			expected:              "project-org",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf.ControlPlane.Enabled = test.controlPlaneEnabled
+			conf.Adapter.DefaultOrganizationID = test.defaultOrganizationID
+			apiYaml := APIYaml{}
+			apiYaml.Data.OrganizationID = test.organizationID
+			apiYaml.FormatAndUpdateInfo()
+			assert.Equal(t, test.expected, apiYaml.Data.OrganizationID)
+		})
+	}
+}
+
+func TestNewAPIYamlWithAdditionalProperties(t *testing.T) {
+	apiYamlTemplate := `type: api
+version: v4.1.0
+data:
+  name: Store
+  context: /store
+  version: v1
+  type: HTTP
+  endpointConfig:
+    endpoint_type: http
+    production_endpoints:
+      url: http://store.wso2.com
+%s
+`
+	tests := []struct {
+		name                 string
+		additionalProperties string
+		expected             map[string]string
+	}{
+		{
+			name: "Additional properties as a map",
+			additionalProperties: `  additionalProperties:
+    owner: team-store
+    priority: 1`,
+			expected: map[string]string{"owner": "team-store", "priority": "1"},
+		},
+		{
+			name: "Additional properties as a list of name value pairs",
+			additionalProperties: `  additionalProperties:
+    - name: owner
+      value: team-store
+      display: true
+    - name: tier
+      value: gold
+      display: false`,
+			expected: map[string]string{"owner": "team-store", "tier": "gold"},
+		},
+		{
+			name:                 "Empty additional properties",
+			additionalProperties: `  additionalProperties: []`,
+			expected:             map[string]string{},
+		},
+		{
+			name:                 "No additional properties",
+			additionalProperties: "",
+			expected:             nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apiYaml, err := NewAPIYaml([]byte(fmt.Sprintf(apiYamlTemplate, test.additionalProperties)))
+			assert.Nil(t, err)
+			if test.expected == nil {
+				assert.Empty(t, apiYaml.Data.AdditionalProperties)
+			} else {
+				assert.Equal(t, test.expected, map[string]string(apiYaml.Data.AdditionalProperties))
+			}
+
+			var mgwSwagger MgwSwagger
+			assert.Nil(t, mgwSwagger.PopulateFromAPIYaml(apiYaml))
+			assert.Equal(t, map[string]string(apiYaml.Data.AdditionalProperties), mgwSwagger.GetAdditionalProperties(),
+				"additional properties should be retained in the MgwSwagger")
+		})
+	}
+
+	_, err := NewAPIYaml([]byte(fmt.Sprintf(apiYamlTemplate, `  additionalProperties: owner`)))
+	assert.NotNil(t, err, "additional properties which are neither a map nor a list should be rejected")
+}
+
+func TestNewAPIYamlWithSchemaVersion(t *testing.T) {
+	apiYamlTemplate := `type: api
+%s
+data:
+  name: Store
+  context: /store
+  version: v1
+  type: HTTP
+  endpointConfig:
+    endpoint_type: http
+    production_endpoints:
+      url: http://store.wso2.com
+`
+	tests := []struct {
+		name          string
+		schemaVersion string
+		isSupported   bool
+	}{
+		{name: "Major version", schemaVersion: "version: v4", isSupported: true},
+		{name: "Minor version", schemaVersion: "version: v4.1", isSupported: true},
+		{name: "Patch version", schemaVersion: "version: v4.2.0", isSupported: true},
+		{name: "Schema version not provided", schemaVersion: "", isSupported: true},
+		{name: "Older major version", schemaVersion: "version: v3.2.0", isSupported: false},
+		{name: "Newer major version", schemaVersion: "version: v5.0.0", isSupported: false},
+		{name: "Version without prefix", schemaVersion: "version: 4.1.0", isSupported: false},
+		{name: "Malformed version", schemaVersion: "version: v4.1.0-beta", isSupported: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apiYaml, err := NewAPIYaml([]byte(fmt.Sprintf(apiYamlTemplate, test.schemaVersion)))
+			if test.isSupported {
+				assert.Nil(t, err, "api.yaml with a supported schema version should be accepted")
+				assert.Equal(t, "Store", apiYaml.Data.Name)
+			} else {
+				assert.NotNil(t, err, "api.yaml with an unsupported schema version should be rejected")
+			}
+		})
+	}
+}
+
+func TestNewAPIYamlWithUnsupportedFeatures(t *testing.T) {
+	apiYamlContent := `type: api
+version: v4.2.0
+data:
+  name: Store
+  context: /store
+  version: v1
+  type: HTTP
+  lifeCycleStatus: PROTOTYPED
+  endpointImplementationType: INLINE
+  responseCachingEnabled: true
+  mediationPolicies:
+    - name: json_validator
+      type: IN
+  advertiseInfo:
+    advertised: false
+`
+	apiYaml, err := NewAPIYaml([]byte(apiYamlContent))
+	assert.Nil(t, err, "api.yaml with the unsupported features which can be tolerated should be accepted")
+	assert.Equal(t, constants.MockedOASEndpointType, apiYaml.Data.EndpointImplementationType,
+		"INLINE endpoints should be served with the mocked responses")
+	featureNames := make([]string, 0, len(apiYaml.UnsupportedFeatures))
+	for _, feature := range apiYaml.UnsupportedFeatures {
+		featureNames = append(featureNames, feature.Name)
+		assert.NotEmpty(t, feature.Message, "Unsupported feature %v should be described", feature.Name)
+	}
+	assert.ElementsMatch(t, []string{UnsupportedFeatureInlineEndpoint, UnsupportedFeatureResponseCaching,
+		UnsupportedFeatureMediationSequence}, featureNames)
+
+	apiProject := ProjectAPI{APIYaml: apiYaml}
+	apiProject.AddUnsupportedFeature(UnsupportedFeatureMediationSequence)
+	apiProject.AddUnsupportedFeature(UnsupportedFeatureAdvertiseOnlyAPI)
+	assert.Len(t, apiProject.GetUnsupportedFeatures(), 4, "Each unsupported feature should be reported once")
+
+	var mgwSwagger MgwSwagger
+	mgwSwagger.AddUnsupportedFeatureWarnings(apiProject.GetUnsupportedFeatures())
+	assert.Len(t, mgwSwagger.GetParseWarnings(), 4)
+	assert.NotNil(t, mgwSwagger.ValidateParseWarnings([]string{ParseWarningUnsupportedFeature}),
+		"Unsupported features should fail the API if configured in failOnParseWarnings")
+}
+
+func TestNewAPIYamlWithCacheVaryHeaders(t *testing.T) {
+	apiYamlContent := `type: api
+version: v4.2.0
+data:
+  name: Store
+  context: /store
+  version: v1
+  type: HTTP
+  endpointConfig:
+    endpoint_type: http
+    production_endpoints:
+      url: http://store-backend:8080
+  Operations:
+    - target: /orders
+      verb: GET
+      cacheVaryHeaders:
+        - Accept-Language
+        - X-Tenant
+    - target: /orders
+      verb: POST
+`
+	apiYaml, err := NewAPIYaml([]byte(apiYamlContent))
+	assert.Nil(t, err, "api.yaml with the cache vary headers should be accepted")
+	if assert.Len(t, apiYaml.Data.Operations, 2) {
+		assert.Equal(t, []string{"Accept-Language", "X-Tenant"}, apiYaml.Data.Operations[0].CacheVaryHeaders)
+		assert.Empty(t, apiYaml.Data.Operations[1].CacheVaryHeaders)
+	}
+	if assert.Len(t, apiYaml.UnsupportedFeatures, 1, "Cache vary headers should be reported as response caching") {
+		assert.Equal(t, UnsupportedFeatureResponseCaching, apiYaml.UnsupportedFeatures[0].Name)
+	}
+
+	apiYaml, err = NewAPIYaml([]byte(strings.Replace(apiYamlContent, `      cacheVaryHeaders:
+        - Accept-Language
+        - X-Tenant
+`, "", 1)))
+	assert.Nil(t, err)
+	assert.Empty(t, apiYaml.UnsupportedFeatures, "Operations without the cache vary headers should not be reported")
+
+	conf, _ := config.ReadConfigs()
+	previousResponseCache := conf.Envoy.Filters.ResponseCache
+	defer func() {
+		conf.Envoy.Filters.ResponseCache = previousResponseCache
+	}()
+	conf.Envoy.Filters.ResponseCache.Enabled = true
+	apiYaml, err = NewAPIYaml([]byte(apiYamlContent))
+	assert.Nil(t, err)
+	assert.Empty(t, apiYaml.UnsupportedFeatures, "Cache vary headers should be supported with the cache filter")
+}
+
+func TestNewAPIYamlWithInlineAndProxyEndpoints(t *testing.T) {
+	apiYamlTemplate := `type: api
+version: v4.2.0
+data:
+  name: Store
+  context: /store
+  version: v1
+  type: HTTP
+  endpointImplementationType: INLINE
+%s`
+	tests := []struct {
+		name           string
+		endpointConfig string
+		isValid        bool
+	}{
+		{
+			name:    "INLINE endpoint implementation without endpoints",
+			isValid: true,
+		},
+		{
+			name: "INLINE endpoint implementation with endpoints without URLs",
+			endpointConfig: `  endpointConfig:
+    endpoint_type: http
+    production_endpoints:
+      url: ""
+`,
+			isValid: true,
+		},
+		{
+			name: "INLINE endpoint implementation with production endpoints",
+			endpointConfig: `  endpointConfig:
+    endpoint_type: http
+    production_endpoints:
+      url: http://store-backend:8080
+`,
+		},
+		{
+			name: "INLINE endpoint implementation with load balanced sandbox endpoints",
+			endpointConfig: `  endpointConfig:
+    endpoint_type: load_balance
+    sandbox_endpoints:
+      - url: http://store-backend-1:8080
+      - url: http://store-backend-2:8080
+`,
+		},
+		{
+			name: "INLINE endpoint implementation with failover endpoints",
+			endpointConfig: `  endpointConfig:
+    endpoint_type: failover
+    production_failovers:
+      - url: http://store-backend-failover:8080
+`,
+		},
+		{
+			name: "INLINE endpoint implementation with an AWS Lambda endpoint",
+			endpointConfig: `  endpointConfig:
+    endpoint_type: awslambda
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewAPIYaml([]byte(fmt.Sprintf(apiYamlTemplate, test.endpointConfig)))
+			if test.isValid {
+				assert.Nil(t, err, "api.yaml with only the INLINE endpoint implementation should be accepted")
+			} else if assert.NotNil(t, err, "api.yaml with both the INLINE and the proxy endpoints should be rejected") {
+				assert.Contains(t, err.Error(), "mutually exclusive")
+			}
+		})
+	}
+}
+
+func TestNewAPIYamlWithEndpointType(t *testing.T) {
+	apiYamlTemplate := `type: api
+version: v4.1.0
+data:
+  name: Store
+  context: /store
+  version: v1
+  type: HTTP
+  endpointConfig:
+%s
+`
+	tests := []struct {
+		name           string
+		endpointConfig string
+		errorMessage   string
+	}{
+		{
+			name: "Load balanced endpoints",
+			endpointConfig: `    endpoint_type: load_balance
+    production_endpoints:
+      - url: http://store-1.wso2.com
+      - url: http://store-2.wso2.com
+    sandbox_endpoints:
+      - url: http://store-sandbox-1.wso2.com
+      - url: http://store-sandbox-2.wso2.com`,
+		},
+		{
+			name: "Load balanced with a single production endpoint",
+			endpointConfig: `    endpoint_type: load_balance
+    production_endpoints:
+      url: http://store.wso2.com`,
+			errorMessage: "endpoint_type load_balance requires multiple production endpoints",
+		},
+		{
+			name: "Load balanced with a single sandbox endpoint",
+			endpointConfig: `    endpoint_type: load_balance
+    production_endpoints:
+      - url: http://store-1.wso2.com
+      - url: http://store-2.wso2.com
+    sandbox_endpoints:
+      - url: http://store-sandbox.wso2.com`,
+			errorMessage: "endpoint_type load_balance requires multiple sandbox endpoints",
+		},
+		{
+			name: "Failover endpoints",
+			endpointConfig: `    endpoint_type: failover
+    production_endpoints:
+      url: http://store.wso2.com
+    production_failovers:
+      - url: http://store-failover.wso2.com`,
+		},
+		{
+			name: "Failover without the production failover endpoints",
+			endpointConfig: `    endpoint_type: failover
+    production_endpoints:
+      url: http://store.wso2.com
+    production_failovers: []`,
+			errorMessage: "endpoint_type failover requires the production failover endpoints",
+		},
+		{
+			name: "Failover without the sandbox failover endpoints",
+			endpointConfig: `    endpoint_type: failover
+    production_endpoints:
+      url: http://store.wso2.com
+    production_failovers:
+      - url: http://store-failover.wso2.com
+    sandbox_endpoints:
+      url: http://store-sandbox.wso2.com`,
+			errorMessage: "endpoint_type failover requires the sandbox failover endpoints",
+		},
+		{
+			name: "Failover endpoints without the sandbox endpoints",
+			endpointConfig: `    endpoint_type: failover
+    production_endpoints:
+      url: http://store.wso2.com
+    production_failovers:
+      - url: http://store-failover.wso2.com
+    sandbox_failovers:
+      - url: http://store-sandbox-failover.wso2.com`,
+			errorMessage: "endpoint_type failover requires the sandbox endpoints along with the sandbox failover endpoints",
+		},
+		{
+			name: "Failover endpoints with priorities",
+			endpointConfig: `    endpoint_type: failover
+    production_endpoints:
+      url: http://store.wso2.com
+    production_failovers:
+      - url: http://store-failover-1.wso2.com
+        priority: 2
+      - url: http://store-failover-2.wso2.com
+        priority: 1`,
+		},
+		{
+			name: "Priority of a primary endpoint",
+			endpointConfig: `    endpoint_type: failover
+    production_endpoints:
+      url: http://store.wso2.com
+      priority: 1
+    production_failovers:
+      - url: http://store-failover.wso2.com`,
+			errorMessage: "only the failover endpoints can be prioritized",
+		},
+		{
+			name: "Failover endpoint with a priority which is not positive",
+			endpointConfig: `    endpoint_type: failover
+    production_endpoints:
+      url: http://store.wso2.com
+    production_failovers:
+      - url: http://store-failover.wso2.com
+        priority: 0`,
+			errorMessage: "should be a positive number",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewAPIYaml([]byte(fmt.Sprintf(apiYamlTemplate, test.endpointConfig)))
+			if test.errorMessage == "" {
+				assert.Nil(t, err, "endpoint_type matching the endpoints should be accepted")
+			} else if assert.Error(t, err, "endpoint_type conflicting with the endpoints should be rejected") {
+				assert.Contains(t, err.Error(), test.errorMessage)
+			}
+		})
+	}
+}
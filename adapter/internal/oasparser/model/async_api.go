@@ -166,13 +166,13 @@ func (asyncAPI AsyncAPI) getResources() []*Resource {
 func populatePoliciesFromVendorExtensions(operation *Operation, vendorExtensions map[string]interface{}) {
 	var newResourcePath string
 	policyParameters := make(map[string]interface{})
-	if uriMapping, found := vendorExtensions[constants.XUriMapping]; found {
-		newResourcePath = uriMapping.(string)
+	if uriMapping, found := getStringExtension(vendorExtensions, constants.XUriMapping); found {
+		newResourcePath = uriMapping
 		if strings.Contains(newResourcePath, "?") {
 			newResourcePath = newResourcePath[:strings.Index(newResourcePath, "?")]
 		}
 		// URI Mapping parameter is only used when enforcer needs to map path parameters to query parameters.
-		policyParameters[constants.XUriMapping] = uriMapping.(string)
+		policyParameters[constants.XUriMapping] = uriMapping
 	} else {
 		newResourcePath = "/"
 	}
@@ -189,14 +189,8 @@ func populatePoliciesFromVendorExtensions(operation *Operation, vendorExtensions
 }
 
 func getSecurityArray(vendorExtensions map[string]interface{}) (security []map[string][]string) {
-	if vendorExtensions[constants.XScopes] != nil {
+	if scopes, found := getStringArrayExtension(vendorExtensions, constants.XScopes); found && scopes != nil {
 		securityItem := make(map[string][]string)
-		rawScopes := vendorExtensions[constants.XScopes].([]interface{})
-		var scopes []string
-
-		for _, rawScope := range rawScopes {
-			scopes = append(scopes, rawScope.(string))
-		}
 		securityItem[constants.APIMOauth2Type] = scopes
 		security = append(security, securityItem)
 	}
@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// AwsLambdaConfig represents an AWS Lambda function invoked as the backend of an operation. The requests are sent to
+// the regional Lambda endpoint, signed (SigV4) with the credentials of the access method.
+type AwsLambdaConfig struct {
+	// FunctionARN is the ARN of the function, ex: arn:aws:lambda:us-east-1:123456789012:function:orders
+	FunctionARN string `json:"functionArn,omitempty"`
+	// Region of the function. Defaults to the region of the function ARN.
+	Region string `json:"region,omitempty"`
+	// AccessMethod is either role-supplied (default) or stored
+	AccessMethod string `json:"accessMethod,omitempty"`
+	// RoleARN is the role assumed to invoke the function, when the access method is role-supplied
+	RoleARN         string `json:"roleArn,omitempty"`
+	RoleSessionName string `json:"roleSessionName,omitempty"`
+	// AccessKey and SecretKey are references to the secrets holding the access keys, in the form of $env{NAME},
+	// when the access method is stored
+	AccessKey string `json:"accessKey,omitempty"`
+	SecretKey string `json:"secretKey,omitempty"`
+	// TimeoutInMillis is the timeout of the invocation. The route timeout is used if not provided.
+	TimeoutInMillis uint32 `json:"timeoutInMillis,omitempty"`
+}
+
+var (
+	// arn:aws:lambda:<region>:<account id>:function:<function name>[:<version or alias>]
+	awsLambdaFunctionARNRegex = regexp.MustCompile(`^arn:aws[a-zA-Z-]*:lambda:([a-z0-9-]+):[0-9]{12}:function:` +
+		`[a-zA-Z0-9-_]+(:[a-zA-Z0-9-_$]+)?$`)
+	awsRoleARNRegex        = regexp.MustCompile(`^arn:aws[a-zA-Z-]*:iam::[0-9]{12}:role/.+$`)
+	secretReferenceRegex   = regexp.MustCompile(`^\$env\{[^{}]+\}$`)
+	awsLambdaAccessMethods = []string{constants.AwsLambdaRoleSupplied, constants.AwsLambdaStored}
+)
+
+// resolveAwsLambdaConfig validates the AWS Lambda configuration of an operation and returns it with the defaults
+// applied. The error names the missing or invalid property.
+func resolveAwsLambdaConfig(awsLambda AwsLambdaConfig, policies OperationPolicies) (*AwsLambdaConfig, error) {
+	if awsLambda.FunctionARN == "" {
+		return nil, errors.New("functionArn is not provided")
+	}
+	matches := awsLambdaFunctionARNRegex.FindStringSubmatch(awsLambda.FunctionARN)
+	if matches == nil {
+		return nil, fmt.Errorf("functionArn %q is not a valid AWS Lambda function ARN", awsLambda.FunctionARN)
+	}
+	if awsLambda.Region == "" {
+		awsLambda.Region = matches[1]
+	} else if awsLambda.Region != matches[1] {
+		return nil, fmt.Errorf("region %q does not match the region %q of the functionArn", awsLambda.Region,
+			matches[1])
+	}
+
+	if awsLambda.AccessMethod == "" {
+		awsLambda.AccessMethod = constants.AwsLambdaRoleSupplied
+	}
+	switch awsLambda.AccessMethod {
+	case constants.AwsLambdaRoleSupplied:
+		if awsLambda.AccessKey != "" || awsLambda.SecretKey != "" {
+			return nil, errors.New("accessKey and secretKey are not used with the role-supplied access method")
+		}
+		if awsLambda.RoleARN != "" && !awsRoleARNRegex.MatchString(awsLambda.RoleARN) {
+			return nil, fmt.Errorf("roleArn %q is not a valid IAM role ARN", awsLambda.RoleARN)
+		}
+		if awsLambda.RoleARN != "" && awsLambda.RoleSessionName == "" {
+			return nil, errors.New("roleSessionName is not provided for the roleArn")
+		}
+	case constants.AwsLambdaStored:
+		if awsLambda.AccessKey == "" {
+			return nil, errors.New("accessKey is not provided for the stored access method")
+		}
+		if awsLambda.SecretKey == "" {
+			return nil, errors.New("secretKey is not provided for the stored access method")
+		}
+		if !secretReferenceRegex.MatchString(awsLambda.AccessKey) {
+			return nil, errors.New("accessKey should be a secret reference in the form of $env{NAME}")
+		}
+		if !secretReferenceRegex.MatchString(awsLambda.SecretKey) {
+			return nil, errors.New("secretKey should be a secret reference in the form of $env{NAME}")
+		}
+	default:
+		return nil, fmt.Errorf("accessMethod %q is not supported. Supported access methods: %v",
+			awsLambda.AccessMethod, awsLambdaAccessMethods)
+	}
+
+	// The method rewrite routes the requests of the new method via an internal route, which is not supported
+	// with the function as the backend
+	for _, policy := range policies.Request {
+		if policy.Action == constants.ActionRewriteMethod {
+			return nil, fmt.Errorf("awsLambda cannot be used along with the %v policy %v",
+				constants.ActionRewriteMethod, policy.PolicyName)
+		}
+	}
+	return &awsLambda, nil
+}
@@ -22,7 +22,9 @@ package model
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
+	"path"
 	"regexp"
 	"strconv"
 	"strings"
@@ -44,36 +46,21 @@ func arrayContains(a []string, x string) bool {
 // getXWso2AuthHeader extracts the value of xWso2AuthHeader extension.
 // if the property is not available, an empty string is returned.
 func getXWso2AuthHeader(vendorExtensions map[string]interface{}) string {
-	xWso2AuthHeader := ""
-	if y, found := vendorExtensions[constants.XAuthHeader]; found {
-		if val, ok := y.(string); ok {
-			xWso2AuthHeader = val
-		}
-	}
+	xWso2AuthHeader, _ := getStringExtension(vendorExtensions, constants.XAuthHeader)
 	return xWso2AuthHeader
 }
 
 // getXWso2Basepath extracts the value of xWso2BasePath extension.
 // if the property is not available, an empty string is returned.
 func getXWso2Basepath(vendorExtensions map[string]interface{}) string {
-	xWso2basepath := ""
-	if y, found := vendorExtensions[constants.XWso2BasePath]; found {
-		if val, ok := y.(string); ok {
-			xWso2basepath = val
-		}
-	}
+	xWso2basepath, _ := getStringExtension(vendorExtensions, constants.XWso2BasePath)
 	return xWso2basepath
 }
 
 // getXWso2HTTP2BackendEnabled extracts the value of XWso2HTTP2BackendEnabled extension.
 // if the property is not available, false is returned.
 func getXWso2HTTP2BackendEnabled(vendorExtensions map[string]interface{}) bool {
-	xWso2HTTP2BackendEnabled := false
-	if y, found := vendorExtensions[constants.XWso2HTTP2BackendEnabled]; found {
-		if val, ok := y.(bool); ok {
-			xWso2HTTP2BackendEnabled = val
-		}
-	}
+	xWso2HTTP2BackendEnabled, _ := getBoolExtension(vendorExtensions, constants.XWso2HTTP2BackendEnabled, false)
 	return xWso2HTTP2BackendEnabled
 }
 
@@ -82,28 +69,18 @@ func getXWso2HTTP2BackendEnabled(vendorExtensions map[string]interface{}) bool {
 // will be prioritized.
 // if both the properties are not available, an empty string is returned.
 func ResolveThrottlingTier(vendorExtensions map[string]interface{}) string {
-	xTier := ""
-	if x, found := vendorExtensions[constants.XWso2ThrottlingTier]; found {
-		if val, ok := x.(string); ok {
-			xTier = val
-		}
-	} else if y, found := vendorExtensions[constants.XThrottlingTier]; found {
-		if val, ok := y.(string); ok {
-			xTier = val
-		}
+	if _, found := vendorExtensions[constants.XWso2ThrottlingTier]; found {
+		xTier, _ := getStringExtension(vendorExtensions, constants.XWso2ThrottlingTier)
+		return xTier
 	}
+	xTier, _ := getStringExtension(vendorExtensions, constants.XThrottlingTier)
 	return xTier
 }
 
 // ResolveAmznResourceName extracts the value of x-amzn-resource-name extension.
 // If the property is not availble, an empty string is returned.
 func ResolveAmznResourceName(vendorExtensions map[string]interface{}) string {
-	xAmznResourceName := ""
-	if x, found := vendorExtensions[constants.XAmznResourceName]; found {
-		if val, ok := x.(string); ok {
-			xAmznResourceName = val
-		}
-	}
+	xAmznResourceName, _ := getStringExtension(vendorExtensions, constants.XAmznResourceName)
 	return xAmznResourceName
 }
 
@@ -114,24 +91,15 @@ func ResolveAmznResourceName(vendorExtensions map[string]interface{}) string {
 // If the API definition is fed through apictl, the users can use either
 // x-wso2-disable-security : true/false to enable and disable security.
 func ResolveDisableSecurity(vendorExtensions map[string]interface{}) bool {
-	disableSecurity := false
-	y, vExtAuthType := vendorExtensions[constants.XAuthType]
-	z, vExtDisableSecurity := vendorExtensions[constants.XWso2DisableSecurity]
-	if vExtDisableSecurity {
-		// If x-wso2-disable-security is present, then disableSecurity = val
-		if val, ok := z.(bool); ok {
-			disableSecurity = val
-		}
-	}
-	if vExtAuthType && !disableSecurity {
+	// If x-wso2-disable-security is present, then disableSecurity = val
+	disableSecurity, _ := getBoolExtension(vendorExtensions, constants.XWso2DisableSecurity, false)
+	if !disableSecurity {
 		// If APIs are published through APIM, all resource levels contains x-auth-type
-		// vendor extension.
-		if val, ok := y.(string); ok {
-			// If the x-auth-type vendor ext is None, then the API/resource is considerd
-			// to be non secure
-			if val == constants.None {
-				disableSecurity = true
-			}
+		// vendor extension. If the x-auth-type vendor ext is None, then the API/resource is considerd
+		// to be non secure
+		if authType, found := getStringExtension(vendorExtensions, constants.XAuthType); found &&
+			authType == constants.None {
+			disableSecurity = true
 		}
 	}
 	return disableSecurity
@@ -156,6 +124,10 @@ func getHostandBasepathandPort(apiType string, rawURL string) (*Endpoint, error)
 	// Remove leading and trailing spaces of rawURL
 	rawURL = strings.Trim(rawURL, " ")
 
+	if socketPath, isUnixSocket := getUnixSocketPath(rawURL); isUnixSocket {
+		return getUnixSocketEndpoint(rawURL, socketPath)
+	}
+
 	if !strings.Contains(rawURL, "://") {
 		if (apiType == constants.HTTP || apiType == constants.GRAPHQL) {
 			rawURL = "http://" + rawURL
@@ -176,6 +148,12 @@ func getHostandBasepathandPort(apiType string, rawURL string) (*Endpoint, error)
 		return nil, errors.New("malformed endpoint detected (Invalid host name) : " + rawURL)
 	}
 
+	// The query of the endpoint is appended to the query of the forwarded requests, hence it should be valid
+	if _, err := url.ParseQuery(parsedURL.RawQuery); err != nil {
+		logger.LoggerOasparser.Error("Malformed endpoint detected (Invalid query) : ", rawURL)
+		return nil, errors.New("malformed endpoint detected (Invalid query) : " + rawURL)
+	}
+
 	host = parsedURL.Hostname()
 	basepath = parsedURL.Path
 	if parsedURL.Port() != "" {
@@ -202,7 +180,39 @@ func getHostandBasepathandPort(apiType string, rawURL string) (*Endpoint, error)
 		urlType = "ws"
 	}
 
-	return &Endpoint{Host: host, Basepath: basepath, Port: port, URLType: urlType, RawURL: rawURL}, nil
+	return &Endpoint{Host: host, Basepath: basepath, Port: port, URLType: urlType, RawURL: rawURL,
+		QueryParams: parsedURL.RawQuery}, nil
+}
+
+// getUnixSocketEndpoint returns the endpoint of a unix domain socket given in the form of unix:///path/to.sock, where
+// the host of the endpoint is the path of the socket.
+func getUnixSocketEndpoint(rawURL, socketPath string) (*Endpoint, error) {
+	if err := validateUnixSocketPath(socketPath); err != nil {
+		logger.LoggerOasparser.Errorf("Malformed endpoint detected (%v) : %v", err, rawURL)
+		return nil, fmt.Errorf("malformed endpoint detected (%v) : %v", err, rawURL)
+	}
+	return &Endpoint{Host: socketPath, URLType: constants.UnixSocketURLType, RawURL: rawURL}, nil
+}
+
+// getUnixSocketPath returns the path of the unix domain socket if the endpoint is given as unix:///path/to.sock
+func getUnixSocketPath(endpoint string) (string, bool) {
+	endpoint = strings.TrimSpace(endpoint)
+	if !strings.HasPrefix(endpoint, constants.UnixSocketURLType+"://") {
+		return "", false
+	}
+	return strings.TrimPrefix(endpoint, constants.UnixSocketURLType+"://"), true
+}
+
+// validateUnixSocketPath checks whether the path of a unix domain socket is absolute and clean, so that the socket
+// does not depend on the working directory of the router.
+func validateUnixSocketPath(socketPath string) error {
+	if !path.IsAbs(socketPath) {
+		return errors.New("unix domain socket path should be absolute")
+	}
+	if path.Clean(socketPath) != socketPath || strings.ContainsAny(socketPath, "?#") {
+		return errors.New("unix domain socket path should be a clean file path")
+	}
+	return nil
 }
 
 // unmarshalSwaggerResources used to populate mgwSwagger for both OpenAPI and AsyncAPI
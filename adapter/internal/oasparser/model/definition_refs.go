@@ -0,0 +1,181 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+const refKey string = "$ref"
+
+// ValidateDefinitionRefs validates that all the $refs of the OpenAPI definition of the API project resolve within
+// the project. Internal refs are resolved against the definition itself, while the refs to other files are resolved
+// against the definition files bundled in the Definitions directory of the project. Remote refs are not resolved.
+// An error listing the unresolved refs is returned if any of the refs does not resolve.
+func (apiProject *ProjectAPI) ValidateDefinitionRefs() error {
+	var definition map[string]interface{}
+	if err := json.Unmarshal(apiProject.APIDefinition, &definition); err != nil {
+		// Not a JSON definition (i.e. GraphQL SDL) or an invalid one which is reported while parsing.
+		return nil
+	}
+	if _, isSwagger := definition[constants.Swagger]; !isSwagger {
+		if _, isOpenAPI := definition[constants.OpenAPI]; !isOpenAPI {
+			return nil
+		}
+	}
+	resolver := &definitionRefResolver{
+		documents:  map[string]interface{}{"": definition},
+		files:      apiProject.DefinitionFiles,
+		unresolved: make(map[string]bool),
+	}
+	resolver.validateRefsOfDocument("", definition)
+	if len(resolver.unresolved) == 0 {
+		return nil
+	}
+	unresolvedRefs := make([]string, 0, len(resolver.unresolved))
+	for ref := range resolver.unresolved {
+		unresolvedRefs = append(unresolvedRefs, ref)
+	}
+	sort.Strings(unresolvedRefs)
+	logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+		Message: fmt.Sprintf("Unresolved $refs found in the API definition of the API %s:%s : %v",
+			apiProject.APIYaml.Data.Name, apiProject.APIYaml.Data.Version, strings.Join(unresolvedRefs, ", ")),
+		Severity:  logging.MINOR,
+		ErrorCode: 2240,
+	})
+	return fmt.Errorf("unresolved $refs in the API definition: %s", strings.Join(unresolvedRefs, ", "))
+}
+
+// definitionRefResolver resolves the $refs of an API definition and the definition files referred from it.
+type definitionRefResolver struct {
+	// path relative to the Definitions directory -> parsed document. The API definition itself has the empty path.
+	documents  map[string]interface{}
+	files      map[string][]byte
+	unresolved map[string]bool
+}
+
+// validateRefsOfDocument validates the $refs found anywhere in the node of the document in the given path.
+func (resolver *definitionRefResolver) validateRefsOfDocument(docPath string, node interface{}) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if ref, isString := child.(string); key == refKey && isString {
+				resolver.validateRef(docPath, ref)
+				continue
+			}
+			resolver.validateRefsOfDocument(docPath, child)
+		}
+	case []interface{}:
+		for _, child := range value {
+			resolver.validateRefsOfDocument(docPath, child)
+		}
+	}
+}
+
+// validateRef records the ref as unresolved if it cannot be resolved from the document in the given path.
+func (resolver *definitionRefResolver) validateRef(docPath, ref string) {
+	refDocPath, pointer := ref, ""
+	if i := strings.Index(ref, "#"); i >= 0 {
+		refDocPath, pointer = ref[:i], ref[i+1:]
+	}
+	if refDocPath == "" {
+		refDocPath = docPath
+	} else {
+		if refURL, err := url.Parse(refDocPath); err != nil || refURL.IsAbs() || path.IsAbs(refDocPath) {
+			resolver.unresolved[resolver.displayRef(docPath, ref)] = true
+			return
+		}
+		refDocPath = path.Join(path.Dir(docPath), refDocPath)
+	}
+	document, found := resolver.getDocument(refDocPath)
+	if !found || !resolvePointer(document, pointer) {
+		resolver.unresolved[resolver.displayRef(docPath, ref)] = true
+	}
+}
+
+// getDocument returns the parsed document in the given path. The refs of a bundled definition file are validated
+// when it is referred for the first time.
+func (resolver *definitionRefResolver) getDocument(docPath string) (interface{}, bool) {
+	if document, found := resolver.documents[docPath]; found {
+		return document, true
+	}
+	content, found := resolver.files[docPath]
+	if !found {
+		return nil, false
+	}
+	var document interface{}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, false
+	}
+	// The document is recorded before validating its refs, so that circular refs between the files terminate.
+	resolver.documents[docPath] = document
+	resolver.validateRefsOfDocument(docPath, document)
+	return document, true
+}
+
+// displayRef returns the ref prefixed with the file it is found in, if it is not found in the API definition.
+func (resolver *definitionRefResolver) displayRef(docPath, ref string) string {
+	if docPath == "" {
+		return ref
+	}
+	return docPath + ": " + ref
+}
+
+// resolvePointer returns whether the JSON pointer resolves within the document.
+func resolvePointer(document interface{}, pointer string) bool {
+	if pointer == "" {
+		return true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return false
+	}
+	node := document
+	for _, token := range strings.Split(pointer[1:], "/") {
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch value := node.(type) {
+		case map[string]interface{}:
+			child, found := value[token]
+			if !found {
+				return false
+			}
+			node = child
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(value) {
+				return false
+			}
+			node = value[index]
+		default:
+			return false
+		}
+	}
+	return true
+}
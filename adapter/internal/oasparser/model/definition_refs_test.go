@@ -0,0 +1,119 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDefinitionRefs(t *testing.T) {
+	definition := `{
+  "openapi": "3.0.1",
+  "info": {"title": "PetStore", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {"application/json": {"schema": {"$ref": "%s"}}}
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {"type": "object", "properties": {"tag": {"$ref": "#/components/schemas/Tag"}}},
+      "Tag": {"type": "string"}
+    }
+  }
+}`
+	definitionFiles := map[string][]byte{
+		"schemas/pets.json":   []byte(`{"Pets": {"type": "array", "items": {"$ref": "pet.json#/Pet"}}}`),
+		"schemas/pet.json":    []byte(`{"Pet": {"type": "object", "properties": {"owner": {"$ref": "owner.json"}}}}`),
+		"schemas/broken.json": []byte(`{"Broken": {"$ref": "missing.json#/Owner"}}`),
+	}
+	tests := []struct {
+		name         string
+		ref          string
+		errorMessage string
+	}{
+		{
+			name: "Internal ref",
+			ref:  "#/components/schemas/Pet",
+		},
+		{
+			name: "Ref to a bundled definition file",
+			ref:  "schemas/pets.json#/Pets",
+			// owner.json referred from pet.json is not bundled
+			errorMessage: "unresolved $refs in the API definition: schemas/pet.json: owner.json",
+		},
+		{
+			name:         "Broken internal ref",
+			ref:          "#/components/schemas/Owner",
+			errorMessage: "unresolved $refs in the API definition: #/components/schemas/Owner",
+		},
+		{
+			name:         "Ref to a file which is not bundled",
+			ref:          "schemas/owner.json#/Owner",
+			errorMessage: "unresolved $refs in the API definition: schemas/owner.json#/Owner",
+		},
+		{
+			name:         "Broken ref inside a bundled definition file",
+			ref:          "schemas/broken.json#/Broken",
+			errorMessage: "unresolved $refs in the API definition: schemas/broken.json: missing.json#/Owner",
+		},
+		{
+			name:         "Remote ref",
+			ref:          "https://petstore.com/schemas.json#/Pet",
+			errorMessage: "unresolved $refs in the API definition: https://petstore.com/schemas.json#/Pet",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apiProject := ProjectAPI{
+				APIDefinition:   []byte(fmt.Sprintf(definition, test.ref)),
+				DefinitionFiles: definitionFiles,
+			}
+			err := apiProject.ValidateDefinitionRefs()
+			if test.errorMessage == "" {
+				assert.Nil(t, err)
+			} else if assert.NotNil(t, err) {
+				assert.Equal(t, test.errorMessage, err.Error())
+			}
+		})
+	}
+
+	resolvableFiles := map[string][]byte{
+		"schemas/pets.json":  definitionFiles["schemas/pets.json"],
+		"schemas/pet.json":   definitionFiles["schemas/pet.json"],
+		"schemas/owner.json": []byte(`{"type": "object"}`),
+	}
+	apiProject := ProjectAPI{
+		APIDefinition:   []byte(fmt.Sprintf(definition, "schemas/pets.json#/Pets")),
+		DefinitionFiles: resolvableFiles,
+	}
+	assert.Nil(t, apiProject.ValidateDefinitionRefs(), "Refs to the bundled definition files should resolve")
+
+	graphQLProject := ProjectAPI{APIDefinition: []byte("type Query { pet: String }")}
+	assert.Nil(t, graphQLProject.ValidateDefinitionRefs(), "Non OpenAPI definitions should not be validated")
+}
@@ -0,0 +1,212 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// resolveEndpointHost resolves the IP addresses of the host of an endpoint.
+var resolveEndpointHost = func(ctx context.Context, host string) ([]net.IP, error) {
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ips := make([]net.IP, len(addresses))
+	for i, address := range addresses {
+		ips[i] = address.IP
+	}
+	return ips, nil
+}
+
+// guardedEndpoint is an endpoint of the API checked by the endpoint guard, along with where it is defined.
+type guardedEndpoint struct {
+	endpoint Endpoint
+	location string
+}
+
+// validateEndpointHosts resolves the hosts of the production, sandbox and interceptor endpoints of the API and returns
+// an error if any of those resolves only to the loopback, link-local or forbidden addresses, when the endpoint guard
+// is enabled. Hence the endpoints cannot reach the internal services of the gateway, such as the cloud metadata
+// service. The hosts which cannot be resolved are reported as parse warnings, unless failOnDNSError is enabled.
+// This needs to be called once the endpoints of the API are final (i.e. after the endpoints of the environment are
+// applied).
+func (swagger *MgwSwagger) validateEndpointHosts() error {
+	conf, _ := config.ReadConfigs()
+	guardConfig := conf.Adapter.EndpointGuard
+	if !guardConfig.Enabled {
+		return nil
+	}
+	forbiddenNetworks := parseEndpointGuardCIDRs(guardConfig.ForbiddenCIDRs)
+	allowedNetworks := parseEndpointGuardCIDRs(guardConfig.AllowedCIDRs)
+	dnsTimeout := time.Duration(guardConfig.DNSTimeoutInSeconds) * time.Second
+
+	for _, guarded := range swagger.getGuardedEndpoints() {
+		host := strings.TrimSuffix(strings.Trim(guarded.endpoint.Host, "[]"), ".")
+		if host == "" || guarded.endpoint.URLType == constants.UnixSocketURLType || guarded.endpoint.ServiceDiscoveryString != "" {
+			continue
+		}
+		if isEndpointHostAllowed(host, guardConfig.AllowedHosts) {
+			continue
+		}
+		addresses, err := resolveGuardedHost(host, dnsTimeout)
+		if err != nil {
+			if guardConfig.FailOnDNSError {
+				return fmt.Errorf("host %s of the %s endpoint %s cannot be resolved. %v", host, guarded.location,
+					guarded.endpoint.RawURL, err)
+			}
+			logger.LoggerOasparser.Warnf("Host %s of the %s endpoint %s of the API %s:%s cannot be resolved. %v",
+				host, guarded.location, guarded.endpoint.RawURL, swagger.title, swagger.version, err)
+			swagger.addParseWarning(ParseWarningUnresolvedEndpointHost,
+				"host %s of the %s endpoint %s cannot be resolved", host, guarded.location, guarded.endpoint.RawURL)
+			continue
+		}
+		if isForbiddenEndpoint(addresses, forbiddenNetworks, allowedNetworks) {
+			return fmt.Errorf("%s endpoint %s is rejected, as its host %s resolves only to the internal addresses %v",
+				guarded.location, guarded.endpoint.RawURL, host, addresses)
+		}
+	}
+	return nil
+}
+
+// getGuardedEndpoints returns the production, sandbox, fallback and interceptor endpoints of the API, its resources
+// and operations.
+func (swagger *MgwSwagger) getGuardedEndpoints() []guardedEndpoint {
+	var guardedEndpoints []guardedEndpoint
+	addCluster := func(endpointCluster *EndpointCluster, location string) {
+		if endpointCluster == nil {
+			return
+		}
+		for _, endpoint := range endpointCluster.Endpoints {
+			guardedEndpoints = append(guardedEndpoints, guardedEndpoint{endpoint: endpoint, location: location})
+		}
+	}
+	addInterceptors := func(vendorExtensions map[string]interface{}, level, location string) {
+		for _, extensionName := range []string{constants.XWso2RequestInterceptor, constants.XWso2ResponseInterceptor} {
+			if _, found := vendorExtensions[extensionName]; !found {
+				continue
+			}
+			interceptor := swagger.GetInterceptor(vendorExtensions, extensionName, level)
+			addCluster(&interceptor.EndpointCluster, location+" interceptor")
+		}
+	}
+
+	addCluster(swagger.productionEndpoints, "API level production")
+	addCluster(swagger.sandboxEndpoints, "API level sandbox")
+	if swagger.fallbackEndpoint != nil {
+		guardedEndpoints = append(guardedEndpoints,
+			guardedEndpoint{endpoint: *swagger.fallbackEndpoint, location: "API level fallback"})
+	}
+	addInterceptors(swagger.vendorExtensions, constants.APILevelInterceptor, "API level")
+	for _, resource := range swagger.resources {
+		location := "resource " + resource.path
+		addCluster(resource.productionEndpoints, location+" production")
+		addCluster(resource.sandboxEndpoints, location+" sandbox")
+		addInterceptors(resource.vendorExtensions, constants.ResourceLevelInterceptor, location)
+		for _, operation := range resource.methods {
+			operationLocation := fmt.Sprintf("operation %s %s", operation.method, resource.path)
+			addInterceptors(operation.vendorExtensions, constants.OperationLevelInterceptor, operationLocation)
+			for _, isIn := range []bool{true, false} {
+				if interceptor := operation.GetCallInterceptorService(isIn); interceptor.Enable {
+					addCluster(&interceptor.EndpointCluster, operationLocation+" interceptor policy")
+				}
+			}
+		}
+	}
+	return guardedEndpoints
+}
+
+// resolveGuardedHost returns the IP addresses of the host, which is the address itself if the host is an IP address.
+func resolveGuardedHost(host string, timeout time.Duration) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	addresses, err := resolveEndpointHost(ctx, host)
+	if err == nil && len(addresses) == 0 {
+		err = fmt.Errorf("no addresses are found for the host %s", host)
+	}
+	return addresses, err
+}
+
+// isEndpointHostAllowed returns true if the host is listed in the allowed hosts of the endpoint guard.
+func isEndpointHostAllowed(host string, allowedHosts []string) bool {
+	for _, allowedHost := range allowedHosts {
+		if strings.EqualFold(strings.TrimSuffix(strings.TrimSpace(allowedHost), "."), host) {
+			return true
+		}
+	}
+	return false
+}
+
+// isForbiddenEndpoint returns true if none of the addresses of an endpoint can be connected to, i.e. each of those is
+// a loopback, link-local or forbidden address which is not in the allowed networks.
+func isForbiddenEndpoint(addresses []net.IP, forbiddenNetworks, allowedNetworks []*net.IPNet) bool {
+	for _, address := range addresses {
+		if !isForbiddenAddress(address, forbiddenNetworks, allowedNetworks) {
+			return false
+		}
+	}
+	return true
+}
+
+func isForbiddenAddress(address net.IP, forbiddenNetworks, allowedNetworks []*net.IPNet) bool {
+	for _, network := range allowedNetworks {
+		if network.Contains(address) {
+			return false
+		}
+	}
+	if address.IsLoopback() || address.IsLinkLocalUnicast() || address.IsLinkLocalMulticast() ||
+		address.IsUnspecified() {
+		return true
+	}
+	for _, network := range forbiddenNetworks {
+		if network.Contains(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEndpointGuardCIDRs parses the CIDRs of the endpoint guard configuration, ignoring the invalid CIDRs.
+func parseEndpointGuardCIDRs(cidrs []string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Invalid CIDR %q in the endpoint guard configuration is ignored. %v", cidr, err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 2241,
+			})
+			continue
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,199 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
+)
+
+func TestValidateEndpointHosts(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousGuardConfig := conf.Adapter.EndpointGuard
+	previousResolver := resolveEndpointHost
+	defer func() {
+		conf.Adapter.EndpointGuard = previousGuardConfig
+		resolveEndpointHost = previousResolver
+	}()
+	resolvedHosts := map[string][]net.IP{
+		"metadata.internal": {net.ParseIP("169.254.169.254")},
+		"localhost":         {net.ParseIP("127.0.0.1"), net.ParseIP("::1")},
+		"petstore.io":       {net.ParseIP("93.184.216.34")},
+		"mixed.internal":    {net.ParseIP("127.0.0.1"), net.ParseIP("93.184.216.34")},
+		"adapter.internal":  {net.ParseIP("10.0.0.5")},
+	}
+	resolveEndpointHost = func(ctx context.Context, host string) ([]net.IP, error) {
+		if addresses, found := resolvedHosts[host]; found {
+			return addresses, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	tests := []struct {
+		name            string
+		forbiddenCIDRs  []string
+		allowedHosts    []string
+		allowedCIDRs    []string
+		failOnDNSError  bool
+		endpoint        string
+		isErrorExpected bool
+		errorContains   string
+		warningExpected bool
+	}{
+		{
+			name:            "Cloud metadata address",
+			endpoint:        "http://169.254.169.254/latest/meta-data",
+			isErrorExpected: true,
+			errorContains:   "169.254.169.254",
+		},
+		{
+			name:            "Host resolving to the metadata address",
+			endpoint:        "http://metadata.internal/latest",
+			isErrorExpected: true,
+			errorContains:   "169.254.169.254",
+		},
+		{
+			name:            "Host resolving to loopback addresses",
+			endpoint:        "http://localhost:9095/admin",
+			isErrorExpected: true,
+			errorContains:   "127.0.0.1",
+		},
+		{
+			name:     "Host resolving to a public address",
+			endpoint: "https://petstore.io/v1",
+		},
+		{
+			name:     "Host resolving to both loopback and public addresses",
+			endpoint: "https://mixed.internal/v1",
+		},
+		{
+			name:     "Host resolving to a private address which is not forbidden",
+			endpoint: "http://adapter.internal:9843",
+		},
+		{
+			name:            "Host resolving to a forbidden CIDR",
+			forbiddenCIDRs:  []string{"10.0.0.0/8"},
+			endpoint:        "http://adapter.internal:9843",
+			isErrorExpected: true,
+			errorContains:   "10.0.0.5",
+		},
+		{
+			name:         "Allowed host",
+			allowedHosts: []string{"localhost"},
+			endpoint:     "http://localhost:9095/admin",
+		},
+		{
+			name:         "Address in an allowed CIDR",
+			allowedCIDRs: []string{"127.0.0.0/8", "::1/128"},
+			endpoint:     "http://localhost:9095/admin",
+		},
+		{
+			name:            "Unresolvable host",
+			endpoint:        "http://unknown.petstore.io",
+			warningExpected: true,
+		},
+		{
+			name:            "Unresolvable host when failing on DNS errors",
+			failOnDNSError:  true,
+			endpoint:        "http://unknown.petstore.io",
+			isErrorExpected: true,
+			errorContains:   "unknown.petstore.io",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf.Adapter.EndpointGuard.Enabled = true
+			conf.Adapter.EndpointGuard.DNSTimeoutInSeconds = 5
+			conf.Adapter.EndpointGuard.ForbiddenCIDRs = test.forbiddenCIDRs
+			conf.Adapter.EndpointGuard.AllowedHosts = test.allowedHosts
+			conf.Adapter.EndpointGuard.AllowedCIDRs = test.allowedCIDRs
+			conf.Adapter.EndpointGuard.FailOnDNSError = test.failOnDNSError
+			endpoint, err := getHTTPEndpoint(test.endpoint)
+			assert.Nil(t, err)
+			mgwSwagger := MgwSwagger{
+				title:               "PetStore",
+				version:             "1.0.0",
+				productionEndpoints: generateEndpointCluster(constants.ProdClustersConfigNamePrefix, []Endpoint{*endpoint}, constants.LoadBalance),
+			}
+			err = mgwSwagger.validateEndpointHosts()
+			if test.isErrorExpected {
+				if assert.NotNil(t, err) {
+					assert.Contains(t, err.Error(), test.errorContains)
+				}
+				return
+			}
+			assert.Nil(t, err)
+			if test.warningExpected {
+				if assert.Len(t, mgwSwagger.GetParseWarnings(), 1) {
+					assert.Equal(t, ParseWarningUnresolvedEndpointHost, mgwSwagger.GetParseWarnings()[0].Code)
+				}
+			} else {
+				assert.Empty(t, mgwSwagger.GetParseWarnings())
+			}
+		})
+	}
+}
+
+func TestValidateEndpointHostsOfInterceptorsAndEnvProps(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousGuardConfig := conf.Adapter.EndpointGuard
+	defer func() {
+		conf.Adapter.EndpointGuard = previousGuardConfig
+	}()
+	conf.Adapter.EndpointGuard.Enabled = true
+	conf.Adapter.EndpointGuard.DNSTimeoutInSeconds = 5
+	publicEndpoint, _ := getHTTPEndpoint("https://93.184.216.34/v1")
+
+	mgwSwagger := MgwSwagger{
+		title:               "PetStore",
+		version:             "1.0.0",
+		productionEndpoints: generateEndpointCluster(constants.ProdClustersConfigNamePrefix, []Endpoint{*publicEndpoint}, constants.LoadBalance),
+		resources: []*Resource{{
+			path:    "/pets",
+			methods: []*Operation{NewOperation("GET", nil, nil)},
+			vendorExtensions: map[string]interface{}{
+				constants.XWso2RequestInterceptor: map[string]interface{}{
+					"serviceURL": "http://169.254.169.254:8080",
+				},
+			},
+		}},
+	}
+	err := mgwSwagger.validateEndpointHosts()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "resource /pets interceptor")
+		assert.Contains(t, err.Error(), "169.254.169.254")
+	}
+
+	mgwSwagger.resources[0].vendorExtensions = nil
+	assert.Nil(t, mgwSwagger.validateEndpointHosts())
+	mgwSwagger.SetEnvLabelProperties(synchronizer.APIEnvProps{
+		APIConfigs: synchronizer.APIConfigs{SandBoxEndpoint: "http://127.0.0.1:9001/admin"},
+	})
+	err = mgwSwagger.validateEndpointHosts()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "API level sandbox")
+		assert.Contains(t, err.Error(), "127.0.0.1")
+	}
+}
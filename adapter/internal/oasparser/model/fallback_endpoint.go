@@ -0,0 +1,86 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/extensions"
+)
+
+// validateFallbackEndpointURL validates the URL of the x-wso2-fallback-endpoint extension the same way as the URLs
+// of the other endpoints.
+func validateFallbackEndpointURL(rawURL string) error {
+	_, err := getFallbackEndpoint(rawURL)
+	return err
+}
+
+func getFallbackEndpoint(rawURL string) (*Endpoint, error) {
+	endpoint, err := getHTTPEndpoint(rawURL)
+	if err != nil {
+		return nil, &extensions.ValidationError{Message: fmt.Sprintf("invalid URL %q. %v", rawURL, err)}
+	}
+	if err := endpoint.validateEndpoint(); err != nil {
+		return nil, &extensions.ValidationError{Message: fmt.Sprintf("invalid URL %q. %v", rawURL, err)}
+	}
+	return endpoint, nil
+}
+
+// setXWso2FallbackEndpoint sets the endpoint to which the requests of the API are routed when all the API level
+// production endpoints are unhealthy (e.g. a static "sorry server"), given by the x-wso2-fallback-endpoint extension.
+// The fallback endpoint is health checked the same way as the production endpoints (i.e. it has to accept the TCP
+// connections of the health checks), and receives the requests only while it is healthy.
+func (swagger *MgwSwagger) setXWso2FallbackEndpoint() error {
+	var rawURL string
+	found, err := extensions.Extract(swagger.vendorExtensions, constants.XWso2FallbackEndpoint, &rawURL)
+	if err != nil || !found {
+		return err
+	}
+	swagger.fallbackEndpoint, err = getFallbackEndpoint(rawURL)
+	return err
+}
+
+// GetFallbackEndpoint returns the endpoint to which the requests of the API are routed when all the API level
+// production endpoints are unhealthy. Nil is returned if the API has no fallback endpoint.
+func (swagger *MgwSwagger) GetFallbackEndpoint() *Endpoint {
+	return swagger.fallbackEndpoint
+}
+
+// validateFallbackEndpoint returns an error if the API has a fallback endpoint, but the API level production
+// endpoints are not health checked or have a different basepath. The router fails over to the fallback endpoint
+// only when the production endpoints are detected to be unhealthy by the active health checks, which the fallback
+// endpoint has to pass as well.
+func (swagger *MgwSwagger) validateFallbackEndpoint() error {
+	if swagger.fallbackEndpoint == nil {
+		return nil
+	}
+	if swagger.productionEndpoints == nil || !swagger.productionEndpoints.IsHealthChecked() {
+		return fmt.Errorf("%s requires the API level production endpoints to be health checked, which is enabled "+
+			"only for multiple load balanced or failover endpoints. The fallback endpoint has to pass the same health "+
+			"checks", constants.XWso2FallbackEndpoint)
+	}
+	// the requests are rewritten to the same basepath for all the endpoints of a cluster
+	basepath := strings.TrimSuffix(swagger.productionEndpoints.Endpoints[0].Basepath, "/")
+	if strings.TrimSuffix(swagger.fallbackEndpoint.Basepath, "/") != basepath {
+		return fmt.Errorf("basepath of the %s %s should be the same as the basepath of the production endpoints, %q",
+			constants.XWso2FallbackEndpoint, swagger.fallbackEndpoint.RawURL, basepath)
+	}
+	return nil
+}
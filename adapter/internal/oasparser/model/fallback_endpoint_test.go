@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+func TestSetXWso2FallbackEndpoint(t *testing.T) {
+	newSwagger := func(fallbackURL interface{}, productionURLs ...string) *MgwSwagger {
+		var productionEndpoints []Endpoint
+		for _, productionURL := range productionURLs {
+			endpoint, err := getHTTPEndpoint(productionURL)
+			assert.Nil(t, err, "Error while parsing the production endpoint %v", productionURL)
+			productionEndpoints = append(productionEndpoints, *endpoint)
+		}
+		return &MgwSwagger{
+			vendorExtensions: map[string]interface{}{constants.XWso2FallbackEndpoint: fallbackURL},
+			productionEndpoints: generateEndpointCluster(constants.ProdClustersConfigNamePrefix, productionEndpoints,
+				constants.LoadBalance),
+		}
+	}
+
+	mgwSwagger := newSwagger("https://sorry.wso2.com/api", "http://petstore1.wso2.com/api",
+		"http://petstore2.wso2.com/api")
+	assert.Nil(t, mgwSwagger.setXWso2FallbackEndpoint())
+	if fallbackEndpoint := mgwSwagger.GetFallbackEndpoint(); assert.NotNil(t, fallbackEndpoint) {
+		assert.Equal(t, "sorry.wso2.com", fallbackEndpoint.Host)
+		assert.Equal(t, uint32(443), fallbackEndpoint.Port)
+		assert.Equal(t, "https", fallbackEndpoint.URLType)
+	}
+	assert.Nil(t, mgwSwagger.validateFallbackEndpoint())
+
+	withoutFallback := &MgwSwagger{}
+	assert.Nil(t, withoutFallback.setXWso2FallbackEndpoint())
+	assert.Nil(t, withoutFallback.GetFallbackEndpoint())
+	assert.Nil(t, withoutFallback.validateFallbackEndpoint())
+
+	invalidURLs := map[string]interface{}{
+		"URL without a host":        "https:///api",
+		"URL with an invalid port":  "https://sorry.wso2.com:70000/api",
+		"URL which is not a string": []string{"https://sorry.wso2.com/api"},
+	}
+	for name, invalidURL := range invalidURLs {
+		invalidSwagger := newSwagger(invalidURL, "http://petstore1.wso2.com/api", "http://petstore2.wso2.com/api")
+		assert.NotNil(t, invalidSwagger.setXWso2FallbackEndpoint(), "Fallback endpoint should be rejected for the %s",
+			name)
+	}
+
+	singleEndpoint := newSwagger("https://sorry.wso2.com/api", "http://petstore1.wso2.com/api")
+	assert.Nil(t, singleEndpoint.setXWso2FallbackEndpoint())
+	assert.NotNil(t, singleEndpoint.validateFallbackEndpoint(),
+		"Fallback endpoint should be rejected when the production endpoints are not health checked")
+
+	basepathMismatch := newSwagger("https://sorry.wso2.com", "http://petstore1.wso2.com/api",
+		"http://petstore2.wso2.com/api")
+	assert.Nil(t, basepathMismatch.setXWso2FallbackEndpoint())
+	assert.NotNil(t, basepathMismatch.validateFallbackEndpoint(),
+		"Fallback endpoint should be rejected when the basepath differs from the production endpoints")
+}
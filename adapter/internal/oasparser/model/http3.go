@@ -0,0 +1,52 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// IsHTTP3Enabled returns true if the API advertises to the clients that it is served over HTTP/3.
+func (swagger *MgwSwagger) IsHTTP3Enabled() bool {
+	return swagger.http3
+}
+
+// setXWso2HTTP3 enables advertising HTTP/3 for the API, if the extension is provided in the API level.
+func (swagger *MgwSwagger) setXWso2HTTP3() {
+	swagger.http3, _ = getBoolExtension(swagger.vendorExtensions, constants.XWso2HTTP3, false)
+}
+
+// validateHTTP3 returns an error if HTTP/3 is enabled for the API while the router does not serve HTTP/3, which
+// requires HTTP/3 to be enabled for the router along with the secured listener, as HTTP/3 is only served over TLS.
+func (swagger *MgwSwagger) validateHTTP3() error {
+	if !swagger.http3 {
+		return nil
+	}
+	conf, _ := config.ReadConfigs()
+	if conf.Envoy.SecuredListenerPort == 0 {
+		return fmt.Errorf("%s is enabled, but HTTP/3 requires TLS and the secured listener of the router is not "+
+			"configured", constants.XWso2HTTP3)
+	}
+	if !conf.Envoy.HTTP3.Enabled {
+		return fmt.Errorf("%s is enabled, but HTTP/3 is not enabled for the router", constants.XWso2HTTP3)
+	}
+	return nil
+}
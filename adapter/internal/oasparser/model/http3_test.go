@@ -0,0 +1,85 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+func TestValidateHTTP3(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousHTTP3Config := conf.Envoy.HTTP3
+	previousSecuredListenerPort := conf.Envoy.SecuredListenerPort
+	defer func() {
+		conf.Envoy.HTTP3 = previousHTTP3Config
+		conf.Envoy.SecuredListenerPort = previousSecuredListenerPort
+	}()
+
+	tests := []struct {
+		name                string
+		extension           interface{}
+		routerHTTP3Enabled  bool
+		securedListenerPort uint32
+		http3Expected       bool
+		errorContains       string
+	}{
+		{
+			name:                "HTTP/3 enabled for the API and the router",
+			extension:           true,
+			routerHTTP3Enabled:  true,
+			securedListenerPort: 9095,
+			http3Expected:       true,
+		},
+		{
+			name:                "HTTP/3 enabled for the API only",
+			extension:           true,
+			securedListenerPort: 9095,
+			http3Expected:       true,
+			errorContains:       "HTTP/3 is not enabled for the router",
+		},
+		{
+			name:               "HTTP/3 enabled without TLS",
+			extension:          true,
+			routerHTTP3Enabled: true,
+			http3Expected:      true,
+			errorContains:      "HTTP/3 requires TLS",
+		},
+		{
+			name:                "HTTP/3 disabled for the API",
+			extension:           false,
+			securedListenerPort: 0,
+		},
+	}
+	for _, test := range tests {
+		conf.Envoy.HTTP3.Enabled = test.routerHTTP3Enabled
+		conf.Envoy.SecuredListenerPort = test.securedListenerPort
+		mgwSwagger := MgwSwagger{vendorExtensions: map[string]interface{}{constants.XWso2HTTP3: test.extension}}
+		mgwSwagger.setXWso2HTTP3()
+		assert.Equal(t, test.http3Expected, mgwSwagger.IsHTTP3Enabled(), test.name)
+		err := mgwSwagger.validateHTTP3()
+		if test.errorContains == "" {
+			assert.Nil(t, err, test.name)
+		} else if assert.NotNil(t, err, test.name) {
+			assert.Contains(t, err.Error(), test.errorContains, test.name)
+		}
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/wso2/product-microgateway/adapter/internal/interceptor"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/extensions"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/utills"
 	"github.com/wso2/product-microgateway/adapter/internal/svcdiscovery"
 	"github.com/wso2/product-microgateway/adapter/pkg/logging"
@@ -76,6 +77,35 @@ type MgwSwagger struct {
 	xWso2ApplicationSecurity   bool
 	GraphQLSchema              string
 	GraphQLComplexities        GraphQLComplexityYaml
+	excludedOperations         []string
+	contextAliases             []string
+	visibility                 string
+	visibleRoles               []string
+	xWso2NotFoundResponse      *NotFoundResponseConfig
+	xWso2StripRequestHeaders   []string
+	additionalProperties       map[string]string
+	unmatchedRequests          UnmatchedRequestsConfig
+	stripAuthHeader            *bool
+	rateLimitKey               *RateLimitKey
+	maxRequestHeadersKb        uint32
+	jwksConfig                 *JwksConfig
+	sessionAffinity            *SessionAffinity
+	parseWarnings              []ParseWarning
+	definitionHash             string
+	bufferRequest              bool
+	localRateLimit             *LocalRateLimit
+	rateLimit                  *RateLimit
+	responseHeaders            map[string]string
+	fallbackEndpoint           *Endpoint
+	upstreamDecompression      bool
+	http3                      bool
+	// subscriptions of the requests are not validated
+	disableSubscriptionValidation bool
+	// overridable field -> provenance of its effective value
+	provenance   map[string]ConfigProvenance
+	skippedFiles []string
+	// schemes of the API definition (OpenAPI v2)
+	schemes []string
 }
 
 // EndpointCluster represent an upstream cluster
@@ -88,6 +118,16 @@ type EndpointCluster struct {
 	SecurityConfig EndpointSecurity
 	// Is http2 protocol enabled
 	HTTP2BackendEnabled bool
+	// SessionAffinity routes the requests of a session to the same endpoint, if it is not nil
+	SessionAffinity *SessionAffinity
+	// FallbackEndpoint receives the requests only when all the endpoints of the cluster are unhealthy, if it is not nil
+	FallbackEndpoint *Endpoint
+}
+
+// IsHealthChecked returns true if the endpoints of the cluster are actively health checked by the router, which is
+// done only when there are multiple endpoints to choose from.
+func (endpointCluster *EndpointCluster) IsHealthChecked() bool {
+	return len(endpointCluster.Endpoints) > 1
 }
 
 // Endpoint represents the structure of an endpoint.
@@ -98,18 +138,26 @@ type Endpoint struct {
 	// In openAPI v2, it is determined from the basePath property
 	// In openAPi v3, it is determined from the server object's suffix
 	Basepath string
-	// https, http, ws, wss
+	// https, http, ws, wss, unix
 	// In openAPI v2, it is fetched from the schemes entry
 	// In openAPI v3, it is extracted from the server property under servers object
 	// only https and http are supported at the moment.
+	// unix is used for the endpoints specified as unix domain sockets, where the Host is the path of the socket.
 	URLType string
 	// Port of the endpoint.
 	// If the port is not specified, 80 is assigned if URLType is http
 	// 443 is assigned if URLType is https
+	// The port is not applicable to the unix domain sockets.
 	Port uint32
 	//ServiceDiscoveryQuery consul query for service discovery
 	ServiceDiscoveryString string
 	RawURL                 string
+	// QueryParams is the encoded query of the endpoint URL (e.g. key=value&version=2), which is appended to the
+	// query of the requests forwarded to the endpoint.
+	QueryParams string
+	// Priority is the failover priority of the endpoint, where 0 is the highest priority. The endpoints of a failover
+	// EndpointCluster are prioritized in their order if none of those has a priority.
+	Priority uint32
 }
 
 // EndpointConfig holds the configs such as timeout, retry, etc. for the EndpointCluster
@@ -117,6 +165,24 @@ type EndpointConfig struct {
 	RetryConfig     *RetryConfig     `mapstructure:"retryConfig"`
 	TimeoutInMillis uint32           `mapstructure:"timeoutInMillis"`
 	CircuitBreakers *CircuitBreakers `mapstructure:"circuitBreakers"`
+	// TLSCipherSuites overrides the upstream cipher suites configured globally, for the TLS connections to the
+	// endpoints of the cluster
+	TLSCipherSuites []string `mapstructure:"tlsCipherSuites"`
+	// IdleTimeout is the duration (e.g. 90s) after which the idle connections to the endpoints of the cluster are
+	// closed. The default idle timeout of the router is applied if it is empty.
+	IdleTimeout string `mapstructure:"idleTimeout"`
+	// TLSSkipVerify skips the verification of the certificates of the endpoints of the cluster. It is applied only
+	// if router.upstream.tls.allowTlsSkipVerify is enabled.
+	TLSSkipVerify bool `mapstructure:"tlsSkipVerify"`
+}
+
+// SessionAffinity holds the cookie on which the requests of a session are routed to the same endpoint of the
+// EndpointCluster.
+type SessionAffinity struct {
+	CookieName string
+	// CookieTTL is the lifetime of the cookie generated by the router when a request does not have it. A session
+	// cookie is generated if it is zero.
+	CookieTTL time.Duration
 }
 
 // RetryConfig holds the parameters for retries done by cc to the EndpointCluster
@@ -152,6 +218,23 @@ type CorsConfig struct {
 	AccessControlExposeHeaders    []string `mapstructure:"accessControlExposeHeaders"`
 }
 
+// NotFoundResponseConfig represents the API level response returned when a request matches the API
+// context but none of the API operations
+type NotFoundResponseConfig struct {
+	StatusCode  uint32 `mapstructure:"statusCode"`
+	Body        string `mapstructure:"body"`
+	ContentType string `mapstructure:"contentType"`
+}
+
+// UnmatchedRequestsConfig represents how the requests which do not match any operation of the API are responded.
+// The global configuration is used for the properties not provided within the x-wso2-unmatched-requests extension.
+type UnmatchedRequestsConfig struct {
+	// MethodNotAllowed replies with 405 when the path of a resource matches but not the method.
+	MethodNotAllowed bool `mapstructure:"methodNotAllowed"`
+	// VhostCatchAll enables the catch-all route of the vhost the API is deployed in.
+	VhostCatchAll bool `mapstructure:"vhostCatchAll"`
+}
+
 // InterceptEndpoint contains the parameters of endpoint security
 type InterceptEndpoint struct {
 	Enable          bool
@@ -180,6 +263,23 @@ func (swagger *MgwSwagger) GetCorsConfig() *CorsConfig {
 	return swagger.xWso2Cors
 }
 
+// IsMethodNotAllowedEnabled returns true if the requests matching a resource path of the API with a method
+// which is not defined for the resource should be replied with 405.
+func (swagger *MgwSwagger) IsMethodNotAllowedEnabled() bool {
+	return swagger.unmatchedRequests.MethodNotAllowed
+}
+
+// IsVhostCatchAllEnabled returns true if the API requires the catch-all route of its vhost.
+func (swagger *MgwSwagger) IsVhostCatchAllEnabled() bool {
+	return swagger.unmatchedRequests.VhostCatchAll
+}
+
+// GetNotFoundResponseConfig returns the custom response for unmatched sub-paths of the API.
+// Returns nil if the API does not define one.
+func (swagger *MgwSwagger) GetNotFoundResponseConfig() *NotFoundResponseConfig {
+	return swagger.xWso2NotFoundResponse
+}
+
 // GetAPIType returns the openapi version
 func (swagger *MgwSwagger) GetAPIType() string {
 	return swagger.apiType
@@ -205,6 +305,67 @@ func (swagger *MgwSwagger) GetXWso2HTTP2BackendEnabled() bool {
 	return swagger.xWso2HTTP2BackendEnabled
 }
 
+// GetStripAuthHeader returns whether the authorization header should be removed from the requests of the API before
+// those are sent to the backends. Nil is returned if the API does not override enableOutboundAuthHeader of the
+// enforcer.
+func (swagger *MgwSwagger) GetStripAuthHeader() *bool {
+	return swagger.stripAuthHeader
+}
+
+// GetRateLimitKey returns the source of the key used to rate limit the requests of the API. Nil is returned if
+// the requests are rate limited by the API and the application.
+func (swagger *MgwSwagger) GetRateLimitKey() *RateLimitKey {
+	return swagger.rateLimitKey
+}
+
+// IsRequestBufferingEnabled returns whether the requests of the API are buffered completely before being sent to
+// the backends.
+func (swagger *MgwSwagger) IsRequestBufferingEnabled() bool {
+	return swagger.bufferRequest
+}
+
+// GetLocalRateLimit returns the limit of the requests of the API enforced by each router. Nil is returned if the
+// API is not rate limited locally.
+func (swagger *MgwSwagger) GetLocalRateLimit() *LocalRateLimit {
+	return swagger.localRateLimit
+}
+
+// GetRateLimit returns the explicit limit of the requests of the API at each router, which overrides the named
+// throttling tiers.
+// Nil is returned if the API is throttled by the named tiers.
+func (swagger *MgwSwagger) GetRateLimit() *RateLimit {
+	return swagger.rateLimit
+}
+
+// GetResponseHeaders returns the headers added to all the responses of the API.
+func (swagger *MgwSwagger) GetResponseHeaders() map[string]string {
+	return swagger.responseHeaders
+}
+
+// GetMaxRequestHeadersKb returns the maximum size of the request headers of the API in KiB. Zero is returned if the
+// API does not limit the size below the limit of the listeners.
+func (swagger *MgwSwagger) GetMaxRequestHeadersKb() uint32 {
+	return swagger.maxRequestHeadersKb
+}
+
+// GetJwksConfig returns the JWKS endpoint of the API. Nil is returned if the JWTs of the API are validated with the
+// keys of the configured token issuers.
+func (swagger *MgwSwagger) GetJwksConfig() *JwksConfig {
+	return swagger.jwksConfig
+}
+
+// GetSessionAffinity returns the session affinity of the API level endpoints. Nil is returned if the requests are
+// load balanced without the session affinity.
+func (swagger *MgwSwagger) GetSessionAffinity() *SessionAffinity {
+	return swagger.sessionAffinity
+}
+
+// GetXWso2StripRequestHeaders returns the request headers to be removed before the requests are sent to the
+// backends, set via the x-wso2-strip-request-headers vendor extension.
+func (swagger *MgwSwagger) GetXWso2StripRequestHeaders() []string {
+	return swagger.xWso2StripRequestHeaders
+}
+
 // GetVendorExtensions returns the map of vendor extensions which are defined
 // at openAPI's root level.
 func (swagger *MgwSwagger) GetVendorExtensions() map[string]interface{} {
@@ -257,6 +418,39 @@ func (swagger *MgwSwagger) GetXWso2RequestBodyPass() bool {
 	return swagger.xWso2RequestBodyPass
 }
 
+// GetContextAliases returns the additional contexts the API is served at along with its basepath, set via the
+// x-wso2-context-aliases vendor extension.
+func (swagger *MgwSwagger) GetContextAliases() []string {
+	return swagger.contextAliases
+}
+
+// GetDefinitionHash returns the canonical hash of the API project the API is deployed from. Empty if the API is
+// not deployed from an API project.
+func (swagger *MgwSwagger) GetDefinitionHash() string {
+	return swagger.definitionHash
+}
+
+// GetExcludedOperations returns the operations (in "METHOD path" format) which are omitted from the
+// API as those are excluded on the gateway labels of this adapter.
+func (swagger *MgwSwagger) GetExcludedOperations() []string {
+	return swagger.excludedOperations
+}
+
+// GetVisibility returns the visibility (PUBLIC, PRIVATE or RESTRICTED) of the API.
+func (swagger *MgwSwagger) GetVisibility() string {
+	return swagger.visibility
+}
+
+// GetVisibleRoles returns the roles the API is visible to, when the visibility is RESTRICTED.
+func (swagger *MgwSwagger) GetVisibleRoles() []string {
+	return swagger.visibleRoles
+}
+
+// GetAdditionalProperties returns the custom metadata (additionalProperties) attached to the API in the api.yaml.
+func (swagger *MgwSwagger) GetAdditionalProperties() map[string]string {
+	return swagger.additionalProperties
+}
+
 // GetClientCerts returns the client certificates of the API
 func (swagger *MgwSwagger) GetClientCerts() []Certificate {
 	return swagger.clientCertificates
@@ -272,6 +466,11 @@ func (swagger *MgwSwagger) SetID(id string) {
 	swagger.id = id
 }
 
+// SetDefinitionHash sets the canonical hash of the API project the API is deployed from.
+func (swagger *MgwSwagger) SetDefinitionHash(definitionHash string) {
+	swagger.definitionHash = definitionHash
+}
+
 // SetName sets the name of the API
 func (swagger *MgwSwagger) SetName(name string) {
 	swagger.title = name
@@ -347,8 +546,9 @@ func (swagger *MgwSwagger) GetEndpointType() string {
 	return swagger.EndpointType
 }
 
-// SetOperationPolicies this will merge operation level policies provided in api yaml
+// SetOperationPolicies this will merge operation level policies and deprecation status provided in api yaml
 func (swagger *MgwSwagger) SetOperationPolicies(apiProject ProjectAPI) (err error) {
+	swagger.addCustomMethodOperations(apiProject.APIYaml.Data.Operations)
 	for _, resource := range swagger.resources {
 		path := strings.TrimSuffix(resource.path, "/")
 		for _, operation := range resource.methods {
@@ -359,9 +559,67 @@ func (swagger *MgwSwagger) SetOperationPolicies(apiProject ProjectAPI) (err erro
 					if err != nil {
 						return err
 					}
+					err = swagger.validateOperationPolicyCompatibility(operation.policies, apiProject.Policies, constants.HTTP,
+						yamlOperation.Target, method)
+					if err != nil {
+						return err
+					}
+					err = validatePayloadTransformationPolicies(operation.policies, operation.consumes, operation.produces)
+					if err != nil {
+						return fmt.Errorf("invalid payload transformation of the operation %v %v. %v", method,
+							resource.path, err)
+					}
 					if operation.policies.Request != nil || operation.policies.Response != nil || operation.policies.Fault != nil {
 						resource.hasPolicies = true
 					}
+					operation.deprecated = yamlOperation.Deprecated
+					if operation.deprecated {
+						resource.hasPolicies = true // to add the deprecation header only to the routes of this operation
+					}
+					if len(yamlOperation.RequiredQueryParams) > 0 {
+						if err = validateRequiredQueryParams(yamlOperation.RequiredQueryParams); err != nil {
+							return fmt.Errorf("invalid requiredQueryParams of the operation %v %v. %v", method,
+								resource.path, err)
+						}
+						operation.requiredQueryParams = yamlOperation.RequiredQueryParams
+						resource.hasPolicies = true // to match the query parameters only in the routes of this operation
+					}
+					if yamlOperation.RewritePath != nil {
+						if err = validateRewritePath(*yamlOperation.RewritePath, operation.policies); err != nil {
+							return fmt.Errorf("invalid rewritePath of the operation %v %v. %v", method,
+								resource.path, err)
+						}
+						operation.rewritePath = yamlOperation.RewritePath
+						resource.hasPolicies = true // to rewrite the path only in the routes of this operation
+					}
+					if yamlOperation.AwsLambda != nil {
+						awsLambda, err := resolveAwsLambdaConfig(*yamlOperation.AwsLambda, operation.policies)
+						if err != nil {
+							return fmt.Errorf("invalid awsLambda of the operation %v %v. %v", method,
+								resource.path, err)
+						}
+						operation.awsLambda = awsLambda
+						resource.hasPolicies = true // to route only the requests of this operation to the function
+					}
+					if yamlOperation.Timeout != "" {
+						if operation.timeout, err = parseOperationTimeout(yamlOperation.Timeout); err != nil {
+							return fmt.Errorf("invalid timeout of the operation %v %v. %v", method,
+								resource.path, err)
+						}
+						resource.hasPolicies = true // to set the timeout only in the routes of this operation
+					}
+					if len(yamlOperation.RemapStatus) > 0 {
+						if err = validateStatusRemaps(yamlOperation.RemapStatus); err != nil {
+							return fmt.Errorf("invalid remapStatus of the operation %v %v. %v", method,
+								resource.path, err)
+						}
+						operation.statusRemaps = yamlOperation.RemapStatus
+						resource.hasPolicies = true // to remap the status only in the routes of this operation
+					}
+					if len(yamlOperation.CacheVaryHeaders) > 0 {
+						operation.cacheVaryHeaders = getCacheVaryHeaders(yamlOperation.CacheVaryHeaders)
+						resource.hasPolicies = true // to vary the cached responses only of this operation
+					}
 					break
 				}
 			}
@@ -370,6 +628,158 @@ func (swagger *MgwSwagger) SetOperationPolicies(apiProject ProjectAPI) (err erro
 	return nil
 }
 
+// ValidateOperationPolicies validates whether the operation policies of the api.yaml can be applied to the API.
+// This is used for the API types of which the operation policies are not set, to reject the policies which would
+// otherwise be ignored.
+func (swagger *MgwSwagger) ValidateOperationPolicies(apiProject ProjectAPI) error {
+	resolvePolicies := func(policyList PolicyList, flow PolicyFlow) PolicyList {
+		resolvedPolicies := make(PolicyList, len(policyList))
+		for i, policy := range policyList {
+			// resolve the policy action where possible, to validate the actions supported by Choreo Connect
+			if fmtPolicy, err := apiProject.Policies.getFormattedPolicyFromTemplated(policy, flow, swagger); err == nil {
+				policy = fmtPolicy
+			}
+			resolvedPolicies[i] = policy
+		}
+		return resolvedPolicies
+	}
+	for _, yamlOperation := range apiProject.APIYaml.Data.Operations {
+		policies := OperationPolicies{
+			Request:  resolvePolicies(yamlOperation.OperationPolicies.Request, policyInFlow),
+			Response: resolvePolicies(yamlOperation.OperationPolicies.Response, policyOutFlow),
+			Fault:    resolvePolicies(yamlOperation.OperationPolicies.Fault, policyFaultFlow),
+		}
+		err := swagger.validateOperationPolicyCompatibility(policies, apiProject.Policies, swagger.apiType,
+			yamlOperation.Target, yamlOperation.Verb)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOperationPolicyCompatibility validates whether the policies can be applied to the flows of the operation.
+// Incompatible policies fail the deployment, unless the strict policy compatibility is disabled from the config,
+// where those are only logged as warnings.
+func (swagger *MgwSwagger) validateOperationPolicyCompatibility(policies OperationPolicies,
+	policyContainers PolicyContainerMap, apiType, target, method string) error {
+	conf, _ := config.ReadConfigs()
+	for _, flowPolicies := range []struct {
+		flow     PolicyFlow
+		policies PolicyList
+	}{
+		{policyInFlow, policies.Request},
+		{policyOutFlow, policies.Response},
+		{policyFaultFlow, policies.Fault},
+	} {
+		for _, policy := range flowPolicies.policies {
+			err := policyContainers.validatePolicyCompatibility(policy, flowPolicies.flow, apiType, method)
+			if err == nil {
+				continue
+			}
+			errMsg := fmt.Sprintf("policy %q cannot be applied to the %s flow of the operation %s %s of the API %q in org %q: %v",
+				policy.GetFullName(), flowPolicies.flow, method, target, swagger.GetID(), swagger.OrganizationID, err)
+			if !conf.Adapter.StrictPolicyCompatibility {
+				logger.LoggerOasparser.Warn(errMsg)
+				continue
+			}
+			logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+				Message:   errMsg,
+				Severity:  logging.MINOR,
+				ErrorCode: 2213,
+			})
+			return errors.New(errMsg)
+		}
+	}
+	return nil
+}
+
+// oasHTTPMethods are the HTTP methods which can be defined as operations in the OpenAPI definitions.
+var oasHTTPMethods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+
+// addCustomMethodOperations adds the operations of the api.yaml having custom HTTP methods to the matching
+// resources, as those methods cannot be defined in the OpenAPI definition.
+func (swagger *MgwSwagger) addCustomMethodOperations(yamlOperations []OperationYaml) {
+	for _, yamlOperation := range yamlOperations {
+		method := strings.ToUpper(yamlOperation.Verb)
+		if method == "" || arrayContains(oasHTTPMethods, method) {
+			continue
+		}
+		for _, resource := range swagger.resources {
+			if strings.TrimSuffix(resource.path, "/") != strings.TrimSuffix(yamlOperation.Target, "/") ||
+				arrayContains(resource.GetMethodList(), method) {
+				continue
+			}
+			logger.LoggerOasparser.Debugf("Adding the custom method %v to the resource %v of the API %v:%v", method,
+				resource.path, swagger.title, swagger.version)
+			resource.methods = append(resource.methods, NewOperation(method, nil, nil))
+		}
+	}
+}
+
+// validateRequiredQueryParams checks whether the required query parameter names are non empty, unique and consist
+// of the unreserved characters, so that those can be matched without URL decoding.
+func validateRequiredQueryParams(queryParams []string) error {
+	queryParamSet := make(map[string]struct{}, len(queryParams))
+	for _, queryParam := range queryParams {
+		if match, _ := regexp.MatchString("^[a-zA-Z0-9~_.-]+$", queryParam); !match {
+			return fmt.Errorf("query parameter name %q should only contain letters, digits and the characters - . _ ~",
+				queryParam)
+		}
+		if _, found := queryParamSet[queryParam]; found {
+			return fmt.Errorf("query parameter %q is duplicated", queryParam)
+		}
+		queryParamSet[queryParam] = struct{}{}
+	}
+	return nil
+}
+
+// parseOperationTimeout parses the timeout of an operation given as a duration (e.g. 90s, 2m). The timeout should
+// be positive and should not exceed the maximum route timeout of the router.
+func parseOperationTimeout(timeout string) (time.Duration, error) {
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, fmt.Errorf("timeout %q is not a valid duration. %v", timeout, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("timeout %q should be positive", timeout)
+	}
+	conf, _ := config.ReadConfigs()
+	maxTimeout := time.Duration(conf.Envoy.Upstream.Timeouts.MaxRouteTimeoutInSeconds) * time.Second
+	if duration > maxTimeout {
+		return 0, fmt.Errorf("timeout %q exceeds the maximum route timeout %v", timeout, maxTimeout)
+	}
+	return duration, nil
+}
+
+// captureGroupRefRegex matches the references to the capture groups (i.e. \1, \2, etc.) in a regex substitution
+var captureGroupRefRegex = regexp.MustCompile(`\\(\d+)`)
+
+// validateRewritePath checks whether the pattern of the path rewrite compiles and the substitution refers only the
+// capture groups of the pattern. The path rewrite cannot be used along with a path rewrite policy.
+func validateRewritePath(rewritePath RewritePathConfig, policies OperationPolicies) error {
+	if rewritePath.Pattern == "" {
+		return errors.New("pattern is mandatory")
+	}
+	pattern, err := regexp.Compile(rewritePath.Pattern)
+	if err != nil {
+		return fmt.Errorf("pattern %q does not compile. %v", rewritePath.Pattern, err)
+	}
+	for _, captureGroupRef := range captureGroupRefRegex.FindAllStringSubmatch(rewritePath.Substitution, -1) {
+		if groupIndex, _ := strconv.Atoi(captureGroupRef[1]); groupIndex > pattern.NumSubexp() {
+			return fmt.Errorf("substitution refers the capture group %v, while the pattern has only %v capture groups",
+				groupIndex, pattern.NumSubexp())
+		}
+	}
+	for _, policy := range policies.Request {
+		if policy.Action == constants.ActionRewritePath {
+			return fmt.Errorf("rewritePath cannot be used along with the %v policy %v", constants.ActionRewritePath,
+				policy.PolicyName)
+		}
+	}
+	return nil
+}
+
 // SanitizeAPISecurity this will validate api level and operation level swagger security
 // if apiyaml security is provided swagger security will be removed accordingly
 func (swagger *MgwSwagger) SanitizeAPISecurity(isYamlAPIKey bool, isYamlOauth bool, isYamlMutualssl bool, isYamlMutualsslMandatory bool, isYamlOauthBasicAuthAPIKeyMandatory bool) {
@@ -507,8 +917,33 @@ func (swagger *MgwSwagger) SetXWso2Extensions() error {
 	swagger.setXWso2Cors()
 	swagger.setXWso2ThrottlingTier()
 	swagger.setDisableSecurity()
+	swagger.setPublicPaths()
+	if err := swagger.setAnonymousTiers(); err != nil {
+		logger.LoggerOasparser.Errorf("Error while adding %s. %v", constants.XWso2AnonymousTier, err)
+		return err
+	}
+	if err := swagger.setXWso2MockResponseSelections(); err != nil {
+		logger.LoggerOasparser.Errorf("Error while adding %s. %v", constants.XWso2MockResponseSelection, err)
+		return err
+	}
+	if err := swagger.setXWso2AnalyticsProperties(); err != nil {
+		logger.LoggerOasparser.Errorf("Error while adding %s. %v", constants.XWso2AnalyticsProperties, err)
+		return err
+	}
+	if err := swagger.setXWso2FallbackEndpoint(); err != nil {
+		logger.LoggerOasparser.Errorf("Error while adding %s. %v", constants.XWso2FallbackEndpoint, err)
+		return err
+	}
 	swagger.setXWso2AuthHeader()
 	swagger.setXWso2HTTP2BackendEnabled()
+	swagger.setXWso2NotFoundResponse()
+	swagger.setXWso2StripRequestHeaders()
+	swagger.setXWso2UnmatchedRequests()
+	swagger.setXWso2StripAuthHeader()
+	swagger.setXWso2UpstreamDecompression()
+	swagger.setXWso2HTTP3()
+	swagger.setXWso2ContextAliases()
+	swagger.setXWso2WebSocketOperations()
 
 	// Error nil for successful execution
 	return nil
@@ -553,6 +988,64 @@ func (swagger *MgwSwagger) SetEnvLabelProperties(envProps synchronizer.APIEnvPro
 	}
 }
 
+// SetEnvLabelEndpointSecurity overrides the endpoint security of the production and sandbox endpoints with the
+// endpoint security provided in the env properties of the environment being deployed. This should be called after
+// the endpoint security of the api.yaml is applied, hence the credentials of the environment take precedence.
+func (swagger *MgwSwagger) SetEnvLabelEndpointSecurity(envProps synchronizer.APIEnvProps) error {
+	prodSecurity := envProps.APIConfigs.ProductionEndpointSecurity
+	sandSecurity := envProps.APIConfigs.SandboxEndpointSecurity
+	if err := validateEnvEndpointSecurity(prodSecurity, "production"); err != nil {
+		return err
+	}
+	if err := validateEnvEndpointSecurity(sandSecurity, "sandbox"); err != nil {
+		return err
+	}
+	if prodSecurity != nil && swagger.productionEndpoints != nil {
+		logger.LoggerOasparser.Infof("Production endpoint security is overridden by env properties for %v : %v",
+			swagger.title, swagger.version)
+		swagger.productionEndpoints.SecurityConfig = mergeEnvEndpointSecurity(swagger.productionEndpoints.SecurityConfig,
+			*prodSecurity)
+	}
+	if sandSecurity != nil && swagger.sandboxEndpoints != nil {
+		logger.LoggerOasparser.Infof("Sandbox endpoint security is overridden by env properties for %v : %v",
+			swagger.title, swagger.version)
+		swagger.sandboxEndpoints.SecurityConfig = mergeEnvEndpointSecurity(swagger.sandboxEndpoints.SecurityConfig,
+			*sandSecurity)
+	}
+	return nil
+}
+
+func validateEnvEndpointSecurity(envSecurity *synchronizer.EndpointSecurity, endpointType string) error {
+	if envSecurity == nil {
+		return nil
+	}
+	if envSecurity.Username != "" && envSecurity.Password == "" {
+		return fmt.Errorf("password is not provided with the username of the %v endpoint security in env properties",
+			endpointType)
+	}
+	if envSecurity.Type != "" && !strings.EqualFold(envSecurity.Type, "BASIC") {
+		return fmt.Errorf("%v endpoint security type : %v in env properties is not currently supported with WSO2 Choreo Connect",
+			endpointType, envSecurity.Type)
+	}
+	return nil
+}
+
+// mergeEnvEndpointSecurity enables the endpoint security using the credentials of the env properties. The type and
+// the custom parameters of the API revision are retained unless those are overridden.
+func mergeEnvEndpointSecurity(endpointSecurity EndpointSecurity, envSecurity synchronizer.EndpointSecurity) EndpointSecurity {
+	endpointSecurity.Enabled = true
+	if envSecurity.Type != "" {
+		endpointSecurity.Type = envSecurity.Type
+	} else if endpointSecurity.Type == "" {
+		endpointSecurity.Type = "BASIC"
+	}
+	if envSecurity.Username != "" {
+		endpointSecurity.Username = envSecurity.Username
+		endpointSecurity.Password = envSecurity.Password
+	}
+	return endpointSecurity
+}
+
 // SetEnvVariables sets environment specific values to the mgwswagger
 func (swagger *MgwSwagger) SetEnvVariables(apiHashValue string) {
 	productionEndpoints, sandboxEndpoints := retrieveEndpointsFromEnv(apiHashValue)
@@ -701,16 +1194,46 @@ func (endpointCluster *EndpointCluster) SetEndpointsConfig(endpointInfos []Endpo
 			endpointCluster.Config.RetryConfig = retryConfig
 		}
 	}
+
+	// TLS cipher suites
+	if len(endpointCluster.Config.TLSCipherSuites) == 0 {
+		endpointCluster.Config.TLSCipherSuites = endpointInfos[0].Config.TLSCipherSuites
+	}
+
+	// connection idle timeout
+	if endpointCluster.Config.IdleTimeout == "" {
+		endpointCluster.Config.IdleTimeout = endpointInfos[0].Config.IdleTimeout
+	}
+
+	// TLS certificate verification
+	if !endpointCluster.Config.TLSSkipVerify {
+		endpointCluster.Config.TLSSkipVerify = endpointInfos[0].Config.TLSSkipVerify
+	}
 	return nil
 }
 
 func (swagger *MgwSwagger) setXWso2ThrottlingTier() {
+	if swagger.rateLimit != nil {
+		// explicit rate limit of the api.yaml overrides the named tiers
+		swagger.setUnlimitedOperationTiers()
+		return
+	}
 	tier := ResolveThrottlingTier(swagger.vendorExtensions)
 	if tier != "" {
 		swagger.xWso2ThrottlingTier = tier
 	}
 }
 
+// setUnlimitedOperationTiers sets the tiers of the operations to Unlimited, as the operations of the APIs with an
+// explicit rate limit are not throttled by their named tiers either.
+func (swagger *MgwSwagger) setUnlimitedOperationTiers() {
+	for _, resource := range swagger.resources {
+		for _, operation := range resource.methods {
+			operation.tier = constants.UnlimitedTier
+		}
+	}
+}
+
 // SetXWSO2AuthHeader sets the AuthHeader of the API
 func (swagger *MgwSwagger) setXWso2AuthHeader() {
 	authorizationHeader := getXWso2AuthHeader(swagger.vendorExtensions)
@@ -723,6 +1246,17 @@ func (swagger *MgwSwagger) setDisableSecurity() {
 	swagger.disableSecurity = ResolveDisableSecurity(swagger.vendorExtensions)
 }
 
+// IsMockedOperation returns true if the operation is served with the mocked responses given by its examples instead
+// of proxying to the backend. All the operations of a mocked API are mocked, whereas only the operations having
+// mocked responses are mocked in a prototyped API.
+func (swagger *MgwSwagger) IsMockedOperation(operation *Operation) bool {
+	if swagger.EndpointImplementationType == constants.MockedOASEndpointType {
+		return true
+	}
+	mockedAPIConfig := operation.GetMockedAPIConfig()
+	return swagger.IsPrototyped && mockedAPIConfig != nil && len(mockedAPIConfig.Responses) > 0
+}
+
 // Validate method confirms that the mgwSwagger has all required fields in the required format.
 // This needs to be checked prior to generate router/enforcer related resources.
 func (swagger *MgwSwagger) Validate() error {
@@ -763,11 +1297,80 @@ func (swagger *MgwSwagger) Validate() error {
 			}
 		}
 	}
-	err := swagger.validateBasePath()
+	err := swagger.validateHTTPMethods()
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while parsing the API %s:%s - %v", swagger.title, swagger.version, err)
+		return err
+	}
+	err = swagger.validateFallbackEndpoint()
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while parsing the API %s:%s - %v", swagger.title, swagger.version, err)
+		return err
+	}
+	err = swagger.validateEndpointHosts()
 	if err != nil {
 		logger.LoggerOasparser.Errorf("Error while parsing the API %s:%s - %v", swagger.title, swagger.version, err)
 		return err
 	}
+	err = swagger.validateBasePath()
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while parsing the API %s:%s - %v", swagger.title, swagger.version, err)
+		return err
+	}
+	err = swagger.validateContextAliases()
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while parsing the API %s:%s - %v", swagger.title, swagger.version, err)
+		return err
+	}
+	err = swagger.validateWebSocketOperations()
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while parsing the API %s:%s - %v", swagger.title, swagger.version, err)
+		return err
+	}
+	err = swagger.validatePathTemplates()
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while parsing the API %s:%s - %v", swagger.title, swagger.version, err)
+		return err
+	}
+	err = swagger.validateHTTP3()
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while parsing the API %s:%s - %v", swagger.title, swagger.version, err)
+		return err
+	}
+	return nil
+}
+
+// validateWebSocketOperations checks whether the operations upgrading the connections to WebSockets are GET
+// operations, as the WebSocket handshake is only initiated with a GET request.
+func (swagger *MgwSwagger) validateWebSocketOperations() error {
+	for _, resource := range swagger.resources {
+		for _, operation := range resource.methods {
+			if operation.webSocket && !strings.EqualFold(operation.method, "GET") {
+				return fmt.Errorf("%s extension of the operation %s %s is only allowed for GET operations",
+					constants.XWso2WebSocket, operation.method, resource.path)
+			}
+		}
+	}
+	return nil
+}
+
+// validateHTTPMethods checks whether the methods of all the operations are allowed by the adapter configuration.
+func (swagger *MgwSwagger) validateHTTPMethods() error {
+	conf, _ := config.ReadConfigs()
+	for _, resource := range swagger.resources {
+		for _, operation := range resource.methods {
+			allowed := false
+			for _, allowedMethod := range conf.Adapter.AllowedHTTPMethods {
+				if strings.EqualFold(operation.method, allowedMethod) {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				return fmt.Errorf("HTTP method %v of the resource %v is not allowed", operation.method, resource.path)
+			}
+		}
+	}
 	return nil
 }
 
@@ -781,10 +1384,34 @@ func (swagger *MgwSwagger) validateBasePath() error {
 	return nil
 }
 
+// validateContextAliases checks whether the context aliases are valid basepaths, which differ from the basepath of
+// the API and from each other. The trailing slashes are ignored when the contexts are compared.
+func (swagger *MgwSwagger) validateContextAliases() error {
+	contexts := map[string]struct{}{strings.TrimSuffix(swagger.xWso2Basepath, "/"): {}}
+	for _, contextAlias := range swagger.contextAliases {
+		if match, _ := regexp.MatchString("^[/][a-zA-Z0-9~/_.-]*$", contextAlias); !match {
+			return fmt.Errorf("invalid context alias %q. Does not start with / or includes invalid characters",
+				contextAlias)
+		}
+		normalizedAlias := strings.TrimSuffix(contextAlias, "/")
+		if normalizedAlias == strings.TrimSuffix(swagger.xWso2Basepath, "/") {
+			return fmt.Errorf("context alias %q is the same as the basepath of the API", contextAlias)
+		}
+		if _, found := contexts[normalizedAlias]; found {
+			return fmt.Errorf("context alias %q is given more than once", contextAlias)
+		}
+		contexts[normalizedAlias] = struct{}{}
+	}
+	return nil
+}
+
 func (endpoint *Endpoint) validateEndpoint() error {
 	if len(endpoint.ServiceDiscoveryString) > 0 {
 		return nil
 	}
+	if endpoint.IsUnixSocket() {
+		return validateUnixSocketPath(endpoint.Host)
+	}
 	if endpoint.Port == 0 || endpoint.Port > 65535 {
 		return errors.New("endpoint port value should be between 0 and 65535")
 	}
@@ -799,8 +1426,17 @@ func (endpoint *Endpoint) validateEndpoint() error {
 	return err
 }
 
+// IsUnixSocket checks whether the endpoint is a unix domain socket
+func (endpoint *Endpoint) IsUnixSocket() bool {
+	return endpoint.URLType == constants.UnixSocketURLType
+}
+
 // GetAuthorityHeader creates the authority header using Host and Port in the form of Host [ ":" Port ]
+// As the unix domain sockets do not have a host name, localhost is used for those.
 func (endpoint *Endpoint) GetAuthorityHeader() string {
+	if endpoint.IsUnixSocket() {
+		return "localhost"
+	}
 	return strings.Join([]string{endpoint.Host, strconv.FormatUint(uint64(endpoint.Port), 10)}, ":")
 }
 
@@ -850,6 +1486,73 @@ func (endpointCluster *EndpointCluster) validateEndpointCluster(endpointName str
 			if endpointCluster.Config.TimeoutInMillis > maxTimeoutInMillis {
 				endpointCluster.Config.TimeoutInMillis = maxTimeoutInMillis
 			}
+			// Validate TLS cipher suites
+			if err = validateTLSCipherSuites(endpointCluster.Config.TLSCipherSuites); err != nil {
+				logger.LoggerOasparser.Errorf("Error while parsing the %s endpoints. %v", endpointName, err)
+				return err
+			}
+			// Validate connection idle timeout
+			if err = validateIdleTimeout(endpointCluster.Config.IdleTimeout); err != nil {
+				logger.LoggerOasparser.Errorf("Error while parsing the %s endpoints. %v", endpointName, err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateIdleTimeout validates that the connection idle timeout of the endpoints is a positive duration, if given.
+func validateIdleTimeout(idleTimeout string) error {
+	if idleTimeout == "" {
+		return nil
+	}
+	duration, err := time.ParseDuration(idleTimeout)
+	if err != nil {
+		return fmt.Errorf("invalid idle timeout %q. The idle timeout should be a duration such as 90s or 5m", idleTimeout)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("invalid idle timeout %q. The idle timeout should be a positive duration", idleTimeout)
+	}
+	return nil
+}
+
+// supportedTLSCipherSuites are the TLS 1.0 - 1.2 cipher suites supported by the router (BoringSSL names). Cipher
+// suites of TLS 1.3 are not configurable.
+var supportedTLSCipherSuites = map[string]struct{}{
+	"ECDHE-ECDSA-AES128-GCM-SHA256": {},
+	"ECDHE-RSA-AES128-GCM-SHA256":   {},
+	"ECDHE-ECDSA-CHACHA20-POLY1305": {},
+	"ECDHE-RSA-CHACHA20-POLY1305":   {},
+	"ECDHE-ECDSA-AES128-SHA":        {},
+	"ECDHE-RSA-AES128-SHA":          {},
+	"ECDHE-ECDSA-AES256-GCM-SHA384": {},
+	"ECDHE-RSA-AES256-GCM-SHA384":   {},
+	"ECDHE-ECDSA-AES256-SHA":        {},
+	"ECDHE-RSA-AES256-SHA":          {},
+	"ECDHE-PSK-AES128-CBC-SHA":      {},
+	"ECDHE-PSK-AES256-CBC-SHA":      {},
+	"ECDHE-PSK-CHACHA20-POLY1305":   {},
+	"AES128-GCM-SHA256":             {},
+	"AES128-SHA":                    {},
+	"AES256-GCM-SHA384":             {},
+	"AES256-SHA":                    {},
+	"PSK-AES128-CBC-SHA":            {},
+	"PSK-AES256-CBC-SHA":            {},
+	"DES-CBC3-SHA":                  {},
+}
+
+// validateTLSCipherSuites validates the cipher suites against the cipher suites supported by the router. A group of
+// cipher suites having equal preference can be provided in the form of [cipher1|cipher2].
+func validateTLSCipherSuites(cipherSuites []string) error {
+	for _, cipherSuite := range cipherSuites {
+		cipherNames := []string{strings.TrimSpace(cipherSuite)}
+		if strings.HasPrefix(cipherNames[0], "[") && strings.HasSuffix(cipherNames[0], "]") {
+			cipherNames = strings.Split(strings.Trim(cipherNames[0], "[]"), "|")
+		}
+		for _, cipherName := range cipherNames {
+			if _, found := supportedTLSCipherSuites[strings.TrimSpace(cipherName)]; !found {
+				return fmt.Errorf("unsupported TLS cipher suite %q", cipherSuite)
+			}
 		}
 	}
 	return nil
@@ -992,33 +1695,98 @@ func (swagger *MgwSwagger) setXWso2HTTP2BackendEnabled() {
 	swagger.xWso2HTTP2BackendEnabled = extHTTP2BackendEnabled
 }
 
-func (swagger *MgwSwagger) setXWso2Cors() {
-	if cors, corsFound := swagger.vendorExtensions[constants.XWso2Cors]; corsFound {
-		logger.LoggerOasparser.Debugf("%v configuration is available", constants.XWso2Cors)
-		if parsedCors, parsedCorsOk := cors.(map[string]interface{}); parsedCorsOk {
-			//Default CorsConfiguration
-			corsConfig := &CorsConfig{
-				Enabled: true,
+func (swagger *MgwSwagger) setXWso2ContextAliases() {
+	swagger.contextAliases, _ = getStringArrayExtension(swagger.vendorExtensions, constants.XWso2ContextAliases)
+}
+
+// setXWso2WebSocketOperations marks the operations having the x-wso2-websocket extension, whose routes upgrade the
+// connections to WebSockets using the endpoints of the API.
+func (swagger *MgwSwagger) setXWso2WebSocketOperations() {
+	for _, resource := range swagger.resources {
+		for _, operation := range resource.methods {
+			if webSocket, _ := getBoolExtension(operation.vendorExtensions, constants.XWso2WebSocket, false); !webSocket {
+				continue
 			}
-			err := parser.Decode(parsedCors, &corsConfig)
+			operation.webSocket = true
+			var idleTimeout uint32
+			found, err := extensions.Extract(operation.vendorExtensions, constants.XWso2WebSocketIdleTimeout, &idleTimeout)
 			if err != nil {
-				logger.LoggerOasparser.Errorf("Error while parsing %v: "+err.Error(), constants.XWso2Cors)
-				return
-			}
-			if corsConfig.Enabled {
-				logger.LoggerOasparser.Debugf("API Level Cors Configuration is applied : %+v\n", corsConfig)
-				swagger.xWso2Cors = corsConfig
-				return
+				logger.LoggerOasparser.Errorf("Error while parsing %v of the operation %s %s. %v",
+					constants.XWso2WebSocketIdleTimeout, operation.method, resource.path, err)
+			} else if found {
+				operation.webSocketIdleTimeout = time.Duration(idleTimeout) * time.Second
 			}
-			swagger.xWso2Cors = generateGlobalCors()
-			return
+			resource.hasPolicies = true // to add the upgrade configs only to the routes of this operation
 		}
-		logger.LoggerOasparser.Errorf("Error while parsing %v .", constants.XWso2Cors)
-	} else {
+	}
+}
+
+func (swagger *MgwSwagger) setXWso2StripRequestHeaders() {
+	swagger.xWso2StripRequestHeaders, _ = getStringArrayExtension(swagger.vendorExtensions,
+		constants.XWso2StripRequestHeaders)
+}
+
+func (swagger *MgwSwagger) setXWso2Cors() {
+	//Default CorsConfiguration
+	corsConfig := &CorsConfig{
+		Enabled: true,
+	}
+	found, err := extensions.Extract(swagger.vendorExtensions, constants.XWso2Cors, corsConfig)
+	if !found {
 		swagger.xWso2Cors = generateGlobalCors()
+		return
+	}
+	logger.LoggerOasparser.Debugf("%v configuration is available", constants.XWso2Cors)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while parsing %v: %v", constants.XWso2Cors, err.Error())
+		return
+	}
+	if corsConfig.Enabled {
+		logger.LoggerOasparser.Debugf("API Level Cors Configuration is applied : %+v\n", corsConfig)
+		swagger.xWso2Cors = corsConfig
+		return
+	}
+	swagger.xWso2Cors = generateGlobalCors()
+}
+func (swagger *MgwSwagger) setXWso2NotFoundResponse() {
+	notFoundResponseConfig := &NotFoundResponseConfig{
+		StatusCode:  404,
+		ContentType: "application/json",
+	}
+	found, err := extensions.Extract(swagger.vendorExtensions, constants.XWso2NotFoundResponse, notFoundResponseConfig)
+	if !found {
+		return
+	}
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while parsing %v for API %v:%v. %v", constants.XWso2NotFoundResponse,
+			swagger.title, swagger.version, err.Error())
+		return
 	}
+	logger.LoggerOasparser.Debugf("API level not found response is applied : %+v", notFoundResponseConfig)
+	swagger.xWso2NotFoundResponse = notFoundResponseConfig
 }
 
+// setXWso2StripAuthHeader overrides the stripAuthHeader of the api.yaml, if the extension is provided.
+func (swagger *MgwSwagger) setXWso2StripAuthHeader() {
+	if stripAuthHeader, found := getBoolExtension(swagger.vendorExtensions, constants.XWso2StripAuthHeader,
+		false); found {
+		swagger.stripAuthHeader = &stripAuthHeader
+	}
+}
+
+func (swagger *MgwSwagger) setXWso2UnmatchedRequests() {
+	conf, _ := config.ReadConfigs()
+	unmatchedRequests := UnmatchedRequestsConfig{
+		MethodNotAllowed: conf.Envoy.UnmatchedRequests.MethodNotAllowedEnabled,
+		VhostCatchAll:    conf.Envoy.UnmatchedRequests.VhostCatchAll.Enabled,
+	}
+	if _, err := extensions.Extract(swagger.vendorExtensions, constants.XWso2UnmatchedRequests,
+		&unmatchedRequests); err != nil {
+		logger.LoggerOasparser.Errorf("Error while parsing %v for API %v:%v. %v", constants.XWso2UnmatchedRequests,
+			swagger.title, swagger.version, err.Error())
+	}
+	swagger.unmatchedRequests = unmatchedRequests
+}
 func generateEndpointCluster(endpointPrefix string, endpoints []Endpoint, endpointType string) *EndpointCluster {
 	if len(endpoints) > 0 {
 		endpointCluster := EndpointCluster{
@@ -1087,69 +1855,57 @@ func (swagger *MgwSwagger) GetInterceptor(vendorExtensions map[string]interface{
 	requestTimeoutV := conf.Envoy.ClusterTimeoutInSeconds
 	includesV := &interceptor.RequestInclusions{}
 
-	if x, found := vendorExtensions[extensionName]; found {
-		if val, ok := x.(map[string]interface{}); ok {
-			//serviceURL mandatory
-			if v, found := val[constants.ServiceURL]; found {
-				serviceURLV := v.(string)
-				endpoint, err := getHTTPEndpoint(serviceURLV)
-				if err != nil {
-					logger.LoggerOasparser.Error("Error reading interceptors service url value", err)
-					return InterceptEndpoint{}
-				}
-				if endpoint.Basepath != "" {
-					logger.LoggerOasparser.Warnf("Interceptor serviceURL basepath is given as %v but it will be ignored",
-						endpoint.Basepath)
-				}
-				endpointCluster.Endpoints = []Endpoint{*endpoint}
-
-			} else {
-				logger.LoggerOasparser.Error("Error reading interceptors service url value")
-				return InterceptEndpoint{}
-			}
-			//clusterTimeout optional
-			if v, found := val[constants.ClusterTimeout]; found {
-				p, err := strconv.ParseInt(fmt.Sprint(v), 0, 0)
-				if err == nil {
-					clusterTimeoutV = time.Duration(p)
-				} else {
-					logger.LoggerOasparser.Errorf("Error reading interceptors %v value : %v", constants.ClusterTimeout, err.Error())
-				}
-			}
-			//requestTimeout optional
-			if v, found := val[constants.RequestTimeout]; found {
-				p, err := strconv.ParseInt(fmt.Sprint(v), 0, 0)
-				if err == nil {
-					requestTimeoutV = time.Duration(p)
-				} else {
-					logger.LoggerOasparser.Errorf("Error reading interceptors %v value : %v", constants.RequestTimeout, err.Error())
-				}
-			}
-			//includes optional
-			if v, found := val[constants.Includes]; found {
-				includes := v.([]interface{})
-				if len(includes) > 0 {
-					// convert type of includes from "[]interface{}" to "[]string"
-					includesStr := make([]string, len(includes))
-					for i, v := range includes {
-						includesStr[i] = v.(string)
-					}
-					includesV = GenerateInterceptorIncludes(includesStr)
-				}
-			}
-
-			return InterceptEndpoint{
-				Enable:          true,
-				EndpointCluster: endpointCluster,
-				ClusterTimeout:  clusterTimeoutV,
-				RequestTimeout:  requestTimeoutV,
-				Includes:        includesV,
-				Level:           level,
-			}
+	var interceptor interceptorExtension
+	found, err := extensions.Extract(vendorExtensions, extensionName, &interceptor)
+	if !found {
+		return InterceptEndpoint{}
+	}
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error parsing %v values to mgwSwagger. %v", extensionName, err.Error())
+		return InterceptEndpoint{}
+	}
+	//serviceURL mandatory
+	endpoint, err := getHTTPEndpoint(interceptor.ServiceURL)
+	if err != nil {
+		logger.LoggerOasparser.Error("Error reading interceptors service url value", err)
+		return InterceptEndpoint{}
+	}
+	if endpoint.Basepath != "" {
+		logger.LoggerOasparser.Warnf("Interceptor serviceURL basepath is given as %v but it will be ignored",
+			endpoint.Basepath)
+	}
+	endpointCluster.Endpoints = []Endpoint{*endpoint}
+	//clusterTimeout optional
+	if interceptor.ClusterTimeout != nil {
+		p, err := strconv.ParseInt(fmt.Sprint(interceptor.ClusterTimeout), 0, 0)
+		if err == nil {
+			clusterTimeoutV = time.Duration(p)
+		} else {
+			logger.LoggerOasparser.Errorf("Error reading interceptors %v value : %v", constants.ClusterTimeout, err.Error())
 		}
-		logger.LoggerOasparser.Error("Error parsing response interceptors values to mgwSwagger")
 	}
-	return InterceptEndpoint{}
+	//requestTimeout optional
+	if interceptor.RequestTimeout != nil {
+		p, err := strconv.ParseInt(fmt.Sprint(interceptor.RequestTimeout), 0, 0)
+		if err == nil {
+			requestTimeoutV = time.Duration(p)
+		} else {
+			logger.LoggerOasparser.Errorf("Error reading interceptors %v value : %v", constants.RequestTimeout, err.Error())
+		}
+	}
+	//includes optional
+	if len(interceptor.Includes) > 0 {
+		includesV = GenerateInterceptorIncludes(interceptor.Includes)
+	}
+
+	return InterceptEndpoint{
+		Enable:          true,
+		EndpointCluster: endpointCluster,
+		ClusterTimeout:  clusterTimeoutV,
+		RequestTimeout:  requestTimeoutV,
+		Includes:        includesV,
+		Level:           level,
+	}
 }
 
 //GenerateInterceptorIncludes generate includes
@@ -1192,6 +1948,8 @@ func (swagger *MgwSwagger) GetMgwSwagger(apiContent []byte) error {
 		return err
 	}
 	definitionVersion := utills.FindAPIDefinitionVersion(definitionJsn)
+	// basepath derived from the api.yaml context, if the swagger is populated from an api.yaml
+	contextBasepath := swagger.xWso2Basepath
 
 	if definitionVersion == constants.Swagger2 {
 		var swaggerSpec spec.Swagger
@@ -1226,15 +1984,125 @@ func (swagger *MgwSwagger) GetMgwSwagger(apiContent []byte) error {
 		})
 		return err
 	}
+	conf, _ := config.ReadConfigs()
+	if definitionVersion == constants.Swagger2 || definitionVersion == constants.OpenAPI3 {
+		err = swagger.resolveBasepathConflict(contextBasepath, definitionVersion,
+			conf.Adapter.BasepathConflictResolution)
+		if err != nil {
+			return err
+		}
+	}
 	err = swagger.SetXWso2Extensions()
 	if err != nil {
 		logger.LoggerOasparser.Error("Error occurred while setting x-wso2 extensions for ",
 			swagger.GetTitle(), " ", err)
 		return err
 	}
+	err = swagger.excludeOperationsOnGateways(conf.ControlPlane.EnvironmentLabels)
+	if err != nil {
+		return err
+	}
+	swagger.validateEndpointSchemes()
+	return nil
+}
+
+// resolveBasepathConflict decides the basepath of the API, when the basepath derived from the basePath (if OpenAPI v2)
+// or the servers (if OpenAPI v3) of the API definition differs from the basepath derived from the api.yaml context.
+// The basepath of the API definition is used, unless the resolution is contextWins. An error is returned if the
+// resolution is error.
+func (swagger *MgwSwagger) resolveBasepathConflict(contextBasepath, definitionVersion, resolution string) error {
+	definitionBasepath := swagger.xWso2Basepath
+	if contextBasepath == "" || definitionBasepath == contextBasepath {
+		return nil
+	}
+	definitionSource := "servers"
+	if definitionVersion == constants.Swagger2 {
+		definitionSource = "basePath"
+	}
+	switch resolution {
+	case constants.BasepathResolutionContextWins:
+		swagger.xWso2Basepath = contextBasepath
+		logger.LoggerOasparser.Infof("Basepath %q of the API %s:%s is taken from the api.yaml context, ignoring the "+
+			"basepath %q derived from the %s of the API definition.", contextBasepath, swagger.title, swagger.version,
+			definitionBasepath, definitionSource)
+	case constants.BasepathResolutionError:
+		return fmt.Errorf("basepath %q derived from the %s of the API definition conflicts with the basepath %q "+
+			"derived from the api.yaml context of the API %s:%s", definitionBasepath, definitionSource,
+			contextBasepath, swagger.title, swagger.version)
+	default:
+		if resolution != constants.BasepathResolutionServersWins {
+			logger.LoggerOasparser.Warnf("Unsupported basepath conflict resolution %q. Hence %s is used.", resolution,
+				constants.BasepathResolutionServersWins)
+		}
+		logger.LoggerOasparser.Infof("Basepath %q of the API %s:%s is taken from the %s of the API definition, "+
+			"ignoring the basepath %q derived from the api.yaml context.", definitionBasepath, swagger.title,
+			swagger.version, definitionSource, contextBasepath)
+	}
 	return nil
 }
 
+// GetUpstreamBasepath returns the basepath of the production endpoints of the API, or the sandbox endpoints if the
+// API does not have production endpoints.
+func (swagger *MgwSwagger) GetUpstreamBasepath() string {
+	if swagger.productionEndpoints != nil && len(swagger.productionEndpoints.Endpoints) > 0 {
+		return swagger.productionEndpoints.Endpoints[0].Basepath
+	}
+	if swagger.sandboxEndpoints != nil && len(swagger.sandboxEndpoints.Endpoints) > 0 {
+		return swagger.sandboxEndpoints.Endpoints[0].Basepath
+	}
+	return ""
+}
+
+// excludeOperationsOnGateways removes the operations which list any of the provided gateway labels under the
+// x-wso2-exclude-on-gateways extension. Resources left without operations are removed as well, and an error is
+// returned if none of the resources remain.
+func (swagger *MgwSwagger) excludeOperationsOnGateways(gatewayLabels []string) error {
+	if len(swagger.resources) == 0 {
+		return nil
+	}
+	var filteredResources []*Resource
+	for _, resource := range swagger.resources {
+		var filteredOperations []*Operation
+		for _, operation := range resource.methods {
+			if excludedLabel, excluded := getExcludedGatewayLabel(operation.vendorExtensions, gatewayLabels); excluded {
+				excludedOperation := operation.method + " " + resource.path
+				logger.LoggerOasparser.Debugf("Operation %s of the API %s:%s is excluded as it is marked to be excluded "+
+					"on the gateway label %s.", excludedOperation, swagger.title, swagger.version, excludedLabel)
+				swagger.excludedOperations = append(swagger.excludedOperations, excludedOperation)
+				continue
+			}
+			filteredOperations = append(filteredOperations, operation)
+		}
+		if len(filteredOperations) == 0 {
+			logger.LoggerOasparser.Debugf("Resource %s of the API %s:%s is removed as all of its operations are excluded.",
+				resource.path, swagger.title, swagger.version)
+			continue
+		}
+		resource.methods = filteredOperations
+		filteredResources = append(filteredResources, resource)
+	}
+	if len(filteredResources) == 0 {
+		return fmt.Errorf("all the operations of the API %s:%s are excluded on the gateway labels %v using %s",
+			swagger.title, swagger.version, gatewayLabels, constants.XWso2ExcludeOnGateways)
+	}
+	swagger.resources = filteredResources
+	return nil
+}
+
+// getExcludedGatewayLabel returns the first gateway label which is listed under the x-wso2-exclude-on-gateways
+// extension of the provided vendor extensions.
+func getExcludedGatewayLabel(vendorExtensions map[string]interface{}, gatewayLabels []string) (string, bool) {
+	excludedLabels, found := getStringArrayExtension(vendorExtensions, constants.XWso2ExcludeOnGateways)
+	if !found {
+		return "", false
+	}
+	for _, excludedLabel := range excludedLabels {
+		if arrayContains(gatewayLabels, excludedLabel) {
+			return excludedLabel, true
+		}
+	}
+	return "", false
+}
 //PopulateFromAPIYaml populates the mgwSwagger object for APIs using API.yaml
 // TODO - (VirajSalaka) read cors config and populate mgwSwagger feild
 func (swagger *MgwSwagger) PopulateFromAPIYaml(apiYaml APIYaml) error {
@@ -1250,9 +2118,36 @@ func (swagger *MgwSwagger) PopulateFromAPIYaml(apiYaml APIYaml) error {
 	swagger.xWso2Basepath = data.Context + "/" + swagger.version
 	swagger.LifecycleStatus = data.LifeCycleStatus
 	swagger.IsDefaultVersion = data.IsDefaultVersion
+	swagger.visibility = data.Visibility
+	swagger.visibleRoles = data.VisibleRoles
+	swagger.additionalProperties = data.AdditionalProperties
+	swagger.stripAuthHeader = data.StripAuthHeader
+	swagger.rateLimitKey = data.RateLimitKey
+	if data.RateLimitKey != nil {
+		if conf, _ := config.ReadConfigs(); !conf.Envoy.RateLimit.Enabled {
+			logger.LoggerOasparser.Warnf("Rate limit key of the API %s:%s is not applied as the rate limit service "+
+				"is not enabled (router.rateLimit.enabled)", data.Name, data.Version)
+		}
+	}
+	if data.MaxRequestHeadersKb != nil && *data.MaxRequestHeadersKb > 0 {
+		swagger.maxRequestHeadersKb = uint32(*data.MaxRequestHeadersKb)
+	}
+	swagger.jwksConfig = data.JwksConfig
+	swagger.bufferRequest = data.BufferRequest
+	swagger.localRateLimit = data.LocalRateLimit
+	swagger.responseHeaders = data.ResponseHeaders
+	swagger.setSubscriptionValidationDisabled(data.DisableSubscriptionValidation)
 
 	// Added with both HTTP and WS APIs. x-throttling-tier is not used with WS.
 	swagger.xWso2ThrottlingTier = data.APIThrottlingPolicy
+	if data.RateLimit != nil {
+		// The explicit rate limit is enforced by each router instead of the named tier, hence the API is not
+		// throttled by the tiers of the API or its operations. The tiers of the operations are reset once the
+		// resources are read from the API definition.
+		swagger.rateLimit = data.RateLimit
+		swagger.localRateLimit = data.RateLimit.toLocalRateLimit()
+		swagger.xWso2ThrottlingTier = constants.UnlimitedTier
+	}
 
 	// productionURL & sandBoxURL values are extracted from endpointConfig in api.yaml
 	endpointConfig := data.EndpointConfig
@@ -1263,6 +2158,11 @@ func (swagger *MgwSwagger) PopulateFromAPIYaml(apiYaml APIYaml) error {
 
 	swagger.EndpointType = endpointConfig.EndpointType
 	swagger.EndpointImplementationType = data.EndpointImplementationType
+	sessionAffinity, err := apiYaml.getSessionAffinity()
+	if err != nil {
+		return err
+	}
+	swagger.sessionAffinity = sessionAffinity
 
 	// from here onwards it will process endpoint info
 	// So discontinue if the implementation type is mocked_oas
@@ -1309,6 +2209,10 @@ func (swagger *MgwSwagger) PopulateFromAPIYaml(apiYaml APIYaml) error {
 				return err
 			}
 		}
+		if endpointType == constants.FailOver {
+			endpoints = prioritizeFailoverEndpoints(endpoints, len(endpointConfig.ProductionEndpoints),
+				endpointConfig.ProductionFailoverEndpoints)
+		}
 		swagger.productionEndpoints = generateEndpointCluster(constants.ProdClustersConfigNamePrefix, endpoints, endpointType)
 	}
 
@@ -1351,6 +2255,10 @@ func (swagger *MgwSwagger) PopulateFromAPIYaml(apiYaml APIYaml) error {
 				return err
 			}
 		}
+		if endpointType == constants.FailOver {
+			endpoints = prioritizeFailoverEndpoints(endpoints, len(endpointConfig.SandBoxEndpoints),
+				endpointConfig.SandboxFailoverEndpoints)
+		}
 		swagger.sandboxEndpoints = generateEndpointCluster(constants.SandClustersConfigNamePrefix, endpoints, endpointType)
 	}
 
@@ -1380,6 +2288,9 @@ func (swagger *MgwSwagger) PopulateFromAPIYaml(apiYaml APIYaml) error {
 		if err != nil {
 			return err
 		}
+		if swagger.rateLimit != nil {
+			swagger.setUnlimitedOperationTiers()
+		}
 	}
 	return nil
 }
@@ -18,10 +18,14 @@
 package model
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
 )
 
 func TestGetXWso2Endpoints(t *testing.T) {
@@ -311,6 +315,190 @@ func TestValidateBasePath(t *testing.T) {
 	}
 }
 
+func TestValidateHTTPMethods(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousAllowedMethods := conf.Adapter.AllowedHTTPMethods
+	defer func() {
+		conf.Adapter.AllowedHTTPMethods = previousAllowedMethods
+	}()
+
+	mgwSwagger := MgwSwagger{
+		resources: []*Resource{{
+			path:    "/pets",
+			methods: []*Operation{NewOperation("GET", nil, nil), NewOperation("PATCH", nil, nil)},
+		}},
+	}
+	mgwSwagger.addCustomMethodOperations([]OperationYaml{
+		{Target: "/pets/", Verb: "report"},
+		{Target: "/pets", Verb: "get"},
+		{Target: "/pets/{petId}", Verb: "REPORT"},
+	})
+	assert.Equal(t, []string{"GET", "PATCH", "REPORT"}, mgwSwagger.resources[0].GetMethodList(),
+		"Custom method should be added only to the resource with the matching path")
+
+	err := mgwSwagger.validateHTTPMethods()
+	if assert.NotNil(t, err, "Custom methods should not be allowed by default") {
+		assert.Contains(t, err.Error(), "REPORT")
+	}
+	conf.Adapter.AllowedHTTPMethods = append([]string{"report"}, previousAllowedMethods...)
+	assert.Nil(t, mgwSwagger.validateHTTPMethods(), "Configured custom methods should be allowed")
+
+	mgwSwagger.resources[0].methods = append(mgwSwagger.resources[0].methods, NewOperation("TRACE", nil, nil))
+	err = mgwSwagger.validateHTTPMethods()
+	if assert.NotNil(t, err, "TRACE should not be allowed by default") {
+		assert.Contains(t, err.Error(), "TRACE")
+	}
+}
+
+func TestValidateWebSocketOperations(t *testing.T) {
+	webSocketExtensions := map[string]interface{}{
+		constants.XWso2WebSocket:            true,
+		constants.XWso2WebSocketIdleTimeout: float64(300),
+	}
+	mgwSwagger := MgwSwagger{
+		resources: []*Resource{
+			{path: "/notifications", methods: []*Operation{NewOperation("GET", nil, nil)}},
+			{path: "/notifications/stream", methods: []*Operation{NewOperation("GET", nil, webSocketExtensions)}},
+		},
+	}
+	mgwSwagger.setXWso2WebSocketOperations()
+	assert.False(t, mgwSwagger.resources[0].HasPolicies(), "Resource without WebSocket operations should not be split")
+	assert.True(t, mgwSwagger.resources[1].HasPolicies(), "Resource with a WebSocket operation should be split")
+	assert.False(t, mgwSwagger.resources[0].methods[0].IsWebSocket())
+	assert.True(t, mgwSwagger.resources[1].methods[0].IsWebSocket())
+	assert.Equal(t, 300*time.Second, mgwSwagger.resources[1].methods[0].GetWebSocketIdleTimeout())
+	assert.Nil(t, mgwSwagger.validateWebSocketOperations(), "WebSocket GET operations should be valid")
+
+	mgwSwagger.resources[1].methods = append(mgwSwagger.resources[1].methods,
+		NewOperation("POST", nil, map[string]interface{}{constants.XWso2WebSocket: true}))
+	mgwSwagger.setXWso2WebSocketOperations()
+	err := mgwSwagger.validateWebSocketOperations()
+	if assert.NotNil(t, err, "WebSocket operations other than GET should not be allowed") {
+		assert.Contains(t, err.Error(), "POST /notifications/stream")
+	}
+}
+
+func TestValidateRewritePath(t *testing.T) {
+	rewritePathPolicies := OperationPolicies{Request: PolicyList{
+		{PolicyName: "rewritePath", Action: constants.ActionRewritePath},
+	}}
+	tests := []struct {
+		name        string
+		rewritePath RewritePathConfig
+		policies    OperationPolicies
+		isValid     bool
+	}{
+		{"Valid rewrite", RewritePathConfig{`^/pets/(\d+)/(\w+)$`, `/animals/\2/\1`}, OperationPolicies{}, true},
+		{"Rewrite without capture groups", RewritePathConfig{`^/pets$`, `/animals`}, OperationPolicies{}, true},
+		{"Empty pattern", RewritePathConfig{"", `/animals`}, OperationPolicies{}, false},
+		{"Pattern does not compile", RewritePathConfig{`^/pets/(\d+$`, `/animals/\1`}, OperationPolicies{}, false},
+		{"Unknown capture group", RewritePathConfig{`^/pets/(\d+)$`, `/animals/\2`}, OperationPolicies{}, false},
+		{"Along with a rewrite path policy", RewritePathConfig{`^/pets$`, `/animals`}, rewritePathPolicies, false},
+	}
+	for _, test := range tests {
+		err := validateRewritePath(test.rewritePath, test.policies)
+		if test.isValid {
+			assert.Nil(t, err, test.name)
+		} else {
+			assert.NotNil(t, err, test.name)
+		}
+	}
+}
+
+func TestParseOperationTimeout(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousMaxRouteTimeout := conf.Envoy.Upstream.Timeouts.MaxRouteTimeoutInSeconds
+	defer func() {
+		conf.Envoy.Upstream.Timeouts.MaxRouteTimeoutInSeconds = previousMaxRouteTimeout
+	}()
+	conf.Envoy.Upstream.Timeouts.MaxRouteTimeoutInSeconds = 300
+
+	tests := []struct {
+		name     string
+		timeout  string
+		expected time.Duration
+		isValid  bool
+	}{
+		{"Timeout in seconds", "90s", 90 * time.Second, true},
+		{"Timeout in minutes and seconds", "2m30s", 150 * time.Second, true},
+		{"Maximum route timeout", "5m", 5 * time.Minute, true},
+		{"Timeout without a unit", "90", 0, false},
+		{"Invalid timeout", "two minutes", 0, false},
+		{"Zero timeout", "0s", 0, false},
+		{"Negative timeout", "-10s", 0, false},
+		{"Timeout exceeding the maximum route timeout", "301s", 0, false},
+	}
+	for _, test := range tests {
+		timeout, err := parseOperationTimeout(test.timeout)
+		if test.isValid {
+			assert.Nil(t, err, test.name)
+			assert.Equal(t, test.expected, timeout, test.name)
+		} else {
+			assert.NotNil(t, err, test.name)
+		}
+	}
+
+	proj := ProjectAPI{}
+	proj.APIYaml.Data.Operations = []OperationYaml{{Target: "/pets", Verb: "GET", Timeout: "2m"}}
+	swagger := &MgwSwagger{resources: []*Resource{{path: "/pets", methods: []*Operation{
+		NewOperation("GET", nil, nil), NewOperation("POST", nil, nil)}}}}
+	err := swagger.SetOperationPolicies(proj)
+	assert.Nil(t, err, "Valid operation timeout should be accepted")
+	assert.Equal(t, 2*time.Minute, swagger.resources[0].methods[0].GetTimeout())
+	assert.Equal(t, time.Duration(0), swagger.resources[0].methods[1].GetTimeout(),
+		"Timeout should only be set for the operation")
+	assert.True(t, swagger.resources[0].HasPolicies(), "Routes should be created per operation for the timeout")
+
+	proj.APIYaml.Data.Operations[0].Timeout = "2 minutes"
+	err = swagger.SetOperationPolicies(proj)
+	if assert.Error(t, err, "Invalid operation timeout should be rejected") {
+		assert.Contains(t, err.Error(), "invalid timeout of the operation GET /pets")
+	}
+}
+
+func TestSetOperationStatusRemaps(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusRemaps []StatusRemapConfig
+		isValid      bool
+	}{
+		{"Unconditional remap", []StatusRemapConfig{{From: 200, To: 502}}, true},
+		{"Remaps conditioned on the body", []StatusRemapConfig{{From: 200, To: 404, BodyContains: "NOT_FOUND"},
+			{From: 200, To: 502, BodyContains: "\"error\""}, {From: 200, To: 500}}, true},
+		{"Invalid from status", []StatusRemapConfig{{From: 99, To: 502}}, false},
+		{"Informational to status", []StatusRemapConfig{{From: 200, To: 101}}, false},
+		{"Missing to status", []StatusRemapConfig{{From: 200}}, false},
+		{"Remap following an unconditional remap of the status", []StatusRemapConfig{{From: 200, To: 500},
+			{From: 200, To: 404, BodyContains: "NOT_FOUND"}}, false},
+	}
+	for _, test := range tests {
+		err := validateStatusRemaps(test.statusRemaps)
+		if test.isValid {
+			assert.Nil(t, err, test.name)
+		} else {
+			assert.NotNil(t, err, test.name)
+		}
+	}
+
+	proj := ProjectAPI{}
+	proj.APIYaml.Data.Operations = []OperationYaml{{Target: "/pets", Verb: "GET",
+		RemapStatus: []StatusRemapConfig{{From: 200, To: 502, BodyContains: "error"}}}}
+	swagger := &MgwSwagger{resources: []*Resource{{path: "/pets", methods: []*Operation{
+		NewOperation("GET", nil, nil), NewOperation("POST", nil, nil)}}}}
+	err := swagger.SetOperationPolicies(proj)
+	assert.Nil(t, err, "Valid status remaps should be accepted")
+	assert.Equal(t, []StatusRemapConfig{{From: 200, To: 502, BodyContains: "error"}},
+		swagger.resources[0].methods[0].GetStatusRemaps())
+	assert.Empty(t, swagger.resources[0].methods[1].GetStatusRemaps(), "Status should only be remapped for the operation")
+	assert.True(t, swagger.resources[0].HasPolicies(), "Routes should be created per operation for the status remaps")
+
+	proj.APIYaml.Data.Operations[0].RemapStatus[0].To = 0
+	err = swagger.SetOperationPolicies(proj)
+	if assert.Error(t, err, "Invalid status remap should be rejected") {
+		assert.Contains(t, err.Error(), "invalid remapStatus of the operation GET /pets")
+	}
+}
+
 func TestGetAuthorityHeader(t *testing.T) {
 	type getXWso2AuthorityHeaderTestItem struct {
 		serviceURL      string
@@ -354,3 +542,351 @@ func TestGetEndpointType(t *testing.T) {
 		assert.Equal(t, item.result, resultEndpointType, item.message)
 	}
 }
+
+func TestExcludeOperationsOnGateways(t *testing.T) {
+	excludedOnDefault := map[string]interface{}{constants.XWso2ExcludeOnGateways: []interface{}{"Default", "Internal"}}
+	excludedOnOther := map[string]interface{}{constants.XWso2ExcludeOnGateways: []interface{}{"Internal"}}
+	swagger := MgwSwagger{
+		title:   "PetStore",
+		version: "1.0.0",
+		resources: []*Resource{
+			{path: "/pets", methods: []*Operation{
+				NewOperation("GET", nil, excludedOnOther),
+				NewOperation("POST", nil, excludedOnDefault),
+			}},
+			{path: "/pets/{petId}", methods: []*Operation{
+				NewOperation("DELETE", nil, excludedOnDefault),
+			}},
+		},
+	}
+	err := swagger.excludeOperationsOnGateways([]string{"Default"})
+	assert.Nil(t, err, "Error should not be returned when some of the operations remain")
+	assert.Len(t, swagger.GetResources(), 1, "Resource should be removed when all of its operations are excluded")
+	assert.Equal(t, "/pets", swagger.GetResources()[0].GetPath())
+	assert.Equal(t, []string{"GET"}, swagger.GetResources()[0].GetMethodList())
+	assert.Equal(t, []string{"POST /pets", "DELETE /pets/{petId}"}, swagger.GetExcludedOperations())
+
+	err = swagger.excludeOperationsOnGateways([]string{"Internal"})
+	assert.NotNil(t, err, "Error should be returned when all the operations are excluded")
+}
+
+func TestSetEnvLabelEndpointSecurity(t *testing.T) {
+	newSwagger := func() MgwSwagger {
+		return MgwSwagger{
+			title:   "PetStore",
+			version: "1.0.0",
+			productionEndpoints: &EndpointCluster{SecurityConfig: EndpointSecurity{Enabled: true, Type: "BASIC",
+				Username: "revision-user", Password: "revision-password",
+				CustomParameters: map[string]string{"param": "value"}}},
+			sandboxEndpoints: &EndpointCluster{},
+		}
+	}
+
+	swagger := newSwagger()
+	err := swagger.SetEnvLabelEndpointSecurity(synchronizer.APIEnvProps{APIConfigs: synchronizer.APIConfigs{
+		ProductionEndpointSecurity: &synchronizer.EndpointSecurity{Username: "prod-user", Password: "prod-password"},
+		SandboxEndpointSecurity:    &synchronizer.EndpointSecurity{Username: "sand-user", Password: "sand-password"},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, EndpointSecurity{Enabled: true, Type: "BASIC", Username: "prod-user", Password: "prod-password",
+		CustomParameters: map[string]string{"param": "value"}}, swagger.GetProdEndpoints().SecurityConfig)
+	assert.Equal(t, EndpointSecurity{Enabled: true, Type: "BASIC", Username: "sand-user", Password: "sand-password"},
+		swagger.GetSandEndpoints().SecurityConfig, "endpoint security should be enabled by the env properties")
+
+	swagger = newSwagger()
+	unchangedSwagger := newSwagger()
+	err = swagger.SetEnvLabelEndpointSecurity(synchronizer.APIEnvProps{})
+	assert.Nil(t, err)
+	assert.Equal(t, unchangedSwagger.GetProdEndpoints().SecurityConfig, swagger.GetProdEndpoints().SecurityConfig,
+		"endpoint security should not be changed without env properties")
+
+	swagger = newSwagger()
+	err = swagger.SetEnvLabelEndpointSecurity(synchronizer.APIEnvProps{APIConfigs: synchronizer.APIConfigs{
+		SandboxEndpointSecurity: &synchronizer.EndpointSecurity{Username: "sand-user"},
+	}})
+	assert.NotNil(t, err, "username without a password should fail the validation")
+	assert.Equal(t, EndpointSecurity{}, swagger.GetSandEndpoints().SecurityConfig)
+
+	err = swagger.SetEnvLabelEndpointSecurity(synchronizer.APIEnvProps{APIConfigs: synchronizer.APIConfigs{
+		ProductionEndpointSecurity: &synchronizer.EndpointSecurity{Type: "DIGEST", Username: "user", Password: "pass"},
+	}})
+	assert.NotNil(t, err, "unsupported endpoint security types should fail the validation")
+}
+
+func TestSetEndpointsConfigWithTLSCipherSuites(t *testing.T) {
+	endpointInfo := EndpointInfo{Endpoint: "https://petstore.swagger.io/v2"}
+	endpointInfo.Config.TLSCipherSuites = []string{"ECDHE-RSA-AES256-GCM-SHA384",
+		"[ECDHE-RSA-AES128-GCM-SHA256|ECDHE-RSA-CHACHA20-POLY1305]"}
+	endpointCluster := &EndpointCluster{
+		Endpoints: []Endpoint{{Host: "petstore.swagger.io", URLType: "https", Port: 443}},
+	}
+	err := endpointCluster.SetEndpointsConfig([]EndpointInfo{endpointInfo})
+	assert.Nil(t, err)
+	assert.Equal(t, endpointInfo.Config.TLSCipherSuites, endpointCluster.Config.TLSCipherSuites)
+	assert.Nil(t, endpointCluster.validateEndpointCluster("API level production"))
+
+	endpointCluster.Config.TLSCipherSuites = []string{"ECDHE-RSA-AES256-GCM-SHA384", "TLS_RSA_WITH_RC4_128_MD5"}
+	err = endpointCluster.validateEndpointCluster("API level production")
+	if assert.NotNil(t, err, "unsupported cipher suites should fail the validation") {
+		assert.Contains(t, err.Error(), "TLS_RSA_WITH_RC4_128_MD5")
+	}
+	endpointCluster.Config.TLSCipherSuites = []string{"[ECDHE-RSA-AES128-GCM-SHA256|INVALID-CIPHER]"}
+	assert.NotNil(t, endpointCluster.validateEndpointCluster("API level production"),
+		"unsupported cipher suites in an equal preference group should fail the validation")
+}
+
+func TestSetEndpointsConfigWithIdleTimeout(t *testing.T) {
+	endpointInfo := EndpointInfo{Endpoint: "https://petstore.swagger.io/v2"}
+	endpointInfo.Config.IdleTimeout = "90s"
+	endpointCluster := &EndpointCluster{
+		Endpoints: []Endpoint{{Host: "petstore.swagger.io", URLType: "https", Port: 443}},
+	}
+	err := endpointCluster.SetEndpointsConfig([]EndpointInfo{endpointInfo})
+	assert.Nil(t, err)
+	assert.Equal(t, "90s", endpointCluster.Config.IdleTimeout)
+	assert.Nil(t, endpointCluster.validateEndpointCluster("API level production"))
+
+	for _, invalidIdleTimeout := range []string{"90", "ninety seconds", "-5s", "0s"} {
+		endpointCluster.Config.IdleTimeout = invalidIdleTimeout
+		err = endpointCluster.validateEndpointCluster("API level production")
+		if assert.NotNil(t, err, "idle timeout %q should fail the validation", invalidIdleTimeout) {
+			assert.Contains(t, err.Error(), invalidIdleTimeout)
+		}
+	}
+}
+
+func TestSetEndpointsConfigWithTLSSkipVerify(t *testing.T) {
+	endpointInfo := EndpointInfo{Endpoint: "https://dev.wso2.com/v2"}
+	endpointInfo.Config.TLSSkipVerify = true
+	endpointCluster := &EndpointCluster{
+		Endpoints: []Endpoint{{Host: "dev.wso2.com", URLType: "https", Port: 443}},
+	}
+	err := endpointCluster.SetEndpointsConfig([]EndpointInfo{endpointInfo})
+	assert.Nil(t, err)
+	assert.True(t, endpointCluster.Config.TLSSkipVerify)
+
+	endpointCluster = &EndpointCluster{
+		Endpoints: []Endpoint{{Host: "dev.wso2.com", URLType: "https", Port: 443}},
+	}
+	err = endpointCluster.SetEndpointsConfig([]EndpointInfo{{Endpoint: "https://dev.wso2.com/v2"}})
+	assert.Nil(t, err)
+	assert.False(t, endpointCluster.Config.TLSSkipVerify, "certificates should be verified by default")
+}
+
+func TestEndpointSecurityPasswordRedaction(t *testing.T) {
+	envProps := synchronizer.APIEnvProps{APIConfigs: synchronizer.APIConfigs{
+		ProductionEndpointSecurity: &synchronizer.EndpointSecurity{Username: "prod-user", Password: "prod-password"},
+	}}
+	endpointCluster := EndpointCluster{SecurityConfig: EndpointSecurity{Enabled: true, Username: "prod-user",
+		Password: "prod-password"}}
+	for _, logged := range []string{fmt.Sprintf("%v", envProps), fmt.Sprintf("%+v", envProps),
+		fmt.Sprintf("%v", endpointCluster), fmt.Sprintf("%+v", &endpointCluster)} {
+		assert.Contains(t, logged, "prod-user")
+		assert.NotContains(t, logged, "prod-password", "password should be masked when logged")
+	}
+}
+
+func TestGetMgwSwaggerWithBasepathConflict(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousResolution := conf.Adapter.BasepathConflictResolution
+	defer func() {
+		conf.Adapter.BasepathConflictResolution = previousResolution
+	}()
+
+	definitions := map[string]string{
+		"OpenAPI v3": `openapi: 3.0.1
+info:
+  title: Store
+  version: v1
+servers:
+  - url: https://backend/api/v3
+paths:
+  /orders:
+    get:
+      responses:
+        "200":
+          description: OK
+`,
+		"OpenAPI v2": `swagger: "2.0"
+info:
+  title: Store
+  version: v1
+host: backend
+basePath: /api/v3
+schemes:
+  - https
+paths:
+  /orders:
+    get:
+      responses:
+        "200":
+          description: OK
+`,
+	}
+	tests := []struct {
+		name             string
+		resolution       string
+		expectedBasepath string
+		isExpError       bool
+	}{
+		{
+			name:             "Default resolution uses the basepath of the API definition",
+			resolution:       previousResolution,
+			expectedBasepath: "/api/v3",
+		},
+		{
+			name:             "Basepath of the API definition wins",
+			resolution:       constants.BasepathResolutionServersWins,
+			expectedBasepath: "/api/v3",
+		},
+		{
+			name:             "api.yaml context wins",
+			resolution:       constants.BasepathResolutionContextWins,
+			expectedBasepath: "/store/v1",
+		},
+		{
+			name:       "Conflict is rejected",
+			resolution: constants.BasepathResolutionError,
+			isExpError: true,
+		},
+	}
+	for definitionType, definition := range definitions {
+		for _, test := range tests {
+			t.Run(definitionType+" "+test.name, func(t *testing.T) {
+				conf.Adapter.BasepathConflictResolution = test.resolution
+				var apiYaml APIYaml
+				apiYaml.Data.Name = "Store"
+				apiYaml.Data.Context = "/store"
+				apiYaml.Data.Version = "v1"
+				apiYaml.Data.APIType = constants.HTTP
+				var mgwSwagger MgwSwagger
+				assert.Nil(t, mgwSwagger.PopulateFromAPIYaml(apiYaml))
+
+				err := mgwSwagger.GetMgwSwagger([]byte(definition))
+				if test.isExpError {
+					if assert.NotNil(t, err) {
+						assert.Contains(t, err.Error(), "/api/v3")
+						assert.Contains(t, err.Error(), "/store/v1")
+					}
+					return
+				}
+				assert.Nil(t, err)
+				assert.Equal(t, test.expectedBasepath, mgwSwagger.GetXWso2Basepath())
+				assert.Equal(t, "/api/v3", mgwSwagger.GetUpstreamBasepath(),
+					"upstream basepath should not depend on the resolution")
+			})
+		}
+	}
+}
+
+func TestIsMockedOperation(t *testing.T) {
+	definition := `openapi: 3.0.1
+info:
+  title: Store
+  version: v1
+paths:
+  /orders:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              example:
+                orderId: 1
+    post:
+      responses:
+        "201":
+          description: Created
+`
+	tests := []struct {
+		name                       string
+		implementationStatus       string
+		endpointImplementationType string
+		expectedMockedMethods      []string
+	}{
+		{
+			name:                  "Operations of a published API are proxied",
+			expectedMockedMethods: []string{},
+		},
+		{
+			name:                  "Operations of a prototyped API having examples are mocked",
+			implementationStatus:  constants.Prototyped,
+			expectedMockedMethods: []string{"GET"},
+		},
+		{
+			name:                       "Operations of a mocked API are mocked",
+			endpointImplementationType: constants.MockedOASEndpointType,
+			expectedMockedMethods:      []string{"GET", "POST"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var apiYaml APIYaml
+			apiYaml.Data.Name = "Store"
+			apiYaml.Data.Context = "/store"
+			apiYaml.Data.Version = "v1"
+			apiYaml.Data.APIType = constants.HTTP
+			apiYaml.Data.EndpointImplementationType = test.endpointImplementationType
+			apiYaml.Data.EndpointConfig.ImplementationStatus = test.implementationStatus
+			apiYaml.Data.EndpointConfig.ProductionEndpoints = []EndpointInfo{{Endpoint: "https://prototype.store.com"}}
+			var mgwSwagger MgwSwagger
+			assert.Nil(t, mgwSwagger.PopulateFromAPIYaml(apiYaml))
+			assert.Nil(t, mgwSwagger.GetMgwSwagger([]byte(definition)))
+
+			mockedMethods := []string{}
+			for _, resource := range mgwSwagger.GetResources() {
+				for _, operation := range resource.GetMethod() {
+					if mgwSwagger.IsMockedOperation(operation) {
+						mockedMethods = append(mockedMethods, operation.GetMethod())
+					}
+				}
+			}
+			assert.ElementsMatch(t, test.expectedMockedMethods, mockedMethods)
+		})
+	}
+}
+
+func TestValidateContextAliases(t *testing.T) {
+	tests := []struct {
+		name           string
+		contextAliases string
+		errorMessage   string
+	}{
+		{
+			name:           "Distinct context aliases",
+			contextAliases: `["/store-alias", "/shop/v1"]`,
+		},
+		{
+			name:           "Context alias same as the basepath",
+			contextAliases: `["/store/"]`,
+			errorMessage:   "context alias \"/store/\" is the same as the basepath of the API",
+		},
+		{
+			name:           "Duplicate context aliases",
+			contextAliases: `["/shop", "/shop/"]`,
+			errorMessage:   "context alias \"/shop/\" is given more than once",
+		},
+		{
+			name:           "Context alias with invalid characters",
+			contextAliases: `["shop?"]`,
+			errorMessage:   "invalid context alias \"shop?\". Does not start with / or includes invalid characters",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			definition := `{"openapi": "3.0.1", "info": {"title": "Store", "version": "v1"},
+				"x-wso2-context-aliases": ` + test.contextAliases + `, "paths": {}}`
+			var mgwSwagger MgwSwagger
+			assert.Nil(t, mgwSwagger.GetMgwSwagger([]byte(definition)))
+			mgwSwagger.xWso2Basepath = "/store"
+			err := mgwSwagger.validateContextAliases()
+			if test.errorMessage == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, []string{"/store-alias", "/shop/v1"}, mgwSwagger.GetContextAliases())
+			} else if assert.NotNil(t, err) {
+				assert.Equal(t, test.errorMessage, err.Error())
+			}
+		})
+	}
+}
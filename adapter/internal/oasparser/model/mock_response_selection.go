@@ -0,0 +1,164 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/extensions"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/api"
+)
+
+// MockResponseSelection represents the x-wso2-mock-response-selection extension of an operation, which selects the
+// mocked response by the value of a request header. The base response (i.e. the response preferred by the Prefer
+// header of the request, or else the default response) is returned for the values which are not listed.
+//
+//	x-wso2-mock-response-selection:
+//	  header: x-mock-scenario
+//	  responses:
+//	    not-found:
+//	      code: "404"
+//	    premium-pet:
+//	      example: premium
+type MockResponseSelection struct {
+	Header    string                            `mapstructure:"header"`
+	Responses map[string]MockResponsePreference `mapstructure:"responses"`
+}
+
+// MockResponsePreference selects a mocked response by the status code and the example name, in the same way as the
+// code and example preferences of the Prefer header.
+type MockResponsePreference struct {
+	Code    string `mapstructure:"code"`
+	Example string `mapstructure:"example"`
+}
+
+// String returns the preference in the format of the Prefer header (e.g. "code=404, example=notFound").
+func (preference MockResponsePreference) String() string {
+	var preferences []string
+	if preference.Code != "" {
+		preferences = append(preferences, "code="+preference.Code)
+	}
+	if preference.Example != "" {
+		preferences = append(preferences, "example="+preference.Example)
+	}
+	return strings.Join(preferences, ", ")
+}
+
+// GetPreference returns the preference of the mocked response selected by the value of the header, or an empty
+// string if the base response should be returned for the value.
+func (selection *MockResponseSelection) GetPreference(headerValue string) string {
+	if preference, found := selection.Responses[headerValue]; found {
+		return preference.String()
+	}
+	return ""
+}
+
+func validateMockResponseSelection(selection MockResponseSelection) error {
+	if selection.Header == "" {
+		return &extensions.ValidationError{Path: "header", Message: "header is mandatory"}
+	}
+	if len(selection.Responses) == 0 {
+		return &extensions.ValidationError{Path: "responses", Message: "at least one response should be listed"}
+	}
+	for value, preference := range selection.Responses {
+		if preference.Code == "" && preference.Example == "" {
+			return &extensions.ValidationError{
+				Path:    "responses." + value,
+				Message: "either the code or the example of the response should be set",
+			}
+		}
+		if _, err := strconv.Atoi(preference.Code); preference.Code != "" && (len(preference.Code) != 3 || err != nil) {
+			return &extensions.ValidationError{
+				Path:    "responses." + value + ".code",
+				Message: fmt.Sprintf("invalid status code %q. Status code should be a three digit number", preference.Code),
+			}
+		}
+	}
+	return nil
+}
+
+// setXWso2MockResponseSelections sets the mocked responses of the operations selected by a request header. The
+// responses are validated against the mocked API configs of the operations, hence this needs to be called after
+// those are set. An error is returned if a listed response is not available in the API definition.
+func (swagger *MgwSwagger) setXWso2MockResponseSelections() error {
+	for _, resource := range swagger.resources {
+		for _, operation := range resource.methods {
+			var selection MockResponseSelection
+			found, err := extensions.Extract(operation.vendorExtensions, constants.XWso2MockResponseSelection,
+				&selection)
+			if !found {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("invalid %s of the operation %s %s. %v", constants.XWso2MockResponseSelection,
+					operation.method, resource.path, err)
+			}
+			for value, preference := range selection.Responses {
+				if err := validateMockResponsePreference(preference, operation.mockedAPIConfig); err != nil {
+					return fmt.Errorf("invalid response %q in %s of the operation %s %s. %v", value,
+						constants.XWso2MockResponseSelection, operation.method, resource.path, err)
+				}
+			}
+			operation.mockResponseSelection = &selection
+			resource.hasPolicies = true // to pass the selection only to the routes of this operation
+		}
+	}
+	return nil
+}
+
+// validateMockResponsePreference checks whether the preferred response is available in the mocked API config, the
+// same way the enforcer resolves the code and example preferences (i.e. "404" falls back to "40x" and then to "4xx",
+// and the example is matched in lower case).
+func validateMockResponsePreference(preference MockResponsePreference, mockedAPIConfig *api.MockedApiConfig) error {
+	if mockedAPIConfig == nil || len(mockedAPIConfig.Responses) == 0 {
+		return fmt.Errorf("the operation does not have mocked responses")
+	}
+	responses := mockedAPIConfig.Responses
+	if preference.Code != "" {
+		responses = nil
+		for _, code := range []string{preference.Code, preference.Code[:2] + "x", preference.Code[:1] + "xx"} {
+			for _, response := range mockedAPIConfig.Responses {
+				if response.Code == code {
+					responses = append(responses, response)
+				}
+			}
+			if len(responses) > 0 {
+				break
+			}
+		}
+		if len(responses) == 0 {
+			return fmt.Errorf("response for the code %s is not defined", preference.Code)
+		}
+	}
+	if preference.Example == "" {
+		return nil
+	}
+	for _, response := range responses {
+		for _, content := range response.Content {
+			for _, example := range content.Examples {
+				if example.Ref == strings.ToLower(preference.Example) {
+					return nil
+				}
+			}
+		}
+	}
+	return fmt.Errorf("example %s is not defined in lower case", preference.Example)
+}
@@ -0,0 +1,108 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/api"
+)
+
+func TestSetXWso2MockResponseSelections(t *testing.T) {
+	mockedAPIConfig := &api.MockedApiConfig{
+		Responses: []*api.MockedResponseConfig{
+			{Code: "200", Content: []*api.MockedContentConfig{{
+				ContentType: "application/json",
+				Examples: []*api.MockedContentExample{
+					{Ref: "basic", Body: `{"name": "doggie"}`},
+					{Ref: "premium", Body: `{"name": "doggie", "tier": "premium"}`},
+				},
+			}}},
+			{Code: "4xx", Content: []*api.MockedContentConfig{{
+				ContentType: "application/json",
+				Examples:    []*api.MockedContentExample{{Ref: "notfound", Body: `{"error": "not found"}`}},
+			}}},
+		},
+	}
+	newMockedSwagger := func(selection map[string]interface{}) *MgwSwagger {
+		operation := NewOperation("GET", nil, map[string]interface{}{
+			constants.XWso2MockResponseSelection: selection,
+		})
+		operation.mockedAPIConfig = mockedAPIConfig
+		return &MgwSwagger{resources: []*Resource{{path: "/pets", methods: []*Operation{operation}}}}
+	}
+
+	mgwSwagger := newMockedSwagger(map[string]interface{}{
+		"header": "X-Mock-Scenario",
+		"responses": map[string]interface{}{
+			"premium-pet": map[string]interface{}{"example": "premium"},
+			"not-found":   map[string]interface{}{"code": "404", "example": "notFound"},
+			"bad-request": map[string]interface{}{"code": "400"},
+		},
+	})
+	assert.NoError(t, mgwSwagger.setXWso2MockResponseSelections())
+	selection := mgwSwagger.resources[0].methods[0].GetMockResponseSelection()
+	if assert.NotNil(t, selection, "Mocked responses should be selected by the header") {
+		assert.Equal(t, "X-Mock-Scenario", selection.Header)
+		assert.Equal(t, "example=premium", selection.GetPreference("premium-pet"))
+		assert.Equal(t, "code=404, example=notFound", selection.GetPreference("not-found"),
+			"Response of the code should fall back to the response of the code range")
+		assert.Equal(t, "code=400", selection.GetPreference("bad-request"))
+		assert.Empty(t, selection.GetPreference("unknown"), "Base response should be returned for unlisted values")
+		assert.Empty(t, selection.GetPreference(""), "Base response should be returned without the header")
+	}
+	assert.True(t, mgwSwagger.resources[0].hasPolicies,
+		"Selection should be applied only to the routes of the operation")
+
+	invalidSelections := map[string]map[string]interface{}{
+		"header is missing": {
+			"responses": map[string]interface{}{"not-found": map[string]interface{}{"code": "404"}},
+		},
+		"responses are missing": {"header": "X-Mock-Scenario"},
+		"response is empty": {
+			"header":    "X-Mock-Scenario",
+			"responses": map[string]interface{}{"empty": map[string]interface{}{}},
+		},
+		"code is invalid": {
+			"header":    "X-Mock-Scenario",
+			"responses": map[string]interface{}{"not-found": map[string]interface{}{"code": "4xx"}},
+		},
+		"code is not mocked": {
+			"header":    "X-Mock-Scenario",
+			"responses": map[string]interface{}{"server-error": map[string]interface{}{"code": "500"}},
+		},
+		"example is not mocked for the code": {
+			"header":    "X-Mock-Scenario",
+			"responses": map[string]interface{}{"premium-pet": map[string]interface{}{"code": "404", "example": "premium"}},
+		},
+	}
+	for name, invalidSelection := range invalidSelections {
+		assert.Error(t, newMockedSwagger(invalidSelection).setXWso2MockResponseSelections(),
+			"Selection should be rejected when the %s", name)
+	}
+
+	notMockedSwagger := newMockedSwagger(map[string]interface{}{
+		"header":    "X-Mock-Scenario",
+		"responses": map[string]interface{}{"premium-pet": map[string]interface{}{"example": "premium"}},
+	})
+	notMockedSwagger.resources[0].methods[0].mockedAPIConfig = nil
+	assert.Error(t, notMockedSwagger.setXWso2MockResponseSelections(),
+		"Selection should be rejected for the operations without mocked responses")
+}
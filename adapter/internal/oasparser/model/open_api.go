@@ -19,6 +19,7 @@ package model
 
 import (
 	"encoding/json"
+	"net/http"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -75,6 +76,7 @@ func (swagger *MgwSwagger) SetInfoOpenAPI(swagger3 openapi3.Swagger) error {
 	if err != nil {
 		return err
 	}
+	swagger.collectParseWarningsOpenAPI(swagger3)
 
 	swagger.xWso2RequestBodyPass = getRequestBodyBufferConfig(swagger.vendorExtensions)
 
@@ -122,6 +124,12 @@ func setPathInfoOpenAPI(path string, methods []*Operation, pathItem *openapi3.Pa
 	return resource
 }
 
+// openAPIMethods are the methods of the operations of a path in the order of the OpenAPI specification. The operations
+// are read in this order, hence the methods of the generated routes (e.g. the allow header) do not change between
+// the deployments of an API.
+var openAPIMethods = []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodOptions,
+	http.MethodHead, http.MethodPatch, http.MethodTrace, http.MethodConnect}
+
 func setResourcesOpenAPI(openAPI openapi3.Swagger) ([]*Resource, error) {
 	var resources []*Resource
 
@@ -133,17 +141,15 @@ func setResourcesOpenAPI(openAPI openapi3.Swagger) ([]*Resource, error) {
 		for path, pathItem := range openAPI.Paths {
 			// Checks for resource level security. (security is disabled in resource level using x-wso2-disable-security extension)
 			isResourceLvlSecurityDisabled, foundInResourceLevel := resolveDisableSecurity(pathItem.ExtensionProps)
-			methodsArray := make([]*Operation, len(pathItem.Operations()))
-			var arrayIndex int = 0
-			for httpMethod, operation := range pathItem.Operations() {
-				if operation != nil {
+			methodsArray := make([]*Operation, 0, len(pathItem.Operations()))
+			for _, httpMethod := range openAPIMethods {
+				if operation := pathItem.GetOperation(httpMethod); operation != nil {
 					if foundInResourceLevel {
 						operation.ExtensionProps = addDisableSecurityIfNotPresent(operation.ExtensionProps, isResourceLvlSecurityDisabled)
 					} else if found {
 						operation.ExtensionProps = addDisableSecurityIfNotPresent(operation.ExtensionProps, val)
 					}
-					methodsArray[arrayIndex] = getOperationLevelDetails(operation, httpMethod)
-					arrayIndex++
+					methodsArray = append(methodsArray, getOperationLevelDetails(operation, httpMethod))
 				}
 			}
 
@@ -191,18 +197,15 @@ func getRequestBodyBufferConfig(vendorExtensions map[string]interface{}) bool {
 	if !configs.Envoy.PayloadPassingToEnforcer.PassRequestPayload {
 		return false
 	}
-	if val, found := vendorExtensions[constants.XWso2PassRequestPayloadToEnforcer]; found {
-		if passerValue, ok := val.(bool); ok {
-			return passerValue
-		}
-	}
-	return true
+	passRequestPayload, _ := getBoolExtension(vendorExtensions, constants.XWso2PassRequestPayloadToEnforcer, true)
+	return passRequestPayload
 }
 
 func getOperationLevelDetails(operation *openapi3.Operation, method string) *Operation {
 	extensions := convertExtensibletoReadableFormat(operation.ExtensionProps)
 	mgwOperation := NewOperation(method, nil, extensions)
 	mgwOperation.SetMockedAPIConfigOAS3(operation)
+	mgwOperation.SetMediaTypesOAS3(operation)
 	if operation.Security == nil {
 		return mgwOperation
 	}
@@ -249,14 +252,7 @@ func convertExtensibletoReadableFormat(vendorExtensions openapi3.ExtensionProps)
 // 1st bool represnt the value of the vendor extension.
 // 2nd bool represent if the vendor extension present.
 func resolveDisableSecurity(vendorExtensions openapi3.ExtensionProps) (bool, bool) {
-	extensions := convertExtensibletoReadableFormat(vendorExtensions)
-	if y, found := extensions[constants.XWso2DisableSecurity]; found {
-		if val, ok := y.(bool); ok {
-			return val, found
-		}
-		logger.LoggerOasparser.Errorln("Error while parsing the x-wso2-label")
-	}
-	return false, false
+	return getBoolExtension(convertExtensibletoReadableFormat(vendorExtensions), constants.XWso2DisableSecurity, false)
 }
 
 // This method add the disable security to given vendor extensions, if it's not present.
@@ -272,16 +268,9 @@ func addDisableSecurityIfNotPresent(vendorExtensions openapi3.ExtensionProps, va
 //
 // Default value is 'default'
 func GetXWso2Label(vendorExtensions openapi3.ExtensionProps) []string {
-	vendorExtensionsMap := convertExtensibletoReadableFormat(vendorExtensions)
-	var labelArray []string
-	if y, found := vendorExtensionsMap[constants.XWso2Label]; found {
-		if val, ok := y.([]interface{}); ok {
-			for _, label := range val {
-				labelArray = append(labelArray, label.(string))
-			}
-			return labelArray
-		}
-		logger.LoggerOasparser.Errorln("Error while parsing the x-wso2-label")
+	if labels, found := getStringArrayExtension(convertExtensibletoReadableFormat(vendorExtensions),
+		constants.XWso2Label); found {
+		return labels
 	}
 	return []string{"default"}
 }
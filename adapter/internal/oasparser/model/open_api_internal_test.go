@@ -130,6 +130,30 @@ func TestSetResourcesOpenAPI(t *testing.T) {
 	}
 }
 
+func TestSetResourcesOpenAPIOperationOrder(t *testing.T) {
+	openAPI := openapi3.Swagger{
+		Paths: openapi3.Paths{
+			"/pets": &openapi3.PathItem{
+				Trace:  &openapi3.Operation{},
+				Delete: &openapi3.Operation{},
+				Post:   &openapi3.Operation{},
+				Get:    &openapi3.Operation{},
+				Patch:  &openapi3.Operation{},
+			},
+		},
+	}
+	for i := 0; i < 10; i++ {
+		resources, err := setResourcesOpenAPI(openAPI)
+		assert.Nil(t, err, "No error should be encountered when setting resources")
+		var methods []string
+		for _, operation := range resources[0].GetMethod() {
+			methods = append(methods, operation.GetMethod())
+		}
+		assert.Equal(t, []string{"GET", "POST", "DELETE", "PATCH", "TRACE"}, methods,
+			"Operations should be in the order of the OpenAPI specification")
+	}
+}
+
 func TestGetHostandBasepathandPort(t *testing.T) {
 	type setResourcesTestItem struct {
 		input   string
@@ -187,6 +211,42 @@ func TestGetHostandBasepathandPort(t *testing.T) {
 			},
 			message: "When leading and trailing spaces present",
 		},
+		{
+			input: "https://petstore.io:8000/api/v2?apiKey=abc%20123&version=2",
+			result: &Endpoint{
+				Host:        "petstore.io",
+				Basepath:    "/api/v2",
+				Port:        8000,
+				URLType:     "https",
+				RawURL:      "https://petstore.io:8000/api/v2?apiKey=abc%20123&version=2",
+				QueryParams: "apiKey=abc%20123&version=2",
+			},
+			message: "when query parameters are provided in the endpoint",
+		},
+		{
+			input:   "https://petstore.io:8000/api/v2?apiKey=%zz",
+			result:  nil,
+			message: "when the query parameters of the endpoint are malformed",
+		},
+		{
+			input: "unix:///var/run/backend/backend.sock",
+			result: &Endpoint{
+				Host:    "/var/run/backend/backend.sock",
+				URLType: "unix",
+				RawURL:  "unix:///var/run/backend/backend.sock",
+			},
+			message: "when a unix domain socket is provided",
+		},
+		{
+			input:   "unix://var/run/backend.sock",
+			result:  nil,
+			message: "when the path of the unix domain socket is relative",
+		},
+		{
+			input:   "unix:///var/run/../backend.sock",
+			result:  nil,
+			message: "when the path of the unix domain socket is not clean",
+		},
 	}
 	for _, item := range dataItems {
 		resultResources, err := getHTTPEndpoint(item.input)
@@ -0,0 +1,302 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-openapi/spec"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+)
+
+// Codes of the warnings reported for the defects of the API definitions which are tolerated by the parser.
+// The codes should not be changed, as those are used by the tooling to suppress the known warnings.
+const (
+	// ParseWarningMissingOperationID is reported for the operations without an operationId.
+	ParseWarningMissingOperationID = "MISSING_OPERATION_ID"
+	// ParseWarningUnusedComponent is reported for the schemas in the components (OpenAPI v3) or the definitions
+	// (OpenAPI v2) which are not referred from anywhere in the definition.
+	ParseWarningUnusedComponent = "UNUSED_COMPONENT"
+	// ParseWarningInvalidFormat is reported for the schemas having a format which is not applicable to their type.
+	ParseWarningInvalidFormat = "INVALID_FORMAT"
+	// ParseWarningUnmatchedPublicPath is reported for the public path patterns of the x-wso2-public-paths extension
+	// which do not match any resource of the API.
+	ParseWarningUnmatchedPublicPath = "UNMATCHED_PUBLIC_PATH"
+	// ParseWarningUnsupportedFeature is reported for the features of the API project which are not supported, and
+	// hence ignored when the API is deployed.
+	ParseWarningUnsupportedFeature = "UNSUPPORTED_FEATURE"
+	// ParseWarningSchemeMismatch is reported for the endpoints whose scheme is not listed in the schemes of the API
+	// definition (OpenAPI v2) applicable to the endpoints.
+	ParseWarningSchemeMismatch = "SCHEME_MISMATCH"
+	// ParseWarningUnprotectedBackend is reported for the non-public APIs which neither authenticate the clients nor
+	// authenticate to the backends, hence the backends are fully exposed through the API.
+	ParseWarningUnprotectedBackend = "UNPROTECTED_BACKEND"
+	// ParseWarningUnresolvedEndpointHost is reported for the endpoints whose hosts cannot be resolved by the endpoint
+	// guard.
+	ParseWarningUnresolvedEndpointHost = "UNRESOLVED_ENDPOINT_HOST"
+	// ParseWarningIneffectiveDecompression is reported for the APIs decompressing the responses of the backends,
+	// while none of their interceptors or policies read the response body.
+	ParseWarningIneffectiveDecompression = "INEFFECTIVE_DECOMPRESSION"
+	// ParseWarningDisallowedVaryHeader is reported for the cache vary headers of the operations which are not allowed
+	// by the cache filter of the router, hence the responses of the operations are not cached.
+	ParseWarningDisallowedVaryHeader = "DISALLOWED_VARY_HEADER"
+)
+
+// ParseWarning is a defect of the API definition which does not fail the deployment of the API.
+type ParseWarning struct {
+	Code    string
+	Message string
+}
+
+var (
+	oas3SchemaRefRegex = regexp.MustCompile(`"#/components/schemas/([^"]+)"`)
+	oas2SchemaRefRegex = regexp.MustCompile(`"#/definitions/([^"]+)"`)
+	// formats applicable to each schema type. Any format is applicable to the strings, except the numeric formats.
+	numericFormats = map[string][]string{
+		"integer": {"int32", "int64"},
+		"number":  {"float", "double", "int32", "int64"},
+	}
+)
+
+// GetParseWarnings returns the warnings reported while parsing the API definition.
+func (swagger *MgwSwagger) GetParseWarnings() []ParseWarning {
+	return swagger.parseWarnings
+}
+
+// ValidateParseWarnings returns an error if any of the parse warnings has one of the given codes.
+func (swagger *MgwSwagger) ValidateParseWarnings(failOnCodes []string) error {
+	for _, warning := range swagger.parseWarnings {
+		if arrayContains(failOnCodes, warning.Code) {
+			return fmt.Errorf("API definition of the API %v:%v has the warning %v. %v", swagger.title,
+				swagger.version, warning.Code, warning.Message)
+		}
+	}
+	return nil
+}
+
+func (swagger *MgwSwagger) addParseWarning(code, format string, args ...interface{}) {
+	warning := ParseWarning{Code: code, Message: fmt.Sprintf(format, args...)}
+	logger.LoggerOasparser.Debugf("Parse warning %v in the API %v:%v. %v", code, swagger.title, swagger.version,
+		warning.Message)
+	swagger.parseWarnings = append(swagger.parseWarnings, warning)
+}
+
+// validateSchemaFormat reports a warning if the format is not applicable to the type of the schema.
+func (swagger *MgwSwagger) validateSchemaFormat(schemaType, format, location string) {
+	if schemaType == "" || format == "" {
+		return
+	}
+	isApplicable := false
+	switch schemaType {
+	case "integer", "number":
+		isApplicable = arrayContains(numericFormats[schemaType], format)
+	case "string":
+		isApplicable = !arrayContains(numericFormats["number"], format)
+	}
+	if !isApplicable {
+		swagger.addParseWarning(ParseWarningInvalidFormat, "format %q is not applicable to the type %q of %v",
+			format, schemaType, location)
+	}
+}
+
+// collectParseWarningsOpenAPI collects the warnings of the openAPI v3 definition.
+func (swagger *MgwSwagger) collectParseWarningsOpenAPI(swagger3 openapi3.Swagger) {
+	visited := make(map[*openapi3.Schema]struct{})
+	var validateSchema func(schemaRef *openapi3.SchemaRef, location string)
+	validateSchema = func(schemaRef *openapi3.SchemaRef, location string) {
+		if schemaRef == nil || schemaRef.Value == nil {
+			return
+		}
+		// referred schemas are validated in the components
+		if schemaRef.Ref != "" {
+			return
+		}
+		schema := schemaRef.Value
+		if _, found := visited[schema]; found {
+			return
+		}
+		visited[schema] = struct{}{}
+		swagger.validateSchemaFormat(schema.Type, schema.Format, location)
+		for _, name := range getSortedMapKeys(schema.Properties) {
+			validateSchema(schema.Properties[name], location+".properties."+name)
+		}
+		validateSchema(schema.Items, location+".items")
+		validateSchema(schema.AdditionalProperties, location+".additionalProperties")
+		validateSchema(schema.Not, location+".not")
+		for i, subSchema := range schema.AllOf {
+			validateSchema(subSchema, fmt.Sprintf("%v.allOf[%d]", location, i))
+		}
+		for i, subSchema := range schema.AnyOf {
+			validateSchema(subSchema, fmt.Sprintf("%v.anyOf[%d]", location, i))
+		}
+		for i, subSchema := range schema.OneOf {
+			validateSchema(subSchema, fmt.Sprintf("%v.oneOf[%d]", location, i))
+		}
+	}
+
+	for _, path := range getSortedMapKeys(swagger3.Paths) {
+		pathItem := swagger3.Paths[path]
+		operations := pathItem.Operations()
+		for _, method := range getSortedMapKeys(operations) {
+			operation := operations[method]
+			if operation == nil {
+				continue
+			}
+			location := fmt.Sprintf("the operation %v %v", method, path)
+			if operation.OperationID == "" {
+				swagger.addParseWarning(ParseWarningMissingOperationID, "operationId is not defined for %v", location)
+			}
+			for _, parameter := range append(append(openapi3.Parameters{}, pathItem.Parameters...), operation.Parameters...) {
+				if parameter != nil && parameter.Value != nil {
+					validateSchema(parameter.Value.Schema, fmt.Sprintf("the parameter %v of %v", parameter.Value.Name,
+						location))
+				}
+			}
+			if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+				content := operation.RequestBody.Value.Content
+				for _, mediaType := range getSortedMapKeys(content) {
+					validateSchema(content[mediaType].Schema, fmt.Sprintf("the %v request body of %v", mediaType,
+						location))
+				}
+			}
+		}
+	}
+	for _, name := range getSortedMapKeys(swagger3.Components.Schemas) {
+		validateSchema(swagger3.Components.Schemas[name], "components.schemas."+name)
+	}
+
+	if len(swagger3.Components.Schemas) == 0 {
+		return
+	}
+	definition, err := json.Marshal(swagger3)
+	if err != nil {
+		logger.LoggerOasparser.Debugf("Unused components of the API %v:%v are not checked. %v", swagger.title,
+			swagger.version, err)
+		return
+	}
+	swagger.collectUnusedComponentWarnings(getSortedMapKeys(swagger3.Components.Schemas), definition,
+		oas3SchemaRefRegex, "components.schemas.")
+}
+
+// collectParseWarningsSwagger collects the warnings of the openAPI v2 (swagger) definition.
+func (swagger *MgwSwagger) collectParseWarningsSwagger(swagger2 spec.Swagger) {
+	var validateSchema func(schema *spec.Schema, location string)
+	validateSchema = func(schema *spec.Schema, location string) {
+		// referred schemas are validated in the definitions
+		if schema == nil || schema.Ref.String() != "" {
+			return
+		}
+		if len(schema.Type) == 1 {
+			swagger.validateSchemaFormat(schema.Type[0], schema.Format, location)
+		}
+		for _, name := range getSortedMapKeys(schema.Properties) {
+			property := schema.Properties[name]
+			validateSchema(&property, location+".properties."+name)
+		}
+		if schema.Items != nil {
+			validateSchema(schema.Items.Schema, location+".items")
+			for i := range schema.Items.Schemas {
+				validateSchema(&schema.Items.Schemas[i], fmt.Sprintf("%v.items[%d]", location, i))
+			}
+		}
+		if schema.AdditionalProperties != nil {
+			validateSchema(schema.AdditionalProperties.Schema, location+".additionalProperties")
+		}
+		for i := range schema.AllOf {
+			validateSchema(&schema.AllOf[i], fmt.Sprintf("%v.allOf[%d]", location, i))
+		}
+	}
+
+	if swagger2.Paths != nil {
+		for _, path := range getSortedMapKeys(swagger2.Paths.Paths) {
+			pathItem := swagger2.Paths.Paths[path]
+			operations := map[string]*spec.Operation{"GET": pathItem.Get, "PUT": pathItem.Put, "POST": pathItem.Post,
+				"DELETE": pathItem.Delete, "OPTIONS": pathItem.Options, "HEAD": pathItem.Head, "PATCH": pathItem.Patch}
+			for _, method := range getSortedMapKeys(operations) {
+				operation := operations[method]
+				if operation == nil {
+					continue
+				}
+				location := fmt.Sprintf("the operation %v %v", method, path)
+				if operation.ID == "" {
+					swagger.addParseWarning(ParseWarningMissingOperationID, "operationId is not defined for %v",
+						location)
+				}
+				for _, parameter := range append(append([]spec.Parameter{}, pathItem.Parameters...),
+					operation.Parameters...) {
+					parameterLocation := fmt.Sprintf("the parameter %v of %v", parameter.Name, location)
+					if parameter.Schema != nil {
+						validateSchema(parameter.Schema, parameterLocation)
+					} else {
+						swagger.validateSchemaFormat(parameter.Type, parameter.Format, parameterLocation)
+					}
+				}
+			}
+		}
+	}
+	for _, name := range getSortedMapKeys(swagger2.Definitions) {
+		definition := swagger2.Definitions[name]
+		validateSchema(&definition, "definitions."+name)
+	}
+
+	if len(swagger2.Definitions) == 0 {
+		return
+	}
+	definition, err := json.Marshal(swagger2)
+	if err != nil {
+		logger.LoggerOasparser.Debugf("Unused definitions of the API %v:%v are not checked. %v", swagger.title,
+			swagger.version, err)
+		return
+	}
+	swagger.collectUnusedComponentWarnings(getSortedMapKeys(swagger2.Definitions), definition, oas2SchemaRefRegex,
+		"definitions.")
+}
+
+// collectUnusedComponentWarnings reports a warning for each of the given schemas, which is not referred from
+// anywhere in the definition.
+func (swagger *MgwSwagger) collectUnusedComponentWarnings(schemaNames []string, definition []byte,
+	refRegex *regexp.Regexp, locationPrefix string) {
+	referredSchemas := make(map[string]struct{})
+	for _, match := range refRegex.FindAllSubmatch(definition, -1) {
+		referredSchemas[strings.ReplaceAll(strings.ReplaceAll(string(match[1]), "~1", "/"), "~0", "~")] = struct{}{}
+	}
+	for _, name := range schemaNames {
+		if _, found := referredSchemas[name]; !found {
+			swagger.addParseWarning(ParseWarningUnusedComponent, "%v%v is not referred from the API definition",
+				locationPrefix, name)
+		}
+	}
+}
+
+// getSortedMapKeys returns the sorted keys of a map having string keys, so that the warnings are reported in the
+// same order for the same definition.
+func getSortedMapKeys(stringKeyedMap interface{}) []string {
+	mapKeys := reflect.ValueOf(stringKeyedMap).MapKeys()
+	keys := make([]string, 0, len(mapKeys))
+	for _, key := range mapKeys {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWarningsOpenAPI(t *testing.T) {
+	definition := `{
+  "openapi": "3.0.1",
+  "info": {"title": "PetStore", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer", "format": "date"}}],
+        "responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pets"}}}}}
+      },
+      "post": {
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+        "responses": {"201": {"description": "Created"}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {"type": "object", "properties": {"id": {"type": "string", "format": "int64"}, "name": {"type": "string", "format": "email"}}},
+      "Pets": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}},
+      "Error": {"type": "object", "properties": {"code": {"type": "integer", "format": "int32"}}}
+    }
+  }
+}`
+	var mgwSwagger MgwSwagger
+	err := mgwSwagger.GetMgwSwagger([]byte(definition))
+	assert.Nil(t, err, "Error while parsing the API definition")
+	assert.Equal(t, []ParseWarning{
+		{Code: ParseWarningMissingOperationID, Message: "operationId is not defined for the operation POST /pets"},
+		{Code: ParseWarningInvalidFormat,
+			Message: `format "date" is not applicable to the type "integer" of the parameter limit of the operation GET /pets`},
+		{Code: ParseWarningInvalidFormat,
+			Message: `format "int64" is not applicable to the type "string" of components.schemas.Pet.properties.id`},
+		{Code: ParseWarningUnusedComponent, Message: "components.schemas.Error is not referred from the API definition"},
+	}, sortParseWarningsByCode(mgwSwagger.GetParseWarnings()))
+
+	assert.Nil(t, mgwSwagger.ValidateParseWarnings(nil), "Warnings should not fail the API by default")
+	assert.Nil(t, mgwSwagger.ValidateParseWarnings([]string{"UNKNOWN_WARNING"}))
+	assert.NotNil(t, mgwSwagger.ValidateParseWarnings([]string{ParseWarningUnusedComponent}),
+		"Warnings with the given codes should fail the API")
+}
+
+func TestParseWarningsSwagger(t *testing.T) {
+	definition := `{
+  "swagger": "2.0",
+  "info": {"title": "PetStore", "version": "1.0.0"},
+  "paths": {
+    "/pets/{petId}": {
+      "parameters": [{"name": "petId", "in": "path", "required": true, "type": "number", "format": "uuid"}],
+      "get": {
+        "operationId": "getPet",
+        "responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/Pet"}}}
+      },
+      "delete": {
+        "operationId": "deletePet",
+        "responses": {"200": {"description": "OK"}}
+      }
+    }
+  },
+  "definitions": {
+    "Pet": {"type": "object", "properties": {"id": {"type": "integer", "format": "int64"}}},
+    "Owner": {"type": "object", "properties": {"age": {"type": "boolean", "format": "int32"}}}
+  }
+}`
+	var mgwSwagger MgwSwagger
+	err := mgwSwagger.GetMgwSwagger([]byte(definition))
+	assert.Nil(t, err, "Error while parsing the API definition")
+	assert.Equal(t, []ParseWarning{
+		{Code: ParseWarningInvalidFormat,
+			Message: `format "uuid" is not applicable to the type "number" of the parameter petId of the operation DELETE /pets/{petId}`},
+		{Code: ParseWarningInvalidFormat,
+			Message: `format "uuid" is not applicable to the type "number" of the parameter petId of the operation GET /pets/{petId}`},
+		{Code: ParseWarningInvalidFormat,
+			Message: `format "int32" is not applicable to the type "boolean" of definitions.Owner.properties.age`},
+		{Code: ParseWarningUnusedComponent, Message: "definitions.Owner is not referred from the API definition"},
+	}, sortParseWarningsByCode(mgwSwagger.GetParseWarnings()))
+}
+
+// sortParseWarningsByCode sorts the warnings by the code, retaining the order of the warnings having the same code.
+func sortParseWarningsByCode(warnings []ParseWarning) []ParseWarning {
+	codeOrder := []string{ParseWarningMissingOperationID, ParseWarningInvalidFormat, ParseWarningUnusedComponent}
+	var sortedWarnings []ParseWarning
+	for _, code := range codeOrder {
+		for _, warning := range warnings {
+			if warning.Code == code {
+				sortedWarnings = append(sortedWarnings, warning)
+			}
+		}
+	}
+	return sortedWarnings
+}
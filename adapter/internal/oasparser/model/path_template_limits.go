@@ -0,0 +1,68 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+// pathTemplateParamRegex matches the path parameters of a path template, the same way as those are replaced with the
+// capture groups of the route regexes
+var pathTemplateParamRegex = regexp.MustCompile(`{([^}]+)}`)
+
+// validatePathTemplates returns an error quoting the path template of the first resource exceeding the maximum number
+// of path parameters, the maximum number of path segments or the maximum length of the path templates. The limits
+// set to 0 are not checked.
+func (swagger *MgwSwagger) validatePathTemplates() error {
+	conf, _ := config.ReadConfigs()
+	limits := conf.Adapter.PathTemplateLimits
+	for _, resource := range swagger.resources {
+		pathTemplate := resource.path
+		if limits.MaxTemplateLength > 0 && len(pathTemplate) > limits.MaxTemplateLength {
+			return fmt.Errorf("path template %q is %d characters long, which exceeds the maximum of %d characters",
+				pathTemplate, len(pathTemplate), limits.MaxTemplateLength)
+		}
+		if paramCount := len(pathTemplateParamRegex.FindAllString(pathTemplate, -1)); limits.MaxPathParameters > 0 &&
+			paramCount > limits.MaxPathParameters {
+			return fmt.Errorf("path template %q has %d path parameters, which exceeds the maximum of %d path parameters",
+				pathTemplate, paramCount, limits.MaxPathParameters)
+		}
+		if segmentCount := countPathSegments(pathTemplate); limits.MaxPathSegments > 0 &&
+			segmentCount > limits.MaxPathSegments {
+			return fmt.Errorf("path template %q has %d path segments, which exceeds the maximum of %d path segments",
+				pathTemplate, segmentCount, limits.MaxPathSegments)
+		}
+	}
+	return nil
+}
+
+// countPathSegments returns the number of non empty segments of the path template, excluding its query.
+func countPathSegments(pathTemplate string) int {
+	pathTemplate = strings.SplitN(pathTemplate, "?", 2)[0]
+	count := 0
+	for _, segment := range strings.Split(pathTemplate, "/") {
+		if segment != "" {
+			count++
+		}
+	}
+	return count
+}
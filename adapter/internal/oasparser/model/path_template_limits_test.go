@@ -0,0 +1,86 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+func TestValidatePathTemplates(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousLimits := conf.Adapter.PathTemplateLimits
+	defer func() {
+		conf.Adapter.PathTemplateLimits = previousLimits
+	}()
+	conf.Adapter.PathTemplateLimits.MaxPathParameters = 3
+	conf.Adapter.PathTemplateLimits.MaxPathSegments = 4
+	conf.Adapter.PathTemplateLimits.MaxTemplateLength = 40
+
+	tests := []struct {
+		name          string
+		path          string
+		errorContains string
+	}{
+		{
+			name: "Path parameters at the limit",
+			path: "/stores/{storeId}/{petId}/{ownerId}",
+		},
+		{
+			name:          "Path parameters past the limit",
+			path:          "/{storeId}/{petId}/{ownerId}/{tagId}",
+			errorContains: `path template "/{storeId}/{petId}/{ownerId}/{tagId}" has 4 path parameters`,
+		},
+		{
+			name: "Path segments at the limit",
+			path: "/stores/pets/owners/tags/",
+		},
+		{
+			name:          "Path segments past the limit",
+			path:          "/stores/pets/owners/tags/names",
+			errorContains: `path template "/stores/pets/owners/tags/names" has 5 path segments`,
+		},
+		{
+			name: "Template length at the limit",
+			path: "/" + strings.Repeat("a", 39),
+		},
+		{
+			name:          "Template length past the limit",
+			path:          "/" + strings.Repeat("a", 40),
+			errorContains: `path template "/` + strings.Repeat("a", 40) + `" is 41 characters long`,
+		},
+	}
+	for _, test := range tests {
+		mgwSwagger := MgwSwagger{resources: []*Resource{{path: "/pets"}, {path: test.path}}}
+		err := mgwSwagger.validatePathTemplates()
+		if test.errorContains == "" {
+			assert.Nil(t, err, test.name)
+		} else if assert.NotNil(t, err, test.name) {
+			assert.Contains(t, err.Error(), test.errorContains, test.name)
+		}
+	}
+
+	conf.Adapter.PathTemplateLimits.MaxPathParameters = 0
+	conf.Adapter.PathTemplateLimits.MaxPathSegments = 0
+	conf.Adapter.PathTemplateLimits.MaxTemplateLength = 0
+	mgwSwagger := MgwSwagger{resources: []*Resource{{path: "/{a}/{b}/{c}/{d}/{e}/" + strings.Repeat("a", 40)}}}
+	assert.Nil(t, mgwSwagger.validatePathTemplates(), "Limits set to 0 should not be checked")
+}
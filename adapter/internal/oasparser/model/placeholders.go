@@ -0,0 +1,188 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Names of the built-in placeholders, which are given as ${<name>} in the mock response bodies and the string
+// parameters of the operation policies. A placeholder is escaped by prefixing it with a backslash (\${<name>}).
+const (
+	// PlaceholderGatewayVhost is substituted with the vhost the API is deployed to.
+	PlaceholderGatewayVhost = "gw.vhost"
+	// PlaceholderAPIContext is substituted with the basepath the API is exposed on.
+	PlaceholderAPIContext = "api.context"
+	// PlaceholderAPIVersion is substituted with the version of the API.
+	PlaceholderAPIVersion = "api.version"
+	// PlaceholderEnvironmentName is substituted with the name of the gateway environment the API is deployed to.
+	PlaceholderEnvironmentName = "env.name"
+)
+
+// PlaceholderValues holds the values of the built-in placeholders for a deployment of an API.
+type PlaceholderValues struct {
+	Vhost           string
+	Context         string
+	Version         string
+	EnvironmentName string
+}
+
+// placeholderRegex matches the placeholders and the escaped placeholders
+var placeholderRegex = regexp.MustCompile(`\\?\$\{([^{}]*)\}`)
+
+func (values PlaceholderValues) getValue(name string) (string, bool) {
+	switch name {
+	case PlaceholderGatewayVhost:
+		return values.Vhost, true
+	case PlaceholderAPIContext:
+		return values.Context, true
+	case PlaceholderAPIVersion:
+		return values.Version, true
+	case PlaceholderEnvironmentName:
+		return values.EnvironmentName, true
+	}
+	return "", false
+}
+
+// resolve substitutes the placeholders in the text. An error is returned for the first unknown placeholder.
+func (values PlaceholderValues) resolve(text, location string) (string, error) {
+	if !strings.Contains(text, "${") {
+		return text, nil
+	}
+	var err error
+	resolvedText := placeholderRegex.ReplaceAllStringFunc(text, func(placeholder string) string {
+		if strings.HasPrefix(placeholder, `\`) {
+			return placeholder[1:]
+		}
+		value, found := values.getValue(placeholder[2 : len(placeholder)-1])
+		if !found && err == nil {
+			err = fmt.Errorf("unknown placeholder %v in %v", placeholder, location)
+		}
+		return value
+	})
+	return resolvedText, err
+}
+
+// resolveJSON substitutes the placeholders in the string values of the JSON document. The document is encoded again
+// only if it has placeholders, so that the escaping of the substituted values is handled by the JSON encoder.
+func (values PlaceholderValues) resolveJSON(document, location string) (string, error) {
+	if !strings.Contains(document, "${") {
+		return document, nil
+	}
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+	var content interface{}
+	if err := decoder.Decode(&content); err != nil {
+		return values.resolve(document, location)
+	}
+	var resolveValue func(value interface{}) (interface{}, error)
+	resolveValue = func(value interface{}) (interface{}, error) {
+		var err error
+		switch typedValue := value.(type) {
+		case string:
+			return values.resolve(typedValue, location)
+		case []interface{}:
+			for i := range typedValue {
+				if typedValue[i], err = resolveValue(typedValue[i]); err != nil {
+					return nil, err
+				}
+			}
+		case map[string]interface{}:
+			for key := range typedValue {
+				if typedValue[key], err = resolveValue(typedValue[key]); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return value, nil
+	}
+	content, err := resolveValue(content)
+	if err != nil {
+		return "", err
+	}
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err = encoder.Encode(content); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+// ResolvePlaceholders substitutes the built-in placeholders in the mock response bodies and the string parameters
+// of the operation policies of the API. This should be called after the API is validated, as the values differ for
+// each deployment of the API. An error with the location is returned if an unknown placeholder is found.
+func (swagger *MgwSwagger) ResolvePlaceholders(values PlaceholderValues) error {
+	for _, resource := range swagger.resources {
+		for _, operation := range resource.methods {
+			operationName := fmt.Sprintf("the operation %v %v", operation.method, resource.path)
+			if err := values.resolveMockedAPIConfig(operation, operationName); err != nil {
+				return err
+			}
+			policyLists := []PolicyList{operation.policies.Request, operation.policies.Response, operation.policies.Fault}
+			for _, policyList := range policyLists {
+				for _, policy := range policyList {
+					if err := values.resolvePolicyParameters(policy, operationName); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (values PlaceholderValues) resolveMockedAPIConfig(operation *Operation, operationName string) (err error) {
+	if operation.mockedAPIConfig == nil {
+		return nil
+	}
+	for _, response := range operation.mockedAPIConfig.Responses {
+		for _, content := range response.Content {
+			for _, example := range content.Examples {
+				location := fmt.Sprintf("the mock response %v (%v) of %v", response.Code, content.ContentType,
+					operationName)
+				if example.Body, err = values.resolveJSON(example.Body, location); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (values PlaceholderValues) resolvePolicyParameters(policy Policy, operationName string) (err error) {
+	parameters, isMap := policy.Parameters.(map[string]interface{})
+	if !isMap {
+		return nil
+	}
+	for name, value := range parameters {
+		stringValue, isString := value.(string)
+		if !isString {
+			continue
+		}
+		location := fmt.Sprintf("the parameter %v of the policy %v of %v", name, policy.PolicyName, operationName)
+		if parameters[name], err = values.resolve(stringValue, location); err != nil {
+			return err
+		}
+	}
+	return nil
+}
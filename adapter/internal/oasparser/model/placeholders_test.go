@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/api"
+)
+
+func getMgwSwaggerWithPlaceholders(mockBody, headerValue string) MgwSwagger {
+	operation := &Operation{
+		method: "GET",
+		mockedAPIConfig: &api.MockedApiConfig{
+			Responses: []*api.MockedResponseConfig{{
+				Code: "200",
+				Content: []*api.MockedContentConfig{{
+					ContentType: "application/json",
+					Examples:    []*api.MockedContentExample{{Body: mockBody}},
+				}},
+			}},
+		},
+		policies: OperationPolicies{Response: PolicyList{{
+			PolicyName: "addHeader",
+			Action:     constants.ActionHeaderAdd,
+			Parameters: map[string]interface{}{
+				constants.HeaderName:  "Link",
+				constants.HeaderValue: headerValue,
+			},
+		}}},
+	}
+	return MgwSwagger{resources: []*Resource{{path: "/pets", methods: []*Operation{operation}}}}
+}
+
+func TestResolvePlaceholders(t *testing.T) {
+	values := PlaceholderValues{
+		Vhost:           "us.wso2.com",
+		Context:         "/petstore/1.0.0",
+		Version:         "1.0.0",
+		EnvironmentName: "us-region",
+	}
+
+	swagger := getMgwSwaggerWithPlaceholders(
+		`{"count":10,"self":"https://${gw.vhost}${api.context}/pets","env":"${env.name}","raw":"\\${gw.vhost}"}`,
+		"<https://${gw.vhost}${api.context}/pets?page=2>; rel=\"next\"; version=${api.version}")
+	err := swagger.ResolvePlaceholders(values)
+	assert.Nil(t, err, "Built-in placeholders should be resolved")
+	operation := swagger.resources[0].methods[0]
+	assert.Equal(t,
+		`{"count":10,"env":"us-region","raw":"${gw.vhost}","self":"https://us.wso2.com/petstore/1.0.0/pets"}`,
+		operation.GetMockedAPIConfig().Responses[0].Content[0].Examples[0].Body)
+	assert.Equal(t, "<https://us.wso2.com/petstore/1.0.0/pets?page=2>; rel=\"next\"; version=1.0.0",
+		operation.GetPolicies().Response[0].Parameters.(map[string]interface{})[constants.HeaderValue])
+
+	swagger = getMgwSwaggerWithPlaceholders(`{"self":"https://gw.wso2.com/pets"}`, `\${gw.vhost} is escaped`)
+	err = swagger.ResolvePlaceholders(values)
+	assert.Nil(t, err, "Escaped placeholders should not be resolved")
+	operation = swagger.resources[0].methods[0]
+	assert.Equal(t, `{"self":"https://gw.wso2.com/pets"}`,
+		operation.GetMockedAPIConfig().Responses[0].Content[0].Examples[0].Body,
+		"Mock bodies without placeholders should not be changed")
+	assert.Equal(t, "${gw.vhost} is escaped",
+		operation.GetPolicies().Response[0].Parameters.(map[string]interface{})[constants.HeaderValue])
+
+	swagger = getMgwSwaggerWithPlaceholders(`{"self":"https://${gw.host}/pets"}`, "")
+	err = swagger.ResolvePlaceholders(values)
+	if assert.Error(t, err, "Unknown placeholders in mock bodies should be rejected") {
+		assert.Contains(t, err.Error(), "${gw.host}")
+		assert.Contains(t, err.Error(), "the mock response 200 (application/json) of the operation GET /pets")
+	}
+
+	swagger = getMgwSwaggerWithPlaceholders(`{}`, "${api.name}")
+	err = swagger.ResolvePlaceholders(values)
+	if assert.Error(t, err, "Unknown placeholders in policy parameters should be rejected") {
+		assert.Contains(t, err.Error(), "${api.name}")
+		assert.Contains(t, err.Error(), "the parameter headerValue of the policy addHeader of the operation GET /pets")
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"text/template"
 
 	"github.com/wso2/product-microgateway/adapter/internal/loggers"
@@ -239,6 +240,28 @@ func (spec *PolicySpecification) validatePolicy(policy Policy, flow PolicyFlow)
 	return nil
 }
 
+// validatePolicyCompatibility validates whether the policy can be applied to the given flow of an operation with the
+// given API type and HTTP method, using the policy specification and the policy actions supported by Choreo Connect
+func (p PolicyContainerMap) validatePolicyCompatibility(policy Policy, flow PolicyFlow, apiType, method string) error {
+	spec := p[policy.GetFullName()].Specification
+	if len(spec.Data.ApplicableFlows) > 0 && !arrayContains(spec.Data.ApplicableFlows, string(flow)) {
+		return fmt.Errorf("policy is only applicable to the flows %v", spec.Data.ApplicableFlows)
+	}
+	if len(spec.Data.SupportedAPITypes) > 0 {
+		apiTypeSupported := false
+		for _, supportedAPIType := range spec.Data.SupportedAPITypes {
+			if strings.EqualFold(supportedAPIType, apiType) {
+				apiTypeSupported = true
+				break
+			}
+		}
+		if !apiTypeSupported {
+			return fmt.Errorf("policy only supports the API types %v", spec.Data.SupportedAPITypes)
+		}
+	}
+	return validatePolicyActionCompatibility(policy, flow, apiType, method)
+}
+
 // fillDefaultsInPolicy updates the policy with default values defined in the spec if the key is not found in the policy
 func (spec *PolicySpecification) fillDefaultsInPolicy(policy *Policy) {
 	if paramMap, isMap := policy.Parameters.(map[string]interface{}); isMap {
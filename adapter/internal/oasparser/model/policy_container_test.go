@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
 )
 
 func TestPolicySpecificationValidatePolicy(t *testing.T) {
@@ -164,6 +166,238 @@ func TestAPIProjectGetFormattedPolicyFromTemplated(t *testing.T) {
 	assert.Equal(t, expFormattedP, actualFormattedP, "Converting operational policies to Choreo Connect format failed")
 }
 
+func TestValidateOperationPoliciesForWSAPI(t *testing.T) {
+	spec := getSampleTestPolicySpec()
+	spec.Data.ApplicableFlows = []string{"request", "response"}
+	proj := ProjectAPI{
+		Policies: map[string]PolicyContainer{
+			"fooAddRequestHeader_v1": {
+				Specification: spec,
+				Definition:    PolicyDefinition{RawData: getSampleTestPolicyDef()},
+			},
+		},
+	}
+	proj.APIYaml.Data.Operations = []OperationYaml{
+		{
+			Target: "/notifications",
+			Verb:   "SUBSCRIBE",
+			OperationPolicies: OperationPolicies{
+				Response: PolicyList{
+					{
+						PolicyName:    "fooAddRequestHeader",
+						PolicyVersion: "v1",
+						Parameters:    map[string]interface{}{"fooName": "fooHeaderName", "fooValue": "fooHeaderValue"},
+					},
+				},
+			},
+		},
+	}
+	swagger := &MgwSwagger{apiType: constants.WS}
+	err := swagger.ValidateOperationPolicies(proj)
+	if assert.Error(t, err, "Response policies should not be applied to WS APIs") {
+		for _, expected := range []string{"fooAddRequestHeader_v1", "response", "SUBSCRIBE", "/notifications"} {
+			assert.Contains(t, err.Error(), expected)
+		}
+	}
+
+	conf, _ := config.ReadConfigs()
+	conf.Adapter.StrictPolicyCompatibility = false
+	defer func() {
+		conf.Adapter.StrictPolicyCompatibility = true
+	}()
+	assert.Nil(t, swagger.ValidateOperationPolicies(proj),
+		"Incompatible policies should only be warned when the strict policy compatibility is disabled")
+
+	conf.Adapter.StrictPolicyCompatibility = true
+	proj.APIYaml.Data.Operations[0].OperationPolicies = OperationPolicies{}
+	assert.Nil(t, swagger.ValidateOperationPolicies(proj), "Operations without policies should be valid")
+}
+
+func TestSetOperationPoliciesWithRequestBodyPolicy(t *testing.T) {
+	spec := PolicySpecification{}
+	spec.Data.Name = "fooCallInterceptor"
+	spec.Data.Version = "v1"
+	spec.Data.ApplicableFlows = []string{"request", "response"}
+	spec.Data.SupportedGateways = []string{"ChoreoConnect"}
+	spec.Data.SupportedAPITypes = []string{"HTTP"}
+	policy := Policy{
+		PolicyName:    "fooCallInterceptor",
+		PolicyVersion: "v1",
+		Parameters:    map[string]interface{}{"includes": "request_headers,request_body"},
+	}
+	proj := ProjectAPI{
+		Policies: map[string]PolicyContainer{
+			"fooCallInterceptor_v1": {
+				Specification: spec,
+				Definition: PolicyDefinition{RawData: []byte(`
+definition:
+  action: CALL_INTERCEPTOR_SERVICE
+  parameters:
+    interceptorServiceURL: https://interceptor:8443
+    includes: {{ .includes }}
+`)},
+			},
+		},
+	}
+
+	for _, test := range []struct {
+		method     string
+		isExpError bool
+		message    string
+	}{
+		{"GET", true, "Request body policy should not be applied to a GET operation"},
+		{"POST", false, "Request body policy should be applied to a POST operation"},
+	} {
+		proj.APIYaml.Data.Operations = []OperationYaml{
+			{Target: "/pets", Verb: test.method, OperationPolicies: OperationPolicies{Request: PolicyList{policy}}},
+		}
+		swagger := &MgwSwagger{
+			resources: []*Resource{{path: "/pets", methods: []*Operation{NewOperation(test.method, nil, nil)}}},
+		}
+		err := swagger.SetOperationPolicies(proj)
+		if test.isExpError {
+			if assert.Error(t, err, test.message) {
+				assert.Contains(t, err.Error(), "request flow of the operation GET /pets")
+			}
+		} else {
+			assert.Nil(t, err, test.message)
+		}
+	}
+}
+
+func TestSetOperationPoliciesWithPayloadTransformationPolicies(t *testing.T) {
+	getPayloadPolicyContainer := func(name, action string) PolicyContainer {
+		spec := PolicySpecification{}
+		spec.Data.Name = name
+		spec.Data.Version = "v1"
+		spec.Data.ApplicableFlows = []string{"request", "response"}
+		spec.Data.SupportedGateways = []string{"ChoreoConnect"}
+		spec.Data.SupportedAPITypes = []string{"HTTP"}
+		return PolicyContainer{
+			Specification: spec,
+			Definition: PolicyDefinition{RawData: []byte(`
+definition:
+  action: ` + action + `
+  parameters:
+  {{- range $name, $value := . }}
+    {{ $name }}: {{ $value }}
+  {{- end }}
+`)},
+		}
+	}
+	proj := ProjectAPI{
+		Policies: map[string]PolicyContainer{
+			"payloadToXML_v1":  getPayloadPolicyContainer("payloadToXML", constants.ActionPayloadToXML),
+			"payloadToJSON_v1": getPayloadPolicyContainer("payloadToJSON", constants.ActionPayloadToJSON),
+		},
+	}
+	payloadToXML := func(params map[string]interface{}) Policy {
+		return Policy{PolicyName: "payloadToXML", PolicyVersion: "v1", Parameters: params}
+	}
+	payloadToJSON := func(params map[string]interface{}) Policy {
+		return Policy{PolicyName: "payloadToJSON", PolicyVersion: "v1", Parameters: params}
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		consumes []string
+		produces []string
+		policies OperationPolicies
+		expError string
+	}{
+		{
+			name:     "JSON request transformed to XML in the request flow",
+			method:   "POST",
+			consumes: []string{"application/json"},
+			policies: OperationPolicies{Request: PolicyList{
+				payloadToXML(map[string]interface{}{"rootElementName": "soap:Envelope", "attributePrefix": "_"})}},
+		},
+		{
+			name:     "XML response transformed to JSON in the response flow",
+			method:   "GET",
+			produces: []string{"application/problem+json", "application/json; charset=utf-8"},
+			policies: OperationPolicies{Response: PolicyList{
+				payloadToJSON(map[string]interface{}{"ignoreAttributes": "true"})}},
+		},
+		{
+			name:     "Media types are not validated when those are not declared",
+			method:   "PUT",
+			policies: OperationPolicies{Request: PolicyList{payloadToJSON(nil)}, Response: PolicyList{payloadToXML(nil)}},
+		},
+		{
+			name:     "Request payload of the operation is not JSON",
+			method:   "POST",
+			consumes: []string{"application/xml"},
+			policies: OperationPolicies{Request: PolicyList{payloadToXML(nil)}},
+			expError: "requires JSON payloads in the request flow",
+		},
+		{
+			name:     "Response payload of the operation is not XML",
+			method:   "GET",
+			produces: []string{"application/json"},
+			policies: OperationPolicies{Response: PolicyList{payloadToXML(nil)}},
+			expError: "requires XML payloads in the response flow",
+		},
+		{
+			name:     "Both transformations in the same flow",
+			method:   "POST",
+			policies: OperationPolicies{Request: PolicyList{payloadToXML(nil), payloadToJSON(nil)}},
+			expError: "cannot be combined in the request flow",
+		},
+		{
+			name:     "Invalid root element name",
+			method:   "POST",
+			policies: OperationPolicies{Request: PolicyList{payloadToXML(map[string]interface{}{"rootElementName": "1st"})}},
+			expError: "should be a valid XML element name",
+		},
+		{
+			name:     "Root element name is not applicable to the XML to JSON transformation",
+			method:   "GET",
+			policies: OperationPolicies{Response: PolicyList{payloadToJSON(map[string]interface{}{"rootElementName": "pets"})}},
+			expError: "only applicable to the policy action \"PAYLOAD_TO_XML\"",
+		},
+		{
+			name:   "Attribute prefix along with ignored attributes",
+			method: "GET",
+			policies: OperationPolicies{Response: PolicyList{
+				payloadToJSON(map[string]interface{}{"ignoreAttributes": "true", "attributePrefix": "_"})}},
+			expError: "cannot be used when the attributes are ignored",
+		},
+		{
+			name:     "Request payload transformation of a bodyless operation",
+			method:   "GET",
+			policies: OperationPolicies{Request: PolicyList{payloadToXML(nil)}},
+			expError: "request flow of the operation GET /pets",
+		},
+	}
+
+	for _, test := range tests {
+		proj.APIYaml.Data.Operations = []OperationYaml{
+			{Target: "/pets", Verb: test.method, OperationPolicies: test.policies},
+		}
+		operation := NewOperation(test.method, nil, nil)
+		operation.consumes = test.consumes
+		operation.produces = test.produces
+		swagger := &MgwSwagger{resources: []*Resource{{path: "/pets", methods: []*Operation{operation}}}}
+		err := swagger.SetOperationPolicies(proj)
+		if test.expError != "" {
+			if assert.Error(t, err, test.name) {
+				assert.Contains(t, err.Error(), test.expError, test.name)
+			}
+			continue
+		}
+		if !assert.Nil(t, err, test.name) {
+			continue
+		}
+		for _, policy := range append(operation.GetPolicies().Request, operation.GetPolicies().Response...) {
+			assert.True(t, policy.IsPassToEnforcer, "%s: payload should be transformed by the enforcer", test.name)
+			assert.Contains(t, []string{constants.ActionPayloadToXML, constants.ActionPayloadToJSON}, policy.Action,
+				test.name)
+		}
+	}
+}
+
 func getSampleTestPolicySpec() PolicySpecification {
 	spec := PolicySpecification{}
 	spec.Data.Name = "fooAddRequestHeader"
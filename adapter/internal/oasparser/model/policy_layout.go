@@ -20,49 +20,97 @@ package model
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
 )
 
+var (
+	// allPolicyFlows are all the flows in which the policies are applied
+	allPolicyFlows = []PolicyFlow{policyInFlow, policyOutFlow, policyFaultFlow}
+	// requestAndResponseFlows are the flows in which the policies are applied by the router as well as the enforcer
+	requestAndResponseFlows = []PolicyFlow{policyInFlow, policyOutFlow}
+	// requestFlowOnly is for the policies which only change the request sent to the backend
+	requestFlowOnly = []PolicyFlow{policyInFlow}
+	// policyAPITypes are the API types of which the operation policies are applied by Choreo Connect
+	policyAPITypes = []string{constants.HTTP}
+	// bodylessMethods are the HTTP methods of which the request body does not have defined semantics
+	bodylessMethods = []string{"GET", "HEAD", "DELETE", "TRACE"}
+)
+
 // supportedPoliciesMap maps (policy action name) -> (policy layout)
 var supportedPoliciesMap = map[string]policyLayout{
 	constants.ActionHeaderAdd: {
-		RequiredParams:   []string{constants.HeaderName, constants.HeaderValue},
-		IsPassToEnforcer: false,
+		RequiredParams:    []string{constants.HeaderName, constants.HeaderValue},
+		IsPassToEnforcer:  false,
+		ApplicableFlows:   allPolicyFlows,
+		SupportedAPITypes: policyAPITypes,
 	},
 	constants.ActionHeaderRemove: {
-		RequiredParams:   []string{constants.HeaderName},
-		IsPassToEnforcer: false,
+		RequiredParams:    []string{constants.HeaderName},
+		IsPassToEnforcer:  false,
+		ApplicableFlows:   allPolicyFlows,
+		SupportedAPITypes: policyAPITypes,
 	},
 	"ADD_QUERY": {
-		RequiredParams:   []string{"queryParamName", "queryParamValue"},
-		IsPassToEnforcer: true,
+		RequiredParams:    []string{"queryParamName", "queryParamValue"},
+		IsPassToEnforcer:  true,
+		ApplicableFlows:   requestFlowOnly,
+		SupportedAPITypes: policyAPITypes,
 	},
 	constants.ActionInterceptorService: {
-		RequiredParams:   []string{constants.InterceptorServiceURL, constants.InterceptorServiceIncludes},
-		IsPassToEnforcer: false,
+		RequiredParams:    []string{constants.InterceptorServiceURL, constants.InterceptorServiceIncludes},
+		IsPassToEnforcer:  false,
+		ApplicableFlows:   requestAndResponseFlows,
+		SupportedAPITypes: policyAPITypes,
 	},
 	constants.ActionRewriteMethod: {
-		RequiredParams:   []string{constants.UpdatedMethod},
-		IsPassToEnforcer: true,
+		RequiredParams:    []string{constants.UpdatedMethod},
+		IsPassToEnforcer:  true,
+		ApplicableFlows:   requestFlowOnly,
+		SupportedAPITypes: policyAPITypes,
 	},
 	constants.ActionRewritePath: {
-		RequiredParams:   []string{constants.RewritePathResourcePath, constants.IncludeQueryParams},
-		IsPassToEnforcer: true,
+		RequiredParams:    []string{constants.RewritePathResourcePath, constants.IncludeQueryParams},
+		IsPassToEnforcer:  true,
+		ApplicableFlows:   requestFlowOnly,
+		SupportedAPITypes: policyAPITypes,
+	},
+	constants.ActionPayloadToXML: {
+		// Following parameters are not required (optional)
+		// "rootElementName", "attributePrefix"
+		RequiredParams:    []string{},
+		IsPassToEnforcer:  true,
+		ApplicableFlows:   requestAndResponseFlows,
+		SupportedAPITypes: policyAPITypes,
+	},
+	constants.ActionPayloadToJSON: {
+		// Following parameters are not required (optional)
+		// "attributePrefix", "ignoreAttributes"
+		RequiredParams:    []string{},
+		IsPassToEnforcer:  true,
+		ApplicableFlows:   requestAndResponseFlows,
+		SupportedAPITypes: policyAPITypes,
 	},
 	"OPA": {
 		// Following parameters are not required (optional)
 		// "rule", token", "additionalProperties", "sendAccessToken", "maxOpenConnections", "maxPerRoute"
 		// "connectionTimeout", "requestGenerator"
-		RequiredParams:   []string{"serverURL", "policy"},
-		IsPassToEnforcer: true,
+		RequiredParams:    []string{"serverURL", "policy"},
+		IsPassToEnforcer:  true,
+		ApplicableFlows:   requestFlowOnly,
+		SupportedAPITypes: policyAPITypes,
 	},
 }
 
 // PolicyLayout holds the layout of policy that support by Choreo Connect
 type policyLayout struct {
-	RequiredParams   []string
-	IsPassToEnforcer bool
+	RequiredParams    []string
+	IsPassToEnforcer  bool
+	ApplicableFlows   []PolicyFlow
+	SupportedAPITypes []string
 }
 
 // validatePolicyAction validates policy against the policy definition that supported by Choreo Connect
@@ -83,3 +131,157 @@ func validatePolicyAction(policy *Policy) error {
 	}
 	return nil
 }
+
+// validatePolicyActionCompatibility validates whether the policy action is supported by Choreo Connect for the given
+// flow of an operation with the given API type and HTTP method. Policies without a resolved action are not validated.
+func validatePolicyActionCompatibility(policy Policy, flow PolicyFlow, apiType, method string) error {
+	layout, ok := supportedPoliciesMap[policy.Action]
+	if !ok {
+		return nil
+	}
+	flowSupported := false
+	for _, applicableFlow := range layout.ApplicableFlows {
+		if applicableFlow == flow {
+			flowSupported = true
+			break
+		}
+	}
+	if !flowSupported {
+		return fmt.Errorf("policy action %q is not supported in the %s flow", policy.Action, flow)
+	}
+	if !arrayContains(layout.SupportedAPITypes, strings.ToUpper(apiType)) {
+		return fmt.Errorf("policy action %q is not supported for %s APIs", policy.Action, apiType)
+	}
+	if flow == policyInFlow && arrayContains(bodylessMethods, strings.ToUpper(method)) && isRequestBodyPolicy(policy) {
+		return fmt.Errorf("policy action %q processes the request body, which is not expected for %s requests",
+			policy.Action, method)
+	}
+	return nil
+}
+
+// isRequestBodyPolicy checks whether the policy reads or modifies the request body
+func isRequestBodyPolicy(policy Policy) bool {
+	if isPayloadTransformationPolicy(policy) {
+		return true
+	}
+	if policy.Action != constants.ActionInterceptorService {
+		return false
+	}
+	if params, isMap := policy.Parameters.(map[string]interface{}); isMap {
+		if includes, isString := params[constants.InterceptorServiceIncludes].(string); isString {
+			return GenerateInterceptorIncludes(strings.Split(includes, ",")).RequestBody
+		}
+	}
+	return false
+}
+
+// xmlNameRegex matches the names of the XML elements, optionally qualified with a namespace prefix
+var xmlNameRegex = regexp.MustCompile(`^([A-Za-z_][\w.-]*:)?[A-Za-z_][\w.-]*$`)
+
+// isPayloadTransformationPolicy checks whether the policy transforms the payload between JSON and XML
+func isPayloadTransformationPolicy(policy Policy) bool {
+	return policy.Action == constants.ActionPayloadToXML || policy.Action == constants.ActionPayloadToJSON
+}
+
+// validatePayloadTransformationPolicies validates the parameters of the payload transformation policies and checks
+// whether the payloads of the operation can be transformed, using the media types declared for the operation.
+// The request payload consumed by the operation should be in the source format of the transformation in the request
+// flow, while the response payload produced by the operation should be in the target format in the response flow.
+// The media types are not validated if those are not declared.
+func validatePayloadTransformationPolicies(policies OperationPolicies, consumes, produces []string) error {
+	for _, flowPolicies := range []struct {
+		flow       PolicyFlow
+		policies   PolicyList
+		mediaTypes []string
+	}{
+		{policyInFlow, policies.Request, consumes},
+		{policyOutFlow, policies.Response, produces},
+	} {
+		var transformation *Policy
+		for i, policy := range flowPolicies.policies {
+			if !isPayloadTransformationPolicy(policy) {
+				continue
+			}
+			if transformation != nil && transformation.Action != policy.Action {
+				return fmt.Errorf("policy actions %q and %q cannot be combined in the %s flow",
+					transformation.Action, policy.Action, flowPolicies.flow)
+			}
+			if err := validatePayloadTransformationParams(policy); err != nil {
+				return fmt.Errorf("policy %q in the %s flow is invalid. %v", policy.PolicyName, flowPolicies.flow, err)
+			}
+			transformation = &flowPolicies.policies[i]
+		}
+		if transformation == nil || len(flowPolicies.mediaTypes) == 0 {
+			continue
+		}
+		sourceFormat, targetFormat := "json", "xml"
+		if transformation.Action == constants.ActionPayloadToJSON {
+			sourceFormat, targetFormat = "xml", "json"
+		}
+		format := sourceFormat
+		if flowPolicies.flow == policyOutFlow {
+			format = targetFormat
+		}
+		if !containsMediaTypeOfFormat(flowPolicies.mediaTypes, format) {
+			return fmt.Errorf("policy action %q requires %s payloads in the %s flow, while the operation only "+
+				"declares the media types %v", transformation.Action, strings.ToUpper(format), flowPolicies.flow,
+				flowPolicies.mediaTypes)
+		}
+	}
+	return nil
+}
+
+// validatePayloadTransformationParams validates the optional parameters of a payload transformation policy
+func validatePayloadTransformationParams(policy Policy) error {
+	params, isMap := policy.Parameters.(map[string]interface{})
+	if !isMap {
+		return nil
+	}
+	if rootElementName, found := params[constants.PayloadRootElementName]; found {
+		if policy.Action != constants.ActionPayloadToXML {
+			return fmt.Errorf("parameter %q is only applicable to the policy action %q", constants.PayloadRootElementName,
+				constants.ActionPayloadToXML)
+		}
+		if name, isString := rootElementName.(string); !isString || !xmlNameRegex.MatchString(name) {
+			return fmt.Errorf("parameter %q should be a valid XML element name", constants.PayloadRootElementName)
+		}
+	}
+	if attributePrefix, found := params[constants.PayloadAttributePrefix]; found {
+		if prefix, isString := attributePrefix.(string); !isString || prefix == "" || strings.ContainsAny(prefix, " \t\r\n") {
+			return fmt.Errorf("parameter %q should be a non empty string without whitespaces",
+				constants.PayloadAttributePrefix)
+		}
+	}
+	if ignoreAttributes, found := params[constants.PayloadIgnoreAttributes]; found {
+		if policy.Action != constants.ActionPayloadToJSON {
+			return fmt.Errorf("parameter %q is only applicable to the policy action %q", constants.PayloadIgnoreAttributes,
+				constants.ActionPayloadToJSON)
+		}
+		ignore, isBool := ignoreAttributes.(bool)
+		if !isBool {
+			value, isString := ignoreAttributes.(string)
+			var err error
+			if ignore, err = strconv.ParseBool(value); !isString || err != nil {
+				return fmt.Errorf("parameter %q should be a boolean", constants.PayloadIgnoreAttributes)
+			}
+		}
+		if _, found := params[constants.PayloadAttributePrefix]; found && ignore {
+			return fmt.Errorf("parameter %q cannot be used when the attributes are ignored",
+				constants.PayloadAttributePrefix)
+		}
+	}
+	return nil
+}
+
+// containsMediaTypeOfFormat checks whether any of the media types is of the given format (json or xml), including
+// the structured syntax suffixes (e.g. application/problem+json) and the wildcard media types
+func containsMediaTypeOfFormat(mediaTypes []string, format string) bool {
+	for _, mediaType := range mediaTypes {
+		mediaType = strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+		if mediaType == "*/*" || mediaType == "application/*" || mediaType == "text/*" ||
+			strings.HasSuffix(mediaType, "/"+format) || strings.HasSuffix(mediaType, "+"+format) {
+			return true
+		}
+	}
+	return false
+}
@@ -25,6 +25,7 @@ import "github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
 type ProjectAPI struct {
 	APIYaml             APIYaml
 	APIEnvProps         map[string]synchronizer.APIEnvProps
+	APIParams           map[string]APIParamsConfigs // environment -> configurations overridden by api_params.yaml
 	Deployments         []Deployment
 	APIDefinition       []byte
 	InterceptorCerts    []byte
@@ -34,6 +35,12 @@ type ProjectAPI struct {
 	DownstreamCerts     map[string][]byte  // cert filename -> cert bytes
 	ClientCerts         []CertificateDetails
 	GraphQLComplexities GraphQLComplexityYaml
+	DefinitionFiles     map[string][]byte // path relative to the Definitions dir -> JSON content of the bundled definition files
+	DefinitionHash      string            // canonical hash of the files of the project read while it is extracted
+	// unsupported features found in the files of the project other than the api.yaml, which are ignored
+	UnsupportedFeatures []UnsupportedFeature
+	// files of the project skipped without being read, ex: the documents and the images of the API
+	SkippedFiles []string
 }
 
 // DeploymentEnvironments represents content of deployment_environments.yaml file
@@ -0,0 +1,197 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// Sources of the effective configurations of an API. The configurations overridden by the api_params.yaml of the
+// API project for an environment are reported with the source api_params:<environment>.
+const (
+	ProvenanceSourceAdapterDefault         = "adapter default"
+	ProvenanceSourceAPIYaml                = "api.yaml"
+	ProvenanceSourceAPIParamsPrefix        = "api_params:"
+	ProvenanceSourceAPIDefinition          = "API definition"
+	ProvenanceSourceDeploymentEnvironments = "deployment_environments.yaml"
+	ProvenanceSourceAPIEnvProps            = "APIEnvProps"
+	ProvenanceSourceEnvVariables           = "env variables"
+)
+
+// Overridable configurations of an API of which the provenance is recorded.
+const (
+	ProvenanceFieldVhost                      = "vhost"
+	ProvenanceFieldProductionEndpoints        = "productionEndpoints"
+	ProvenanceFieldSandboxEndpoints           = "sandboxEndpoints"
+	ProvenanceFieldProductionEndpointSecurity = "productionEndpointSecurity"
+	ProvenanceFieldSandboxEndpointSecurity    = "sandboxEndpointSecurity"
+	ProvenanceFieldAuthorizationHeader        = "authorizationHeader"
+)
+
+const redactedValue = "******"
+
+// ConfigProvenance holds the source of the effective value of an overridable configuration of an API, along with the
+// values of the sources overridden by it. The secrets are redacted from the values.
+type ConfigProvenance struct {
+	Field      string
+	Source     string
+	Value      string
+	Overridden []OverriddenConfig
+	// fingerprint detects the changes of the secrets which are redacted from the value
+	fingerprint string
+}
+
+// OverriddenConfig is a value of a configuration overridden by a source applied later.
+type OverriddenConfig struct {
+	Source string
+	Value  string
+}
+
+// GetProvenance returns the provenance of the overridable configurations of the API sorted by the field.
+func (swagger *MgwSwagger) GetProvenance() []ConfigProvenance {
+	provenance := make([]ConfigProvenance, 0, len(swagger.provenance))
+	for _, configProvenance := range swagger.provenance {
+		configProvenance.Overridden = append([]OverriddenConfig{}, configProvenance.Overridden...)
+		provenance = append(provenance, configProvenance)
+	}
+	sort.Slice(provenance, func(i, j int) bool {
+		return provenance[i].Field < provenance[j].Field
+	})
+	return provenance
+}
+
+// GetSkippedFiles returns the files of the API project which are neither read nor deployed.
+func (swagger *MgwSwagger) GetSkippedFiles() []string {
+	return swagger.skippedFiles
+}
+
+// SetSkippedFiles sets the files of the API project which are neither read nor deployed.
+func (swagger *MgwSwagger) SetSkippedFiles(skippedFiles []string) {
+	swagger.skippedFiles = skippedFiles
+}
+
+// RecordProvenance records the source as the source of the endpoints, the endpoint security and the authorization
+// header of the API which are changed since the provenance is last recorded. Hence this needs to be called once
+// the configurations of each source are applied, in the order those are applied.
+func (swagger *MgwSwagger) RecordProvenance(source string) {
+	var prodSecurity, sandSecurity *EndpointSecurity
+	if swagger.productionEndpoints != nil {
+		prodSecurity = &swagger.productionEndpoints.SecurityConfig
+	}
+	if swagger.sandboxEndpoints != nil {
+		sandSecurity = &swagger.sandboxEndpoints.SecurityConfig
+	}
+	swagger.RecordProvenanceValue(ProvenanceFieldProductionEndpoints, source,
+		getEndpointsProvenanceValue(swagger.productionEndpoints))
+	swagger.RecordProvenanceValue(ProvenanceFieldSandboxEndpoints, source,
+		getEndpointsProvenanceValue(swagger.sandboxEndpoints))
+	prodSecurityValue, prodSecurityFingerprint := getEndpointSecurityProvenanceValue(prodSecurity)
+	swagger.recordProvenance(ProvenanceFieldProductionEndpointSecurity, source, prodSecurityValue,
+		prodSecurityFingerprint)
+	sandSecurityValue, sandSecurityFingerprint := getEndpointSecurityProvenanceValue(sandSecurity)
+	swagger.recordProvenance(ProvenanceFieldSandboxEndpointSecurity, source, sandSecurityValue,
+		sandSecurityFingerprint)
+	swagger.RecordProvenanceValue(ProvenanceFieldAuthorizationHeader, source, swagger.xWso2AuthHeader)
+}
+
+// RecordProvenanceValue records the source as the source of the value of the field, if the value is changed since
+// the provenance of the field is last recorded. The value should not contain secrets.
+func (swagger *MgwSwagger) RecordProvenanceValue(field, source, value string) {
+	swagger.recordProvenance(field, source, value, value)
+}
+
+func (swagger *MgwSwagger) recordProvenance(field, source, value, fingerprint string) {
+	if swagger.provenance == nil {
+		swagger.provenance = make(map[string]ConfigProvenance)
+	}
+	configProvenance, found := swagger.provenance[field]
+	if !found {
+		if value == "" {
+			return
+		}
+		swagger.provenance[field] = ConfigProvenance{Field: field, Source: source, Value: value, fingerprint: fingerprint}
+		return
+	}
+	if configProvenance.fingerprint == fingerprint {
+		return
+	}
+	configProvenance.Overridden = append(append([]OverriddenConfig{}, configProvenance.Overridden...),
+		OverriddenConfig{Source: configProvenance.Source, Value: configProvenance.Value})
+	configProvenance.Source = source
+	configProvenance.Value = value
+	configProvenance.fingerprint = fingerprint
+	swagger.provenance[field] = configProvenance
+}
+
+// getEndpointsProvenanceValue returns the URLs of the endpoints separated by commas, with the passwords of the URLs
+// redacted. The query parameters of the URLs are omitted, as those could carry the credentials of the endpoints.
+func getEndpointsProvenanceValue(endpointCluster *EndpointCluster) string {
+	if endpointCluster == nil {
+		return ""
+	}
+	urls := make([]string, 0, len(endpointCluster.Endpoints))
+	for _, endpoint := range endpointCluster.Endpoints {
+		if endpoint.IsUnixSocket() {
+			urls = append(urls, constants.UnixSocketURLType+":"+endpoint.Host)
+			continue
+		}
+		if endpoint.RawURL != "" {
+			if parsedURL, err := url.Parse(endpoint.RawURL); err == nil {
+				parsedURL.RawQuery = ""
+				urls = append(urls, parsedURL.Redacted())
+				continue
+			}
+		}
+		urls = append(urls, endpoint.URLType+"://"+endpoint.Host+":"+strconv.FormatUint(uint64(endpoint.Port), 10)+
+			endpoint.Basepath)
+	}
+	return strings.Join(urls, ",")
+}
+
+// getEndpointSecurityProvenanceValue returns the endpoint security with the password and the values of the custom
+// parameters redacted, along with a fingerprint of the endpoint security including the secrets. The fingerprint is
+// hashed so that the secrets are not retained with the provenance.
+func getEndpointSecurityProvenanceValue(endpointSecurity *EndpointSecurity) (string, string) {
+	if endpointSecurity == nil || !endpointSecurity.Enabled {
+		return "", ""
+	}
+	value := fmt.Sprintf("type=%s, username=%s", endpointSecurity.Type, endpointSecurity.Username)
+	fingerprint := value + "\x00" + endpointSecurity.Password
+	if endpointSecurity.Password != "" {
+		value += ", password=" + redactedValue
+	}
+	parameterNames := make([]string, 0, len(endpointSecurity.CustomParameters))
+	for name := range endpointSecurity.CustomParameters {
+		parameterNames = append(parameterNames, name)
+	}
+	sort.Strings(parameterNames)
+	for _, name := range parameterNames {
+		value += ", " + name + "=" + redactedValue
+		fingerprint += "\x00" + name + "=" + endpointSecurity.CustomParameters[name]
+	}
+	fingerprintHash := sha256.Sum256([]byte(fingerprint))
+	return value, hex.EncodeToString(fingerprintHash[:])
+}
@@ -0,0 +1,105 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// setPublicPaths disables the security of the operations whose resource path matches one of the public path
+// patterns, which are given in the adapter configuration for all the APIs and in the x-wso2-public-paths extension
+// per API. The patterns are matched against the path templates of the resources (e.g. /docs/{docId}), where "*"
+// matches a single path segment and "**" matches any number of path segments.
+//
+// The patterns of the configuration are common to all the APIs, hence only the patterns of the x-wso2-public-paths
+// extension which match no resource are reported as parse warnings.
+func (swagger *MgwSwagger) setPublicPaths() {
+	if swagger.apiType == constants.WS {
+		return
+	}
+	conf, _ := config.ReadConfigs()
+	apiPatterns, _ := getStringArrayExtension(swagger.vendorExtensions, constants.XWso2PublicPaths)
+	for _, pattern := range apiPatterns {
+		if !swagger.applyPublicPathPattern(pattern) {
+			swagger.addParseWarning(ParseWarningUnmatchedPublicPath,
+				"public path pattern %q of the %s extension does not match any resource", pattern,
+				constants.XWso2PublicPaths)
+		}
+	}
+	for _, pattern := range conf.Adapter.PublicPathPatterns {
+		if !swagger.applyPublicPathPattern(pattern) {
+			logger.LoggerOasparser.Debugf("Public path pattern %q does not match any resource of the API %s:%s",
+				pattern, swagger.title, swagger.version)
+		}
+	}
+}
+
+// applyPublicPathPattern disables the security of the operations of the resources matching the pattern and returns
+// true if any resource matched.
+func (swagger *MgwSwagger) applyPublicPathPattern(pattern string) bool {
+	patternRegex := publicPathPatternToRegex(pattern)
+	matched := false
+	for _, resource := range swagger.resources {
+		if !patternRegex.MatchString(resource.path) {
+			continue
+		}
+		matched = true
+		for _, operation := range resource.methods {
+			if operation.disableSecurity {
+				continue
+			}
+			operation.disableSecurity = true
+			operation.publicPathPattern = pattern
+			logger.LoggerOasparser.Infof("Security is disabled for the operation %s %s of the API %s:%s as it "+
+				"matches the public path pattern %q", operation.method, resource.path, swagger.title,
+				swagger.version, pattern)
+		}
+	}
+	return matched
+}
+
+// publicPathPatternToRegex converts a glob pattern of a resource path to an anchored regex. The trailing slashes of
+// the patterns and the paths are ignored.
+func publicPathPatternToRegex(pattern string) *regexp.Regexp {
+	pattern = strings.TrimSuffix(strings.TrimSpace(pattern), "/")
+	var regex strings.Builder
+	regex.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "/**"):
+			regex.WriteString("(/.*)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			regex.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			regex.WriteString("[^/]*")
+		case pattern[i] == '?':
+			regex.WriteString("[^/]")
+		default:
+			regex.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	regex.WriteString("/?$")
+	return regexp.MustCompile(regex.String())
+}
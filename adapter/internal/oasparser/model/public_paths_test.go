@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+func TestPublicPathPatternToRegex(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		matches bool
+	}{
+		{"/swagger.json", "/swagger.json", true},
+		{"/swagger.json", "/swaggerXjson", false},
+		{"/docs", "/docs/", true},
+		{"/docs/", "/docs", true},
+		{"/docs/*", "/docs/{docId}", true},
+		{"/docs/*", "/docs", false},
+		{"/docs/*", "/docs/{docId}/pages", false},
+		{"/docs/**", "/docs", true},
+		{"/docs/**", "/docs/{docId}/pages", true},
+		{"/docs/**", "/documents", false},
+		{"/*/health", "/v1/health", true},
+		{"/pets/?", "/pets/1", true},
+		{"/pets/?", "/pets/12", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.matches, publicPathPatternToRegex(test.pattern).MatchString(test.path),
+			"Pattern %q matching the path %q is incorrect", test.pattern, test.path)
+	}
+}
+
+func TestSetPublicPaths(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousPublicPathPatterns := conf.Adapter.PublicPathPatterns
+	defer func() {
+		conf.Adapter.PublicPathPatterns = previousPublicPathPatterns
+	}()
+	conf.Adapter.PublicPathPatterns = []string{"/swagger.json", "/health"}
+
+	mgwSwagger := MgwSwagger{
+		title:   "PetStore",
+		version: "1.0.0",
+		vendorExtensions: map[string]interface{}{
+			constants.XWso2PublicPaths: []interface{}{"/docs/**", "/images/*"},
+		},
+		resources: []*Resource{
+			{path: "/pets", methods: []*Operation{NewOperation("GET", nil, nil)}},
+			{path: "/swagger.json", methods: []*Operation{NewOperation("GET", nil, nil)}},
+			{path: "/docs/{docId}", methods: []*Operation{NewOperation("GET", nil, nil),
+				NewOperation("PUT", nil, map[string]interface{}{constants.XWso2DisableSecurity: true})}},
+		},
+	}
+	mgwSwagger.setPublicPaths()
+
+	assert.False(t, mgwSwagger.resources[0].methods[0].GetDisableSecurity(),
+		"Security should not be disabled for the resources not matching the public paths")
+	assert.True(t, mgwSwagger.resources[1].methods[0].GetDisableSecurity(),
+		"Security should be disabled for the resources matching the public paths of the config")
+	assert.Equal(t, "/swagger.json", mgwSwagger.resources[1].methods[0].GetPublicPathPattern())
+	assert.True(t, mgwSwagger.resources[2].methods[0].GetDisableSecurity(),
+		"Security should be disabled for the resources matching the public paths of the API")
+	assert.Equal(t, "/docs/**", mgwSwagger.resources[2].methods[0].GetPublicPathPattern())
+	assert.True(t, mgwSwagger.resources[2].methods[1].GetDisableSecurity())
+	assert.Empty(t, mgwSwagger.resources[2].methods[1].GetPublicPathPattern(),
+		"Operations without security in the API definition should not be attributed to the public paths")
+
+	// Only the unmatched patterns of the API are reported, as the patterns of the config are common to all the APIs
+	if assert.Len(t, mgwSwagger.GetParseWarnings(), 1) {
+		assert.Equal(t, ParseWarningUnmatchedPublicPath, mgwSwagger.GetParseWarnings()[0].Code)
+		assert.Contains(t, mgwSwagger.GetParseWarnings()[0].Message, "/images/*")
+	}
+}
@@ -45,6 +45,8 @@ type Resource struct {
 	vendorExtensions    map[string]interface{}
 	hasPolicies         bool
 	amznResourceName    string
+	// schemes listed in the operations of the resource (OpenAPI v2), which override the schemes of the API
+	schemes []string
 }
 
 // GetProdEndpoints returns the production endpoints object of a given resource.
@@ -57,6 +59,12 @@ func (resource *Resource) GetSandEndpoints() *EndpointCluster {
 	return resource.sandboxEndpoints
 }
 
+// GetSchemes returns the schemes listed in the operations of the resource. Nil is returned if the resource uses the
+// schemes of the API.
+func (resource *Resource) GetSchemes() []string {
+	return resource.schemes
+}
+
 // GetPath returns the pathItem name (of openAPI definition) corresponding to a given resource
 func (resource *Resource) GetPath() string {
 	return resource.path
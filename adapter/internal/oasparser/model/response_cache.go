@@ -0,0 +1,66 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+)
+
+// getCacheVaryHeaders returns the cacheVaryHeaders of an operation of the api.yaml in lower case, without the empty
+// and the duplicate headers.
+func getCacheVaryHeaders(headers []string) []string {
+	var varyHeaders []string
+	for _, header := range headers {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if header != "" && !arrayContains(varyHeaders, header) {
+			varyHeaders = append(varyHeaders, header)
+		}
+	}
+	return varyHeaders
+}
+
+// AddResponseCacheWarnings reports a parse warning for each cacheVaryHeaders of the operations which is not allowed
+// by the cache filter of the router. The responses varying by such a header are not cached.
+func (swagger *MgwSwagger) AddResponseCacheWarnings() {
+	conf, _ := config.ReadConfigs()
+	if !conf.Envoy.Filters.ResponseCache.Enabled {
+		return
+	}
+	var allowedVaryHeaders []string
+	for _, header := range conf.Envoy.Filters.ResponseCache.AllowedVaryHeaders {
+		allowedVaryHeaders = append(allowedVaryHeaders, strings.ToLower(strings.TrimSpace(header)))
+	}
+	for _, resource := range swagger.resources {
+		for _, operation := range resource.methods {
+			for _, header := range operation.cacheVaryHeaders {
+				if arrayContains(allowedVaryHeaders, header) {
+					continue
+				}
+				logger.LoggerOasparser.Warnf("Responses of the operation %s %s of the API %s:%s are not cached as "+
+					"the cache vary header %s is not allowed by the router", operation.method, resource.path,
+					swagger.title, swagger.version, header)
+				swagger.addParseWarning(ParseWarningDisallowedVaryHeader, "responses of the operation %s %s are not "+
+					"cached as the cache vary header %s is not allowed by the router (router.filters.responseCache."+
+					"allowedVaryHeaders)", operation.method, resource.path, header)
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+func TestGetCacheVaryHeaders(t *testing.T) {
+	assert.Equal(t, []string{"accept-language", "x-tenant"},
+		getCacheVaryHeaders([]string{"Accept-Language", " X-Tenant ", "", "x-tenant"}))
+	assert.Empty(t, getCacheVaryHeaders(nil))
+}
+
+func TestAddResponseCacheWarnings(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	previousResponseCache := conf.Envoy.Filters.ResponseCache
+	defer func() {
+		conf.Envoy.Filters.ResponseCache = previousResponseCache
+	}()
+	conf.Envoy.Filters.ResponseCache.AllowedVaryHeaders = []string{"Accept-Language"}
+
+	newMgwSwagger := func() MgwSwagger {
+		operation := NewOperation("GET", nil, nil)
+		operation.cacheVaryHeaders = []string{"accept-language", "x-tenant"}
+		return MgwSwagger{
+			title:     "PetStore",
+			version:   "1.0.0",
+			resources: []*Resource{{path: "/pets", methods: []*Operation{operation}}},
+		}
+	}
+
+	conf.Envoy.Filters.ResponseCache.Enabled = false
+	mgwSwagger := newMgwSwagger()
+	mgwSwagger.AddResponseCacheWarnings()
+	assert.Empty(t, mgwSwagger.GetParseWarnings(), "Responses are not cached without the cache filter")
+
+	conf.Envoy.Filters.ResponseCache.Enabled = true
+	mgwSwagger = newMgwSwagger()
+	mgwSwagger.AddResponseCacheWarnings()
+	warnings := mgwSwagger.GetParseWarnings()
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, ParseWarningDisallowedVaryHeader, warnings[0].Code)
+		assert.Contains(t, warnings[0].Message, "x-tenant")
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"strings"
+
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+)
+
+// httpSchemes are the schemes of the API definitions which are compared with the schemes of the endpoints.
+var httpSchemes = []string{"http", "https"}
+
+// validateEndpointSchemes reports the endpoints whose scheme contradicts the schemes of the API definition
+// (OpenAPI v2) as parse warnings, e.g. an http endpoint of an API which only lists https. The endpoints of the API
+// are compared with the schemes of the API, and the endpoints of the resources (or the API, if the resource has
+// no endpoints) are compared with the schemes of the operations of the resource (or the API, if the operations do
+// not list schemes). The schemes other than http and https are not compared.
+func (swagger *MgwSwagger) validateEndpointSchemes() {
+	apiSchemes := getHTTPSchemes(swagger.schemes)
+	swagger.validateEndpointClusterSchemes(swagger.productionEndpoints, apiSchemes, "API")
+	swagger.validateEndpointClusterSchemes(swagger.sandboxEndpoints, apiSchemes, "API")
+	for _, resource := range swagger.resources {
+		resourceSchemes := getHTTPSchemes(resource.schemes)
+		hasResourceEndpoints := resource.productionEndpoints != nil || resource.sandboxEndpoints != nil
+		if len(resourceSchemes) == 0 && !hasResourceEndpoints {
+			// Already validated along with the API
+			continue
+		}
+		if len(resourceSchemes) == 0 {
+			resourceSchemes = apiSchemes
+		}
+		productionEndpoints, sandboxEndpoints := resource.productionEndpoints, resource.sandboxEndpoints
+		if !hasResourceEndpoints {
+			productionEndpoints, sandboxEndpoints = swagger.productionEndpoints, swagger.sandboxEndpoints
+		}
+		location := "resource " + resource.path
+		swagger.validateEndpointClusterSchemes(productionEndpoints, resourceSchemes, location)
+		swagger.validateEndpointClusterSchemes(sandboxEndpoints, resourceSchemes, location)
+	}
+}
+
+// validateEndpointClusterSchemes adds a parse warning for each http or https endpoint of the cluster whose scheme is
+// not one of the given schemes.
+func (swagger *MgwSwagger) validateEndpointClusterSchemes(endpointCluster *EndpointCluster, schemes []string,
+	location string) {
+	if endpointCluster == nil || len(schemes) == 0 {
+		return
+	}
+	for _, endpoint := range endpointCluster.Endpoints {
+		urlType := strings.ToLower(endpoint.URLType)
+		if !arrayContains(httpSchemes, urlType) || arrayContains(schemes, urlType) {
+			continue
+		}
+		logger.LoggerOasparser.Warnf("Endpoint %s of the %s of the API %s:%s uses the scheme %s, which is not "+
+			"listed in the schemes %v", endpoint.RawURL, location, swagger.title, swagger.version, urlType, schemes)
+		swagger.addParseWarning(ParseWarningSchemeMismatch,
+			"endpoint %s of the %s uses the scheme %s, which is not listed in the schemes %v", endpoint.RawURL,
+			location, urlType, schemes)
+	}
+}
+
+// getHTTPSchemes returns the http and https schemes of the given schemes in lower case.
+func getHTTPSchemes(schemes []string) []string {
+	var filteredSchemes []string
+	for _, scheme := range schemes {
+		scheme = strings.ToLower(strings.TrimSpace(scheme))
+		if arrayContains(httpSchemes, scheme) && !arrayContains(filteredSchemes, scheme) {
+			filteredSchemes = append(filteredSchemes, scheme)
+		}
+	}
+	return filteredSchemes
+}
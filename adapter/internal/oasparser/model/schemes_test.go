@@ -0,0 +1,109 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const swaggerWithSchemesTemplate = `swagger: "2.0"
+info:
+  title: PetStore
+  version: 1.0.0
+schemes: %s
+x-wso2-production-endpoints:
+  urls:
+    - %s
+paths:
+  /pets:
+    get:
+      operationId: getPets
+      responses:
+        "200":
+          description: OK
+  /orders:
+    get:
+      operationId: getOrders
+      schemes: [http]
+      responses:
+        "200":
+          description: OK
+`
+
+func TestValidateEndpointSchemes(t *testing.T) {
+	tests := []struct {
+		name             string
+		schemes          string
+		endpoint         string
+		expectedWarnings []string
+	}{
+		{
+			name:     "Endpoint scheme listed in the schemes of the API and the operations",
+			schemes:  "[https, http]",
+			endpoint: "http://petstore.io/v1",
+		},
+		{
+			name:     "Endpoint scheme listed in the schemes of the API but not in the schemes of the operations",
+			schemes:  "[https]",
+			endpoint: "https://petstore.io/v1",
+			expectedWarnings: []string{
+				"endpoint https://petstore.io/v1 of the resource /orders uses the scheme https, which is not listed " +
+					"in the schemes [http]",
+			},
+		},
+		{
+			name:     "Endpoint scheme contradicting the schemes of the API",
+			schemes:  "[HTTPS]",
+			endpoint: "http://petstore.io/v1",
+			expectedWarnings: []string{
+				"endpoint http://petstore.io/v1 of the API uses the scheme http, which is not listed in the schemes " +
+					"[https]",
+			},
+		},
+		{
+			name:     "Schemes other than http and https",
+			schemes:  "[ws]",
+			endpoint: "https://petstore.io/v1",
+			expectedWarnings: []string{
+				"endpoint https://petstore.io/v1 of the resource /orders uses the scheme https, which is not listed " +
+					"in the schemes [http]",
+			},
+		},
+	}
+	for _, test := range tests {
+		var mgwSwagger MgwSwagger
+		err := mgwSwagger.GetMgwSwagger([]byte(fmt.Sprintf(swaggerWithSchemesTemplate, test.schemes,
+			test.endpoint)))
+		assert.Nil(t, err, test.name)
+		var warnings []string
+		for _, warning := range mgwSwagger.GetParseWarnings() {
+			if warning.Code == ParseWarningSchemeMismatch {
+				warnings = append(warnings, warning.Message)
+			}
+		}
+		assert.Equal(t, test.expectedWarnings, warnings, test.name)
+	}
+}
+
+func TestGetHTTPSchemes(t *testing.T) {
+	assert.Equal(t, []string{"https", "http"}, getHTTPSchemes([]string{"HTTPS", "ws", "http", "https"}))
+	assert.Empty(t, getHTTPSchemes([]string{"wss"}))
+}
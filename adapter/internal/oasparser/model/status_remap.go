@@ -0,0 +1,56 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+)
+
+// StatusRemapConfig remaps the status code of the responses of the backend of an operation, ex: to respond with 502
+// when the backend responds with 200 and an error in the body.
+type StatusRemapConfig struct {
+	// From is the status code of the backend response
+	From int `json:"from,omitempty"`
+	// To is the status code sent to the client instead
+	To int `json:"to,omitempty"`
+	// BodyContains remaps the status only if the response body contains the given text. The response body is
+	// buffered to be matched, hence the status of any response is remapped if not provided.
+	BodyContains string `json:"bodyContains,omitempty"`
+}
+
+// validateStatusRemaps validates the status remaps of an operation. The remaps are applied in the given order, hence
+// a remap following another unconditional remap of the same status is rejected as it is never applied.
+func validateStatusRemaps(statusRemaps []StatusRemapConfig) error {
+	unconditionalRemaps := make(map[int]struct{})
+	for i, statusRemap := range statusRemaps {
+		if statusRemap.From < 100 || statusRemap.From > 599 {
+			return fmt.Errorf("from status %d of the remap %d is not within 100 and 599", statusRemap.From, i)
+		}
+		if statusRemap.To < 200 || statusRemap.To > 599 {
+			return fmt.Errorf("to status %d of the remap %d is not within 200 and 599", statusRemap.To, i)
+		}
+		if _, found := unconditionalRemaps[statusRemap.From]; found {
+			return fmt.Errorf("remap %d is never applied, as the status %d is already remapped without a "+
+				"bodyContains condition", i, statusRemap.From)
+		}
+		if statusRemap.BodyContains == "" {
+			unconditionalRemaps[statusRemap.From] = struct{}{}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+)
+
+// IsSubscriptionValidationDisabled returns true if the requests to the API are allowed with any valid token, without
+// validating the subscriptions of the applications of the tokens.
+func (swagger *MgwSwagger) IsSubscriptionValidationDisabled() bool {
+	return swagger.disableSubscriptionValidation
+}
+
+// setSubscriptionValidationDisabled disables the subscription validation of the API as given by the api.yaml, unless
+// the subscription validation is forced for all the APIs by the adapter configuration.
+func (swagger *MgwSwagger) setSubscriptionValidationDisabled(disabled bool) {
+	conf, _ := config.ReadConfigs()
+	if disabled && conf.Adapter.ForceSubscriptionValidation {
+		logger.LoggerOasparser.Infof("Subscription validation is disabled for the API %s:%s, but the subscriptions "+
+			"of its requests are validated as forceSubscriptionValidation is enabled", swagger.title, swagger.version)
+		disabled = false
+	}
+	swagger.disableSubscriptionValidation = disabled
+}
@@ -46,10 +46,12 @@ func (swagger *MgwSwagger) SetInfoSwagger(swagger2 spec.Swagger) error {
 	swagger.security = swagger2.Security
 	swagger.apiType = constants.HTTP
 	swagger.resources = getResourcesSwagger(swagger2)
+	swagger.collectParseWarningsSwagger(swagger2)
 
 	swagger.xWso2RequestBodyPass = getRequestBodyBufferConfig(swagger.vendorExtensions)
 
 	swagger.xWso2Basepath = swagger2.BasePath
+	swagger.schemes = swagger2.Schemes
 	// According to the definition, multiple schemes can be mentioned. Since the microgateway can assign only one scheme
 	// https is prioritized over http. If it is ws or wss, the microgateway will print an error.
 	// If the schemes property is not mentioned at all, http will be assigned. (Only swagger 2 version has this property)
@@ -110,6 +112,7 @@ func getResourcesSwagger(swagger2 spec.Swagger) []*Resource {
 				}
 				op := NewOperation(methodName, pathItem.Get.Security, pathItem.Get.Extensions)
 				op.SetMockedAPIConfigOAS2(pathItem.Get)
+				op.SetMediaTypesOAS2(pathItem.Get, swagger2.Consumes, swagger2.Produces)
 				methodsArray = append(methodsArray, op)
 				methodFound = true
 			}
@@ -120,6 +123,7 @@ func getResourcesSwagger(swagger2 spec.Swagger) []*Resource {
 				}
 				op := NewOperation(methodName, pathItem.Post.Security, pathItem.Post.Extensions)
 				op.SetMockedAPIConfigOAS2(pathItem.Post)
+				op.SetMediaTypesOAS2(pathItem.Post, swagger2.Consumes, swagger2.Produces)
 				methodsArray = append(methodsArray, op)
 				methodFound = true
 			}
@@ -130,6 +134,7 @@ func getResourcesSwagger(swagger2 spec.Swagger) []*Resource {
 				}
 				op := NewOperation(methodName, pathItem.Put.Security, pathItem.Put.Extensions)
 				op.SetMockedAPIConfigOAS2(pathItem.Put)
+				op.SetMediaTypesOAS2(pathItem.Put, swagger2.Consumes, swagger2.Produces)
 				methodsArray = append(methodsArray, op)
 				methodFound = true
 			}
@@ -140,6 +145,7 @@ func getResourcesSwagger(swagger2 spec.Swagger) []*Resource {
 				}
 				op := NewOperation(methodName, pathItem.Delete.Security, pathItem.Delete.Extensions)
 				op.SetMockedAPIConfigOAS2(pathItem.Delete)
+				op.SetMediaTypesOAS2(pathItem.Delete, swagger2.Consumes, swagger2.Produces)
 				methodsArray = append(methodsArray, op)
 				methodFound = true
 			}
@@ -150,6 +156,7 @@ func getResourcesSwagger(swagger2 spec.Swagger) []*Resource {
 				}
 				op := NewOperation(methodName, pathItem.Head.Security, pathItem.Head.Extensions)
 				op.SetMockedAPIConfigOAS2(pathItem.Head)
+				op.SetMediaTypesOAS2(pathItem.Head, swagger2.Consumes, swagger2.Produces)
 				methodsArray = append(methodsArray, op)
 				methodFound = true
 			}
@@ -160,6 +167,7 @@ func getResourcesSwagger(swagger2 spec.Swagger) []*Resource {
 				}
 				op := NewOperation(methodName, pathItem.Patch.Security, pathItem.Patch.Extensions)
 				op.SetMockedAPIConfigOAS2(pathItem.Patch)
+				op.SetMediaTypesOAS2(pathItem.Patch, swagger2.Consumes, swagger2.Produces)
 				methodsArray = append(methodsArray, op)
 				methodFound = true
 			}
@@ -170,11 +178,13 @@ func getResourcesSwagger(swagger2 spec.Swagger) []*Resource {
 				}
 				op := NewOperation(methodName, pathItem.Options.Security, pathItem.Options.Extensions)
 				op.SetMockedAPIConfigOAS2(pathItem.Options)
+				op.SetMediaTypesOAS2(pathItem.Options, swagger2.Consumes, swagger2.Produces)
 				methodsArray = append(methodsArray, op)
 				methodFound = true
 			}
 			if methodFound {
 				resource := unmarshalSwaggerResources(path, methodsArray, pathItem.Extensions)
+				resource.schemes = getPathItemSchemesOAS2(pathItem)
 				resources = append(resources, &resource)
 			}
 		}
@@ -182,6 +192,24 @@ func getResourcesSwagger(swagger2 spec.Swagger) []*Resource {
 	return SortResources(resources)
 }
 
+// getPathItemSchemesOAS2 returns the schemes listed in the operations of the path item, which override the schemes of
+// the API for the operations.
+func getPathItemSchemesOAS2(pathItem spec.PathItem) []string {
+	var schemes []string
+	for _, operation := range []*spec.Operation{pathItem.Get, pathItem.Post, pathItem.Put, pathItem.Delete,
+		pathItem.Head, pathItem.Patch, pathItem.Options} {
+		if operation == nil {
+			continue
+		}
+		for _, scheme := range operation.Schemes {
+			if !arrayContains(schemes, scheme) {
+				schemes = append(schemes, scheme)
+			}
+		}
+	}
+	return schemes
+}
+
 // Sets security definitions defined in swagger 2 format.
 func setSecurityDefinitions(swagger2 spec.Swagger) []SecurityScheme {
 	var securitySchemes []SecurityScheme
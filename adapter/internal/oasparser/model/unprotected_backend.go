@@ -0,0 +1,69 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"strings"
+
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// AddUnprotectedBackendWarnings reports a parse warning if the API is not public, but neither authenticates the
+// clients nor authenticates to its production and sandbox backends, which is usually a misconfiguration exposing the
+// backends fully. The security of the clients is absent if it is disabled for the API or for all of its operations.
+// This needs to be called once the endpoint security of the API is final (i.e. after the endpoint security of the
+// environment is applied).
+func (swagger *MgwSwagger) AddUnprotectedBackendWarnings() {
+	if strings.EqualFold(swagger.visibility, constants.PublicVisibility) || swagger.isClientSecurityEnabled() {
+		return
+	}
+	var unprotectedClusters []string
+	if swagger.productionEndpoints != nil && !swagger.productionEndpoints.SecurityConfig.Enabled {
+		unprotectedClusters = append(unprotectedClusters, "production")
+	}
+	if swagger.sandboxEndpoints != nil && !swagger.sandboxEndpoints.SecurityConfig.Enabled {
+		unprotectedClusters = append(unprotectedClusters, "sandbox")
+	}
+	if len(unprotectedClusters) == 0 {
+		return
+	}
+	clusters := strings.Join(unprotectedClusters, " and ")
+	logger.LoggerOasparser.Warnf("Security of the %s API %s:%s is disabled and its %s endpoints do not have endpoint "+
+		"security, hence the backends are exposed without any authentication", swagger.visibility, swagger.title,
+		swagger.version, clusters)
+	swagger.addParseWarning(ParseWarningUnprotectedBackend, "security of the API is disabled and its %s endpoints "+
+		"do not have endpoint security, while the visibility of the API is %q", clusters, swagger.visibility)
+}
+
+// isClientSecurityEnabled returns true unless the security is disabled for the API or for all of its operations.
+func (swagger *MgwSwagger) isClientSecurityEnabled() bool {
+	if swagger.disableSecurity {
+		return false
+	}
+	hasOperations := false
+	for _, resource := range swagger.resources {
+		for _, operation := range resource.methods {
+			hasOperations = true
+			if !operation.disableSecurity {
+				return true
+			}
+		}
+	}
+	return !hasOperations
+}
@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+func TestAddUnprotectedBackendWarnings(t *testing.T) {
+	disableSecurity := map[string]interface{}{constants.XWso2DisableSecurity: true}
+	tests := []struct {
+		name               string
+		visibility         string
+		disableSecurity    bool
+		operations         []*Operation
+		prodSecured        bool
+		withSandbox        bool
+		sandSecured        bool
+		isWarningExpected  bool
+		unprotectedCluster string
+	}{
+		{
+			name:               "Private API without client and endpoint security",
+			visibility:         constants.PrivateVisibility,
+			disableSecurity:    true,
+			operations:         []*Operation{NewOperation("GET", nil, nil)},
+			isWarningExpected:  true,
+			unprotectedCluster: "production",
+		},
+		{
+			name:               "Restricted API whose operations are all without security",
+			visibility:         constants.RestrictedVisibility,
+			operations:         []*Operation{NewOperation("GET", nil, disableSecurity)},
+			withSandbox:        true,
+			isWarningExpected:  true,
+			unprotectedCluster: "production and sandbox",
+		},
+		{
+			name:               "Sandbox endpoints without endpoint security",
+			visibility:         constants.PrivateVisibility,
+			disableSecurity:    true,
+			prodSecured:        true,
+			withSandbox:        true,
+			isWarningExpected:  true,
+			unprotectedCluster: "sandbox",
+		},
+		{
+			name:              "Public API without client and endpoint security",
+			visibility:        constants.PublicVisibility,
+			disableSecurity:   true,
+			isWarningExpected: false,
+		},
+		{
+			name:              "Private API with an operation having security",
+			visibility:        constants.PrivateVisibility,
+			operations:        []*Operation{NewOperation("GET", nil, nil), NewOperation("POST", nil, disableSecurity)},
+			isWarningExpected: false,
+		},
+		{
+			name:              "Private API with endpoint security",
+			visibility:        constants.PrivateVisibility,
+			disableSecurity:   true,
+			prodSecured:       true,
+			withSandbox:       true,
+			sandSecured:       true,
+			isWarningExpected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mgwSwagger := MgwSwagger{
+				title:           "PetStore",
+				version:         "1.0.0",
+				visibility:      test.visibility,
+				disableSecurity: test.disableSecurity,
+				resources:       []*Resource{{path: "/pets", methods: test.operations}},
+				productionEndpoints: &EndpointCluster{
+					SecurityConfig: EndpointSecurity{Enabled: test.prodSecured, Type: "BASIC"},
+				},
+			}
+			if test.withSandbox {
+				mgwSwagger.sandboxEndpoints = &EndpointCluster{
+					SecurityConfig: EndpointSecurity{Enabled: test.sandSecured, Type: "BASIC"},
+				}
+			}
+			mgwSwagger.AddUnprotectedBackendWarnings()
+			warnings := mgwSwagger.GetParseWarnings()
+			if !test.isWarningExpected {
+				assert.Empty(t, warnings)
+				return
+			}
+			if assert.Len(t, warnings, 1) {
+				assert.Equal(t, ParseWarningUnprotectedBackend, warnings[0].Code)
+				assert.Contains(t, warnings[0].Message, test.unprotectedCluster+" endpoints")
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// Names of the features of the API projects exported from APIM, which are not supported by the adapter but are
+// tolerated by ignoring those when the APIs are deployed.
+const (
+	UnsupportedFeatureInlineEndpoint    = "inlineEndpoint"
+	UnsupportedFeatureMediationSequence = "mediationSequence"
+	UnsupportedFeatureResponseCaching   = "responseCaching"
+	UnsupportedFeatureAdvertiseOnlyAPI  = "advertiseOnlyAPI"
+)
+
+// unsupportedFeatureMessages describes how each unsupported feature is tolerated.
+var unsupportedFeatureMessages = map[string]string{
+	UnsupportedFeatureInlineEndpoint: "INLINE endpoint implementation is not supported. The examples of the API " +
+		"definition are served as the mocked responses of the API instead.",
+	UnsupportedFeatureMediationSequence: "Mediation sequences are not supported. The requests and responses of the " +
+		"API are not mediated.",
+	UnsupportedFeatureResponseCaching: "Response caching is not supported. The responses of the API are not cached.",
+	UnsupportedFeatureAdvertiseOnlyAPI: "Advertise only APIs are not supported. The API is deployed to proxy the " +
+		"requests to its endpoints.",
+}
+
+// UnsupportedFeature is a feature used in an API project which is not supported by the adapter. The API is deployed
+// without the feature and the feature is reported as a warning of the deployment.
+type UnsupportedFeature struct {
+	Name    string
+	Message string
+}
+
+// unsupportedAPIYamlFields holds the fields of the api.yaml which are only parsed to find the unsupported features.
+type unsupportedAPIYamlFields struct {
+	Data struct {
+		ResponseCachingEnabled bool          `json:"responseCachingEnabled"`
+		MediationPolicies      []interface{} `json:"mediationPolicies"`
+		AdvertiseInfo          struct {
+			Advertised bool `json:"advertised"`
+		} `json:"advertiseInfo"`
+	} `json:"data"`
+}
+
+// setUnsupportedFeatures finds the unsupported features used in the api.yaml given as JSON. The unsupported features
+// which can be tolerated are removed from the api.yaml, so that the API is deployed without those.
+func (apiYaml *APIYaml) setUnsupportedFeatures(apiJsn []byte) {
+	if apiYaml.Data.EndpointImplementationType == constants.InlineEndpointType {
+		// INLINE is the prototype implementation of APIM, whose scripts cannot be executed by the router. Hence, the
+		// examples of the API definition are served as it is done for the mocked APIs.
+		apiYaml.Data.EndpointImplementationType = constants.MockedOASEndpointType
+		apiYaml.addUnsupportedFeature(UnsupportedFeatureInlineEndpoint)
+	}
+
+	var fields unsupportedAPIYamlFields
+	if err := json.Unmarshal(apiJsn, &fields); err != nil {
+		loggers.LoggerAPI.Debugf("Unsupported features of the api.yaml are not checked as it cannot be parsed. %v",
+			err)
+		return
+	}
+	if len(fields.Data.MediationPolicies) > 0 {
+		apiYaml.addUnsupportedFeature(UnsupportedFeatureMediationSequence)
+	}
+	conf, _ := config.ReadConfigs()
+	if fields.Data.ResponseCachingEnabled || (apiYaml.hasCacheVaryHeaders() && !conf.Envoy.Filters.ResponseCache.Enabled) {
+		apiYaml.addUnsupportedFeature(UnsupportedFeatureResponseCaching)
+	}
+	if fields.Data.AdvertiseInfo.Advertised {
+		apiYaml.addUnsupportedFeature(UnsupportedFeatureAdvertiseOnlyAPI)
+	}
+}
+
+// hasCacheVaryHeaders returns true if an operation of the api.yaml customizes the cache key of its responses.
+func (apiYaml *APIYaml) hasCacheVaryHeaders() bool {
+	for _, operation := range apiYaml.Data.Operations {
+		if len(operation.CacheVaryHeaders) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (apiYaml *APIYaml) addUnsupportedFeature(name string) {
+	apiYaml.UnsupportedFeatures = appendUnsupportedFeature(apiYaml.UnsupportedFeatures, name)
+}
+
+// AddUnsupportedFeature records an unsupported feature found in the files of the API project other than the
+// api.yaml. A feature is recorded only once.
+func (apiProject *ProjectAPI) AddUnsupportedFeature(name string) {
+	apiProject.UnsupportedFeatures = appendUnsupportedFeature(apiProject.UnsupportedFeatures, name)
+}
+
+// GetUnsupportedFeatures returns the unsupported features found in the api.yaml and the other files of the API
+// project.
+func (apiProject *ProjectAPI) GetUnsupportedFeatures() []UnsupportedFeature {
+	features := make([]UnsupportedFeature, 0, len(apiProject.APIYaml.UnsupportedFeatures)+
+		len(apiProject.UnsupportedFeatures))
+	features = append(features, apiProject.APIYaml.UnsupportedFeatures...)
+	for _, feature := range apiProject.UnsupportedFeatures {
+		features = appendUnsupportedFeatureWithMessage(features, feature.Name, feature.Message)
+	}
+	return features
+}
+
+// AddUnsupportedFeatureWarnings reports the unsupported features of the API project as the parse warnings of the API,
+// hence those are returned along with the deployment result.
+func (swagger *MgwSwagger) AddUnsupportedFeatureWarnings(features []UnsupportedFeature) {
+	for _, feature := range features {
+		loggers.LoggerOasparser.Warnf("API %s:%s uses an unsupported feature. %s", swagger.title, swagger.version,
+			feature.Message)
+		swagger.addParseWarning(ParseWarningUnsupportedFeature, "%s", feature.Message)
+	}
+}
+
+func appendUnsupportedFeature(features []UnsupportedFeature, name string) []UnsupportedFeature {
+	return appendUnsupportedFeatureWithMessage(features, name, unsupportedFeatureMessages[name])
+}
+
+func appendUnsupportedFeatureWithMessage(features []UnsupportedFeature, name, message string) []UnsupportedFeature {
+	for _, feature := range features {
+		if feature.Name == name {
+			return features
+		}
+	}
+	return append(features, UnsupportedFeature{Name: name, Message: message})
+}
@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"strings"
+
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// IsUpstreamDecompressionEnabled returns true if the compressed responses of the backends of the API are
+// decompressed, before those are passed to the interceptors and the policies of the API.
+func (swagger *MgwSwagger) IsUpstreamDecompressionEnabled() bool {
+	return swagger.upstreamDecompression
+}
+
+// setXWso2UpstreamDecompression enables the decompression of the responses of the backends, if the extension is
+// provided in the API level.
+func (swagger *MgwSwagger) setXWso2UpstreamDecompression() {
+	swagger.upstreamDecompression, _ = getBoolExtension(swagger.vendorExtensions,
+		constants.XWso2UpstreamDecompression, false)
+}
+
+// AddUpstreamDecompressionWarnings reports a parse warning if the responses of the backends of the API are
+// decompressed, while none of the interceptors or the policies of the API read the response body. The responses
+// are then decompressed only to be sent to the clients uncompressed. This needs to be called once the policies of
+// the API are set.
+func (swagger *MgwSwagger) AddUpstreamDecompressionWarnings() {
+	if !swagger.upstreamDecompression || swagger.hasResponseBodyReaders() {
+		return
+	}
+	logger.LoggerOasparser.Warnf("%s is enabled for the API %s:%s, but none of its interceptors or policies read "+
+		"the response body", constants.XWso2UpstreamDecompression, swagger.title, swagger.version)
+	swagger.addParseWarning(ParseWarningIneffectiveDecompression, "%s is enabled, but none of the interceptors or "+
+		"policies of the API read the response body", constants.XWso2UpstreamDecompression)
+}
+
+// hasResponseBodyReaders returns true if the response body is included in a response interceptor of the API, its
+// resources or operations, or if an operation has a response policy transforming the payload.
+func (swagger *MgwSwagger) hasResponseBodyReaders() bool {
+	readsResponseBody := func(vendorExtensions map[string]interface{}, level string) bool {
+		if _, found := vendorExtensions[constants.XWso2ResponseInterceptor]; !found {
+			return false
+		}
+		interceptor := swagger.GetInterceptor(vendorExtensions, constants.XWso2ResponseInterceptor, level)
+		return interceptor.Enable && interceptor.Includes != nil && interceptor.Includes.ResponseBody
+	}
+
+	if readsResponseBody(swagger.vendorExtensions, constants.APILevelInterceptor) {
+		return true
+	}
+	for _, resource := range swagger.resources {
+		if readsResponseBody(resource.vendorExtensions, constants.ResourceLevelInterceptor) {
+			return true
+		}
+		for _, operation := range resource.methods {
+			if readsResponseBody(operation.vendorExtensions, constants.OperationLevelInterceptor) {
+				return true
+			}
+			interceptor := operation.GetCallInterceptorService(false)
+			if interceptor.Enable && interceptor.Includes != nil && interceptor.Includes.ResponseBody {
+				return true
+			}
+			for _, policy := range operation.policies.Response {
+				if strings.EqualFold(policy.Action, constants.ActionPayloadToJSON) ||
+					strings.EqualFold(policy.Action, constants.ActionPayloadToXML) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
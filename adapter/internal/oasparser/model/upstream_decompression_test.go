@@ -0,0 +1,112 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+func TestAddUpstreamDecompressionWarnings(t *testing.T) {
+	responseInterceptor := func(includes ...string) map[string]interface{} {
+		return map[string]interface{}{
+			constants.XWso2ResponseInterceptor: map[string]interface{}{
+				"serviceURL": "https://interceptor.petstore.io:8443",
+				"includes":   includes,
+			},
+		}
+	}
+	tests := []struct {
+		name                   string
+		upstreamDecompression  bool
+		apiExtensions          map[string]interface{}
+		operationExtensions    map[string]interface{}
+		responsePolicies       PolicyList
+		isDecompressionEnabled bool
+		isWarningExpected      bool
+	}{
+		{
+			name:                   "Decompression without interceptors and policies",
+			upstreamDecompression:  true,
+			isDecompressionEnabled: true,
+			isWarningExpected:      true,
+		},
+		{
+			name:                   "Decompression with an interceptor not reading the response body",
+			upstreamDecompression:  true,
+			apiExtensions:          responseInterceptor("response_headers"),
+			isDecompressionEnabled: true,
+			isWarningExpected:      true,
+		},
+		{
+			name:                   "Decompression with an API level interceptor reading the response body",
+			upstreamDecompression:  true,
+			apiExtensions:          responseInterceptor("response_headers", "response_body"),
+			isDecompressionEnabled: true,
+		},
+		{
+			name:                   "Decompression with an operation level interceptor reading the response body",
+			upstreamDecompression:  true,
+			operationExtensions:    responseInterceptor("response_body"),
+			isDecompressionEnabled: true,
+		},
+		{
+			name:                   "Decompression with a policy transforming the response payload",
+			upstreamDecompression:  true,
+			responsePolicies:       PolicyList{{PolicyName: "jsonToXML", Action: constants.ActionPayloadToXML}},
+			isDecompressionEnabled: true,
+		},
+		{
+			name: "Without decompression",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apiExtensions := map[string]interface{}{}
+			for name, value := range test.apiExtensions {
+				apiExtensions[name] = value
+			}
+			if test.upstreamDecompression {
+				apiExtensions[constants.XWso2UpstreamDecompression] = true
+			}
+			operation := NewOperation("GET", nil, test.operationExtensions)
+			operation.policies.Response = test.responsePolicies
+			mgwSwagger := MgwSwagger{
+				title:            "PetStore",
+				version:          "1.0.0",
+				vendorExtensions: apiExtensions,
+				resources:        []*Resource{{path: "/pets", methods: []*Operation{operation}}},
+			}
+			mgwSwagger.setXWso2UpstreamDecompression()
+			assert.Equal(t, test.isDecompressionEnabled, mgwSwagger.IsUpstreamDecompressionEnabled())
+
+			mgwSwagger.AddUpstreamDecompressionWarnings()
+			warnings := mgwSwagger.GetParseWarnings()
+			if !test.isWarningExpected {
+				assert.Empty(t, warnings)
+				return
+			}
+			if assert.Len(t, warnings, 1) {
+				assert.Equal(t, ParseWarningIneffectiveDecompression, warnings[0].Code)
+				assert.Contains(t, warnings[0].Message, constants.XWso2UpstreamDecompression)
+			}
+		})
+	}
+}
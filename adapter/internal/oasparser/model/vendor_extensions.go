@@ -0,0 +1,122 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+// This file registers the vendor extensions consumed when populating the mgwSwagger object, with the types
+// their values are decoded to. New vendor extensions should be registered here and read using extensions.Extract.
+
+import (
+	"fmt"
+
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/extensions"
+)
+
+// interceptorExtension represents the x-wso2-request-interceptor and x-wso2-response-interceptor extensions.
+// The timeouts are accepted either as numbers or strings, hence those are parsed separately.
+type interceptorExtension struct {
+	ServiceURL     string      `mapstructure:"serviceURL"`
+	ClusterTimeout interface{} `mapstructure:"clusterTimeout"`
+	RequestTimeout interface{} `mapstructure:"requestTimeout"`
+	Includes       []string    `mapstructure:"includes"`
+}
+
+func init() {
+	extensions.Register[string](constants.XAuthHeader, nil)
+	extensions.Register[string](constants.XWso2BasePath, nil)
+	extensions.Register[bool](constants.XWso2HTTP2BackendEnabled, nil)
+	extensions.Register[string](constants.XWso2ThrottlingTier, nil)
+	extensions.Register[string](constants.XThrottlingTier, nil)
+	extensions.Register[string](constants.XAmznResourceName, nil)
+	extensions.Register[string](constants.XAuthType, nil)
+	extensions.Register[bool](constants.XWso2DisableSecurity, nil)
+	extensions.Register[bool](constants.XWso2PassRequestPayloadToEnforcer, nil)
+	extensions.Register[bool](constants.XWso2StripAuthHeader, nil)
+	extensions.Register[bool](constants.XWso2UpstreamDecompression, nil)
+	extensions.Register[bool](constants.XWso2HTTP3, nil)
+	extensions.Register[[]string](constants.XWso2Label, nil)
+	extensions.Register[[]string](constants.XWso2ExcludeOnGateways, nil)
+	extensions.Register[[]string](constants.XScopes, nil)
+	extensions.Register[[]string](constants.XWso2StripRequestHeaders, nil)
+	extensions.Register[[]string](constants.XWso2ContextAliases, nil)
+	extensions.Register[[]string](constants.XWso2PublicPaths, nil)
+	extensions.Register[string](constants.XWso2AnonymousTier, nil)
+	extensions.Register(constants.XWso2MockResponseSelection, validateMockResponseSelection)
+	extensions.Register(constants.XWso2AnalyticsProperties, validateAnalyticsProperties)
+	extensions.Register(constants.XWso2FallbackEndpoint, validateFallbackEndpointURL)
+	extensions.Register[string](constants.XUriMapping, nil)
+	extensions.Register[bool](constants.XWso2WebSocket, nil)
+	extensions.Register[uint32](constants.XWso2WebSocketIdleTimeout, nil)
+	extensions.Register[CorsConfig](constants.XWso2Cors, nil)
+	extensions.Register[UnmatchedRequestsConfig](constants.XWso2UnmatchedRequests, nil)
+	extensions.Register(constants.XWso2NotFoundResponse, validateNotFoundResponseConfig)
+	extensions.Register(constants.XWso2RequestInterceptor, validateInterceptorExtension)
+	extensions.Register(constants.XWso2ResponseInterceptor, validateInterceptorExtension)
+}
+
+func validateNotFoundResponseConfig(notFoundResponseConfig NotFoundResponseConfig) error {
+	if notFoundResponseConfig.StatusCode < 400 || notFoundResponseConfig.StatusCode > 599 {
+		return &extensions.ValidationError{
+			Path: "statusCode",
+			Message: fmt.Sprintf("invalid status code %v. Status code should be within 400 and 599",
+				notFoundResponseConfig.StatusCode),
+		}
+	}
+	return nil
+}
+
+func validateInterceptorExtension(interceptor interceptorExtension) error {
+	if interceptor.ServiceURL == "" {
+		return &extensions.ValidationError{Path: constants.ServiceURL, Message: "service url is mandatory"}
+	}
+	return nil
+}
+
+// getStringExtension returns the value of a vendor extension registered as a string. If the vendor extension is not
+// available or invalid, an empty string is returned.
+func getStringExtension(vendorExtensions map[string]interface{}, name string) (string, bool) {
+	var value string
+	found, err := extensions.Extract(vendorExtensions, name, &value)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while parsing %v. %v", name, err)
+	}
+	return value, found && err == nil
+}
+
+// getBoolExtension returns the value of a vendor extension registered as a boolean. If the vendor extension is not
+// available or invalid, defaultValue is returned.
+func getBoolExtension(vendorExtensions map[string]interface{}, name string, defaultValue bool) (bool, bool) {
+	value := defaultValue
+	found, err := extensions.Extract(vendorExtensions, name, &value)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while parsing %v. %v", name, err)
+	}
+	return value, found && err == nil
+}
+
+// getStringArrayExtension returns the value of a vendor extension registered as a string array. If the vendor
+// extension is not available or invalid, nil is returned.
+func getStringArrayExtension(vendorExtensions map[string]interface{}, name string) ([]string, bool) {
+	var value []string
+	found, err := extensions.Extract(vendorExtensions, name, &value)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while parsing %v. %v", name, err)
+	}
+	return value, found && err == nil
+}
@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/extensions"
+)
+
+func TestMalformedVendorExtensions(t *testing.T) {
+	vendorExtensions := map[string]interface{}{
+		constants.XWso2BasePath:          12,
+		constants.XWso2DisableSecurity:   "true",
+		constants.XAuthType:              constants.None,
+		constants.XScopes:                []interface{}{"read", 1},
+		constants.XWso2ExcludeOnGateways: "Default",
+		constants.XWso2NotFoundResponse:  map[string]interface{}{"statusCode": 200},
+		constants.XWso2Cors:              []interface{}{"http://test.com"},
+		constants.XWso2RequestInterceptor: map[string]interface{}{
+			"includes": []interface{}{"request_headers"},
+		},
+	}
+	assert.Equal(t, "", getXWso2Basepath(vendorExtensions))
+	assert.True(t, ResolveDisableSecurity(vendorExtensions),
+		"x-auth-type should be considered when x-wso2-disable-security is invalid")
+	assert.Nil(t, getSecurityArray(vendorExtensions))
+	_, excluded := getExcludedGatewayLabel(vendorExtensions, []string{"Default"})
+	assert.False(t, excluded)
+
+	swagger := MgwSwagger{vendorExtensions: vendorExtensions}
+	swagger.setXWso2NotFoundResponse()
+	assert.Nil(t, swagger.xWso2NotFoundResponse)
+	swagger.setXWso2Cors()
+	assert.Nil(t, swagger.xWso2Cors)
+	interceptor := swagger.GetInterceptor(vendorExtensions, constants.XWso2RequestInterceptor,
+		constants.OperationLevelInterceptor)
+	assert.False(t, interceptor.Enable, "interceptor without the service url should not be enabled")
+}
+
+func FuzzVendorExtensions(f *testing.F) {
+	f.Add(`{"corsConfigurationEnabled": true, "accessControlAllowOrigins": ["*"], "statusCode": 404}`)
+	f.Add(`{"serviceURL": "https://interceptor:8443", "clusterTimeout": "abc", "includes": [1, "request_body"]}`)
+	f.Add(`{"serviceURL": 8443, "requestTimeout": 20, "includes": "request_body"}`)
+	f.Add(`{"statusCode": -404, "body": {}, "contentType": null}`)
+	f.Add(`["Default", 1, null, {"a": "b"}]`)
+	f.Add(`"None"`)
+	f.Add(`true`)
+	f.Add(`null`)
+	f.Fuzz(func(t *testing.T, rawJSON string) {
+		var rawValue interface{}
+		if err := json.Unmarshal([]byte(rawJSON), &rawValue); err != nil {
+			t.Skip()
+		}
+		vendorExtensions := make(map[string]interface{})
+		for _, name := range extensions.RegisteredExtensions() {
+			vendorExtensions[name] = rawValue
+		}
+		getXWso2AuthHeader(vendorExtensions)
+		getXWso2Basepath(vendorExtensions)
+		getXWso2HTTP2BackendEnabled(vendorExtensions)
+		ResolveThrottlingTier(vendorExtensions)
+		ResolveAmznResourceName(vendorExtensions)
+		ResolveDisableSecurity(vendorExtensions)
+		getRequestBodyBufferConfig(vendorExtensions)
+		getSecurityArray(vendorExtensions)
+		getExcludedGatewayLabel(vendorExtensions, []string{"Default"})
+		NewOperation("GET", nil, vendorExtensions)
+		populatePoliciesFromVendorExtensions(&Operation{}, vendorExtensions)
+
+		swagger := MgwSwagger{vendorExtensions: vendorExtensions}
+		swagger.setXWso2Basepath()
+		swagger.setXWso2HTTP2BackendEnabled()
+		swagger.setXWso2Cors()
+		swagger.setXWso2NotFoundResponse()
+		swagger.GetInterceptor(vendorExtensions, constants.XWso2RequestInterceptor, constants.OperationLevelInterceptor)
+		swagger.GetInterceptor(vendorExtensions, constants.XWso2ResponseInterceptor, constants.OperationLevelInterceptor)
+	})
+}
@@ -322,7 +322,12 @@ func processArtifactChanges() (err error) {
 		}
 
 		for vhost, environments := range vhostToEnvsMap {
-			err = xds.DeleteAPIs(vhost, apiYaml.Name, apiYaml.Version, environments, apiProject.APIYaml.Data.OrganizationID)
+			err = xds.ExecuteInDeploymentQueueBlocking(xds.GenerateIdentifierForAPIWithoutVhost(apiYaml.Name, apiYaml.Version),
+				func() error {
+					_, err := xds.DeleteAPIs(vhost, apiYaml.Name, apiYaml.Version, environments,
+						apiProject.APIYaml.Data.OrganizationID)
+					return err
+				})
 
 			if err != nil {
 				loggers.LoggerSourceWatcher.ErrorC(logging.ErrorDetails{
@@ -27,12 +27,16 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/wso2/product-microgateway/adapter/config"
 	"github.com/wso2/product-microgateway/adapter/internal/common"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
 	"github.com/wso2/product-microgateway/adapter/internal/notifier"
 	"github.com/wso2/product-microgateway/adapter/pkg/health"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
 
 	apiServer "github.com/wso2/product-microgateway/adapter/internal/api"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
@@ -50,6 +54,8 @@ func init() {
 		conf.ControlPlane.RequestWorkerPool.PauseTimeAfterFailure, conf.Adapter.Truststore.Location,
 		conf.ControlPlane.SkipSSLVerification, conf.ControlPlane.HTTPClient.RequestTimeOut, conf.ControlPlane.RetryInterval,
 		conf.ControlPlane.ServiceURL, conf.ControlPlane.Username, conf.ControlPlane.Password)
+	sync.SetArtifactDownloadParameters(conf.ControlPlane.ArtifactDownload.MaxAttempts,
+		conf.ControlPlane.ArtifactDownload.RetryBackoff, conf.ControlPlane.ArtifactDownload.MaxRetryBackoff)
 }
 
 // PushAPIProjects configure the router and enforcer using the zip containing API project(s) as
@@ -57,14 +63,35 @@ func init() {
 // downloaded apis.zip one by one.
 // If the updating envoy or enforcer fails, this method returns an error, if not error would be nil.
 func PushAPIProjects(payload []byte, environments []string) error {
-	var deploymentList []*notifier.DeployedAPIRevision
 	// Reading the root zip
 	zipReader, err := zip.NewReader(bytes.NewReader(payload), int64(len(payload)))
 	if err != nil {
 		logger.LoggerSync.Errorf("Error occurred while unzipping the apictl project. Error: %v", err.Error())
 		return err
 	}
+	return pushAPIProjects(zipReader, environments)
+}
+
+// PushAPIProjectsFromFile configures the router and enforcer using the zip file containing API project(s), which is
+// downloaded from the control plane. The file is removed once the API projects are pushed.
+func PushAPIProjectsFromFile(filePath string, environments []string) error {
+	defer func() {
+		if err := os.Remove(filePath); err != nil {
+			logger.LoggerSync.Warnf("Error occurred while removing the downloaded file %s. Error: %v", filePath, err)
+		}
+	}()
+	// Reading the root zip, without loading the whole file into the memory
+	zipFile, err := zip.OpenReader(filePath)
+	if err != nil {
+		logger.LoggerSync.Errorf("Error occurred while unzipping the apictl project. Error: %v", err.Error())
+		return err
+	}
+	defer zipFile.Close()
+	return pushAPIProjects(&zipFile.Reader, environments)
+}
 
+func pushAPIProjects(zipReader *zip.Reader, environments []string) error {
+	var deploymentList []*notifier.DeployedAPIRevision
 	// Read deployments from deployment.json file
 	deploymentDescriptor, envProps, err := sync.ReadRootFiles(zipReader)
 	if err != nil {
@@ -112,9 +139,22 @@ func PushAPIProjects(payload []byte, environments []string) error {
 		// Pass the byte slice for the XDS APIs to push it to the enforcer and router
 		// Updating cache one API by one API, if one API failed to update cache continue with others.
 		var deployedRevisionList []*notifier.DeployedAPIRevision
-		deployedRevisionList, err = apiServer.ApplyAPIProjectFromAPIM(apiFileData, vhostToEnvsMap, envProps)
+		deployedRevisionList, err = apiServer.ApplyAPIProjectFromAPIM(apiFileData, vhostToEnvsMap, envProps, false)
+		for err == xds.ErrDeploymentQueueFull {
+			logger.LoggerSync.Infof("Deployment queue is full. Retrying to apply project (API_ID:REVISION_ID).zip : %v "+
+				"after %v", file.Name, xds.GetDeploymentQueueRetryAfter())
+			time.Sleep(xds.GetDeploymentQueueRetryAfter())
+			deployedRevisionList, err = apiServer.ApplyAPIProjectFromAPIM(apiFileData, vhostToEnvsMap, envProps, false)
+		}
 		if err != nil {
-			logger.LoggerSync.Errorf("Error occurred while applying project (API_ID:REVISION_ID).zip : %v, Error : %v", file.Name, err)
+			// The same revision of a broken API could be pushed repeatedly, hence the errors are aggregated by the
+			// API file, which identifies the API and the revision.
+			logger.LogAPIError(&logger.LoggerSync, strings.TrimSuffix(deployment.APIFile, zipExt), logging.ErrorDetails{
+				Message: fmt.Sprintf("Error occurred while applying project (API_ID:REVISION_ID).zip : %v, Error : %v",
+					file.Name, err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 2800,
+			})
 		} else if deployedRevisionList != nil {
 			deploymentList = append(deploymentList, deployedRevisionList...)
 		}
@@ -162,6 +202,7 @@ func FetchAPIsFromControlPlane(updatedAPIID string, updatedEnvs []string) {
 		return
 	}
 
+	waitForDeploymentQueueCapacity()
 	c := make(chan sync.SyncAPIResponse)
 	logger.LoggerSync.Infof("API %s is added/updated to APIList for label %v", updatedAPIID, updatedEnvs)
 	var queryParamMap map[string]string
@@ -170,10 +211,10 @@ func FetchAPIsFromControlPlane(updatedAPIID string, updatedEnvs []string) {
 	for {
 		data := <-c
 		logger.LoggerSync.Debugf("Receiving data for the API: %q", updatedAPIID)
-		if data.Resp != nil {
-			// For successfull fetches, data.Resp would return a byte slice with API project(s)
+		if data.RespFile != "" {
+			// For successfull fetches, data.RespFile would be the downloaded zip file with API project(s)
 			logger.LoggerSync.Infof("Pushing data to router and enforcer for the API %q", updatedAPIID)
-			err := PushAPIProjects(data.Resp, finalEnvs)
+			err := PushAPIProjectsFromFile(data.RespFile, finalEnvs)
 			if err != nil {
 				logger.LoggerSync.Errorf("Error occurred while pushing API data for the API %q: %v ", updatedAPIID, err)
 			}
@@ -189,3 +230,13 @@ func FetchAPIsFromControlPlane(updatedAPIID string, updatedEnvs []string) {
 	}
 
 }
+
+// waitForDeploymentQueueCapacity delays fetching the APIs from the control plane while the deployment queue is full,
+// so that the fetched APIs are not rejected by the deployment queue.
+func waitForDeploymentQueueCapacity() {
+	for xds.IsDeploymentQueueFull() {
+		logger.LoggerSync.Infof("Deployment queue is full. Delaying fetching APIs from control plane by %v",
+			xds.GetDeploymentQueueRetryAfter())
+		time.Sleep(xds.GetDeploymentQueueRetryAfter())
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package testutil contains the utilities shared by the tests of the adapter, such as comparing the discovery
+// resources constructed for an API with the golden files.
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// update regenerates the golden files from the actual values instead of comparing those, when the tests are run
+// with the -update flag (e.g. go test ./internal/api/... -run Golden -update).
+var update = flag.Bool("update", false, "update the golden files with the actual values")
+
+// CanonicalJSON serializes the discovery resource into indented JSON, which is the same whenever the same resource is
+// serialized. The fields are named by their names in the proto definitions and the unpopulated fields are included,
+// so that a field added to the resource shows up in the golden files. The keys of the objects, including the keys of
+// the proto maps, are sorted.
+func CanonicalJSON(message proto.Message) ([]byte, error) {
+	// protojson deliberately varies its whitespace between the builds, hence the output is parsed and formatted again
+	marshalled, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(marshalled))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	var canonical bytes.Buffer
+	encoder := json.NewEncoder(&canonical)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	// maps are encoded with the sorted keys
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return canonical.Bytes(), nil
+}
+
+// AssertGolden checks whether the actual value is the same as the content of the golden file. The golden file is
+// written with the actual value instead, if the tests are run with the -update flag.
+func AssertGolden(t *testing.T, goldenFile string, actual []byte) bool {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(goldenFile), 0755); err != nil {
+			t.Fatalf("Error while creating the directory of the golden file %v. %v", goldenFile, err)
+		}
+		if err := ioutil.WriteFile(goldenFile, actual, 0644); err != nil {
+			t.Fatalf("Error while writing the golden file %v. %v", goldenFile, err)
+		}
+		return true
+	}
+	expected, err := ioutil.ReadFile(goldenFile)
+	if err != nil {
+		t.Errorf("Error while reading the golden file %v. Run the test with -update to create it. %v", goldenFile, err)
+		return false
+	}
+	if bytes.Equal(expected, actual) {
+		return true
+	}
+	t.Errorf("Actual value differs from the golden file %v. Run the test with -update if the change is expected.\n%s",
+		goldenFile, firstDifference(string(expected), string(actual)))
+	return false
+}
+
+// firstDifference describes the first line which differs between the expected and the actual values.
+func firstDifference(expected, actual string) string {
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+	for i := 0; i < len(expectedLines) || i < len(actualLines); i++ {
+		expectedLine, actualLine := "<EOF>", "<EOF>"
+		if i < len(expectedLines) {
+			expectedLine = expectedLines[i]
+		}
+		if i < len(actualLines) {
+			actualLine = actualLines[i]
+		}
+		if expectedLine != actualLine {
+			return fmt.Sprintf("line %d\n  expected: %s\n  actual:   %s", i+1, expectedLine, actualLine)
+		}
+	}
+	return ""
+}
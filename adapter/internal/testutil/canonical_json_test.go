@@ -0,0 +1,49 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/api"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	message := &api.MockedHeaderConfig{Name: "x-mock", Value: "<a&b>"}
+	expected := "{\n  \"name\": \"x-mock\",\n  \"value\": \"<a&b>\"\n}\n"
+
+	for i := 0; i < 5; i++ {
+		actual, err := CanonicalJSON(message)
+		assert.Nil(t, err, "Error while serializing the message")
+		assert.Equal(t, expected, string(actual), "Unexpected canonical JSON")
+	}
+
+	actual, err := CanonicalJSON(&api.MockedHeaderConfig{})
+	assert.Nil(t, err, "Error while serializing the message")
+	assert.Equal(t, "{\n  \"name\": \"\",\n  \"value\": \"\"\n}\n", string(actual),
+		"Unpopulated fields should be serialized")
+}
+
+func TestFirstDifference(t *testing.T) {
+	assert.Equal(t, "", firstDifference("a\nb", "a\nb"), "Same values should not differ")
+	assert.Equal(t, "line 2\n  expected: b\n  actual:   c", firstDifference("a\nb", "a\nc"),
+		"Unexpected difference")
+	assert.Equal(t, "line 3\n  expected: <EOF>\n  actual:   c", firstDifference("a\nb", "a\nb\nc"),
+		"Unexpected difference of the longer value")
+}
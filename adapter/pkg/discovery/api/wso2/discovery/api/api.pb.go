@@ -42,31 +42,32 @@ type Api struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id                    string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title                 string               `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Version               string               `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
-	ApiType               string               `protobuf:"bytes,4,opt,name=apiType,proto3" json:"apiType,omitempty"`
-	Description           string               `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	ProductionEndpoints   *EndpointCluster     `protobuf:"bytes,6,opt,name=productionEndpoints,proto3" json:"productionEndpoints,omitempty"`
-	SandboxEndpoints      *EndpointCluster     `protobuf:"bytes,7,opt,name=sandboxEndpoints,proto3" json:"sandboxEndpoints,omitempty"`
-	Resources             []*Resource          `protobuf:"bytes,8,rep,name=resources,proto3" json:"resources,omitempty"`
-	BasePath              string               `protobuf:"bytes,9,opt,name=basePath,proto3" json:"basePath,omitempty"`
-	Tier                  string               `protobuf:"bytes,10,opt,name=tier,proto3" json:"tier,omitempty"`
-	ApiLifeCycleState     string               `protobuf:"bytes,11,opt,name=apiLifeCycleState,proto3" json:"apiLifeCycleState,omitempty"`
-	SecurityScheme        []*SecurityScheme    `protobuf:"bytes,12,rep,name=securityScheme,proto3" json:"securityScheme,omitempty"`
-	Security              []*SecurityList      `protobuf:"bytes,13,rep,name=security,proto3" json:"security,omitempty"`
-	EndpointSecurity      *EndpointSecurity    `protobuf:"bytes,14,opt,name=endpointSecurity,proto3" json:"endpointSecurity,omitempty"`
-	AuthorizationHeader   string               `protobuf:"bytes,15,opt,name=authorizationHeader,proto3" json:"authorizationHeader,omitempty"`
-	DisableSecurity       bool                 `protobuf:"varint,16,opt,name=disableSecurity,proto3" json:"disableSecurity,omitempty"`
-	Vhost                 string               `protobuf:"bytes,17,opt,name=vhost,proto3" json:"vhost,omitempty"`
-	OrganizationId        string               `protobuf:"bytes,18,opt,name=organizationId,proto3" json:"organizationId,omitempty"`
-	IsMockedApi           bool                 `protobuf:"varint,19,opt,name=isMockedApi,proto3" json:"isMockedApi,omitempty"`
-	ClientCertificates    []*Certificate       `protobuf:"bytes,20,rep,name=clientCertificates,proto3" json:"clientCertificates,omitempty"`
-	MutualSSL             string               `protobuf:"bytes,21,opt,name=mutualSSL,proto3" json:"mutualSSL,omitempty"`
-	ApplicationSecurity   bool                 `protobuf:"varint,22,opt,name=applicationSecurity,proto3" json:"applicationSecurity,omitempty"`
-	GraphQLSchema         string               `protobuf:"bytes,23,opt,name=graphQLSchema,proto3" json:"graphQLSchema,omitempty"`
-	GraphqlComplexityInfo []*GraphqlComplexity `protobuf:"bytes,24,rep,name=graphqlComplexityInfo,proto3" json:"graphqlComplexityInfo,omitempty"`
-	EndpointType          string               `protobuf:"bytes,25,opt,name=endpointType,proto3" json:"endpointType,omitempty"`
+	Id                            string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title                         string               `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Version                       string               `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	ApiType                       string               `protobuf:"bytes,4,opt,name=apiType,proto3" json:"apiType,omitempty"`
+	Description                   string               `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	ProductionEndpoints           *EndpointCluster     `protobuf:"bytes,6,opt,name=productionEndpoints,proto3" json:"productionEndpoints,omitempty"`
+	SandboxEndpoints              *EndpointCluster     `protobuf:"bytes,7,opt,name=sandboxEndpoints,proto3" json:"sandboxEndpoints,omitempty"`
+	Resources                     []*Resource          `protobuf:"bytes,8,rep,name=resources,proto3" json:"resources,omitempty"`
+	BasePath                      string               `protobuf:"bytes,9,opt,name=basePath,proto3" json:"basePath,omitempty"`
+	Tier                          string               `protobuf:"bytes,10,opt,name=tier,proto3" json:"tier,omitempty"`
+	ApiLifeCycleState             string               `protobuf:"bytes,11,opt,name=apiLifeCycleState,proto3" json:"apiLifeCycleState,omitempty"`
+	SecurityScheme                []*SecurityScheme    `protobuf:"bytes,12,rep,name=securityScheme,proto3" json:"securityScheme,omitempty"`
+	Security                      []*SecurityList      `protobuf:"bytes,13,rep,name=security,proto3" json:"security,omitempty"`
+	EndpointSecurity              *EndpointSecurity    `protobuf:"bytes,14,opt,name=endpointSecurity,proto3" json:"endpointSecurity,omitempty"`
+	AuthorizationHeader           string               `protobuf:"bytes,15,opt,name=authorizationHeader,proto3" json:"authorizationHeader,omitempty"`
+	DisableSecurity               bool                 `protobuf:"varint,16,opt,name=disableSecurity,proto3" json:"disableSecurity,omitempty"`
+	Vhost                         string               `protobuf:"bytes,17,opt,name=vhost,proto3" json:"vhost,omitempty"`
+	OrganizationId                string               `protobuf:"bytes,18,opt,name=organizationId,proto3" json:"organizationId,omitempty"`
+	IsMockedApi                   bool                 `protobuf:"varint,19,opt,name=isMockedApi,proto3" json:"isMockedApi,omitempty"`
+	ClientCertificates            []*Certificate       `protobuf:"bytes,20,rep,name=clientCertificates,proto3" json:"clientCertificates,omitempty"`
+	MutualSSL                     string               `protobuf:"bytes,21,opt,name=mutualSSL,proto3" json:"mutualSSL,omitempty"`
+	ApplicationSecurity           bool                 `protobuf:"varint,22,opt,name=applicationSecurity,proto3" json:"applicationSecurity,omitempty"`
+	GraphQLSchema                 string               `protobuf:"bytes,23,opt,name=graphQLSchema,proto3" json:"graphQLSchema,omitempty"`
+	GraphqlComplexityInfo         []*GraphqlComplexity `protobuf:"bytes,24,rep,name=graphqlComplexityInfo,proto3" json:"graphqlComplexityInfo,omitempty"`
+	EndpointType                  string               `protobuf:"bytes,25,opt,name=endpointType,proto3" json:"endpointType,omitempty"`
+	DisableSubscriptionValidation bool                 `protobuf:"varint,26,opt,name=disableSubscriptionValidation,proto3" json:"disableSubscriptionValidation,omitempty"`
 }
 
 func (x *Api) Reset() {
@@ -276,6 +277,13 @@ func (x *Api) GetEndpointType() string {
 	return ""
 }
 
+func (x *Api) GetDisableSubscriptionValidation() bool {
+	if x != nil {
+		return x.DisableSubscriptionValidation
+	}
+	return false
+}
+
 var File_wso2_discovery_api_api_proto protoreflect.FileDescriptor
 
 var file_wso2_discovery_api_api_proto_rawDesc = []byte{
@@ -296,7 +304,7 @@ var file_wso2_discovery_api_api_proto_rawDesc = []byte{
 	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x43, 0x65, 0x72, 0x74, 0x69,
 	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x77, 0x73,
 	0x6f, 0x32, 0x2f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69,
-	0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x71, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa3,
+	0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x71, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe9,
 	0x09, 0x0a, 0x03, 0x41, 0x70, 0x69, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07,
@@ -371,14 +379,19 @@ var file_wso2_discovery_api_api_proto_rawDesc = []byte{
 	0x71, 0x6c, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x78, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f,
 	0x12, 0x22, 0x0a, 0x0c, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
 	0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x42, 0x72, 0x0a, 0x25, 0x6f, 0x72, 0x67, 0x2e, 0x77, 0x73, 0x6f, 0x32,
-	0x2e, 0x63, 0x68, 0x6f, 0x72, 0x65, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x2e,
-	0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x61, 0x70, 0x69, 0x42, 0x08, 0x41,
-	0x70, 0x69, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x6e, 0x76, 0x6f, 0x79, 0x70, 0x72, 0x6f, 0x78, 0x79,
-	0x2f, 0x67, 0x6f, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2d, 0x70, 0x6c, 0x61, 0x6e,
-	0x65, 0x2f, 0x77, 0x73, 0x6f, 0x32, 0x2f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
-	0x2f, 0x61, 0x70, 0x69, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x44, 0x0a, 0x1d, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1d, 0x64, 0x69, 0x73,
+	0x61, 0x62, 0x6c, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x72, 0x0a, 0x25, 0x6f, 0x72,
+	0x67, 0x2e, 0x77, 0x73, 0x6f, 0x32, 0x2e, 0x63, 0x68, 0x6f, 0x72, 0x65, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x2e, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e,
+	0x61, 0x70, 0x69, 0x42, 0x08, 0x41, 0x70, 0x69, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a,
+	0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x6e, 0x76, 0x6f,
+	0x79, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2f, 0x67, 0x6f, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x2d, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2f, 0x77, 0x73, 0x6f, 0x32, 0x2f, 0x64, 0x69, 0x73,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
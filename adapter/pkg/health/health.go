@@ -21,6 +21,8 @@ import (
 	"context"
 	healthservice "github.com/wso2/product-microgateway/adapter/pkg/health/api/wso2/health/service"
 	logger "github.com/wso2/product-microgateway/adapter/pkg/loggers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"sync"
 )
 
@@ -31,6 +33,14 @@ var (
 		false: "UNHEALTHY",
 	}
 	mutexForHealthUpdate sync.Mutex
+	readOnlyMode         bool
+)
+
+// Header in the health check response which marks the mode of the adapter
+const (
+	adapterModeHeader string = "adapter-mode"
+	readOnlyModeValue string = "read-only"
+	activeModeValue   string = "active"
 )
 
 // Service components to be set health status
@@ -48,6 +58,14 @@ func (s service) SetStatus(isHealthy bool) {
 	serviceHealthStatus[string(s)] = isHealthy
 }
 
+// SetReadOnlyMode marks whether the adapter is running as a passive replica. The mode is sent in the
+// "adapter-mode" header of the health check responses and does not affect the health status.
+func SetReadOnlyMode(isReadOnly bool) {
+	mutexForHealthUpdate.Lock()
+	defer mutexForHealthUpdate.Unlock()
+	readOnlyMode = isReadOnly
+}
+
 // Server represents the Health GRPC server
 type Server struct {
 	healthservice.UnimplementedHealthServer
@@ -58,6 +76,16 @@ func (s Server) Check(ctx context.Context, request *healthservice.HealthCheckReq
 	logger.LoggerHealth.Debugf("Querying health state for Adapter service \"%s\"", request.Service)
 	logger.LoggerHealth.Debugf("Internal health state map: %v", serviceHealthStatus)
 
+	mutexForHealthUpdate.Lock()
+	adapterMode := activeModeValue
+	if readOnlyMode {
+		adapterMode = readOnlyModeValue
+	}
+	mutexForHealthUpdate.Unlock()
+	if err := grpc.SetHeader(ctx, metadata.Pairs(adapterModeHeader, adapterMode)); err != nil {
+		logger.LoggerHealth.Debugf("Error while setting the adapter mode header in the health check response: %v", err)
+	}
+
 	if request.Service == "" {
 		// overall health of the server
 		isHealthy := true
@@ -85,6 +85,75 @@ var (
 		Name: "process_open_fds",
 		Help: "Number of open file descriptors.",
 	})
+
+	deploymentQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "adapter_deployment_queue_depth",
+		Help: "Number of deployment tasks waiting in the deployment queue.",
+	})
+
+	deploymentQueueWaitTime = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name: "adapter_deployment_queue_wait_seconds",
+		Help: "Time deployment tasks waited in the deployment queue before being applied.",
+	})
+
+	xdsSnapshotSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "adapter_xds_snapshot_size_bytes",
+		Help: "Serialized size of the latest router configuration snapshot of the label.",
+	}, []string{"label"})
+
+	apiXdsResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "adapter_api_xds_resources",
+		Help: "Number of router resources of the given type generated for the API.",
+	}, []string{"organization", "api", "resource"})
+
+	apiXdsSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "adapter_api_xds_size_bytes",
+		Help: "Serialized size of the router resources generated for the API.",
+	}, []string{"organization", "api"})
+
+	organizationXdsResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "adapter_organization_xds_resources",
+		Help: "Number of router resources of the given type generated for the APIs of the organization.",
+	}, []string{"organization", "resource"})
+
+	organizationXdsSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "adapter_organization_xds_size_bytes",
+		Help: "Serialized size of the router resources generated for the APIs of the organization.",
+	}, []string{"organization"})
+
+	organizationDeployedAPIs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "adapter_organization_deployed_apis",
+		Help: "Number of APIs deployed by the organization, counted against the API quota of the organization.",
+	}, []string{"organization"})
+
+	artifactDownloadRetries = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "adapter_artifact_download_retries_total",
+		Help: "Number of times the runtime artifacts were downloaded again from the control plane, as the " +
+			"downloaded bytes could not be verified.",
+	})
+
+	artifactDownloadBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "adapter_artifact_download_bytes_total",
+		Help: "Number of bytes of the runtime artifacts downloaded from the control plane.",
+	})
+
+	routeRegexCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "adapter_route_regex_cache_requests_total",
+		Help: "Number of route regexes looked up in the route regex cache, by whether those were found (hit) or " +
+			"generated (miss).",
+	}, []string{"result"})
+
+	routeRegexCacheEntries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "adapter_route_regex_cache_entries",
+		Help: "Number of route regexes in the route regex cache.",
+	})
+)
+
+// Types of the router resources counted in the resource usage metrics
+const (
+	xdsResourceRoute    = "route"
+	xdsResourceCluster  = "cluster"
+	xdsResourceEndpoint = "endpoint"
 )
 
 func init() {
@@ -94,7 +163,88 @@ func init() {
 
 	// Register other metrics
 	prometheusMetricRegistry.MustRegister(hostInfo, availableCPUs, freePhysicalMemory, usedVirtualMemory, totalVirtualMemory,
-		systemCPULoad, loadAvg, processStartTime, processOpenFDs)
+		systemCPULoad, loadAvg, processStartTime, processOpenFDs, deploymentQueueDepth, deploymentQueueWaitTime,
+		xdsSnapshotSize, apiXdsResources, apiXdsSize, organizationXdsResources, organizationXdsSize,
+		organizationDeployedAPIs, artifactDownloadRetries, artifactDownloadBytes, routeRegexCacheRequests,
+		routeRegexCacheEntries)
+}
+
+// SetDeploymentQueueDepth records the number of deployment tasks waiting in the deployment queue.
+func SetDeploymentQueueDepth(depth int) {
+	deploymentQueueDepth.Set(float64(depth))
+}
+
+// ObserveDeploymentQueueWaitTime records the time a deployment task waited in the deployment queue.
+func ObserveDeploymentQueueWaitTime(waitTime time.Duration) {
+	deploymentQueueWaitTime.Observe(waitTime.Seconds())
+}
+
+// SetXdsSnapshotSize records the serialized size of the latest router configuration snapshot of the label.
+func SetXdsSnapshotSize(label string, sizeInBytes int) {
+	xdsSnapshotSize.WithLabelValues(label).Set(float64(sizeInBytes))
+}
+
+// SetAPIXdsResourceUsage records the number and the serialized size of the router resources generated for the API.
+func SetAPIXdsResourceUsage(organizationID, apiIdentifier string, routes, clusters, endpoints, sizeInBytes int) {
+	apiXdsResources.WithLabelValues(organizationID, apiIdentifier, xdsResourceRoute).Set(float64(routes))
+	apiXdsResources.WithLabelValues(organizationID, apiIdentifier, xdsResourceCluster).Set(float64(clusters))
+	apiXdsResources.WithLabelValues(organizationID, apiIdentifier, xdsResourceEndpoint).Set(float64(endpoints))
+	apiXdsSize.WithLabelValues(organizationID, apiIdentifier).Set(float64(sizeInBytes))
+}
+
+// DeleteAPIXdsResourceUsage removes the resource usage metrics of an undeployed API.
+func DeleteAPIXdsResourceUsage(organizationID, apiIdentifier string) {
+	apiXdsResources.DeletePartialMatch(prometheus.Labels{"organization": organizationID, "api": apiIdentifier})
+	apiXdsSize.DeleteLabelValues(organizationID, apiIdentifier)
+}
+
+// SetOrganizationXdsResourceUsage records the number and the serialized size of the router resources generated
+// for the APIs of the organization.
+func SetOrganizationXdsResourceUsage(organizationID string, routes, clusters, endpoints, sizeInBytes int) {
+	organizationXdsResources.WithLabelValues(organizationID, xdsResourceRoute).Set(float64(routes))
+	organizationXdsResources.WithLabelValues(organizationID, xdsResourceCluster).Set(float64(clusters))
+	organizationXdsResources.WithLabelValues(organizationID, xdsResourceEndpoint).Set(float64(endpoints))
+	organizationXdsSize.WithLabelValues(organizationID).Set(float64(sizeInBytes))
+}
+
+// DeleteOrganizationXdsResourceUsage removes the resource usage metrics of an organization without APIs.
+func DeleteOrganizationXdsResourceUsage(organizationID string) {
+	organizationXdsResources.DeletePartialMatch(prometheus.Labels{"organization": organizationID})
+	organizationXdsSize.DeleteLabelValues(organizationID)
+}
+
+// SetOrganizationDeployedAPIs records the number of APIs deployed by the organization.
+func SetOrganizationDeployedAPIs(organizationID string, deployedAPIs int) {
+	organizationDeployedAPIs.WithLabelValues(organizationID).Set(float64(deployedAPIs))
+}
+
+// DeleteOrganizationDeployedAPIs removes the deployed API count of an organization without APIs.
+func DeleteOrganizationDeployedAPIs(organizationID string) {
+	organizationDeployedAPIs.DeleteLabelValues(organizationID)
+}
+
+// IncArtifactDownloadRetries records a retry of a runtime artifact download from the control plane.
+func IncArtifactDownloadRetries() {
+	artifactDownloadRetries.Inc()
+}
+
+// AddArtifactDownloadBytes records the number of bytes of a runtime artifact downloaded from the control plane.
+func AddArtifactDownloadBytes(bytes int64) {
+	artifactDownloadBytes.Add(float64(bytes))
+}
+
+// IncRouteRegexCacheRequests records a lookup in the route regex cache, which is a hit if the route regex was cached.
+func IncRouteRegexCacheRequests(hit bool) {
+	if hit {
+		routeRegexCacheRequests.WithLabelValues("hit").Inc()
+	} else {
+		routeRegexCacheRequests.WithLabelValues("miss").Inc()
+	}
+}
+
+// SetRouteRegexCacheEntries records the number of route regexes in the route regex cache.
+func SetRouteRegexCacheEntries(entries int) {
+	routeRegexCacheEntries.Set(float64(entries))
 }
 
 // recordMetrics record custom golang metrics
@@ -36,6 +36,7 @@ import (
 	parser "github.com/mitchellh/mapstructure"
 	"github.com/wso2/product-microgateway/adapter/pkg/auth"
 	logger "github.com/wso2/product-microgateway/adapter/pkg/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
 )
 
 const (
@@ -85,6 +86,8 @@ func FetchAPIs(id *string, gwLabel []string, c chan SyncAPIResponse, resourceEnd
 
 // SendRequestToControlPlane is the function triggered to send the request to the control plane.
 // It returns true if a response is received from the api manager.
+// A successful response is downloaded to a temporary file, which is verified against the Content-Length and the
+// checksum headers of the response. The request is sent again with a backoff if the download cannot be verified.
 func SendRequestToControlPlane(req *http.Request, apiID *string, gwLabels []string, c chan SyncAPIResponse,
 	client *http.Client) bool {
 	// Make the request
@@ -93,7 +96,6 @@ func SendRequestToControlPlane(req *http.Request, apiID *string, gwLabels []stri
 	} else {
 		logger.LoggerSync.Debug("Sending the control plane request")
 	}
-	resp, err := client.Do(req)
 
 	respSyncAPI := SyncAPIResponse{}
 
@@ -104,43 +106,75 @@ func SendRequestToControlPlane(req *http.Request, apiID *string, gwLabels []stri
 		respSyncAPI.GatewayLabels = gwLabels
 	}
 
-	// In the event of a connection error, the error would not be nil, then return the error
-	// If the error is not null, proceed
-	if err != nil {
-		logger.LoggerSync.Errorf("Error occurred while retrieving APIs from API manager: %v", err)
-		respSyncAPI.Err = err
-		respSyncAPI.Resp = nil
-		c <- respSyncAPI
-		return false
-	}
+	maxAttempts := workerPool.downloadParams.getMaxAttempts()
+	for attempt := 1; ; attempt++ {
+		resp, err := client.Do(req)
 
-	// get the response in the form of a byte slice
-	respBytes, err := ioutil.ReadAll(resp.Body)
+		// In the event of a connection error, the error would not be nil, then return the error
+		// If the error is not null, proceed
+		if err != nil {
+			logger.LoggerSync.Errorf("Error occurred while retrieving APIs from API manager: %v", err)
+			respSyncAPI.Err = err
+			respSyncAPI.Resp = nil
+			c <- respSyncAPI
+			return false
+		}
 
-	// If the reading response gives an error
-	if err != nil {
-		logger.LoggerSync.Errorf("Error occurred while reading the response: %v", err)
-		respSyncAPI.Err = err
-		respSyncAPI.ErrorCode = resp.StatusCode
-		respSyncAPI.Resp = nil
-		c <- respSyncAPI
-		return false
-	}
-	// For successful response, return the byte slice and nil as error
-	if resp.StatusCode == http.StatusOK {
-		respSyncAPI.Err = nil
-		respSyncAPI.Resp = respBytes
-		c <- respSyncAPI
-		return true
+		if resp.StatusCode != http.StatusOK {
+			// get the response in the form of a byte slice
+			respBytes, err := ioutil.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+
+			// If the reading response gives an error
+			if err != nil {
+				logger.LoggerSync.Errorf("Error occurred while reading the response: %v", err)
+				respSyncAPI.Err = err
+				respSyncAPI.ErrorCode = resp.StatusCode
+				respSyncAPI.Resp = nil
+				c <- respSyncAPI
+				return false
+			}
+			// If the response is not successful, create a new error with the response and log it and return
+			// Ex: for 401 scenarios, 403 scenarios.
+			logger.LoggerSync.Errorf("Failure response from control plane: %v", string(respBytes))
+			respSyncAPI.Err = errors.New(string(respBytes))
+			respSyncAPI.Resp = nil
+			respSyncAPI.ErrorCode = resp.StatusCode
+			c <- respSyncAPI
+			return true
+		}
+
+		// For successful response, return the downloaded file and nil as error
+		artifactFile, err := downloadArtifact(resp)
+		_ = resp.Body.Close()
+		if err == nil {
+			respSyncAPI.Err = nil
+			respSyncAPI.RespFile = artifactFile
+			c <- respSyncAPI
+			return true
+		}
+		if attempt >= maxAttempts {
+			logger.LoggerSync.Errorf("Error occurred while downloading the response after %d attempts: %v",
+				attempt, err)
+			respSyncAPI.Err = err
+			respSyncAPI.ErrorCode = resp.StatusCode
+			respSyncAPI.Resp = nil
+			c <- respSyncAPI
+			return false
+		}
+		backoff := workerPool.downloadParams.getBackoff(attempt)
+		logger.LoggerSync.Warnf("Error occurred while downloading the response (attempt %d of %d): %v. "+
+			"Retrying after %v", attempt, maxAttempts, err, backoff)
+		metrics.IncArtifactDownloadRetries()
+		time.Sleep(backoff)
+		if err := resetRequestBody(req); err != nil {
+			logger.LoggerSync.Errorf("Error occurred while recreating the control plane request: %v", err)
+			respSyncAPI.Err = err
+			respSyncAPI.Resp = nil
+			c <- respSyncAPI
+			return false
+		}
 	}
-	// If the response is not successful, create a new error with the response and log it and return
-	// Ex: for 401 scenarios, 403 scenarios.
-	logger.LoggerSync.Errorf("Failure response from control plane: %v", string(respBytes))
-	respSyncAPI.Err = errors.New(string(respBytes))
-	respSyncAPI.Resp = nil
-	respSyncAPI.ErrorCode = resp.StatusCode
-	c <- respSyncAPI
-	return true
 }
 
 // ConstructControlPlaneRequest constructs the http Request used to send to the control plane
@@ -281,12 +315,13 @@ func ReadRootFiles(reader *zip.Reader) (*DeploymentDescriptor, map[string]map[st
 				logger.LoggerSync.Error("Error reading environment specific properties: ", err)
 				return deploymentDescriptor, apiEnvProps, err
 			}
-			logger.LoggerSync.Debugf("Parsing content of environment specific properties, content: %s", string(data))
 			if apiEnvProps, err = parseEnvProps(data); err != nil {
 				logger.LoggerSync.Errorf("Error occurred while parsing environment specific properties : %v : %v",
 					file.Name, err.Error())
 				return deploymentDescriptor, apiEnvProps, err
 			}
+			// The endpoint security passwords are masked when the parsed properties are logged
+			logger.LoggerSync.Debugf("Parsed environment specific properties: %v", apiEnvProps)
 		}
 	}
 	return deploymentDescriptor, apiEnvProps, nil
@@ -305,8 +340,10 @@ func parseEnvProps(data []byte) (map[string]map[string]APIEnvProps, error) {
 			for apiUUID, apiData := range apis {
 				apiProps := make(map[string]APIEnvProps)
 				if api, ok := apiData.(map[string]interface{}); ok {
-					var envProps APIEnvProps
 					for envLabel, envData := range api {
+						// A new struct per environment, so that the values of an environment are not
+						// carried over to the next environment
+						var envProps APIEnvProps
 						if err := parser.Decode(envData, &envProps); err != nil {
 							logger.LoggerSync.Error("Error parsing environment specific values: ", err)
 							return nil, err
@@ -0,0 +1,169 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * This file contains functions to download the runtime artifacts from the control plane to temporary files and to
+ * verify the downloaded artifacts.
+ */
+
+package synchronizer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
+)
+
+const (
+	// ChecksumHeader is the response header in which the control plane may send the hex encoded SHA-256 checksum
+	// of the runtime artifact.
+	ChecksumHeader string = "X-Checksum-SHA256"
+	etagHeader     string = "ETag"
+
+	artifactFilePattern             string        = "runtime-artifacts-*.zip"
+	defaultArtifactDownloadAttempts int           = 3
+	defaultArtifactDownloadBackoff  time.Duration = 2
+	defaultArtifactDownloadMaxDelay time.Duration = 30
+)
+
+var (
+	// ErrArtifactTruncated is returned when fewer bytes than the Content-Length of the response are downloaded.
+	ErrArtifactTruncated = errors.New("runtime artifact download is truncated")
+	// ErrArtifactChecksumMismatch is returned when the SHA-256 checksum of the downloaded bytes does not match the
+	// checksum sent by the control plane.
+	ErrArtifactChecksumMismatch = errors.New("runtime artifact checksum mismatch")
+
+	sha256HexRegex = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+)
+
+// artifactDownloadParameters holds the retry configuration of the runtime artifact downloads.
+type artifactDownloadParameters struct {
+	maxAttempts  int
+	retryBackoff time.Duration
+	maxBackoff   time.Duration
+}
+
+// SetArtifactDownloadParameters configures how many times a runtime artifact is downloaded when the downloaded bytes
+// cannot be verified, along with the initial and the maximum delay (in seconds) between the attempts. The delay is
+// doubled after each failed attempt.
+func SetArtifactDownloadParameters(maxAttempts int, retryBackoff, maxBackoff time.Duration) {
+	if workerPool == nil {
+		return
+	}
+	workerPool.downloadParams = artifactDownloadParameters{
+		maxAttempts:  maxAttempts,
+		retryBackoff: retryBackoff,
+		maxBackoff:   maxBackoff,
+	}
+}
+
+// getMaxAttempts returns the number of attempts to download a runtime artifact.
+func (params artifactDownloadParameters) getMaxAttempts() int {
+	if params.maxAttempts <= 0 {
+		return defaultArtifactDownloadAttempts
+	}
+	return params.maxAttempts
+}
+
+// getBackoff returns the delay before the next attempt, after the given number of failed attempts.
+func (params artifactDownloadParameters) getBackoff(failedAttempts int) time.Duration {
+	backoff, maxBackoff := params.retryBackoff, params.maxBackoff
+	if backoff <= 0 {
+		backoff = defaultArtifactDownloadBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultArtifactDownloadMaxDelay
+	}
+	delay := backoff * time.Second
+	for i := 1; i < failedAttempts && delay < maxBackoff*time.Second; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff*time.Second {
+		delay = maxBackoff * time.Second
+	}
+	return delay
+}
+
+// downloadArtifact streams the body of a successful control plane response to a temporary file and returns the path
+// of the file. The downloaded bytes are verified against the Content-Length of the response and the SHA-256 checksum
+// sent by the control plane, if any. The file is removed when the download cannot be verified.
+func downloadArtifact(resp *http.Response) (string, error) {
+	file, err := os.CreateTemp("", artifactFilePattern)
+	if err != nil {
+		return "", fmt.Errorf("error while creating a temporary file for the runtime artifact: %w", err)
+	}
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(file, hash), resp.Body)
+	metrics.AddArtifactDownloadBytes(written)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = verifyArtifact(resp, written, hex.EncodeToString(hash.Sum(nil)))
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// verifyArtifact verifies the size and the checksum of a downloaded runtime artifact against the response headers.
+func verifyArtifact(resp *http.Response, written int64, checksum string) error {
+	if resp.ContentLength >= 0 && written != resp.ContentLength {
+		return fmt.Errorf("%w: received %d bytes out of %d bytes", ErrArtifactTruncated, written, resp.ContentLength)
+	}
+	expectedChecksum := getExpectedChecksum(resp.Header)
+	if expectedChecksum != "" && !strings.EqualFold(expectedChecksum, checksum) {
+		return fmt.Errorf("%w: expected %s but received %s", ErrArtifactChecksumMismatch, expectedChecksum, checksum)
+	}
+	return nil
+}
+
+// getExpectedChecksum returns the SHA-256 checksum of the runtime artifact sent by the control plane. The checksum
+// header takes precedence over the ETag, which is considered only if it is a strong ETag of a SHA-256 checksum.
+func getExpectedChecksum(header http.Header) string {
+	if checksum := strings.TrimSpace(header.Get(ChecksumHeader)); checksum != "" {
+		return checksum
+	}
+	etag := strings.Trim(strings.TrimSpace(header.Get(etagHeader)), "\"")
+	if sha256HexRegex.MatchString(etag) {
+		return etag
+	}
+	return ""
+}
+
+// resetRequestBody rewinds the body of the request, so that the request can be sent again.
+func resetRequestBody(req *http.Request) error {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package synchronizer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadArtifact(t *testing.T) {
+	payload := "runtime artifact content"
+	sum := sha256.Sum256([]byte(payload))
+	checksum := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name          string
+		contentLength int64
+		headers       map[string]string
+		expectedErr   error
+	}{
+		{"without verification headers", -1, nil, nil},
+		{"matching content length", int64(len(payload)), nil, nil},
+		{"truncated", int64(len(payload) + 10), nil, ErrArtifactTruncated},
+		{"matching checksum header", -1, map[string]string{ChecksumHeader: strings.ToUpper(checksum)}, nil},
+		{"mismatching checksum header", -1, map[string]string{ChecksumHeader: strings.Repeat("0", 64)},
+			ErrArtifactChecksumMismatch},
+		{"matching etag", -1, map[string]string{etagHeader: "\"" + checksum + "\""}, nil},
+		{"mismatching etag", -1, map[string]string{etagHeader: "\"" + strings.Repeat("0", 64) + "\""},
+			ErrArtifactChecksumMismatch},
+		{"etag other than a checksum", -1, map[string]string{etagHeader: "W/\"1234\""}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Keep the downloaded artifacts in a directory which is removed after the test.
+			t.Setenv("TMPDIR", t.TempDir())
+			h := http.Header{}
+			for key, value := range test.headers {
+				h.Set(key, value)
+			}
+			resp := &http.Response{
+				StatusCode:    http.StatusOK,
+				ContentLength: test.contentLength,
+				Header:        h,
+				Body:          ioutil.NopCloser(strings.NewReader(payload)),
+			}
+			filePath, err := downloadArtifact(resp)
+			if filePath != "" {
+				defer os.Remove(filePath)
+			}
+			if test.expectedErr != nil {
+				assert.True(t, errors.Is(err, test.expectedErr), "Unexpected error: %v", err)
+				assert.Empty(t, filePath)
+				return
+			}
+			if assert.NoError(t, err) {
+				content, err := ioutil.ReadFile(filePath)
+				assert.NoError(t, err)
+				assert.Equal(t, payload, string(content))
+			}
+		})
+	}
+}
+
+func TestArtifactDownloadBackoff(t *testing.T) {
+	params := artifactDownloadParameters{maxAttempts: 5, retryBackoff: 2, maxBackoff: 5}
+	assert.Equal(t, 5, params.getMaxAttempts())
+	assert.Equal(t, 2*time.Second, params.getBackoff(1))
+	assert.Equal(t, 4*time.Second, params.getBackoff(2))
+	assert.Equal(t, 5*time.Second, params.getBackoff(3), "Backoff should be capped at the maximum backoff")
+
+	defaultParams := artifactDownloadParameters{}
+	assert.Equal(t, defaultArtifactDownloadAttempts, defaultParams.getMaxAttempts())
+	assert.Equal(t, defaultArtifactDownloadBackoff*time.Second, defaultParams.getBackoff(1))
+}
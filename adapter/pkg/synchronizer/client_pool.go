@@ -47,6 +47,7 @@ type pool struct {
 	workers            []*worker
 	client             http.Client
 	controlPlaneParams controlPlaneParameters
+	downloadParams     artifactDownloadParameters
 }
 
 type controlPlaneParameters struct {
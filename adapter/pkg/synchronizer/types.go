@@ -22,6 +22,8 @@
 
 package synchronizer
 
+import "fmt"
+
 // SyncAPIResponse struct contains information related to
 // response of the API pulling/fetching from control plane
 // along with the apiId and the gateway label that the call
@@ -32,6 +34,9 @@ type SyncAPIResponse struct {
 	ErrorCode     int
 	APIUUID       string
 	GatewayLabels []string
+	// RespFile is the path of the temporary file to which a successful response is downloaded, in which case Resp
+	// is nil. The receiver should remove the file after processing it.
+	RespFile string
 }
 
 // DeploymentDescriptor represents deployment descriptor file contains in Artifact
@@ -66,8 +71,27 @@ type GatewayLabel struct {
 
 // APIConfigs represents env properties belongs to the API
 type APIConfigs struct {
-	ProductionEndpoint string `mapstructure:"productionEndpoint,omitempty"`
-	SandBoxEndpoint    string `mapstructure:"sandboxEndpoint,omitempty"`
+	ProductionEndpoint         string            `mapstructure:"productionEndpoint,omitempty"`
+	SandBoxEndpoint            string            `mapstructure:"sandboxEndpoint,omitempty"`
+	ProductionEndpointSecurity *EndpointSecurity `mapstructure:"productionEndpointSecurity,omitempty"`
+	SandboxEndpointSecurity    *EndpointSecurity `mapstructure:"sandboxEndpointSecurity,omitempty"`
+}
+
+// EndpointSecurity represents the endpoint security (backend credentials) of an environment, which overrides
+// the endpoint security of the API revision.
+type EndpointSecurity struct {
+	Type     string `mapstructure:"type,omitempty"`
+	Username string `mapstructure:"username,omitempty"`
+	Password string `mapstructure:"password,omitempty"`
+}
+
+// String returns the endpoint security with the password masked, so that the credentials are not logged.
+func (security EndpointSecurity) String() string {
+	password := ""
+	if security.Password != "" {
+		password = "******"
+	}
+	return fmt.Sprintf("{Type:%s Username:%s Password:%s}", security.Type, security.Username, password)
 }
 
 // APIEnvProps represents env properties
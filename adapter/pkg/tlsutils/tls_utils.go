@@ -19,13 +19,17 @@
 package tlsutils
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"sync"
 
 	"github.com/wso2/product-microgateway/adapter/config"
@@ -100,6 +104,32 @@ func IsPublicCertificate(certContent []byte) bool {
 	return false
 }
 
+// SplitPEMCertificates splits a PEM bundle into its certificates, in the order those appear in the bundle. Hence
+// the chain of a certificate bundle (i.e. leaf, intermediates and root) is preserved. The blocks other than the
+// certificates (ex: private keys) and the text in between the blocks are dropped.
+func SplitPEMCertificates(certContent []byte) ([][]byte, error) {
+	var certs [][]byte
+	rest := bytes.TrimSpace(certContent)
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			if bytes.Contains(rest, []byte("-----BEGIN")) {
+				return nil, errors.New("malformed PEM block found after the certificate " + strconv.Itoa(len(certs)))
+			}
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certs = append(certs, pem.EncodeToMemory(block))
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return certs, nil
+}
+
 // InvokeControlPlane sends request to the control plane and returns the response
 func InvokeControlPlane(req *http.Request, skipSSL bool) (*http.Response, error) {
 	tr := &http.Transport{}
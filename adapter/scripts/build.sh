@@ -71,7 +71,9 @@ fi
 # To clean go.sum and go.mod
 go mod tidy
 
-GOOS=linux GOARCH=amd64 CGO_ENABLED=0 go build -v -o target/adapter-linux-amd64 github.com/wso2/product-microgateway/adapter/cmd/adapter
+# The version reported by the capabilities of the adapter REST API
+ADAPTER_VERSION_FLAG="-X github.com/wso2/product-microgateway/adapter/internal/api.adapterVersion=${ADAPTER_VERSION}"
+GOOS=linux GOARCH=amd64 CGO_ENABLED=0 go build -v -ldflags "${ADAPTER_VERSION_FLAG}" -o target/adapter-linux-amd64 github.com/wso2/product-microgateway/adapter/cmd/adapter
 if [ $? -ne 0 ]; then 
   echo "FAILED: Build failure for GOARCH=amd64"
   exit 1
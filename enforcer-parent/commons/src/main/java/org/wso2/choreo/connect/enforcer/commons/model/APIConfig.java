@@ -51,6 +51,7 @@ public class APIConfig {
     private boolean applicationSecurity;
     private GraphQLSchemaDTO graphQLSchemaDTO;
     private String endpointType;
+    private boolean subscriptionValidationDisabled;
 
     /**
      * getApiType returns the API type. This could be one of the following.
@@ -256,6 +257,15 @@ public class APIConfig {
         return endpointType;
     }
 
+    /**
+     * Returns whether the requests to the API are allowed without validating the subscriptions of the applications.
+     *
+     * @return true if the subscription validation is disabled for the API.
+     */
+    public boolean isSubscriptionValidationDisabled() {
+        return subscriptionValidationDisabled;
+    }
+
     /**
      * Implements builder pattern to build an API Config object.
      */
@@ -284,6 +294,7 @@ public class APIConfig {
         private boolean applicationSecurity;
         private GraphQLSchemaDTO graphQLSchemaDTO;
         private String endpointType;
+        private boolean subscriptionValidationDisabled;
 
         public Builder(String name) {
             this.name = name;
@@ -398,6 +409,11 @@ public class APIConfig {
             return this;
         }
 
+        public Builder subscriptionValidationDisabled(boolean subscriptionValidationDisabled) {
+            this.subscriptionValidationDisabled = subscriptionValidationDisabled;
+            return this;
+        }
+
         public APIConfig build() {
             APIConfig apiConfig = new APIConfig();
             apiConfig.name = this.name;
@@ -423,6 +439,7 @@ public class APIConfig {
             apiConfig.applicationSecurity = this.applicationSecurity;
             apiConfig.graphQLSchemaDTO = this.graphQLSchemaDTO;
             apiConfig.endpointType = this.endpointType;
+            apiConfig.subscriptionValidationDisabled = this.subscriptionValidationDisabled;
             return apiConfig;
         }
     }
@@ -255,6 +255,11 @@ private static final long serialVersionUID = 0L;
             endpointType_ = s;
             break;
           }
+          case 208: {
+
+            disableSubscriptionValidation_ = input.readBool();
+            break;
+          }
           default: {
             if (!parseUnknownField(
                 input, unknownFields, extensionRegistry, tag)) {
@@ -1145,6 +1150,17 @@ private static final long serialVersionUID = 0L;
     }
   }
 
+  public static final int DISABLESUBSCRIPTIONVALIDATION_FIELD_NUMBER = 26;
+  private boolean disableSubscriptionValidation_;
+  /**
+   * <code>bool disableSubscriptionValidation = 26;</code>
+   * @return The disableSubscriptionValidation.
+   */
+  @java.lang.Override
+  public boolean getDisableSubscriptionValidation() {
+    return disableSubscriptionValidation_;
+  }
+
   private byte memoizedIsInitialized = -1;
   @java.lang.Override
   public final boolean isInitialized() {
@@ -1234,6 +1250,9 @@ private static final long serialVersionUID = 0L;
     if (!getEndpointTypeBytes().isEmpty()) {
       com.google.protobuf.GeneratedMessageV3.writeString(output, 25, endpointType_);
     }
+    if (disableSubscriptionValidation_ != false) {
+      output.writeBool(26, disableSubscriptionValidation_);
+    }
     unknownFields.writeTo(output);
   }
 
@@ -1329,6 +1348,10 @@ private static final long serialVersionUID = 0L;
     if (!getEndpointTypeBytes().isEmpty()) {
       size += com.google.protobuf.GeneratedMessageV3.computeStringSize(25, endpointType_);
     }
+    if (disableSubscriptionValidation_ != false) {
+      size += com.google.protobuf.CodedOutputStream
+        .computeBoolSize(26, disableSubscriptionValidation_);
+    }
     size += unknownFields.getSerializedSize();
     memoizedSize = size;
     return size;
@@ -1403,6 +1426,8 @@ private static final long serialVersionUID = 0L;
         .equals(other.getGraphqlComplexityInfoList())) return false;
     if (!getEndpointType()
         .equals(other.getEndpointType())) return false;
+    if (getDisableSubscriptionValidation()
+        != other.getDisableSubscriptionValidation()) return false;
     if (!unknownFields.equals(other.unknownFields)) return false;
     return true;
   }
@@ -1483,6 +1508,9 @@ private static final long serialVersionUID = 0L;
     }
     hash = (37 * hash) + ENDPOINTTYPE_FIELD_NUMBER;
     hash = (53 * hash) + getEndpointType().hashCode();
+    hash = (37 * hash) + DISABLESUBSCRIPTIONVALIDATION_FIELD_NUMBER;
+    hash = (53 * hash) + com.google.protobuf.Internal.hashBoolean(
+        getDisableSubscriptionValidation());
     hash = (29 * hash) + unknownFields.hashCode();
     memoizedHashCode = hash;
     return hash;
@@ -1707,6 +1735,8 @@ private static final long serialVersionUID = 0L;
       }
       endpointType_ = "";
 
+      disableSubscriptionValidation_ = false;
+
       return this;
     }
 
@@ -1811,6 +1841,7 @@ private static final long serialVersionUID = 0L;
         result.graphqlComplexityInfo_ = graphqlComplexityInfoBuilder_.build();
       }
       result.endpointType_ = endpointType_;
+      result.disableSubscriptionValidation_ = disableSubscriptionValidation_;
       onBuilt();
       return result;
     }
@@ -2063,6 +2094,9 @@ private static final long serialVersionUID = 0L;
         endpointType_ = other.endpointType_;
         onChanged();
       }
+      if (other.getDisableSubscriptionValidation() != false) {
+        setDisableSubscriptionValidation(other.getDisableSubscriptionValidation());
+      }
       this.mergeUnknownFields(other.unknownFields);
       onChanged();
       return this;
@@ -4806,6 +4840,37 @@ private static final long serialVersionUID = 0L;
       onChanged();
       return this;
     }
+
+    private boolean disableSubscriptionValidation_ ;
+    /**
+     * <code>bool disableSubscriptionValidation = 26;</code>
+     * @return The disableSubscriptionValidation.
+     */
+    @java.lang.Override
+    public boolean getDisableSubscriptionValidation() {
+      return disableSubscriptionValidation_;
+    }
+    /**
+     * <code>bool disableSubscriptionValidation = 26;</code>
+     * @param value The disableSubscriptionValidation to set.
+     * @return This builder for chaining.
+     */
+    public Builder setDisableSubscriptionValidation(boolean value) {
+      
+      disableSubscriptionValidation_ = value;
+      onChanged();
+      return this;
+    }
+    /**
+     * <code>bool disableSubscriptionValidation = 26;</code>
+     * @return This builder for chaining.
+     */
+    public Builder clearDisableSubscriptionValidation() {
+      
+      disableSubscriptionValidation_ = false;
+      onChanged();
+      return this;
+    }
     @java.lang.Override
     public final Builder setUnknownFields(
         final com.google.protobuf.UnknownFieldSet unknownFields) {
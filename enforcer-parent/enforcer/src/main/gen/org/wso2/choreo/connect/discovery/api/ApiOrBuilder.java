@@ -357,4 +357,10 @@ public interface ApiOrBuilder extends
    */
   com.google.protobuf.ByteString
       getEndpointTypeBytes();
+
+  /**
+   * <code>bool disableSubscriptionValidation = 26;</code>
+   * @return The disableSubscriptionValidation.
+   */
+  boolean getDisableSubscriptionValidation();
 }
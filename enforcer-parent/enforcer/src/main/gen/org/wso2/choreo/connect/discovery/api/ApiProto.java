@@ -35,7 +35,7 @@ public final class ApiProto {
       "curity.proto\032(wso2/discovery/api/securit" +
       "y_scheme.proto\032$wso2/discovery/api/Certi" +
       "ficate.proto\032 wso2/discovery/api/graphql" +
-      ".proto\"\306\006\n\003Api\022\n\n\002id\030\001 \001(\t\022\r\n\005title\030\002 \001(" +
+      ".proto\"\355\006\n\003Api\022\n\n\002id\030\001 \001(\t\022\r\n\005title\030\002 \001(" +
       "\t\022\017\n\007version\030\003 \001(\t\022\017\n\007apiType\030\004 \001(\t\022\023\n\013d" +
       "escription\030\005 \001(\t\022@\n\023productionEndpoints\030" +
       "\006 \001(\0132#.wso2.discovery.api.EndpointClust" +
@@ -56,10 +56,11 @@ public final class ApiProto {
       "curity\030\026 \001(\010\022\025\n\rgraphQLSchema\030\027 \001(\t\022D\n\025g" +
       "raphqlComplexityInfo\030\030 \003(\0132%.wso2.discov" +
       "ery.api.GraphqlComplexity\022\024\n\014endpointTyp" +
-      "e\030\031 \001(\tBr\n%org.wso2.choreo.connect.disco" +
-      "very.apiB\010ApiProtoP\001Z=github.com/envoypr" +
-      "oxy/go-control-plane/wso2/discovery/api;" +
-      "apib\006proto3"
+      "e\030\031 \001(\t\022%\n\035disableSubscriptionValidation" +
+      "\030\032 \001(\010Br\n%org.wso2.choreo.connect.discov" +
+      "ery.apiB\010ApiProtoP\001Z=github.com/envoypro" +
+      "xy/go-control-plane/wso2/discovery/api;a" +
+      "pib\006proto3"
     };
     descriptor = com.google.protobuf.Descriptors.FileDescriptor
       .internalBuildGeneratedFileFrom(descriptorData,
@@ -76,7 +77,7 @@ public final class ApiProto {
     internal_static_wso2_discovery_api_Api_fieldAccessorTable = new
       com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
         internal_static_wso2_discovery_api_Api_descriptor,
-        new java.lang.String[] { "Id", "Title", "Version", "ApiType", "Description", "ProductionEndpoints", "SandboxEndpoints", "Resources", "BasePath", "Tier", "ApiLifeCycleState", "SecurityScheme", "Security", "EndpointSecurity", "AuthorizationHeader", "DisableSecurity", "Vhost", "OrganizationId", "IsMockedApi", "ClientCertificates", "MutualSSL", "ApplicationSecurity", "GraphQLSchema", "GraphqlComplexityInfo", "EndpointType", });
+        new java.lang.String[] { "Id", "Title", "Version", "ApiType", "Description", "ProductionEndpoints", "SandboxEndpoints", "Resources", "BasePath", "Tier", "ApiLifeCycleState", "SecurityScheme", "Security", "EndpointSecurity", "AuthorizationHeader", "DisableSecurity", "Vhost", "OrganizationId", "IsMockedApi", "ClientCertificates", "MutualSSL", "ApplicationSecurity", "GraphQLSchema", "GraphqlComplexityInfo", "EndpointType", "DisableSubscriptionValidation", });
     org.wso2.choreo.connect.discovery.api.EndpointClusterProto.getDescriptor();
     org.wso2.choreo.connect.discovery.api.ResourceProto.getDescriptor();
     org.wso2.choreo.connect.discovery.api.EndpointSecurityProto.getDescriptor();
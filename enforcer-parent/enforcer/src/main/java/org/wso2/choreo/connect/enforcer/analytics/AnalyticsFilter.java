@@ -201,6 +201,15 @@ public class AnalyticsFilter {
                     endUserName == null ? APIConstants.END_USER_UNKNOWN : endUserName);
             requestContext.addMetadataToMap(MetadataConstants.API_CONTEXT_KEY,
                     requestContext.getMatchedAPI().getBasePath());
+
+            // Adding the analytics properties of the operation
+            Object analyticsProperties = requestContext.getProperties().get(APIConstants.ANALYTICS_PROPERTIES);
+            if (analyticsProperties instanceof Map) {
+                for (Map.Entry<?, ?> property : ((Map<?, ?>) analyticsProperties).entrySet()) {
+                    requestContext.addMetadataToMap(MetadataConstants.ANALYTICS_PROPERTY_KEY_PREFIX + property.getKey(),
+                            String.valueOf(property.getValue()));
+                }
+            }
         } finally {
             if (Utils.tracingEnabled()) {
                 analyticsSpanScope.close();
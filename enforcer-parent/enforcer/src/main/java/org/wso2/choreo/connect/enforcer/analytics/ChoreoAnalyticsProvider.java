@@ -223,11 +223,30 @@ public class ChoreoAnalyticsProvider implements AnalyticsDataProvider {
 
     @Override
     public Map<String, Object> getProperties() {
+        Map<String, Object> properties = new HashMap<>(getAnalyticsProperties());
         AnalyticsCustomDataProvider customDataProvider = AnalyticsFilter.getAnalyticsCustomDataProvider();
         if (customDataProvider != null && customDataProvider.getCustomProperties(customProperties) != null) {
-            return customDataProvider.getCustomProperties(customProperties);
+            properties.putAll(customDataProvider.getCustomProperties(customProperties));
+            return properties;
         }
-        return this.customProperties;
+        properties.putAll(this.customProperties);
+        return properties;
+    }
+
+    /**
+     * Returns the analytics properties of the operation, given by the x-wso2-analytics-properties extension.
+     *
+     * @return analytics properties of the operation
+     */
+    private Map<String, Object> getAnalyticsProperties() {
+        Map<String, Object> analyticsProperties = new HashMap<>();
+        for (Map.Entry<String, Value> field : getFieldsMapFromLogEntry().entrySet()) {
+            if (field.getKey().startsWith(MetadataConstants.ANALYTICS_PROPERTY_KEY_PREFIX)) {
+                analyticsProperties.put(field.getKey().substring(MetadataConstants.ANALYTICS_PROPERTY_KEY_PREFIX
+                        .length()), field.getValue().getStringValue());
+            }
+        }
+        return analyticsProperties;
     }
 
     @Override
@@ -50,6 +50,7 @@ import org.wso2.choreo.connect.enforcer.constants.HttpConstants;
 import org.wso2.choreo.connect.enforcer.cors.CorsFilter;
 import org.wso2.choreo.connect.enforcer.graphql.GraphQLPayloadUtils;
 import org.wso2.choreo.connect.enforcer.graphql.GraphQLQueryAnalysisFilter;
+import org.wso2.choreo.connect.enforcer.headers.HeaderSizeLimitFilter;
 import org.wso2.choreo.connect.enforcer.security.AuthFilter;
 import org.wso2.choreo.connect.enforcer.security.mtls.MtlsUtils;
 import org.wso2.choreo.connect.enforcer.throttle.ThrottleFilter;
@@ -171,7 +172,8 @@ public class GraphQLAPI implements API {
                 .organizationId(api.getOrganizationId()).endpoints(endpoints).resources(resources)
                 .securitySchemeDefinitions(securitySchemeDefinitions).graphQLSchemaDTO(graphQLSchemaDTO)
                 .trustStore(trustStore).mtlsCertificateTiers(mtlsCertificateTiers).mutualSSL(mutualSSL)
-                .applicationSecurity(applicationSecurity).build();
+                .applicationSecurity(applicationSecurity)
+                .subscriptionValidationDisabled(api.getDisableSubscriptionValidation()).build();
         initFilters();
         return basePath;
     }
@@ -268,6 +270,8 @@ public class GraphQLAPI implements API {
         // CORS filter is added as the first filter, and it is not customizable.
         CorsFilter corsFilter = new CorsFilter();
         this.filters.add(0, corsFilter);
+        // Header size limit of the API is applied before authenticating the request.
+        this.filters.add(1, new HeaderSizeLimitFilter());
     }
 
     private void populateRemoveAndProtectedHeaders(RequestContext requestContext) {
@@ -46,6 +46,7 @@ import org.wso2.choreo.connect.enforcer.constants.APIConstants;
 import org.wso2.choreo.connect.enforcer.constants.Constants;
 import org.wso2.choreo.connect.enforcer.constants.HttpConstants;
 import org.wso2.choreo.connect.enforcer.cors.CorsFilter;
+import org.wso2.choreo.connect.enforcer.headers.HeaderSizeLimitFilter;
 import org.wso2.choreo.connect.enforcer.interceptor.MediationPolicyFilter;
 import org.wso2.choreo.connect.enforcer.security.AuthFilter;
 import org.wso2.choreo.connect.enforcer.security.mtls.MtlsUtils;
@@ -145,8 +146,11 @@ public class RestAPI implements API {
                 ResourceConfig resConfig = Utils.buildResource(operation, res.getPath(), securityScopesMap);
                 resConfig.setPolicyConfig(Utils.genPolicyConfig(operation.getPolicies()));
                 resConfig.setEndpoints(endpointClusterMap);
-                resConfig.setMockApiConfig(getMockedApiOperationConfig(operation.getMockedApiConfig(),
-                        operation.getMethod()));
+                // The operations of a prototyped API are mocked only if the adapter provides the mocked config
+                if (api.getIsMockedApi() || operation.hasMockedApiConfig()) {
+                    resConfig.setMockApiConfig(getMockedApiOperationConfig(operation.getMockedApiConfig(),
+                            operation.getMethod()));
+                }
                 resources.add(resConfig);
             }
         }
@@ -181,7 +185,8 @@ public class RestAPI implements API {
                 .endpoints(endpoints).endpointSecurity(endpointSecurity).mockedApi(api.getIsMockedApi())
                 .trustStore(trustStore).organizationId(api.getOrganizationId())
                 .mtlsCertificateTiers(mtlsCertificateTiers).mutualSSL(mutualSSL)
-                .applicationSecurity(applicationSecurity).endpointType(endpointType).build();
+                .applicationSecurity(applicationSecurity).endpointType(endpointType)
+                .subscriptionValidationDisabled(api.getDisableSubscriptionValidation()).build();
 
         initFilters();
         return basePath;
@@ -224,7 +229,7 @@ public class RestAPI implements API {
             }
             // set metadata for interceptors
             responseObject.setMetaDataMap(requestContext.getMetadataMap());
-            if (requestContext.getMatchedAPI().isMockedApi()) {
+            if (requestContext.getMatchedAPI().isMockedApi() || isMockedOperation(requestContext)) {
                 MockImplUtils.processMockedApiCall(requestContext, responseObject);
                 return responseObject;
             }
@@ -262,6 +267,11 @@ public class RestAPI implements API {
         return this.apiConfig;
     }
 
+    private boolean isMockedOperation(RequestContext requestContext) {
+        return requestContext.getMatchedResourcePaths() != null && requestContext.getMatchedResourcePaths().size() > 0
+                && requestContext.getMatchedResourcePaths().get(0).getMockedApiConfig() != null;
+    }
+
     private MockedApiConfig getMockedApiOperationConfig(
             org.wso2.choreo.connect.discovery.api.MockedApiConfig mockedApiConfig, String operationName) {
         MockedApiConfig configData = new MockedApiConfig();
@@ -311,6 +321,8 @@ public class RestAPI implements API {
         // CORS filter is added as the first filter, and it is not customizable.
         CorsFilter corsFilter = new CorsFilter();
         this.filters.add(0, corsFilter);
+        // Header size limit of the API is applied before authenticating the request.
+        this.filters.add(1, new HeaderSizeLimitFilter());
 
         MediationPolicyFilter mediationPolicyFilter = new MediationPolicyFilter();
         this.filters.add(mediationPolicyFilter);
@@ -30,6 +30,7 @@ import org.wso2.choreo.connect.enforcer.commons.model.ResourceConfig;
 import org.wso2.choreo.connect.enforcer.commons.model.RetryConfig;
 import org.wso2.choreo.connect.enforcer.config.ConfigHolder;
 import org.wso2.choreo.connect.enforcer.config.dto.AuthHeaderDto;
+import org.wso2.choreo.connect.enforcer.constants.APIConstants;
 import org.wso2.choreo.connect.enforcer.constants.AdapterConstants;
 import org.wso2.choreo.connect.enforcer.util.FilterUtils;
 
@@ -157,7 +158,7 @@ public class Utils {
         // Remove Authorization Header
         AuthHeaderDto authHeader = ConfigHolder.getInstance().getConfig().getAuthHeader();
         String authHeaderName = FilterUtils.getAuthHeaderName(requestContext);
-        if (!authHeader.isEnableOutboundAuthHeader()) {
+        if (!isOutboundAuthHeaderEnabled(requestContext, authHeader)) {
             requestContext.getRemoveHeaders().add(authHeaderName);
         }
         // Authorization Header should not be included in the throttle publishing event.
@@ -166,4 +167,21 @@ public class Utils {
         // not allow clients to set cluster header manually
         requestContext.getRemoveHeaders().add(AdapterConstants.CLUSTER_HEADER);
     }
+
+    /**
+     * Returns true if the authorization header should be sent to the backend. The stripAuthHeader of the API
+     * overrides enableOutboundAuthHeader of the enforcer.
+     *
+     * @param requestContext requestContext
+     * @param authHeader     authorization header configuration of the enforcer
+     * @return whether the authorization header is sent to the backend
+     */
+    static boolean isOutboundAuthHeaderEnabled(RequestContext requestContext, AuthHeaderDto authHeader) {
+        Object enableOutboundAuthHeader = requestContext.getProperties()
+                .get(APIConstants.ENABLE_OUTBOUND_AUTH_HEADER);
+        if (enableOutboundAuthHeader instanceof Boolean) {
+            return (Boolean) enableOutboundAuthHeader;
+        }
+        return authHeader.isEnableOutboundAuthHeader();
+    }
 }
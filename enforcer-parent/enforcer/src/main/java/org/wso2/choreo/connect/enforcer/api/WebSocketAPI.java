@@ -38,6 +38,7 @@ import org.wso2.choreo.connect.enforcer.commons.model.SecuritySchemaConfig;
 import org.wso2.choreo.connect.enforcer.config.ConfigHolder;
 import org.wso2.choreo.connect.enforcer.constants.APIConstants;
 import org.wso2.choreo.connect.enforcer.cors.CorsFilter;
+import org.wso2.choreo.connect.enforcer.headers.HeaderSizeLimitFilter;
 import org.wso2.choreo.connect.enforcer.interceptor.MediationPolicyFilter;
 import org.wso2.choreo.connect.enforcer.security.AuthFilter;
 import org.wso2.choreo.connect.enforcer.throttle.ThrottleConstants;
@@ -143,7 +144,8 @@ public class WebSocketAPI implements API {
                 .apiSecurity(apiSecurity).tier(api.getTier()).endpointSecurity(endpointSecurity)
                 .authHeader(api.getAuthorizationHeader()).disableSecurity(api.getDisableSecurity())
                 .organizationId(api.getOrganizationId()).endpoints(endpoints).resources(resources)
-                .securitySchemeDefinitions(securitySchemes).build();
+                .securitySchemeDefinitions(securitySchemes)
+                .subscriptionValidationDisabled(api.getDisableSubscriptionValidation()).build();
         initFilters();
         initUpgradeFilters();
         return basePath;
@@ -234,6 +236,8 @@ public class WebSocketAPI implements API {
         // Cors Filter
         CorsFilter corsFilter = new CorsFilter();
         this.filters.add(corsFilter);
+        // Header size limit of the API
+        this.filters.add(new HeaderSizeLimitFilter());
         // PathRewriteFilter
         MediationPolicyFilter mediationPolicyFilter = new MediationPolicyFilter();
         this.filters.add(mediationPolicyFilter);
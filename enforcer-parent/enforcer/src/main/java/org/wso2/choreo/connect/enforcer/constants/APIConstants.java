@@ -97,6 +97,23 @@ public class APIConstants {
     public static final String PREFER_CODE = "code";
     public static final String PREFER_EXAMPLE = "example";
     public static final List<String> PREFER_KEYS = List.of(PREFER_CODE, PREFER_EXAMPLE);
+    // mocked response selected by the mock selector header of the operation, in the format of the prefer header
+    public static final String MOCK_RESPONSE_PREFERENCE = "mockResponsePreference";
+    // analytics properties of the operation, given by the x-wso2-analytics-properties extension
+    public static final String ANALYTICS_PROPERTIES = "analyticsProperties";
+    // roles allowed to invoke the API, given only for the APIs with RESTRICTED visibility
+    public static final String VISIBLE_ROLES = "visibleRoles";
+    // JWKS endpoint of the API and the refresh interval of its keys, given by the jwksConfig of the api.yaml
+    public static final String API_JWKS_URL = "apiJwksUrl";
+    public static final String API_JWKS_REFRESH_INTERVAL = "apiJwksRefreshInterval";
+    // timeout of the operation in milliseconds, given by the x-wso2-timeout extension of the operation
+    public static final String OPERATION_TIMEOUT = "operationTimeout";
+    // maximum size of the request headers of the API in KiB, given by the maxRequestHeadersKb of the api.yaml
+    public static final String MAX_REQUEST_HEADERS_KB = "maxRequestHeadersKb";
+    // whether the authorization header is sent to the backend, given by the stripAuthHeader of the api.yaml
+    public static final String ENABLE_OUTBOUND_AUTH_HEADER = "enableOutboundAuthHeader";
+    // JWT claim used as the rate limit key of the API, given by the rateLimitKey of the api.yaml
+    public static final String RATE_LIMIT_CLAIM = "rateLimitClaim";
     public static final String APPLICATION_JSON = "application/json";
     public static final String CONTENT_TYPE_TEXT_XML = "text/xml";
     public static final String CONTENT_TYPE_SOAP_XML = "application/soap+xml";
@@ -188,6 +205,7 @@ public class APIConstants {
         public static final String SPIKE_ARREST_UNIT = "spikeArrestUnit";
         public static final String SCOPE = "scope";
         public static final String SCOPE_DELIMITER = " ";
+        public static final String ROLES = "roles";
         public static final String ISSUED_TIME = "iat";
         public static final String EXPIRY_TIME = "exp";
         public static final String JWT_KID = "kid";
@@ -383,6 +401,7 @@ public class APIConstants {
         UNAUTHORIZED("403", 403),
         NOTFOUND("404", 404),
         THROTTLED("429", 429),
+        REQUEST_HEADER_FIELDS_TOO_LARGE("431", 431),
         SERVICE_UNAVAILABLE("503", 503),
         INTERNAL_SERVER_ERROR("500", 500),
         BAD_REQUEST_ERROR("400", 400),
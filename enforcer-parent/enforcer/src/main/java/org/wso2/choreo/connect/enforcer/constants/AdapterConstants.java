@@ -33,6 +33,28 @@ public class AdapterConstants {
     public static final String COMMON_ENFORCER_LABEL = "commonEnforcerLabel";
     // The node identifier Key
     public static final String NODE_IDENTIFIER_KEY = "instanceIdentifier";
+    // The key which specifies the request header selecting the mocked response of the operation
+    public static final String MOCK_SELECTOR_HEADER_KEY = "mockSelectorHeader";
+    // The prefix of the keys which map the values of the mock selector header to the preferred mocked responses
+    public static final String MOCK_RESPONSE_KEY_PREFIX = "mockResponse:";
+    // The prefix of the keys which specify the analytics properties of the operation
+    public static final String ANALYTICS_PROPERTY_KEY_PREFIX = "analyticsProperty:";
+    // The key which specifies the comma separated roles allowed to invoke an API with RESTRICTED visibility
+    public static final String VISIBLE_ROLES_KEY = "visibleRoles";
+    // The key which specifies the JWKS endpoint of the API, whose keys validate the JWTs of the API
+    public static final String JWKS_URL_KEY = "jwksUrl";
+    // The key which specifies the interval in seconds at which the keys of the JWKS endpoint of the API are refreshed
+    public static final String JWKS_REFRESH_INTERVAL_KEY = "jwksRefreshIntervalInSeconds";
+    // The key which specifies the timeout of the operation, which is already the timeout of its route
+    public static final String OPERATION_TIMEOUT_KEY = "operationTimeoutInMillis";
+    // The key which specifies the maximum size of the request headers of the API in KiB, which is lower than the
+    // limit of the listeners
+    public static final String MAX_REQUEST_HEADERS_KB_KEY = "maxRequestHeadersKb";
+    // The key which specifies whether the authorization header of the API is sent to the backend, overriding
+    // enableOutboundAuthHeader of the enforcer
+    public static final String ENABLE_OUTBOUND_AUTH_HEADER_KEY = "enableOutboundAuthHeader";
+    // The key which specifies the JWT claim whose value is published in the metadata as the rate limit key of the API
+    public static final String RATE_LIMIT_CLAIM_KEY = "rateLimitClaim";
 
     /**
      * Key in a Key-Value pair of a router http header to configure retry, etc.
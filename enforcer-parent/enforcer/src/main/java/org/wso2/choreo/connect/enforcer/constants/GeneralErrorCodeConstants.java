@@ -30,6 +30,11 @@ public class GeneralErrorCodeConstants {
     // TODO: (renuka) check error codes with APIM
     public static final int MEDIATION_POLICY_ERROR_CODE = 901100;
 
+    public static final int REQUEST_HEADERS_TOO_LARGE_CODE = 900913;
+    public static final String REQUEST_HEADERS_TOO_LARGE_MESSAGE = "Request Header Fields Too Large";
+    public static final String REQUEST_HEADERS_TOO_LARGE_DESCRIPTION = "The size of the request headers exceeds the "
+            + "limit of the API.";
+
     /**
      * Contains mock impl endpoint apis related errors
      */
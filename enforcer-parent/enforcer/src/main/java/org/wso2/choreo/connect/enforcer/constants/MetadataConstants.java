@@ -52,6 +52,10 @@ public class MetadataConstants {
     public static final String USER_AGENT_KEY = WSO2_METADATA_PREFIX + "user-agent";
     public static final String CLIENT_IP_KEY = WSO2_METADATA_PREFIX + "client-ip";
 
+    public static final String ANALYTICS_PROPERTY_KEY_PREFIX = WSO2_METADATA_PREFIX + "analytics-property-";
+    // value of the JWT claim used as the rate limit key of the API, read by the rate limit actions of the routes
+    public static final String RATE_LIMIT_KEY = WSO2_METADATA_PREFIX + "rate-limit-key";
+
     public static final String ERROR_CODE_KEY = "ErrorCode";
     public static final String CHOREO_CONNECT_ENFORCER_REPLY = "choreo-connect-enforcer-reply";
 }
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.choreo.connect.enforcer.headers;
+
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.choreo.connect.enforcer.commons.Filter;
+import org.wso2.choreo.connect.enforcer.commons.model.RequestContext;
+import org.wso2.choreo.connect.enforcer.constants.APIConstants;
+import org.wso2.choreo.connect.enforcer.constants.GeneralErrorCodeConstants;
+import org.wso2.choreo.connect.enforcer.util.FilterUtils;
+
+import java.nio.charset.StandardCharsets;
+import java.util.Map;
+
+/**
+ * Rejects the requests whose headers exceed the header size limit of the API (maxRequestHeadersKb of the api.yaml).
+ * The listeners only limit the size of the headers of all the APIs, hence the lower limits of the APIs are applied
+ * here. The size is counted as the router does, i.e. the names and the values of the headers, including the pseudo
+ * headers.
+ */
+public class HeaderSizeLimitFilter implements Filter {
+    private static final Logger logger = LogManager.getLogger(HeaderSizeLimitFilter.class);
+
+    @Override
+    public boolean handleRequest(RequestContext requestContext) {
+        Object maxRequestHeadersKb = requestContext.getProperties().get(APIConstants.MAX_REQUEST_HEADERS_KB);
+        if (!(maxRequestHeadersKb instanceof Integer) || requestContext.getHeaders() == null) {
+            return true;
+        }
+        long headersSize = getHeadersSize(requestContext.getHeaders());
+        if (headersSize <= (Integer) maxRequestHeadersKb * 1024L) {
+            return true;
+        }
+        logger.debug("Size of the request headers {} bytes exceeds the limit {} KiB of the API {}:{}", headersSize,
+                maxRequestHeadersKb, requestContext.getMatchedAPI().getName(),
+                requestContext.getMatchedAPI().getVersion());
+        FilterUtils.setErrorToContext(requestContext, GeneralErrorCodeConstants.REQUEST_HEADERS_TOO_LARGE_CODE,
+                APIConstants.StatusCodes.REQUEST_HEADER_FIELDS_TOO_LARGE.getCode(),
+                GeneralErrorCodeConstants.REQUEST_HEADERS_TOO_LARGE_MESSAGE,
+                GeneralErrorCodeConstants.REQUEST_HEADERS_TOO_LARGE_DESCRIPTION);
+        return false;
+    }
+
+    /**
+     * Returns the size of the headers in bytes.
+     *
+     * @param headers request headers
+     * @return size of the names and the values of the headers
+     */
+    static long getHeadersSize(Map<String, String> headers) {
+        long size = 0;
+        for (Map.Entry<String, String> header : headers.entrySet()) {
+            size += header.getKey().getBytes(StandardCharsets.UTF_8).length;
+            if (header.getValue() != null) {
+                size += header.getValue().getBytes(StandardCharsets.UTF_8).length;
+            }
+        }
+        return size;
+    }
+}
@@ -239,6 +239,11 @@ public class AuthFilter implements Filter {
                 }
             } else if (authenticate.isAuthenticated()) {
                 // This section is for application level securities
+                if (!(authenticator instanceof JWTAuthenticator)) {
+                    // Only the JWTs carry the roles of the caller, hence the callers authenticated otherwise are
+                    // rejected if the API is RESTRICTED to some roles.
+                    VisibleRolesValidator.validateRoles(requestContext, null);
+                }
                 if (!requestContext.getMatchedAPI().isMockedApi()) {
                     updateClusterHeaderAndCheckEnv(requestContext, authenticate);
                     // set backend security
@@ -328,12 +333,28 @@ public class AuthFilter implements Filter {
         if (retryConfig != null) {
             addRetryConfigHeaders(requestContext, retryConfig);
         }
-        Integer timeout = endpointCluster.getRouteTimeoutInMillis();
+        Integer timeout = getUpstreamTimeout(requestContext, endpointCluster);
         if (timeout != null) {
             addTimeoutHeaders(requestContext, timeout);
         }
     }
 
+    /**
+     * Returns the timeout of the upstream request. The timeout header overrides the timeout of the route, hence the
+     * timeout of the operation, which is the timeout of its route, is preferred over the timeout of the endpoint.
+     *
+     * @param requestContext  request context
+     * @param endpointCluster endpoint cluster of the request
+     * @return timeout in milliseconds, or null if neither the operation nor the endpoint has a timeout
+     */
+    static Integer getUpstreamTimeout(RequestContext requestContext, EndpointCluster endpointCluster) {
+        Object operationTimeout = requestContext.getProperties().get(APIConstants.OPERATION_TIMEOUT);
+        if (operationTimeout instanceof Integer) {
+            return (Integer) operationTimeout;
+        }
+        return endpointCluster.getRouteTimeoutInMillis();
+    }
+
     private void addRetryConfigHeaders(RequestContext requestContext, RetryConfig retryConfig) {
         requestContext.addOrModifyHeaders(AdapterConstants.HttpRouterHeaders.RETRY_ON,
                 AdapterConstants.HttpRouterHeaderValues.RETRIABLE_STATUS_CODES);
@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.choreo.connect.enforcer.security;
+
+import org.apache.commons.lang3.StringUtils;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.choreo.connect.enforcer.commons.exception.APISecurityException;
+import org.wso2.choreo.connect.enforcer.commons.model.RequestContext;
+import org.wso2.choreo.connect.enforcer.constants.APIConstants;
+import org.wso2.choreo.connect.enforcer.constants.APISecurityConstants;
+
+import java.util.ArrayList;
+import java.util.Collection;
+import java.util.Collections;
+import java.util.List;
+
+/**
+ * Validates the roles of the caller against the visible roles of the APIs with RESTRICTED visibility. The APIs with
+ * PUBLIC and PRIVATE visibility do not have visible roles, hence their callers are not checked for roles and the
+ * security of those APIs is left as it is.
+ */
+public class VisibleRolesValidator {
+    private static final Logger log = LogManager.getLogger(VisibleRolesValidator.class);
+
+    private VisibleRolesValidator() {
+    }
+
+    /**
+     * Returns the roles allowed to invoke the matched API, or an empty list if the roles of the callers are not
+     * checked for the API.
+     *
+     * @param requestContext request context
+     * @return visible roles of the API
+     */
+    @SuppressWarnings("unchecked")
+    public static List<String> getVisibleRoles(RequestContext requestContext) {
+        Object visibleRoles = requestContext.getProperties().get(APIConstants.VISIBLE_ROLES);
+        if (visibleRoles instanceof List) {
+            return (List<String>) visibleRoles;
+        }
+        return Collections.emptyList();
+    }
+
+    /**
+     * Validates that the caller has at least one of the visible roles of the API, if the API has visible roles.
+     *
+     * @param requestContext request context
+     * @param rolesClaim     value of the roles claim of the token of the caller, which is either a list of roles or
+     *                       a comma separated string of roles. Null if the token does not have the claim.
+     * @throws APISecurityException if the caller does not have any of the visible roles of the API
+     */
+    public static void validateRoles(RequestContext requestContext, Object rolesClaim) throws APISecurityException {
+        List<String> visibleRoles = getVisibleRoles(requestContext);
+        if (visibleRoles.isEmpty()) {
+            return;
+        }
+        List<String> callerRoles = getRoles(rolesClaim);
+        for (String role : callerRoles) {
+            if (visibleRoles.contains(role)) {
+                log.debug("Caller has the visible role {} of the API {}:{}", role,
+                        requestContext.getMatchedAPI().getName(), requestContext.getMatchedAPI().getVersion());
+                return;
+            }
+        }
+        log.debug("Caller does not have any of the visible roles {} of the API {}:{}", visibleRoles,
+                requestContext.getMatchedAPI().getName(), requestContext.getMatchedAPI().getVersion());
+        throw new APISecurityException(APIConstants.StatusCodes.UNAUTHORIZED.getCode(),
+                APISecurityConstants.API_AUTH_FORBIDDEN, APISecurityConstants.API_AUTH_FORBIDDEN_MESSAGE);
+    }
+
+    /**
+     * Returns the roles given by the roles claim of a token.
+     *
+     * @param rolesClaim list of roles or comma separated string of roles
+     * @return roles of the claim
+     */
+    static List<String> getRoles(Object rolesClaim) {
+        List<String> roles = new ArrayList<>();
+        if (rolesClaim instanceof Collection) {
+            for (Object role : (Collection<?>) rolesClaim) {
+                if (role != null && StringUtils.isNotBlank(role.toString())) {
+                    roles.add(role.toString().trim());
+                }
+            }
+        } else if (rolesClaim instanceof String) {
+            for (String role : ((String) rolesClaim).split(",")) {
+                if (StringUtils.isNotBlank(role)) {
+                    roles.add(role.trim());
+                }
+            }
+        }
+        return roles;
+    }
+}
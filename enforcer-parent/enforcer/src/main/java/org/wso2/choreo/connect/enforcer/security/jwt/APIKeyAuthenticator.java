@@ -209,11 +209,14 @@ public class APIKeyAuthenticator extends APIKeyHandler {
 
                 validateAPIKeyRestrictions(payload, requestContext, apiContext, apiVersion);
                 APIKeyValidationInfoDTO validationInfoDto;
-                if (ConfigHolder.getInstance().isControlPlaneEnabled()) {
+                // Subscriptions are not validated for the APIs whose subscription validation is disabled in APIM
+                boolean subscriptionValidationDisabled = requestContext.getMatchedAPI()
+                        .isSubscriptionValidationDisabled();
+                if (ConfigHolder.getInstance().isControlPlaneEnabled() && !subscriptionValidationDisabled) {
                     log.debug("Validating subscription for API Key against subscription store."
                             + " context: {} version: {}", apiContext, apiVersion);
                     validationInfoDto = KeyValidator.validateSubscription(apiUuid, apiContext, payload);
-                } else if (apiKeySubValidationEnabled) {
+                } else if (apiKeySubValidationEnabled && !subscriptionValidationDisabled) {
                     log.debug("Validating subscription for API Key using JWT claims against invoked API info."
                             + " context: {} version: {}", apiContext, apiVersion);
                     validationInfoDto = getAPIKeyValidationDTO(requestContext, payload);
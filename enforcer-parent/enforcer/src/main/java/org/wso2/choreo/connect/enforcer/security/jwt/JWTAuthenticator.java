@@ -44,10 +44,12 @@ import org.wso2.choreo.connect.enforcer.config.dto.ExtendedTokenIssuerDto;
 import org.wso2.choreo.connect.enforcer.constants.APIConstants;
 import org.wso2.choreo.connect.enforcer.constants.APISecurityConstants;
 import org.wso2.choreo.connect.enforcer.constants.GeneralErrorCodeConstants;
+import org.wso2.choreo.connect.enforcer.constants.MetadataConstants;
 import org.wso2.choreo.connect.enforcer.dto.APIKeyValidationInfoDTO;
 import org.wso2.choreo.connect.enforcer.security.Authenticator;
 import org.wso2.choreo.connect.enforcer.security.KeyValidator;
 import org.wso2.choreo.connect.enforcer.security.TokenValidationContext;
+import org.wso2.choreo.connect.enforcer.security.VisibleRolesValidator;
 import org.wso2.choreo.connect.enforcer.security.jwt.validator.JWTConstants;
 import org.wso2.choreo.connect.enforcer.security.jwt.validator.JWTValidator;
 import org.wso2.choreo.connect.enforcer.security.jwt.validator.RevokedJWTDataHolder;
@@ -188,7 +190,8 @@ public class JWTAuthenticator implements Authenticator {
                 }
 
             }
-            JWTValidationInfo validationInfo = getJwtValidationInfo(signedJWTInfo, jwtTokenIdentifier);
+            JWTValidationInfo validationInfo = getJwtValidationInfo(requestContext, signedJWTInfo,
+                    jwtTokenIdentifier);
             if (validationInfo != null) {
                 if (validationInfo.isValid()) {
                     // Validate subscriptions
@@ -197,7 +200,8 @@ public class JWTAuthenticator implements Authenticator {
                     ExtendedTokenIssuerDto issuerDto = configuration.getIssuersMap().get(validationInfo.getIssuer());
                     Scope validateSubscriptionSpanScope = null;
                     try {
-                        if (issuerDto.isValidateSubscriptions()) {
+                        if (issuerDto.isValidateSubscriptions()
+                                && !requestContext.getMatchedAPI().isSubscriptionValidationDisabled()) {
                             if (Utils.tracingEnabled()) {
                                 validateSubscriptionSpan = Utils
                                         .startSpan(TracingConstants.SUBSCRIPTION_VALIDATION_SPAN, tracer);
@@ -292,6 +296,10 @@ public class JWTAuthenticator implements Authenticator {
                             Utils.finishSpan(validateScopesSpan);
                         }
                     }
+                    // Validate the roles of the caller for the APIs with RESTRICTED visibility
+                    VisibleRolesValidator.validateRoles(requestContext,
+                            claims.getClaim(APIConstants.JwtTokenConstants.ROLES));
+                    publishRateLimitKey(requestContext, claims);
                     log.debug("JWT authentication successful.");
 
                     // Generate or get backend JWT
@@ -334,6 +342,25 @@ public class JWTAuthenticator implements Authenticator {
 
     }
 
+    /**
+     * Publishes the value of the claim used as the rate limit key of the API in the metadata, from which the rate
+     * limit actions of the route read the key. Nothing is published if the API is not rate limited by a claim, or the
+     * token does not have the claim.
+     *
+     * @param requestContext request context
+     * @param claims         claims of the token
+     */
+    static void publishRateLimitKey(RequestContext requestContext, JWTClaimsSet claims) {
+        Object rateLimitClaim = requestContext.getProperties().get(APIConstants.RATE_LIMIT_CLAIM);
+        if (rateLimitClaim == null) {
+            return;
+        }
+        Object rateLimitKey = claims.getClaim(rateLimitClaim.toString());
+        if (rateLimitKey != null) {
+            requestContext.addMetadataToMap(MetadataConstants.RATE_LIMIT_KEY, rateLimitKey.toString());
+        }
+    }
+
     @Override
     public String getChallengeString() {
         return "Bearer realm=\"Choreo Connect\"";
@@ -521,9 +548,24 @@ public class JWTAuthenticator implements Authenticator {
         return api;
     }
 
-    private JWTValidationInfo getJwtValidationInfo(SignedJWTInfo signedJWTInfo, String jti)
-            throws APISecurityException {
+    private JWTValidationInfo getJwtValidationInfo(RequestContext requestContext, SignedJWTInfo signedJWTInfo,
+                                                   String jti) throws APISecurityException {
 
+        Object apiJwksUrl = requestContext.getProperties().get(APIConstants.API_JWKS_URL);
+        if (apiJwksUrl != null) {
+            // The validity of the token depends on the keys of the API, hence the token caches shared by all the
+            // APIs are not used.
+            try {
+                Object refreshInterval = requestContext.getProperties().get(APIConstants.API_JWKS_REFRESH_INTERVAL);
+                return jwtValidator.validateJWTToken(signedJWTInfo, apiJwksUrl.toString(),
+                        refreshInterval instanceof Long ? (Long) refreshInterval : 0);
+            } catch (EnforcerException e) {
+                log.error("JWT Validation with the JWKS endpoint of the API failed", e);
+                throw new APISecurityException(APIConstants.StatusCodes.UNAUTHENTICATED.getCode(),
+                        APISecurityConstants.API_AUTH_GENERAL_ERROR,
+                        APISecurityConstants.API_AUTH_GENERAL_ERROR_MESSAGE);
+            }
+        }
         String jwtHeader = signedJWTInfo.getSignedJWT().getHeader().toString();
         JWTValidationInfo jwtValidationInfo = null;
         if (isGatewayTokenCacheEnabled &&
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.choreo.connect.enforcer.security.jwt.validator;
+
+import com.nimbusds.jose.jwk.JWK;
+import com.nimbusds.jose.jwk.JWKSet;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.choreo.connect.enforcer.util.JWTUtils;
+
+import java.io.IOException;
+import java.text.ParseException;
+import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+import java.util.function.LongSupplier;
+
+/**
+ * Caches the keys of the JWKS endpoints configured for the APIs (jwksConfig of the api.yaml), which are used to
+ * validate the JWTs of those APIs instead of the keys of the token issuers. The keys of an endpoint are fetched
+ * again once the refresh interval of the API has passed, or when a JWT is signed by a key which is not cached. The
+ * latter is done at most once per {@link #MIN_FETCH_INTERVAL_IN_MILLIS}, so that the JWTs with unknown key IDs do not
+ * make the enforcer call the JWKS endpoint for every request.
+ */
+public class APIJwksCache {
+    // refresh interval used when the API does not give the refresh interval of its JWKS endpoint
+    public static final long DEFAULT_REFRESH_INTERVAL_IN_SECONDS = 3600;
+    static final long MIN_FETCH_INTERVAL_IN_MILLIS = 60 * 1000;
+
+    private static final Logger logger = LogManager.getLogger(APIJwksCache.class);
+    private static final APIJwksCache INSTANCE = new APIJwksCache(JWTUtils::retrieveJWKSConfiguration,
+            System::currentTimeMillis);
+
+    private final Map<String, CachedJwks> jwksByUrl = new ConcurrentHashMap<>();
+    private final JwksFetcher fetcher;
+    private final LongSupplier clock;
+
+    APIJwksCache(JwksFetcher fetcher, LongSupplier clock) {
+        this.fetcher = fetcher;
+        this.clock = clock;
+    }
+
+    public static APIJwksCache getInstance() {
+        return INSTANCE;
+    }
+
+    /**
+     * Returns the key of the given key ID from the JWKS endpoint of an API.
+     *
+     * @param jwksUrl                  JWKS endpoint of the API
+     * @param refreshIntervalInSeconds interval at which the keys are refreshed, or a non positive value to use the
+     *                                 default interval
+     * @param keyID                    key ID of the JWT
+     * @return key of the key ID, or null if the JWKS endpoint does not have the key
+     * @throws IOException    if the keys cannot be fetched from the JWKS endpoint
+     * @throws ParseException if the response of the JWKS endpoint is not a JWK set
+     */
+    public JWK getKey(String jwksUrl, long refreshIntervalInSeconds, String keyID) throws IOException,
+            ParseException {
+        long refreshIntervalInMillis = (refreshIntervalInSeconds > 0 ? refreshIntervalInSeconds :
+                DEFAULT_REFRESH_INTERVAL_IN_SECONDS) * 1000;
+        CachedJwks cachedJwks = jwksByUrl.get(jwksUrl);
+        long now = clock.getAsLong();
+        if (cachedJwks == null || now - cachedJwks.fetchedAt >= refreshIntervalInMillis ||
+                (cachedJwks.jwkSet.getKeyByKeyId(keyID) == null &&
+                        now - cachedJwks.fetchedAt >= MIN_FETCH_INTERVAL_IN_MILLIS)) {
+            cachedJwks = fetch(jwksUrl, cachedJwks);
+        }
+        return cachedJwks.jwkSet.getKeyByKeyId(keyID);
+    }
+
+    private synchronized CachedJwks fetch(String jwksUrl, CachedJwks staleJwks) throws IOException,
+            ParseException {
+        CachedJwks cachedJwks = jwksByUrl.get(jwksUrl);
+        if (cachedJwks != null && cachedJwks != staleJwks) {
+            // fetched by another request in the meantime
+            return cachedJwks;
+        }
+        logger.debug("Fetching the keys of the API JWKS endpoint {}", jwksUrl);
+        String jwks = fetcher.fetch(jwksUrl);
+        if (jwks == null) {
+            throw new IOException("Failed to fetch the keys of the JWKS endpoint " + jwksUrl);
+        }
+        cachedJwks = new CachedJwks(JWKSet.parse(jwks), clock.getAsLong());
+        jwksByUrl.put(jwksUrl, cachedJwks);
+        return cachedJwks;
+    }
+
+    /**
+     * Fetches the JWK set of a JWKS endpoint.
+     */
+    @FunctionalInterface
+    interface JwksFetcher {
+        String fetch(String jwksUrl) throws IOException;
+    }
+
+    private static class CachedJwks {
+        private final JWKSet jwkSet;
+        private final long fetchedAt;
+
+        CachedJwks(JWKSet jwkSet, long fetchedAt) {
+            this.jwkSet = jwkSet;
+            this.fetchedAt = fetchedAt;
+        }
+    }
+}
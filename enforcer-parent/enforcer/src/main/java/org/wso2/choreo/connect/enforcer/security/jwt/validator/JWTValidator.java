@@ -19,6 +19,7 @@
 package org.wso2.choreo.connect.enforcer.security.jwt.validator;
 
 import com.nimbusds.jose.JOSEException;
+import com.nimbusds.jose.jwk.JWK;
 import com.nimbusds.jose.jwk.JWKSet;
 import com.nimbusds.jose.jwk.RSAKey;
 import com.nimbusds.jwt.JWTClaimsSet;
@@ -58,6 +59,23 @@ public class JWTValidator {
 
 
     public JWTValidationInfo validateJWTToken(SignedJWTInfo signedJWTInfo) throws EnforcerException {
+        return validateJWTToken(signedJWTInfo, null, 0);
+    }
+
+    /**
+     * Validates the JWT of an API. If the API has a JWKS endpoint, the signature of the JWT is validated with the keys
+     * of the endpoint of the API instead of the keys of the token issuer. The issuer of the JWT should be configured
+     * in the enforcer in both cases.
+     *
+     * @param signedJWTInfo                signed JWT
+     * @param apiJwksUrl                   JWKS endpoint of the API, or null if the API does not have one
+     * @param apiJwksRefreshIntervalInSecs refresh interval of the keys of the JWKS endpoint of the API, or a non
+     *                                     positive value to use the default interval
+     * @return validation info of the JWT
+     * @throws EnforcerException if the JWT cannot be validated
+     */
+    public JWTValidationInfo validateJWTToken(SignedJWTInfo signedJWTInfo, String apiJwksUrl,
+                                              long apiJwksRefreshIntervalInSecs) throws EnforcerException {
         JWTValidationInfo jwtValidationInfo = new JWTValidationInfo();
         String issuer = signedJWTInfo.getJwtClaimsSet().getIssuer();
         Map<String, ExtendedTokenIssuerDto> tokenIssuers = ConfigHolder.getInstance().getConfig().getIssuersMap();
@@ -66,7 +84,8 @@ public class JWTValidator {
             ExtendedTokenIssuerDto tokenIssuer = tokenIssuers.get(issuer);
             JWTTransformer jwtTransformer = ConfigHolder.getInstance().getConfig().getJwtTransformer(issuer);
             jwtTransformer.loadConfiguration(tokenIssuer);
-            return validateToken(signedJWTInfo, tokenIssuer, jwtTransformer);
+            return validateToken(signedJWTInfo, tokenIssuer, jwtTransformer, apiJwksUrl,
+                    apiJwksRefreshIntervalInSecs);
         }
         jwtValidationInfo.setValid(false);
         jwtValidationInfo.setValidationCode(APIConstants.KeyValidationStatus.API_AUTH_INVALID_CREDENTIALS);
@@ -75,11 +94,17 @@ public class JWTValidator {
     }
 
     private JWTValidationInfo validateToken(SignedJWTInfo signedJWTInfo, ExtendedTokenIssuerDto tokenIssuer,
-                                            JWTTransformer jwtTransformer) throws EnforcerException {
+                                            JWTTransformer jwtTransformer, String apiJwksUrl,
+                                            long apiJwksRefreshIntervalInSecs) throws EnforcerException {
         JWTValidationInfo jwtValidationInfo = new JWTValidationInfo();
         boolean state;
         try {
-            state = validateSignature(signedJWTInfo.getSignedJWT(), tokenIssuer);
+            if (StringUtils.isNotEmpty(apiJwksUrl)) {
+                state = validateSignatureWithAPIJwks(signedJWTInfo.getSignedJWT(), apiJwksUrl,
+                        apiJwksRefreshIntervalInSecs);
+            } else {
+                state = validateSignature(signedJWTInfo.getSignedJWT(), tokenIssuer);
+            }
             if (state) {
                 JWTClaimsSet jwtClaimsSet = signedJWTInfo.getJwtClaimsSet();
                 state = validateTokenExpiry(jwtClaimsSet);
@@ -145,6 +170,37 @@ public class JWTValidator {
         }
     }
 
+    /**
+     * Validates the signature of a JWT with the keys of the JWKS endpoint of an API.
+     *
+     * @param signedJWT                    signed JWT
+     * @param apiJwksUrl                   JWKS endpoint of the API
+     * @param apiJwksRefreshIntervalInSecs refresh interval of the keys of the JWKS endpoint
+     * @return true if the JWT is signed by a key of the JWKS endpoint
+     * @throws EnforcerException if the keys cannot be fetched or the key is not an RSA key
+     */
+    protected boolean validateSignatureWithAPIJwks(SignedJWT signedJWT, String apiJwksUrl,
+                                                   long apiJwksRefreshIntervalInSecs) throws EnforcerException {
+        String keyID = signedJWT.getHeader().getKeyID();
+        if (StringUtils.isEmpty(keyID)) {
+            logger.debug("JWT without a key ID cannot be validated with the JWKS endpoint of the API");
+            return false;
+        }
+        try {
+            JWK key = APIJwksCache.getInstance().getKey(apiJwksUrl, apiJwksRefreshIntervalInSecs, keyID);
+            if (key == null) {
+                logger.debug("JWKS endpoint of the API does not have the key {}", keyID);
+                return false;
+            }
+            if (!(key instanceof RSAKey)) {
+                throw new EnforcerException("Key Algorithm not supported");
+            }
+            return JWTUtils.verifyTokenSignature(signedJWT, ((RSAKey) key).toRSAPublicKey());
+        } catch (ParseException | JOSEException | IOException e) {
+            throw new EnforcerException("JWT Signature verification failed", e);
+        }
+    }
+
     protected boolean validateTokenExpiry(JWTClaimsSet jwtClaimsSet) {
 
         long timestampSkew = 5; //TODO : Read from config.
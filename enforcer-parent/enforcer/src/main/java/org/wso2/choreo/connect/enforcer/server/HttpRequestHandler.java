@@ -19,6 +19,7 @@ package org.wso2.choreo.connect.enforcer.server;
 
 import com.google.protobuf.ByteString;
 import io.envoyproxy.envoy.service.auth.v3.CheckRequest;
+import org.apache.commons.lang3.StringUtils;
 import org.apache.logging.log4j.LogManager;
 import org.apache.logging.log4j.Logger;
 import org.apache.logging.log4j.ThreadContext;
@@ -39,6 +40,8 @@ import org.wso2.choreo.connect.enforcer.graphql.GraphQLPayloadUtils;
 import org.wso2.choreo.connect.enforcer.util.FilterUtils;
 
 import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.List;
 import java.util.Map;
 
 /**
@@ -141,10 +144,162 @@ public class HttpRequestHandler implements RequestHandler<CheckRequest, Response
                 resourceConfigs.add(resourceConfig);
             }
         }
-        return new RequestContext.Builder(requestPath).matchedResourceConfigs(resourceConfigs).requestMethod(method)
-                .certificate(certificate).matchedAPI(api.getAPIConfig()).headers(headers).requestID(requestID)
-                .address(address).prodClusterHeader(prodCluster).sandClusterHeader(sandCluster)
+        RequestContext requestContext = new RequestContext.Builder(requestPath).matchedResourceConfigs(resourceConfigs)
+                .requestMethod(method).certificate(certificate).matchedAPI(api.getAPIConfig()).headers(headers)
+                .requestID(requestID).address(address).prodClusterHeader(prodCluster).sandClusterHeader(sandCluster)
                 .requestTimeStamp(requestTimeInMillis).pathTemplate(pathTemplate).requestPayload(requestPayload)
                 .build();
+        String mockResponsePreference = getMockResponsePreference(request.getAttributes().getContextExtensionsMap(),
+                headers);
+        if (mockResponsePreference != null) {
+            requestContext.getProperties().put(APIConstants.MOCK_RESPONSE_PREFERENCE, mockResponsePreference);
+        }
+        Map<String, String> analyticsProperties =
+                getAnalyticsProperties(request.getAttributes().getContextExtensionsMap());
+        if (!analyticsProperties.isEmpty()) {
+            requestContext.getProperties().put(APIConstants.ANALYTICS_PROPERTIES, analyticsProperties);
+        }
+        List<String> visibleRoles = getVisibleRoles(request.getAttributes().getContextExtensionsMap());
+        if (!visibleRoles.isEmpty()) {
+            requestContext.getProperties().put(APIConstants.VISIBLE_ROLES, visibleRoles);
+        }
+        Integer operationTimeout = getOperationTimeout(request.getAttributes().getContextExtensionsMap());
+        if (operationTimeout != null) {
+            requestContext.getProperties().put(APIConstants.OPERATION_TIMEOUT, operationTimeout);
+        }
+        Integer maxRequestHeadersKb = getMaxRequestHeadersKb(request.getAttributes().getContextExtensionsMap());
+        if (maxRequestHeadersKb != null) {
+            requestContext.getProperties().put(APIConstants.MAX_REQUEST_HEADERS_KB, maxRequestHeadersKb);
+        }
+        String enableOutboundAuthHeader = request.getAttributes().getContextExtensionsMap()
+                .get(AdapterConstants.ENABLE_OUTBOUND_AUTH_HEADER_KEY);
+        if (StringUtils.isNotBlank(enableOutboundAuthHeader)) {
+            requestContext.getProperties().put(APIConstants.ENABLE_OUTBOUND_AUTH_HEADER,
+                    Boolean.parseBoolean(enableOutboundAuthHeader.trim()));
+        }
+        String rateLimitClaim = request.getAttributes().getContextExtensionsMap()
+                .get(AdapterConstants.RATE_LIMIT_CLAIM_KEY);
+        if (StringUtils.isNotBlank(rateLimitClaim)) {
+            requestContext.getProperties().put(APIConstants.RATE_LIMIT_CLAIM, rateLimitClaim);
+        }
+        String jwksUrl = request.getAttributes().getContextExtensionsMap().get(AdapterConstants.JWKS_URL_KEY);
+        if (StringUtils.isNotBlank(jwksUrl)) {
+            requestContext.getProperties().put(APIConstants.API_JWKS_URL, jwksUrl);
+            requestContext.getProperties().put(APIConstants.API_JWKS_REFRESH_INTERVAL,
+                    getJwksRefreshInterval(request.getAttributes().getContextExtensionsMap()));
+        }
+        return requestContext;
+    }
+
+    /**
+     * Returns the timeout of the operation in milliseconds, or null if the operation does not have a timeout.
+     *
+     * @param contextExtensions context extensions of the route
+     * @return timeout of the operation
+     */
+    static Integer getOperationTimeout(Map<String, String> contextExtensions) {
+        String timeout = contextExtensions.get(AdapterConstants.OPERATION_TIMEOUT_KEY);
+        if (StringUtils.isBlank(timeout)) {
+            return null;
+        }
+        try {
+            return Integer.parseInt(timeout.trim());
+        } catch (NumberFormatException e) {
+            logger.debug("Invalid timeout {} of the operation, hence the timeout of the endpoint is used", timeout);
+            return null;
+        }
+    }
+
+    /**
+     * Returns the maximum size of the request headers of the API in KiB, or null if the API does not have a limit
+     * lower than the limit of the listeners.
+     *
+     * @param contextExtensions context extensions of the route
+     * @return maximum size of the request headers in KiB
+     */
+    static Integer getMaxRequestHeadersKb(Map<String, String> contextExtensions) {
+        String maxRequestHeadersKb = contextExtensions.get(AdapterConstants.MAX_REQUEST_HEADERS_KB_KEY);
+        if (StringUtils.isBlank(maxRequestHeadersKb)) {
+            return null;
+        }
+        try {
+            return Integer.parseInt(maxRequestHeadersKb.trim());
+        } catch (NumberFormatException e) {
+            logger.debug("Invalid request headers limit {} of the API, hence it is not applied", maxRequestHeadersKb);
+            return null;
+        }
+    }
+
+    /**
+     * Returns the refresh interval of the keys of the JWKS endpoint of the API, or 0 if the API does not give it.
+     *
+     * @param contextExtensions context extensions of the route
+     * @return refresh interval in seconds
+     */
+    static long getJwksRefreshInterval(Map<String, String> contextExtensions) {
+        String refreshInterval = contextExtensions.get(AdapterConstants.JWKS_REFRESH_INTERVAL_KEY);
+        if (StringUtils.isBlank(refreshInterval)) {
+            return 0;
+        }
+        try {
+            return Long.parseLong(refreshInterval.trim());
+        } catch (NumberFormatException e) {
+            logger.debug("Invalid JWKS refresh interval {} of the API, hence the default is used", refreshInterval);
+            return 0;
+        }
+    }
+
+    /**
+     * Returns the roles allowed to invoke the API. The roles are given only for the APIs with RESTRICTED visibility,
+     * hence an empty list is returned for the PUBLIC and PRIVATE APIs, which are not checked for roles.
+     *
+     * @param contextExtensions context extensions of the route
+     * @return roles allowed to invoke the API
+     */
+    static List<String> getVisibleRoles(Map<String, String> contextExtensions) {
+        List<String> visibleRoles = new ArrayList<>();
+        String roles = contextExtensions.get(AdapterConstants.VISIBLE_ROLES_KEY);
+        if (StringUtils.isBlank(roles)) {
+            return visibleRoles;
+        }
+        for (String role : roles.split(",")) {
+            if (StringUtils.isNotBlank(role)) {
+                visibleRoles.add(role.trim());
+            }
+        }
+        return visibleRoles;
+    }
+
+    /**
+     * Returns the analytics properties of the operation, which are published with the analytics events.
+     *
+     * @param contextExtensions context extensions of the route
+     * @return analytics properties of the operation
+     */
+    static Map<String, String> getAnalyticsProperties(Map<String, String> contextExtensions) {
+        Map<String, String> analyticsProperties = new HashMap<>();
+        for (Map.Entry<String, String> contextExtension : contextExtensions.entrySet()) {
+            if (contextExtension.getKey().startsWith(AdapterConstants.ANALYTICS_PROPERTY_KEY_PREFIX)) {
+                String key = contextExtension.getKey()
+                        .substring(AdapterConstants.ANALYTICS_PROPERTY_KEY_PREFIX.length());
+                analyticsProperties.put(key, contextExtension.getValue());
+            }
+        }
+        return analyticsProperties;
+    }
+
+    /**
+     * Returns the preferred mocked response selected by the value of the mock selector header of the operation.
+     *
+     * @param contextExtensions context extensions of the route
+     * @param headers           request headers
+     * @return preference in the format of the prefer header, or null if the base response should be returned
+     */
+    static String getMockResponsePreference(Map<String, String> contextExtensions, Map<String, String> headers) {
+        String selectorHeader = contextExtensions.get(AdapterConstants.MOCK_SELECTOR_HEADER_KEY);
+        if (selectorHeader == null || !headers.containsKey(selectorHeader)) {
+            return null;
+        }
+        return contextExtensions.get(AdapterConstants.MOCK_RESPONSE_KEY_PREFIX + headers.get(selectorHeader));
     }
 }
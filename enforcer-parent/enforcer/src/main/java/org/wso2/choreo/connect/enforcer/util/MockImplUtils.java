@@ -57,8 +57,12 @@ public class MockImplUtils {
         if (headersMap.containsKey(APIConstants.ACCEPT_HEADER)) {
             acceptType = headersMap.get(APIConstants.ACCEPT_HEADER).split(",");
         }
-        // check prefer header for selected example
-        if (headersMap.containsKey(APIConstants.PREFER_HEADER)) {
+        // the response selected by the mock selector header of the operation overrides the prefer header
+        Object selectedPreference = requestContext.getProperties().get(APIConstants.MOCK_RESPONSE_PREFERENCE);
+        if (selectedPreference != null) {
+            preferences = processPreferHeader(selectedPreference.toString());
+        } else if (headersMap.containsKey(APIConstants.PREFER_HEADER)) {
+            // check prefer header for selected example
             preferences = processPreferHeader(headersMap.get(APIConstants.PREFER_HEADER));
         }
         setMockApiResponse(responseObject, preferences, mockedApiConfig, acceptType);
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.choreo.connect.enforcer.headers;
+
+import org.apache.commons.lang3.StringUtils;
+import org.junit.Assert;
+import org.junit.Test;
+import org.wso2.choreo.connect.enforcer.commons.model.APIConfig;
+import org.wso2.choreo.connect.enforcer.commons.model.RequestContext;
+import org.wso2.choreo.connect.enforcer.constants.APIConstants;
+
+import java.util.HashMap;
+import java.util.Map;
+
+public class HeaderSizeLimitFilterTest {
+
+    @Test
+    public void testHeadersExceedingAPILimitAreRejected() {
+        Map<String, String> headers = new HashMap<>();
+        headers.put(":path", "/petstore/1.0.0/pets");
+        headers.put("x-large", StringUtils.repeat("a", 2 * 1024));
+        RequestContext requestContext = createRequestContext(headers);
+        requestContext.getProperties().put(APIConstants.MAX_REQUEST_HEADERS_KB, 1);
+
+        Assert.assertFalse(new HeaderSizeLimitFilter().handleRequest(requestContext));
+        Assert.assertEquals(431, requestContext.getProperties().get(APIConstants.MessageFormat.STATUS_CODE));
+    }
+
+    @Test
+    public void testHeadersWithinAPILimitAreAccepted() {
+        Map<String, String> headers = new HashMap<>();
+        headers.put(":path", "/petstore/1.0.0/pets");
+        headers.put("x-large", StringUtils.repeat("a", 512));
+        RequestContext requestContext = createRequestContext(headers);
+        requestContext.getProperties().put(APIConstants.MAX_REQUEST_HEADERS_KB, 1);
+        Assert.assertTrue(new HeaderSizeLimitFilter().handleRequest(requestContext));
+
+        // The APIs without a limit are limited only by the listeners.
+        headers.put("x-large", StringUtils.repeat("a", 2 * 1024));
+        Assert.assertTrue(new HeaderSizeLimitFilter().handleRequest(createRequestContext(headers)));
+    }
+
+    @Test
+    public void testGetHeadersSize() {
+        Map<String, String> headers = new HashMap<>();
+        headers.put(":method", "GET");
+        headers.put("x-name", "välue");
+        Assert.assertEquals(7 + 3 + 6 + 6, HeaderSizeLimitFilter.getHeadersSize(headers));
+    }
+
+    private RequestContext createRequestContext(Map<String, String> headers) {
+        APIConfig apiConfig = new APIConfig.Builder("PetStore").version("1.0.0").basePath("/petstore").build();
+        return new RequestContext.Builder("/petstore/1.0.0/pets").matchedAPI(apiConfig).headers(headers).build();
+    }
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.choreo.connect.enforcer.security;
+
+import org.junit.Assert;
+import org.junit.Test;
+import org.wso2.choreo.connect.enforcer.commons.model.APIConfig;
+import org.wso2.choreo.connect.enforcer.commons.model.EndpointCluster;
+import org.wso2.choreo.connect.enforcer.commons.model.RequestContext;
+import org.wso2.choreo.connect.enforcer.constants.APIConstants;
+
+public class AuthFilterTest {
+
+    @Test
+    public void testOperationTimeoutOverridesEndpointTimeout() {
+        EndpointCluster endpointCluster = new EndpointCluster();
+        endpointCluster.setRouteTimeoutInMillis(60000);
+
+        RequestContext requestContext = createRequestContext();
+        requestContext.getProperties().put(APIConstants.OPERATION_TIMEOUT, 5000);
+        Assert.assertEquals(Integer.valueOf(5000), AuthFilter.getUpstreamTimeout(requestContext, endpointCluster));
+    }
+
+    @Test
+    public void testEndpointTimeoutWithoutOperationTimeout() {
+        EndpointCluster endpointCluster = new EndpointCluster();
+        endpointCluster.setRouteTimeoutInMillis(60000);
+        Assert.assertEquals(Integer.valueOf(60000),
+                AuthFilter.getUpstreamTimeout(createRequestContext(), endpointCluster));
+
+        Assert.assertNull(AuthFilter.getUpstreamTimeout(createRequestContext(), new EndpointCluster()));
+    }
+
+    private RequestContext createRequestContext() {
+        APIConfig apiConfig = new APIConfig.Builder("PetStore").version("1.0.0").basePath("/petstore").build();
+        return new RequestContext.Builder("/petstore/1.0.0/pets").matchedAPI(apiConfig).build();
+    }
+}
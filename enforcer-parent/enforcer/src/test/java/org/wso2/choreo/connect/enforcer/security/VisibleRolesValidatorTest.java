@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.choreo.connect.enforcer.security;
+
+import org.junit.Assert;
+import org.junit.Test;
+import org.wso2.choreo.connect.enforcer.commons.exception.APISecurityException;
+import org.wso2.choreo.connect.enforcer.commons.model.APIConfig;
+import org.wso2.choreo.connect.enforcer.commons.model.RequestContext;
+import org.wso2.choreo.connect.enforcer.constants.APIConstants;
+import org.wso2.choreo.connect.enforcer.constants.APISecurityConstants;
+
+import java.util.Arrays;
+import java.util.List;
+
+public class VisibleRolesValidatorTest {
+
+    @Test
+    public void testCallerWithoutVisibleRoleIsRejected() {
+        RequestContext requestContext = createRequestContext(Arrays.asList("admin", "manager"));
+        try {
+            VisibleRolesValidator.validateRoles(requestContext, Arrays.asList("internal/everyone", "viewer"));
+            Assert.fail("Caller without any of the visible roles should be rejected");
+        } catch (APISecurityException e) {
+            Assert.assertEquals(APIConstants.StatusCodes.UNAUTHORIZED.getCode(), e.getStatusCode());
+            Assert.assertEquals(APISecurityConstants.API_AUTH_FORBIDDEN, e.getErrorCode());
+        }
+    }
+
+    @Test
+    public void testCallerWithoutRolesClaimIsRejected() {
+        RequestContext requestContext = createRequestContext(Arrays.asList("admin"));
+        try {
+            VisibleRolesValidator.validateRoles(requestContext, null);
+            Assert.fail("Caller without the roles claim should be rejected");
+        } catch (APISecurityException e) {
+            Assert.assertEquals(APIConstants.StatusCodes.UNAUTHORIZED.getCode(), e.getStatusCode());
+        }
+    }
+
+    @Test
+    public void testCallerWithVisibleRoleIsAccepted() throws APISecurityException {
+        RequestContext requestContext = createRequestContext(Arrays.asList("admin", "manager"));
+        VisibleRolesValidator.validateRoles(requestContext, Arrays.asList("viewer", "manager"));
+        VisibleRolesValidator.validateRoles(requestContext, "viewer, admin");
+    }
+
+    @Test
+    public void testAPIWithoutVisibleRolesDoesNotRequireRoles() throws APISecurityException {
+        // PUBLIC and PRIVATE APIs do not have visible roles, hence even the callers without a token are accepted.
+        RequestContext requestContext = createRequestContext(null);
+        VisibleRolesValidator.validateRoles(requestContext, null);
+        Assert.assertTrue(VisibleRolesValidator.getVisibleRoles(requestContext).isEmpty());
+    }
+
+    @Test
+    public void testGetRoles() {
+        Assert.assertEquals(Arrays.asList("admin", "viewer"),
+                VisibleRolesValidator.getRoles(Arrays.asList("admin", " ", "viewer")));
+        Assert.assertEquals(Arrays.asList("admin", "viewer"), VisibleRolesValidator.getRoles("admin, ,viewer"));
+        Assert.assertTrue(VisibleRolesValidator.getRoles(10).isEmpty());
+    }
+
+    private RequestContext createRequestContext(List<String> visibleRoles) {
+        APIConfig apiConfig = new APIConfig.Builder("PetStore").version("1.0.0").basePath("/petstore").build();
+        RequestContext requestContext = new RequestContext.Builder("/petstore/1.0.0/pets").matchedAPI(apiConfig)
+                .build();
+        if (visibleRoles != null) {
+            requestContext.getProperties().put(APIConstants.VISIBLE_ROLES, visibleRoles);
+        }
+        return requestContext;
+    }
+}
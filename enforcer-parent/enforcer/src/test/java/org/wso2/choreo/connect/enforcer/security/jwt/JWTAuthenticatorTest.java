@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.choreo.connect.enforcer.security.jwt;
+
+import com.nimbusds.jwt.JWTClaimsSet;
+import org.junit.Assert;
+import org.junit.Test;
+import org.wso2.choreo.connect.enforcer.commons.model.APIConfig;
+import org.wso2.choreo.connect.enforcer.commons.model.RequestContext;
+import org.wso2.choreo.connect.enforcer.constants.APIConstants;
+import org.wso2.choreo.connect.enforcer.constants.MetadataConstants;
+
+public class JWTAuthenticatorTest {
+
+    @Test
+    public void testRateLimitClaimIsPublishedInMetadata() {
+        JWTClaimsSet claims = new JWTClaimsSet.Builder().claim("org_id", "acme").build();
+        RequestContext requestContext = createRequestContext();
+        requestContext.getProperties().put(APIConstants.RATE_LIMIT_CLAIM, "org_id");
+
+        JWTAuthenticator.publishRateLimitKey(requestContext, claims);
+        Assert.assertEquals("acme", requestContext.getMetadataMap().get(MetadataConstants.RATE_LIMIT_KEY));
+    }
+
+    @Test
+    public void testRateLimitClaimIsNotPublishedWithoutClaim() {
+        JWTClaimsSet claims = new JWTClaimsSet.Builder().claim("org_id", "acme").build();
+        RequestContext requestContext = createRequestContext();
+        JWTAuthenticator.publishRateLimitKey(requestContext, claims);
+        Assert.assertFalse(requestContext.getMetadataMap().containsKey(MetadataConstants.RATE_LIMIT_KEY));
+
+        // The tokens without the claim are not given a rate limit key.
+        requestContext.getProperties().put(APIConstants.RATE_LIMIT_CLAIM, "tenant");
+        JWTAuthenticator.publishRateLimitKey(requestContext, claims);
+        Assert.assertFalse(requestContext.getMetadataMap().containsKey(MetadataConstants.RATE_LIMIT_KEY));
+    }
+
+    private RequestContext createRequestContext() {
+        APIConfig apiConfig = new APIConfig.Builder("PetStore").version("1.0.0").basePath("/petstore").build();
+        return new RequestContext.Builder("/petstore/1.0.0/pets").matchedAPI(apiConfig).build();
+    }
+}
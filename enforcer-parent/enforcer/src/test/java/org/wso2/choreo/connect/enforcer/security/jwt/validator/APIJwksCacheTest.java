@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.choreo.connect.enforcer.security.jwt.validator;
+
+import com.nimbusds.jose.JOSEException;
+import com.nimbusds.jose.jwk.JWKSet;
+import com.nimbusds.jose.jwk.RSAKey;
+import com.nimbusds.jose.jwk.gen.RSAKeyGenerator;
+import org.junit.Assert;
+import org.junit.Test;
+
+import java.io.IOException;
+import java.text.ParseException;
+import java.util.HashMap;
+import java.util.Map;
+import java.util.concurrent.atomic.AtomicInteger;
+import java.util.concurrent.atomic.AtomicLong;
+
+public class APIJwksCacheTest {
+    private static final String TENANT_A_JWKS = "https://idp.tenant-a.example.com/jwks";
+    private static final String TENANT_B_JWKS = "https://idp.tenant-b.example.com/jwks";
+
+    @Test
+    public void testKeysAreCachedPerAPIJwksUrl() throws JOSEException, IOException, ParseException {
+        RSAKey tenantAKey = new RSAKeyGenerator(2048).keyID("tenant-a").generate();
+        RSAKey tenantBKey = new RSAKeyGenerator(2048).keyID("tenant-b").generate();
+        Map<String, String> jwksByUrl = new HashMap<>();
+        jwksByUrl.put(TENANT_A_JWKS, new JWKSet(tenantAKey).toString());
+        jwksByUrl.put(TENANT_B_JWKS, new JWKSet(tenantBKey).toString());
+        AtomicInteger fetchCount = new AtomicInteger();
+        AtomicLong now = new AtomicLong(0);
+        APIJwksCache cache = new APIJwksCache(url -> {
+            fetchCount.incrementAndGet();
+            return jwksByUrl.get(url);
+        }, now::get);
+
+        Assert.assertEquals(tenantAKey.toPublicJWK().toJSONString(),
+                cache.getKey(TENANT_A_JWKS, 0, "tenant-a").toJSONString());
+        Assert.assertEquals(tenantBKey.toPublicJWK().toJSONString(),
+                cache.getKey(TENANT_B_JWKS, 0, "tenant-b").toJSONString());
+        Assert.assertEquals(2, fetchCount.get());
+
+        // The key of an API is not used to validate the JWTs of the other APIs.
+        Assert.assertNull(cache.getKey(TENANT_A_JWKS, 0, "tenant-b"));
+
+        // The cached keys are used until the refresh interval has passed.
+        now.set(30 * 1000);
+        Assert.assertNotNull(cache.getKey(TENANT_A_JWKS, 300, "tenant-a"));
+        Assert.assertEquals(2, fetchCount.get());
+        now.set(300 * 1000);
+        Assert.assertNotNull(cache.getKey(TENANT_A_JWKS, 300, "tenant-a"));
+        Assert.assertEquals(3, fetchCount.get());
+    }
+
+    @Test
+    public void testUnknownKeyIsFetchedAtMostOncePerMinFetchInterval() throws JOSEException, IOException,
+            ParseException {
+        RSAKey oldKey = new RSAKeyGenerator(2048).keyID("old").generate();
+        RSAKey rotatedKey = new RSAKeyGenerator(2048).keyID("rotated").generate();
+        Map<String, String> jwksByUrl = new HashMap<>();
+        jwksByUrl.put(TENANT_A_JWKS, new JWKSet(oldKey).toString());
+        AtomicInteger fetchCount = new AtomicInteger();
+        AtomicLong now = new AtomicLong(0);
+        APIJwksCache cache = new APIJwksCache(url -> {
+            fetchCount.incrementAndGet();
+            return jwksByUrl.get(url);
+        }, now::get);
+
+        Assert.assertNotNull(cache.getKey(TENANT_A_JWKS, 0, "old"));
+        jwksByUrl.put(TENANT_A_JWKS, new JWKSet(rotatedKey).toString());
+        Assert.assertNull(cache.getKey(TENANT_A_JWKS, 0, "rotated"));
+        Assert.assertEquals(1, fetchCount.get());
+
+        now.set(APIJwksCache.MIN_FETCH_INTERVAL_IN_MILLIS);
+        Assert.assertEquals(rotatedKey.toPublicJWK().toJSONString(),
+                cache.getKey(TENANT_A_JWKS, 0, "rotated").toJSONString());
+        Assert.assertEquals(2, fetchCount.get());
+    }
+
+    @Test(expected = IOException.class)
+    public void testFailedFetch() throws IOException, ParseException {
+        APIJwksCache cache = new APIJwksCache(url -> null, System::currentTimeMillis);
+        cache.getKey(TENANT_A_JWKS, 0, "tenant-a");
+    }
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2023, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.choreo.connect.enforcer.server;
+
+import org.junit.Assert;
+import org.junit.Test;
+import org.wso2.choreo.connect.enforcer.constants.AdapterConstants;
+
+import java.util.Arrays;
+import java.util.HashMap;
+import java.util.Map;
+
+public class HttpRequestHandlerTest {
+
+    @Test
+    public void testGetVisibleRoles() {
+        Map<String, String> contextExtensions = new HashMap<>();
+        Assert.assertTrue(HttpRequestHandler.getVisibleRoles(contextExtensions).isEmpty());
+
+        contextExtensions.put(AdapterConstants.VISIBLE_ROLES_KEY, "admin,internal/publisher");
+        Assert.assertEquals(Arrays.asList("admin", "internal/publisher"),
+                HttpRequestHandler.getVisibleRoles(contextExtensions));
+    }
+
+    @Test
+    public void testGetOperationTimeout() {
+        Map<String, String> contextExtensions = new HashMap<>();
+        Assert.assertNull(HttpRequestHandler.getOperationTimeout(contextExtensions));
+
+        contextExtensions.put(AdapterConstants.OPERATION_TIMEOUT_KEY, "5000");
+        Assert.assertEquals(Integer.valueOf(5000), HttpRequestHandler.getOperationTimeout(contextExtensions));
+
+        contextExtensions.put(AdapterConstants.OPERATION_TIMEOUT_KEY, "5s");
+        Assert.assertNull(HttpRequestHandler.getOperationTimeout(contextExtensions));
+    }
+
+    @Test
+    public void testGetMaxRequestHeadersKb() {
+        Map<String, String> contextExtensions = new HashMap<>();
+        Assert.assertNull(HttpRequestHandler.getMaxRequestHeadersKb(contextExtensions));
+
+        contextExtensions.put(AdapterConstants.MAX_REQUEST_HEADERS_KB_KEY, "16");
+        Assert.assertEquals(Integer.valueOf(16), HttpRequestHandler.getMaxRequestHeadersKb(contextExtensions));
+    }
+
+    @Test
+    public void testGetJwksRefreshInterval() {
+        Map<String, String> contextExtensions = new HashMap<>();
+        Assert.assertEquals(0, HttpRequestHandler.getJwksRefreshInterval(contextExtensions));
+
+        contextExtensions.put(AdapterConstants.JWKS_REFRESH_INTERVAL_KEY, "300");
+        Assert.assertEquals(300, HttpRequestHandler.getJwksRefreshInterval(contextExtensions));
+    }
+}